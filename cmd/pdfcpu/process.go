@@ -1424,6 +1424,15 @@ func parseForGrid(nup *model.NUp, argInd *int) {
 }
 
 func parseForNUp(nup *model.NUp, argInd *int, nUpValues []int) {
+	if strings.Contains(strings.ToLower(flag.Arg(*argInd)), "x") {
+		// Custom grid: colsxrows
+		if err := pdfcpu.ParseNUpCustomGrid(flag.Arg(*argInd), nup); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+		}
+		*argInd++
+		return
+	}
 	n, err := strconv.Atoi(flag.Arg(*argInd))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
@@ -440,6 +440,16 @@ content ... extract raw page content
    
    strokecolor:      color value to be used when rendering text, see also rendermode
    
+   gradient:         gradient or pattern to be used instead of fillcolor when rendering text:
+                     axial {color} {color} [angle]           ... linear gradient, eg. 'axial red blue 90'
+                     radial {color} {color}                  ... gradient from the center outwards
+                     stripes {color} {color} [angle [width]] ... alternating stripes
+                     colors are color names or hex RGB values
+
+   path:             circle {radius} ... lay out text like a seal in given display unit eg. 'circle 80'
+                     the 1st line runs along the upper half, the 2nd line along the lower half.
+                     A border is rendered as rings enclosing the text.
+
    backgroundcolor:  color value for visualization of the bounding box background for text.
                      "bgcolor" is also accepted. 
    
@@ -473,6 +483,11 @@ content ... extract raw page content
 
    url:              Add link annotation for stamps only (omit https://)
 
+   tile:             repeat the watermark across the whole page (on/off, true/false, t/f)
+                     or shift every other row by 0.0 <= x < 1.0 of the horizontal tile step
+
+   gap:              (dx dy) horizontal and vertical gap between tiles in given display unit eg. '20 40'
+
 A color value: 3 color intensities, where 0.0 < i < 1.0, eg 1.0, 
                or the hex RGB value: #RRGGBB, eg #FF0000 = red
 
@@ -502,7 +517,7 @@ e.g. "pos:bl, off: 20 5"   "rot:45"                 "op:0.5, scale:0.5 abs, rot:
      string ... display string for text based watermarks
        file ... image or PDF file
 description ... fontname, points, position, offset, scalefactor, aligntext, rotation, 
-                diagonal, opacity, rendermode, strokecolor, fillcolor, bgcolor, margins, border
+                diagonal, opacity, rendermode, strokecolor, fillcolor, gradient, path, bgcolor, margins, border
      inFile ... input PDF file
     outFile ... output PDF file
 
@@ -523,7 +538,7 @@ description ... fontname, points, position, offset, scalefactor, aligntext, rota
      string ... display string for text based watermarks
        file ... image or PDF file
 description ... fontname, points, position, offset, scalefactor, aligntext, rotation,
-                diagonal, opacity, rendermode, strokecolor, fillcolor, bgcolor, margins, border
+                diagonal, opacity, rendermode, strokecolor, fillcolor, gradient, path, bgcolor, margins, border
      inFile ... input PDF file
     outFile ... output PDF file
 
@@ -642,7 +657,7 @@ If the input is one imageFile a single page n-up PDF gets generated.
       pages ... inFile only, please refer to "pdfcpu selectedpages"
 description ... dimensions, formsize, orientation
     outFile ... output PDF file
-          n ... the n-Up value (see below for details)
+          n ... the n-Up value (see below for details) or a custom grid colsxrows eg. 3x5
      inFile ... input PDF file
  imageFiles ... input image file(s)
 
@@ -650,6 +665,7 @@ description ... dimensions, formsize, orientation
  Supported values for n: 2 ...  1x2       2x1
                          3 ...  1x3       3x1
                          4 ...  2x2
+                         6 ...  2x3       3x2
                          8 ...  2x4       4x2
                          9 ...  3x3
                         12 ...  3x4       4x3
@@ -679,8 +695,14 @@ description ... dimensions, formsize, orientation
     enforce:         enforce best-fit orientation of individual content (on/off, true/false, t/f).
 
     border:          Print border (on/off, true/false, t/f)
+                     or styled border: width [round] [color] eg. "2 round Red"
 
     margin:          for n-up content: float >= 0 in given display unit
+
+    spacing:         padding around grid cells: float >= 0 in given display unit
+
+    anchor:          align content within grid cell instead of centering:
+                     tl, tc, tr, l, c, r, bl, bc, br
     
     backgroundcolor: background color for margin > 0.
                      "bgcolor" is also accepted.
@@ -696,6 +718,10 @@ Examples: pdfcpu nup out.pdf 4 in.pdf
            write result to out.pdf using the default orientation and default paper size A4.
            in.pdf's page size will be preserved.
 
+          pdfcpu nup -- "spacing:5, anchor:tl" out.pdf 3x5 in.pdf
+           Rearrange pages of in.pdf into grids of 3 columns and 5 rows with 5 points space around each cell
+           aligning the content of each cell to its upper left corner.
+
           pdfcpu nup out.pdf 9 logo.jpg
            Arrange instances of logo.jpg into a 3x3 grid and write result to out.pdf using the A4 default form size.
           
@@ -782,6 +808,11 @@ The last signature may be shorter, e.g. for a booklet of 120 pages with signatur
    binding:          The edge of the paper which has the binding. (long, short)
    multifolio:       Generate multi folio booklet (on/off, true/false, t/f) for n=2 and PDF input only.
    foliosize:        folio size for multi folio booklets only (default:8)
+   flip:             The edge your printer flips sheets along for duplex printing (short, long)
+                     For long-edge flipping the back sides of all sheets get rotated by 180 degrees.
+   blanks:           Insert blank pages completing the last sheet at (end, start, back)
+                     back ... before the last page which remains the back cover.
+   labels:           Print sheet and signature labels into the gutter (on/off, true/false, t/f)
    border:           Print border (on/off, true/false, t/f) 
    guides:           Print folding and cutting lines (on/off, true/false, t/f)
    margin:           Apply content margin (float >= 0 in given display unit)
@@ -816,6 +847,10 @@ Examples:
   
    pdfcpu booklet -- "formsize:A3, btype:bookletadvanced" out.pdf 4 in.pdf
       Arrange pages of in.pdf 4 per sheet side, arranged for advanced binding, onto out.pdf
+
+   pdfcpu booklet -- "formsize:A4, flip:long, blanks:back, labels:on" out.pdf 2 in.pdf
+      Arrange pages of in.pdf 2 per sheet side for a printer flipping along the long edge.
+      Blank pages get inserted before the back cover and each sheet side is labeled in the gutter.
 `
 
 	usageGrid     = "usage: pdfcpu grid [-p(ages) selectedPages] -- [description] outFile m n inFile|imageFiles..." + generalFlags
@@ -1381,7 +1416,7 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
 	usageLongPoster = `Create a poster using paper size.
 
          pages ... Please refer to "pdfcpu selectedpages"
-   description ... formsize(=papersize), dimensions, scalefactor, margin, bgcolor, border
+   description ... formsize(=papersize), dimensions, scalefactor, margin, bgcolor, border, overlap, cutmarks, glue, labels
         inFile ... input PDF file
         outDir ... output directory
    outFileName ... output file name
@@ -1406,6 +1441,16 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
       bgcolor:      color value for visualization of margin / glue area.
 
       border:       if margin set, draw content region border (on/off, true/false, t/f) 
+
+      overlap:      Tiles overlap their right and bottom neighbors (float >= 0 in given display unit)
+
+      cutmarks:     Draw cut marks at the tile corners (on/off, true/false, t/f)
+
+      glue:         Shade the overlapping glue areas (on/off, true/false, t/f)
+
+      labels:       Label tiles and their arrangement on the first page (on/off, true/false, t/f)
+
+      margin can't be combined with overlap, cutmarks, glue or labels.
    
    
    Examples:
@@ -1420,6 +1465,10 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
 
          pdfcpu poster -u cm -- "dim:15 10, margin:1, bgcol:DarkGray, border:on" in.pdf outDir
             Generate a poster via a corresponding grid with cell size 15x10 cm and provide a glue area of 1 cm.
+
+         pdfcpu poster -u cm -- "f:A4, scale:2.0, overlap:1, cutmarks:on, glue:on, labels:on" in.pdf outDir
+            Generate a poster via a grid of A4 pages overlapping by 1 cm.
+            Glue areas get shaded and all tiles get labeled for easy assembly.
             
    See also the related commands: ndown, cut`
 
@@ -0,0 +1,346 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// OpenAction returns rs's document open action or nil if there is none.
+func OpenAction(rs io.ReadSeeker, conf *model.Configuration) (*model.Action, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: OpenAction: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTACTIONS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.OpenAction(ctx)
+}
+
+// OpenActionFile returns inFile's document open action or nil if there is none.
+func OpenActionFile(inFile string, conf *model.Configuration) (*model.Action, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return OpenAction(f, conf)
+}
+
+// SetOpenAction sets the document open action of rs and writes the result to w.
+func SetOpenAction(rs io.ReadSeeker, w io.Writer, a model.Action, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetOpenAction: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.SETACTIONS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetOpenAction(ctx, a); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetOpenActionFile sets the document open action of inFile and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten.
+func SetOpenActionFile(inFile, outFile string, a model.Action, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetOpenAction(f1, f2, a, conf)
+}
+
+// RemoveOpenAction removes the document open action of rs and writes the result to w.
+func RemoveOpenAction(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveOpenAction: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.REMOVEACTIONS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pdfcpu.RemoveOpenAction(ctx); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveOpenActionFile removes the document open action of inFile and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten.
+func RemoveOpenActionFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemoveOpenAction(f1, f2, conf)
+}
+
+// PageActions returns the open and close actions of page pageNr of rs by trigger.
+func PageActions(rs io.ReadSeeker, pageNr int, conf *model.Configuration) (map[string]model.Action, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PageActions: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTACTIONS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.PageActions(ctx, pageNr)
+}
+
+// PageActionsFile returns the open and close actions of page pageNr of inFile by trigger.
+func PageActionsFile(inFile string, pageNr int, conf *model.Configuration) (map[string]model.Action, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return PageActions(f, pageNr, conf)
+}
+
+// SetPageAction sets the page action for trigger of page pageNr of rs and writes the result to w.
+func SetPageAction(rs io.ReadSeeker, w io.Writer, pageNr int, trigger string, a model.Action, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetPageAction: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.SETACTIONS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetPageAction(ctx, pageNr, trigger, a); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetPageActionFile sets the page action for trigger of page pageNr of inFile and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten.
+func SetPageActionFile(inFile, outFile string, pageNr int, trigger string, a model.Action, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetPageAction(f1, f2, pageNr, trigger, a, conf)
+}
+
+// RemovePageAction removes the page action for trigger of page pageNr of rs and writes the result to w.
+func RemovePageAction(rs io.ReadSeeker, w io.Writer, pageNr int, trigger string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemovePageAction: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.REMOVEACTIONS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pdfcpu.RemovePageAction(ctx, pageNr, trigger); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemovePageActionFile removes the page action for trigger of page pageNr of inFile and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten.
+func RemovePageActionFile(inFile, outFile string, pageNr int, trigger string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemovePageAction(f1, f2, pageNr, trigger, conf)
+}
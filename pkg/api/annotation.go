@@ -49,6 +49,56 @@ func Annotations(rs io.ReadSeeker, selectedPages []string, conf *model.Configura
 	return pdfcpu.AnnotationsForSelectedPages(ctx, pages), nil
 }
 
+// Measurements returns the real world dimensions of measuring Line, PolyLine and Polygon annotations of rs for selected pages.
+func Measurements(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (map[int][]pdfcpu.Measurement, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Measurements: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTMEASUREMENTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[int][]pdfcpu.Measurement{}
+
+	for p := 1; p <= ctx.PageCount; p++ {
+		if !pages[p] {
+			continue
+		}
+		mm, err := pdfcpu.Measurements(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if len(mm) > 0 {
+			m[p] = mm
+		}
+	}
+
+	return m, nil
+}
+
+// MeasurementsFile returns the real world dimensions of measuring Line, PolyLine and Polygon annotations of inFile for selected pages.
+func MeasurementsFile(inFile string, selectedPages []string, conf *model.Configuration) (map[int][]pdfcpu.Measurement, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Measurements(f, selectedPages, conf)
+}
+
 // AddAnnotations adds annotations for selected pages in rs and writes the result to w.
 func AddAnnotations(rs io.ReadSeeker, w io.Writer, selectedPages []string, ann model.AnnotationRenderer, conf *model.Configuration) error {
 	if rs == nil {
@@ -400,3 +450,148 @@ func RemoveAnnotationsFile(inFile, outFile string, selectedPages, idsAndTypes []
 
 	return RemoveAnnotations(f1, f2, selectedPages, idsAndTypes, objNrs, conf)
 }
+
+// ExportAnnotationsJSON extracts the annotations of selected pages of rs (originating from source) and writes the result as JSON to w.
+func ExportAnnotationsJSON(rs io.ReadSeeker, w io.Writer, selectedPages []string, source string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExportAnnotationsJSON: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: ExportAnnotationsJSON: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXPORTANNOTATIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	ok, err := pdfcpu.ExportAnnotationsJSON(ctx, pages, source, w)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("pdfcpu: no annotations available")
+	}
+
+	return nil
+}
+
+// ExportAnnotationsFile extracts the annotations of selected pages of inFilePDF and writes the result to outFileJSON.
+func ExportAnnotationsFile(inFilePDF, outFileJSON string, selectedPages []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFilePDF); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(outFileJSON); err != nil {
+		f1.Close()
+		return err
+	}
+	logWritingTo(outFileJSON)
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+	}()
+
+	return ExportAnnotationsJSON(f1, f2, selectedPages, inFilePDF, conf)
+}
+
+// ImportAnnotationsJSON adds the annotations read as JSON from rd to rs and writes the result to w.
+func ImportAnnotationsJSON(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ImportAnnotationsJSON: missing rs")
+	}
+
+	if rd == nil {
+		return errors.New("pdfcpu: ImportAnnotationsJSON: missing rd")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.IMPORTANNOTATIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pdfcpu.ImportAnnotationsJSON(ctx, rd); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ImportAnnotationsFile adds the annotations of inFileJSON to inFilePDF and writes the result to outFilePDF.
+func ImportAnnotationsFile(inFilePDF, inFileJSON, outFilePDF string, conf *model.Configuration) (err error) {
+	var f0, f1, f2 *os.File
+
+	if f0, err = os.Open(inFilePDF); err != nil {
+		return err
+	}
+
+	if f1, err = os.Open(inFileJSON); err != nil {
+		f0.Close()
+		return err
+	}
+
+	tmpFile := inFilePDF + ".tmp"
+	if outFilePDF != "" && inFilePDF != outFilePDF {
+		tmpFile = outFilePDF
+		logWritingTo(outFilePDF)
+	} else {
+		logWritingTo(inFilePDF)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		f0.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			f0.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if err = f0.Close(); err != nil {
+			return
+		}
+		if outFilePDF == "" || inFilePDF == outFilePDF {
+			err = os.Rename(tmpFile, inFilePDF)
+		}
+	}()
+
+	return ImportAnnotationsJSON(f0, f1, f2, conf)
+}
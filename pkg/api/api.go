@@ -34,7 +34,6 @@
 package api
 
 import (
-	"bufio"
 	"io"
 	"os"
 	"sync"
@@ -104,14 +103,29 @@ func ReadContextFile(inFile string) (*model.Context, error) {
 
 // ValidateContext validates ctx.
 func ValidateContext(ctx *model.Context) error {
+	if ctx.ReadOnly() {
+		return model.ErrReadOnly
+	}
 	if ctx.XRefTable.Version() == model.V20 {
 		logDisclaimerPDF20()
 	}
 	return validate.XRefTable(ctx)
 }
 
+// ValidateTouched validates the objects touched by the transaction tx on ctx instead of the whole document.
+// If validation fails tx is rolled back, see validate.Touched.
+func ValidateTouched(ctx *model.Context, tx *model.Transaction) error {
+	if ctx.ReadOnly() {
+		return model.ErrReadOnly
+	}
+	return validate.Touched(ctx, tx)
+}
+
 // OptimizeContext optimizes ctx.
 func OptimizeContext(ctx *model.Context) error {
+	if ctx.ReadOnly() {
+		return model.ErrReadOnly
+	}
 	if log.CLIEnabled() {
 		log.CLI.Println("optimizing...")
 	}
@@ -120,18 +134,24 @@ func OptimizeContext(ctx *model.Context) error {
 
 // WriteContext writes ctx to w.
 func WriteContext(ctx *model.Context, w io.Writer) error {
+	if ctx.ReadOnly() {
+		return model.ErrReadOnly
+	}
 	if f, ok := w.(*os.File); ok {
 		// In order to retrieve the written file size.
 		ctx.Write.Fp = f
 	}
-	ctx.Write.Writer = bufio.NewWriter(w)
+	ctx.Write.SetWriter(w)
 	defer ctx.Write.Flush()
 	return pdfcpu.WriteContext(ctx)
 }
 
 // WriteIncrement writes a PDF increment for ctx to w.
 func WriteIncrement(ctx *model.Context, w io.Writer) error {
-	ctx.Write.Writer = bufio.NewWriter(w)
+	if ctx.ReadOnly() {
+		return model.ErrReadOnly
+	}
+	ctx.Write.SetWriter(w)
 	defer ctx.Write.Flush()
 	return pdfcpu.WriteIncrement(ctx)
 }
@@ -146,6 +166,17 @@ func WriteContextFile(ctx *model.Context, outFile string) error {
 	return WriteContext(ctx, f)
 }
 
+// WriteContextFS writes ctx to fileName within fsys.
+func WriteContextFS(ctx *model.Context, fsys model.FS, fileName string) error {
+	if ctx.ReadOnly() {
+		return model.ErrReadOnly
+	}
+	ctx.Write.Writer = nil
+	ctx.Write.FS = fsys
+	ctx.Write.FileName = fileName
+	return pdfcpu.WriteContext(ctx)
+}
+
 // ReadAndValidate returns a model.Context of rs ready for processing.
 func ReadAndValidate(rs io.ReadSeeker, conf *model.Configuration) (ctx *model.Context, err error) {
 	if ctx, err = ReadContext(rs, conf); err != nil {
@@ -165,6 +196,7 @@ func cmdAssumingOptimization(cmd model.CommandMode) bool {
 		cmd == model.RESETFORMFIELDS ||
 		cmd == model.LISTIMAGES ||
 		cmd == model.UPDATEIMAGES ||
+		cmd == model.REPLACEIMAGE ||
 		cmd == model.EXTRACTIMAGES ||
 		cmd == model.EXTRACTFONTS
 }
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Associated file owners are identified by object number,
+// therefore these commands process the unoptimized context.
+
+// AssociatedFiles returns the files associated with owner in rs.
+func AssociatedFiles(rs io.ReadSeeker, owner model.AFOwner, conf *model.Configuration) ([]model.AssociatedFile, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: AssociatedFiles: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTASSOCIATEDFILES
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.ListAssociatedFiles(owner)
+}
+
+// AssociatedFilesFile returns the files associated with owner in inFile.
+func AssociatedFilesFile(inFile string, owner model.AFOwner, conf *model.Configuration) ([]model.AssociatedFile, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return AssociatedFiles(f, owner, conf)
+}
+
+func addAssociatedFile(ctx *model.Context, owner model.AFOwner, file, relationship string) error {
+	// file is either a file name or a file name and a description separated by a comma.
+	fileName, desc, _ := strings.Cut(file, ",")
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("associating %s with %s\n", fileName, owner)
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	mt := fi.ModTime()
+
+	a := model.Attachment{Reader: f, ID: filepath.Base(fileName), Desc: desc, ModTime: &mt}
+
+	return ctx.AddAssociatedFile(owner, a, relationship)
+}
+
+// AddAssociatedFiles embeds files into a PDF context read from rs, associates them with owner
+// using relationship (see model.AFRelationships) and writes the result to w.
+// file is either a file name or a file name and a description separated by a comma.
+func AddAssociatedFiles(rs io.ReadSeeker, w io.Writer, owner model.AFOwner, files []string, relationship string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddAssociatedFiles: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: AddAssociatedFiles: missing w")
+	}
+
+	if len(files) == 0 {
+		return errors.New("pdfcpu: AddAssociatedFiles: missing files")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDASSOCIATEDFILES
+
+	return updateUnoptimized(rs, w, conf, func(ctx *model.Context) error {
+		for _, fn := range files {
+			if err := addAssociatedFile(ctx, owner, fn, relationship); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AddAssociatedFilesFile embeds files into a PDF context read from inFile, associates them with owner
+// using relationship and writes the result to outFile.
+func AddAssociatedFilesFile(inFile, outFile string, owner model.AFOwner, files []string, relationship string, conf *model.Configuration) error {
+	return updateUnoptimizedFile(inFile, outFile, func(rs io.ReadSeeker, w io.Writer) error {
+		return AddAssociatedFiles(rs, w, owner, files, relationship, conf)
+	})
+}
+
+// RemoveAssociatedFiles removes the files with ids associated with owner from a PDF context read from rs
+// and writes the result to w.
+func RemoveAssociatedFiles(rs io.ReadSeeker, w io.Writer, owner model.AFOwner, ids []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveAssociatedFiles: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: RemoveAssociatedFiles: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEASSOCIATEDFILES
+
+	return updateUnoptimized(rs, w, conf, func(ctx *model.Context) error {
+		for _, id := range ids {
+			ok, err := ctx.RemoveAssociatedFile(owner, id)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.Errorf("pdfcpu: %s has no associated file %s", owner, id)
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveAssociatedFilesFile removes the files with ids associated with owner from a PDF context read from inFile
+// and writes the result to outFile.
+func RemoveAssociatedFilesFile(inFile, outFile string, owner model.AFOwner, ids []string, conf *model.Configuration) error {
+	return updateUnoptimizedFile(inFile, outFile, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemoveAssociatedFiles(rs, w, owner, ids, conf)
+	})
+}
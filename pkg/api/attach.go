@@ -273,6 +273,10 @@ func ExtractAttachments(rs io.ReadSeeker, outDir string, fileNames []string, con
 		return err
 	}
 
+	return writeAttachments(aa, outDir)
+}
+
+func writeAttachments(aa []model.Attachment, outDir string) error {
 	for _, a := range aa {
 
 		fn := SanitizePath(a.FileName)
@@ -308,3 +312,35 @@ func ExtractAttachmentsFile(inFile, outDir string, files []string, conf *model.C
 
 	return ExtractAttachments(f, outDir, files, conf)
 }
+
+// VerifyAttachments extracts all embedded files from a PDF context read from rs
+// and verifies them against their specified size and checksum.
+func VerifyAttachments(rs io.ReadSeeker, conf *model.Configuration) ([]model.AttachmentCheck, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: VerifyAttachments: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTATTACHMENTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.VerifyAttachments()
+}
+
+// VerifyAttachmentsFile extracts all embedded files from a PDF context read from inFile
+// and verifies them against their specified size and checksum.
+func VerifyAttachmentsFile(inFile string, conf *model.Configuration) ([]model.AttachmentCheck, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return VerifyAttachments(f, conf)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// SetPageBackground fills the media box of selected pages of rs with color c and writes the result to w.
+// If underContent is true the background is painted beneath existing content, otherwise c gets multiplied with the page content.
+func SetPageBackground(rs io.ReadSeeker, w io.Writer, selectedPages []string, c color.SimpleColor, underContent bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetPageBackground: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETBACKGROUND
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.SetPageBackground(ctx, pages, c, underContent); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemovePageBackground removes page backgrounds from selected pages of rs and writes the result to w.
+func RemovePageBackground(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemovePageBackground: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEBACKGROUND
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.RemovePageBackground(ctx, pages); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetPageBackgroundFile fills the media box of selected pages of inFile with color c and writes the result to outFile.
+func SetPageBackgroundFile(inFile, outFile string, selectedPages []string, c color.SimpleColor, underContent bool, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetPageBackground(f1, f2, selectedPages, c, underContent, conf)
+}
+
+// RemovePageBackgroundFile removes page backgrounds from selected pages of inFile and writes the result to outFile.
+func RemovePageBackgroundFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemovePageBackground(f1, f2, selectedPages, conf)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// DetectBlankPages returns the numbers of all blank pages of rs.
+// tolerance is the fraction of non white pixels a blank page may have, see pdfcpu.DefaultBlankPageTolerance.
+func DetectBlankPages(rs io.ReadSeeker, tolerance float64, conf *model.Configuration) ([]int, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: DetectBlankPages: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.DETECTBLANKPAGES
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.DetectBlankPages(ctx, tolerance)
+}
+
+// DetectBlankPagesFile returns the numbers of all blank pages of inFile.
+func DetectBlankPagesFile(inFile string, tolerance float64, conf *model.Configuration) ([]int, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return DetectBlankPages(f, tolerance, conf)
+}
+
+// RemoveBlankPages removes all blank pages of rs, writes the result to w and returns the numbers of the removed pages.
+func RemoveBlankPages(rs io.ReadSeeker, w io.Writer, tolerance float64, conf *model.Configuration) ([]int, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: RemoveBlankPages: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEBLANKPAGES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxDest, pageNrs, err := pdfcpu.RemoveBlankPages(ctx, tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("removed %d blank pages\n", len(pageNrs))
+	}
+
+	return pageNrs, Write(ctxDest, w, conf)
+}
+
+// RemoveBlankPagesFile removes all blank pages of inFile, writes the result to outFile and returns the numbers of the removed pages.
+func RemoveBlankPagesFile(inFile, outFile string, tolerance float64, conf *model.Configuration) (pageNrs []int, err error) {
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemoveBlankPages(f1, f2, tolerance, conf)
+}
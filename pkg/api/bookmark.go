@@ -50,6 +50,37 @@ func Bookmarks(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.Bookmark,
 	return pdfcpu.Bookmarks(ctx)
 }
 
+// ExtractOutline returns rs's outline tree resolved to target pages and positions.
+func ExtractOutline(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.OutlineItem, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ExtractOutline: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTBOOKMARKS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+	return pdfcpu.ExtractOutline(ctx)
+}
+
+// ExtractOutlineFile returns inFile's outline tree resolved to target pages and positions.
+func ExtractOutlineFile(inFile string, conf *model.Configuration) ([]pdfcpu.OutlineItem, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ExtractOutline(f, conf)
+}
+
 // ExportBookmarksJSON extracts outline data from rs (originating from source) and writes the result to w.
 func ExportBookmarksJSON(rs io.ReadSeeker, w io.Writer, source string, conf *model.Configuration) error {
 	if rs == nil {
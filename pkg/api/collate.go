@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ReorderPages rearranges the pages of rs according to order and writes the result to w.
+// order lists every page number of rs exactly once.
+func ReorderPages(rs io.ReadSeeker, w io.Writer, order []int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ReorderPages: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REORDERPAGES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.ReorderPages(ctx, order); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ReorderPagesFile rearranges the pages of inFile according to order and writes the result to outFile.
+func ReorderPagesFile(inFile, outFile string, order []int, conf *model.Configuration) (err error) {
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ReorderPages(f1, f2, order, conf)
+}
+
+// Collate merges a sequence of PDF streams, arranges the pages according to pattern and writes the result to w.
+// See pdfcpu.CollateOrder for the pattern syntax, eg. "duplex" merges a front side scan with a reversed back side scan.
+func Collate(rsc []io.ReadSeeker, w io.Writer, pattern string, conf *model.Configuration) error {
+	if len(rsc) == 0 {
+		return errors.New("pdfcpu: Collate: missing rsc")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: Collate: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.COLLATE
+	conf.ValidationMode = model.ValidationRelaxed
+
+	ctxs := make([]*model.Context, len(rsc))
+	for i, rs := range rsc {
+		ctx, err := ReadAndValidate(rs, conf)
+		if err != nil {
+			return err
+		}
+		ctxs[i] = ctx
+	}
+
+	ctxDest, err := pdfcpu.Collate(ctxs, pattern)
+	if err != nil {
+		return err
+	}
+
+	if conf.OptimizeBeforeWriting {
+		if err := OptimizeContext(ctxDest); err != nil {
+			return err
+		}
+	}
+
+	return WriteContext(ctxDest, w)
+}
+
+// CollateFile merges inFiles, arranges the pages according to pattern and writes the result to outFile.
+func CollateFile(inFiles []string, outFile string, pattern string, conf *model.Configuration) (err error) {
+	if len(inFiles) == 0 {
+		return errors.New("pdfcpu: CollateFile: missing inFiles")
+	}
+
+	rsc := make([]io.ReadSeeker, len(inFiles))
+	for i, fName := range inFiles {
+		f, err := os.Open(fName)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		rsc[i] = f
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if err1 := f.Close(); err1 != nil {
+				return
+			}
+			os.Remove(outFile)
+			return
+		}
+		if err = f.Close(); err != nil {
+			return
+		}
+	}()
+
+	logWritingTo(outFile)
+	return Collate(rsc, f, pattern, conf)
+}
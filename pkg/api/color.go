@@ -0,0 +1,330 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ConvertColors converts all colors of rs to target (DeviceGray, DeviceRGB or DeviceCMYK) using an optional rendering intent and writes the result to w.
+// It returns the residual colors which could not be converted, see pdfcpu.ConvertColors.
+func ConvertColors(rs io.ReadSeeker, w io.Writer, target, intent string, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ConvertColors: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CONVERTCOLORS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	residual, err := pdfcpu.ConvertColors(ctx, target, intent)
+	if err != nil {
+		return nil, err
+	}
+
+	return residual, Write(ctx, w, conf)
+}
+
+// ConvertColorsFile converts all colors of inFile to target (DeviceGray, DeviceRGB or DeviceCMYK) using an optional rendering intent and writes the result to outFile.
+// It returns the residual colors which could not be converted, see pdfcpu.ConvertColors.
+func ConvertColorsFile(inFile, outFile, target, intent string, conf *model.Configuration) (residual []string, err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ConvertColors(f1, f2, target, intent, conf)
+}
+
+// InkCoverage returns the estimated ink coverage of selected pages of rs rendered at the given resolution.
+func InkCoverage(rs io.ReadSeeker, selectedPages []string, dpi float64, conf *model.Configuration) ([]pdfcpu.PageInkCoverage, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: InkCoverage: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.INKCOVERAGE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var cc []pdfcpu.PageInkCoverage
+
+	pp := newPageProgress(conf, pages)
+	for p := 1; p <= ctx.PageCount; p++ {
+		if !pages[p] {
+			continue
+		}
+
+		c, err := pdfcpu.InkCoverage(ctx, p, dpi)
+		if err != nil {
+			return nil, err
+		}
+		pp.next()
+
+		cc = append(cc, *c)
+	}
+
+	return cc, nil
+}
+
+// InkCoverageFile returns the estimated ink coverage of selected pages of inFile rendered at the given resolution.
+func InkCoverageFile(inFile string, selectedPages []string, dpi float64, conf *model.Configuration) ([]pdfcpu.PageInkCoverage, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return InkCoverage(f, selectedPages, dpi, conf)
+}
+
+// Colorants returns the colorants used by Separation and DeviceN color spaces of rs.
+func Colorants(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.Colorant, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Colorants: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTCOLORANTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.Colorants(ctx)
+}
+
+// ListColorants returns a formatted list of the colorants used by rs.
+func ListColorants(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListColorants: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTCOLORANTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListColorants(ctx)
+}
+
+// ListColorantsFile returns a formatted list of the colorants used by inFile.
+func ListColorantsFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListColorants(f, conf)
+}
+
+// RemapColorant maps the colorant name of rs to an equivalent color in altCS (DeviceGray, DeviceRGB or DeviceCMYK) and writes the result to w.
+func RemapColorant(rs io.ReadSeeker, w io.Writer, name, altCS string, comps []float64, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemapColorant: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMAPCOLORANT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.RemapColorant(ctx, name, altCS, comps)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.Errorf("pdfcpu: RemapColorant: no Separation color space found for %s", name)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemapColorantFile maps the colorant name of inFile to an equivalent color in altCS (DeviceGray, DeviceRGB or DeviceCMYK) and writes the result to outFile.
+func RemapColorantFile(inFile, outFile, name, altCS string, comps []float64, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemapColorant(f1, f2, name, altCS, comps, conf)
+}
+
+// RenameColorant renames the colorant oldName of rs to newName and writes the result to w.
+func RenameColorant(rs io.ReadSeeker, w io.Writer, oldName, newName string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RenameColorant: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.RENAMECOLORANT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.RenameColorant(ctx, oldName, newName)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.Errorf("pdfcpu: RenameColorant: colorant not found: %s", oldName)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RenameColorantFile renames the colorant oldName of inFile to newName and writes the result to outFile.
+func RenameColorantFile(inFile, outFile, oldName, newName string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RenameColorant(f1, f2, oldName, newName, conf)
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pkg/errors"
 )
@@ -285,3 +286,33 @@ func ChangeOwnerPasswordFile(inFile, outFile string, pwOld, pwNew string, conf *
 
 	return ChangeOwnerPassword(f1, f2, pwOld, pwNew, conf)
 }
+
+// ChangeOwnerPasswordAsIncrement changes the owner password of rws and writes out a PDF increment.
+// The file encryption key, user password and permissions remain unchanged which is supported for AES-256 only.
+// A configuration containing the current passwords is required.
+func ChangeOwnerPasswordAsIncrement(rws io.ReadWriteSeeker, pwOld, pwNew string, conf *model.Configuration) error {
+	if rws == nil {
+		return errors.New("pdfcpu: ChangeOwnerPasswordAsIncrement: missing rws")
+	}
+
+	if conf == nil {
+		return errors.New("pdfcpu: missing configuration for change owner password")
+	}
+
+	conf.Cmd = model.CHANGEOPW
+	conf.OwnerPW = pwOld
+
+	ctx, err := ReadAndValidate(rws, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.ChangeOwnerPasswordInPlace(ctx, pwNew); err != nil {
+		return err
+	}
+
+	ctx.Write.Increment = true
+	ctx.Write.Offset = ctx.Read.FileSize
+
+	return WriteIncr(ctx, rws, conf)
+}
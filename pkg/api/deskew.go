@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// DeskewPages corrects the skew and removes speckle noise of selected scanned pages of rs and writes the result to w.
+// opts defaults to pdfcpu.DefaultDeskewOptions.
+func DeskewPages(rs io.ReadSeeker, w io.Writer, selectedPages []string, opts *pdfcpu.DeskewOptions, conf *model.Configuration) ([]pdfcpu.DeskewedPage, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: DeskewPages: missing rs")
+	}
+
+	if opts == nil {
+		o := pdfcpu.DefaultDeskewOptions()
+		opts = &o
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.DESKEW
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	dpp, err := pdfcpu.DeskewPages(ctx, pages, *opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return dpp, Write(ctx, w, conf)
+}
+
+// DeskewPagesFile corrects the skew and removes speckle noise of selected scanned pages of inFile and writes the result to outFile.
+func DeskewPagesFile(inFile, outFile string, selectedPages []string, opts *pdfcpu.DeskewOptions, conf *model.Configuration) (dpp []pdfcpu.DeskewedPage, err error) {
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return DeskewPages(f1, f2, selectedPages, opts, conf)
+}
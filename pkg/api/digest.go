@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readForManifest(rs io.ReadSeeker, conf *model.Configuration) (*model.Context, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.MANIFEST
+
+	return ReadAndValidate(rs, conf)
+}
+
+// PageDigest returns the hex encoded digest of the canonicalized content of page pageNr of rs using algo.
+func PageDigest(rs io.ReadSeeker, pageNr int, algo string, conf *model.Configuration) (string, error) {
+	if rs == nil {
+		return "", errors.New("pdfcpu: PageDigest: missing rs")
+	}
+
+	ctx, err := readForManifest(rs, conf)
+	if err != nil {
+		return "", err
+	}
+
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return "", errors.Errorf("pdfcpu: PageDigest: invalid page number: %d", pageNr)
+	}
+
+	bb, err := pdfcpu.PageDigest(ctx, pageNr, algo)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bb), nil
+}
+
+// DocumentManifest returns the page digests of rs.
+func DocumentManifest(rs io.ReadSeeker, conf *model.Configuration) (*pdfcpu.Manifest, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: DocumentManifest: missing rs")
+	}
+
+	ctx, err := readForManifest(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.DocumentManifest(ctx)
+}
+
+// DocumentManifestFile returns the page digests of inFile.
+func DocumentManifestFile(inFile string, conf *model.Configuration) (*pdfcpu.Manifest, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return DocumentManifest(f, conf)
+}
+
+// VerifyManifest returns the numbers of all pages of rs not matching m.
+func VerifyManifest(rs io.ReadSeeker, m pdfcpu.Manifest, conf *model.Configuration) ([]int, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: VerifyManifest: missing rs")
+	}
+
+	ctx, err := readForManifest(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.VerifyManifest(ctx, m)
+}
+
+// VerifyManifestFile returns the numbers of all pages of inFile not matching m.
+func VerifyManifestFile(inFile string, m pdfcpu.Manifest, conf *model.Configuration) ([]int, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return VerifyManifest(f, m, conf)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// DumpJSON writes the object graph of rs as JSON to w.
+// Validation is skipped in order to support inspection of malformed files.
+// withData includes base64 encoded raw stream data.
+func DumpJSON(rs io.ReadSeeker, w io.Writer, withData bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: DumpJSON: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.DUMP
+
+	ctx, err := ReadContext(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	return ctx.DumpJSON(w, withData)
+}
+
+// DumpJSONFile writes the object graph of inFile as JSON to outFileJSON.
+func DumpJSONFile(inFile, outFileJSON string, withData bool, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+	defer f1.Close()
+
+	if f2, err = os.Create(outFileJSON); err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := f2.Close(); err == nil {
+			err = err1
+		}
+	}()
+
+	logWritingTo(outFileJSON)
+
+	return DumpJSON(f1, f2, withData, conf)
+}
+
+// RebuildFromJSON builds a PDF from a JSON dump read from r and writes the result to w.
+// The dump needs to include stream data.
+func RebuildFromJSON(r io.Reader, w io.Writer, conf *model.Configuration) error {
+	if r == nil {
+		return errors.New("pdfcpu: RebuildFromJSON: missing r")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.DUMP
+
+	ctx, err := model.ContextFromJSON(r, conf)
+	if err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RebuildFromJSONFile builds a PDF from the JSON dump inFileJSON and writes the result to outFile.
+func RebuildFromJSONFile(inFileJSON, outFile string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFileJSON); err != nil {
+		return err
+	}
+	defer f1.Close()
+
+	if f2, err = os.Create(outFile); err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := f2.Close(); err == nil {
+			err = err1
+		}
+	}()
+
+	logWritingTo(outFile)
+
+	return RebuildFromJSON(f1, f2, conf)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// EmbedMissingFonts embeds installed TrueType fonts resolved by resolve for the unembedded fonts of rs
+// and writes the result to w.
+// pdfcpu.DefaultFontResolver is used if resolve is nil.
+func EmbedMissingFonts(rs io.ReadSeeker, w io.Writer, resolve pdfcpu.FontResolver, conf *model.Configuration) ([]pdfcpu.FontEmbedding, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: EmbedMissingFonts: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EMBEDFONTS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	fes, err := pdfcpu.EmbedMissingFonts(ctx, resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	if log.CLIEnabled() {
+		for _, fe := range fes {
+			if fe.Embedded() {
+				log.CLI.Printf("obj#%d %s: embedded %s\n", fe.ObjNr, fe.BaseFont, fe.FontName)
+				continue
+			}
+			log.CLI.Printf("obj#%d %s: not embedded: %s\n", fe.ObjNr, fe.BaseFont, fe.Reason)
+		}
+	}
+
+	if err := Write(ctx, w, conf); err != nil {
+		return nil, err
+	}
+
+	return fes, nil
+}
+
+// EmbedMissingFontsFile embeds installed TrueType fonts resolved by resolve for the unembedded fonts of inFile
+// and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten.
+func EmbedMissingFontsFile(inFile, outFile string, resolve pdfcpu.FontResolver, conf *model.Configuration) (fes []pdfcpu.FontEmbedding, err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			fes = nil
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return EmbedMissingFonts(f1, f2, resolve, conf)
+}
@@ -18,6 +18,7 @@ package api
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -33,6 +34,27 @@ import (
 	"github.com/pkg/errors"
 )
 
+// pageProgress reports extraction progress over selected pages.
+type pageProgress struct {
+	conf        *model.Configuration
+	done, total int
+}
+
+func newPageProgress(conf *model.Configuration, pages types.IntSet) *pageProgress {
+	total := 0
+	for _, v := range pages {
+		if v {
+			total++
+		}
+	}
+	return &pageProgress{conf: conf, total: total}
+}
+
+func (pp *pageProgress) next() {
+	pp.done++
+	pp.conf.ReportProgress(model.StageExtract, pp.done, pp.total)
+}
+
 // ExtractImagesRaw returns []pdfcpu.Image containing io.Readers for images contained in selectedPages.
 // Beware of memory intensive returned slice.
 func ExtractImagesRaw(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]map[int]model.Image, error) {
@@ -56,6 +78,7 @@ func ExtractImagesRaw(rs io.ReadSeeker, selectedPages []string, conf *model.Conf
 	}
 
 	var images []map[int]model.Image
+	pp := newPageProgress(conf, pages)
 	for i, v := range pages {
 		if !v {
 			continue
@@ -65,6 +88,7 @@ func ExtractImagesRaw(rs io.ReadSeeker, selectedPages []string, conf *model.Conf
 			return nil, err
 		}
 		images = append(images, mm)
+		pp.next()
 	}
 
 	return images, nil
@@ -102,6 +126,7 @@ func ExtractImages(rs io.ReadSeeker, selectedPages []string, digestImage func(mo
 	sort.Ints(pageNrs)
 	maxPageDigits := len(strconv.Itoa(pageNrs[len(pageNrs)-1]))
 
+	pp := newPageProgress(conf, pages)
 	for _, i := range pageNrs {
 		mm, err := pdfcpu.ExtractPageImages(ctx, i, false)
 		if err != nil {
@@ -113,6 +138,7 @@ func ExtractImages(rs io.ReadSeeker, selectedPages []string, digestImage func(mo
 				return err
 			}
 		}
+		pp.next()
 	}
 
 	return nil
@@ -178,6 +204,7 @@ func ExtractFonts(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 
 	objNrs, skipped := types.IntSet{}, types.IntSet{}
 
+	pp := newPageProgress(conf, pages)
 	for i, v := range pages {
 		if !v {
 			continue
@@ -189,6 +216,7 @@ func ExtractFonts(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 		if err := writeFonts(ff, outDir, fileName); err != nil {
 			return err
 		}
+		pp.next()
 	}
 
 	ff, err := pdfcpu.ExtractFormFonts(ctx)
@@ -273,6 +301,7 @@ func ExtractPages(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 
 	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
 
+	pp := newPageProgress(conf, pages)
 	for _, i := range sortedPages(pages) {
 		r, err := ExtractPage(ctx, i)
 		if err != nil {
@@ -281,6 +310,7 @@ func ExtractPages(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 		if err := WritePage(r, outDir, fileName, i); err != nil {
 			return err
 		}
+		pp.next()
 	}
 
 	return nil
@@ -324,6 +354,7 @@ func ExtractContent(rs io.ReadSeeker, outDir, fileName string, selectedPages []s
 
 	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
 
+	pp := newPageProgress(conf, pages)
 	for p, v := range pages {
 		if !v {
 			continue
@@ -333,6 +364,7 @@ func ExtractContent(rs io.ReadSeeker, outDir, fileName string, selectedPages []s
 		if err != nil {
 			return err
 		}
+		pp.next()
 		if r == nil {
 			continue
 		}
@@ -371,6 +403,162 @@ func ExtractContentFile(inFile, outDir string, selectedPages []string, conf *mod
 	return ExtractContent(f, outDir, inFile, selectedPages, conf)
 }
 
+// ExtractSVG renders selected pages of rs into SVG files in outDir.
+func ExtractSVG(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExtractSVG: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTSVG
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+
+	pp := newPageProgress(conf, pages)
+	for p, v := range pages {
+		if !v {
+			continue
+		}
+
+		r, err := pdfcpu.ExtractPageAsSVG(ctx, p)
+		if err != nil {
+			return err
+		}
+		pp.next()
+
+		outFile := filepath.Join(outDir, fmt.Sprintf("%s_page_%d.svg", fileName, p))
+		logWritingTo(outFile)
+		f, err := os.Create(outFile)
+		if err != nil {
+			return err
+		}
+
+		if _, err = io.Copy(f, r); err != nil {
+			f.Close()
+			return err
+		}
+
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExtractSVGFile renders selected pages of inFile into SVG files in outDir.
+func ExtractSVGFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting svg from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	return ExtractSVG(f, outDir, inFile, selectedPages, conf)
+}
+
+// ExtractPaths returns the vector paths of selected pages of rs by page number.
+func ExtractPaths(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (map[int][]pdfcpu.Path, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ExtractPaths: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTPATHS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[int][]pdfcpu.Path{}
+
+	pp := newPageProgress(conf, pages)
+	for p, v := range pages {
+		if !v {
+			continue
+		}
+
+		paths, err := pdfcpu.ExtractPaths(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		pp.next()
+
+		m[p] = paths
+	}
+
+	return m, nil
+}
+
+// ExtractPathsFile writes the vector paths of selected pages of inFile as JSON into outDir.
+func ExtractPathsFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting paths from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	m, err := ExtractPaths(f, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	fileName := strings.TrimSuffix(filepath.Base(inFile), ".pdf")
+
+	for p, paths := range m {
+		bb, err := json.MarshalIndent(paths, "", "\t")
+		if err != nil {
+			return err
+		}
+
+		outFile := filepath.Join(outDir, fmt.Sprintf("%s_paths_%d.json", fileName, p))
+		logWritingTo(outFile)
+		f, err := os.Create(outFile)
+		if err != nil {
+			return err
+		}
+
+		if _, err = f.Write(bb); err != nil {
+			f.Close()
+			return err
+		}
+
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ExtractMetadata dumps all metadata dict entries for rs into outDir.
 func ExtractMetadata(rs io.ReadSeeker, outDir, fileName string, conf *model.Configuration) error {
 	if rs == nil {
@@ -427,3 +615,85 @@ func ExtractMetadataFile(inFile, outDir string, conf *model.Configuration) error
 
 	return ExtractMetadata(f, outDir, filepath.Base(inFile), conf)
 }
+
+// ThreeDModels returns the 3D artwork of all 3D annotations of rs for selected pages.
+func ThreeDModels(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]pdfcpu.ThreeDModel, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ThreeDModels: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACT3D
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var mm []pdfcpu.ThreeDModel
+
+	pp := newPageProgress(conf, pages)
+	for p := 1; p <= ctx.PageCount; p++ {
+		if !pages[p] {
+			continue
+		}
+		mm1, err := pdfcpu.ThreeDModels(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		mm = append(mm, mm1...)
+		pp.next()
+	}
+
+	return mm, nil
+}
+
+// Extract3DModels dumps the U3D and PRC data of all 3D annotations of rs into outDir for selected pages.
+func Extract3DModels(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	mm, err := ThreeDModels(rs, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+
+	for _, m := range mm {
+		outFile := filepath.Join(outDir, fmt.Sprintf("%s_3D_%d_%d.%s", fileName, m.PageNr, m.StreamObjNr, m.Ext()))
+		logWritingTo(outFile)
+		f, err := os.Create(outFile)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(f, m); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Extract3DModelsFile dumps the U3D and PRC data of all 3D annotations of inFile into outDir for selected pages.
+func Extract3DModelsFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting 3D models from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	return Extract3DModels(f, outDir, filepath.Base(inFile), selectedPages, conf)
+}
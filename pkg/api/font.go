@@ -19,7 +19,8 @@ package api
 import (
 	"bytes"
 	"fmt"
-
+	"io/fs"
+	"path"
 	"path/filepath"
 	"sort"
 	"unicode/utf8"
@@ -88,6 +89,33 @@ func InstallFonts(fileNames []string) error {
 	return font.LoadUserFonts()
 }
 
+// InstallFontsFS installs true type fonts read from fsys for embedding.
+func InstallFontsFS(fsys fs.FS, fileNames []string) error {
+	if log.CLIEnabled() {
+		log.CLI.Printf("installing to %s...", font.UserFontDir)
+	}
+
+	for _, fn := range fileNames {
+		ext := path.Ext(fn)
+		if ext != ".ttf" && ext != ".ttc" {
+			continue
+		}
+		bb, err := fs.ReadFile(fsys, fn)
+		if err == nil {
+			if ext == ".ttf" {
+				err = font.InstallFontFromBytes(font.UserFontDir, fn, bb)
+			} else {
+				err = font.InstallTrueTypeCollectionFromBytes(font.UserFontDir, fn, bb)
+			}
+		}
+		if err != nil && log.CLIEnabled() {
+			log.CLI.Printf("%v", err)
+		}
+	}
+
+	return font.LoadUserFonts()
+}
+
 func rowLabel(xRefTable *model.XRefTable, i int, td model.TextDescriptor, baseFontName, baseFontKey string, buf *bytes.Buffer, mb *types.Rectangle, left bool) {
 	x := 39.
 	if !left {
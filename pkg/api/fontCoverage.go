@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// FontCoverage reports for each embedded font used on selectedPages of rs the characters shown
+// versus the glyphs present in its font program flagging characters rendered using the .notdef glyph.
+func FontCoverage(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]pdfcpu.FontGlyphCoverage, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: FontCoverage: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTFONTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	fcs, err := pdfcpu.FontCoverage(ctx, pages)
+	if err != nil {
+		return nil, err
+	}
+
+	if log.CLIEnabled() {
+		for _, fc := range fcs {
+			switch {
+			case !fc.Checked():
+				log.CLI.Printf("obj#%d %s: %d characters, %s\n", fc.ObjNr, fc.BaseFont, fc.Referenced, fc.Reason)
+			case fc.Complete():
+				log.CLI.Printf("obj#%d %s: %d characters, %d glyphs, complete\n", fc.ObjNr, fc.BaseFont, fc.Referenced, fc.Glyphs)
+			default:
+				log.CLI.Printf("obj#%d %s: %d characters, %d glyphs, %d missing\n", fc.ObjNr, fc.BaseFont, fc.Referenced, fc.Glyphs, len(fc.Missing))
+				for _, mg := range fc.Missing {
+					log.CLI.Printf("  <%X> %q\n", mg.Code, mg.Text)
+				}
+			}
+		}
+	}
+
+	return fcs, nil
+}
+
+// FontCoverageFile reports for each embedded font used on selectedPages of inFile the characters shown
+// versus the glyphs present in its font program flagging characters rendered using the .notdef glyph.
+func FontCoverageFile(inFile string, selectedPages []string, conf *model.Configuration) ([]pdfcpu.FontGlyphCoverage, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return FontCoverage(f, selectedPages, conf)
+}
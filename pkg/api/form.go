@@ -420,13 +420,25 @@ func ExportFormFile(inFilePDF, outFileJSON string, conf *model.Configuration) (e
 	return ExportFormJSON(f1, f2, inFilePDF, conf)
 }
 
+// choiceValues returns the set of valid values of a choice field.
+func choiceValues(opts, exportValues []string) map[string]bool {
+	m := make(map[string]bool, len(opts)+len(exportValues))
+	for _, s := range opts {
+		m[s] = true
+	}
+	for _, s := range exportValues {
+		m[s] = true
+	}
+	return m
+}
+
 func validateComboBoxValues(f form.Form) error {
 	for _, cb := range f.ComboBoxes {
 		if cb.Value == "" || cb.Editable {
 			continue
 		}
 		if len(cb.Options) > 0 {
-			if !types.MemberOf(cb.Value, cb.Options) {
+			if !choiceValues(cb.Options, cb.ExportValues)[cb.Value] {
 				i, err := strconv.Atoi(cb.Value)
 				if err == nil && i < len(cb.Options) {
 					return nil
@@ -444,8 +456,9 @@ func validateListBoxValues(f form.Form) error {
 			continue
 		}
 		if len(lb.Options) > 0 {
+			m := choiceValues(lb.Options, lb.ExportValues)
 			for _, v := range lb.Values {
-				if !types.MemberOf(v, lb.Options) {
+				if !m[v] {
 					i, err := strconv.Atoi(v)
 					if err == nil && i < len(lb.Options) {
 						return nil
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// SetTabOrder sets the tab order (see form.TabOrders) of selected pages of rs and writes the result to w.
+func SetTabOrder(rs io.ReadSeeker, w io.Writer, selectedPages []string, tabs string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetTabOrder: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: SetTabOrder: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETTABORDER
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	for pageNr, v := range pages {
+		if !v {
+			continue
+		}
+		if err := form.SetTabOrder(ctx, pageNr, tabs); err != nil {
+			return err
+		}
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetTabOrderFile sets the tab order (see form.TabOrders) of selected pages of inFile and writes the result to outFile.
+func SetTabOrderFile(inFile, outFile string, selectedPages []string, tabs string, conf *model.Configuration) error {
+	return updateUnoptimizedFile(inFile, outFile, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetTabOrder(rs, w, selectedPages, tabs, conf)
+	})
+}
+
+// CalculationOrder returns the fully qualified names of the form fields of rs in calculation order.
+func CalculationOrder(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: CalculationOrder: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTFORMFIELDS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return form.CalculationOrder(ctx.XRefTable)
+}
+
+// SetCalculationOrder sets the calculation order of the form fields of rs identified by fully qualified names
+// and writes the result to w.
+func SetCalculationOrder(rs io.ReadSeeker, w io.Writer, fieldNames []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetCalculationOrder: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: SetCalculationOrder: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETCALCULATIONORDER
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := form.SetCalculationOrder(ctx.XRefTable, fieldNames); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetCalculationOrderFile sets the calculation order of the form fields of inFile identified by fully qualified names
+// and writes the result to outFile.
+func SetCalculationOrderFile(inFile, outFile string, fieldNames []string, conf *model.Configuration) error {
+	return updateUnoptimizedFile(inFile, outFile, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetCalculationOrder(rs, w, fieldNames, conf)
+	})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/primitives"
+	"github.com/pkg/errors"
+)
+
+// SetSignatureAppearance renders the appearance template sa into the unsigned signature field id of rs and writes the result to w.
+func SetSignatureAppearance(rs io.ReadSeeker, w io.Writer, id string, sa *primitives.SignatureAppearance, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetSignatureAppearance: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: SetSignatureAppearance: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETSIGNATUREAPPEARANCE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := form.SetSignatureAppearance(ctx, id, sa); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetSignatureAppearanceFile renders the appearance template sa into the unsigned signature field id of inFile and writes the result to outFile.
+func SetSignatureAppearanceFile(inFile, outFile, id string, sa *primitives.SignatureAppearance, conf *model.Configuration) error {
+	return updateUnoptimizedFile(inFile, outFile, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetSignatureAppearance(rs, w, id, sa, conf)
+	})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// readSeekerFS returns an io.ReadSeeker for name within fsys.
+// Files not supporting io.Seeker get buffered.
+func readSeekerFS(fsys fs.FS, name string) (io.ReadSeeker, func() error, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rs, ok := f.(io.ReadSeeker); ok {
+		return rs, f.Close, nil
+	}
+
+	defer f.Close()
+
+	bb, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bytes.NewReader(bb), func() error { return nil }, nil
+}
+
+// ReadFS returns the validated context of the PDF file name within fsys, eg. an embed.FS.
+func ReadFS(fsys fs.FS, name string, conf *model.Configuration) (*model.Context, error) {
+	if fsys == nil {
+		return nil, errors.New("pdfcpu: ReadFS: missing fsys")
+	}
+
+	rs, closeFn, err := readSeekerFS(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+
+	return ReadAndValidate(rs, conf)
+}
+
+// ImageWatermarkFS returns an image watermark configuration for the image file name within fsys.
+func ImageWatermarkFS(fsys fs.FS, name, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	if !model.ImageFileName(name) {
+		return nil, errors.New("imageFileName has to have one of these extensions: .jpg, .jpeg, .png, .tif, .tiff, .webp")
+	}
+
+	bb, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	wm, err := ImageWatermarkForReader(bytes.NewReader(bb), desc, onTop, update, u)
+	if err != nil {
+		return nil, err
+	}
+
+	wm.FileName = name
+
+	return wm, nil
+}
+
+// PDFWatermarkFS returns a PDF watermark configuration for the PDF file name within fsys.
+// Apply watermark/stamp to destination file with pageNrSrc of name for selected pages.
+// If pageNr == 0 apply a multi watermark/stamp applying all src pages in ascending manner to destination pages.
+func PDFWatermarkFS(fsys fs.FS, name string, pageNrSrc int, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	bb, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return PDFWatermarkForReadSeeker(bytes.NewReader(bb), pageNrSrc, desc, onTop, update, u)
+}
+
+// ImportImagesFS appends PDF pages containing images read from fsys to rs and writes the result to w.
+// If rs == nil a new PDF file will be written to w.
+func ImportImagesFS(rs io.ReadSeeker, w io.Writer, fsys fs.FS, imgFiles []string, imp *pdfcpu.Import, conf *model.Configuration) error {
+	if fsys == nil {
+		return errors.New("pdfcpu: ImportImagesFS: missing fsys")
+	}
+
+	imgs := make([]io.Reader, 0, len(imgFiles))
+	for _, fn := range imgFiles {
+		f, err := fsys.Open(fn)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		imgs = append(imgs, f)
+	}
+
+	return ImportImages(rs, w, imgs, imp, conf)
+}
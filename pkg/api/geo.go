@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// GeoInfo returns the geospatial viewports of selected pages of rs by page number.
+func GeoInfo(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (map[int][]pdfcpu.GeoReference, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: GeoInfo: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.GEOINFO
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[int][]pdfcpu.GeoReference{}
+
+	for p := 1; p <= ctx.PageCount; p++ {
+		if !pages[p] {
+			continue
+		}
+		gg, err := pdfcpu.GeoInfo(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if len(gg) > 0 {
+			m[p] = gg
+		}
+	}
+
+	return m, nil
+}
+
+// GeoInfoFile returns the geospatial viewports of selected pages of inFile by page number.
+func GeoInfoFile(inFile string, selectedPages []string, conf *model.Configuration) (map[int][]pdfcpu.GeoReference, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return GeoInfo(f, selectedPages, conf)
+}
+
+// SetGeoReference georeferences selected pages of rs and writes the result to w.
+func SetGeoReference(rs io.ReadSeeker, w io.Writer, selectedPages []string, geoRef pdfcpu.GeoReference, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetGeoReference: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETGEOREFERENCE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	for p := 1; p <= ctx.PageCount; p++ {
+		if !pages[p] {
+			continue
+		}
+		if err := pdfcpu.SetGeoReference(ctx, p, geoRef); err != nil {
+			return err
+		}
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetGeoReferenceFile georeferences selected pages of inFile and writes the result to outFile.
+func SetGeoReferenceFile(inFile, outFile string, selectedPages []string, geoRef pdfcpu.GeoReference, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetGeoReference(f1, f2, selectedPages, geoRef, conf)
+}
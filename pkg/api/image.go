@@ -171,3 +171,92 @@ func UpdateImagesFile(inFile, imageFile, outFile string, objNr, pageNr int, id s
 
 	return UpdateImages(f0, f1, f2, objNr, pageNr, id, conf)
 }
+
+// ReplaceImage swaps the data of the image XObject identified by objNr or (pageNr and resourceId) with the image read from rd.
+// Unlike UpdateImages the new image may have different dimensions, placement remains untouched.
+func ReplaceImage(rs io.ReadSeeker, rd io.Reader, w io.Writer, objNr, pageNr int, id string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ReplaceImage: missing rs")
+	}
+
+	if rd == nil {
+		return errors.New("pdfcpu: ReplaceImage: missing rd")
+	}
+
+	if objNr < 1 && (pageNr == 0 || id == "") {
+		return errors.New("pdfcpu: ReplaceImage: missing objNr or pageNr and id")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REPLACEIMAGE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.ReplaceImage(ctx, rd, objNr, pageNr, id); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ReplaceImageFile swaps the data of the image XObject identified by objNr or (pageNr and resourceId) with imageFile.
+func ReplaceImageFile(inFile, imageFile, outFile string, objNr, pageNr int, id string, conf *model.Configuration) (err error) {
+	if objNr < 1 {
+		if err = ensurePageNrAndId(&pageNr, &id, imageFile); err != nil {
+			return err
+		}
+	}
+
+	var f0, f1, f2 *os.File
+
+	if f0, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f1, err = os.Open(imageFile); err != nil {
+		f0.Close()
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		f0.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			f0.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if err = f0.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ReplaceImage(f0, f1, f2, objNr, pageNr, id, conf)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Impose arranges selected pages of rs on press sheets according to imp and writes the result to w.
+func Impose(rs io.ReadSeeker, w io.Writer, selectedPages []string, imp *model.Imposition, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: Impose: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.IMPOSE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.ImposeFromPDF(ctx, pages, imp); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ImposeFile arranges selected pages of inFile on press sheets according to imp and writes the result to outFile.
+func ImposeFile(inFile, outFile string, selectedPages []string, imp *model.Imposition, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(outFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("imposing %s into %s ...\n", inFile, outFile)
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		err = f1.Close()
+	}()
+
+	return Impose(f1, f2, selectedPages, imp, conf)
+}
+
+// ImposeFileWithLayout is like ImposeFile using the JSON encoded imposition layoutFile.
+func ImposeFileWithLayout(inFile, outFile, layoutFile string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(layoutFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	imp, err := pdfcpu.ParseImposition(f)
+	if err != nil {
+		return err
+	}
+
+	return ImposeFile(inFile, outFile, selectedPages, imp, conf)
+}
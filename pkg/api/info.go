@@ -18,6 +18,7 @@ package api
 
 import (
 	"io"
+	"sort"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -59,3 +60,46 @@ func PDFInfo(rs io.ReadSeeker, fileName string, selectedPages []string, fonts bo
 
 	return pdfcpu.Info(ctx, fileName, pages, fonts)
 }
+
+// PageResources returns the resources referenced by selected pages of rs.
+func PageResources(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]*pdfcpu.PageResourceUsage, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PageResources: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTINFO
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNrs := make([]int, 0, len(pages))
+	for i, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	sort.Ints(pageNrs)
+
+	uu := make([]*pdfcpu.PageResourceUsage, 0, len(pageNrs))
+	for _, i := range pageNrs {
+		u, err := pdfcpu.PageResources(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		uu = append(uu, u)
+	}
+
+	return uu, nil
+}
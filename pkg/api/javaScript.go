@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ListJavaScript returns all JavaScript actions of rs
+// including document level scripts, open actions, page, field, annotation and outline actions.
+func ListJavaScript(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.JavaScript, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListJavaScript: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTJAVASCRIPT
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListJavaScript(ctx)
+}
+
+// ListJavaScriptFile returns all JavaScript actions of inFile.
+func ListJavaScriptFile(inFile string, conf *model.Configuration) ([]pdfcpu.JavaScript, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListJavaScript(f, conf)
+}
+
+// ReplaceJavaScript applies f to all JavaScript actions of rs within scope and writes the result to w.
+// Scripts for which f returns nil are removed.
+func ReplaceJavaScript(rs io.ReadSeeker, w io.Writer, scope pdfcpu.JSScope, f pdfcpu.JSRewriter, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ReplaceJavaScript: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEJAVASCRIPT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.RewriteJavaScript(ctx, scope, f)
+	if err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("%d JavaScript actions affected\n", n)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveJavaScript removes all JavaScript actions of rs within scope and writes the result to w.
+func RemoveJavaScript(rs io.ReadSeeker, w io.Writer, scope pdfcpu.JSScope, conf *model.Configuration) error {
+	return ReplaceJavaScript(rs, w, scope, nil, conf)
+}
+
+// RemoveJavaScriptFile removes all JavaScript actions of inFile within scope and writes the result to outFile.
+func RemoveJavaScriptFile(inFile, outFile string, scope pdfcpu.JSScope, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemoveJavaScript(f1, f2, scope, conf)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// MediaAssets returns the media files embedded by RichMedia and Screen annotations of rs for selected pages.
+func MediaAssets(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]pdfcpu.MediaAsset, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: MediaAssets: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTMEDIA
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var mm []pdfcpu.MediaAsset
+
+	pp := newPageProgress(conf, pages)
+	for p := 1; p <= ctx.PageCount; p++ {
+		if !pages[p] {
+			continue
+		}
+		mm1, err := pdfcpu.MediaAssets(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		mm = append(mm, mm1...)
+		pp.next()
+	}
+
+	return mm, nil
+}
+
+// ExtractMediaAssets dumps the media files embedded by RichMedia and Screen annotations of rs into outDir for selected pages.
+func ExtractMediaAssets(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	mm, err := MediaAssets(rs, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+
+	for _, m := range mm {
+		name := filepath.Base(m.FileName)
+		if name == "." || name == string(filepath.Separator) {
+			name = "media"
+		}
+		outFile := filepath.Join(outDir, fmt.Sprintf("%s_%d_%d_%s", fileName, m.PageNr, m.ObjNr, name))
+		logWritingTo(outFile)
+		f, err := os.Create(outFile)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(f, m); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExtractMediaAssetsFile dumps the media files embedded by RichMedia and Screen annotations of inFile into outDir for selected pages.
+func ExtractMediaAssetsFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting media assets from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	return ExtractMediaAssets(f, outDir, filepath.Base(inFile), selectedPages, conf)
+}
+
+// AddVideoAnnotation embeds video v into page pageNr of rs and writes the result to w.
+func AddVideoAnnotation(rs io.ReadSeeker, w io.Writer, pageNr int, v pdfcpu.Video, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddVideoAnnotation: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDVIDEO
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return errors.Errorf("pdfcpu: AddVideoAnnotation: invalid page number: %d", pageNr)
+	}
+
+	if _, err := pdfcpu.AddVideoAnnotation(ctx, pageNr, v); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddVideoAnnotationFile embeds videoFile into page pageNr of inFile using an optional poster image and writes the result to outFile.
+func AddVideoAnnotationFile(inFile, outFile string, pageNr int, rect types.Rectangle, videoFile, posterFile string, conf *model.Configuration) (err error) {
+	var f0, f1, f2, f3 *os.File
+
+	if f0, err = os.Open(videoFile); err != nil {
+		return err
+	}
+	defer f0.Close()
+
+	v := pdfcpu.Video{Reader: f0, Rect: rect, FileName: filepath.Base(videoFile)}
+
+	if posterFile != "" {
+		if f3, err = os.Open(posterFile); err != nil {
+			return err
+		}
+		defer f3.Close()
+		v.Poster = f3
+	}
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AddVideoAnnotation(f1, f2, pageNr, v, conf)
+}
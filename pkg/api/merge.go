@@ -47,17 +47,24 @@ func appendTo(rs io.ReadSeeker, fName string, ctxDest *model.Context, dividerPag
 	return pdfcpu.MergeXRefTables(fName, ctxSource, ctxDest, false, dividerPage)
 }
 
-func appendFile(fName string, ctxDest *model.Context, dividerPage bool) error {
+// appendFile appends fName to ctxDest's page tree.
+// Unless nil the returned file needs to be closed after ctxDest has been written, see Configuration.ZeroCopyStreams.
+func appendFile(fName string, ctxDest *model.Context, dividerPage bool) (io.Closer, error) {
 	f, err := os.Open(fName)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer f.Close()
 
 	if log.CLIEnabled() {
 		log.CLI.Println(fName)
 	}
-	return appendTo(f, filepath.Base(fName), ctxDest, dividerPage)
+
+	if !ctxDest.ZeroCopyStreams {
+		defer f.Close()
+		return nil, appendTo(f, filepath.Base(fName), ctxDest, dividerPage)
+	}
+
+	return f, appendTo(f, filepath.Base(fName), ctxDest, dividerPage)
 }
 
 // MergeRaw merges a sequence of PDF streams and writes the result to w.
@@ -164,8 +171,19 @@ func Merge(destFile string, inFiles []string, w io.Writer, conf *model.Configura
 		return err
 	}
 
+	var sources []io.Closer
+	defer func() {
+		for _, c := range sources {
+			c.Close()
+		}
+	}()
+
 	for _, fName := range inFiles {
-		if err := appendFile(fName, ctxDest, dividerPage); err != nil {
+		c, err := appendFile(fName, ctxDest, dividerPage)
+		if c != nil {
+			sources = append(sources, c)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -326,3 +344,89 @@ func MergeCreateZipFile(inFile1, inFile2, outFile string, conf *model.Configurat
 	err = MergeCreateZip(f1, f2, f, conf)
 	return err
 }
+
+func mergeCreateTOC(rsc []io.ReadSeeker, fileNames []string, w io.Writer, tc *pdfcpu.TOCConfig, conf *model.Configuration) error {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.MERGECREATE
+	conf.ValidationMode = model.ValidationRelaxed
+
+	ctxs := make([]*model.Context, len(rsc))
+	for i, rs := range rsc {
+		ctx, err := ReadAndValidate(rs, conf)
+		if err != nil {
+			return err
+		}
+		if i < len(fileNames) {
+			// Fallback for TOC entry titles.
+			ctx.Read.FileName = fileNames[i]
+		}
+		ctxs[i] = ctx
+	}
+
+	ctxDest, err := pdfcpu.MergeCreateTOC(ctxs, tc)
+	if err != nil {
+		return err
+	}
+
+	if conf.OptimizeBeforeWriting {
+		if err := OptimizeContext(ctxDest); err != nil {
+			return err
+		}
+	}
+
+	return WriteContext(ctxDest, w)
+}
+
+// MergeCreateTOC merges a sequence of PDF streams, generates a table of contents as configured by tc and writes the result to w.
+func MergeCreateTOC(rsc []io.ReadSeeker, w io.Writer, tc *pdfcpu.TOCConfig, conf *model.Configuration) error {
+	if len(rsc) == 0 {
+		return errors.New("pdfcpu: MergeCreateTOC: missing rsc")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: MergeCreateTOC: missing w")
+	}
+
+	return mergeCreateTOC(rsc, nil, w, tc, conf)
+}
+
+// MergeCreateTOCFile merges inFiles, generates a table of contents as configured by tc and writes the result to outFile.
+// TOC entries default to the document titles or file names.
+func MergeCreateTOCFile(inFiles []string, outFile string, tc *pdfcpu.TOCConfig, conf *model.Configuration) (err error) {
+	if len(inFiles) == 0 {
+		return errors.New("pdfcpu: MergeCreateTOCFile: missing inFiles")
+	}
+
+	rsc := make([]io.ReadSeeker, len(inFiles))
+	for i, fName := range inFiles {
+		f, err := os.Open(fName)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		rsc[i] = f
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if err1 := f.Close(); err1 != nil {
+				return
+			}
+			os.Remove(outFile)
+			return
+		}
+		if err = f.Close(); err != nil {
+			return
+		}
+	}()
+
+	logWritingTo(outFile)
+	return mergeCreateTOC(rsc, inFiles, f, tc, conf)
+}
@@ -36,6 +36,16 @@ func ImageNUpConfig(val int, desc string, conf *model.Configuration) (*model.NUp
 	return pdfcpu.ImageNUpConfig(val, desc, conf)
 }
 
+// PDFNUpGridConfig returns an NUp configuration for Nup-ing PDF files using an arbitrary grid of rows x cols.
+func PDFNUpGridConfig(rows, cols int, desc string, conf *model.Configuration) (*model.NUp, error) {
+	return pdfcpu.PDFNUpGridConfig(rows, cols, desc, conf)
+}
+
+// ImageNUpGridConfig returns an NUp configuration for Nup-ing image files using an arbitrary grid of rows x cols.
+func ImageNUpGridConfig(rows, cols int, desc string, conf *model.Configuration) (*model.NUp, error) {
+	return pdfcpu.ImageNUpGridConfig(rows, cols, desc, conf)
+}
+
 // PDFGridConfig returns a grid configuration for Grid-ing PDF files.
 func PDFGridConfig(rows, cols int, desc string, conf *model.Configuration) (*model.NUp, error) {
 	return pdfcpu.PDFGridConfig(rows, cols, desc, conf)
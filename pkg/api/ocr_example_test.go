@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"image"
+	"image/png"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+// tesseract is an OCR provider running the tesseract command line tool.
+type tesseract struct {
+	lang string // eg. "eng" or "deu+eng"
+}
+
+// Recognize pipes img as PNG into tesseract and parses the recognized words from the TSV output.
+func (t tesseract) Recognize(img image.Image) ([]pdfcpu.OCRWord, error) {
+	var in bytes.Buffer
+	if err := png.Encode(&in, img); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("tesseract", "stdin", "stdout", "-l", t.lang, "tsv")
+	cmd.Stdin = &in
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	// level page_num block_num par_num line_num word_num left top width height conf text
+	var ww []pdfcpu.OCRWord
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		ss := strings.Split(sc.Text(), "\t")
+		if len(ss) < 12 || ss[0] != "5" {
+			continue
+		}
+		var ii [4]int
+		for i := range ii {
+			if ii[i], err = strconv.Atoi(ss[6+i]); err != nil {
+				return nil, err
+			}
+		}
+		ww = append(ww, pdfcpu.OCRWord{
+			Text: ss[11],
+			Box:  image.Rect(ii[0], ii[1], ii[0]+ii[2], ii[1]+ii[3]),
+		})
+	}
+
+	return ww, sc.Err()
+}
+
+func ExampleAddTextLayerFile() {
+
+	// Make all pages of scan.pdf searchable using tesseract and write the result to out.pdf.
+	AddTextLayerFile("scan.pdf", "out.pdf", tesseract{lang: "eng"}, nil, nil)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ApplyOperations reads rs, applies the pipeline ops and writes the result to w.
+// All operations share a single read, validate and write cycle.
+func ApplyOperations(rs io.ReadSeeker, w io.Writer, ops pdfcpu.Operations, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ApplyOperations: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = ops.Cmd()
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if ctx, err = ops.Apply(ctx); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ApplyOperationsFile applies the pipeline ops to inFile and writes the result to outFile.
+func ApplyOperationsFile(inFile, outFile string, ops pdfcpu.Operations, conf *model.Configuration) error {
+	return updateUnoptimizedFile(inFile, outFile, func(rs io.ReadSeeker, w io.Writer) error {
+		return ApplyOperations(rs, w, ops, conf)
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pkg/errors"
 )
@@ -115,6 +116,48 @@ func SetPermissionsFile(inFile, outFile string, conf *model.Configuration) (err
 	return SetPermissions(f1, f2, conf)
 }
 
+// SetPermissionsAsIncrement sets the user access permissions of rws and writes out a PDF increment.
+// The file encryption key and passwords remain unchanged which is supported for AES-256 only.
+// A configuration containing the current passwords is required.
+func SetPermissionsAsIncrement(rws io.ReadWriteSeeker, conf *model.Configuration) error {
+	if rws == nil {
+		return errors.New("pdfcpu: SetPermissionsAsIncrement: missing rws")
+	}
+
+	if conf == nil {
+		return errors.New("pdfcpu: missing configuration for setting permissions")
+	}
+	conf.Cmd = model.SETPERMISSIONS
+
+	ctx, err := ReadAndValidate(rws, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetPermissionsInPlace(ctx, conf.Permissions); err != nil {
+		return err
+	}
+
+	ctx.Write.Increment = true
+	ctx.Write.Offset = ctx.Read.FileSize
+
+	return WriteIncr(ctx, rws, conf)
+}
+
+// SetPermissionsAsIncrementFile sets inFile's user access permissions and appends a PDF increment.
+// A configuration containing the current passwords is required.
+func SetPermissionsAsIncrementFile(inFile string, conf *model.Configuration) error {
+	f, err := os.OpenFile(inFile, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	logWritingTo(inFile)
+
+	return SetPermissionsAsIncrement(f, conf)
+}
+
 // GetPermissions returns the permissions for rs.
 func GetPermissions(rs io.ReadSeeker, conf *model.Configuration) (*int16, error) {
 	if rs == nil {
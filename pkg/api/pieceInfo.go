@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Page-piece dicts of pages do not survive optimization,
+// therefore these commands process the unoptimized context.
+
+// ListPieceInfo returns the private application data stored for page pageNr of rs or for the document if pageNr is 0.
+func ListPieceInfo(rs io.ReadSeeker, pageNr int, conf *model.Configuration) ([]pdfcpu.PieceInfo, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListPieceInfo: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTPIECEINFO
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListPieceInfo(ctx, pageNr)
+}
+
+// ListPieceInfoFile returns the private application data stored for page pageNr of inFile or for the document if pageNr is 0.
+func ListPieceInfoFile(inFile string, pageNr int, conf *model.Configuration) ([]pdfcpu.PieceInfo, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListPieceInfo(f, pageNr, conf)
+}
+
+// updateUnoptimized applies update to the unoptimized context read from rs and writes the result to w.
+func updateUnoptimized(rs io.ReadSeeker, w io.Writer, conf *model.Configuration, update func(ctx *model.Context) error) error {
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := update(ctx); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// updateUnoptimizedFile runs update for inFile writing to outFile or replacing inFile if outFile is empty.
+func updateUnoptimizedFile(inFile, outFile string, update func(rs io.ReadSeeker, w io.Writer) error) (err error) {
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return update(f1, f2)
+}
+
+// SetPieceInfo stores private data of app for page pageNr of rs or for the document if pageNr is 0
+// and writes the result to w.
+func SetPieceInfo(rs io.ReadSeeker, w io.Writer, pageNr int, app string, private types.Object, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetPieceInfo: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETPIECEINFO
+
+	return updateUnoptimized(rs, w, conf, func(ctx *model.Context) error {
+		return pdfcpu.SetPieceInfo(ctx, pageNr, app, private)
+	})
+}
+
+// SetPieceInfoFile stores private data of app for page pageNr of inFile or for the document if pageNr is 0
+// and writes the result to outFile.
+func SetPieceInfoFile(inFile, outFile string, pageNr int, app string, private types.Object, conf *model.Configuration) error {
+	return updateUnoptimizedFile(inFile, outFile, func(rs io.ReadSeeker, w io.Writer) error {
+		return SetPieceInfo(rs, w, pageNr, app, private, conf)
+	})
+}
+
+// RemovePieceInfo removes the private data of app stored for page pageNr of rs or for the document if pageNr is 0
+// and writes the result to w.
+func RemovePieceInfo(rs io.ReadSeeker, w io.Writer, pageNr int, app string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemovePieceInfo: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEPIECEINFO
+
+	return updateUnoptimized(rs, w, conf, func(ctx *model.Context) error {
+		ok, err := pdfcpu.RemovePieceInfo(ctx, pageNr, app)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.Errorf("pdfcpu: no private data for %s", app)
+		}
+		return nil
+	})
+}
+
+// RemovePieceInfoFile removes the private data of app stored for page pageNr of inFile or for the document if pageNr is 0
+// and writes the result to outFile.
+func RemovePieceInfoFile(inFile, outFile string, pageNr int, app string, conf *model.Configuration) error {
+	return updateUnoptimizedFile(inFile, outFile, func(rs io.ReadSeeker, w io.Writer) error {
+		return RemovePieceInfo(rs, w, pageNr, app, conf)
+	})
+}
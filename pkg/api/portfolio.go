@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// CreatePortfolio embeds the documents of p into a PDF portfolio and writes the result to w.
+// The pages read from rs serve as cover sheet, if rs is nil a cover sheet listing the documents gets generated.
+func CreatePortfolio(rs io.ReadSeeker, w io.Writer, p *model.Portfolio, conf *model.Configuration) error {
+	if w == nil {
+		return errors.New("pdfcpu: CreatePortfolio: missing w")
+	}
+
+	if p == nil {
+		return errors.New("pdfcpu: CreatePortfolio: missing portfolio")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDATTACHMENTS
+
+	if rs == nil {
+		ctx, err := pdfcpu.PortfolioCoverSheet(conf, p)
+		if err != nil {
+			return err
+		}
+		if err := ctx.AddPortfolio(p); err != nil {
+			return err
+		}
+		return WriteContext(ctx, w)
+	}
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.AddPortfolio(p); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// CreatePortfolioFile embeds the documents of p into a PDF portfolio using coverFile as cover sheet and writes the result to outFile.
+// Items without data are read from their file name and default to the base name as ID.
+// If coverFile is empty, a cover sheet listing the documents gets generated.
+func CreatePortfolioFile(coverFile, outFile string, p *model.Portfolio, conf *model.Configuration) (err error) {
+	if p == nil {
+		return errors.New("pdfcpu: CreatePortfolioFile: missing portfolio")
+	}
+
+	p1 := *p
+	p1.Items = make([]model.PortfolioItem, len(p.Items))
+	for i, it := range p.Items {
+		if it.Reader == nil {
+			f, err := os.Open(it.FileName)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			it.Reader = f
+			if it.ID == "" {
+				it.ID = filepath.Base(it.FileName)
+			}
+			if it.ModTime == nil {
+				fi, err := f.Stat()
+				if err != nil {
+					return err
+				}
+				mt := fi.ModTime()
+				it.ModTime = &mt
+			}
+		}
+		p1.Items[i] = it
+	}
+
+	var rs io.ReadSeeker
+	if coverFile != "" {
+		f1, err := os.Open(coverFile)
+		if err != nil {
+			return err
+		}
+		defer f1.Close()
+		rs = f1
+	}
+
+	f2, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			return
+		}
+		err = f2.Close()
+	}()
+
+	logWritingTo(outFile)
+
+	return CreatePortfolio(rs, f2, &p1, conf)
+}
+
+// Portfolio returns the collection of a PDF portfolio read from rs including stubs for all embedded documents.
+func Portfolio(rs io.ReadSeeker, conf *model.Configuration) (*model.Portfolio, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Portfolio: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTATTACHMENTS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.Portfolio()
+}
+
+// PortfolioFile returns the collection of a PDF portfolio read from inFile including stubs for all embedded documents.
+func PortfolioFile(inFile string, conf *model.Configuration) (*model.Portfolio, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Portfolio(f, conf)
+}
+
+// ExtractPortfolio extracts all documents of a PDF portfolio read from rs into outDir.
+func ExtractPortfolio(rs io.ReadSeeker, outDir string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExtractPortfolio: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTATTACHMENTS
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ctx.Portfolio(); err != nil {
+		return err
+	}
+
+	aa, err := ctx.ExtractAttachments(nil)
+	if err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting %d documents\n", len(aa))
+	}
+
+	return writeAttachments(aa, outDir)
+}
+
+// ExtractPortfolioFile extracts all documents of a PDF portfolio read from inFile into outDir.
+func ExtractPortfolioFile(inFile, outDir string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return ExtractPortfolio(f, outDir, conf)
+}
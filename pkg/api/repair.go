@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Repair reconstructs the cross reference table of a damaged PDF stream read from rs
+// and writes the repaired PDF stream to w.
+func Repair(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) (*pdfcpu.RepairReport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Repair: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.REPAIR
+
+	ctx, rep, err := pdfcpu.ReadAndRepair(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if log.StatsEnabled() {
+		log.Stats.Printf("XRefTable:\n%s\n", ctx)
+	}
+
+	if err = WriteContext(ctx, w); err != nil {
+		return nil, err
+	}
+
+	return rep, nil
+}
+
+// RepairFile reconstructs the cross reference table of inFile and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten.
+func RepairFile(inFile, outFile string, conf *model.Configuration) (rep *pdfcpu.RepairReport, err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return Repair(f1, f2, conf)
+}
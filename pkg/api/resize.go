@@ -23,6 +23,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 )
 
@@ -106,3 +107,60 @@ func ResizeFile(inFile, outFile string, selectedPages []string, resize *model.Re
 
 	return Resize(f1, f2, selectedPages, resize, conf)
 }
+
+// ResizePages scales selected pages of rs including all page boundaries and annotations to target and writes the result to w.
+func ResizePages(rs io.ReadSeeker, w io.Writer, selectedPages []string, target *types.Dim, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ResizePages: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.RESIZE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.ResizePages(ctx, pages, target); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ResizePagesFile scales selected pages of inFile including all page boundaries and annotations to target and writes the result to outFile.
+func ResizePagesFile(inFile, outFile string, selectedPages []string, target *types.Dim, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(outFile); err != nil {
+		f1.Close()
+		return err
+	}
+	logWritingTo(outFile)
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		err = f1.Close()
+	}()
+
+	return ResizePages(f1, f2, selectedPages, target, conf)
+}
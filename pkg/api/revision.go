@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Revisions returns the revision history of rs, starting with the original document.
+func Revisions(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.RevisionInfo, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Revisions: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.ValidationMode = model.ValidationRelaxed
+
+	return pdfcpu.Revisions(rs, conf)
+}
+
+// RevisionsFile returns the revision history of inFile, starting with the original document.
+func RevisionsFile(inFile string, conf *model.Configuration) ([]pdfcpu.RevisionInfo, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Revisions(f, conf)
+}
+
+// ExtractRevision writes rs as of revision n to w.
+// The revision is written byte for byte preserving any signatures.
+func ExtractRevision(rs io.ReadSeeker, w io.Writer, n int) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExtractRevision: missing rs")
+	}
+
+	r, err := pdfcpu.Revision(rs, n)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// ExtractRevisionFile writes inFile as of revision n to outFile.
+func ExtractRevisionFile(inFile, outFile string, n int) (err error) {
+	f1, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f1.Close()
+
+	f2, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f2.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	logWritingTo(outFile)
+
+	return ExtractRevision(f1, f2, n)
+}
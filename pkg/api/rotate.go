@@ -93,3 +93,55 @@ func RotateFile(inFile, outFile string, rotation int, selectedPages []string, co
 
 	return Rotate(f1, f2, rotation, selectedPages, conf)
 }
+
+// NormalizeRotation bakes the page rotation of rs into the page content and writes the result to w.
+func NormalizeRotation(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: NormalizeRotation: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ROTATE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.NormalizeRotation(ctx); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// NormalizeRotationFile bakes the page rotation of inFile into the page content and writes the result to outFile.
+func NormalizeRotationFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(outFile); err != nil {
+		f1.Close()
+		return err
+	}
+	logWritingTo(outFile)
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		err = f1.Close()
+	}()
+
+	return NormalizeRotation(f1, f2, conf)
+}
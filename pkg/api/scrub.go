@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ScrubMetadata removes or anonymizes metadata of rs according to opts and writes the result to w.
+// It returns a list of all scrubbed items.
+func ScrubMetadata(rs io.ReadSeeker, w io.Writer, opts pdfcpu.ScrubOptions, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ScrubMetadata: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SCRUBMETADATA
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := pdfcpu.ScrubMetadata(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if log.CLIEnabled() {
+		for _, s := range ss {
+			log.CLI.Printf("scrubbed %s\n", s)
+		}
+	}
+
+	if err := Write(ctx, w, conf); err != nil {
+		return nil, err
+	}
+
+	return ss, nil
+}
+
+// ScrubMetadataFile removes or anonymizes metadata of inFile according to opts and writes the result to outFile.
+// It returns a list of all scrubbed items.
+func ScrubMetadataFile(inFile, outFile string, opts pdfcpu.ScrubOptions, conf *model.Configuration) (ss []string, err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			ss = nil
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ScrubMetadata(f1, f2, opts, conf)
+}
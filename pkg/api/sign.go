@@ -18,10 +18,12 @@ package api
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/sign"
 	"github.com/pkg/errors"
 )
 
@@ -178,3 +180,124 @@ func ValidateSignaturesFile(inFile string, all, full bool, conf *model.Configura
 
 	return digest(signValidResults, full), nil
 }
+
+// AddDocTimeStamp appends an increment to rws containing a document time-stamp (ETSI.RFC3161) obtained from tsa.
+// A document time-stamp extends the validity of existing signatures (PAdES-LTA).
+// contentsSize is the number of bytes reserved for the time-stamp token, 0 for pdfcpu.DocTimeStampContentsSize.
+func AddDocTimeStamp(rws io.ReadWriteSeeker, tsa sign.TSAClient, contentsSize int, conf *model.Configuration) error {
+	if rws == nil {
+		return errors.New("pdfcpu: AddDocTimeStamp: missing rws")
+	}
+
+	if tsa == nil {
+		return errors.New("pdfcpu: AddDocTimeStamp: missing tsa")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDDOCTIMESTAMP
+
+	ctx, err := ReadAndValidate(rws, conf)
+	if err != nil {
+		return err
+	}
+
+	if *ctx.HeaderVersion < model.V14 {
+		return errors.New("pdfcpu: Incremental writing unsupported for PDF version < V1.4 (Hint: Use pdfcpu optimize then try again)")
+	}
+
+	sigDict, err := pdfcpu.PrepareDocTimeStamp(ctx, contentsSize)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteIncr(ctx, rws, conf); err != nil {
+		return err
+	}
+
+	return pdfcpu.FinalizeDocTimeStamp(ctx, rws, *sigDict, tsa)
+}
+
+// AddDocTimeStampFile appends a document time-stamp obtained from tsa to inFile and writes the result to outFile.
+// If outFile is empty inFile gets updated in place.
+func AddDocTimeStampFile(inFile, outFile string, tsa sign.TSAClient, contentsSize int, conf *model.Configuration) (err error) {
+	if outFile != "" && outFile != inFile {
+		if _, err := pdfcpu.CopyFile(inFile, outFile, true); err != nil {
+			return err
+		}
+		inFile = outFile
+	}
+
+	logWritingTo(inFile)
+
+	f, err := os.OpenFile(inFile, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := f.Close(); err == nil {
+			err = err1
+		}
+	}()
+
+	return AddDocTimeStamp(f, tsa, contentsSize, conf)
+}
+
+// AddValidationInfo appends an increment to rws embedding the certificates of all signatures
+// and their revocation information obtained from fetcher into the Document Security Store (PAdES-LTV).
+func AddValidationInfo(rws io.ReadWriteSeeker, fetcher sign.RevocationFetcher, conf *model.Configuration) error {
+	if rws == nil {
+		return errors.New("pdfcpu: AddValidationInfo: missing rws")
+	}
+
+	if fetcher == nil {
+		return errors.New("pdfcpu: AddValidationInfo: missing fetcher")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDVALIDATIONINFO
+
+	ctx, err := ReadAndValidate(rws, conf)
+	if err != nil {
+		return err
+	}
+
+	if *ctx.HeaderVersion < model.V14 {
+		return errors.New("pdfcpu: Incremental writing unsupported for PDF version < V1.4 (Hint: Use pdfcpu optimize then try again)")
+	}
+
+	if err := pdfcpu.AddValidationInfo(ctx, fetcher); err != nil {
+		return err
+	}
+
+	return WriteIncr(ctx, rws, conf)
+}
+
+// AddValidationInfoFile embeds validation information obtained from fetcher for all signatures of inFile
+// and writes the result to outFile.
+// If outFile is empty inFile gets updated in place.
+func AddValidationInfoFile(inFile, outFile string, fetcher sign.RevocationFetcher, conf *model.Configuration) (err error) {
+	if outFile != "" && outFile != inFile {
+		if _, err := pdfcpu.CopyFile(inFile, outFile, true); err != nil {
+			return err
+		}
+		inFile = outFile
+	}
+
+	logWritingTo(inFile)
+
+	f, err := os.OpenFile(inFile, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := f.Close(); err == nil {
+			err = err1
+		}
+	}()
+
+	return AddValidationInfo(f, fetcher, conf)
+}
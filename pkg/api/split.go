@@ -317,3 +317,95 @@ func SplitByPageNrFile(inFile, outDir string, pageNrs []int, conf *model.Configu
 
 	return SplitByPageNr(f, outDir, filepath.Base(inFile), pageNrs, conf)
 }
+
+// SplitByBookmarks splits rs along the outline entries of level and writes one file per entry named after its title into outDir.
+func SplitByBookmarks(rs io.ReadSeeker, outDir string, level int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SplitByBookmarks: missing rs")
+	}
+
+	ctx, err := context(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	bss, err := pdfcpu.SplitByBookmarks(ctx, level)
+	if err != nil {
+		return err
+	}
+
+	for _, bs := range bss {
+		var b bytes.Buffer
+		if err := WriteContext(bs.Ctx, &b); err != nil {
+			return err
+		}
+		outFile := filepath.Join(outDir, bs.FileName+".pdf")
+		logWritingTo(outFile)
+		if err := pdfcpu.WriteReader(outFile, &b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SplitByBookmarksFile splits inFile along the outline entries of level and writes one file per entry named after its title into outDir.
+func SplitByBookmarksFile(inFile, outDir string, level int, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("splitting %s along bookmarks of level %d into %s ...\n", inFile, level, outDir)
+	}
+
+	return SplitByBookmarks(f, outDir, level, conf)
+}
+
+// SplitBySeparator splits rs at separator pages matching sc and writes the resulting files into outDir.
+func SplitBySeparator(rs io.ReadSeeker, outDir, fileName string, sc *pdfcpu.SeparatorCriteria, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SplitBySeparator: missing rs")
+	}
+
+	ctx, err := context(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pss, err := pdfcpu.SplitBySeparator(ctx, sc)
+	if err != nil {
+		return err
+	}
+
+	for _, ps := range pss {
+		var b bytes.Buffer
+		if err := WriteContext(ps.Ctx, &b); err != nil {
+			return err
+		}
+		outFile := splitOutPath(outDir, fileName, false, ps.From, ps.Thru)
+		logWritingTo(outFile)
+		if err := pdfcpu.WriteReader(outFile, &b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SplitBySeparatorFile splits inFile at separator pages matching sc and writes the resulting files into outDir.
+func SplitBySeparatorFile(inFile, outDir string, sc *pdfcpu.SeparatorCriteria, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("splitting %s along separator pages into %s ...\n", inFile, outDir)
+	}
+
+	return SplitBySeparator(f, outDir, inFile, sc, conf)
+}
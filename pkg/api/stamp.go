@@ -32,6 +32,7 @@ func WatermarkContext(ctx *model.Context, selectedPages types.IntSet, wm *model.
 }
 
 // AddWatermarksMap adds watermarks in m to corresponding pages in rs and writes the result to w.
+// All watermarks in m need to be either stamps or watermarks.
 func AddWatermarksMap(rs io.ReadSeeker, w io.Writer, m map[int]*model.Watermark, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: AddWatermarksMap: missing rs")
@@ -100,6 +101,7 @@ func AddWatermarksMapFile(inFile, outFile string, m map[int]*model.Watermark, co
 }
 
 // AddWatermarksSliceMap adds watermarks in m to corresponding pages in rs and writes the result to w.
+// All watermarks in m need to be either stamps or watermarks.
 func AddWatermarksSliceMap(rs io.ReadSeeker, w io.Writer, m map[int][]*model.Watermark, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: AddWatermarksSliceMap: missing rs")
@@ -311,6 +313,57 @@ func RemoveWatermarksFile(inFile, outFile string, selectedPages []string, conf *
 	return RemoveWatermarks(f1, f2, selectedPages, conf)
 }
 
+// RemoveWatermarkByID removes the watermarks added with Watermark.ID id from rs and writes the result to w.
+func RemoveWatermarkByID(rs io.ReadSeeker, w io.Writer, id string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveWatermarkByID: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEWATERMARKS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.RemoveWatermarkByID(ctx, id); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// UpdateWatermarkByID replaces the watermarks added with Watermark.ID id in rs by wm and writes the result to w.
+func UpdateWatermarkByID(rs io.ReadSeeker, w io.Writer, id string, wm *model.Watermark, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: UpdateWatermarkByID: missing rs")
+	}
+
+	if wm == nil {
+		return errors.New("pdfcpu: missing watermark configuration")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDWATERMARKS
+	conf.OptimizeDuplicateContentStreams = false
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.UpdateWatermarkByID(ctx, id, wm); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
 // HasWatermarks checks rs for watermarks.
 func HasWatermarks(rs io.ReadSeeker, conf *model.Configuration) (bool, error) {
 	if rs == nil {
@@ -427,6 +480,23 @@ func PDFMultiWatermarkForReadSeeker(rs io.ReadSeeker, startPageNrSrc, startPageN
 	return wm, nil
 }
 
+// PDFMultiWatermarkRangeForReadSeeker returns a PDF watermark configuration.
+// Define a source PDF watermark/stamp sequence using rs from page startPageNrSrc thru endPageNrSrc, 0 for the last page of rs.
+// Apply this sequence to the destination PDF file starting at page startPageNrDest for selected pages.
+// Destination pages beyond the sequence are stamped with page fallbackPageNrSrc of rs,
+// with the last page of the sequence for 0 or not at all for model.PdfNoFallback.
+func PDFMultiWatermarkRangeForReadSeeker(rs io.ReadSeeker, startPageNrSrc, endPageNrSrc, startPageNrDest, fallbackPageNrSrc int, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	wm, err := PDFMultiWatermarkForReadSeeker(rs, startPageNrSrc, startPageNrDest, desc, onTop, update, u)
+	if err != nil {
+		return nil, err
+	}
+
+	wm.PdfMultiEndPageNrSrc = endPageNrSrc
+	wm.PdfMultiFallbackPageNr = fallbackPageNrSrc
+
+	return wm, nil
+}
+
 // AddTextWatermarksFile adds text stamps/watermarks to all selected pages of inFile and writes the result to outFile.
 func AddTextWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, text, desc string, conf *model.Configuration) error {
 	unit := types.POINTS
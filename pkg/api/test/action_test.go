@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestOpenAction(t *testing.T) {
+	msg := "TestOpenAction"
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile := filepath.Join(outDir, "openAction.pdf")
+
+	// Open at page 5 fit-width.
+	dest := model.Destination{Typ: model.DestFitH, PageNr: 5, Top: 792}
+	if err := api.SetOpenActionFile(inFile, outFile, model.NewGoToAction(dest), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	a, err := api.OpenActionFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if a == nil || a.Type != "GoTo" || a.Dest == nil || *a.Dest != dest {
+		t.Fatalf("%s: want %s, got %v\n", msg, model.NewGoToAction(dest), a)
+	}
+
+	// Replace with a named action.
+	if err := api.SetOpenActionFile(outFile, "", model.NewNamedAction("LastPage"), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if a, err = api.OpenActionFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if a == nil || a.Type != "Named" || a.Name != "LastPage" {
+		t.Fatalf("%s: want Named LastPage, got %v\n", msg, a)
+	}
+
+	if err := api.RemoveOpenActionFile(outFile, "", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if a, err = api.OpenActionFile(outFile, nil); err != nil || a != nil {
+		t.Fatalf("%s: want no open action, got %v %v\n", msg, a, err)
+	}
+
+	// Invalid actions
+	for _, a := range []model.Action{
+		model.NewGoToAction(model.Destination{Typ: model.DestFit, PageNr: 24}),
+		model.NewGoToAction(model.Destination{Typ: model.DestXYZ, PageNr: 1, Zoom: -1}),
+		model.NewGoToAction(model.Destination{Typ: model.DestFitR, PageNr: 1, Left: 100, Right: 50, Top: 100}),
+		model.NewNamedAction("Explode"),
+		{Type: "JavaScript"},
+	} {
+		if err := api.SetOpenActionFile(inFile, outFile, a, nil); err == nil {
+			t.Fatalf("%s: want error for %s\n", msg, a)
+		}
+	}
+}
+
+func TestPageActions(t *testing.T) {
+	msg := "TestPageActions"
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile := filepath.Join(outDir, "pageActions.pdf")
+
+	open := model.NewGoToAction(model.Destination{Typ: model.DestXYZ, PageNr: 3, Left: 0, Top: 500, Zoom: 1.5})
+	if err := api.SetPageActionFile(inFile, outFile, 2, model.PageActionOpen, open, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SetPageActionFile(outFile, "", 2, model.PageActionClose, model.NewNamedAction("FirstPage"), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m, err := api.PageActionsFile(outFile, 2, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(m) != 2 || m[model.PageActionOpen].String() != open.String() || m[model.PageActionClose].Name != "FirstPage" {
+		t.Fatalf("%s: unexpected page actions: %v\n", msg, m)
+	}
+
+	if err := api.RemovePageActionFile(outFile, "", 2, model.PageActionOpen, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if m, err = api.PageActionsFile(outFile, 2, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, ok := m[model.PageActionOpen]; ok || len(m) != 1 {
+		t.Fatalf("%s: unexpected page actions: %v\n", msg, m)
+	}
+
+	if err := api.SetPageActionFile(inFile, outFile, 2, "X", open, nil); err == nil {
+		t.Fatalf("%s: want error for invalid trigger\n", msg)
+	}
+	if err := api.SetPageActionFile(inFile, outFile, 24, model.PageActionOpen, open, nil); err == nil {
+		t.Fatalf("%s: want error for invalid page\n", msg)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -1016,6 +1018,110 @@ func TestPolygonAnnotation(t *testing.T) {
 	}
 }
 
+func TestStyledBorderAnnotations(t *testing.T) {
+	msg := "TestStyledBorderAnnotations"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "StyledBorderAnnotations.pdf")
+
+	squareAnn := model.NewSquareAnnotation(
+		*types.NewRectangle(30, 30, 130, 90), // rect
+		0,                                    // apObjNr
+		"Dashed rounded square",              // contents
+		"IDDashedSquare",                     // id
+		"",                                   // modDate
+		0,                                    // f
+		&color.Red,                           // col
+		"Title1",                             // title
+		nil,                                  // popupIndRef
+		nil,                                  // ca
+		"",                                   // rc
+		"",                                   // subject
+		&color.LightGray,                     // fillCol
+		0, 0, 0, 0,                           // margins
+		2,              // borderWidth
+		model.BSDashed, // borderStyle
+		false,          // cloudyBorder
+		0)              // cloudyBorderIntensity
+	squareAnn.BorderDash = []float64{6, 3}
+	squareAnn.BorderRadX, squareAnn.BorderRadY = 10, 10
+
+	circleAnn := model.NewCircleAnnotation(
+		*types.NewRectangle(150, 30, 270, 110), // rect
+		0,                                      // apObjNr
+		"Cloudy circle",                        // contents
+		"IDCloudyCircle",                       // id
+		"",                                     // modDate
+		0,                                      // f
+		&color.Blue,                            // col
+		"Title1",                               // title
+		nil,                                    // popupIndRef
+		nil,                                    // ca
+		"",                                     // rc
+		"",                                     // subject
+		nil,                                    // fillCol
+		5, 5, 5, 5,                             // margins
+		1,             // borderWidth
+		model.BSSolid, // borderStyle
+		true,          // cloudyBorder
+		2)             // cloudyBorderIntensity
+
+	m := map[int][]model.AnnotationRenderer{1: {squareAnn, circleAnn}}
+	if err := api.AddAnnotationsMapFile(inFile, outFile, m, nil, false); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	a, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	found := 0
+	for _, o := range a {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		nm := d1.StringEntry("NM")
+		if nm == nil || (*nm != "IDDashedSquare" && *nm != "IDCloudyCircle") {
+			continue
+		}
+		found++
+		if _, ok := d1.Find("AP"); !ok {
+			t.Fatalf("%s: %s: missing appearance stream\n", msg, *nm)
+		}
+		bs := d1.DictEntry("BS")
+		if bs == nil {
+			t.Fatalf("%s: %s: missing border style\n", msg, *nm)
+		}
+		if *nm == "IDDashedSquare" {
+			if bs.ArrayEntry("D") == nil {
+				t.Fatalf("%s: %s: missing dash array\n", msg, *nm)
+			}
+			if a := d1.ArrayEntry("Border"); len(a) != 4 {
+				t.Fatalf("%s: %s: want border array with radii and dash array, got %v\n", msg, *nm, a)
+			}
+		} else if d1.DictEntry("BE") == nil {
+			t.Fatalf("%s: %s: missing border effect\n", msg, *nm)
+		}
+	}
+	if found != 2 {
+		t.Fatalf("%s: want 2 annotations, got %d\n", msg, found)
+	}
+}
+
 func TestLineAnnotation(t *testing.T) {
 	msg := "TestLineAnnotation"
 
@@ -1094,3 +1200,174 @@ func TestCaretAnnotation(t *testing.T) {
 		t.Fatalf("%s add: %v\n", msg, err)
 	}
 }
+
+func TestMeasurementAnnotations(t *testing.T) {
+	msg := "TestMeasurementAnnotations"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "MeasurementAnnotations.pdf")
+
+	// 1 pt = 0.5 m
+	measure := model.NewScaleMeasure("1 pt = 0.5 m", "m", 0.5)
+	measure.D = append(measure.D, model.NumberFormat{Unit: "cm", Factor: 100, Denominator: 10})
+	measureDict, err := measure.Dict()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	lineIntent := model.IntentLineDimension
+	lineAnn := model.NewLineAnnotation(
+		*types.NewRectangle(100, 100, 200, 200), // rect
+		0,                                       // apObjNr
+		"",                                      // contents
+		"IDDimLine",                             // id
+		"",                                      // modDate
+		0,                                       // f
+		&color.Black,                            // col
+		"",                                      // title
+		nil,                                     // popupIndRef
+		nil,                                     // ca
+		"",                                      // rc
+		"",                                      // subject
+		types.NewPoint(100, 100),                // P1
+		types.NewPoint(130, 140),                // P2
+		nil,                                     // start lineEndingStyle
+		nil,                                     // end lineEndingStyle
+		0,                                       // leader line length
+		0,                                       // leader line offset
+		0,                                       // leader line extension length
+		&lineIntent,                             // intent
+		measureDict,                             // measure
+		false,                                   // caption
+		false,                                   // caption position top
+		0,                                       // caption offset X
+		0,                                       // caption offset Y
+		nil,                                     // fillCol
+		1,                                       // borderWidth
+		model.BSSolid)                           // borderStyle
+
+	polygonIntent := model.IntentPolygonDimension
+	polygonAnn := model.NewPolygonAnnotation(
+		*types.NewRectangle(300, 300, 400, 400), // rect
+		0,                                       // apObjNr
+		"",                                      // contents
+		"IDDimPolygon",                          // id
+		"",                                      // modDate
+		0,                                       // f
+		&color.Black,                            // col
+		"",                                      // title
+		nil,                                     // popupIndRef
+		nil,                                     // ca
+		"",                                      // rc
+		"",                                      // subject
+		types.NewNumberArray(300, 300, 400, 300, 400, 400, 300, 400), // vertices
+		nil,            // path
+		&polygonIntent, // intent
+		measureDict,    // measure
+		nil,            // fillCol
+		1,              // borderWidth
+		model.BSSolid,  // borderStyle
+		false,          // cloudyBorder
+		0)              // cloudyBorderIntensity
+
+	m := map[int][]model.AnnotationRenderer{1: {lineAnn, polygonAnn}}
+	if err := api.AddAnnotationsMapFile(inFile, outFile, m, nil, false); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	mm, err := api.MeasurementsFile(outFile, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(mm[1]) != 2 {
+		t.Fatalf("%s: want 2 measurements, got %d\n", msg, len(mm[1]))
+	}
+
+	for _, ms := range mm[1] {
+		switch ms.Subtype {
+		case "Line":
+			// 50 pt
+			if ms.Length != 25 || ms.FormattedLength() != "25 m" {
+				t.Fatalf("%s: line: want 25 m, got %f (%s)\n", msg, ms.Length, ms.FormattedLength())
+			}
+		case "Polygon":
+			// 100 x 100 pt
+			if ms.Length != 200 || ms.Area != 2500 {
+				t.Fatalf("%s: polygon: want 200 m/2500 sq m, got %f/%f\n", msg, ms.Length, ms.Area)
+			}
+			if ms.FormattedArea() != "2,500 sq m" {
+				t.Fatalf("%s: polygon: want 2,500 sq m, got %s\n", msg, ms.FormattedArea())
+			}
+		default:
+			t.Fatalf("%s: unexpected subtype: %s\n", msg, ms.Subtype)
+		}
+	}
+
+	if got := model.FormatMeasurement(1.255, measure.D); got != "1 m 25.5 cm" {
+		t.Fatalf("%s: want 1 m 25.5 cm, got %s\n", msg, got)
+	}
+}
+
+func exportedAnnotations(t *testing.T, msg, inFile string) ([]byte, *pdfcpu.AnnotationsJSON) {
+	t.Helper()
+
+	fileJSON := filepath.Join(outDir, filepath.Base(inFile)+".json")
+	if err := api.ExportAnnotationsFile(inFile, fileJSON, nil, nil); err != nil {
+		t.Fatalf("%s export: %v\n", msg, err)
+	}
+
+	bb, err := os.ReadFile(fileJSON)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	aj := &pdfcpu.AnnotationsJSON{}
+	if err := json.Unmarshal(bb, aj); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Ignore the header.
+	bb, err = json.Marshal([]any{aj.Pages, aj.Streams})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	return bb, aj
+}
+
+func TestExportImportAnnotationsJSON(t *testing.T) {
+	msg := "TestExportImportAnnotationsJSON"
+
+	for _, fn := range []string{"annotTest.pdf", "text_annotations.pdf"} {
+		inFile := filepath.Join(inDir, fn)
+		bb, aj := exportedAnnotations(t, msg, inFile)
+
+		if len(aj.Pages) == 0 {
+			t.Fatalf("%s %s: no annotations exported\n", msg, fn)
+		}
+		for _, pa := range aj.Pages {
+			for _, a := range pa.Annotations {
+				if a.Type == "Stamp" && a.AP == nil {
+					t.Fatalf("%s %s: stamp without appearance\n", msg, fn)
+				}
+			}
+		}
+
+		// Import into the same document stripped of all annotations.
+		outFile := filepath.Join(outDir, fn)
+		if err := api.RemoveAnnotationsFile(inFile, outFile, nil, nil, nil, nil, false); err != nil {
+			t.Fatalf("%s %s remove: %v\n", msg, fn, err)
+		}
+		if err := api.ImportAnnotationsFile(outFile, outFile+".json", "", nil); err != nil {
+			t.Fatalf("%s %s import: %v\n", msg, fn, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+
+		bb1, _ := exportedAnnotations(t, msg, outFile)
+		if !bytes.Equal(bb, bb1) {
+			t.Fatalf("%s %s: annotations changed on round trip\n", msg, fn)
+		}
+	}
+}
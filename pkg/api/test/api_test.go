@@ -17,12 +17,17 @@ limitations under the License.
 package test
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -226,6 +231,67 @@ func TestManipulateContext(t *testing.T) {
 	}
 }
 
+func TestWriteContextFS(t *testing.T) {
+	msg := "TestWriteContextFS"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: ReadContextFile %s: %v\n", msg, inFile, err)
+	}
+
+	// Write to memory.
+	memFS := model.NewMemFS()
+	if err := api.WriteContextFS(ctx, memFS, "abc.pdf"); err != nil {
+		t.Fatalf("%s: WriteContextFS: %v\n", msg, err)
+	}
+	bb, err := memFS.ReadFile("abc.pdf")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.Validate(bytes.NewReader(bb), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Write two files into a zip archive.
+	var buf bytes.Buffer
+	zipFS := model.NewZipFS(&buf)
+	for _, fn := range []string{"a.pdf", "b.pdf"} {
+		ctx, err := api.ReadContextFile(inFile)
+		if err != nil {
+			t.Fatalf("%s: ReadContextFile %s: %v\n", msg, inFile, err)
+		}
+		if err := api.WriteContextFS(ctx, zipFS, fn); err != nil {
+			t.Fatalf("%s: WriteContextFS: %v\n", msg, err)
+		}
+	}
+	if err := zipFS.Close(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(zr.File) != 2 {
+		t.Fatalf("%s: want 2 zip entries, got %d\n", msg, len(zr.File))
+	}
+	for _, zf := range zr.File {
+		rc, err := zf.Open()
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		bb, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := api.Validate(bytes.NewReader(bb), nil); err != nil {
+			t.Fatalf("%s: %s: %v\n", msg, zf.Name, err)
+		}
+	}
+}
+
 func TestInfo(t *testing.T) {
 	msg := "TestInfo"
 	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
@@ -244,3 +310,432 @@ func TestInfo(t *testing.T) {
 		t.Fatalf("%s: missing Info\n", msg)
 	}
 }
+
+func TestProgress(t *testing.T) {
+	msg := "TestProgress"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	type progress struct{ calls, done, total int }
+	got := map[string]*progress{}
+
+	conf := model.NewDefaultConfiguration()
+	conf.Progress = func(stage string, done, total int) {
+		p, ok := got[stage]
+		if !ok {
+			p = &progress{}
+			got[stage] = p
+		}
+		// Each stage may run repeatedly but must not go backwards within a run.
+		if (done < p.done && done != 1) || done > total {
+			t.Errorf("%s: %s: unexpected progress %d/%d after %d\n", msg, stage, done, total, p.done)
+		}
+		p.calls++
+		p.done, p.total = done, total
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	if err := api.Optimize(f, io.Discard, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ExtractContent(f, outDir, "progress", nil, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, stage := range []string{model.StageRead, model.StageOptimize, model.StageWrite, model.StageExtract} {
+		p, ok := got[stage]
+		if !ok {
+			t.Fatalf("%s: missing progress for stage %s\n", msg, stage)
+		}
+		if p.done != p.total {
+			t.Fatalf("%s: %s: incomplete progress %d/%d\n", msg, stage, p.done, p.total)
+		}
+	}
+
+	if got[model.StageExtract].total != 52 {
+		t.Fatalf("%s: want 52 extracted pages, got %d\n", msg, got[model.StageExtract].total)
+	}
+}
+
+func TestStructuredLogging(t *testing.T) {
+	msg := "TestStructuredLogging"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	var buf bytes.Buffer
+	conf := model.NewDefaultConfiguration()
+	conf.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	if err := api.Optimize(f, io.Discard, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	events := map[string]map[string]any{}
+	ops := map[string]int{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var m map[string]any
+		if err := dec.Decode(&m); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		s := m["msg"].(string)
+		events[s] = m
+		if s == "pdfcpu: end" {
+			ops[m["op"].(string)]++
+		}
+	}
+
+	for _, s := range []string{"pdfcpu: read", "pdfcpu: optimized", "pdfcpu: written"} {
+		if _, ok := events[s]; !ok {
+			t.Fatalf("%s: missing event %q\n", msg, s)
+		}
+	}
+	if n := events["pdfcpu: read"]["objects"].(float64); n == 0 {
+		t.Fatalf("%s: missing object count\n", msg)
+	}
+	for _, op := range []string{"read", "validate", "optimize", "write"} {
+		if ops[op] == 0 {
+			t.Fatalf("%s: missing trace for %s\n", msg, op)
+		}
+	}
+}
+
+type testMetrics struct {
+	mu       sync.Mutex
+	counters map[string]int64
+	timers   map[string]time.Duration
+}
+
+func (m *testMetrics) Count(name string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[name] += n
+}
+
+func (m *testMetrics) Observe(name string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timers[name] += d
+}
+
+func TestMetrics(t *testing.T) {
+	msg := "TestMetrics"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	m := &testMetrics{counters: map[string]int64{}, timers: map[string]time.Duration{}}
+	conf := model.NewDefaultConfiguration()
+	conf.Metrics = m
+	conf.DecodeAllStreams = true
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	if err := api.Optimize(f, &buf, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, name := range []string{model.MetricObjectsParsed, model.MetricStreamsDecoded} {
+		if m.counters[name] == 0 {
+			t.Fatalf("%s: missing counter %s\n", msg, name)
+		}
+	}
+	if got := m.counters[model.MetricBytesWritten]; got != int64(buf.Len()) {
+		t.Fatalf("%s: bytes written want:%d got:%d\n", msg, buf.Len(), got)
+	}
+	for _, name := range []string{model.MetricReadDuration, model.MetricValidateDuration, model.MetricOptimizeDuration, model.MetricWriteDuration} {
+		if _, ok := m.timers[name]; !ok {
+			t.Fatalf("%s: missing timer %s\n", msg, name)
+		}
+	}
+}
+
+func TestObjectStreamWritePolicy(t *testing.T) {
+	msg := "TestObjectStreamWritePolicy"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+
+	write := func(opts func(wc *model.WriteContext)) []byte {
+		t.Helper()
+		ctx, err := api.ReadContextFile(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		opts(ctx.Write)
+		var buf bytes.Buffer
+		if err := api.WriteContext(ctx, &buf); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := api.Validate(bytes.NewReader(buf.Bytes()), nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		return buf.Bytes()
+	}
+
+	bb := write(func(wc *model.WriteContext) {})
+	objStms := bytes.Count(bb, []byte("/ObjStm"))
+	if objStms == 0 || !bytes.Contains(bb, []byte("/XRef")) {
+		t.Fatalf("%s: missing xref stream or object streams\n", msg)
+	}
+
+	// Force classic cross reference table.
+	bb = write(func(wc *model.WriteContext) { wc.XRefMode = model.XRefModeTable })
+	if bytes.Contains(bb, []byte("/XRef")) || bytes.Contains(bb, []byte("/ObjStm")) {
+		t.Fatalf("%s: unexpected xref stream or object stream\n", msg)
+	}
+	if !bytes.Contains(bb, []byte("\nxref")) || !bytes.Contains(bb, []byte("trailer")) {
+		t.Fatalf("%s: missing xref table\n", msg)
+	}
+
+	// Limit objects per object stream.
+	bb = write(func(wc *model.WriteContext) { wc.ObjStmMaxObjects = 2 })
+	if n := bytes.Count(bb, []byte("/ObjStm")); n <= objStms {
+		t.Fatalf("%s: want more than %d object streams, got %d\n", msg, objStms, n)
+	}
+
+	// Exclude dicts from object streams.
+	bb = write(func(wc *model.WriteContext) { wc.ObjStmTypes = model.ObjStmArray | model.ObjStmScalar })
+	if n := bytes.Count(bb, []byte("/ObjStm")); n >= objStms {
+		t.Fatalf("%s: want less than %d object streams, got %d\n", msg, objStms, n)
+	}
+}
+
+func TestDeterministicOutput(t *testing.T) {
+	msg := "TestDeterministicOutput"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := []byte("reproducible0001")
+
+	optimize := func() []byte {
+		t.Helper()
+		conf := model.NewDefaultConfiguration()
+		conf.Deterministic = true
+		conf.DeterministicTime = ts
+		conf.FileID = id
+		f, err := os.Open(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		defer f.Close()
+		var buf bytes.Buffer
+		if err := api.Optimize(f, &buf, conf); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		return buf.Bytes()
+	}
+
+	bb := optimize()
+	for i := 0; i < 3; i++ {
+		if !bytes.Equal(bb, optimize()) {
+			t.Fatalf("%s: output not reproducible\n", msg)
+		}
+	}
+
+	if !bytes.Contains(bb, []byte("/ModDate("+types.DateString(ts)+")")) {
+		t.Fatalf("%s: missing fixed ModDate\n", msg)
+	}
+	if !bytes.Contains(bb, []byte(fmt.Sprintf("<%x>]", id))) {
+		t.Fatalf("%s: missing caller provided ID\n", msg)
+	}
+}
+
+func TestMaxMemory(t *testing.T) {
+	msg := "TestMaxMemory"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	optimize := func(maxMemory int64) ([]byte, *testMetrics) {
+		t.Helper()
+		m := &testMetrics{counters: map[string]int64{}, timers: map[string]time.Duration{}}
+		conf := model.NewDefaultConfiguration()
+		conf.Deterministic = true
+		conf.FileID = []byte("reproducible0001")
+		conf.MaxMemory = maxMemory
+		conf.Metrics = m
+		f, err := os.Open(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		defer f.Close()
+		var buf bytes.Buffer
+		if err := api.Optimize(f, &buf, conf); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		return buf.Bytes(), m
+	}
+
+	want, m := optimize(0)
+	if m.counters[model.MetricStreamsSpilled] != 0 {
+		t.Fatalf("%s: no streams should be spilled without budget\n", msg)
+	}
+
+	// Spilled streams are transparent to processing.
+	got, m := optimize(1024)
+	if m.counters[model.MetricStreamsSpilled] == 0 {
+		t.Fatalf("%s: streams should be spilled\n", msg)
+	}
+	if !bytes.Equal(want, got) {
+		t.Fatalf("%s: output differs when spilling streams\n", msg)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	conf := model.NewDefaultConfiguration()
+	conf.MaxMemory = 1024
+	ctx, err := api.ReadContext(f, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx.Read.StreamMemory > conf.MaxMemory {
+		t.Fatalf("%s: stream memory %d exceeds budget\n", msg, ctx.Read.StreamMemory)
+	}
+	if ctx.SpillFile == nil || ctx.SpillFile.Size() == 0 {
+		t.Fatalf("%s: missing spill file\n", msg)
+	}
+
+	// Dereferencing loads spilled stream data.
+	for objNr, entry := range ctx.Table {
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || !sd.Spilled() {
+			continue
+		}
+		sd1, _, err := ctx.DereferenceStreamDict(*types.NewIndirectRef(objNr, 0))
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if sd1.Spilled() || int64(len(sd1.Raw)) != *sd1.StreamLength {
+			t.Fatalf("%s: obj#%d: spilled stream not loaded\n", msg, objNr)
+		}
+	}
+
+	if err := ctx.SpillFile.Close(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestZeroCopyStreams(t *testing.T) {
+	msg := "TestZeroCopyStreams"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	optimize := func(zeroCopy bool) []byte {
+		t.Helper()
+		conf := model.NewDefaultConfiguration()
+		conf.Deterministic = true
+		conf.FileID = []byte("reproducible0001")
+		conf.ZeroCopyStreams = zeroCopy
+		f, err := os.Open(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		defer f.Close()
+		var buf bytes.Buffer
+		if err := api.Optimize(f, &buf, conf); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		return buf.Bytes()
+	}
+
+	if !bytes.Equal(optimize(false), optimize(true)) {
+		t.Fatalf("%s: output differs when copying streams\n", msg)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	conf := model.NewDefaultConfiguration()
+	conf.ZeroCopyStreams = true
+	ctx, err := api.ReadContext(f, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	deferred := 0
+	for _, entry := range ctx.Table {
+		if sd, ok := entry.Object.(types.StreamDict); ok && sd.Spilled() {
+			deferred++
+		}
+	}
+	if deferred == 0 {
+		t.Fatalf("%s: stream data should be loaded on demand\n", msg)
+	}
+
+	// Input files stay open until the merged file has been written.
+	outFile := filepath.Join(outDir, "zeroCopyMerge.pdf")
+	inFiles := []string{inFile, filepath.Join(inDir, "Acroforms2.pdf")}
+	if err := api.MergeCreateFile(inFiles, outFile, false, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestCompactXRef(t *testing.T) {
+	msg := "TestCompactXRef"
+
+	for _, fn := range []string{"OptimizeTest.pdf", "Acroforms2.pdf", "adobe_errata.pdf"} {
+		for _, xRefStream := range []bool{false, true} {
+			conf := model.NewDefaultConfiguration()
+			conf.CompactXRef = true
+			conf.WriteXRefStream = xRefStream
+			conf.WriteObjectStream = xRefStream
+
+			ctx, err := api.ReadContextFile(filepath.Join(inDir, fn))
+			if err != nil {
+				t.Fatalf("%s %s: %v\n", msg, fn, err)
+			}
+			ctx.Configuration = conf
+			if err := api.OptimizeContext(ctx); err != nil {
+				t.Fatalf("%s %s: %v\n", msg, fn, err)
+			}
+			pageCount := ctx.PageCount
+
+			var buf bytes.Buffer
+			if err := api.WriteContext(ctx, &buf); err != nil {
+				t.Fatalf("%s %s: %v\n", msg, fn, err)
+			}
+
+			xc := ctx.Write.XRefCompaction
+			if xc == nil {
+				t.Fatalf("%s %s: missing compaction report\n", msg, fn)
+			}
+			if xc.BytesSaved() < 0 {
+				t.Fatalf("%s %s: xref grew by %d bytes\n", msg, fn, -xc.BytesSaved())
+			}
+
+			ctx, err = api.ReadValidateAndOptimize(bytes.NewReader(buf.Bytes()), model.NewDefaultConfiguration())
+			if err != nil {
+				t.Fatalf("%s %s: %v\n", msg, fn, err)
+			}
+			if ctx.PageCount != pageCount {
+				t.Fatalf("%s %s: pageCount want:%d got:%d\n", msg, fn, pageCount, ctx.PageCount)
+			}
+			for objNr := 1; objNr < *ctx.Size; objNr++ {
+				if e, found := ctx.Find(objNr); !found || e.Free {
+					t.Fatalf("%s %s: object numbers not dense, missing obj #%d\n", msg, fn, objNr)
+				}
+			}
+		}
+	}
+}
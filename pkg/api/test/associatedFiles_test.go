@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// imageObjNr returns the object number of some image XObject of inFile.
+func imageObjNr(t *testing.T, inFile string) int {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("imageObjNr: %v\n", err)
+	}
+	for objNr, e := range ctx.Table {
+		if sd, ok := e.Object.(types.StreamDict); ok && sd.Subtype() != nil && *sd.Subtype() == "Image" {
+			return objNr
+		}
+	}
+
+	t.Fatalf("imageObjNr: no image in %s\n", inFile)
+	return 0
+}
+
+func attachmentIDs(t *testing.T, inFile string) []string {
+	t.Helper()
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("attachmentIDs: %v\n", err)
+	}
+	defer f.Close()
+
+	aa, err := api.Attachments(f, nil)
+	if err != nil {
+		t.Fatalf("attachmentIDs: %v\n", err)
+	}
+
+	var ss []string
+	for _, a := range aa {
+		ss = append(ss, a.ID)
+	}
+	return ss
+}
+
+func TestAssociatedFiles(t *testing.T) {
+	msg := "TestAssociatedFiles"
+
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "associatedFiles.pdf")
+
+	doc := model.AFOwner{}
+	page := model.AFOwner{PageNr: 1}
+	obj := model.AFOwner{ObjNr: imageObjNr(t, inFile)}
+
+	if err := api.AddAssociatedFilesFile(inFile, outFile, doc, []string{filepath.Join(resDir, "test.wav") + ",source audio"}, "Source", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AddAssociatedFilesFile(outFile, "", page, []string{filepath.Join(inDir, "go.pdf")}, "Alternative", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AddAssociatedFilesFile(outFile, "", obj, []string{filepath.Join(inDir, "T4.pdf")}, "", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AddAssociatedFilesFile(outFile, "", page, []string{filepath.Join(inDir, "T4.pdf")}, "Nonsense", nil); err == nil {
+		t.Fatalf("%s: missing error for invalid relationship\n", msg)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, tt := range []struct {
+		owner        model.AFOwner
+		id, desc, rl string
+	}{
+		{doc, "test.wav", "source audio", "Source"},
+		{page, "go.pdf", "", "Alternative"},
+		{obj, "T4.pdf", "", "Unspecified"},
+	} {
+		aff, err := api.AssociatedFilesFile(outFile, tt.owner, nil)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.owner, err)
+		}
+		if len(aff) != 1 || aff[0].ID != tt.id || aff[0].Desc != tt.desc || aff[0].Relationship != tt.rl {
+			t.Fatalf("%s %s: unexpected associated files: %v\n", msg, tt.owner, aff)
+		}
+	}
+
+	// Document level associated files are also regular attachments.
+	if ids := attachmentIDs(t, outFile); len(ids) != 1 || ids[0] != "test.wav" {
+		t.Fatalf("%s: unexpected attachments: %v\n", msg, ids)
+	}
+
+	if err := api.RemoveAssociatedFilesFile(outFile, "", doc, []string{"test.wav"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ids := attachmentIDs(t, outFile); len(ids) > 0 {
+		t.Fatalf("%s: want no attachments, got %v\n", msg, ids)
+	}
+	if err := api.RemoveAssociatedFilesFile(outFile, "", page, []string{"T4.pdf"}, nil); err == nil {
+		t.Fatalf("%s: missing error removing unknown associated file\n", msg)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func prepareForAttachmentTest(t *testing.T) error {
@@ -293,3 +294,55 @@ func TestSanitizePath(t *testing.T) {
 	}
 
 }
+
+func TestVerifyAttachments(t *testing.T) {
+	msg := "TestVerifyAttachments"
+
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile := filepath.Join(outDir, "verifyAttachments.pdf")
+
+	if err := api.AddAttachmentsFile(inFile, outFile, []string{filepath.Join(resDir, "test.wav")}, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	acs, err := api.VerifyAttachmentsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(acs) != 1 || !acs[0].CheckSum || !acs[0].OK() || acs[0].FileName != "test.wav" {
+		t.Fatalf("%s: unexpected result: %+v\n", msg, acs)
+	}
+
+	// Corrupt the embedded file parameters.
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, entry := range ctx.Table {
+		if entry == nil || entry.Object == nil {
+			continue
+		}
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || sd.Type() == nil || *sd.Type() != "EmbeddedFile" {
+			continue
+		}
+		params := sd.DictEntry("Params")
+		params["Size"] = types.Integer(acs[0].Size + 1)
+		params["CheckSum"] = types.HexLiteral(strings.Repeat("00", 16))
+	}
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if acs, err = api.VerifyAttachmentsFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(acs) != 1 || !acs[0].SizeMismatch || !acs[0].CheckSumMismatch || acs[0].OK() {
+		t.Fatalf("%s: want mismatches, got %+v\n", msg, acs)
+	}
+
+	// Extraction still succeeds.
+	if err := api.ExtractAttachmentsFile(outFile, outDir, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
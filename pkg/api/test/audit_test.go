@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func contains(ii []int, i int) bool {
+	for _, j := range ii {
+		if i == j {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAudit(t *testing.T) {
+	msg := "TestAudit"
+	inFile := filepath.Join(inDir, "go.pdf")
+
+	r, err := api.AuditFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(r.Reachable) == 0 {
+		t.Fatalf("%s: no reachable objects\n", msg)
+	}
+	if len(r.Cycles) == 0 {
+		t.Fatalf("%s: missing page tree cycles\n", msg)
+	}
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	rootObjNr := ctx.Root.ObjectNumber.Value()
+
+	// Add an orphan pair of objects.
+	ir1, err := ctx.IndRefForNewObject(types.StringLiteral("orphan"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ir2, err := ctx.IndRefForNewObject(types.Dict{"Child": *ir1})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	orphan1, orphan2 := ir1.ObjectNumber.Value(), ir2.ObjectNumber.Value()
+
+	r, err = pdfcpu.Audit(ctx)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !contains(r.Unreachable, orphan1) || !contains(r.Unreachable, orphan2) {
+		t.Fatalf("%s: orphans not detected: %v\n", msg, r.Unreachable)
+	}
+	if !contains(r.Reachable, rootObjNr) {
+		t.Fatalf("%s: root not reachable\n", msg)
+	}
+	if r.RetainedSize[rootObjNr] <= r.Size[rootObjNr] {
+		t.Fatalf("%s: root retains %d bytes only\n", msg, r.RetainedSize[rootObjNr])
+	}
+
+	// Write with GC enabled and keep one of the orphans including its children.
+	outFile := filepath.Join(outDir, "gc.pdf")
+	ctx.Configuration.GCUnreachable = true
+	ctx.Configuration.GCKeep = []int{orphan2}
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	r, err = api.AuditFile(outFile, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(r.Unreachable) != 2 {
+		t.Fatalf("%s: want 2 kept unreachable objects, got: %v\n", msg, r.Unreachable)
+	}
+}
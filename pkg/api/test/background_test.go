@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pkg/errors"
+)
+
+func pageContent(t *testing.T, msg, fileName string, pageNr int) []byte {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(fileName)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The background fills the media box.
+	mb := inhPAttrs.MediaBox
+	fill := fmt.Sprintf("%.2f %.2f %.2f %.2f re f", mb.LL.X, mb.LL.Y, mb.Width(), mb.Height())
+	if bytes.Contains(bb, []byte("/PageBackground")) && !bytes.Contains(bb, []byte(fill)) {
+		t.Fatalf("%s: background does not match media box %s\n", msg, mb)
+	}
+
+	return bb
+}
+
+func TestPageBackground(t *testing.T) {
+	msg := "TestPageBackground"
+
+	for _, tt := range []struct {
+		inFile       string
+		underContent bool
+	}{
+		{"test.pdf", true},
+		{"mountain.pdf", false}, // tint a scan
+	} {
+		inFile := filepath.Join(inDir, tt.inFile)
+		outFile := filepath.Join(outDir, fmt.Sprintf("background_%t_%s", tt.underContent, tt.inFile))
+
+		c := color.SimpleColor{R: 1, G: 1, B: .8}
+		if err := api.SetPageBackgroundFile(inFile, outFile, []string{"1"}, c, tt.underContent, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.inFile, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.inFile, err)
+		}
+
+		bb := pageContent(t, msg, outFile, 1)
+		i := bytes.Index(bb, []byte("/PageBackground"))
+		if i < 0 {
+			t.Fatalf("%s %s: missing page background\n", msg, tt.inFile)
+		}
+		if tt.underContent && bytes.Contains(bb[:i], []byte(" Tj")) {
+			t.Fatalf("%s %s: background painted over content\n", msg, tt.inFile)
+		}
+		if !tt.underContent && !bytes.Contains(bb[i:], []byte(" gs ")) {
+			t.Fatalf("%s %s: missing blend mode\n", msg, tt.inFile)
+		}
+
+		if err := api.RemovePageBackgroundFile(outFile, "", nil, nil); err != nil {
+			t.Fatalf("%s %s remove: %v\n", msg, tt.inFile, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.inFile, err)
+		}
+		if bytes.Contains(pageContent(t, msg, outFile, 1), []byte("/PageBackground")) {
+			t.Fatalf("%s %s: page background not removed\n", msg, tt.inFile)
+		}
+
+		if err := api.RemovePageBackgroundFile(outFile, "", nil, nil); !errors.Is(err, pdfcpu.ErrNoPageBackground) {
+			t.Fatalf("%s %s: want ErrNoPageBackground, got: %v\n", msg, tt.inFile, err)
+		}
+	}
+}
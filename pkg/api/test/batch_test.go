@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/batch"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestBatchProcess(t *testing.T) {
+	msg := "TestBatchProcess"
+
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	ops := []pdfcpu.Operation{
+		pdfcpu.Rotate{Pages: []int{1}, Rotation: 90},
+		pdfcpu.AddKeywords{Keywords: []string{"batch"}},
+	}
+
+	var jobs []batch.JobSpec
+	for _, fn := range []string{"batch1.pdf", "batch2.pdf", "batch3.pdf"} {
+		jobs = append(jobs, batch.JobSpec{InFile: inFile, OutFile: filepath.Join(outDir, fn), Ops: ops})
+	}
+
+	// The encrypted job shares the configuration with a plain job.
+	conf := model.NewDefaultConfiguration()
+	jobs[2].Conf = conf
+	jobs = append(jobs,
+		batch.JobSpec{
+			InFile:  inFile,
+			OutFile: filepath.Join(outDir, "batch4.pdf"),
+			Ops:     append(ops[:len(ops):len(ops)], pdfcpu.Encrypt{UserPW: "upw", OwnerPW: "opw", Permissions: model.PermissionsAll}),
+			Conf:    conf,
+		},
+		batch.JobSpec{InFile: filepath.Join(inDir, "missing.pdf"), OutFile: filepath.Join(outDir, "batch5.pdf"), Ops: ops},
+		batch.JobSpec{InFile: inFile, OutFile: filepath.Join(outDir, "batch6.pdf"), Ops: []pdfcpu.Operation{pdfcpu.RemovePages{Pages: []int{99}}}},
+	)
+
+	results := batch.Process(jobs, 3)
+	if len(results) != len(jobs) {
+		t.Fatalf("%s: want %d results, got %d\n", msg, len(jobs), len(results))
+	}
+
+	for i, r := range results {
+		if r.Job.OutFile != jobs[i].OutFile {
+			t.Fatalf("%s: result %d out of order: %s\n", msg, i, r.Job.OutFile)
+		}
+		failing := i >= 4
+		if failing != (r.Err != nil) {
+			t.Fatalf("%s: job %d: unexpected result: %v\n", msg, i, r.Err)
+		}
+	}
+
+	if conf.Cmd != 0 || conf.OwnerPW != "" {
+		t.Fatalf("%s: job configuration modified\n", msg)
+	}
+
+	for _, j := range jobs[:3] {
+		listKeywords(t, msg, j.OutFile, []string{"batch"})
+		if err := api.ValidateFile(j.OutFile, nil); err != nil {
+			t.Fatalf("%s: validate %s: %v\n", msg, j.OutFile, err)
+		}
+	}
+
+	outFile := jobs[3].OutFile
+	if err := api.ValidateFile(outFile, nil); err == nil {
+		t.Fatalf("%s: %s should be encrypted\n", msg, outFile)
+	}
+	conf = model.NewDefaultConfiguration()
+	conf.UserPW = "upw"
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestRemoveBlankPages(t *testing.T) {
+	msg := "TestRemoveBlankPages"
+
+	inFile := filepath.Join(inDir, "pike-stanford.pdf")
+	blankFile := filepath.Join(outDir, "withBlankPages.pdf")
+	outFile := filepath.Join(outDir, "withoutBlankPages.pdf")
+
+	tol := pdfcpu.DefaultBlankPageTolerance
+
+	pp, err := api.DetectBlankPagesFile(inFile, tol, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pp) > 0 {
+		t.Fatalf("%s: unexpected blank pages: %v\n", msg, pp)
+	}
+
+	pageCount, err := api.PageCountFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Insert blank pages after pages 1 and 3.
+	if err := api.InsertPagesFile(inFile, blankFile, []string{"1", "3"}, false, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pp, err = api.DetectBlankPagesFile(blankFile, tol, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if want := []int{2, 5}; !reflect.DeepEqual(pp, want) {
+		t.Fatalf("%s: want blank pages %v, got %v\n", msg, want, pp)
+	}
+
+	pp, err = api.RemoveBlankPagesFile(blankFile, outFile, tol, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pp) != 2 {
+		t.Fatalf("%s: want 2 removed pages, got %v\n", msg, pp)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	n, err := api.PageCountFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n != pageCount {
+		t.Fatalf("%s: want %d pages, got %d\n", msg, pageCount, n)
+	}
+}
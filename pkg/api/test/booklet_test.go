@@ -286,3 +286,19 @@ func TestBooklet(t *testing.T) {
 		})
 	}
 }
+
+func TestBookletDuplexLongEdgeWithSheetLabels(t *testing.T) {
+	msg := "TestBookletDuplexLongEdgeWithSheetLabels"
+	inFile := filepath.Join(inDir, "WaldenFull.pdf")
+	outFile := filepath.Join("..", "..", "samples", "booklet", "BookletDuplexLongEdgeWithSheetLabels.pdf")
+
+	testBooklet(t, msg, []string{inFile}, outFile, nil, "formsize:A4, flip:long, blanks:back, labels:on, guides:on", 2, false, nil)
+
+	n, err := api.PageCountFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n%2 != 0 {
+		t.Fatalf("%s: want complete sheets, got %d pages\n", msg, n)
+	}
+}
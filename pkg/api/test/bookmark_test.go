@@ -17,8 +17,10 @@
 package test
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -194,3 +196,70 @@ func TestImportBookmarks(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func TestExtractOutline(t *testing.T) {
+	msg := "TestExtractOutline"
+
+	for _, tt := range []struct {
+		fn     string
+		title  string
+		dest   string
+		pageNr int
+		fit    string
+		y      float64
+	}{
+		{"ECSTR11-01.pdf", "Introduction", "chapter.1", 11, "XYZ", 715.221}, // named destination
+		{"WaldenFull.pdf", "Cover", "", 1, "XYZ", 845},                      // explicit destination
+		{"TheGoProgrammingLanguageCh1.pdf", "Contents", "", 8, "Fit", 0},    // no position
+	} {
+		items, err := api.ExtractOutlineFile(filepath.Join(inDir, tt.fn), nil)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fn, err)
+		}
+		if len(items) == 0 {
+			t.Fatalf("%s %s: missing outline\n", msg, tt.fn)
+		}
+
+		item := items[0]
+		if item.Title != tt.title || item.Dest != tt.dest || item.PageNr != tt.pageNr || item.Fit != tt.fit {
+			t.Fatalf("%s %s: unexpected item %+v\n", msg, tt.fn, item)
+		}
+		if (item.Y == nil) != (tt.y == 0) || item.Y != nil && *item.Y != tt.y {
+			t.Fatalf("%s %s: want y %.3f, got %v\n", msg, tt.fn, tt.y, item.Y)
+		}
+
+		// Outline items resolve like bookmarks.
+		f, err := os.Open(filepath.Join(inDir, tt.fn))
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fn, err)
+		}
+		bms, err := api.Bookmarks(f, nil)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fn, err)
+		}
+		if len(bms) != len(items) {
+			t.Fatalf("%s %s: want %d items, got %d\n", msg, tt.fn, len(bms), len(items))
+		}
+		for i, bm := range bms {
+			if bm.Title != items[i].Title || bm.PageFrom != items[i].PageNr || len(bm.Kids) != len(items[i].Kids) {
+				t.Fatalf("%s %s: item %d: want %s/%d, got %+v\n", msg, tt.fn, i, bm.Title, bm.PageFrom, items[i])
+			}
+		}
+
+		bb, err := json.Marshal(items)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fn, err)
+		}
+		var items1 []pdfcpu.OutlineItem
+		if err := json.Unmarshal(bb, &items1); err != nil || !reflect.DeepEqual(items, items1) {
+			t.Fatalf("%s %s: JSON round trip failed: %v\n", msg, tt.fn, err)
+		}
+	}
+
+	// No outline
+	items, err := api.ExtractOutlineFile(filepath.Join(inDir, "test.pdf"), nil)
+	if err != nil || items != nil {
+		t.Fatalf("%s: want no outline, got %v %v\n", msg, items, err)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestCanvas(t *testing.T) {
+	msg := "TestCanvas"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "gobook.0.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	c, err := pdfcpu.NewCanvas(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := c.SetOpacity(.5, .5); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for i, fontName := range []string{"Helvetica", "Courier", "Helvetica"} {
+		td := model.TextDescriptor{
+			Text:     "Hello Canvas",
+			FontName: fontName,
+			FontSize: 24,
+			X:        50,
+			Y:        float64(700 - 50*i),
+			FillCol:  color.Red,
+			RMode:    draw.RMFill,
+		}
+		if _, err := c.Text(td); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+
+	f, err := os.Open(filepath.Join(resDir, "logoSmall.png"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	ir, err := c.Image(f, types.NewRectangle(50, 400, 150, 500))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := c.XObject(*ir, matrix.CalcTransformMatrix(50, 50, 0, 1, 200, 400)); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	c.Path(func(w io.Writer) {
+		draw.DrawRect(w, types.NewRectangle(40, 390, 260, 510), 2, &color.Blue, nil)
+	})
+
+	r := types.NewRectangle(300, 400, 500, 500)
+	id, err := c.FillPattern(model.FillPattern{Type: model.PatternAxial, C0: color.Red, C1: color.Blue}, r)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	c.Path(func(w io.Writer) {
+		draw.FillRectWithPattern(w, r, 0, nil, id, nil)
+	})
+
+	// Text along a wave and text with per glyph rotations.
+	td := model.TextDescriptor{Text: "Along a path", FontName: "Helvetica", FontSize: 18, HAlign: types.AlignCenter}
+	wave := model.TextPath{Points: []types.Point{{X: 300, Y: 300}, {X: 375, Y: 340}, {X: 450, Y: 300}, {X: 525, Y: 340}}}
+	if _, err := c.TextOnPath(td, wave); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	td.Text = "Tilt"
+	tilt := model.TextPath{Points: []types.Point{{X: 50, Y: 300}, {X: 200, Y: 300}}, Rotations: []float64{-20, 0, 20, 40}}
+	if _, err := c.TextOnPath(td, tilt); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	tilt.Rotations = tilt.Rotations[1:]
+	if _, err := c.TextOnPath(td, tilt); err == nil {
+		t.Fatalf("%s: want error for missing glyph rotation\n", msg)
+	}
+
+	bb, err := c.TextOnPath(model.TextDescriptor{Text: "Circle", FontName: "Helvetica", FontSize: 12}, model.TextPath{Center: types.Point{X: 300, Y: 150}, Radius: 50, Angle: 90})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if bb == nil || bb.LL.Y < 190 || bb.Contains(types.Point{X: 300, Y: 150}) {
+		t.Fatalf("%s: unexpected bounding box for text on circle: %v\n", msg, bb)
+	}
+
+	if err := c.SetOpacity(.5, .5); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := c.Flush(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, _, inhPAttrs, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// 2 fonts, 1 image, 1 ExtGState, 1 pattern.
+	for category, want := range map[string]int{"Font": 2, "XObject": 1, "ExtGState": 1, "Pattern": 1} {
+		got := 0
+		sub, err := ctx.DereferenceDict(inhPAttrs.Resources[category])
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		for _, o := range sub {
+			if ir, ok := o.(types.IndirectRef); ok && ir.ObjectNumber.Value() > 0 {
+				got++
+			}
+		}
+		if got < want {
+			t.Fatalf("%s: %s: want at least %d resources, got %d\n", msg, category, want, got)
+		}
+	}
+
+	a, err := ctx.DereferenceArray(d["Contents"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(a) < 3 {
+		t.Fatalf("%s: want isolated page content, got %d content streams\n", msg, len(a))
+	}
+
+	// Canvas content goes on top of the previous canvas content.
+	if _, err := c.Text(model.TextDescriptor{Text: "Page 1", FontName: "Helvetica", FontSize: 12, X: 50, Y: 50}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	outFile := filepath.Join(outDir, "canvas.pdf")
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
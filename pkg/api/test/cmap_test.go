@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// cjkFontDict returns an unembedded Type0 font dict using the predefined CMap encoding.
+func cjkFontDict(ctx *model.Context, t *testing.T, baseFont, encoding, ordering string) types.IndirectRef {
+	t.Helper()
+
+	fd := types.Dict{
+		"Type":        types.Name("FontDescriptor"),
+		"FontName":    types.Name(baseFont),
+		"FontFamily":  types.StringLiteral(baseFont),
+		"Flags":       types.Integer(6),
+		"FontBBox":    types.NewNumberArray(-25, -254, 1000, 880),
+		"ItalicAngle": types.Integer(0),
+		"Ascent":      types.Integer(880),
+		"Descent":     types.Integer(-120),
+		"CapHeight":   types.Integer(880),
+		"StemV":       types.Integer(93),
+	}
+	cidFont := types.Dict{
+		"Type":     types.Name("Font"),
+		"Subtype":  types.Name("CIDFontType0"),
+		"BaseFont": types.Name(baseFont),
+		"CIDSystemInfo": types.Dict{
+			"Registry":   types.StringLiteral("Adobe"),
+			"Ordering":   types.StringLiteral(ordering),
+			"Supplement": types.Integer(2),
+		},
+		"FontDescriptor": fd,
+		"DW":             types.Integer(1000),
+	}
+	indRef, err := ctx.IndRefForNewObject(types.Dict{
+		"Type":            types.Name("Font"),
+		"Subtype":         types.Name("Type0"),
+		"BaseFont":        types.Name(baseFont),
+		"Encoding":        types.Name(encoding),
+		"DescendantFonts": types.Array{cidFont},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	return *indRef
+}
+
+func TestPredefinedCMaps(t *testing.T) {
+	msg := "TestPredefinedCMaps"
+
+	var buf bytes.Buffer
+	json := `{"pages": {"1": {"content": {"text": [{"value": "CJK", "pos": [50, 750], "font": {"name": "Helvetica", "size": 12}}]}}}}`
+	if err := api.Create(nil, strings.NewReader(json), &buf, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContext(bytes.NewReader(buf.Bytes()), conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fonts := types.Dict{
+		"F1": cjkFontDict(ctx, t, "STSong-Light", "UniGB-UCS2-H", "GB1"),
+		"F2": cjkFontDict(ctx, t, "KozMinPro-Regular", "90ms-RKSJ-H", "Japan1"),
+		"F3": cjkFontDict(ctx, t, "KozMinPro-Regular", "UniJIS-UCS2-V", "Japan1"),
+	}
+
+	// 中文 in UCS-2, 日本 in Shift-JIS followed by a half-width "A" and 日本 in vertical writing mode.
+	content := "BT /F1 12 Tf 50 700 Td <4E2D6587> Tj ET\n" +
+		"BT /F2 12 Tf 50 650 Td <93FA967B41> Tj ET\n" +
+		"BT /F3 12 Tf 300 700 Td <65E5672C> Tj ET\n"
+	indRef, err := ctx.StreamDictIndRef([]byte(content))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d["Contents"] = *indRef
+	d["Resources"] = types.Dict{"Font": fonts}
+
+	inFile := filepath.Join(outDir, "predefinedCMaps.pdf")
+	if err := api.WriteContextFile(ctx, inFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Optimization keeps the fonts intact.
+	if err := api.OptimizeFile(inFile, "", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	strict := model.NewDefaultConfiguration()
+	strict.ValidationMode = model.ValidationStrict
+	if err := api.ValidateFile(inFile, strict); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err = api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	s, err := pdfcpu.ExtractPageText(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, want := range []string{"中文", "日本A"} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("%s: want %q in %q\n", msg, want, s)
+		}
+	}
+
+	// Vertical writing renders one glyph per text element, the second one below the first.
+	svgDir := filepath.Join(outDir, "predefinedCMaps")
+	if err := os.MkdirAll(svgDir, 0755); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ExtractSVGFile(inFile, svgDir, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	files, err := filepath.Glob(filepath.Join(svgDir, "*.svg"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("%s: want 1 svg file, got %v %v\n", msg, files, err)
+	}
+	bb, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	svg := string(bb)
+	for _, want := range []string{">中文<", ">日本A<", ">日<", ">本<"} {
+		if !strings.Contains(svg, want) {
+			t.Fatalf("%s: want %q in %s\n", msg, want, svg)
+		}
+	}
+
+	// A CMap has to match the character collection of its CIDFont.
+	ctx, err = api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, entry := range ctx.Table {
+		if d, ok := entry.Object.(types.Dict); ok && d.NameEntry("Encoding") != nil && *d.NameEntry("Encoding") == "UniGB-UCS2-H" {
+			d["Encoding"] = types.Name("UniKS-UCS2-H")
+		}
+	}
+	outFile := filepath.Join(outDir, "predefinedCMapsInvalid.pdf")
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, strict); err == nil || !strings.Contains(err.Error(), "UniKS-UCS2-H") {
+		t.Fatalf("%s: want error for CMap UniKS-UCS2-H used with GB1, got %v\n", msg, err)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func pageDigests(t *testing.T, msg, fileName string) []string {
+	t.Helper()
+	m, err := api.DocumentManifestFile(fileName, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ss := make([]string, len(m.Pages))
+	for i, p := range m.Pages {
+		ss[i] = p.Digest
+	}
+	return ss
+}
+
+func TestReorderPages(t *testing.T) {
+	msg := "TestReorderPages"
+
+	inFile := filepath.Join(inDir, "pike-stanford.pdf")
+	outFile := filepath.Join(outDir, "reversed.pdf")
+
+	want := pageDigests(t, msg, inFile)
+	n := len(want)
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = n - i
+	}
+
+	if err := api.ReorderPagesFile(inFile, outFile, order, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	got := pageDigests(t, msg, outFile)
+	for i := range got {
+		if got[i] != want[n-1-i] {
+			t.Fatalf("%s: page %d: want original page %d\n", msg, i+1, n-i)
+		}
+	}
+
+	if err := api.ReorderPagesFile(inFile, outFile, order[1:], nil); err == nil {
+		t.Fatalf("%s: missing error for incomplete order\n", msg)
+	}
+}
+
+func TestCollateDuplex(t *testing.T) {
+	msg := "TestCollateDuplex"
+
+	inFile := filepath.Join(inDir, "pike-stanford.pdf")
+	fronts := filepath.Join(outDir, "fronts.pdf")
+	backs := filepath.Join(outDir, "backs.pdf")
+	outFile := filepath.Join(outDir, "duplex.pdf")
+
+	want := pageDigests(t, msg, inFile)
+	if len(want)%2 > 0 {
+		t.Fatalf("%s: need even page count\n", msg)
+	}
+
+	// Simulate a single sided scan: all front sides followed by all back sides in reverse order.
+	if err := api.CollectFile(inFile, fronts, []string{"odd"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	var evenDesc []string
+	for i := len(want); i > 0; i -= 2 {
+		evenDesc = append(evenDesc, strconv.Itoa(i))
+	}
+	if err := api.CollectFile(inFile, backs, evenDesc, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.CollateFile([]string{fronts, backs}, outFile, pdfcpu.CollateDuplex, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if got := pageDigests(t, msg, outFile); !reflect.DeepEqual(got, want) {
+		t.Fatalf("%s: collated pages do not match original page sequence\n", msg)
+	}
+}
+
+func TestCollateOrder(t *testing.T) {
+	for _, tt := range []struct {
+		pageCounts []int
+		pattern    string
+		want       []int
+	}{
+		{[]int{3, 3}, "duplex", []int{1, 6, 2, 5, 3, 4}},
+		{[]int{2, 2, 2}, "zip", []int{1, 3, 5, 2, 4, 6}},
+		{[]int{3, 1}, "1,2", []int{1, 4, 2, 3}},
+		{[]int{4}, "1:1-2,1:n-3", []int{1, 4, 2, 3}},
+	} {
+		got, err := pdfcpu.CollateOrder(tt.pageCounts, tt.pattern)
+		if err != nil {
+			t.Fatalf("%s: %v\n", tt.pattern, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("%s: want %v, got %v\n", tt.pattern, tt.want, got)
+		}
+	}
+
+	for _, pattern := range []string{"1", "1,1", "3", "1:0-2,2"} {
+		if _, err := pdfcpu.CollateOrder([]int{2, 2}, pattern); err == nil {
+			t.Fatalf("%s: missing error\n", pattern)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestConvertColors(t *testing.T) {
+	msg := "TestConvertColors"
+
+	for _, tt := range []struct {
+		inFile   string
+		target   string
+		intent   string
+		residual []string
+	}{
+		{"test.pdf", model.DeviceGrayCS, "", nil},
+		// VectorApple.pdf uses Separation and DeviceN color spaces and mesh shadings.
+		{"VectorApple.pdf", model.DeviceRGBCS, "RelativeColorimetric", []string{"Separation color space", "DeviceN color space", "mesh shading type 7"}},
+		{"mountain.pdf", model.DeviceCMYKCS, "Perceptual", nil},
+	} {
+		inFile := filepath.Join(inDir, tt.inFile)
+		outFile := filepath.Join(outDir, fmt.Sprintf("convertColors_%s_%s", tt.target, tt.inFile))
+
+		residual, err := api.ConvertColorsFile(inFile, outFile, tt.target, tt.intent, nil)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.inFile, err)
+		}
+		if len(residual) > 0 && tt.residual == nil {
+			t.Fatalf("%s %s: unexpected residual colors: %v\n", msg, tt.inFile, residual)
+		}
+		for _, want := range tt.residual {
+			var found bool
+			for _, s := range residual {
+				if strings.HasSuffix(s, ": "+want) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("%s %s: missing residual color %q: %v\n", msg, tt.inFile, want, residual)
+			}
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.inFile, err)
+		}
+
+		ops, err := model.ParseContentOps(string(pageContent(t, msg, outFile, 1)))
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.inFile, err)
+		}
+		ignored := map[string][]string{
+			model.DeviceGrayCS: {"rg", "RG", "k", "K"},
+			model.DeviceRGBCS:  {"g", "G", "k", "K"},
+			model.DeviceCMYKCS: {"g", "G", "rg", "RG"},
+		}[tt.target]
+		for _, op := range ops {
+			if types.MemberOf(op.Operator, ignored) {
+				t.Fatalf("%s %s: unexpected operator %s\n", msg, tt.inFile, op.Operator)
+			}
+		}
+
+		f, err := os.Open(outFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.inFile, err)
+		}
+		mm, err := api.Images(f, nil, nil)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.inFile, err)
+		}
+
+		for _, m := range mm {
+			for _, img := range m {
+				if img.IsImgMask || img.Cs == "Indexed" {
+					continue
+				}
+				if img.Cs != tt.target {
+					t.Fatalf("%s %s: obj#%d want %s, got %s\n", msg, tt.inFile, img.ObjNr, tt.target, img.Cs)
+				}
+			}
+		}
+	}
+
+	if _, err := api.ConvertColorsFile(filepath.Join(inDir, "test.pdf"), filepath.Join(outDir, "convertColors.pdf"), "Lab", "", nil); err == nil {
+		t.Fatalf("%s: missing error for unsupported target\n", msg)
+	}
+
+	if _, err := api.ConvertColorsFile(filepath.Join(inDir, "test.pdf"), filepath.Join(outDir, "convertColors.pdf"), model.DeviceRGBCS, "Vivid", nil); err == nil {
+		t.Fatalf("%s: missing error for unsupported rendering intent\n", msg)
+	}
+}
+
+func TestInkCoverage(t *testing.T) {
+	msg := "TestInkCoverage"
+
+	inFile := filepath.Join(inDir, "mountain.pdf")
+	outFile := filepath.Join(outDir, "inkCoverageGray.pdf")
+
+	cc, err := api.InkCoverageFile(inFile, []string{"1"}, 36, nil)
+	if err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+	if len(cc) != 1 || cc[0].PageNr != 1 {
+		t.Fatalf("%s %s: unexpected result: %v\n", msg, inFile, cc)
+	}
+	if cc[0].Total <= 0 || !cc[0].Color {
+		t.Fatalf("%s %s: want color page: %v\n", msg, inFile, cc[0].Colorants)
+	}
+
+	// A page converted to DeviceGray uses black only.
+	if _, err := api.ConvertColorsFile(inFile, outFile, model.DeviceGrayCS, "", nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+	cc, err = api.InkCoverageFile(outFile, []string{"1"}, 36, nil)
+	if err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+	if cc[0].Color {
+		t.Fatalf("%s %s: want grayscale page: %v\n", msg, outFile, cc[0].Colorants)
+	}
+	if cc[0].Colorants[pdfcpu.InkBlack] <= 0 {
+		t.Fatalf("%s %s: missing black coverage\n", msg, outFile)
+	}
+}
+
+func colorants(t *testing.T, msg, fileName string) []pdfcpu.Colorant {
+	t.Helper()
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		t.Fatalf("%s %s: %v\n", msg, fileName, err)
+	}
+	defer f.Close()
+
+	cc, err := api.Colorants(f, nil)
+	if err != nil {
+		t.Fatalf("%s %s: %v\n", msg, fileName, err)
+	}
+
+	return cc
+}
+
+func TestSpotColors(t *testing.T) {
+	msg := "TestSpotColors"
+
+	inFile := filepath.Join(inDir, "VectorApple.pdf")
+	outFile := filepath.Join(outDir, "spotColorsRenamed.pdf")
+
+	// VectorApple.pdf uses a Separation color space for Black.
+	name := "Black"
+	cc := colorants(t, msg, inFile)
+	if len(cc) == 0 || cc[0].Name != name || !cc[0].Process || cc[0].Separation != 1 {
+		t.Fatalf("%s %s: unexpected colorants: %v\n", msg, inFile, cc)
+	}
+
+	if _, err := api.ListColorantsFile(inFile, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+
+	// Turn it into a spot colorant.
+	if err := api.RenameColorantFile(inFile, outFile, name, "PANTONE 123 C", nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+	var found bool
+	for _, c := range colorants(t, msg, outFile) {
+		if c.Name == name {
+			t.Fatalf("%s %s: colorant %s not renamed\n", msg, outFile, name)
+		}
+		if c.Name == "PANTONE 123 C" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("%s %s: missing renamed colorant\n", msg, outFile)
+	}
+
+	// Remap the renamed colorant to a CMYK equivalent.
+	inFile = outFile
+	outFile = filepath.Join(outDir, "spotColorsRemapped.pdf")
+	if err := api.RemapColorantFile(inFile, outFile, "PANTONE 123 C", model.DeviceCMYKCS, []float64{0, .5, 1, 0}, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+	for _, c := range colorants(t, msg, outFile) {
+		if c.Name == "PANTONE 123 C" && c.Separation > 0 && !types.MemberOf(model.DeviceCMYKCS, c.Alternates) {
+			t.Fatalf("%s %s: want alternate %s, got %v\n", msg, outFile, model.DeviceCMYKCS, c.Alternates)
+		}
+	}
+
+	if err := api.RemapColorantFile(inFile, outFile, "PANTONE 123 C", model.DeviceRGBCS, []float64{1, 0}, nil); err == nil {
+		t.Fatalf("%s: missing error for invalid color components\n", msg)
+	}
+}
@@ -17,11 +17,18 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func TestDisableConfigDir(t *testing.T) {
@@ -47,3 +54,116 @@ func TestDisableConfigDir_Parallel(t *testing.T) {
 	wg.Wait()
 	t.Log("DisableConfigDir passed")
 }
+
+func TestReadOnlyContext(t *testing.T) {
+	msg := "TestReadOnlyContext"
+	inFile := filepath.Join(inDir, "annotTest.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := ctx.SetReadOnly(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.WriteContext(ctx, io.Discard); err != model.ErrReadOnly {
+		t.Fatalf("%s: want ErrReadOnly, got %v\n", msg, err)
+	}
+	if _, err := ctx.IndRefForNewObject(types.NewDict()); err != model.ErrReadOnly {
+		t.Fatalf("%s: want ErrReadOnly, got %v\n", msg, err)
+	}
+
+	// Dereference all objects concurrently including lazy object stream objects
+	// while cloning.
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%4 == 0 {
+				ctx1, err := ctx.Clone()
+				if err == nil {
+					err = api.ValidateContext(ctx1)
+				}
+				if err != nil {
+					errs <- err
+				}
+				return
+			}
+			for objNr := 1; objNr < *ctx.Size; objNr++ {
+				if _, err := ctx.Dereference(*types.NewIndirectRef(objNr, 0)); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestCloneContext(t *testing.T) {
+	msg := "TestCloneContext"
+	inFile := filepath.Join(inDir, "annotTest.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	ctx, err := api.ReadAndValidate(f, model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := ctx.SetReadOnly(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Stamp and write independent clones of a shared template concurrently.
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx1, err := ctx.Clone()
+			if err != nil {
+				errs <- err
+				return
+			}
+			wm, err := api.TextWatermark(fmt.Sprintf("copy %d", i), "", true, false, types.POINTS)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if err := pdfcpu.AddWatermarks(ctx1, nil, wm); err != nil {
+				errs <- err
+				return
+			}
+			var buf bytes.Buffer
+			if err := api.WriteContext(ctx1, &buf); err != nil {
+				errs <- err
+				return
+			}
+			if err := api.Validate(bytes.NewReader(buf.Bytes()), nil); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The template is left untouched.
+	if ctx.Watermarked {
+		t.Fatalf("%s: template got watermarked\n", msg)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func readValidatedContext(t *testing.T, inFile string) *model.Context {
+	t.Helper()
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", inFile, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", inFile, err)
+	}
+	return ctx
+}
+
+func TestCopyObjects(t *testing.T) {
+	msg := "TestCopyObjects"
+
+	src := readValidatedContext(t, filepath.Join(inDir, "testImage.pdf"))
+	dst := readValidatedContext(t, filepath.Join(inDir, "test.pdf"))
+
+	// Graft the image XObjects of src page 1 onto dst page 1.
+	_, _, inhPAttrs, err := src.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	xObjs := inhPAttrs.Resources.DictEntry("XObject")
+	if len(xObjs) == 0 {
+		t.Fatalf("%s: missing XObjects\n", msg)
+	}
+	var (
+		names []string
+		roots []types.IndirectRef
+	)
+	for k, v := range xObjs {
+		names = append(names, k)
+		roots = append(roots, v.(types.IndirectRef))
+	}
+
+	refs, err := pdfcpu.CopyObjects(dst, src, roots)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(refs) != len(roots) {
+		t.Fatalf("%s: want %d refs, got %d\n", msg, len(roots), len(refs))
+	}
+
+	d, _, inhPAttrs, err := dst.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	xObjDict := types.Dict{}
+	for i, name := range names {
+		xObjDict["Copied"+name] = refs[i]
+	}
+	res := inhPAttrs.Resources.Clone().(types.Dict)
+	res["XObject"] = xObjDict
+	d["Resources"] = res
+
+	outFile := filepath.Join(outDir, "copyObjects.pdf")
+	if err := api.WriteContextFile(dst, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+	ii, err := api.Images(f, []string{"1"}, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ii) != 1 || len(ii[0]) != len(roots) {
+		t.Fatalf("%s: want %d images on page 1, got %v\n", msg, len(roots), ii)
+	}
+}
+
+func TestCopyObjectsCycle(t *testing.T) {
+	msg := "TestCopyObjectsCycle"
+
+	src := readValidatedContext(t, filepath.Join(inDir, "test.pdf"))
+	dst := readValidatedContext(t, filepath.Join(inDir, "test.pdf"))
+
+	// a -> b -> a
+	a := types.Dict{"Name": types.Name("a")}
+	irA, err := src.IndRefForNewObject(a)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	irB, err := src.IndRefForNewObject(types.Dict{"Name": types.Name("b"), "Next": *irA})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	a["Next"] = *irB
+
+	size := len(dst.Table)
+
+	refs, err := pdfcpu.CopyObjects(dst, src, []types.IndirectRef{*irA, *irB, *irA})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(dst.Table) != size+2 {
+		t.Fatalf("%s: want 2 new objects, got %d\n", msg, len(dst.Table)-size)
+	}
+	if refs[0] != refs[2] || refs[0] == refs[1] {
+		t.Fatalf("%s: unexpected refs %v\n", msg, refs)
+	}
+
+	a1, err := dst.DereferenceDict(refs[0])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	b1, err := dst.DereferenceDict(a1["Next"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if next := b1.IndirectRefEntry("Next"); next == nil || *next != refs[0] || *b1.NameEntry("Name") != "b" {
+		t.Fatalf("%s: corrupt cycle %v %v\n", msg, a1, b1)
+	}
+
+	// The source remains untouched.
+	if next := a.IndirectRefEntry("Next"); next == nil || *next != *irB {
+		t.Fatalf("%s: source modified: %v\n", msg, a)
+	}
+}
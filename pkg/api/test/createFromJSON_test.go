@@ -18,9 +18,11 @@ package test
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/linebreak"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
@@ -82,6 +84,8 @@ func TestCreateContentPrimitivesViaJson(t *testing.T) {
 		{"TestBoxesAndColors", "boxesAndColors.json", "boxesAndColors.pdf"},
 		{"TestBoxesAndMargin", "boxesAndMargin.json", "boxesAndMargin.pdf"},
 		{"TestBoxesAndRotation", "boxesAndRotation.json", "boxesAndRotation.pdf"},
+		{"TestBoxesAndGradients", "boxesAndGradients.json", "boxesAndGradients.pdf"},
+		{"TestBoxesAndBorders", "boxesAndBorders.json", "boxesAndBorders.pdf"},
 
 		// Table
 		{"TestTable", "table.json", "table.pdf"},
@@ -100,6 +104,23 @@ func TestCreateContentPrimitivesViaJson(t *testing.T) {
 
 }
 
+func TestCreateWrappedTextViaJson(t *testing.T) {
+	msg := "TestCreateWrappedTextViaJson"
+
+	// Liang's sample patterns plus exceptions for the words hyphenated in this sample.
+	patterns := "hy3ph he2n hena4 hen5at 1na n2at 1tio 2io o2n"
+	exceptions := "com-par-a-tive-ly con-tain-ing jus-ti-fi-ca-tion doc-u-ments es-pe-cial-ly gen-er-at-ed"
+	h, err := linebreak.NewHyphenator(strings.NewReader(patterns), strings.NewReader(exceptions))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	linebreak.RegisterHyphenator("en-us", h)
+
+	inFileJSON := filepath.Join(inDir, "json", "create", "textWrapAndHyphenation.json")
+	outFile := filepath.Join(outDir, "textWrapAndHyphenation.pdf")
+	createPDF(t, msg, "", inFileJSON, outFile, conf)
+}
+
 func TestCreateFormPrimitivesViaJson(t *testing.T) {
 
 	inDirForm := filepath.Join(inDir, "json", "form")
@@ -207,6 +207,13 @@ func TestPoster(t *testing.T) {
 			"posterDimScaled",
 			types.CENTIMETRES,
 			"dim:15 10, scale:2.0, margin:1, bgcol:#E9967A, border:on"},
+
+		{"TestPosterOverlap", // 4x4 grid of overlapping A6 tiles => A2
+			"test.pdf", // A4
+			"cut",
+			"posterOverlap",
+			types.CENTIMETRES,
+			"f:A6, scale:2.0, overlap:1, cutmarks:on, glue:on, labels:on"},
 	} {
 		testPoster(t, tt.msg, tt.inFile, tt.outDir, tt.outFile, tt.unit, tt.cutConf)
 	}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// writeSkewedScan writes a grayscale page image with text like lines skewed clockwise by angle degrees
+// and n isolated speckles.
+func writeSkewedScan(t *testing.T, fileName string, angle float64, n int) {
+	t.Helper()
+
+	w, h := 850, 1100
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+
+	tan := math.Tan(angle * math.Pi / 180)
+	for y0 := 150; y0 < h-150; y0 += 30 {
+		for x := 100; x < w-100; x++ {
+			if x%40 > 34 {
+				// word gap
+				continue
+			}
+			y := y0 + int(math.Round(float64(x)*tan))
+			for dy := 0; dy < 8; dy++ {
+				img.Pix[(y+dy)*img.Stride+x] = 0x20
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		img.Pix[(30+i*10)*img.Stride+30] = 0
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeskewPages(t *testing.T) {
+	msg := "TestDeskewPages"
+
+	imgFile := filepath.Join(outDir, "skewedScan.png")
+	scanFile := filepath.Join(outDir, "skewedScan.pdf")
+	outFile := filepath.Join(outDir, "deskewedScan.pdf")
+
+	writeSkewedScan(t, imgFile, 2, 5)
+
+	imp, err := pdfcpu.ParseImportDetails("pos:full", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ImportImagesFile([]string{imgFile}, scanFile, imp, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	dpp, err := api.DeskewPagesFile(scanFile, outFile, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(dpp) != 1 {
+		t.Fatalf("%s: want 1 processed page, got %d\n", msg, len(dpp))
+	}
+
+	dp := dpp[0]
+	if math.Abs(dp.Angle-2) > .2 {
+		t.Fatalf("%s: want skew angle 2, got %.2f\n", msg, dp.Angle)
+	}
+	if dp.Speckles != 5 {
+		t.Fatalf("%s: want 5 removed speckles, got %d\n", msg, dp.Speckles)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// A deskewed page has no skew left.
+	dpp, err = api.DeskewPagesFile(outFile, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(dpp) != 1 || math.Abs(dpp[0].Angle) > .2 || dpp[0].Speckles > 0 {
+		t.Fatalf("%s: unexpected result for deskewed page: %+v\n", msg, dpp)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestDocumentManifest(t *testing.T) {
+	msg := "TestDocumentManifest"
+
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+	outFile := filepath.Join(outDir, "manifest.pdf")
+
+	m, err := api.DocumentManifestFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(m.Pages) == 0 {
+		t.Fatalf("%s: empty manifest\n", msg)
+	}
+
+	// Rewriting without object streams renumbers objects and reencodes streams.
+	conf := model.NewDefaultConfiguration()
+	conf.WriteObjectStream = false
+	conf.WriteXRefStream = false
+	if err := api.OptimizeFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pp, err := api.VerifyManifestFile(outFile, *m, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pp) > 0 {
+		t.Fatalf("%s: unexpected mismatching pages: %v\n", msg, pp)
+	}
+
+	// Tamper with page 1.
+	if err := api.RotateFile(outFile, "", 90, []string{"1"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pp, err = api.VerifyManifestFile(outFile, *m, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !reflect.DeepEqual(pp, []int{1}) {
+		t.Fatalf("%s: want mismatch for page 1, got: %v\n", msg, pp)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	s, err := api.PageDigest(f, 1, "SHA-256", nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if s != m.Pages[0].Digest {
+		t.Fatalf("%s: digest mismatch: %s != %s\n", msg, s, m.Pages[0].Digest)
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := api.PageDigest(f, 1, "MD4", nil); err == nil {
+		t.Fatalf("%s: missing error for unsupported algorithm\n", msg)
+	}
+}
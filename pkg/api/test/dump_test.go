@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestDumpJSONAndRebuild(t *testing.T) {
+	msg := "TestDumpJSONAndRebuild"
+
+	for _, fn := range []string{"go.pdf", "Acroforms2.pdf", "TheGoProgrammingLanguageCh1.pdf"} {
+		inFile := filepath.Join(inDir, fn)
+		jsonFile := filepath.Join(outDir, fn+".json")
+		outFile := filepath.Join(outDir, "rebuilt_"+fn)
+
+		if err := api.DumpJSONFile(inFile, jsonFile, true, nil); err != nil {
+			t.Fatalf("%s dump %s: %v\n", msg, fn, err)
+		}
+
+		if err := api.RebuildFromJSONFile(jsonFile, outFile, nil); err != nil {
+			t.Fatalf("%s rebuild %s: %v\n", msg, fn, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s validate %s: %v\n", msg, outFile, err)
+		}
+
+		n1, err := api.PageCountFile(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		n2, err := api.PageCountFile(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if n1 != n2 {
+			t.Fatalf("%s %s: pageCount want %d got %d\n", msg, fn, n1, n2)
+		}
+	}
+}
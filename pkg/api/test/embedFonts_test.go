@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestEmbedMissingFonts(t *testing.T) {
+	msg := "TestEmbedMissingFonts"
+	inFile := filepath.Join(outDir, "fontsNotEmbedded.pdf")
+	outFile := filepath.Join(outDir, "fontsEmbedded.pdf")
+
+	json := `{
+	"pages": {
+		"1": {
+			"content": {
+				"text": [
+					{"value": "Hello Grüße", "pos": [50, 700], "font": {"name": "Helvetica", "size": 12}},
+					{"value": "Hello", "pos": [50, 650], "font": {"name": "Courier", "size": 12}}
+				]
+			}
+		}
+	}
+}`
+	var buf bytes.Buffer
+	if err := api.Create(nil, strings.NewReader(json), &buf, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := os.WriteFile(inFile, buf.Bytes(), os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Use Roboto-Regular in place of Helvetica, leave Courier alone.
+	resolve := func(baseFont string) string {
+		if baseFont == "Helvetica" {
+			return "Roboto-Regular"
+		}
+		return ""
+	}
+
+	fes, err := api.EmbedMissingFontsFile(inFile, outFile, resolve, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(fes) != 2 {
+		t.Fatalf("%s: want 2 unembedded fonts, got %+v\n", msg, fes)
+	}
+	for _, fe := range fes {
+		if fe.Embedded() != (fe.BaseFont == "Helvetica") {
+			t.Fatalf("%s: unexpected result: %+v\n", msg, fe)
+		}
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.OptimizeContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Text extraction is not affected.
+	s, err := pdfcpu.ExtractPageText(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !strings.Contains(s, "Hello Grüße") {
+		t.Fatalf("%s: missing text in %q\n", msg, s)
+	}
+
+	ff, err := pdfcpu.ExtractFonts(ctx, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ff) != 1 || ff[0].Name != "Roboto-Regular" || ff[0].Subtype != "TrueType" || ff[0].Type != "ttf" {
+		t.Fatalf("%s: unexpected embedded fonts: %+v\n", msg, ff)
+	}
+
+	// Nothing left to embed except Courier.
+	if fes, err = api.EmbedMissingFontsFile(outFile, "", resolve, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(fes) != 1 || fes[0].BaseFont != "Courier" {
+		t.Fatalf("%s: unexpected result: %+v\n", msg, fes)
+	}
+}
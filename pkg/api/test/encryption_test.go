@@ -17,9 +17,18 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
@@ -230,3 +239,465 @@ func TestSetPermissions(t *testing.T) {
 		t.Fatalf("%s: got: %d want: %d", msg, uint16(*p), uint16(permNew))
 	}
 }
+
+func TestInPlaceEncryptionChanges(t *testing.T) {
+	msg := "TestInPlaceEncryptionChanges"
+
+	for _, fileName := range []string{
+		"5116.DCT_Filter.pdf",
+		filepath.Join("pdf20", "SimplePDF2.0.pdf"),
+	} {
+		inFile := filepath.Join(inDir, fileName)
+		outFile := filepath.Join(outDir, "test.pdf")
+
+		conf := model.NewAESConfiguration("upw", "opw", 256)
+		if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+			t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+		}
+
+		bb, err := os.ReadFile(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		// Set all permissions.
+		conf = model.NewAESConfiguration("upw", "opw", 256)
+		conf.Permissions = model.PermissionsAll
+		if err := api.SetPermissionsAsIncrementFile(outFile, conf); err != nil {
+			t.Fatalf("%s: set permissions %s: %v\n", msg, outFile, err)
+		}
+
+		// Change owner password.
+		f, err := os.OpenFile(outFile, os.O_RDWR, 0644)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		conf = model.NewAESConfiguration("upw", "opw", 256)
+		if err := api.ChangeOwnerPasswordAsIncrement(f, "opw", "opwNew", conf); err != nil {
+			t.Fatalf("%s: change opw %s: %v\n", msg, outFile, err)
+		}
+		f.Close()
+
+		// Both changes have been appended.
+		bb1, err := os.ReadFile(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if len(bb1) <= len(bb) || !bytes.Equal(bb, bb1[:len(bb)]) {
+			t.Fatalf("%s: %s not written incrementally\n", msg, outFile)
+		}
+
+		// The user password still works and reveals the new permissions.
+		p, err := api.GetPermissionsFile(outFile, model.NewAESConfiguration("upw", "", 256))
+		if err != nil {
+			t.Fatalf("%s: get permissions %s: %v\n", msg, outFile, err)
+		}
+		if p == nil || uint16(*p) != uint16(model.PermissionsAll) {
+			t.Fatalf("%s: unexpected permissions for %s\n", msg, outFile)
+		}
+
+		// The new owner password works, the old one doesn't.
+		if _, err := api.GetPermissionsFile(outFile, model.NewAESConfiguration("", "opwNew", 256)); err != nil {
+			t.Fatalf("%s: get permissions using new opw %s: %v\n", msg, outFile, err)
+		}
+		if _, err := api.GetPermissionsFile(outFile, model.NewAESConfiguration("", "opw", 256)); err == nil {
+			t.Fatalf("%s: get permissions using old opw %s\n", msg, outFile)
+		}
+
+		conf = model.NewAESConfiguration("upw", "opwNew", 256)
+		if err := api.ValidateFile(outFile, conf); err != nil {
+			t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+		}
+	}
+
+	// For R <= 4 the file encryption key depends on the permissions.
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	outFile := filepath.Join(outDir, "test.pdf")
+	conf := model.NewAESConfiguration("upw", "opw", 128)
+	if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+	}
+	conf = model.NewAESConfiguration("upw", "opw", 128)
+	conf.Permissions = model.PermissionsAll
+	if err := api.SetPermissionsAsIncrementFile(outFile, conf); err != pdfcpu.ErrReencryptionRequired {
+		t.Fatalf("%s: want ErrReencryptionRequired, got: %v\n", msg, err)
+	}
+}
+
+func TestUnicodePasswords(t *testing.T) {
+	msg := "TestUnicodePasswords"
+
+	for _, fileName := range []string{
+		"5116.DCT_Filter.pdf",
+		filepath.Join("pdf20", "SimplePDF2.0.pdf"),
+	} {
+		inFile := filepath.Join(inDir, fileName)
+		outFile := filepath.Join(outDir, "test.pdf")
+
+		// U+FB01 (ligature fi) and U+00A0 (no-break space) are subject to SASLprep.
+		conf := model.NewAESConfiguration("grüße ﬁ", "own er", 256)
+		if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+			t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+		}
+
+		conf = model.NewAESConfiguration("grüße fi", "", 256)
+		if err := api.ValidateFile(outFile, conf); err != nil {
+			t.Fatalf("%s: validate %s using upw: %v\n", msg, outFile, err)
+		}
+
+		conf = model.NewAESConfiguration("", "own er", 256)
+		if err := api.ValidateFile(outFile, conf); err != nil {
+			t.Fatalf("%s: validate %s using opw: %v\n", msg, outFile, err)
+		}
+
+		conf = model.NewAESConfiguration("grusse fi", "", 256)
+		if err := api.ValidateFile(outFile, conf); err == nil {
+			t.Fatalf("%s: validate %s using wrong upw\n", msg, outFile)
+		}
+	}
+}
+
+func TestPlaintextMetadata(t *testing.T) {
+	msg := "TestPlaintextMetadata"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+	outFile := filepath.Join(outDir, "test.pdf")
+
+	for _, keyLength := range []int{128, 256} {
+		conf := model.NewAESConfiguration("upw", "opw", keyLength)
+		conf.PlaintextMetadata = true
+		if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+			t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+		}
+
+		bb, err := os.ReadFile(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if !bytes.Contains(bb, []byte("<?xpacket")) {
+			t.Fatalf("%s: %s: metadata encrypted\n", msg, outFile)
+		}
+
+		conf = model.NewAESConfiguration("upw", "opw", keyLength)
+		if err := api.DecryptFile(outFile, "", conf); err != nil {
+			t.Fatalf("%s: decrypt %s: %v\n", msg, outFile, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+		}
+	}
+
+	// Crypt filters need V 4 or higher.
+	conf := model.NewRC4Configuration("upw", "opw", 40)
+	conf.PlaintextMetadata = true
+	if err := api.EncryptFile(inFile, outFile, conf); err == nil {
+		t.Fatalf("%s: encrypt %s using RC4-40 and plaintext metadata\n", msg, outFile)
+	}
+}
+
+func TestEncryptEmbeddedFilesOnly(t *testing.T) {
+	msg := "TestEncryptEmbeddedFilesOnly"
+	inFile := filepath.Join(outDir, "Acroforms2.pdf")
+	attFile := filepath.Join(outDir, "secret.txt")
+	outFile := filepath.Join(outDir, "test.pdf")
+
+	copyFile(t, filepath.Join(inDir, "Acroforms2.pdf"), inFile)
+
+	want := []byte("This is a secret attachment.")
+	if err := os.WriteFile(attFile, want, 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AddAttachmentsFile(inFile, "", []string{attFile}, false, nil); err != nil {
+		t.Fatalf("%s: add attachment: %v\n", msg, err)
+	}
+
+	conf := model.NewAESConfiguration("", "opw", 256)
+	conf.EncryptEmbeddedFilesOnly = true
+	if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+	}
+
+	// Regular streams remain plaintext.
+	bb, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.Contains(bb, []byte("<?xpacket")) {
+		t.Fatalf("%s: %s: metadata encrypted\n", msg, outFile)
+	}
+	if !bytes.Contains(bb, []byte("/EFF")) || bytes.Contains(bb, want) {
+		t.Fatalf("%s: %s: embedded file not encrypted\n", msg, outFile)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	aa, err := api.ExtractAttachmentsRaw(f, "", nil, nil)
+	if err != nil {
+		t.Fatalf("%s: extract attachments: %v\n", msg, err)
+	}
+	if len(aa) != 1 {
+		t.Fatalf("%s: want 1 attachment, got %d\n", msg, len(aa))
+	}
+	got, err := io.ReadAll(aa[0])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s: attachment mismatch: %s\n", msg, got)
+	}
+}
+
+func extractSingleAttachment(t *testing.T, fileName string, conf *model.Configuration) ([]byte, error) {
+	t.Helper()
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	aa, err := api.ExtractAttachmentsRaw(f, "", nil, conf)
+	if err != nil {
+		return nil, err
+	}
+	if len(aa) != 1 {
+		t.Fatalf("want 1 attachment, got %d\n", len(aa))
+	}
+	return io.ReadAll(aa[0])
+}
+
+func TestEncryptedAttachmentsOnly(t *testing.T) {
+	msg := "TestEncryptedAttachmentsOnly"
+	inFile := filepath.Join(outDir, "Acroforms2.pdf")
+	attFile := filepath.Join(outDir, "secret.txt")
+	outFile := filepath.Join(outDir, "test.pdf")
+
+	copyFile(t, filepath.Join(inDir, "Acroforms2.pdf"), inFile)
+
+	want := []byte("This is a secret attachment.")
+	if err := os.WriteFile(attFile, want, 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AddAttachmentsFile(inFile, "", []string{attFile}, false, nil); err != nil {
+		t.Fatalf("%s: add attachment: %v\n", msg, err)
+	}
+
+	for _, keyLength := range []int{128, 256} {
+		conf := model.NewAESConfiguration("upw", "opw", keyLength)
+		conf.Permissions = model.PermissionsAll
+		conf.EncryptEmbeddedFilesOnly = true
+		if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+			t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+		}
+
+		// The document body is accessible w/o password.
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: validate %s w/o password: %v\n", msg, outFile, err)
+		}
+
+		f, err := os.Open(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		aa, err := api.Attachments(f, nil)
+		f.Close()
+		if err != nil || len(aa) != 1 {
+			t.Fatalf("%s: list attachments w/o password: %d %v\n", msg, len(aa), err)
+		}
+
+		// Attachments need the user password.
+		if _, err := extractSingleAttachment(t, outFile, nil); err != model.ErrEmbeddedFilesLocked {
+			t.Fatalf("%s: extract attachment w/o password: %v\n", msg, err)
+		}
+
+		// Modifications need the user password.
+		if err := api.OptimizeFile(outFile, filepath.Join(outDir, "test1.pdf"), nil); err == nil {
+			t.Fatalf("%s: optimize %s w/o password\n", msg, outFile)
+		}
+
+		got, err := extractSingleAttachment(t, outFile, model.NewAESConfiguration("upw", "", keyLength))
+		if err != nil {
+			t.Fatalf("%s: extract attachment: %v\n", msg, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s: attachment mismatch: %s\n", msg, got)
+		}
+	}
+
+	// Public-key security handler
+	cert, key := selfSignedCert(t, "recipient")
+
+	conf := model.NewAESConfiguration("", "", 256)
+	conf.EncryptRecipients = []*x509.Certificate{cert}
+	conf.Permissions = model.PermissionsAll
+	conf.EncryptEmbeddedFilesOnly = true
+	if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate %s w/o private key: %v\n", msg, outFile, err)
+	}
+	if _, err := extractSingleAttachment(t, outFile, nil); err != model.ErrEmbeddedFilesLocked {
+		t.Fatalf("%s: extract attachment w/o private key: %v\n", msg, err)
+	}
+
+	conf = model.NewDefaultConfiguration()
+	conf.DecryptCert, conf.DecryptKey = cert, key
+	got, err := extractSingleAttachment(t, outFile, conf)
+	if err != nil {
+		t.Fatalf("%s: extract attachment: %v\n", msg, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s: attachment mismatch: %s\n", msg, got)
+	}
+}
+
+func selfSignedCert(t *testing.T, cn string) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key
+}
+
+func testPubSecEncryption(t *testing.T, fileName string, keyLength int) {
+	t.Helper()
+	msg := "testPubSecEncryption"
+
+	inFile := filepath.Join(inDir, fileName)
+	outFile := filepath.Join(outDir, "test.pdf")
+
+	cert1, key1 := selfSignedCert(t, "recipient1")
+	cert2, key2 := selfSignedCert(t, "recipient2")
+	cert3, key3 := selfSignedCert(t, "stranger")
+
+	// Encrypt file for 2 recipients.
+	conf := model.NewAESConfiguration("", "", keyLength)
+	conf.EncryptRecipients = []*x509.Certificate{cert1, cert2}
+	if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+	}
+
+	// Reading w/o private key should fail.
+	if err := api.ValidateFile(outFile, nil); err == nil {
+		t.Fatalf("%s: validate %s w/o private key\n", msg, outFile)
+	}
+
+	// Reading using a non recipient key should fail.
+	conf = model.NewDefaultConfiguration()
+	conf.DecryptCert, conf.DecryptKey = cert3, key3
+	if err := api.ValidateFile(outFile, conf); err == nil {
+		t.Fatalf("%s: validate %s using stranger key\n", msg, outFile)
+	}
+
+	// Any recipient may read.
+	conf = model.NewDefaultConfiguration()
+	conf.DecryptCert, conf.DecryptKey = cert1, key1
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+	}
+
+	// Decrypt file.
+	conf = model.NewDefaultConfiguration()
+	conf.DecryptCert, conf.DecryptKey = cert2, key2
+	if err := api.DecryptFile(outFile, "", conf); err != nil {
+		t.Fatalf("%s: decrypt %s: %v\n", msg, outFile, err)
+	}
+
+	// Validate decrypted file.
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+	}
+}
+
+func TestEncryptionKeyCache(t *testing.T) {
+	msg := "TestEncryptionKeyCache"
+	inFile := filepath.Join(inDir, "pdf20", "SimplePDF2.0.pdf")
+	outFile := filepath.Join(outDir, "test.pdf")
+
+	pdfcpu.ClearEncryptionKeyCache()
+	defer pdfcpu.ClearEncryptionKeyCache()
+
+	if err := api.EncryptFile(inFile, outFile, model.NewAESConfiguration("upw", "opw", 256)); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+	}
+
+	m := &testMetrics{counters: map[string]int64{}, timers: map[string]time.Duration{}}
+
+	for i := 0; i < 3; i++ {
+		conf := model.NewAESConfiguration("upw", "", 256)
+		conf.CacheEncryptionKeys = true
+		conf.Metrics = m
+		if err := api.ValidateFile(outFile, conf); err != nil {
+			t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+		}
+	}
+	if n := m.counters[model.MetricKeyCacheHits]; n != 2 {
+		t.Fatalf("%s: want 2 key cache hits, got %d\n", msg, n)
+	}
+
+	// Rejected passwords are not cached and get reported.
+	errThrottled := errors.New("too many attempts")
+	var rejected int
+
+	for i := 0; i < 2; i++ {
+		conf := model.NewAESConfiguration("wrong", "", 256)
+		conf.CacheEncryptionKeys = true
+		conf.Metrics = m
+		conf.PasswordRejected = func(id []byte) error {
+			if len(id) == 0 {
+				t.Fatalf("%s: missing file ID\n", msg)
+			}
+			if rejected++; rejected > 1 {
+				return errThrottled
+			}
+			return nil
+		}
+		err := api.ValidateFile(outFile, conf)
+		if i == 0 && err != pdfcpu.ErrWrongPassword {
+			t.Fatalf("%s: want wrong password error, got %v\n", msg, err)
+		}
+		if i == 1 && err != errThrottled {
+			t.Fatalf("%s: want throttling error, got %v\n", msg, err)
+		}
+	}
+	if n := m.counters[model.MetricKeyCacheHits]; n != 2 {
+		t.Fatalf("%s: want 2 key cache hits, got %d\n", msg, n)
+	}
+}
+
+func TestPubSecEncryption(t *testing.T) {
+	for _, fileName := range []string{
+		"5116.DCT_Filter.pdf",
+		"adobe_errata.pdf",
+	} {
+		testPubSecEncryption(t, fileName, 128)
+		testPubSecEncryption(t, fileName, 256)
+	}
+}
@@ -17,15 +17,23 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -134,6 +142,116 @@ func TestExtractImagesSoftMasks(t *testing.T) {
 	}
 }
 
+func TestExtractImagesComposeMasks(t *testing.T) {
+	msg := "TestExtractImagesComposeMasks"
+	inFile := filepath.Join(inDir, "VectorApple.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	conf := model.NewDefaultConfiguration()
+	conf.ComposeImageMasks = true
+
+	pages, err := api.ExtractImagesRaw(f, nil, conf)
+	if err != nil {
+		t.Fatalf("%s extract: %v\n", msg, err)
+	}
+
+	// IndexedCMYK and DeviceCMYK images w/ softmask.
+	want := map[int]bool{36: true, 245: true}
+
+	var composed int
+	for _, m := range pages {
+		for _, img := range m {
+			if !want[img.ObjNr] {
+				continue
+			}
+			if img.FileType != "png" {
+				t.Fatalf("%s: obj#%d want png, got %s\n", msg, img.ObjNr, img.FileType)
+			}
+			im, err := png.Decode(img)
+			if err != nil {
+				t.Fatalf("%s: obj#%d decode: %v\n", msg, img.ObjNr, err)
+			}
+			if _, ok := im.(*image.NRGBA); !ok {
+				t.Fatalf("%s: obj#%d want NRGBA, got %T\n", msg, img.ObjNr, im)
+			}
+			composed++
+		}
+	}
+
+	if composed != len(want) {
+		t.Fatalf("%s: want %d composed images, got %d\n", msg, len(want), composed)
+	}
+}
+
+func TestComposeImageMatte(t *testing.T) {
+	msg := "TestComposeImageMatte"
+
+	ctx, err := pdfcpu.CreateContextWithXRefTable(nil, types.PaperSize["A4"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// A soft mask with alpha 1 and .5 and white as matte color.
+	smask := types.StreamDict{
+		Dict: types.Dict{
+			"Type":             types.Name("XObject"),
+			"Subtype":          types.Name("Image"),
+			"Width":            types.Integer(2),
+			"Height":           types.Integer(1),
+			"ColorSpace":       types.Name(model.DeviceGrayCS),
+			"BitsPerComponent": types.Integer(8),
+			"Matte":            types.Array{types.Float(1), types.Float(1), types.Float(1)},
+		},
+		Raw:     []byte{255, 128},
+		Content: []byte{255, 128},
+	}
+	ir, err := ctx.IndRefForNewObject(smask)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Two red pixels preblended with white.
+	b := []byte{255, 0, 0, 255, 127, 127}
+	sd := &types.StreamDict{
+		Dict: types.Dict{
+			"Type":             types.Name("XObject"),
+			"Subtype":          types.Name("Image"),
+			"Width":            types.Integer(2),
+			"Height":           types.Integer(1),
+			"ColorSpace":       types.Name(model.DeviceRGBCS),
+			"BitsPerComponent": types.Integer(8),
+			"SMask":            *ir,
+		},
+		Raw:     b,
+		Content: b,
+	}
+
+	r, typ, err := pdfcpu.ComposeImage(ctx.XRefTable, sd, false, 0)
+	if err != nil || r == nil || typ != "png" {
+		t.Fatalf("%s: compose failed: %v\n", msg, err)
+	}
+	im, err := png.Decode(r)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	img, ok := im.(*image.NRGBA)
+	if !ok {
+		t.Fatalf("%s: want NRGBA, got %T\n", msg, im)
+	}
+
+	for x, wantA := range []uint8{255, 128} {
+		c := img.NRGBAAt(x, 0)
+		if c.R != 255 || c.G > 1 || c.B > 1 || c.A != wantA {
+			t.Fatalf("%s: pixel %d: want red with alpha %d, got %v\n", msg, x, wantA, c)
+		}
+	}
+}
+
 func TestExtractImagesLowLevel(t *testing.T) {
 	msg := "TestExtractImagesLowLevel"
 	fileName := "testImage.pdf"
@@ -215,6 +333,124 @@ func TestExtractFontsLowLevel(t *testing.T) {
 	}
 }
 
+// sfntWithout returns the TrueType font program bb without the tables tags.
+func sfntWithout(bb []byte, tags ...string) []byte {
+	type table struct {
+		tag  string
+		data []byte
+	}
+	var tt []table
+	for i := 0; i < int(binary.BigEndian.Uint16(bb[4:])); i++ {
+		rec := bb[12+16*i:]
+		tag := string(rec[:4])
+		if slices.Contains(tags, tag) {
+			continue
+		}
+		off, l := binary.BigEndian.Uint32(rec[8:]), binary.BigEndian.Uint32(rec[12:])
+		tt = append(tt, table{tag, bb[off : off+(l+3)&^3]})
+	}
+
+	var hdr, data bytes.Buffer
+	hdr.Write(bb[:4])
+	binary.Write(&hdr, binary.BigEndian, []uint16{uint16(len(tt)), 0, 0, 0})
+	off := 12 + 16*len(tt)
+	for _, t := range tt {
+		hdr.WriteString(t.tag)
+		binary.Write(&hdr, binary.BigEndian, []uint32{0, uint32(off + data.Len()), uint32(len(t.data))})
+		data.Write(t.data)
+	}
+
+	return append(hdr.Bytes(), data.Bytes()...)
+}
+
+func TestExtractFontsWithMetadata(t *testing.T) {
+	msg := "TestExtractFontsWithMetadata"
+
+	json := `{
+	"pages": {
+		"1": {
+			"content": {
+				"text": [
+					{"value": "Grüße", "pos": [50, 700], "font": {"name": "Roboto-Regular", "size": 12}},
+					{"value": "Hello", "pos": [50, 650], "font": {"name": "Helvetica", "size": 12}}
+				]
+			}
+		}
+	}
+}`
+	var buf bytes.Buffer
+	if err := api.Create(nil, strings.NewReader(json), &buf, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContext(bytes.NewReader(buf.Bytes()), conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Strip the embedded subset of Roboto-Regular down to the tables required for rendering.
+	for objNr, entry := range ctx.Table {
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || sd.IntEntry("Length1") == nil {
+			continue
+		}
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if !bytes.HasPrefix(sd.Content, []byte{0, 1, 0, 0}) {
+			continue
+		}
+		sd.Content = sfntWithout(sd.Content, "cmap", "name", "post", "OS/2")
+		if err := sd.Encode(); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		ctx.Table[objNr].Object = sd
+	}
+
+	if err := api.OptimizeContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ff, err := pdfcpu.ExtractFonts(ctx, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ff) != 1 {
+		t.Fatalf("%s: want 1 embedded font, got %d\n", msg, len(ff))
+	}
+
+	f := ff[0]
+	if f.Name != "Roboto-Regular" || f.Type != "ttf" || f.Subtype != "Type0" || f.Encoding != "Identity-H" || len(f.Prefix) != 6 {
+		t.Fatalf("%s: unexpected font metadata: %+v\n", msg, f)
+	}
+
+	bb, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, f.Prefix+"+"+f.Name+"."+f.Type), bb, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The reconstructed cmap table maps the used characters to their glyphs.
+	m, err := font.GlyphUnicodes(bb)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	var sb strings.Builder
+	for _, u := range m {
+		sb.WriteRune(rune(u))
+	}
+	for _, r := range "Grüße" {
+		if !strings.ContainsRune(sb.String(), r) {
+			t.Fatalf("%s: missing %q in cmap: %q\n", msg, r, sb.String())
+		}
+	}
+}
+
 func TestExtractPages(t *testing.T) {
 	msg := "TestExtractPages"
 	// Extract page #1 into outDir.
@@ -283,6 +519,47 @@ func TestExtractContentLowLevel(t *testing.T) {
 	t.Logf("Page content (PDF-syntax) for page %d:\n%s", i, string(bb))
 }
 
+func TestExtractSVG(t *testing.T) {
+	msg := "TestExtractSVG"
+	// Render all pages into SVG files in outDir.
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	if err := api.ExtractSVGFile(inFile, outDir, nil, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+}
+
+func TestExtractPageAsSVGLowLevel(t *testing.T) {
+	msg := "TestExtractPageAsSVGLowLevel"
+	inFile := filepath.Join(inDir, "VectorApple.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s read context: %v\n", msg, err)
+	}
+
+	r, err := pdfcpu.ExtractPageAsSVG(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s extractPageAsSVG: %v\n", msg, err)
+	}
+
+	bb, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("%s readAll: %v\n", msg, err)
+	}
+
+	// Make sure we produced well formed XML.
+	dec := xml.NewDecoder(bytes.NewReader(bb))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("%s: invalid svg: %v\n", msg, err)
+		}
+	}
+}
+
 func TestExtractMetadata(t *testing.T) {
 	msg := "TestExtractMetadata"
 	// Extract all metadata into outDir.
@@ -318,3 +595,249 @@ func TestExtractMetadataLowLevel(t *testing.T) {
 			md.ObjNr, md.ParentObjNr, md.ParentType, string(bb))
 	}
 }
+
+func TestExtractPaths(t *testing.T) {
+	msg := "TestExtractPaths"
+
+	inFile := filepath.Join(inDir, "VectorApple.pdf")
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	m, err := api.ExtractPaths(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+	curves := 0
+	for _, p := range m[1] {
+		for _, s := range p.Segments {
+			if s.Op == pdfcpu.PathCurveTo {
+				curves++
+			}
+		}
+	}
+	if len(m[1]) == 0 || curves == 0 {
+		t.Fatalf("%s %s: missing paths\n", msg, inFile)
+	}
+
+	if err := api.ExtractPathsFile(inFile, outDir, nil, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+
+	// Check a well known path.
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := ctx.AppendContent(d, []byte("q 2 0 0 2 10 10 cm 1 0 0 RG 3 w [2 1] 0 d 0 0 m 10 0 l 10 10 5 15 0 10 c S Q")); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	paths, err := pdfcpu.ExtractPaths(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	p := paths[len(paths)-1]
+
+	if !p.Stroke || p.Fill || p.LineWidth != 3 || p.StrokeColorSpace != "DeviceRGB" || fmt.Sprint(p.StrokeColor) != "[1 0 0]" || fmt.Sprint(p.DashArray) != "[2 1]" {
+		t.Fatalf("%s: unexpected path state: %+v\n", msg, p)
+	}
+
+	var sb strings.Builder
+	for _, s := range p.UserSpace() {
+		sb.WriteString(s.Op)
+		for _, pt := range s.Points {
+			fmt.Fprintf(&sb, " %.0f %.0f", pt.X, pt.Y)
+		}
+		sb.WriteString(" ")
+	}
+	want := "m 10 10 l 30 10 c 30 30 20 40 10 30 "
+	if got := sb.String(); got != want {
+		t.Fatalf("%s: got %s, want %s\n", msg, got, want)
+	}
+}
+
+func TestExtract3DModels(t *testing.T) {
+	msg := "TestExtract3DModels"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "3D.pdf")
+
+	// A fake U3D payload is sufficient for a round trip.
+	data := append([]byte("U3D\x00"), bytes.Repeat([]byte{0x01, 0x02, 0x03}, 100)...)
+
+	views := []model.ThreeDView{
+		{Name: "Front", C2W: []float64{1, 0, 0, 0, 0, -1, 0, 1, 0, 0, -100, 0}, CO: 100},
+		{Name: "Top"},
+	}
+
+	ann := model.NewThreeDAnnotation(
+		*types.NewRectangle(100, 100, 400, 400), // rect
+		0,                                       // apObjNr
+		"3D model",                              // contents
+		"ID3D",                                  // id
+		"",                                      // modDate
+		model.AnnPrint,                          // f
+		nil,                                     // col
+		data,                                    // data
+		"",                                      // format
+		views,                                   // views
+		"PV",                                    // activation
+		"PI",                                    // deactivation
+		true)                                    // interactive
+
+	if err := api.AddAnnotationsFile(inFile, outFile, []string{"1"}, ann, nil, false); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	// 3D artwork survives optimization.
+	if err := api.OptimizeFile(outFile, "", nil); err != nil {
+		t.Fatalf("%s optimize: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.ThreeDModels(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(mm) != 1 {
+		t.Fatalf("%s: want 1 3D model, got %d\n", msg, len(mm))
+	}
+
+	m := mm[0]
+	if m.PageNr != 1 || m.Format != "U3D" || m.Activation != "PV" || m.Deactivation != "PI" || !m.Interactive {
+		t.Fatalf("%s: unexpected 3D model: %+v\n", msg, m)
+	}
+	if len(m.Views) != 2 || m.DefaultView != "Front" {
+		t.Fatalf("%s: unexpected views: %v default: %s\n", msg, m.Views, m.DefaultView)
+	}
+
+	bb, err := io.ReadAll(m)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.Equal(bb, data) {
+		t.Fatalf("%s: 3D data mismatch\n", msg)
+	}
+
+	if err := api.Extract3DModelsFile(outFile, outDir, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, fmt.Sprintf("3D_3D_1_%d.u3d", m.StreamObjNr))); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ann.Data = []byte("no 3D")
+	ann.Format = model.ThreeDFormat(ann.Data)
+	if err := api.AddAnnotationsFile(inFile, outFile, nil, ann, nil, false); err == nil {
+		t.Fatalf("%s: missing error for unsupported 3D format\n", msg)
+	}
+}
+
+func TestExtractMediaAssets(t *testing.T) {
+	msg := "TestExtractMediaAssets"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "video.pdf")
+
+	// A fake MP4 payload is sufficient for a round trip.
+	data := append([]byte("\x00\x00\x00\x18ftypmp42"), bytes.Repeat([]byte{0x04, 0x05, 0x06}, 100)...)
+	videoFile := filepath.Join(outDir, "video.mp4")
+	if err := os.WriteFile(videoFile, data, 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	posterFile := filepath.Join(resDir, "logoSmall.png")
+	rect := *types.NewRectangle(100, 100, 420, 340)
+
+	if err := api.AddVideoAnnotationFile(inFile, outFile, 1, rect, videoFile, posterFile, nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	// Media dictionaries survive optimization and validation.
+	if err := api.OptimizeFile(outFile, "", nil); err != nil {
+		t.Fatalf("%s optimize: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.MediaAssets(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(mm) != 1 {
+		t.Fatalf("%s: want 1 media asset, got %d\n", msg, len(mm))
+	}
+
+	m := mm[0]
+	if m.PageNr != 1 || m.Annotation != "Screen" || m.FileName != "video.mp4" || m.ContentType != "video/mp4" {
+		t.Fatalf("%s: unexpected media asset: %+v\n", msg, m)
+	}
+
+	bb, err := io.ReadAll(m)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.Equal(bb, data) {
+		t.Fatalf("%s: media data mismatch\n", msg)
+	}
+
+	if err := api.ExtractMediaAssetsFile(outFile, outDir, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, fmt.Sprintf("video_1_%d_video.mp4", m.ObjNr))); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestMediaAssetsCircularNameTree(t *testing.T) {
+	msg := "TestMediaAssetsCircularNameTree"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// An Assets name tree node listing itself as kid.
+	assets := types.Dict{}
+	ir, err := ctx.IndRefForNewObject(assets)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	assets["Kids"] = types.Array{*ir}
+
+	ann := types.Dict{
+		"Type":             types.Name("Annot"),
+		"Subtype":          types.Name("RichMedia"),
+		"Rect":             types.NewRectangle(0, 0, 100, 100).Array(),
+		"RichMediaContent": types.Dict{"Assets": *ir},
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	pageDict["Annots"] = types.Array{ann}
+
+	if _, err := pdfcpu.MediaAssets(ctx, 1); err == nil {
+		t.Fatalf("%s: circular name tree should fail\n", msg)
+	}
+}
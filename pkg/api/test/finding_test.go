@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func readContextForFindings(t *testing.T, msg, inFile string, conf *model.Configuration) *model.Context {
+	t.Helper()
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	ctx, err := api.ReadContext(f, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	return ctx
+}
+
+func findings(ff []model.Finding, id string) (n int, fatal bool) {
+	for _, f := range ff {
+		if f.ID == id {
+			n++
+			fatal = fatal || f.Fatal
+		}
+	}
+	return n, fatal
+}
+
+func TestFindingSeverityVersion(t *testing.T) {
+	msg := "TestFindingSeverityVersion"
+	inFile := filepath.Join(inDir, "Wonderwall.pdf")
+
+	v := model.V13
+
+	// Pretend inFile claims PDF 1.3 which fails strict validation.
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationStrict
+	ctx := readContextForFindings(t, msg, inFile, conf)
+	ctx.HeaderVersion, ctx.RootVersion = &v, nil
+
+	if err := api.ValidateContext(ctx); err == nil {
+		t.Fatalf("%s: missing version error\n", msg)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingVersion); n != 1 || !fatal {
+		t.Fatalf("%s: want 1 fatal version finding, got %d %t\n", msg, n, fatal)
+	}
+
+	// Downgrade version findings to warnings.
+	conf = model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationStrict
+	conf.FindingSeverity = map[string]model.Severity{model.FindingVersion: model.SeverityWarning}
+	ctx = readContextForFindings(t, msg, inFile, conf)
+	ctx.HeaderVersion, ctx.RootVersion = &v, nil
+
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingVersion); n == 0 || fatal {
+		t.Fatalf("%s: want non fatal version findings, got %d %t\n", msg, n, fatal)
+	}
+
+	bb, err := json.Marshal(ctx.Findings[0])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !strings.Contains(string(bb), `"severity":"warning"`) {
+		t.Fatalf("%s: unexpected json: %s\n", msg, bb)
+	}
+}
+
+func TestFindingSeveritySpecViolation(t *testing.T) {
+	msg := "TestFindingSeveritySpecViolation"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+
+	removeFontType := func(ctx *model.Context) {
+		for _, e := range ctx.Table {
+			if e == nil || e.Free {
+				continue
+			}
+			if d, ok := e.Object.(types.Dict); ok && d.Type() != nil && *d.Type() == "Font" {
+				d.Delete("Type")
+			}
+		}
+	}
+
+	// Relaxed validation digests font dicts missing "Type".
+	conf := model.NewDefaultConfiguration()
+	ctx := readContextForFindings(t, msg, inFile, conf)
+	removeFontType(ctx)
+
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingFontType); n == 0 || fatal {
+		t.Fatalf("%s: want non fatal fontType findings, got %d %t\n", msg, n, fatal)
+	}
+
+	// Promote fontType findings to errors.
+	conf = model.NewDefaultConfiguration()
+	conf.FindingSeverity = map[string]model.Severity{model.FindingFontType: model.SeverityError}
+	ctx = readContextForFindings(t, msg, inFile, conf)
+	removeFontType(ctx)
+
+	if err := api.ValidateContext(ctx); err == nil {
+		t.Fatalf("%s: missing fontType error\n", msg)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingFontType); n != 1 || !fatal {
+		t.Fatalf("%s: want 1 fatal fontType finding, got %d %t\n", msg, n, fatal)
+	}
+}
+
+func TestFindingSeverityPages(t *testing.T) {
+	msg := "TestFindingSeverityPages"
+	inFile := filepath.Join(inDir, "test.pdf")
+
+	emptyContents := func(ctx *model.Context) {
+		rootDict, err := ctx.Catalog()
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		d, err := ctx.DereferenceDict(rootDict["Pages"])
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		for d.Type() != nil && *d.Type() == "Pages" {
+			if d, err = ctx.DereferenceDict(d.ArrayEntry("Kids")[0]); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+		}
+		d["Contents"] = types.Array{}
+	}
+
+	// Relaxed validation digests an empty page content array.
+	ctx := readContextForFindings(t, msg, inFile, model.NewDefaultConfiguration())
+	emptyContents(ctx)
+
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingPages); n != 1 || fatal {
+		t.Fatalf("%s: want 1 non fatal pages finding, got %d %t\n", msg, n, fatal)
+	}
+
+	// Promote pages findings from warning to error.
+	conf := model.NewDefaultConfiguration()
+	conf.FindingSeverity = map[string]model.Severity{model.FindingPages: model.SeverityError}
+	ctx = readContextForFindings(t, msg, inFile, conf)
+	emptyContents(ctx)
+
+	if err := api.ValidateContext(ctx); err == nil {
+		t.Fatalf("%s: missing pages error\n", msg)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingPages); n != 1 || !fatal {
+		t.Fatalf("%s: want 1 fatal pages finding, got %d %t\n", msg, n, fatal)
+	}
+}
+
+func TestFindingInvalidEntryPoint(t *testing.T) {
+	msg := "TestFindingInvalidEntryPoint"
+	inFile := filepath.Join(inDir, "test.pdf")
+
+	ctx := readContextForFindings(t, msg, inFile, model.NewDefaultConfiguration())
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	rootDict["OpenAction"] = types.Integer(1)
+
+	if err := api.ValidateContext(ctx); err == nil {
+		t.Fatalf("%s: missing action error\n", msg)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingAction); n != 1 || !fatal {
+		t.Fatalf("%s: want 1 fatal action finding, got %d %t\n", msg, n, fatal)
+	}
+	if n, _ := findings(ctx.Findings, model.FindingInvalid); n != 0 {
+		t.Fatalf("%s: unexpected invalid finding\n", msg)
+	}
+}
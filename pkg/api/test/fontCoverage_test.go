@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestFontCoverage(t *testing.T) {
+	msg := "TestFontCoverage"
+	inFile := filepath.Join(outDir, "fontCoverage.pdf")
+
+	json := `{
+	"pages": {
+		"1": {
+			"content": {
+				"text": [
+					{"value": "Hello World", "pos": [50, 700], "font": {"name": "Roboto-Regular", "size": 12}},
+					{"value": "Hello", "pos": [50, 650], "font": {"name": "Helvetica", "size": 12}}
+				]
+			}
+		}
+	}
+}`
+	var buf bytes.Buffer
+	if err := api.Create(nil, strings.NewReader(json), &buf, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fcs, err := api.FontCoverage(bytes.NewReader(buf.Bytes()), nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	// Helvetica is not embedded.
+	if len(fcs) != 1 || !fcs[0].Complete() || fcs[0].Referenced != 8 {
+		t.Fatalf("%s: want complete coverage of 8 characters, got %+v\n", msg, fcs)
+	}
+
+	ctx, err := api.ReadContext(bytes.NewReader(buf.Bytes()), conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Show "Z" which is not part of the font subset and a glyph id beyond the glyph count.
+	d, _, inhPAttrs, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fonts, err := ctx.DereferenceDict(inhPAttrs.Resources["Font"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	var id string
+	for k, o := range fonts {
+		if fd, err := ctx.DereferenceDict(o); err == nil && strings.HasSuffix(*fd.NameEntry("BaseFont"), "Roboto-Regular") {
+			id = k
+		}
+	}
+	font.UserFontMetricsLock.RLock()
+	gid := font.UserFontMetrics["Roboto-Regular"].Chars['Z']
+	font.UserFontMetricsLock.RUnlock()
+
+	indRef, err := ctx.StreamDictIndRef([]byte(fmt.Sprintf("BT /%s 12 Tf 50 600 Td <%04X FFF0> Tj ET", id, gid)))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d["Contents"] = types.Array{d["Contents"], *indRef}
+
+	if err := api.WriteContextFile(ctx, inFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if fcs, err = api.FontCoverageFile(inFile, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(fcs) != 1 || fcs[0].Complete() || fcs[0].Referenced != 10 {
+		t.Fatalf("%s: want incomplete coverage of 10 characters, got %+v\n", msg, fcs)
+	}
+	mm := fcs[0].Missing
+	if len(mm) != 2 || mm[0].Code != uint32(gid) || mm[0].Text != "" || mm[1].Code != 0xFFF0 {
+		t.Fatalf("%s: want missing glyphs for Z and <FFF0>, got %+v\n", msg, mm)
+	}
+}
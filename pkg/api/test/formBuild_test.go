@@ -0,0 +1,512 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/primitives"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestBuildForm(t *testing.T) {
+	msg := "TestBuildForm"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "formBuild.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := form.AddTextField(ctx, 1, types.NewRectangle(50, 700, 250, 720),
+		form.TextFieldOptions{FieldOptions: form.FieldOptions{ID: "name", Required: true}, Value: "Jane"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := form.AddTextField(ctx, 1, types.NewRectangle(50, 670, 150, 690),
+		form.TextFieldOptions{FieldOptions: form.FieldOptions{ID: "zip"}, Comb: true, MaxLen: 5}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := form.AddCheckBox(ctx, 1, types.NewRectangle(50, 640, 62, 652),
+		form.CheckBoxOptions{FieldOptions: form.FieldOptions{ID: "subscribe"}, Checked: true}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := form.AddRadioGroup(ctx, 1, form.RadioGroupOptions{
+		FieldOptions: form.FieldOptions{ID: "size"},
+		Buttons: []form.RadioButton{
+			{Value: "S", Rect: types.NewRectangle(50, 610, 62, 622)},
+			{Value: "M", Rect: types.NewRectangle(70, 610, 82, 622)},
+			{Value: "L", Rect: types.NewRectangle(90, 610, 102, 622)},
+		},
+		Value: "M",
+	}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := form.AddComboBox(ctx, 1, types.NewRectangle(50, 580, 150, 600),
+		form.ComboBoxOptions{FieldOptions: form.FieldOptions{ID: "color"}, Options: []string{"red", "green", "blue"}, Value: "green"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := form.AddSignatureField(ctx, 1, types.NewRectangle(300, 100, 500, 150), form.FieldOptions{ID: "signature"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Field ids are unique.
+	if _, err := form.AddCheckBox(ctx, 1, types.NewRectangle(50, 550, 62, 562),
+		form.CheckBoxOptions{FieldOptions: form.FieldOptions{ID: "name"}}); err == nil {
+		t.Fatalf("%s: missing error for duplicate field\n", msg)
+	}
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	fields, err := api.FormFields(f, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	want := map[string]struct {
+		typ form.FieldType
+		v   string
+	}{
+		"name":      {form.FTText, "Jane"},
+		"zip":       {form.FTText, ""},
+		"subscribe": {form.FTCheckBox, "Yes"},
+		"size":      {form.FTRadioButtonGroup, "M"},
+		"color":     {form.FTComboBox, "green"},
+	}
+	// Signature fields are listed as text fields.
+	if len(fields) != len(want)+1 {
+		t.Fatalf("%s: want %d fields, got %d\n", msg, len(want)+1, len(fields))
+	}
+	for _, fi := range fields {
+		if fi.Name == "signature" {
+			continue
+		}
+		w, ok := want[fi.Name]
+		if !ok || fi.Typ != w.typ || fi.V != w.v {
+			t.Fatalf("%s: unexpected field: %+v\n", msg, fi)
+		}
+	}
+
+	// The created form can be filled.
+	conf := model.NewDefaultConfiguration()
+	conf.Cmd = model.RESETFORMFIELDS
+	if err := api.ResetFormFieldsFile(outFile, "", nil, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestBuildFormActions(t *testing.T) {
+	msg := "TestBuildFormActions"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "formBuildActions.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	amount := &form.FieldFormat{Type: form.FormatNumber, Decimals: 2, SepStyle: form.SepDotComma, Currency: "€"}
+	zero, hundred := 0., 100.
+
+	for _, f := range []struct {
+		id, v string
+		y     float64
+		fmt   *form.FieldFormat
+		calc  *form.Calculation
+	}{
+		{"a", "1.5", 700, amount, nil},
+		{"b", "", 670, amount, nil},
+		{"sum", "", 640, amount, &form.Calculation{Op: "SUM", Fields: []string{"a", "b"}}},
+		{"discount", "10", 610, &form.FieldFormat{Type: form.FormatPercent, Min: &zero, Max: &hundred}, nil},
+		{"due", "2026-12-31", 580, &form.FieldFormat{Type: form.FormatDate, DateFormat: "yyyy-mm-dd"}, nil},
+	} {
+		opts := form.TextFieldOptions{FieldOptions: form.FieldOptions{ID: f.id}, Value: f.v, Format: f.fmt, Calculate: f.calc}
+		if _, err := form.AddTextField(ctx, 1, types.NewRectangle(50, f.y, 250, f.y+20), opts); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, f.id, err)
+		}
+	}
+
+	for _, opts := range []form.TextFieldOptions{
+		{FieldOptions: form.FieldOptions{ID: "x"}, Value: "31.12.2026", Format: &form.FieldFormat{Type: form.FormatDate, DateFormat: "yyyy-mm-dd"}},
+		{FieldOptions: form.FieldOptions{ID: "x"}, Value: "101", Format: &form.FieldFormat{Type: form.FormatPercent, Max: &hundred}},
+		{FieldOptions: form.FieldOptions{ID: "x"}, Format: &form.FieldFormat{Type: form.FormatDate, DateFormat: "yy"}},
+		{FieldOptions: form.FieldOptions{ID: "x"}, Calculate: &form.Calculation{Op: "DIV", Fields: []string{"a"}}},
+	} {
+		if _, err := form.AddTextField(ctx, 1, types.NewRectangle(300, 100, 400, 120), opts); err == nil {
+			t.Fatalf("%s: missing error for %+v\n", msg, opts)
+		}
+	}
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	fields, err := api.FormFields(f, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, fi := range fields {
+		if want := fi.Name == "due"; want != (fi.Typ == form.FTDate) {
+			t.Fatalf("%s: unexpected field type: %+v\n", msg, fi)
+		}
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ss, err := api.CalculationOrder(f, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ss) != 1 || ss[0] != "sum" {
+		t.Fatalf("%s: unexpected calculation order: %v\n", msg, ss)
+	}
+}
+
+func TestBuildChoiceFields(t *testing.T) {
+	msg := "TestBuildChoiceFields"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "formChoice.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Choice fields with thousands of options and export values differing from display values.
+	countries, countryCodes := make([]string, 3000), make([]string, 3000)
+	for i := range countries {
+		countries[i], countryCodes[i] = fmt.Sprintf("Country %d", i), fmt.Sprintf("C%04d", i)
+	}
+	tags, tagCodes := make([]string, 2000), make([]string, 2000)
+	for i := range tags {
+		tags[i], tagCodes[i] = fmt.Sprintf("Tag %d", i), fmt.Sprintf("T%04d", i)
+	}
+
+	if _, err := form.AddComboBox(ctx, 1, types.NewRectangle(50, 700, 250, 720), form.ComboBoxOptions{
+		FieldOptions: form.FieldOptions{ID: "country"},
+		Options:      countries,
+		ExportValues: countryCodes,
+		Value:        "Country 7"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := form.AddComboBox(ctx, 1, types.NewRectangle(50, 670, 250, 690), form.ComboBoxOptions{
+		FieldOptions: form.FieldOptions{ID: "invalid"},
+		Options:      countries,
+		ExportValues: countryCodes[1:]}); err == nil {
+		t.Fatalf("%s: missing error for export values mismatch\n", msg)
+	}
+	if _, err := form.AddListBox(ctx, 1, types.NewRectangle(50, 500, 250, 660), form.ListBoxOptions{
+		FieldOptions: form.FieldOptions{ID: "tags"},
+		Options:      tags,
+		ExportValues: tagCodes,
+		Values:       []string{"T0001"},
+		Multi:        true}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Fill using display or export values.
+	json := `{"forms": [{
+		"combobox": [{"name": "country", "value": "Country 1234"}],
+		"listbox": [{"name": "tags", "values": ["T1999", "Tag 42"]}]
+	}]}`
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	var buf bytes.Buffer
+	err = api.FillForm(f, strings.NewReader(json), &buf, nil)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fg, err := api.ExportForm(bytes.NewReader(buf.Bytes()), outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fm := fg.Forms[0]
+	if len(fm.ComboBoxes) != 1 || len(fm.ListBoxes) != 1 {
+		t.Fatalf("%s: missing choice fields\n", msg)
+	}
+	cb, lb := fm.ComboBoxes[0], fm.ListBoxes[0]
+	if cb.Value != "C1234" || len(cb.Options) != 3000 || len(cb.ExportValues) != 3000 {
+		t.Fatalf("%s: unexpected combo box: %s %d %d\n", msg, cb.Value, len(cb.Options), len(cb.ExportValues))
+	}
+	if len(lb.Values) != 2 || lb.Values[0] != "T1999" || lb.Values[1] != "T0042" || len(lb.ExportValues) != 2000 {
+		t.Fatalf("%s: unexpected list box values: %v\n", msg, lb.Values)
+	}
+
+	fields, err := api.FormFields(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, f := range fields {
+		if f.Name == "country" && !strings.HasPrefix(f.Opts, "Country 0[C0000],Country 1[C0001]") {
+			t.Fatalf("%s: unexpected option listing: %.40s\n", msg, f.Opts)
+		}
+	}
+}
+
+func TestSignatureAppearance(t *testing.T) {
+	msg := "TestSignatureAppearance"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "formSignatureAppearance.pdf")
+
+	ctx := buildTextFields(t, msg, inFile, []string{"name"})
+	if _, err := form.AddSignatureField(ctx, 1, types.NewRectangle(50, 500, 290, 580), form.FieldOptions{ID: "signature"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	logo, err := os.ReadFile(filepath.Join(resDir, "logoSmall.png"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// One template applied to any number of signature fields.
+	sa := &primitives.SignatureAppearance{
+		Name:     "Jane Doe",
+		Reason:   "Approval",
+		Location: "Berlin",
+		Date:     time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		Logo:     logo,
+		Graph: [][]types.Point{
+			{{X: 0.1, Y: 0.2}, {X: 0.3, Y: 0.8}, {X: 0.5, Y: 0.3}, {X: 0.9, Y: 0.7}},
+		},
+	}
+
+	if err := api.SetSignatureAppearanceFile(outFile, "", "name", sa, nil); err == nil {
+		t.Fatalf("%s: missing error for non signature field\n", msg)
+	}
+	if err := api.SetSignatureAppearanceFile(outFile, "", "signature", &primitives.SignatureAppearance{}, nil); err == nil {
+		t.Fatalf("%s: missing error for empty appearance\n", msg)
+	}
+	if err := api.SetSignatureAppearanceFile(outFile, "", "signature", sa, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err = api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	widgets, ok := ctx.PageAnnots[1][model.AnnWidget]
+	if !ok || widgets.IndRefs == nil {
+		t.Fatalf("%s: missing widgets\n", msg)
+	}
+	for _, ir := range *widgets.IndRefs {
+		d, err := ctx.DereferenceDict(ir)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if ft := d.NameEntry("FT"); ft == nil || *ft != "Sig" {
+			continue
+		}
+		sd, _, err := ctx.DereferenceStreamDict(d.DictEntry("AP")["N"])
+		if err != nil || sd == nil {
+			t.Fatalf("%s: missing signature appearance: %v\n", msg, err)
+		}
+		res := sd.DictEntry("Resources")
+		if res.DictEntry("Font") == nil || res.DictEntry("XObject") == nil {
+			t.Fatalf("%s: incomplete signature appearance resources\n", msg)
+		}
+		return
+	}
+	t.Fatalf("%s: missing signature field\n", msg)
+}
+
+// fieldAppearance returns the decoded normal appearance of the field named name.
+func fieldAppearance(t *testing.T, msg string, ctx *model.Context, name string) (types.Dict, string) {
+	t.Helper()
+
+	for _, entry := range ctx.Table {
+		d, ok := entry.Object.(types.Dict)
+		if !ok {
+			continue
+		}
+		if s, err := d.StringOrHexLiteralEntry("T"); err != nil || s == nil || *s != name {
+			continue
+		}
+		sd, _, err := ctx.DereferenceStreamDict(d.DictEntry("AP")["N"])
+		if err != nil || sd == nil {
+			t.Fatalf("%s: missing appearance for %s: %v\n", msg, name, err)
+		}
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		return d, string(sd.Content)
+	}
+
+	t.Fatalf("%s: missing field %s\n", msg, name)
+	return nil, ""
+}
+
+func TestCombAndRichTextFields(t *testing.T) {
+	msg := "TestCombAndRichTextFields"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "formCombAndRichText.pdf")
+	outFileFilled := filepath.Join(outDir, "formCombAndRichTextFilled.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := form.AddTextField(ctx, 1, types.NewRectangle(50, 700, 150, 720),
+		form.TextFieldOptions{FieldOptions: form.FieldOptions{ID: "zip"}, Comb: true, MaxLen: 5, Align: types.AlignCenter}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := form.AddTextField(ctx, 1, types.NewRectangle(50, 600, 250, 680),
+		form.TextFieldOptions{FieldOptions: form.FieldOptions{ID: "notes"}, Multiline: true, RichText: true, Value: "Hello\nWorld"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := form.AddTextField(ctx, 1, types.NewRectangle(50, 550, 150, 570),
+		form.TextFieldOptions{FieldOptions: form.FieldOptions{ID: "bad"}, Comb: true, MaxLen: 5, RichText: true}); err == nil {
+		t.Fatalf("%s: missing error for rich text comb field\n", msg)
+	}
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Fill the comb field and the rich text field using an XHTML value.
+	data := `{"forms":[{"textfield":[
+		{"name":"zip","value":"123"},
+		{"name":"notes","value":"<body xmlns=\"http://www.w3.org/1999/xhtml\"><p style=\"color:#FF0000;font-size:10pt\">Red line</p><p>Second line</p></body>"}
+	]}]}`
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	if err := api.FillForm(f, strings.NewReader(data), &buf, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := os.WriteFile(outFileFilled, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFileFilled, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ctx, err = api.ReadContextFile(outFileFilled); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// One glyph per cell, centered within 5 cells.
+	_, s := fieldAppearance(t, msg, ctx, "zip")
+	if n := strings.Count(s, "Tj"); n != 3 {
+		t.Fatalf("%s: zip: want 3 glyphs, got %d: %s\n", msg, n, s)
+	}
+	for _, glyph := range []string{"(1) Tj", "(2) Tj", "(3) Tj"} {
+		if !strings.Contains(s, glyph) {
+			t.Fatalf("%s: zip: missing %s: %s\n", msg, glyph, s)
+		}
+	}
+
+	// The rich text style is applied and the plain text goes into V.
+	d, s := fieldAppearance(t, msg, ctx, "notes")
+	if !strings.Contains(s, " 10 Tf") || !strings.Contains(s, "1.00 0.00 0.00 rg") {
+		t.Fatalf("%s: notes: rich text style not applied: %s\n", msg, s)
+	}
+	if !strings.Contains(s, "(Red line) Tj") || !strings.Contains(s, "(Second line) Tj") {
+		t.Fatalf("%s: notes: missing text: %s\n", msg, s)
+	}
+	if d["RV"] == nil {
+		t.Fatalf("%s: notes: missing RV\n", msg)
+	}
+
+	// Field listings escape line breaks.
+	fields, err := api.FormFields(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, fi := range fields {
+		if fi.Name == "notes" && fi.V != `Red line\nSecond line` {
+			t.Fatalf("%s: notes: unexpected value: %q\n", msg, fi.V)
+		}
+	}
+
+	// Resetting a rich text field removes its rich text value.
+	conf := model.NewDefaultConfiguration()
+	conf.Cmd = model.RESETFORMFIELDS
+	if err := api.ResetFormFieldsFile(outFileFilled, "", []string{"notes"}, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx, err = api.ReadContextFile(outFileFilled); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if d, _ = fieldAppearance(t, msg, ctx, "notes"); d["RV"] != nil {
+		t.Fatalf("%s: notes: RV not reset\n", msg)
+	}
+}
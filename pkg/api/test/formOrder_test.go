@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// buildTextFields adds a text field for each id to page 1 of a validated context read from inFile.
+func buildTextFields(t *testing.T, msg, inFile string, ids []string) *model.Context {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for i, id := range ids {
+		y := 700 - float64(i)*30
+		if _, err := form.AddTextField(ctx, 1, types.NewRectangle(50, y, 250, y+20),
+			form.TextFieldOptions{FieldOptions: form.FieldOptions{ID: id}}); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+
+	return ctx
+}
+
+func widgetNames(t *testing.T, msg, inFile string) []string {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if tabs := d.NameEntry("Tabs"); tabs == nil || *tabs != "W" {
+		t.Fatalf("%s: missing tab order\n", msg)
+	}
+
+	annots, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	var ss []string
+	for _, o := range annots {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if st := d.NameEntry("Subtype"); st == nil || *st != "Widget" {
+			continue
+		}
+		s, err := d.StringOrHexLiteralEntry("T")
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		ss = append(ss, *s)
+	}
+
+	return ss
+}
+
+func TestFormTabOrder(t *testing.T) {
+	msg := "TestFormTabOrder"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "formTabOrder.pdf")
+
+	ctx := buildTextFields(t, msg, inFile, []string{"a", "b", "sum"})
+
+	if err := form.OrderWidgets(ctx, 1, []string{"sum", "a", "b"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := form.OrderWidgets(ctx, 1, []string{"sum", "c"}); err == nil {
+		t.Fatalf("%s: missing error for unknown field\n", msg)
+	}
+	if err := form.SetTabOrder(ctx, 1, "X"); err == nil {
+		t.Fatalf("%s: missing error for invalid tab order\n", msg)
+	}
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SetTabOrderFile(outFile, "", nil, "W", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ss := widgetNames(t, msg, outFile)
+	if len(ss) != 3 || ss[0] != "sum" || ss[1] != "a" || ss[2] != "b" {
+		t.Fatalf("%s: unexpected widget order: %v\n", msg, ss)
+	}
+}
+
+func TestFormCalculationOrder(t *testing.T) {
+	msg := "TestFormCalculationOrder"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "formCalculationOrder.pdf")
+
+	ctx := buildTextFields(t, msg, inFile, []string{"a", "b", "sum", "total"})
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.SetCalculationOrderFile(outFile, "", []string{"total", "sum"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SetCalculationOrderFile(outFile, "", []string{"sum", "c"}, nil); err == nil {
+		t.Fatalf("%s: missing error for unknown field\n", msg)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	ss, err := api.CalculationOrder(f, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ss) != 2 || ss[0] != "total" || ss[1] != "sum" {
+		t.Fatalf("%s: unexpected calculation order: %v\n", msg, ss)
+	}
+
+	// Validation detects calculation orders referring to unknown fields.
+	ctx = readContextForFindings(t, msg, outFile, nil)
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	acroForm, err := ctx.DereferenceDict(rootDict["AcroForm"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ir, err := ctx.IndRefForNewObject(types.Dict{"FT": types.Name("Tx"), "T": types.StringLiteral("orphan")})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	co, err := ctx.DereferenceArray(acroForm["CO"])
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	acroForm["CO"] = append(co, *ir)
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingCalculationOrder); n != 1 || fatal {
+		t.Fatalf("%s: want 1 non fatal calculationOrder finding, got %d %t\n", msg, n, fatal)
+	}
+}
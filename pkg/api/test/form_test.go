@@ -19,11 +19,14 @@ package test
 import (
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 /**************************************************************
@@ -211,6 +214,79 @@ func TestFillForm(t *testing.T) {
 	}
 }
 
+func TestFillFormAutoFontSize(t *testing.T) {
+
+	msg := "TestFillFormAutoFontSize"
+	inFile := filepath.Join(samplesDir, "form", "demoSinglePage", "english.pdf")
+	inFileJSON := filepath.Join(samplesDir, "form", "fill", "english.json")
+	autoFile := filepath.Join(outDir, "englishAutoFontSize.pdf")
+	outFile := filepath.Join(outDir, "englishAutoFontSizeFilled.pdf")
+
+	// Switch all text fields to auto font size (0 Tf).
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	n := 0
+	for _, entry := range ctx.Table {
+		d, ok := entry.Object.(types.Dict)
+		if !ok || d.NameEntry("FT") == nil || *d.NameEntry("FT") != "Tx" || d.StringEntry("DA") == nil {
+			continue
+		}
+		da := strings.Fields(*d.StringEntry("DA"))
+		for i := 2; i < len(da); i++ {
+			if da[i] == "Tf" {
+				da[i-1] = "0"
+			}
+		}
+		d["DA"] = types.StringLiteral(strings.Join(da, " "))
+		n++
+	}
+	if n == 0 {
+		t.Fatalf("%s: no text fields found\n", msg)
+	}
+	if err := api.WriteContextFile(ctx, autoFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.FillFormFile(autoFile, inFileJSON, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Text field appearances use font sizes derived from the field dimensions
+	// instead of a default which is too large for the single line fields of this form.
+	if ctx, err = api.ReadContextFile(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	re := regexp.MustCompile(`/\S+ (\d+) Tf `)
+	sizes := map[string]bool{}
+	for objNr, entry := range ctx.Table {
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok {
+			continue
+		}
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("%s: obj#%d: %v\n", msg, objNr, err)
+		}
+		s := string(sd.Content)
+		if !strings.Contains(s, "/Tx BMC") {
+			continue
+		}
+		for _, m := range re.FindAllStringSubmatch(s, -1) {
+			if m[1] == "0" {
+				t.Fatalf("%s: obj#%d: appearance using font size 0: %s\n", msg, objNr, s)
+			}
+			sizes[m[1]] = true
+		}
+	}
+	if len(sizes) == 0 {
+		t.Fatalf("%s: no text field appearances found\n", msg)
+	}
+	if len(sizes) == 1 && sizes["12"] {
+		t.Fatalf("%s: font sizes not derived from field dimensions\n", msg)
+	}
+}
+
 func TestMultiFillFormJSON(t *testing.T) {
 
 	inDir := filepath.Join(samplesDir, "form", "demoSinglePage")
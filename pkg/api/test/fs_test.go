@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// assetFS simulates embedded assets.
+func assetFS(t *testing.T, fileNames ...string) fstest.MapFS {
+	t.Helper()
+
+	fsys := fstest.MapFS{}
+	for _, fn := range fileNames {
+		bb, err := os.ReadFile(fn)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		fsys["assets/"+filepath.Base(fn)] = &fstest.MapFile{Data: bb}
+	}
+
+	return fsys
+}
+
+func TestReadFS(t *testing.T) {
+	msg := "TestReadFS"
+
+	fsys := assetFS(t, filepath.Join(inDir, "5116.DCT_Filter.pdf"))
+
+	ctx, err := api.ReadFS(fsys, "assets/5116.DCT_Filter.pdf", nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx.PageCount != 52 {
+		t.Fatalf("%s: want 52 pages, got %d\n", msg, ctx.PageCount)
+	}
+
+	if _, err := api.ReadFS(fsys, "assets/missing.pdf", nil); err == nil {
+		t.Fatalf("%s: missing error for missing file\n", msg)
+	}
+}
+
+func TestStampAndImportFS(t *testing.T) {
+	msg := "TestStampAndImportFS"
+
+	fsys := assetFS(t,
+		filepath.Join(inDir, "Acroforms2.pdf"),
+		filepath.Join(resDir, "pdfchip3.png"),
+		filepath.Join(resDir, "mountain.jpg"))
+
+	// Import images into a new PDF.
+	var buf bytes.Buffer
+	imgFiles := []string{"assets/pdfchip3.png", "assets/mountain.jpg"}
+	if err := api.ImportImagesFS(nil, &buf, fsys, imgFiles, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Stamp imported pages with an image and a PDF page.
+	ctx, err := api.ReadAndValidate(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx.PageCount != 2 {
+		t.Fatalf("%s: want 2 pages, got %d\n", msg, ctx.PageCount)
+	}
+
+	wm, err := api.ImageWatermarkFS(fsys, "assets/pdfchip3.png", "scale:.5", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := pdfcpu.AddWatermarks(ctx, nil, wm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	wm, err = api.PDFWatermarkFS(fsys, "assets/Acroforms2.pdf", 1, "scale:.3", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := pdfcpu.AddWatermarks(ctx, nil, wm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.WriteContextFile(ctx, filepath.Join(outDir, "stampFS.pdf")); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestInstallFontsFS(t *testing.T) {
+	msg := "TestInstallFontsFS"
+
+	fsys := os.DirFS(filepath.Join(inDir, "fonts"))
+
+	if err := api.InstallFontsFS(fsys, []string{"Roboto-Regular.ttf"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
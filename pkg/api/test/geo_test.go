@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestGeoReference(t *testing.T) {
+	msg := "TestGeoReference"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(outDir, "geo.pdf")
+
+	// Map the page region (100,100)-(500,700) to a WGS84 bounding box.
+	geoRef := pdfcpu.GeoReference{
+		Name: "map",
+		BBox: types.NewRectangle(100, 100, 500, 700),
+		EPSG: 4326,
+		GPTS: []float64{48, 16, 49, 16, 49, 17, 48, 17},
+		PDU:  []string{"KM", "SQKM", "DEG"},
+	}
+
+	if err := api.SetGeoReferenceFile(inFile, outFile, []string{"1"}, geoRef, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Replace the viewport.
+	geoRef.GPTS = []float64{47, 15, 48, 15, 48, 16, 47, 16}
+	if err := api.SetGeoReferenceFile(outFile, "", []string{"1"}, geoRef, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m, err := api.GeoInfoFile(outFile, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(m) != 1 || len(m[1]) != 1 {
+		t.Fatalf("%s: want 1 geo reference on page 1, got %v\n", msg, m)
+	}
+
+	g := m[1][0]
+	if g.Name != "map" || g.EPSG != 4326 || g.GCSType != "GEOGCS" || len(g.PDU) != 3 {
+		t.Fatalf("%s: unexpected geo reference: %v\n", msg, g)
+	}
+
+	lat, lon, err := g.LatLon(300, 400)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if math.Abs(lat-47.5) > 1e-6 || math.Abs(lon-15.5) > 1e-6 {
+		t.Fatalf("%s: want 47.5/15.5, got %f/%f\n", msg, lat, lon)
+	}
+
+	geoRef.EPSG = 0
+	if err := api.SetGeoReferenceFile(inFile, outFile, nil, geoRef, nil); err == nil {
+		t.Fatalf("%s: missing error for missing coordinate system\n", msg)
+	}
+}
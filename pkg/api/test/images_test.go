@@ -17,6 +17,7 @@ limitations under the License.
 package test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -143,3 +144,51 @@ func TestUpdateImages(t *testing.T) {
 			tt.id)
 	}
 }
+
+func TestReplaceImage(t *testing.T) {
+	msg := "TestReplaceImage"
+
+	inFile := filepath.Join(samplesDir, "images", "test.pdf")
+	imgFile := filepath.Join(resDir, "logoSmall.png")
+
+	for _, tt := range []struct {
+		outFile string
+		objNr   int // by objNr
+		pageNr  int // or by (pageNr, id)
+		id      string
+	}{
+		{"imageReplacedByObjNr.pdf", 8, 0, ""},
+		{"imageReplacedByPageNrAndId.pdf", 0, 1, "Im1"},
+	} {
+		outFile := filepath.Join(outDir, tt.outFile)
+		if err := api.ReplaceImageFile(inFile, imgFile, outFile, tt.objNr, tt.pageNr, tt.id, conf); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, outFile, err)
+		}
+		if err := api.ValidateFile(outFile, conf); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		f, err := os.Open(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		mm, err := api.Images(f, []string{"1"}, conf)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		var found bool
+		for _, img := range mm[0] {
+			if img.Name == "Im1" {
+				found = true
+				if img.Width != 500 || img.Height != 550 {
+					t.Fatalf("%s %s: unexpected dimensions %dx%d\n", msg, outFile, img.Width, img.Height)
+				}
+			}
+		}
+		if !found {
+			t.Fatalf("%s %s: missing image Im1\n", msg, outFile)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestImpose(t *testing.T) {
+	msg := "TestImpose"
+	inFile := filepath.Join(inDir, "bookletTest.pdf")
+
+	pageCount, err := api.PageCountFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, tt := range []struct {
+		name  string
+		imp   model.Imposition
+		sides int
+	}{
+		{"cutStack",
+			model.Imposition{Scheme: model.ImposeCutStack, Cols: 2, Rows: 2, CropMarks: true, RegistrationMarks: true, ColorBars: true},
+			(pageCount + 3) / 4},
+		{"cutStackDuplex",
+			model.Imposition{Scheme: model.ImposeCutStack, Cols: 2, Rows: 2, Duplex: true, Gutter: 18, CropMarks: true},
+			2 * (((pageCount+1)/2 + 3) / 4)},
+		{"signature",
+			model.Imposition{Scheme: model.ImposeSignature, SignatureSize: 2, Creep: 0.5, CropMarks: true, ColorBars: true},
+			2 * ((pageCount + 3) / 4)},
+	} {
+		outFile := filepath.Join(outDir, "impose_"+tt.name+".pdf")
+		imp := tt.imp
+		if err := api.ImposeFile(inFile, outFile, nil, &imp, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+		n, err := api.PageCountFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+		if n != tt.sides {
+			t.Fatalf("%s %s: want %d sheet sides, got %d\n", msg, tt.name, tt.sides, n)
+		}
+	}
+
+	// Custom layout: 2 pages head to head on the front, 1 on the back.
+	layoutFile := filepath.Join(outDir, "layout.json")
+	layout := `{
+		"scheme": "custom",
+		"paperSize": "A4",
+		"slots": [
+			{"page": 1, "x": 0, "y": 421, "width": 595, "height": 421, "rotate": 180},
+			{"page": 2, "x": 0, "y": 0, "width": 595, "height": 421},
+			{"page": 3, "back": true, "x": 0, "y": 0, "width": 595, "height": 842}
+		]
+	}`
+	if err := os.WriteFile(layoutFile, []byte(layout), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	outFile := filepath.Join(outDir, "impose_custom.pdf")
+	if err := api.ImposeFileWithLayout(inFile, outFile, layoutFile, []string{"1-6"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, err := api.PageCountFile(outFile); err != nil || n != 4 {
+		t.Fatalf("%s custom: want 4 sheet sides, got %d (%v)\n", msg, n, err)
+	}
+
+	// Invalid layouts are rejected.
+	for _, s := range []string{
+		`{"scheme": "custom", "slots": [{"page": 1, "x": 0, "y": 0, "width": 5000, "height": 10}]}`,
+		`{"scheme": "cutStack"}`,
+		`{"scheme": "signature", "cols": 3}`,
+		`{"scheme": "unknown"}`,
+		`{"scheme": "signature", "creeep": 1}`,
+	} {
+		if err := os.WriteFile(layoutFile, []byte(s), 0644); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := api.ImposeFileWithLayout(inFile, outFile, layoutFile, nil, nil); err == nil {
+			t.Fatalf("%s: invalid layout accepted: %s\n", msg, s)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func jsAction(script string, next types.Object) types.Dict {
+	d := types.Dict(map[string]types.Object{
+		"Type": types.Name("Action"),
+		"S":    types.Name("JavaScript"),
+		"JS":   types.StringLiteral(script),
+	})
+	if next != nil {
+		d["Next"] = next
+	}
+	return d
+}
+
+func createJavaScriptPDF(t *testing.T, outFile string) {
+	t.Helper()
+	msg := "createJavaScriptPDF"
+
+	// The annotation demo comes with a JavaScript open action.
+	xRefTable, err := pdfcpu.CreateAnnotationDemoXRef()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.CreatePDFFile(xRefTable, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Add a document level script.
+	ir, err := ctx.IndRefForNewObject(jsAction("var x = 1;", nil))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	rootDict["Names"] = types.Dict(map[string]types.Object{
+		"JavaScript": types.Dict(map[string]types.Object{
+			"Names": types.Array{types.StringLiteral("init"), *ir},
+		}),
+	})
+
+	// Add a page open script followed by a URI action.
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	uri := types.Dict(map[string]types.Object{
+		"Type": types.Name("Action"),
+		"S":    types.Name("URI"),
+		"URI":  types.StringLiteral("https://pdfcpu.io"),
+	})
+	d["AA"] = types.Dict(map[string]types.Object{"O": jsAction("app.beep(0);", uri)})
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestJavaScript(t *testing.T) {
+	msg := "TestJavaScript"
+
+	inFile := filepath.Join(outDir, "js.pdf")
+	outFile := filepath.Join(outDir, "jsStripped.pdf")
+
+	createJavaScriptPDF(t, inFile)
+
+	jj, err := api.ListJavaScriptFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	scopes := map[pdfcpu.JSScope]int{}
+	for _, js := range jj {
+		scopes[js.Scope]++
+	}
+	if scopes[pdfcpu.JSDocument] != 2 || scopes[pdfcpu.JSPage] != 1 {
+		t.Fatalf("%s: unexpected JavaScript: %v\n", msg, jj)
+	}
+
+	// Strip page scripts only and keep the following URI action.
+	if err := api.RemoveJavaScriptFile(inFile, outFile, pdfcpu.JSPage, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	aa, err := ctx.DereferenceDict(d["AA"])
+	if err != nil || aa == nil {
+		t.Fatalf("%s: missing page additional actions: %v\n", msg, err)
+	}
+	a, err := ctx.DereferenceDict(aa["O"])
+	if err != nil || a == nil || *a.NameEntry("S") != "URI" {
+		t.Fatalf("%s: want URI action, got: %v\n", msg, a)
+	}
+
+	jj, err = api.ListJavaScriptFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(jj) != scopes[pdfcpu.JSDocument] {
+		t.Fatalf("%s: unexpected JavaScript after page stripping: %v\n", msg, jj)
+	}
+
+	// Replace the remaining scripts.
+	f1, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f1.Close()
+
+	outFile2 := filepath.Join(outDir, "jsReplaced.pdf")
+	f2, err := os.Create(outFile2)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	noop := "// removed"
+	if err := api.ReplaceJavaScript(f1, f2, pdfcpu.JSAll, func(js pdfcpu.JavaScript) *string { return &noop }, nil); err != nil {
+		f2.Close()
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := f2.Close(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	jj, err = api.ListJavaScriptFile(outFile2, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, js := range jj {
+		if js.Script != noop {
+			t.Fatalf("%s: unexpected script: %s\n", msg, js)
+		}
+	}
+
+	// Strip everything.
+	if err := api.RemoveJavaScriptFile(outFile2, "", pdfcpu.JSAll, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile2, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	jj, err = api.ListJavaScriptFile(outFile2, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(jj) != 0 {
+		t.Fatalf("%s: want no JavaScript, got: %v\n", msg, jj)
+	}
+
+	if _, err := pdfcpu.ParseJSScope("page, field"); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := pdfcpu.ParseJSScope("cookies"); err == nil {
+		t.Fatalf("%s: missing error for invalid scope\n", msg)
+	}
+}
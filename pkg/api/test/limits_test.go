@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func readWithLimits(fileName string, limits model.Limits) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	conf := model.NewDefaultConfiguration()
+	conf.Limits = limits
+	conf.DecodeAllStreams = true
+
+	_, err = api.ReadAndValidate(f, conf)
+	return err
+}
+
+func TestLimits(t *testing.T) {
+	msg := "TestLimits"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+
+	// Generous limits don't get in the way.
+	limits := model.Limits{MaxObjects: 1000, MaxNestingDepth: 32, MaxStreamSize: 1 << 20, MaxXRefSections: 10}
+	if err := readWithLimits(inFile, limits); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, tt := range []struct {
+		limits model.Limits
+		want   string
+	}{
+		{model.Limits{MaxObjects: 10}, types.LimitObjects},
+		{model.Limits{MaxNestingDepth: 1}, types.LimitNestingDepth},
+		{model.Limits{MaxStreamSize: 100}, types.LimitStreamSize},
+		{model.Limits{MaxXRefSections: 1}, types.LimitXRefSections},
+	} {
+		err := readWithLimits(inFile, tt.limits)
+		var e *types.LimitError
+		if !errors.As(err, &e) {
+			t.Fatalf("%s %s: want LimitError, got: %v\n", msg, tt.want, err)
+		}
+		if e.Limit != tt.want {
+			t.Fatalf("%s: want %s, got %s\n", msg, tt.want, e.Limit)
+		}
+	}
+}
+
+func FuzzReadWithLimits(f *testing.F) {
+	for _, fn := range []string{"testWithText.pdf", "annotTest.pdf", "5116.DCT_Filter.pdf"} {
+		bb, err := os.ReadFile(filepath.Join(inDir, fn))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(bb)
+	}
+
+	limits := model.Limits{MaxObjects: 10000, MaxNestingDepth: 32, MaxStreamSize: 10 << 20, MaxXRefSections: 32}
+
+	f.Fuzz(func(t *testing.T, bb []byte) {
+		conf := model.NewDefaultConfiguration()
+		conf.Limits = limits
+		// Errors are expected, panics are not.
+		api.ReadAndValidate(bytes.NewReader(bb), conf)
+	})
+}
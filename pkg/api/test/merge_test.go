@@ -21,9 +21,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func TestMergeCreateNew(t *testing.T) {
@@ -172,3 +175,131 @@ func TestMergeRaw(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func TestMergeCreateTOC(t *testing.T) {
+	msg := "TestMergeCreateTOC"
+	inFiles := []string{
+		filepath.Join(inDir, "go.pdf"),
+		filepath.Join(inDir, "mountain.pdf"),
+		filepath.Join(inDir, "annotTest.pdf"),
+	}
+
+	// Merge inFiles behind a TOC page listing each document with its starting page,
+	// and also create an outline entry for each document.
+	outFile := filepath.Join(outDir, "outWithTOC.pdf")
+	tc := &pdfcpu.TOCConfig{Title: "Report Bundle", TOCPage: true, Bookmarks: true}
+	if err := api.MergeCreateTOCFile(inFiles, outFile, tc, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx.PageCount != 26 {
+		t.Fatalf("%s: want 26 pages, got %d\n", msg, ctx.PageCount)
+	}
+
+	s, err := pdfcpu.ExtractPageText(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, want := range []string{"Report Bundle", "mountain\n25"} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("%s: TOC page is missing %q:\n%s\n", msg, want, s)
+		}
+	}
+
+	bms, err := pdfcpu.Bookmarks(ctx)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	want := []int{1, 2, 25, 26}
+	if len(bms) != len(want) {
+		t.Fatalf("%s: want %d bookmarks, got %d\n", msg, len(want), len(bms))
+	}
+	for i, bm := range bms {
+		if bm.PageFrom != want[i] {
+			t.Fatalf("%s: bookmark %q: want page %d, got %d\n", msg, bm.Title, want[i], bm.PageFrom)
+		}
+	}
+
+	// Only create outline entries using custom titles.
+	outFile = filepath.Join(outDir, "outWithTOCBookmarks.pdf")
+	tc = &pdfcpu.TOCConfig{Titles: []string{"Go", "Mountain", "Annotations"}, Bookmarks: true}
+	if err := api.MergeCreateTOCFile(inFiles, outFile, tc, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ctx, err = api.ReadContextFile(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if bms, err = pdfcpu.Bookmarks(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(bms) != 3 || bms[1].Title != "Mountain" || bms[1].PageFrom != 24 {
+		t.Fatalf("%s: unexpected bookmarks: %v\n", msg, bms)
+	}
+}
+
+// resourceCounts returns the number of font dicts, images and forms of inFile.
+func resourceCounts(t *testing.T, inFile string) (int, int, int) {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", inFile, err)
+	}
+
+	var fonts, images, forms int
+	for _, e := range ctx.Table {
+		if e == nil || e.Free || e.Object == nil {
+			continue
+		}
+		switch o := e.Object.(type) {
+		case types.Dict:
+			if typ := o.Type(); typ != nil && *typ == "Font" {
+				fonts++
+			}
+		case types.StreamDict:
+			if st := o.Subtype(); st != nil && *st == "Image" {
+				images++
+			}
+			if st := o.Subtype(); st != nil && *st == "Form" {
+				forms++
+			}
+		}
+	}
+
+	return fonts, images, forms
+}
+
+func TestMergeDeduplicateResources(t *testing.T) {
+	msg := "TestMergeDeduplicateResources"
+	inFile := filepath.Join(inDir, "go.pdf")
+
+	// Documents produced from the same template share their fonts, images and forms.
+	outFile := filepath.Join(outDir, "goOptimized.pdf")
+	if err := api.OptimizeFile(inFile, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fonts, images, forms := resourceCounts(t, outFile)
+
+	outFile = filepath.Join(outDir, "goMerged.pdf")
+	if err := api.MergeCreateFile([]string{inFile, inFile, inFile}, outFile, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fonts1, images1, forms1 := resourceCounts(t, outFile)
+	if fonts1 != fonts || images1 != images || forms1 != forms {
+		t.Fatalf("%s: want %d fonts, %d images, %d forms, got %d, %d, %d\n", msg, fonts, images, forms, fonts1, images1, forms1)
+	}
+}
@@ -109,3 +109,37 @@ func TestNUp(t *testing.T) {
 		testNUp(t, tt.msg, tt.inFiles, tt.outFile, tt.selectedPages, tt.desc, tt.n, tt.isImg, conf)
 	}
 }
+
+func TestNUpCustomGrid(t *testing.T) {
+	msg := "TestNUpCustomGrid"
+	inFile := filepath.Join(inDir, "WaldenFull.pdf")
+	outFile := filepath.Join(samplesDir, "nup", "NUpCustomGrid.pdf")
+
+	// 5 columns x 2 rows with spaced, styled cell borders and content aligned to the upper left corner.
+	nup, err := api.PDFNUpGridConfig(2, 5, "form:A3L, spacing:5, border:1 round Blue, anchor:tl", nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if nup.N() != 10 || nup.Anchor == nil || nup.CellBorder == nil || nup.Spacing != 5 {
+		t.Fatalf("%s: unexpected n-Up configuration: %v\n", msg, nup)
+	}
+
+	if err := api.NUpFile([]string{inFile}, outFile, nil, nup, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	n, err := api.PageCountFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	n1, err := api.PageCountFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if want := (n + 9) / 10; n1 != want {
+		t.Fatalf("%s: got %d pages, want %d\n", msg, n1, want)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"image"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// fakeOCR recognizes the same words on every image.
+type fakeOCR struct {
+	calls int
+	words []string // defaults to Hello World
+}
+
+func (o *fakeOCR) Recognize(img image.Image) ([]pdfcpu.OCRWord, error) {
+	o.calls++
+	words := o.words
+	if words == nil {
+		words = []string{"Hello", "World"}
+	}
+	var ww []pdfcpu.OCRWord
+	for i, s := range words {
+		x := 100 + i*110
+		ww = append(ww, pdfcpu.OCRWord{Text: s, Box: image.Rect(x, 150, x+100, 158)})
+	}
+	return ww, nil
+}
+
+func TestAddTextLayer(t *testing.T) {
+	msg := "TestAddTextLayer"
+
+	imgFile := filepath.Join(outDir, "scan.png")
+	scanFile := filepath.Join(outDir, "scan.pdf")
+	outFile := filepath.Join(outDir, "searchableScan.pdf")
+
+	writeSkewedScan(t, imgFile, 0, 0)
+
+	imp, err := pdfcpu.ParseImportDetails("pos:full", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ImportImagesFile([]string{imgFile}, scanFile, imp, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ocr := &fakeOCR{}
+	if err := api.AddTextLayerFile(scanFile, outFile, ocr, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ocr.calls != 1 {
+		t.Fatalf("%s: want 1 recognized image, got %d\n", msg, ocr.calls)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	s, err := pdfcpu.ExtractPageText(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !strings.Contains(s, "Hello") || !strings.Contains(s, "World") {
+		t.Fatalf("%s: missing recognized text: %q\n", msg, s)
+	}
+
+	// Pages showing text are skipped.
+	if err := api.AddTextLayerFile(outFile, "", ocr, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ocr.calls != 1 {
+		t.Fatalf("%s: unexpected recognition of searchable page\n", msg)
+	}
+}
+
+func TestAddTextLayerNonLatin(t *testing.T) {
+	msg := "TestAddTextLayerNonLatin"
+
+	imgFile := filepath.Join(outDir, "scanNonLatin.png")
+	scanFile := filepath.Join(outDir, "scanNonLatin.pdf")
+	outFile := filepath.Join(outDir, "searchableScanNonLatin.pdf")
+
+	writeSkewedScan(t, imgFile, 0, 0)
+
+	imp, err := pdfcpu.ParseImportDetails("pos:full", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ImportImagesFile([]string{imgFile}, scanFile, imp, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Cyrillic, CJK, Greek and a character outside the Basic Multilingual Plane.
+	words := []string{"Привет", "日本語", "λόγος", "𝄞"}
+
+	ocr := &fakeOCR{words: words}
+	if err := api.AddTextLayerFile(scanFile, outFile, ocr, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	s, err := pdfcpu.ExtractPageText(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, w := range words {
+		if !strings.Contains(s, w) {
+			t.Fatalf("%s: missing recognized word %q: %q\n", msg, w, s)
+		}
+	}
+}
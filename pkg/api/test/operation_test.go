@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestPipeline(t *testing.T) {
+	msg := "TestPipeline"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+	outFile := filepath.Join(outDir, "pipeline.pdf")
+
+	wm, err := api.TextWatermark("Demo", "", false, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ops := pdfcpu.Pipeline(
+		pdfcpu.Optimize{},
+		pdfcpu.Watermark{Watermark: wm},
+		pdfcpu.Pipeline(
+			pdfcpu.AddKeywords{Keywords: []string{"pipeline"}},
+			pdfcpu.Encrypt{UserPW: "upw", OwnerPW: "opw", Permissions: model.PermissionsAll},
+		),
+	)
+
+	if ops.Cmd() != model.OPTIMIZE {
+		t.Fatalf("%s: want cmd %d, got %d\n", msg, model.OPTIMIZE, ops.Cmd())
+	}
+
+	if err := api.ApplyOperationsFile(inFile, outFile, ops, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.UserPW = "upw"
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	ctx, err := api.ReadAndValidate(f, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx.Encrypt == nil {
+		t.Fatalf("%s: %s should be encrypted\n", msg, outFile)
+	}
+	if err := pdfcpu.DetectWatermarks(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !ctx.Watermarked {
+		t.Fatalf("%s: %s should be watermarked\n", msg, outFile)
+	}
+	kw, err := pdfcpu.KeywordsList(ctx)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(kw) != 1 || kw[0] != "pipeline" {
+		t.Fatalf("%s: want keywords [pipeline], got %v\n", msg, kw)
+	}
+
+	// A failing operation aborts the pipeline.
+	ops = pdfcpu.Pipeline(pdfcpu.Rotate{Rotation: 45})
+	if err := api.ApplyOperationsFile(inFile, filepath.Join(outDir, "pipeline2.pdf"), ops, nil); err == nil {
+		t.Fatalf("%s: invalid rotation should fail\n", msg)
+	}
+}
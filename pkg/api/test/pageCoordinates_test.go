@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/primitives"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestVisualPlacement(t *testing.T) {
+	msg := "TestVisualPlacement"
+	outFile := filepath.Join(outDir, "visualPlacement.pdf")
+
+	ctx := readValidatedContext(t, filepath.Join(inDir, "test.pdf"))
+
+	// Rotate and crop page 1.
+	if err := pdfcpu.RotatePages(ctx, types.IntSet{1: true}, 90); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d, _, inhPAttrs, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	mb := inhPAttrs.MediaBox
+	d["CropBox"] = types.NewRectangle(mb.LL.X+50, mb.LL.Y+100, mb.UR.X-50, mb.UR.Y-100).Array()
+
+	pc, err := ctx.PageCoordinates(1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if pc.Rot != 90 || pc.CropBox.LL.X != mb.LL.X+50 {
+		t.Fatalf("%s: unexpected page coordinates %+v\n", msg, pc)
+	}
+
+	rectForDict := func(d types.Dict) *types.Rectangle {
+		t.Helper()
+		r, err := ctx.RectForArray(d.ArrayEntry("Rect"))
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		return r
+	}
+
+	// A square in the lower left corner of the displayed page.
+	_, d, err = pdfcpu.AddVisualAnnotationToPage(ctx, 1, squareAnn, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	want := pc.RectToUserSpace(types.NewRectangle(300, 0, 350, 50))
+	if r := rectForDict(d); !r.Equals(*want) || r.UR.X != pc.CropBox.UR.X || r.LL.Y != pc.CropBox.LL.Y+300 {
+		t.Fatalf("%s: square: want %v, got %v\n", msg, want, r)
+	}
+
+	// A wide text field displayed upright.
+	visual := types.NewRectangle(20, 20, 220, 50)
+	ir, err := form.AddTextField(ctx, 1, visual, form.TextFieldOptions{FieldOptions: form.FieldOptions{ID: "name", Visual: true}, Value: "upright"})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if d, err = ctx.DereferenceDict(*ir); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if r := rectForDict(d); !r.Equals(*pc.RectToUserSpace(visual)) || r.Width() != 30 || r.Height() != 200 {
+		t.Fatalf("%s: text field: want %v, got %v\n", msg, pc.RectToUserSpace(visual), r)
+	}
+	if r := d.DictEntry("MK").IntEntry("R"); r == nil || *r != 90 {
+		t.Fatalf("%s: text field: want MK/R 90, got %v\n", msg, d.DictEntry("MK"))
+	}
+
+	if _, err := form.AddSignatureField(ctx, 1, types.NewRectangle(20, 60, 220, 120), form.FieldOptions{ID: "signature", Visual: true}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Signature appearances get rendered upright.
+	sa := &primitives.SignatureAppearance{Name: "Jane Doe", Date: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	if err := api.SetSignatureAppearanceFile(outFile, "", "signature", sa, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx = readValidatedContext(t, outFile)
+	widgets := ctx.PageAnnots[1][model.AnnWidget]
+	if widgets.IndRefs == nil || len(*widgets.IndRefs) != 2 {
+		t.Fatalf("%s: want 2 widgets\n", msg)
+	}
+	for _, ir := range *widgets.IndRefs {
+		d, err := ctx.DereferenceDict(ir)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if ft := d.NameEntry("FT"); ft == nil || *ft != "Sig" {
+			continue
+		}
+		sd, _, err := ctx.DereferenceStreamDict(d.DictEntry("AP")["N"])
+		if err != nil || sd == nil {
+			t.Fatalf("%s: missing signature appearance: %v\n", msg, err)
+		}
+		bb, m := sd.ArrayEntry("BBox"), sd.ArrayEntry("Matrix")
+		if len(bb) != 4 || bb[2] != types.Float(200) || len(m) != 6 || m[1] != types.Float(1) {
+			t.Fatalf("%s: want rotated appearance, got BBox %v Matrix %v\n", msg, bb, m)
+		}
+	}
+}
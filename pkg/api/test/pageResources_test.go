@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestPageResources(t *testing.T) {
+	msg := "TestPageResources"
+
+	f, err := os.Open(filepath.Join(inDir, "mountain.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	uu, err := api.PageResources(f, []string{"1"}, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(uu) != 1 || uu[0].PageNr != 1 {
+		t.Fatalf("%s: want resources for page 1, got %d\n", msg, len(uu))
+	}
+
+	u := uu[0]
+	if len(u.Images) == 0 {
+		t.Fatalf("%s: missing images\n", msg)
+	}
+	img := u.Images[0]
+	if img.Width == 0 || img.Height == 0 || img.Filter == "" || img.Size == 0 {
+		t.Fatalf("%s: incomplete image info: %+v\n", msg, img)
+	}
+	if len(img.Placements) == 0 || img.Placements[0].HorDPI <= 0 {
+		t.Fatalf("%s: missing image placement: %+v\n", msg, img)
+	}
+
+	f1, err := os.Open(filepath.Join(inDir, "go.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f1.Close()
+
+	uu, err = api.PageResources(f1, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	var fonts int
+	for _, u := range uu {
+		for _, fo := range u.Fonts {
+			if fo.Name == "" || fo.Type == "" {
+				t.Fatalf("%s: incomplete font info: %+v\n", msg, fo)
+			}
+		}
+		fonts += len(u.Fonts)
+	}
+	if fonts == 0 {
+		t.Fatalf("%s: missing fonts\n", msg)
+	}
+}
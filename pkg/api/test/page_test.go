@@ -17,11 +17,13 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -98,3 +100,257 @@ func TestInsertCustomBlankPage(t *testing.T) {
 	}
 
 }
+
+// linkPageTree turns the page tree of ctx into a linked list.
+func linkPageTree(t *testing.T, msg string, ctx *model.Context, pages []types.IndirectRef) {
+	t.Helper()
+
+	var next *types.IndirectRef
+
+	for i := len(pages) - 1; i >= 0; i-- {
+		d := types.Dict{"Type": types.Name("Pages"), "Count": types.Integer(len(pages) - i), "Kids": types.Array{pages[i]}}
+		if next != nil {
+			d["Kids"] = append(d["Kids"].(types.Array), *next)
+		}
+		ir, err := ctx.IndRefForNewObject(d)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		pageDict, err := ctx.DereferenceDict(pages[i])
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		pageDict["Parent"] = *ir
+		if next != nil {
+			nextDict, err := ctx.DereferenceDict(*next)
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			nextDict["Parent"] = *ir
+		}
+		next = ir
+	}
+
+	ctx.RootDict["Pages"] = *next
+}
+
+func TestRebuildPageTree(t *testing.T) {
+	msg := "TestRebuildPageTree"
+	inFile := filepath.Join(inDir, "gobook.0.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	n := ctx.PageCount
+	if n <= model.PageTreeMaxKids {
+		t.Fatalf("%s: need more than %d pages, got %d\n", msg, model.PageTreeMaxKids, n)
+	}
+
+	// Move inherited page attributes into the page dicts.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i + 1
+	}
+	if err := pdfcpu.ReorderPages(ctx, order); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pages := make([]types.IndirectRef, n)
+	for i := range pages {
+		ir, err := ctx.PageDictIndRef(i + 1)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		pages[i] = *ir
+	}
+
+	linkPageTree(t, msg, ctx, pages)
+
+	s, err := ctx.PageTreeShape()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !s.Degenerate() || s.Pages != n {
+		t.Fatalf("%s: want degenerate page tree, got %+v\n", msg, *s)
+	}
+
+	var buf bytes.Buffer
+	if err := api.WriteContext(ctx, &buf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	bb := buf.Bytes()
+
+	// Validation reports the degenerate page tree.
+	ctx1, err := api.ReadContext(bytes.NewReader(bb), model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx1); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, _ := findings(ctx1.Findings, model.FindingPageTree); n != 1 {
+		t.Fatalf("%s: want 1 page tree finding, got %d\n", msg, n)
+	}
+
+	// Optimize rebalances the page tree.
+	conf := model.NewDefaultConfiguration()
+	conf.OptimizePageTree = true
+	buf.Reset()
+	if err := api.Optimize(bytes.NewReader(bb), &buf, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ctx1, err = api.ReadValidateAndOptimize(bytes.NewReader(buf.Bytes()), model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, _ := findings(ctx1.Findings, model.FindingPageTree); n != 0 {
+		t.Fatalf("%s: want no page tree finding, got %d\n", msg, n)
+	}
+	if ctx1.PageCount != n {
+		t.Fatalf("%s: pageCount want:%d got:%d\n", msg, n, ctx1.PageCount)
+	}
+
+	// Rebuilding preserves the page order.
+	if err := pdfcpu.RebuildPageTree(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if s, err = ctx.PageTreeShape(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if s.Degenerate() || s.Depth != model.BalancedPageTreeDepth(n) || s.MaxKids > model.PageTreeMaxKids {
+		t.Fatalf("%s: want balanced page tree, got %+v\n", msg, *s)
+	}
+	for i, ir := range pages {
+		ir1, err := ctx.PageDictIndRef(i + 1)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if ir1 == nil || ir1.ObjectNumber != ir.ObjectNumber {
+			t.Fatalf("%s: page %d: want %s got %v\n", msg, i+1, ir, ir1)
+		}
+	}
+}
+
+func inheritedPageAttrs(t *testing.T, msg string, ctx *model.Context) []model.InheritedPageAttrs {
+	t.Helper()
+
+	aa := make([]model.InheritedPageAttrs, ctx.PageCount)
+	for i := range aa {
+		_, _, inhPAttrs, err := ctx.PageDict(i+1, false)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if inhPAttrs.MediaBox == nil || inhPAttrs.Resources == nil {
+			t.Fatalf("%s: page %d: missing MediaBox or Resources\n", msg, i+1)
+		}
+		aa[i] = *inhPAttrs
+	}
+
+	return aa
+}
+
+// checkInheritedPageAttrs compares the resolved page attributes of ctx with want.
+// Resources are skipped for written files since optimization drops unused resources.
+func checkInheritedPageAttrs(t *testing.T, msg string, ctx *model.Context, want []model.InheritedPageAttrs, resources bool) {
+	t.Helper()
+
+	for i, a := range inheritedPageAttrs(t, msg, ctx) {
+		w := want[i]
+		if a.MediaBox.String() != w.MediaBox.String() || a.Rotate != w.Rotate || (a.CropBox == nil) != (w.CropBox == nil) {
+			t.Fatalf("%s: page %d: want %+v, got %+v\n", msg, i+1, w, a)
+		}
+		if !resources {
+			continue
+		}
+		if ok, err := model.EqualObjects(a.Resources, w.Resources, ctx.XRefTable); err != nil || !ok {
+			t.Fatalf("%s: page %d: resources differ\n", msg, i+1)
+		}
+	}
+}
+
+func TestInheritedPageAttrs(t *testing.T) {
+	msg := "TestInheritedPageAttrs"
+	inFile := filepath.Join(inDir, "gobook.0.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Get intermediate page tree nodes.
+	if err := pdfcpu.RebuildPageTree(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	want := inheritedPageAttrs(t, msg, ctx)
+
+	if err := pdfcpu.PullUpPageAttrs(ctx, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkInheritedPageAttrs(t, msg, ctx, want, true)
+
+	root, err := ctx.Pages()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d, err := ctx.DereferenceDict(*root)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, found := d.Find("MediaBox"); !found {
+		t.Fatalf("%s: want shared MediaBox at page tree root\n", msg)
+	}
+	if err := pdfcpu.ClearInheritedPageAttr(ctx, nil, "MediaBox"); err == nil {
+		t.Fatalf("%s: clearing the only MediaBox should fail\n", msg)
+	}
+	if _, found := d.Find("MediaBox"); !found {
+		t.Fatalf("%s: MediaBox should survive a failed clear\n", msg)
+	}
+
+	if err := pdfcpu.PushDownPageAttrs(ctx, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkInheritedPageAttrs(t, msg, ctx, want, true)
+	for _, k := range []string{"Resources", "MediaBox", "CropBox", "Rotate"} {
+		if _, found := d.Find(k); found {
+			t.Fatalf("%s: unexpected %s at page tree root\n", msg, k)
+		}
+	}
+
+	// Rotate all pages of the first intermediate page tree node.
+	kid := d.ArrayEntry("Kids")[0].(types.IndirectRef)
+	if err := pdfcpu.SetInheritedPageAttr(ctx, &kid, "Rotate", types.Integer(90)); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := pdfcpu.SetInheritedPageAttr(ctx, nil, "Parent", types.Integer(90)); err == nil {
+		t.Fatalf("%s: Parent is not inheritable\n", msg)
+	}
+
+	kidDict, err := ctx.DereferenceDict(kid)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	rotated := *kidDict.IntEntry("Count")
+	for i := 0; i < rotated; i++ {
+		want[i].Rotate = 90
+	}
+
+	ctx = writeAndReadContext(t, msg, ctx)
+	checkInheritedPageAttrs(t, msg, ctx, want, false)
+
+	if err := pdfcpu.ClearInheritedPageAttr(ctx, &kid, "Rotate"); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for i := 0; i < rotated; i++ {
+		want[i].Rotate = 0
+	}
+	checkInheritedPageAttrs(t, msg, ctx, want, false)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// readPDF20Context reads and validates inFile pretending it claims PDF 2.0.
+func readPDF20Context(t *testing.T, msg, inFile string, conf *model.Configuration) *model.Context {
+	t.Helper()
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	ctx := readContextForFindings(t, msg, inFile, conf)
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	v := model.V20
+	ctx.RootVersion = &v
+
+	return ctx
+}
+
+func addPDF20PageFeatures(t *testing.T, msg string, ctx *model.Context, blackPtComp string) {
+	t.Helper()
+
+	d, _, inhPAttrs, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	gs := types.Dict{
+		"Type":           types.Name("ExtGState"),
+		"UseBlackPtComp": types.Name(blackPtComp),
+		"HTO":            types.NewNumberArray(0, 0),
+	}
+	res := inhPAttrs.Resources.Clone().(types.Dict)
+	res["ExtGState"] = types.Dict{"GS20": gs}
+	d["Resources"] = res
+
+	annot := types.Dict{
+		"Type":     types.Name("Annot"),
+		"Subtype":  types.Name("Projection"),
+		"Rect":     types.NewNumberArray(100, 100, 200, 200),
+		"Contents": types.StringLiteral("projection"),
+		"ExData":   types.Dict{"Type": types.Name("ExData"), "Subtype": types.Name("MarkupGeo")},
+	}
+	ir, err := ctx.IndRefForNewObject(annot)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d["Annots"] = types.Array{*ir}
+}
+
+func TestValidatePDF20Features(t *testing.T) {
+	msg := "TestValidatePDF20Features"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+
+	ctx := readPDF20Context(t, msg, inFile, nil)
+	addPDF20PageFeatures(t, msg, ctx, "ON")
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx.CustomExtensions {
+		t.Fatalf("%s: projection annotation treated as custom extension\n", msg)
+	}
+
+	ctx = readPDF20Context(t, msg, inFile, nil)
+	addPDF20PageFeatures(t, msg, ctx, "Maybe")
+	if err := api.ValidateContext(ctx); err == nil || !strings.Contains(err.Error(), "UseBlackPtComp") {
+		t.Fatalf("%s: want UseBlackPtComp error, got %v\n", msg, err)
+	}
+}
+
+func TestValidatePDF20WrapperDocument(t *testing.T) {
+	msg := "TestValidatePDF20WrapperDocument"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+
+	addPayload := func(ctx *model.Context, associate bool) {
+		a := model.Attachment{Reader: strings.NewReader("encrypted"), ID: "payload.pdf"}
+		if err := ctx.AddAttachment(a, false); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		_, o, err := ctx.SearchEmbeddedFilesNameTreeNodeByContent("payload.pdf")
+		if err != nil || o == nil {
+			t.Fatalf("%s: missing payload: %v\n", msg, err)
+		}
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		d["EP"] = types.Dict{"Type": types.Name("EncryptedPayload"), "Subtype": types.Name("MyCryptoFilter")}
+		if !associate {
+			return
+		}
+		d["AFRelationship"] = types.Name("EncryptedPayload")
+		rootDict, err := ctx.Catalog()
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		rootDict["AF"] = types.Array{o}
+	}
+
+	ctx := readPDF20Context(t, msg, inFile, nil)
+	addPayload(ctx, true)
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, _ := findings(ctx.Findings, model.FindingEncryptedPayload); n > 0 {
+		t.Fatalf("%s: unexpected encryptedPayload finding\n", msg)
+	}
+
+	// Relaxed validation digests payloads not associated with the document.
+	ctx = readPDF20Context(t, msg, inFile, nil)
+	addPayload(ctx, false)
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, fatal := findings(ctx.Findings, model.FindingEncryptedPayload); n != 1 || fatal {
+		t.Fatalf("%s: want 1 non fatal encryptedPayload finding, got %d %t\n", msg, n, fatal)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.FindingSeverity = map[string]model.Severity{model.FindingEncryptedPayload: model.SeverityError}
+	ctx = readPDF20Context(t, msg, inFile, conf)
+	addPayload(ctx, false)
+	if err := api.ValidateContext(ctx); err == nil {
+		t.Fatalf("%s: missing encryptedPayload error\n", msg)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestPieceInfo(t *testing.T) {
+	msg := "TestPieceInfo"
+
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	outFile := filepath.Join(outDir, "pieceInfo.pdf")
+
+	private := types.Dict{"Layer": types.StringLiteral("Background"), "Version": types.Integer(3)}
+
+	if err := api.SetPieceInfoFile(inFile, outFile, 1, "MyApp", private, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SetPieceInfoFile(outFile, "", 0, "MyApp", types.StringLiteral("document data"), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pp, err := api.ListPieceInfoFile(outFile, 1, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pp) != 1 || pp[0].App != "MyApp" || pp[0].LastModified == nil {
+		t.Fatalf("%s: unexpected page piece info: %+v\n", msg, pp)
+	}
+	d, ok := pp[0].Private.(types.Dict)
+	if !ok {
+		t.Fatalf("%s: want private dict, got %T\n", msg, pp[0].Private)
+	}
+	if v := d.IntEntry("Version"); v == nil || *v != 3 {
+		t.Fatalf("%s: unexpected private data: %s\n", msg, d)
+	}
+
+	pp, err = api.ListPieceInfoFile(outFile, 0, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pp) != 1 || pp[0].Private.String() != "(document data)" {
+		t.Fatalf("%s: unexpected document piece info: %+v\n", msg, pp)
+	}
+
+	if err := api.RemovePieceInfoFile(outFile, "", 1, "MyApp", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if pp, err = api.ListPieceInfoFile(outFile, 1, nil); err != nil || len(pp) > 0 {
+		t.Fatalf("%s: want no page piece info, got %+v %v\n", msg, pp, err)
+	}
+	if err := api.RemovePieceInfoFile(outFile, "", 1, "MyApp", nil); err == nil {
+		t.Fatalf("%s: missing error removing unknown piece info\n", msg)
+	}
+}
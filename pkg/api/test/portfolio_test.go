@@ -17,10 +17,13 @@ limitations under the License.
 package test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
 func TestPortfolio(t *testing.T) {
@@ -76,3 +79,114 @@ func TestPortfolio(t *testing.T) {
 		t.Fatalf("%s: validate: %v\n", msg, err)
 	}
 }
+
+func TestCreatePortfolio(t *testing.T) {
+	msg := "TestCreatePortfolio"
+
+	received := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	p := &model.Portfolio{
+		View:    "D",
+		Initial: "go.pdf",
+		Fields: []model.PortfolioField{
+			{Key: "FileName", Name: "Name", Subtype: "F", Order: 1},
+			{Key: "Author", Name: "Author", Subtype: "S", Order: 2, Editable: true},
+			{Key: "Pages", Name: "Pages", Subtype: "N", Order: 3},
+			{Key: "Received", Name: "Received", Subtype: "D", Order: 4},
+			{Key: "Size", Name: "Size", Subtype: "Size", Order: 5, Hidden: true},
+		},
+		Sort:      []string{"Received", "FileName"},
+		Ascending: []bool{false},
+		Items: []model.PortfolioItem{
+			{
+				Attachment: model.Attachment{FileName: filepath.Join(inDir, "go.pdf"), Desc: "Go slides"},
+				Fields:     map[string]interface{}{"Author": "Yossi Gil", "Pages": 23, "Received": received},
+			},
+			{
+				Attachment: model.Attachment{FileName: filepath.Join(inDir, "mountain.pdf")},
+				Fields:     map[string]interface{}{"Pages": 1},
+			},
+			{
+				Attachment: model.Attachment{FileName: filepath.Join(resDir, "logoSmall.png")},
+			},
+		},
+	}
+
+	// Create a portfolio with generated cover sheet.
+	outFile := filepath.Join(outDir, "portfolio.pdf")
+	if err := api.CreatePortfolioFile("", outFile, p, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	p1, err := api.PortfolioFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if p1.View != "D" || p1.Initial != "go.pdf" || len(p1.Fields) != 5 || len(p1.Items) != 3 {
+		t.Fatalf("%s: unexpected portfolio: %+v\n", msg, p1)
+	}
+	if f := p1.Fields[1]; f.Key != "Author" || f.Subtype != "S" || !f.Editable || !p1.Fields[4].Hidden {
+		t.Fatalf("%s: unexpected fields: %+v\n", msg, p1.Fields)
+	}
+	if len(p1.Sort) != 2 || p1.Sort[0] != "Received" || p1.Ascending[0] || !p1.Ascending[1] {
+		t.Fatalf("%s: unexpected sort: %v %v\n", msg, p1.Sort, p1.Ascending)
+	}
+
+	it := p1.Items[0]
+	if it.ID != "go.pdf" || it.Desc != "Go slides" {
+		t.Fatalf("%s: unexpected item: %+v\n", msg, it)
+	}
+	if it.Fields["Author"] != "Yossi Gil" || it.Fields["Pages"] != 23. {
+		t.Fatalf("%s: unexpected item fields: %v\n", msg, it.Fields)
+	}
+	if d, ok := it.Fields["Received"].(time.Time); !ok || !d.Equal(received) {
+		t.Fatalf("%s: unexpected date: %v\n", msg, it.Fields["Received"])
+	}
+
+	// Extract the embedded documents.
+	dir := filepath.Join(outDir, "portfolio")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ExtractPortfolioFile(outFile, dir, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	n, err := api.PageCountFile(filepath.Join(dir, "go.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n != 23 {
+		t.Fatalf("%s: extracted go.pdf: want 23 pages, got %d\n", msg, n)
+	}
+
+	// Use an existing document as cover sheet.
+	outFile = filepath.Join(outDir, "portfolioWithCover.pdf")
+	p.Fields, p.Sort, p.Ascending = nil, nil, nil
+	for i := range p.Items {
+		p.Items[i].Fields = nil
+	}
+	if err := api.CreatePortfolioFile(filepath.Join(inDir, "annotTest.pdf"), outFile, p, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if p1, err = api.PortfolioFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(p1.Fields) != len(model.DefaultPortfolioFields) || len(p1.Items) != 3 {
+		t.Fatalf("%s: unexpected portfolio: %+v\n", msg, p1)
+	}
+
+	// Regular documents are no portfolios.
+	if _, err := api.PortfolioFile(filepath.Join(inDir, "go.pdf"), nil); err == nil {
+		t.Fatalf("%s: missing error for non portfolio\n", msg)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/validate"
+)
+
+func rules(vv []validate.PreflightViolation) map[string]bool {
+	m := map[string]bool{}
+	for _, v := range vv {
+		m[v.Rule] = true
+	}
+	return m
+}
+
+func TestPreflight(t *testing.T) {
+	msg := "TestPreflight"
+	inFile := filepath.Join(inDir, "mountain.pdf")
+
+	profileFile := filepath.Join(outDir, "preflight.json")
+	profile := `{"name": "strict", "maxPageWidth": 100, "maxPageHeight": 100, "minImageDPI": 100000, "fontsEmbedded": true, "noEncryption": true}`
+	if err := os.WriteFile(profileFile, []byte(profile), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	vv, err := api.PreflightFile(inFile, profileFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	m := rules(vv)
+	if !m[validate.RuleMaxPageSize] || !m[validate.RuleMinImageDPI] {
+		t.Fatalf("%s: missing violations: %v\n", msg, vv)
+	}
+	for _, v := range vv {
+		if v.PageNr == 0 {
+			t.Fatalf("%s: missing page location: %s\n", msg, v)
+		}
+	}
+
+	// A lenient profile passes.
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	vv, err = api.Preflight(f, &validate.PreflightProfile{Name: "lenient", MaxPageWidth: 14400, MaxPageHeight: 14400, MinImageDPI: 1}, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(vv) > 0 {
+		t.Fatalf("%s: unexpected violations: %v\n", msg, vv)
+	}
+
+	// Unknown rules are rejected.
+	if err := os.WriteFile(profileFile, []byte(`{"maxPageWidht": 100}`), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := api.PreflightFile(inFile, profileFile, nil); err == nil {
+		t.Fatalf("%s: invalid profile accepted\n", msg)
+	}
+}
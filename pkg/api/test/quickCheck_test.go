@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestQuickCheck(t *testing.T) {
+	msg := "TestQuickCheck"
+
+	for _, tt := range []struct {
+		fn         string
+		updates    int
+		linearized bool
+	}{
+		{"test.pdf", 0, false},
+		{"Hybrid-PDF.pdf", 0, false},
+		{"go.pdf", 0, false},
+		{"WaldenFull.pdf", 1, true}, // linearized, then updated
+	} {
+		inFile := filepath.Join(inDir, tt.fn)
+		res, err := api.QuickCheckFile(inFile, nil)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fn, err)
+		}
+		pageCount, err := api.PageCountFile(inFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fn, err)
+		}
+		if res.PageCount != pageCount || res.Updates != tt.updates || res.Linearized != tt.linearized || res.Encrypted {
+			t.Fatalf("%s %s: unexpected result %+v\n", msg, tt.fn, res)
+		}
+	}
+
+	// Incremental updates
+	inFile := filepath.Join(outDir, "quickCheck.pdf")
+	copyFile(t, filepath.Join(inDir, "test.pdf"), inFile)
+	add2Annotations(t, msg, inFile, true)
+	res, err := api.QuickCheckFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if res.Updates != 2 {
+		t.Fatalf("%s: want 2 updates, got %d\n", msg, res.Updates)
+	}
+
+	// Encryption
+	encFile := filepath.Join(outDir, "quickCheckEnc.pdf")
+	if err := api.EncryptFile(filepath.Join(inDir, "test.pdf"), encFile, confForAlgorithm(true, 256, "upw", "opw")); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if res, err = api.QuickCheckFile(encFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !res.Encrypted {
+		t.Fatalf("%s: want encrypted\n", msg)
+	}
+
+	// Truncated and garbage files
+	bb, err := os.ReadFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, bb := range [][]byte{bb[:len(bb)/2], []byte("%PDF-1.7\nno xref\n%%EOF\n"), []byte("garbage")} {
+		if res, err := api.QuickCheck(bytes.NewReader(bb), nil); err == nil {
+			t.Fatalf("%s: want error, got %+v\n", msg, res)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// damagedFile writes inFile using the given xref flavor and cuts off the output at the last occurrence of marker.
+func damagedFile(t *testing.T, msg, inFile, outFile, marker string, xRefStream bool) {
+	t.Helper()
+
+	conf := model.NewDefaultConfiguration()
+	conf.WriteObjectStream = xRefStream
+	conf.WriteXRefStream = xRefStream
+
+	var buf bytes.Buffer
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	if err := api.Optimize(f, &buf, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bb := buf.Bytes()
+	i := bytes.LastIndex(bb, []byte(marker))
+	if i < 0 {
+		t.Fatalf("%s: missing %q\n", msg, marker)
+	}
+
+	if err := os.WriteFile(outFile, bb[:i], os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	msg := "TestRepair"
+
+	for _, tt := range []struct {
+		fileName      string
+		marker        string
+		xRefStream    bool
+		rootRecovered bool
+		pageCount     int
+	}{
+		// Truncated right before the xref section: no trailer, locate the catalog.
+		{"go.pdf", "\nxref", false, true, 23},
+		// Truncated within the xref stream: salvage trailer and object streams.
+		{"Acroforms2.pdf", "startxref", true, false, 3},
+	} {
+		inFile := filepath.Join(inDir, tt.fileName)
+		damaged := filepath.Join(outDir, "damaged_"+tt.fileName)
+		damagedFile(t, msg, inFile, damaged, tt.marker, tt.xRefStream)
+
+		f, err := os.Open(damaged)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		_, rep, err := pdfcpu.ReadAndRepair(f, nil)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fileName, err)
+		}
+		if !rep.HeaderFound || rep.RootRecovered != tt.rootRecovered || len(rep.Damaged) > 0 {
+			t.Fatalf("%s %s: unexpected report: %+v\n", msg, tt.fileName, rep)
+		}
+		if tt.xRefStream && (!rep.TrailerFound || rep.ObjectStreams == 0 || rep.CompressedObjects == 0) {
+			t.Fatalf("%s %s: object streams not salvaged: %+v\n", msg, tt.fileName, rep)
+		}
+
+		outFile := filepath.Join(outDir, "repaired_"+tt.fileName)
+		if _, err := api.RepairFile(damaged, outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fileName, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fileName, err)
+		}
+
+		n, err := api.PageCountFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.fileName, err)
+		}
+		if n != tt.pageCount {
+			t.Fatalf("%s %s: want %d pages, got %d\n", msg, tt.fileName, tt.pageCount, n)
+		}
+	}
+}
+
+func TestTolerateCorruptStreams(t *testing.T) {
+	msg := "TestTolerateCorruptStreams"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "go.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Corrupt the Flate encoded content stream of page 1.
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ir := d.IndirectRefEntry("Contents")
+	sd, _, err := ctx.DereferenceStreamDict(*ir)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	objNr := ir.ObjectNumber.Value()
+	raw := append([]byte{}, sd.Raw...)
+	for i := len(raw) / 2; i < len(raw)/2+16; i++ {
+		raw[i] ^= 0x5A
+	}
+	sd.Raw, sd.Content = raw, nil
+	ctx.Table[objNr].Object = *sd
+
+	corruptFile := filepath.Join(outDir, "corruptStream.pdf")
+	if err := api.WriteContextFile(ctx, corruptFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// By default the damaged stream fails to decode.
+	ctx, err = api.ReadContextFile(corruptFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if sd, _, err = ctx.DereferenceStreamDict(*ir); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := sd.Decode(); err == nil {
+		t.Fatalf("%s: missing decode error\n", msg)
+	}
+	if len(ctx.DamageReport()) > 0 {
+		t.Fatalf("%s: unexpected damage report\n", msg)
+	}
+
+	// Tolerate the damage and keep the decodable prefix.
+	conf := model.NewDefaultConfiguration()
+	conf.TolerateCorruptStreams = true
+	f, err := os.Open(corruptFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+	if ctx, err = api.ReadAndValidate(f, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	dd := ctx.DamageReport()
+	if len(dd) != 1 || dd[0].ObjNr != objNr || dd[0].ValidLength == 0 || dd[0].ValidLength >= dd[0].RawLength {
+		t.Fatalf("%s: unexpected damage report: %v\n", msg, dd)
+	}
+
+	if sd, _, err = ctx.DereferenceStreamDict(*ir); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := sd.Decode(); err != nil || len(sd.Content) != dd[0].Decoded {
+		t.Fatalf("%s: repaired stream: %v\n", msg, err)
+	}
+
+	outFile := filepath.Join(outDir, "repairedStream.pdf")
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package test
 
 import (
+	"math"
 	"path/filepath"
 	"testing"
 
@@ -138,3 +139,80 @@ func TestResizeToDimensions(t *testing.T) {
 		t.Fatalf("%s resize: %v\n", msg, err)
 	}
 }
+
+func TestResizePages(t *testing.T) {
+	msg := "TestResizePages"
+	inFile := filepath.Join(inDir, "annotTest.pdf")
+	boxFile := filepath.Join(outDir, "annotTestBoxes.pdf")
+	outFile := filepath.Join(outDir, "annotTestResized.pdf")
+
+	pb, err := api.PageBoundaries("trim:20, bleed:10", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AddBoxesFile(inFile, boxFile, []string{"1"}, pb, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(boxFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d, _, inhPAttrs, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	mb := inhPAttrs.MediaBox
+	annots, _ := ctx.DereferenceArray(d["Annots"])
+	if len(annots) == 0 {
+		t.Fatalf("%s: missing annotations\n", msg)
+	}
+
+	// Shrink to half size.
+	target := &types.Dim{Width: mb.Width() / 2, Height: mb.Height() / 2}
+	if err := api.ResizePagesFile(boxFile, outFile, []string{"1"}, target, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err = api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d, _, inhPAttrs, err = ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	eq := func(f1, f2 float64) bool { return math.Abs(f1-f2) < .01 }
+
+	if r := inhPAttrs.MediaBox; !eq(r.Width(), target.Width) || !eq(r.Height(), target.Height) {
+		t.Fatalf("%s: want mediaBox %s, got %s\n", msg, target, r)
+	}
+
+	for k, want := range map[string]float64{"TrimBox": 10, "BleedBox": 5} {
+		a, err := ctx.DereferenceArray(d[k])
+		if err != nil || a == nil {
+			t.Fatalf("%s: missing %s\n", msg, k)
+		}
+		r, _ := ctx.RectForArray(a)
+		if !eq(r.LL.X, want) || !eq(r.Width(), target.Width-2*want) {
+			t.Fatalf("%s: %s not scaled: %s\n", msg, k, r)
+		}
+	}
+
+	annots1, _ := ctx.DereferenceArray(d["Annots"])
+	if len(annots1) != len(annots) {
+		t.Fatalf("%s: want %d annotations, got %d\n", msg, len(annots), len(annots1))
+	}
+	for _, o := range annots1 {
+		d1, _ := ctx.DereferenceDict(o)
+		a, _ := ctx.DereferenceArray(d1["Rect"])
+		r, _ := ctx.RectForArray(a)
+		if !r.FitsWithin(inhPAttrs.MediaBox) {
+			t.Fatalf("%s: annotation rect %s outside of resized page\n", msg, r)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestRevisions(t *testing.T) {
+	msg := "TestRevisions"
+
+	fn := "test.pdf"
+	inFile := filepath.Join(outDir, "revisions.pdf")
+	copyFile(t, filepath.Join(inDir, fn), inFile)
+
+	// Append three increments.
+	add2Annotations(t, msg, inFile, true)
+	if err := api.RemoveAnnotationsFile(inFile, "", nil, nil, nil, nil, true); err != nil {
+		t.Fatalf("%s remove: %v\n", msg, err)
+	}
+
+	rr, err := api.RevisionsFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(rr) != 4 {
+		t.Fatalf("%s: want 4 revisions, got %d\n", msg, len(rr))
+	}
+
+	fi, err := os.Stat(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	last := rr[len(rr)-1]
+	if last.Size != fi.Size() {
+		t.Fatalf("%s: last revision size want:%d got:%d\n", msg, fi.Size(), last.Size)
+	}
+	if len(last.Changed) == 0 || len(last.Changed) >= len(rr[0].Changed) {
+		t.Fatalf("%s: unexpected changed objects %v\n", msg, last.Changed)
+	}
+	if len(last.ID) != 2 || last.ID[0] != rr[0].ID[0] {
+		t.Fatalf("%s: permanent ID changed: %v %v\n", msg, rr[0].ID, last.ID)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	changed, err := pdfcpu.ChangedObjects(f, len(rr), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(changed) != len(last.Changed) {
+		t.Fatalf("%s: changed objects want:%v got:%v\n", msg, last.Changed, changed)
+	}
+
+	// The original document has no annotations, each following increment adds one.
+	for i, want := range []int{0, 1, 2} {
+		outFile := filepath.Join(outDir, "revision.pdf")
+		if err := api.ExtractRevisionFile(inFile, outFile, i+1); err != nil {
+			t.Fatalf("%s extract: %v\n", msg, err)
+		}
+		if got := annotationCount(t, outFile); got != want {
+			t.Fatalf("%s: revision %d annotations want:%d got:%d\n", msg, i+1, want, got)
+		}
+	}
+
+	if _, err := pdfcpu.Revision(f, len(rr)+1); err == nil {
+		t.Fatalf("%s: invalid revision should fail\n", msg)
+	}
+}
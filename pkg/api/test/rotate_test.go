@@ -41,3 +41,63 @@ func TestRotate(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func TestNormalizeRotation(t *testing.T) {
+	msg := "TestNormalizeRotation"
+
+	for _, tt := range []struct {
+		fileName string
+		rotation int
+	}{
+		{"go.pdf", 90},
+		{"go.pdf", 180},
+		{"annotTest.pdf", 270},
+	} {
+		inFile := filepath.Join(inDir, tt.fileName)
+		rotFile := filepath.Join(outDir, "rotated.pdf")
+		outFile := filepath.Join(outDir, "normalized.pdf")
+
+		if err := api.RotateFile(inFile, rotFile, tt.rotation, nil, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := api.NormalizeRotationFile(rotFile, outFile, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		ctx, err := api.ReadContextFile(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		for i := 1; i <= ctx.PageCount; i++ {
+			d, _, inhPAttrs, err := ctx.PageDict(i, false)
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if inhPAttrs.Rotate != 0 {
+				t.Fatalf("%s %s: page %d: unexpected rotation %d\n", msg, tt.fileName, i, inhPAttrs.Rotate)
+			}
+			annots, _ := ctx.DereferenceArray(d["Annots"])
+			for _, o := range annots {
+				d1, _ := ctx.DereferenceDict(o)
+				a, _ := ctx.DereferenceArray(d1["Rect"])
+				if r, _ := ctx.RectForArray(a); r != nil && !r.FitsWithin(inhPAttrs.MediaBox) {
+					t.Fatalf("%s %s: page %d: annotation rect %s outside of page\n", msg, tt.fileName, i, r)
+				}
+			}
+		}
+
+		// The displayed pages are unchanged.
+		pdd, err := api.VisualDiffFile(rotFile, outFile, "", 36, nil)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		for _, pd := range pdd {
+			if pd.Score > .001 {
+				t.Fatalf("%s %s %d: page %d differs: %f\n", msg, tt.fileName, tt.rotation, pd.PageNr, pd.Score)
+			}
+		}
+	}
+}
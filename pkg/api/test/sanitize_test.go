@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestSanitize(t *testing.T) {
+	msg := "TestSanitize"
+
+	inFile := filepath.Join(outDir, "unsanitized.pdf")
+	outFile := filepath.Join(outDir, "sanitized.pdf")
+
+	createJavaScriptPDF(t, inFile)
+
+	if err := api.AddAttachmentsFile(inFile, "", []string{filepath.Join(resDir, "test.wav")}, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Keep URI actions.
+	policy := pdfcpu.DefaultSanitizePolicy()
+	policy.URI = false
+
+	r, err := api.SanitizeFile(inFile, outFile, policy, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	var js int
+	for _, s := range r.Actions {
+		if strings.HasPrefix(s, "URI") {
+			t.Fatalf("%s: unexpected removal: %s\n", msg, s)
+		}
+		if strings.HasPrefix(s, "JavaScript") {
+			js++
+		}
+	}
+	if js != 3 {
+		t.Fatalf("%s: want 3 removed JavaScript actions, got:\n%s\n", msg, r)
+	}
+
+	found := false
+	for _, s := range r.EmbeddedFiles {
+		if s == "test.wav" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("%s: missing removed attachment:\n%s\n", msg, r)
+	}
+
+	jj, err := api.ListJavaScriptFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(jj) != 0 {
+		t.Fatalf("%s: want no JavaScript, got: %v\n", msg, jj)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	aa, err := api.Attachments(f, nil)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(aa) != 0 {
+		t.Fatalf("%s: want no attachments, got: %v\n", msg, aa)
+	}
+
+	// Now also strip the URI actions.
+	r, err = api.SanitizeFile(outFile, "", pdfcpu.DefaultSanitizePolicy(), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if r.Count() == 0 {
+		t.Fatalf("%s: missing URI action removal\n", msg)
+	}
+	for _, s := range r.Actions {
+		if !strings.HasPrefix(s, "URI") {
+			t.Fatalf("%s: unexpected removal: %s\n", msg, s)
+		}
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestScrubMetadata(t *testing.T) {
+	msg := "TestScrubMetadata"
+
+	inFile := filepath.Join(inDir, "text_annotations.pdf")
+	outFile := filepath.Join(outDir, "scrubbed.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	id := ctx.ID[0].String()
+
+	opts := pdfcpu.DefaultScrubOptions()
+	opts.InfoKeep = []string{"Title"}
+	opts.Author = "anonymous"
+
+	ss, err := api.ScrubMetadataFile(inFile, outFile, opts, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ss) == 0 {
+		t.Fatalf("%s: nothing scrubbed\n", msg)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ctx, err = api.ReadContextFile(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if ctx.ID[0].String() == id {
+		t.Fatalf("%s: document ID not regenerated\n", msg)
+	}
+
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, found := d.Find("Title"); !found {
+		t.Fatalf("%s: missing Title\n", msg)
+	}
+	if _, found := d.Find("Creator"); found {
+		t.Fatalf("%s: Creator not scrubbed\n", msg)
+	}
+	if s, err := ctx.DereferenceText(d["Author"]); err != nil || s != "anonymous" {
+		t.Fatalf("%s: Author not anonymized: %s %v\n", msg, s, err)
+	}
+
+	pd, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	a, err := ctx.DereferenceArray(pd["Annots"])
+	if err != nil || len(a) == 0 {
+		t.Fatalf("%s: missing annotations: %v\n", msg, err)
+	}
+	for _, o := range a {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		for _, k := range []string{"CreationDate", "M"} {
+			if _, found := d.Find(k); found {
+				t.Fatalf("%s: annotation %s not scrubbed\n", msg, k)
+			}
+		}
+		if o, found := d.Find("T"); found {
+			if s, err := ctx.DereferenceText(o); err != nil || s != "anonymous" {
+				t.Fatalf("%s: annotation author not anonymized: %s %v\n", msg, s, err)
+			}
+		}
+	}
+
+	// XMP metadata
+	inFile = filepath.Join(inDir, "adobe_errata.pdf")
+	if _, err := api.ScrubMetadataFile(inFile, outFile, pdfcpu.DefaultScrubOptions(), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx, err = api.ReadContextFile(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, found := rootDict.Find("Metadata"); found {
+		t.Fatalf("%s: XMP metadata not scrubbed\n", msg)
+	}
+}
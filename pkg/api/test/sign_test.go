@@ -17,11 +17,31 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hhrutter/pkcs7"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/sign"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func logResults(ss []string) {
@@ -105,3 +125,294 @@ func TestValidateSignature_ETSI_CAdES_Detached(t *testing.T) {
 		logResults(ss)
 	}
 }
+
+// fakeTSA answers RFC 3161 requests with a dummy token and records the message imprint.
+func fakeTSA(t *testing.T, token []byte, digest *[]byte) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/timestamp-query" {
+			http.Error(w, "unexpected content type", http.StatusBadRequest)
+			return
+		}
+		bb, _ := io.ReadAll(r.Body)
+		var req struct {
+			Version        int
+			MessageImprint struct {
+				HashAlgorithm struct{ Algorithm asn1.ObjectIdentifier }
+				HashedMessage []byte
+			}
+			Nonce   asn1.RawValue `asn1:"optional"`
+			CertReq bool          `asn1:"optional"`
+		}
+		if _, err := asn1.Unmarshal(bb, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*digest = req.MessageImprint.HashedMessage
+
+		resp, _ := asn1.Marshal(struct {
+			Status struct{ Status int }
+			Token  asn1.RawValue
+		}{Token: asn1.RawValue{FullBytes: token}})
+
+		w.Header().Set("Content-Type", "application/timestamp-reply")
+		w.Write(resp)
+	}))
+}
+
+func TestAddDocTimeStamp(t *testing.T) {
+	msg := "TestAddDocTimeStamp"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "docTimeStamp.pdf")
+
+	token, _ := asn1.Marshal(struct{ Dummy string }{"time-stamp token"})
+
+	var digest []byte
+	ts := fakeTSA(t, token, &digest)
+	defer ts.Close()
+
+	tsa := sign.HTTPTSAClient{URL: ts.URL}
+
+	if err := api.AddDocTimeStampFile(inFile, outFile, tsa, 1024, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	bb, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fi, err := os.Stat(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.Equal(bb[:fi.Size()], mustReadFile(t, inFile)) {
+		t.Fatalf("%s: original revision modified\n", msg)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ctx.Signatures) != 1 {
+		t.Fatalf("%s: want 1 signature, got %d\n", msg, len(ctx.Signatures))
+	}
+
+	var sigDict types.Dict
+	for _, sigs := range ctx.Signatures {
+		for objNr := range sigs {
+			d, err := ctx.DereferenceDict(*types.NewIndirectRef(objNr, 0))
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if sigDict, err = ctx.DereferenceDict(d["V"]); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+		}
+	}
+	if sf := sigDict.NameEntry("SubFilter"); sf == nil || *sf != "ETSI.RFC3161" {
+		t.Fatalf("%s: missing DocTimeStamp\n", msg)
+	}
+
+	// The byte range covers the whole file except the time-stamp token.
+	br := sigDict.ArrayEntry("ByteRange")
+	if len(br) != 4 {
+		t.Fatalf("%s: invalid ByteRange: %v\n", msg, br)
+	}
+	a, b, c := br[1].(types.Integer).Value(), br[2].(types.Integer).Value(), br[3].(types.Integer).Value()
+	if b+c != len(bb) || bb[a] != '<' || bb[b-1] != '>' {
+		t.Fatalf("%s: ByteRange does not cover the file: %v\n", msg, br)
+	}
+
+	h := sha256.New()
+	h.Write(bb[:a])
+	h.Write(bb[b:])
+	if !bytes.Equal(h.Sum(nil), digest) {
+		t.Fatalf("%s: message imprint mismatch\n", msg)
+	}
+
+	if !bytes.HasPrefix(bb[a+1:], []byte(hex.EncodeToString(token))) {
+		t.Fatalf("%s: missing time-stamp token\n", msg)
+	}
+
+	// A second time-stamp gets appended as another increment.
+	if err := api.AddDocTimeStampFile(outFile, "", tsa, 1024, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The dummy token gets rejected but the latest time-stamp is processed.
+	results, err := api.ValidateSignatures(outFile, true, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(results) != 1 || results[0].Details.SubFilter != "ETSI.RFC3161" {
+		t.Fatalf("%s: unexpected signature validation results: %d\n", msg, len(results))
+	}
+
+	// Insufficient reserved space.
+	if err := api.AddDocTimeStampFile(inFile, outFile, tsa, 8, nil); err == nil {
+		t.Fatalf("%s: missing error for insufficient contents size\n", msg)
+	}
+}
+
+// fakeRevocationFetcher returns dummy OCSP responses and records the certificates asked for.
+type fakeRevocationFetcher struct {
+	calls []string
+}
+
+func (f *fakeRevocationFetcher) OCSP(cert, issuer *x509.Certificate) ([]byte, error) {
+	f.calls = append(f.calls, cert.Subject.CommonName)
+	return asn1.Marshal(struct{ Status, Subject string }{"good", cert.Subject.CommonName})
+}
+
+func (f *fakeRevocationFetcher) CRLs(cert *x509.Certificate) ([][]byte, error) {
+	return nil, fmt.Errorf("no CRL for: %s", cert.Subject)
+}
+
+func testCertificate(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if parent == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	} else {
+		tmpl.OCSPServer = []string{"http://ocsp.example.com"}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key
+}
+
+func TestAddValidationInfo(t *testing.T) {
+	msg := "TestAddValidationInfo"
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "validationInfo.pdf")
+
+	ca, caKey := testCertificate(t, "Test CA", nil, nil)
+	tsaCert, tsaKey := testCertificate(t, "Test TSA", ca, caKey)
+
+	sd, err := pkcs7.NewSignedData([]byte("time-stamp token"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := sd.AddSignerChain(tsaCert, tsaKey, []*x509.Certificate{ca}, pkcs7.SignerInfoConfig{}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	sd.Detach()
+	token, err := sd.Finish()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	var digest []byte
+	ts := fakeTSA(t, token, &digest)
+	defer ts.Close()
+
+	if err := api.AddDocTimeStampFile(inFile, outFile, sign.HTTPTSAClient{URL: ts.URL}, 4096, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	size := len(mustReadFile(t, outFile))
+
+	fetcher := &fakeRevocationFetcher{}
+	if err := api.AddValidationInfoFile(outFile, "", fetcher, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Only the TSA certificate needs revocation information, the CA is a trust anchor.
+	if len(fetcher.calls) != 1 || fetcher.calls[0] != "Test TSA" {
+		t.Fatalf("%s: unexpected revocation requests: %v\n", msg, fetcher.calls)
+	}
+
+	checkDSS := func() {
+		t.Helper()
+
+		ctx, err := api.ReadContextFile(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := api.ValidateContext(ctx); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if ctx.DSS == nil {
+			t.Fatalf("%s: missing DSS\n", msg)
+		}
+
+		certs, err := ctx.DereferenceArray(ctx.DSS["Certs"])
+		if err != nil || len(certs) != 2 {
+			t.Fatalf("%s: want 2 DSS certs, got %d %v\n", msg, len(certs), err)
+		}
+		ocsps, err := ctx.DereferenceArray(ctx.DSS["OCSPs"])
+		if err != nil || len(ocsps) != 1 {
+			t.Fatalf("%s: want 1 DSS OCSP response, got %d %v\n", msg, len(ocsps), err)
+		}
+
+		vri, err := ctx.DereferenceDict(ctx.DSS["VRI"])
+		if err != nil || len(vri) != 1 {
+			t.Fatalf("%s: want 1 VRI entry, got %d %v\n", msg, len(vri), err)
+		}
+		h := sha1.Sum(token)
+		key := strings.ToUpper(hex.EncodeToString(h[:]))
+		e, err := ctx.DereferenceDict(vri[key])
+		if err != nil || e == nil {
+			t.Fatalf("%s: missing VRI entry for %s\n", msg, key)
+		}
+		if len(e.ArrayEntry("Cert")) != 2 || len(e.ArrayEntry("OCSP")) != 1 {
+			t.Fatalf("%s: incomplete VRI entry: %s\n", msg, e)
+		}
+	}
+
+	checkDSS()
+
+	bb := mustReadFile(t, outFile)
+	if len(bb) <= size {
+		t.Fatalf("%s: missing increment\n", msg)
+	}
+
+	// Repeating the update does not duplicate validation information.
+	if err := api.AddValidationInfoFile(outFile, "", fetcher, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkDSS()
+
+	// Files without signatures.
+	if err := api.AddValidationInfoFile(inFile, filepath.Join(outDir, "noSignatures.pdf"), fetcher, nil); err == nil {
+		t.Fatalf("%s: missing error for unsigned file\n", msg)
+	}
+}
+
+func mustReadFile(t *testing.T, fileName string) []byte {
+	t.Helper()
+	bb, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bb
+}
@@ -17,7 +17,13 @@ limitations under the License.
 package test
 
 import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -102,3 +108,173 @@ func TestSplitLowLevel(t *testing.T) {
 		t.Fatalf("%s write: %v\n", msg, err)
 	}
 }
+
+func TestSplitByBookmarksLevel(t *testing.T) {
+	msg := "TestSplitByBookmarksLevel"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	bmFile := filepath.Join(outDir, "bookmarked.pdf")
+
+	bms := []pdfcpu.Bookmark{
+		{PageFrom: 1, Title: "Part 1: Intro", Kids: []pdfcpu.Bookmark{
+			{PageFrom: 2, Title: "Chapter A"},
+			{PageFrom: 4, Title: "Chapter B"},
+		}},
+		{PageFrom: 6, Title: "Part/2", Kids: []pdfcpu.Bookmark{
+			{PageFrom: 7, Title: "Chapter A"},
+		}},
+	}
+	if err := api.AddBookmarksFile(inFile, bmFile, bms, true, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pageCount, err := api.PageCountFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, tt := range []struct {
+		level int
+		files map[string]int
+	}{
+		{1, map[string]int{"Part_1_Intro": 5, "Part_2": pageCount - 5}},
+		{2, map[string]int{"Chapter_A": 2, "Chapter_B": 2, "Chapter_A_2": pageCount - 6}},
+	} {
+		dir := filepath.Join(outDir, "bookmarks", strconv.Itoa(tt.level))
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := api.SplitByBookmarksFile(bmFile, dir, tt.level, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		for fn, want := range tt.files {
+			got, err := api.PageCountFile(filepath.Join(dir, fn+".pdf"))
+			if err != nil {
+				t.Fatalf("%s level %d: %v\n", msg, tt.level, err)
+			}
+			if got != want {
+				t.Fatalf("%s level %d: %s: want %d pages, got %d\n", msg, tt.level, fn, want, got)
+			}
+		}
+	}
+
+	if err := api.SplitByBookmarksFile(bmFile, outDir, 3, nil); err == nil {
+		t.Fatalf("%s: split at missing outline level succeeded\n", msg)
+	}
+}
+
+// writeCode39 writes s as Code 39 barcode into a PNG file.
+func writeCode39(t *testing.T, s, fileName string) {
+	t.Helper()
+
+	patterns := map[rune]int{'*': 0x094, 'S': 0x046, 'E': 0x118, 'P': 0x052, '1': 0x121}
+	narrow, wide, quiet := 4, 10, 40
+
+	ww := []int{}
+	for _, r := range "*" + s + "*" {
+		p := patterns[r]
+		for i := 8; i >= 0; i-- {
+			w := narrow
+			if p&(1<<i) > 0 {
+				w = wide
+			}
+			ww = append(ww, w)
+		}
+		ww = append(ww, narrow)
+	}
+
+	width := 2 * quiet
+	for _, w := range ww {
+		width += w
+	}
+	img := image.NewGray(image.Rect(0, 0, width, 200))
+	for i := 0; i < width; i++ {
+		for y := 0; y < 200; y++ {
+			img.SetGray(i, y, color.Gray{Y: 255})
+		}
+	}
+	x := quiet
+	for i, w := range ww {
+		if i%2 == 0 {
+			for dx := 0; dx < w; dx++ {
+				for y := 20; y < 180; y++ {
+					img.SetGray(x+dx, y, color.Gray{})
+				}
+			}
+		}
+		x += w
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitBySeparator(t *testing.T) {
+	msg := "TestSplitBySeparator"
+	mountain := filepath.Join(inDir, "mountain.pdf")
+	goFile := filepath.Join(inDir, "go.pdf")
+
+	// Patch sheet carrying barcode SEP1.
+	imgFile := filepath.Join(outDir, "sep.png")
+	writeCode39(t, "SEP1", imgFile)
+	sepFile := filepath.Join(outDir, "sep.pdf")
+	if err := api.ImportImagesFile([]string{imgFile}, sepFile, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	inFile := filepath.Join(outDir, "scanned.pdf")
+	if err := api.MergeCreateFile([]string{mountain, sepFile, mountain, sepFile, goFile}, inFile, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	pageCount, err := api.PageCountFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	check := func(dir string, want map[string]int) {
+		t.Helper()
+		for fn, n := range want {
+			got, err := api.PageCountFile(filepath.Join(dir, fn))
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if got != n {
+				t.Fatalf("%s: %s: want %d pages, got %d\n", msg, fn, n, got)
+			}
+		}
+	}
+
+	// Split at barcode and drop patch sheets.
+	dir := filepath.Join(outDir, "barcode")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	sc := &pdfcpu.SeparatorCriteria{Barcode: "SEP1", Drop: true}
+	if err := api.SplitBySeparatorFile(inFile, dir, sc, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	check(dir, map[string]int{
+		"scanned_1.pdf": 1,
+		"scanned_3.pdf": 1,
+		"scanned_5-" + strconv.Itoa(pageCount) + ".pdf": pageCount - 4,
+	})
+
+	// Split at text and keep the separator page.
+	dir = filepath.Join(outDir, "text")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	sc = &pdfcpu.SeparatorCriteria{Text: regexp.MustCompile(`Go Programming Language`)}
+	if err := api.SplitBySeparatorFile(inFile, dir, sc, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	check(dir, map[string]int{
+		"scanned_1-4.pdf": 4,
+		"scanned_5-" + strconv.Itoa(pageCount) + ".pdf": pageCount - 4,
+	})
+}
@@ -17,12 +17,18 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -109,6 +115,38 @@ func TestAddWatermarks(t *testing.T) {
 					strokec: #808080"`,
 			""},
 
+		{"TestWatermarkText",
+			"Walden.pdf",
+			"TextGradient.pdf",
+			[]string{"1-"},
+			"text",
+			"Gradient",
+			"font:Helvetica-Bold, points:64, scale:1 abs, gradient:axial #FF0000 #0000FF 45, rot:30"},
+
+		{"TestWatermarkText",
+			"Walden.pdf",
+			"TextStripes.pdf",
+			[]string{"1-"},
+			"text",
+			"Stripes",
+			"font:Helvetica-Bold, points:64, scale:1 abs, gradient:stripes black #FF8C00 -45 3, mode:2, strokec:black"},
+
+		{"TestWatermarkText",
+			"Walden.pdf",
+			"TextSeal.pdf",
+			[]string{"1-"},
+			"text",
+			"ACME CORPORATION\nAPPROVED",
+			"font:Helvetica-Bold, points:18, scale:1 abs, path:circle 80, border:3 #C00000, color:#C00000, rot:15, op:.8"},
+
+		{"TestWatermarkText",
+			"Walden.pdf",
+			"TextSealRelative.pdf",
+			[]string{"1-"},
+			"text",
+			"Page %p of %P * pdfcpu *",
+			"pos:tr, off:-20 -20, scale:.3, path:circle 50, rot:0"},
+
 		{"TestWatermarkText",
 			"Walden.pdf",
 			"TextAlongLeftBorder.pdf",
@@ -637,3 +675,570 @@ func TestRecycleWM(t *testing.T) {
 		t.Fatalf("%s %s: %v\n", msg, outFile, err)
 	}
 }
+
+func TestWatermarkByID(t *testing.T) {
+	msg := "TestWatermarkByID"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+	onTop := true
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s readContext: %v\n", msg, err)
+	}
+	annots, _, err := pdfcpu.ListAnnotations(ctx.PageAnnots)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, s := range []string{"Draft", "Footer"} {
+		wm, err := api.TextWatermark(s, "url:pdfcpu.io", onTop, false, types.POINTS)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		wm.ID = strings.ToLower(s)
+		if err := pdfcpu.AddWatermarks(ctx, nil, wm); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := api.WriteContext(ctx, &buf); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+
+	// Replace the draft stamp.
+	wm, err := api.TextWatermark("Final", "", onTop, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	var buf1 bytes.Buffer
+	if err := api.UpdateWatermarkByID(bytes.NewReader(buf.Bytes()), &buf1, "draft", wm, nil); err != nil {
+		t.Fatalf("%s update: %v\n", msg, err)
+	}
+
+	// Unknown ids are reported.
+	if err := api.RemoveWatermarkByID(bytes.NewReader(buf1.Bytes()), io.Discard, "unknown", nil); err == nil {
+		t.Fatalf("%s: removing unknown id should fail\n", msg)
+	}
+
+	// Remove the footer, the updated draft stamp remains.
+	buf.Reset()
+	if err := api.RemoveWatermarkByID(bytes.NewReader(buf1.Bytes()), &buf, "footer", nil); err != nil {
+		t.Fatalf("%s remove: %v\n", msg, err)
+	}
+	if ok, err := api.HasWatermarks(bytes.NewReader(buf.Bytes()), nil); err != nil || !ok {
+		t.Fatalf("%s: missing watermark: %v\n", msg, err)
+	}
+	if err := api.RemoveWatermarkByID(bytes.NewReader(buf.Bytes()), io.Discard, "footer", nil); err == nil {
+		t.Fatalf("%s: footer should be gone\n", msg)
+	}
+
+	buf1.Reset()
+	if err := api.RemoveWatermarkByID(bytes.NewReader(buf.Bytes()), &buf1, "draft", nil); err != nil {
+		t.Fatalf("%s remove: %v\n", msg, err)
+	}
+	if ok, err := api.HasWatermarks(bytes.NewReader(buf1.Bytes()), nil); err != nil || ok {
+		t.Fatalf("%s: unexpected watermark: %v\n", msg, err)
+	}
+
+	// Removing the stamps also removed their links.
+	ctx, err = api.ReadContext(bytes.NewReader(buf1.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, _, err := pdfcpu.ListAnnotations(ctx.PageAnnots); err != nil || n != annots {
+		t.Fatalf("%s: want %d annotations, got %d: %v\n", msg, annots, n, err)
+	}
+}
+
+func pageOpacities(t *testing.T, ctx *model.Context, pageNr int) map[float64]bool {
+	t.Helper()
+
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, true)
+	if err != nil || d == nil {
+		t.Fatalf("page %d: %v\n", pageNr, err)
+	}
+
+	m := map[float64]bool{}
+	gsDict := inhPAttrs.Resources.DictEntry("ExtGState")
+	for _, o := range gsDict {
+		gs, err := ctx.DereferenceDict(o)
+		if err != nil || gs == nil {
+			continue
+		}
+		if f, ok := gs.Find("CA"); ok {
+			if f, ok := f.(types.Float); ok {
+				m[f.Value()] = true
+			}
+		}
+	}
+
+	return m
+}
+
+func TestAddWatermarksMapMixed(t *testing.T) {
+	msg := "TestAddWatermarksMapMixed"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+	outFile := filepath.Join(outDir, "WatermarksMapMixed.pdf")
+
+	draft, err := api.TextWatermark("Draft", "rot:45, op:.3", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	confidential, err := api.TextWatermark("Confidential", "rot:-30, op:.6, fillc:#ff0000", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	logo, err := api.ImageWatermark(filepath.Join(resDir, "pdfchip3.png"), "scale:.25, pos:br, rot:0, op:.8", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	page, err := api.PDFWatermark(filepath.Join(inDir, "Wonderwall.pdf")+":1", "scale:.5, rot:10, op:.5", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// draft is shared by pages 1 and 2.
+	m := map[int]*model.Watermark{1: draft, 2: draft, 3: confidential, 4: logo, 5: page}
+
+	want := map[int]float64{1: .3, 2: .3, 3: .6, 4: .8, 5: .5}
+
+	if err := api.AddWatermarksMapFile(inFile, outFile, m, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	for pageNr, op := range want {
+		if !pageOpacities(t, ctx, pageNr)[op] {
+			t.Fatalf("%s: page %d: missing watermark opacity %.1f\n", msg, pageNr, op)
+		}
+	}
+
+	if ok, err := api.HasWatermarksFile(outFile, nil); err != nil || !ok {
+		t.Fatalf("%s: missing watermarks: %v\n", msg, err)
+	}
+}
+
+func TestAddWatermarksMapMixedOnTop(t *testing.T) {
+	msg := "TestAddWatermarksMapMixedOnTop"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+	outFile := filepath.Join(outDir, "WatermarksMapMixedOnTop.pdf")
+
+	wm, err := api.TextWatermark("Draft", "rot:45, op:.3", false, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	stamp, err := api.TextWatermark("Confidential", "rot:-30, op:.6", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m := map[int]*model.Watermark{1: wm, 2: stamp}
+	if err := api.AddWatermarksMapFile(inFile, outFile, m, nil); err == nil {
+		t.Fatalf("%s: mixing stamps and watermarks should fail\n", msg)
+	}
+
+	m1 := map[int][]*model.Watermark{1: {wm, stamp}}
+	if err := api.AddWatermarksSliceMapFile(inFile, outFile, m1, nil); err == nil {
+		t.Fatalf("%s: mixing stamps and watermarks should fail\n", msg)
+	}
+}
+
+func TestAddWatermarksMapNil(t *testing.T) {
+	msg := "TestAddWatermarksMapNil"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+	outFile := filepath.Join(outDir, "WatermarksMapNil.pdf")
+
+	wm, err := api.TextWatermark("Draft", "rot:45, op:.3", false, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m := map[int]*model.Watermark{1: wm, 2: nil}
+	if err := api.AddWatermarksMapFile(inFile, outFile, m, nil); err == nil {
+		t.Fatalf("%s: nil watermark should fail\n", msg)
+	}
+
+	m1 := map[int][]*model.Watermark{1: {wm}, 2: {wm, nil}}
+	if err := api.AddWatermarksSliceMapFile(inFile, outFile, m1, nil); err == nil {
+		t.Fatalf("%s: nil watermark should fail\n", msg)
+	}
+}
+
+func tilingPatternCount(t *testing.T, ctx *model.Context) int {
+	t.Helper()
+
+	c := 0
+	for _, e := range ctx.Table {
+		if e == nil || e.Free {
+			continue
+		}
+		sd, ok := e.Object.(types.StreamDict)
+		if !ok {
+			continue
+		}
+		if pt := sd.IntEntry("PatternType"); pt != nil && *pt == 1 {
+			c++
+		}
+	}
+
+	return c
+}
+
+func TestTiledWatermark(t *testing.T) {
+	msg := "TestTiledWatermark"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+
+	for _, tt := range []struct {
+		outFile  string
+		mode     int
+		modeParm string
+		desc     string
+	}{
+		{"TiledText.pdf", model.WMText, "DRAFT", "tile:.5, gap:30 60, scale:1 abs, points:36, rot:45, op:.3"},
+		{"TiledImage.pdf", model.WMImage, filepath.Join(resDir, "pdfchip3.png"), "tile:on, gap:20, scale:.1, rot:0, op:.5"},
+	} {
+		outFile := filepath.Join(outDir, tt.outFile)
+
+		var err error
+		if tt.mode == model.WMText {
+			err = api.AddTextWatermarksFile(inFile, outFile, nil, false, tt.modeParm, tt.desc, nil)
+		} else {
+			err = api.AddImageWatermarksFile(inFile, outFile, nil, false, tt.modeParm, tt.desc, nil)
+		}
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+
+		ctx, err := api.ReadContextFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+
+		// One pattern is shared by all pages of equal size.
+		if c := tilingPatternCount(t, ctx); c < 1 || c > ctx.PageCount {
+			t.Fatalf("%s %s: unexpected tiling pattern count: %d\n", msg, tt.outFile, c)
+		}
+
+		if ok, err := api.HasWatermarksFile(outFile, nil); err != nil || !ok {
+			t.Fatalf("%s %s: missing watermarks: %v\n", msg, tt.outFile, err)
+		}
+
+		if err := api.RemoveWatermarksFile(outFile, outFile, nil, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.outFile, err)
+		}
+	}
+
+	// Illegal stagger.
+	if _, err := api.TextWatermark("DRAFT", "tile:1.5", false, false, types.POINTS); err == nil {
+		t.Fatalf("%s: expected tile stagger error\n", msg)
+	}
+}
+
+func stampedPages(t *testing.T, ctx *model.Context) []int {
+	t.Helper()
+
+	var pp []int
+	for i := 1; i <= ctx.PageCount; i++ {
+		d, _, _, err := ctx.PageDict(i, false)
+		if err != nil {
+			t.Fatalf("page %d: %v\n", i, err)
+		}
+		bb, err := ctx.PageContent(d, i)
+		if err != nil {
+			t.Fatalf("page %d: %v\n", i, err)
+		}
+		if bytes.Contains(bb, []byte("/Subtype /Watermark")) {
+			pp = append(pp, i)
+		}
+	}
+
+	return pp
+}
+
+func stampFormContent(t *testing.T, ctx *model.Context, pageNr int) []byte {
+	t.Helper()
+
+	_, _, inhPAttrs, err := ctx.PageDict(pageNr, true)
+	if err != nil {
+		t.Fatalf("page %d: %v\n", pageNr, err)
+	}
+
+	for _, o := range inhPAttrs.Resources.DictEntry("XObject") {
+		sd, _, err := ctx.DereferenceStreamDict(o)
+		if err != nil || sd == nil {
+			continue
+		}
+		if _, ok := sd.Find("OC"); !ok {
+			continue
+		}
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("page %d: %v\n", pageNr, err)
+		}
+		return sd.Content
+	}
+
+	return nil
+}
+
+func TestPDFMultiStampRange(t *testing.T) {
+	msg := "TestPDFMultiStampRange"
+	inFile := filepath.Join(inDir, "grid_example.pdf")
+	stampFile := filepath.Join(inDir, "Wonderwall.pdf")
+
+	bb, err := os.ReadFile(stampFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, tt := range []struct {
+		fallback int
+		want     []int
+	}{
+		{0, []int{2, 3, 4}},                // repeat source page 3
+		{6, []int{2, 3, 4}},                // use source page 6
+		{model.PdfNoFallback, []int{2, 3}}, // leave page 4 alone
+	} {
+		// Stamp source pages 2-3 onto destination pages 2-3.
+		wm, err := api.PDFMultiWatermarkRangeForReadSeeker(bytes.NewReader(bb), 2, 3, 2, tt.fallback, "scale:.5, rot:15, op:.7", true, false, types.POINTS)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		ctx, err := api.ReadContextFile(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		if err := pdfcpu.AddWatermarks(ctx, nil, wm); err != nil {
+			t.Fatalf("%s fallback %d: %v\n", msg, tt.fallback, err)
+		}
+
+		if got := stampedPages(t, ctx); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Fatalf("%s fallback %d: stamped pages %v, want %v\n", msg, tt.fallback, got, tt.want)
+		}
+
+		if tt.fallback != model.PdfNoFallback {
+			same := bytes.Equal(stampFormContent(t, ctx, 3), stampFormContent(t, ctx, 4))
+			if same != (tt.fallback == 0) {
+				t.Fatalf("%s fallback %d: unexpected stamp on page 4\n", msg, tt.fallback)
+			}
+		}
+
+		outFile := filepath.Join(outDir, fmt.Sprintf("multiStampRange%d.pdf", tt.fallback))
+		if err := api.WriteContextFile(ctx, outFile); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+
+	// Invalid source range.
+	wm, err := api.PDFMultiWatermarkRangeForReadSeeker(bytes.NewReader(bb), 2, 7, 1, 0, "", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := pdfcpu.AddWatermarks(ctx, nil, wm); err == nil {
+		t.Fatalf("%s: expected invalid source range error\n", msg)
+	}
+}
+
+func TestPageForm(t *testing.T) {
+	msg := "TestPageForm"
+
+	ctxSrc, err := api.ReadContextFile(filepath.Join(inDir, "gobook.0.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctxSrc); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Rotate the source page in order to get a landscape form.
+	if err := pdfcpu.RotatePages(ctxSrc, types.IntSet{1: true}, 90); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	_, _, inhPAttrs, err := ctxSrc.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	r := inhPAttrs.MediaBox
+	if inhPAttrs.CropBox != nil {
+		r = inhPAttrs.CropBox
+	}
+
+	pf, err := pdfcpu.CreateFormXObjectFromPage(ctxSrc, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if pf.Width != r.Height() || pf.Height != r.Width() {
+		t.Fatalf("%s: want %.2f x %.2f, got %.2f x %.2f\n", msg, r.Height(), r.Width(), pf.Width, pf.Height)
+	}
+
+	ctxDest, err := api.ReadContextFile(filepath.Join(inDir, "gobook.0.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctxDest); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Place the form twice on page 1 and once on the last page.
+	for _, m := range []matrix.Matrix{
+		matrix.CalcTransformMatrix(.25, .25, 0, 1, 10, 10),
+		matrix.CalcTransformMatrix(.25, .25, 1, 0, 300, 10),
+	} {
+		if err := pf.Place(ctxDest, 1, m); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+	if err := pf.Place(ctxDest, ctxDest.PageCount, matrix.IdentMatrix); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ir, err := pf.IndRef(ctxDest)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	outFile := filepath.Join(outDir, "pageForm.pdf")
+	if err := api.WriteContextFile(ctxDest, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for pageNr, want := range map[int]int{1: 2, ctx.PageCount: 1} {
+		_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		d := inhPAttrs.Resources.DictEntry("XObject")
+		got := 0
+		for _, o := range d {
+			if ir1, ok := o.(types.IndirectRef); ok && ir1.ObjectNumber == ir.ObjectNumber {
+				got++
+			}
+		}
+		if got != want {
+			t.Fatalf("%s: page %d: want %d placements, got %d\n", msg, pageNr, want, got)
+		}
+	}
+}
+
+func extGStateCount(ctx *model.Context) int {
+	n := 0
+	for _, e := range ctx.Table {
+		if e == nil || e.Free {
+			continue
+		}
+		if d, ok := e.Object.(types.Dict); ok && d.Type() != nil && *d.Type() == "ExtGState" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestExtGStateSharing(t *testing.T) {
+	msg := "TestExtGStateSharing"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "gobook.0.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	n := extGStateCount(ctx)
+
+	// Repeated stamps of equal opacity share a single ExtGState.
+	for i := 0; i < 5; i++ {
+		wm, err := api.TextWatermark("Draft", "op:.5, rot:45", true, false, types.POINTS)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := pdfcpu.AddWatermarks(ctx, nil, wm); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+	if got := extGStateCount(ctx) - n; got != 1 {
+		t.Fatalf("%s: want 1 new ExtGState, got %d\n", msg, got)
+	}
+
+	fill, stroke := .5, .5
+	gs := model.ExtGState{FillOpacity: &fill, StrokeOpacity: &stroke}
+
+	ir1, err := ctx.EnsureExtGStateFor(gs)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ir2, err := ctx.EnsureExtGStateFor(model.Opacity(.5, .5))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if *ir1 != *ir2 {
+		t.Fatalf("%s: equal graphics states not shared: %s %s\n", msg, ir1, ir2)
+	}
+
+	gs.BlendMode, gs.Dash, gs.DashPhase = "Multiply", []float64{3, 2}, 1
+	ir3, err := ctx.EnsureExtGStateFor(gs)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if *ir3 == *ir1 {
+		t.Fatalf("%s: distinct graphics states shared: %s\n", msg, ir3)
+	}
+
+	// A shared dict modified in the meantime does not get reused.
+	d, err := ctx.DereferenceDict(*ir3)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d["LW"] = types.Float(2)
+	ir4, err := ctx.EnsureExtGStateFor(gs)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if *ir4 == *ir3 {
+		t.Fatalf("%s: modified graphics state reused: %s\n", msg, ir4)
+	}
+
+	// Equal dicts of a freshly read file get reused.
+	outFile := filepath.Join(outDir, "extGStateSharing.pdf")
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if ctx, err = api.ReadContextFile(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	n = extGStateCount(ctx)
+	if _, err := ctx.EnsureExtGStateFor(model.Opacity(.5, .5)); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if got := extGStateCount(ctx); got != n {
+		t.Fatalf("%s: want %d ExtGStates, got %d\n", msg, n, got)
+	}
+}
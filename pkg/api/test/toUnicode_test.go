@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestRepairToUnicode(t *testing.T) {
+	msg := "TestRepairToUnicode"
+	inFile := filepath.Join(outDir, "toUnicodeBroken.pdf")
+	outFile := filepath.Join(outDir, "toUnicodeRepaired.pdf")
+
+	json := `{
+	"pages": {
+		"1": {
+			"content": {
+				"text": [
+					{"value": "Grüße Ωmega", "pos": [50, 700], "font": {"name": "Roboto-Regular", "size": 12}},
+					{"value": "Hello", "pos": [50, 650], "font": {"name": "Helvetica", "size": 12}},
+					{"value": "Hello", "pos": [50, 600], "font": {"name": "Courier", "size": 12}}
+				]
+			}
+		}
+	}
+}`
+	var buf bytes.Buffer
+	if err := api.Create(nil, strings.NewReader(json), &buf, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContext(bytes.NewReader(buf.Bytes()), conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Break text extraction for all fonts:
+	// Roboto-Regular loses its ToUnicode CMap, Helvetica gets a bogus one,
+	// Courier gets glyph name differences without a ToUnicode CMap.
+	bogus := pdfcpu.ToUnicodeMap{CodeLen: 1, M: map[uint32]string{}}
+	for c := uint32(32); c < 128; c++ {
+		bogus.M[c] = string(rune(0xE000 + c))
+	}
+	indRef, err := ctx.StreamDictIndRef(bogus.Bytes())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, entry := range ctx.Table {
+		d, ok := entry.Object.(types.Dict)
+		if !ok || d.Type() == nil || *d.Type() != "Font" || d.NameEntry("BaseFont") == nil {
+			continue
+		}
+		switch bf := *d.NameEntry("BaseFont"); {
+		case strings.HasSuffix(bf, "Roboto-Regular") && *d.Subtype() == "Type0":
+			d.Delete("ToUnicode")
+		case bf == "Helvetica":
+			d["ToUnicode"] = *indRef
+		case bf == "Courier":
+			d["Encoding"] = types.Dict{
+				"Type":         types.Name("Encoding"),
+				"BaseEncoding": types.Name("WinAnsiEncoding"),
+				"Differences":  types.Array{types.Integer(72), types.Name("H.sc"), types.Integer(101), types.Name("e_e"), types.Integer(108), types.Name("lslash")},
+			}
+		}
+	}
+	if err := api.WriteContextFile(ctx, inFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	rep, err := api.AuditToUnicode(f, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(rep.Issues) != 3 || rep.Repaired() != 3 {
+		t.Fatalf("%s: want 3 repairable fonts, got %+v\n", msg, rep)
+	}
+
+	if rep, err = api.RepairToUnicodeFile(inFile, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	want := map[string]string{"Roboto-Regular": "missing font program", "Helvetica": "bogus encoding", "Courier": "missing glyph names"}
+	for _, ti := range rep.Issues {
+		bf := ti.BaseFont
+		if i := strings.IndexByte(bf, '+'); i >= 0 {
+			bf = bf[i+1:]
+		}
+		if got := ti.Problem + " " + ti.Source; got != want[bf] {
+			t.Fatalf("%s: %s: want %q, got %q\n", msg, bf, want[bf], got)
+		}
+	}
+
+	ctx, err = api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	s, err := pdfcpu.ExtractPageText(ctx, 1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, want := range []string{"Grüße Ωmega", "Hello", "Heełło"} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("%s: missing %+q in extracted text: %+q\n", msg, want, s)
+		}
+	}
+
+	// Nothing left to repair.
+	f.Close()
+	if f, err = os.Open(outFile); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if rep, err = api.AuditToUnicode(f, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(rep.Issues) > 0 {
+		t.Fatalf("%s: unexpected issues: %+v\n", msg, rep.Issues)
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestTransactionIncrement(t *testing.T) {
+	msg := "TestTransactionIncrement"
+	outFile := filepath.Join(outDir, "tx.pdf")
+	if err := copyFile(t, filepath.Join(inDir, "go.pdf"), outFile); err != nil {
+		t.Fatalf("%s copyFile: %v\n", msg, err)
+	}
+
+	f, err := os.OpenFile(outFile, os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	ctx, err := api.ReadAndValidate(f, nil)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+	if ctx.Info == nil {
+		t.Fatalf("%s: missing info dict\n", msg)
+	}
+	infoObjNr := ctx.Info.ObjectNumber.Value()
+
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// A rolled back transaction leaves the context untouched.
+	tx := ctx.Begin()
+	if err := tx.ReplaceObject(infoObjNr, types.Dict{"Title": types.StringLiteral("Rollback")}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	tx.Rollback()
+	if d1, _ := ctx.DereferenceDict(*ctx.Info); d1.PDFString() != d.PDFString() {
+		t.Fatalf("%s: rollback failed\n", msg)
+	}
+	if err := tx.Commit(); err == nil {
+		t.Fatalf("%s: commit after rollback should fail\n", msg)
+	}
+
+	// Referencing an object deleted within the same transaction fails on commit.
+	tx = ctx.Begin()
+	ir, err := ctx.IndRefForNewObject(types.StringLiteral("tmp"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := tx.DeleteObject(ir.ObjectNumber.Value()); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	d1 := d.Clone().(types.Dict)
+	d1["Tmp"] = *ir
+	if err := tx.ReplaceObject(infoObjNr, d1); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := tx.Commit(); err == nil {
+		t.Fatalf("%s: commit should fail because of dangling reference\n", msg)
+	}
+
+	tx = ctx.Begin()
+	d1 = d.Clone().(types.Dict)
+	d1["Title"] = types.StringLiteral("Transaction")
+	if err := tx.ReplaceObject(infoObjNr, d1); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if objNrs := tx.ObjNrs(); len(objNrs) != 1 || objNrs[0] != infoObjNr {
+		t.Fatalf("%s: unexpected touched objects: %v\n", msg, objNrs)
+	}
+
+	ctx.Write.Increment = true
+	ctx.Write.Offset = ctx.Read.FileSize
+	if err := api.WriteIncr(ctx, f, ctx.Configuration); err != nil {
+		t.Fatalf("%s write increment: %v\n", msg, err)
+	}
+	f.Close()
+
+	ctx, err = api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s reread: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+	d, err = ctx.DereferenceDict(*ctx.Info)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if title := d.StringEntry("Title"); title == nil || *title != "Transaction" {
+		t.Fatalf("%s: want Title Transaction, got %v\n", msg, title)
+	}
+}
+
+func TestTransactionDeleteIncrement(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		mode model.XRefMode
+	}{
+		{"table", model.XRefModeTable},
+		{"stream", model.XRefModeStream},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := "TestTransactionDeleteIncrement " + tt.name
+			outFile := filepath.Join(outDir, "txDelete"+tt.name+".pdf")
+			if err := copyFile(t, filepath.Join(inDir, "go.pdf"), outFile); err != nil {
+				t.Fatalf("%s copyFile: %v\n", msg, err)
+			}
+
+			f, err := os.OpenFile(outFile, os.O_RDWR, 0644)
+			if err != nil {
+				t.Fatalf("%s open: %v\n", msg, err)
+			}
+			defer f.Close()
+
+			ctx, err := api.ReadAndValidate(f, nil)
+			if err != nil {
+				t.Fatalf("%s read: %v\n", msg, err)
+			}
+
+			ir := ctx.RootDict.IndirectRefEntry("StructTreeRoot")
+			if ir == nil {
+				t.Fatalf("%s: missing StructTreeRoot\n", msg)
+			}
+			objNr := ir.ObjectNumber.Value()
+			e, _ := ctx.FindTableEntryLight(objNr)
+			gen := *e.Generation
+
+			// Drop the structure tree root and its only reference.
+			tx := ctx.Begin()
+			if err := tx.DeleteObject(objNr); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			rootDict := ctx.RootDict.Clone().(types.Dict)
+			rootDict.Delete("StructTreeRoot")
+			rootDict.Delete("MarkInfo")
+			if err := tx.ReplaceObject(ctx.Root.ObjectNumber.Value(), rootDict); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if err := api.ValidateTouched(ctx, tx); err != nil {
+				t.Fatalf("%s validate touched: %v\n", msg, err)
+			}
+			if err := tx.Commit(); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+
+			// A reference to the deleted object is caught by validating the touched objects only.
+			tx = ctx.Begin()
+			d, err := ctx.DereferenceDict(*ctx.Info)
+			if err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			d1 := d.Clone().(types.Dict)
+			d1["Tmp"] = *ir
+			if err := tx.ReplaceObject(ctx.Info.ObjectNumber.Value(), d1); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			ctx.XRefTable.ValidationMode = model.ValidationStrict
+			if err := api.ValidateTouched(ctx, tx); err == nil {
+				t.Fatalf("%s: validate touched should fail because of reference to deleted obj#%d\n", msg, objNr)
+			}
+			tx.Rollback()
+
+			ctx.Write.Increment = true
+			ctx.Write.Offset = ctx.Read.FileSize
+			ctx.Write.XRefMode = tt.mode
+			if err := api.WriteIncr(ctx, f, ctx.Configuration); err != nil {
+				t.Fatalf("%s write increment: %v\n", msg, err)
+			}
+			f.Close()
+
+			ctx, err = api.ReadContextFile(outFile)
+			if err != nil {
+				t.Fatalf("%s reread: %v\n", msg, err)
+			}
+			if err := api.ValidateContext(ctx); err != nil {
+				t.Fatalf("%s validate: %v\n", msg, err)
+			}
+
+			e, found := ctx.FindTableEntryLight(objNr)
+			if !found || !e.Free {
+				t.Fatalf("%s: obj#%d should be free\n", msg, objNr)
+			}
+			if *e.Generation != gen+1 {
+				t.Fatalf("%s: obj#%d: want generation %d, got %d\n", msg, objNr, gen+1, *e.Generation)
+			}
+			if _, found := ctx.RootDict.Find("StructTreeRoot"); found {
+				t.Fatalf("%s: StructTreeRoot should be gone\n", msg)
+			}
+		})
+	}
+}
+
+func TestValidateTouchedPage(t *testing.T) {
+	msg := "TestValidateTouchedPage"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "test.pdf"))
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	d, ir, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	objNr := ir.ObjectNumber.Value()
+	want := d.PDFString()
+
+	for _, tt := range []struct {
+		name   string
+		modify func(d types.Dict)
+	}{
+		{"invalid MediaBox", func(d types.Dict) { d["MediaBox"] = types.Array{types.Integer(0), types.Integer(0)} }},
+		{"missing Parent", func(d types.Dict) { d.Delete("Parent") }},
+	} {
+		d1 := d.Clone().(types.Dict)
+		tt.modify(d1)
+
+		tx := ctx.Begin()
+		if err := tx.ReplaceObject(objNr, d1); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+		if err := api.ValidateTouched(ctx, tx); err == nil {
+			t.Fatalf("%s %s: validate touched should fail\n", msg, tt.name)
+		}
+		if ff := ctx.Findings; len(ff) != 1 || ff[0].ID != model.FindingPages || !ff[0].Fatal {
+			t.Fatalf("%s %s: want fatal %s finding, got %v\n", msg, tt.name, model.FindingPages, ff)
+		}
+
+		// The invalid replacement has been rolled back.
+		if err := tx.Commit(); err != model.ErrTransactionClosed {
+			t.Fatalf("%s %s: want %v, got %v\n", msg, tt.name, model.ErrTransactionClosed, err)
+		}
+		d2, err := ctx.DereferenceDict(*ir)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+		if d2.PDFString() != want {
+			t.Fatalf("%s %s: rollback failed\n", msg, tt.name)
+		}
+		ctx.Findings = nil
+	}
+
+	// A valid replacement passes.
+	d1 := d.Clone().(types.Dict)
+	d1["Rotate"] = types.Integer(90)
+	tx := ctx.Begin()
+	if err := tx.ReplaceObject(objNr, d1); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateTouched(ctx, tx); err != nil {
+		t.Fatalf("%s validate touched: %v\n", msg, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func writeAndReadContext(t *testing.T, msg string, ctx *model.Context) *model.Context {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := api.WriteContext(ctx, &buf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadValidateAndOptimize(bytes.NewReader(buf.Bytes()), model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	return ctx
+}
+
+func TestNameTree(t *testing.T) {
+	msg := "TestNameTree"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "gobook.0.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ir, err := ctx.PageDictIndRef(1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	dests := ctx.NameTree(model.NameTreeDests)
+	for i := 100; i < 200; i++ {
+		if err := dests.Insert(fmt.Sprintf("dest%d", i), types.Array{*ir, types.Name("Fit")}); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+	if ok, err := dests.Delete("dest150"); err != nil || !ok {
+		t.Fatalf("%s: delete: %t %v\n", msg, ok, err)
+	}
+	if err := dests.Rebalance(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx = writeAndReadContext(t, msg, ctx)
+
+	// Drop the cache populated by validation.
+	delete(ctx.Names, model.NameTreeDests)
+	dests = ctx.NameTree(model.NameTreeDests)
+
+	var keys []string
+	if err := dests.Each(func(k string, v types.Object) error {
+		keys = append(keys, k)
+		return nil
+	}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(keys) != 99 || keys[0] != "dest100" || keys[98] != "dest199" {
+		t.Fatalf("%s: unexpected keys: %v\n", msg, keys)
+	}
+
+	if _, ok, _ := dests.Lookup("dest150"); ok {
+		t.Fatalf("%s: dest150 should be gone\n", msg)
+	}
+	if _, err := ctx.DereferenceDestArray("dest151"); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
+func TestNumberTree(t *testing.T) {
+	msg := "TestNumberTree"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "gobook.0.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateContext(ctx); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	labels, err := ctx.PageLabels(true)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Label every page in reverse order.
+	for i := ctx.PageCount - 1; i >= 0; i-- {
+		d := types.Dict{"S": types.Name("D"), "St": types.Integer(i + 1)}
+		if err := labels.Insert(i, d); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+	}
+	if err := labels.Rebalance(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, k := range []int{1, 2, ctx.PageCount - 1} {
+		if ok, err := labels.Delete(k); err != nil || !ok {
+			t.Fatalf("%s: delete %d: %t %v\n", msg, k, ok, err)
+		}
+	}
+	if ok, _ := labels.Delete(1); ok {
+		t.Fatalf("%s: page label 1 deleted twice\n", msg)
+	}
+	if err := labels.Insert(1, types.Dict{"S": types.Name("r")}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pageCount := ctx.PageCount
+	ctx = writeAndReadContext(t, msg, ctx)
+
+	if labels, err = ctx.PageLabels(false); err != nil || labels == nil {
+		t.Fatalf("%s: missing page labels: %v\n", msg, err)
+	}
+
+	var keys []int
+	if err := labels.Each(func(k int, v types.Object) error {
+		keys = append(keys, k)
+		return nil
+	}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(keys) != pageCount-2 {
+		t.Fatalf("%s: want %d page labels, got %d\n", msg, pageCount-2, len(keys))
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i] <= keys[i-1] {
+			t.Fatalf("%s: unsorted keys: %v\n", msg, keys)
+		}
+	}
+
+	o, ok, err := labels.Lookup(1)
+	if err != nil || !ok {
+		t.Fatalf("%s: missing page label 1: %v\n", msg, err)
+	}
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d.NameEntry("S") == nil || *d.NameEntry("S") != "r" {
+		t.Fatalf("%s: unexpected page label 1: %v %v\n", msg, o, err)
+	}
+	if _, ok, _ := labels.Lookup(2); ok {
+		t.Fatalf("%s: page label 2 should be gone\n", msg)
+	}
+}
+
+func TestNumberTreeCircular(t *testing.T) {
+	msg := "TestNumberTreeCircular"
+
+	ctx, err := api.ReadContextFile(filepath.Join(inDir, "gobook.0.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	labels, err := ctx.PageLabels(true)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// A page labels node listing itself as kid.
+	kid := types.Dict{"Limits": types.Array{types.Integer(0), types.Integer(0)}}
+	ir, err := ctx.IndRefForNewObject(kid)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	kid["Kids"] = types.Array{*ir}
+	labels.D.Delete("Nums")
+	labels.D["Kids"] = types.Array{*ir}
+
+	if err := labels.Each(func(int, types.Object) error { return nil }); err == nil {
+		t.Fatalf("%s: circular number tree should fail\n", msg)
+	}
+	if err := api.ValidateContext(ctx); err == nil {
+		t.Fatalf("%s: validation of circular number tree should fail\n", msg)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestSetVersion(t *testing.T) {
+	msg := "TestSetVersion"
+
+	// testImage.pdf uses ICC based color spaces and rendering intents available since PDF 1.2.
+	inFile := filepath.Join(inDir, "testImage.pdf")
+	outFile := filepath.Join(outDir, "version.pdf")
+
+	vi, err := api.PDFVersionFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if vi.Effective != model.V17 || vi.Required != model.V12 {
+		t.Fatalf("%s: unexpected versions %+v\n", msg, vi)
+	}
+
+	// An enforced downgrade fails if features are unsupported.
+	os.Remove(outFile)
+	ff, err := api.SetPDFVersionFile(inFile, outFile, model.V11, true, nil)
+	if err == nil || len(ff) == 0 {
+		t.Fatalf("%s: want error and findings, got %v %v\n", msg, ff, err)
+	}
+	if _, err := os.Stat(outFile); err == nil {
+		t.Fatalf("%s: want no output\n", msg)
+	}
+
+	// Unless not enforced.
+	if ff, err = api.SetPDFVersionFile(inFile, outFile, model.V11, false, nil); err != nil || len(ff) == 0 {
+		t.Fatalf("%s: want findings, got %v %v\n", msg, ff, err)
+	}
+	bb, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !bytes.HasPrefix(bb, []byte("%PDF-1.1")) {
+		t.Fatalf("%s: want header version 1.1, got %q\n", msg, bb[:8])
+	}
+
+	for _, v := range []model.Version{model.V12, model.V16} {
+		if ff, err = api.SetPDFVersionFile(inFile, outFile, v, true, nil); err != nil || len(ff) > 0 {
+			t.Fatalf("%s %s: %v %v\n", msg, v, ff, err)
+		}
+		if vi, err = api.PDFVersionFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, v, err)
+		}
+		if vi.Header != v || vi.Catalog != nil || vi.Effective != v || vi.Required != model.V12 {
+			t.Fatalf("%s %s: unexpected versions %+v\n", msg, v, vi)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestVisualDiff(t *testing.T) {
+	msg := "TestVisualDiff"
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile := filepath.Join(outDir, "goStamped.pdf")
+
+	// Identical documents.
+	pdd, err := api.VisualDiffFile(inFile, inFile, "", 36, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pdd) < 2 {
+		t.Fatalf("%s: want at least 2 pages, got %d\n", msg, len(pdd))
+	}
+	for _, pd := range pdd {
+		if pd.Score != 0 || pd.HashDistance != 0 || pd.Highlight != nil {
+			t.Fatalf("%s: page %d: unexpected difference: %f\n", msg, pd.PageNr, pd.Score)
+		}
+	}
+
+	// Stamp page 2 only.
+	if err := api.AddTextWatermarksFile(inFile, outFile, []string{"2"}, true, "Draft", "scale:1, rot:45, fillc:#000000", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	diffDir := filepath.Join(outDir, "diff")
+	if err := os.MkdirAll(diffDir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pdd, err = api.VisualDiffFile(inFile, outFile, diffDir, 36, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, pd := range pdd {
+		if changed := pd.Score > 0; changed != (pd.PageNr == 2) {
+			t.Fatalf("%s: page %d: unexpected score: %f\n", msg, pd.PageNr, pd.Score)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(diffDir, "go_diff_2.png")); err != nil {
+		t.Fatalf("%s: missing highlight image: %v\n", msg, err)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AuditToUnicode reports the fonts of rs whose text extracts as garbage due to missing or bogus ToUnicode CMaps.
+func AuditToUnicode(rs io.ReadSeeker, conf *model.Configuration) (*pdfcpu.ToUnicodeReport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: AuditToUnicode: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REPAIRTOUNICODE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.AuditToUnicode(ctx)
+}
+
+// RepairToUnicode regenerates missing or bogus ToUnicode CMaps of the fonts of rs where possible
+// and writes the result to w.
+func RepairToUnicode(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) (*pdfcpu.ToUnicodeReport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: RepairToUnicode: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REPAIRTOUNICODE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	rep, err := pdfcpu.RepairToUnicode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if log.CLIEnabled() {
+		for _, ti := range rep.Issues {
+			if ti.Repairable() {
+				log.CLI.Printf("obj#%d %s: %s ToUnicode regenerated from %s\n", ti.ObjNr, ti.BaseFont, ti.Problem, ti.Source)
+				continue
+			}
+			log.CLI.Printf("obj#%d %s: %s ToUnicode not repairable\n", ti.ObjNr, ti.BaseFont, ti.Problem)
+		}
+	}
+
+	if err := Write(ctx, w, conf); err != nil {
+		return nil, err
+	}
+
+	return rep, nil
+}
+
+// RepairToUnicodeFile regenerates missing or bogus ToUnicode CMaps of the fonts of inFile where possible
+// and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten.
+func RepairToUnicodeFile(inFile, outFile string, conf *model.Configuration) (rep *pdfcpu.ToUnicodeReport, err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			rep = nil
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RepairToUnicode(f1, f2, conf)
+}
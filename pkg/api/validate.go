@@ -25,6 +25,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/validate"
 	"github.com/pkg/errors"
 )
 
@@ -128,6 +129,68 @@ func ValidateFiles(inFiles []string, conf *model.Configuration) error {
 	return nil
 }
 
+// QuickCheck verifies the basic well-formedness of a PDF stream read from rs without reading all objects.
+func QuickCheck(rs io.ReadSeeker, conf *model.Configuration) (*pdfcpu.QuickCheckResult, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: QuickCheck: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VALIDATE
+
+	return pdfcpu.QuickCheck(rs, conf)
+}
+
+// QuickCheckFile verifies the basic well-formedness of inFile without reading all objects.
+func QuickCheckFile(inFile string, conf *model.Configuration) (*pdfcpu.QuickCheckResult, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return QuickCheck(f, conf)
+}
+
+// ValidateFindings validates a PDF stream read from rs and returns all validation findings.
+// The returned error is non nil if validation failed.
+func ValidateFindings(rs io.ReadSeeker, conf *model.Configuration) ([]model.Finding, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ValidateFindings: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VALIDATE
+
+	ctx, err := ReadContext(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ValidateContext(ctx); err != nil {
+		err = errors.Wrap(err, fmt.Sprintf("validation error (obj#:%d)", ctx.CurObj))
+	}
+
+	return ctx.Findings, err
+}
+
+// ValidateFindingsFile validates inFile and returns all validation findings.
+func ValidateFindingsFile(inFile string, conf *model.Configuration) ([]model.Finding, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ValidateFindings(f, conf)
+}
+
 // DumpObject writes an object from rs to stdout.
 func DumpObject(rs io.ReadSeeker, mode, objNr int, conf *model.Configuration) error {
 	if rs == nil {
@@ -172,3 +235,74 @@ func DumpObjectFile(inFile string, mode, objNr int, conf *model.Configuration) e
 
 	return DumpObject(f, mode, objNr, conf)
 }
+
+// Audit returns a reachability report for the object graph of rs.
+func Audit(rs io.ReadSeeker, conf *model.Configuration) (*pdfcpu.AuditReport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Audit: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.AUDIT
+
+	ctx, err := ReadContext(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.Audit(ctx)
+}
+
+// AuditFile returns a reachability report for the object graph of inFile.
+func AuditFile(inFile string, conf *model.Configuration) (*pdfcpu.AuditReport, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Audit(f, conf)
+}
+
+// Preflight validates rs and checks it against the rules of profile.
+func Preflight(rs io.ReadSeeker, profile *validate.PreflightProfile, conf *model.Configuration) ([]validate.PreflightViolation, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Preflight: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.PREFLIGHT
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return validate.Preflight(ctx, profile)
+}
+
+// PreflightFile checks inFile against the rules of the JSON encoded preflight profile profileFile.
+func PreflightFile(inFile, profileFile string, conf *model.Configuration) ([]validate.PreflightViolation, error) {
+	f1, err := os.Open(profileFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f1.Close()
+
+	profile, err := validate.ParsePreflightProfile(f1)
+	if err != nil {
+		return nil, err
+	}
+
+	f2, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f2.Close()
+
+	return Preflight(f2, profile, conf)
+}
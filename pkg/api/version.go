@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/validate"
+	"github.com/pkg/errors"
+)
+
+// PDFVersion returns the declared, the effective and the required PDF version of rs.
+func PDFVersion(rs io.ReadSeeker, conf *model.Configuration) (*validate.VersionInfo, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PDFVersion: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VALIDATE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return validate.Versions(ctx)
+}
+
+// PDFVersionFile returns the declared, the effective and the required PDF version of inFile.
+func PDFVersionFile(inFile string, conf *model.Configuration) (*validate.VersionInfo, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return PDFVersion(f, conf)
+}
+
+// SetPDFVersion declares v as the PDF version of rs and writes the result to w.
+// For a downgrade SetPDFVersion returns the features of rs unsupported by v.
+// If enforce is true, a downgrade resulting in unsupported features fails.
+func SetPDFVersion(rs io.ReadSeeker, w io.Writer, v model.Version, enforce bool, conf *model.Configuration) ([]model.Finding, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: SetPDFVersion: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETVERSION
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var ff []model.Finding
+
+	if v < ctx.XRefTable.Version() {
+		if ff, err = validate.VersionAudit(ctx, v); err != nil {
+			return nil, err
+		}
+		if enforce && len(ff) > 0 {
+			return ff, errors.Errorf("pdfcpu: downgrade to %s: %d unsupported features", v, len(ff))
+		}
+	}
+
+	if err := pdfcpu.SetVersion(ctx, v); err != nil {
+		return nil, err
+	}
+
+	if log.CLIEnabled() {
+		for _, f := range ff {
+			log.CLI.Println(f)
+		}
+	}
+
+	if err := Write(ctx, w, conf); err != nil {
+		return nil, err
+	}
+
+	return ff, nil
+}
+
+// SetPDFVersionFile declares v as the PDF version of inFile and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten.
+func SetPDFVersionFile(inFile, outFile string, v model.Version, enforce bool, conf *model.Configuration) (ff []model.Finding, err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetPDFVersion(f1, f2, v, enforce, conf)
+}
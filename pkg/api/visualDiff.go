@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// VisualDiff renders the pages of rs1 and rs2 at dpi and returns per page difference scores.
+func VisualDiff(rs1, rs2 io.ReadSeeker, dpi float64, conf *model.Configuration) ([]pdfcpu.PageDiff, error) {
+	if rs1 == nil || rs2 == nil {
+		return nil, errors.New("pdfcpu: VisualDiff: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VISUALDIFF
+
+	ctx1, err := ReadValidateAndOptimize(rs1, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx2, err := ReadValidateAndOptimize(rs2, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.VisualDiff(ctx1, ctx2, dpi)
+}
+
+func writeDiffHighlight(outFile string, pd pdfcpu.PageDiff) error {
+	logWritingTo(outFile)
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, pd.Highlight); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// VisualDiffFile compares inFile1 and inFile2 page by page
+// and writes a difference highlight image for each differing page into outDir.
+func VisualDiffFile(inFile1, inFile2, outDir string, dpi float64, conf *model.Configuration) ([]pdfcpu.PageDiff, error) {
+	f1, err := os.Open(inFile1)
+	if err != nil {
+		return nil, err
+	}
+	defer f1.Close()
+
+	f2, err := os.Open(inFile2)
+	if err != nil {
+		return nil, err
+	}
+	defer f2.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("comparing %s with %s ...\n", inFile1, inFile2)
+	}
+
+	pdd, err := VisualDiff(f1, f2, dpi, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if outDir == "" {
+		return pdd, nil
+	}
+
+	fileName := strings.TrimSuffix(filepath.Base(inFile1), ".pdf")
+
+	for _, pd := range pdd {
+		if pd.Highlight == nil {
+			continue
+		}
+		outFile := filepath.Join(outDir, fmt.Sprintf("%s_diff_%d.png", fileName, pd.PageNr))
+		if err := writeDiffHighlight(outFile, pd); err != nil {
+			return nil, err
+		}
+	}
+
+	return pdd, nil
+}
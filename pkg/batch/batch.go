@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package batch processes many PDF files concurrently.
+//
+// Each job reads a file, applies a sequence of operations and writes the result:
+//
+//	results := batch.Process([]batch.JobSpec{
+//		{InFile: "in1.pdf", OutFile: "out1.pdf", Ops: pdfcpu.Pipeline(pdfcpu.Rotate{Rotation: 90})},
+//		{InFile: "in2.pdf", OutFile: "out2.pdf", Ops: pdfcpu.Pipeline(pdfcpu.AddKeywords{Keywords: []string{"invoice"}})},
+//	}, 4)
+package batch
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// JobSpec describes a job processing a single file.
+type JobSpec struct {
+	InFile  string
+	OutFile string            // defaults to InFile
+	Ops     pdfcpu.Operations // applied in order, none for optimizing only
+	Conf    *model.Configuration
+}
+
+// Result is the outcome of a job.
+type Result struct {
+	Job JobSpec
+	Err error
+}
+
+func run(job JobSpec) error {
+	if job.InFile == "" {
+		return errors.New("pdfcpu: batch: missing input file")
+	}
+
+	// Jobs must not share configurations since processing updates them.
+	var conf *model.Configuration
+	if job.Conf != nil {
+		c := *job.Conf
+		conf = &c
+	} else {
+		conf = model.NewDefaultConfiguration()
+	}
+
+	return api.ApplyOperationsFile(job.InFile, job.OutFile, job.Ops, conf)
+}
+
+// Process runs jobs using up to workers concurrent workers, 0 for one worker per CPU.
+// It returns the results in job order.
+func Process(jobs []JobSpec, workers int) []Result {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	results := make([]Result, len(jobs))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = Result{Job: jobs[i], Err: run(jobs[i])}
+			}
+		}()
+	}
+
+	for i := range jobs {
+		indices <- i
+	}
+	close(indices)
+
+	wg.Wait()
+
+	return results
+}
@@ -79,7 +79,7 @@ func (f ascii85Decode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error
 			return nil, err
 		}
 	} else {
-		if _, err := io.CopyN(&b2, decoder, maxLen); err != nil {
+		if _, err := io.CopyN(&b2, decoder, maxLen); err != nil && err != io.EOF {
 			return nil, err
 		}
 	}
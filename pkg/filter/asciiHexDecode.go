@@ -73,8 +73,8 @@ func (f asciiHexDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, erro
 		p = append(p, '0')
 	}
 
-	if maxLen < 0 {
-		maxLen = int64(hex.DecodedLen(len(p)))
+	if l := int64(hex.DecodedLen(len(p))); maxLen < 0 || maxLen > l {
+		maxLen = l
 	}
 	dst := make([]byte, maxLen)
 
@@ -106,7 +106,10 @@ func passThru(rin io.Reader, maxLen int64) (*bytes.Buffer, error) {
 	if maxLen < 0 {
 		_, err = io.Copy(&b, rin)
 	} else {
-		_, err = io.CopyN(&b, rin, maxLen)
+		// Streams shorter than maxLen are fine.
+		if _, err = io.CopyN(&b, rin, maxLen); err == io.EOF {
+			err = nil
+		}
 	}
 	if err != nil && strings.Contains(err.Error(), "invalid checksum") {
 		if log.CLIEnabled() {
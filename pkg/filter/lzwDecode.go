@@ -86,7 +86,9 @@ func (f lzwDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
 	if maxLen < 0 {
 		written, err = io.Copy(&b, rc)
 	} else {
-		written, err = io.CopyN(&b, rc, maxLen)
+		if written, err = io.CopyN(&b, rc, maxLen); err == io.EOF {
+			err = nil
+		}
 	}
 	if err != nil {
 		return nil, err
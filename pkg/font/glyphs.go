@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// TrueTypeGlyphs describes the glyphs of a TrueType font program.
+type TrueTypeGlyphs struct {
+	NumGlyphs int
+	Cmaps     map[string]map[uint32]uint16 // cmap subtables by "platformID/encodingID" eg. "3/1"
+	empty     map[uint16]bool              // glyphs without outline
+}
+
+// Outline returns true if the glyph gid exists and has an outline.
+func (g TrueTypeGlyphs) Outline(gid uint16) bool {
+	return int(gid) < g.NumGlyphs && !g.empty[gid]
+}
+
+func (t table) cmapFormat0() map[uint32]uint16 {
+	m := map[uint32]uint16{}
+	for c := 0; c < 256; c++ {
+		if gid := uint16(t.data[6+c]); gid > 0 {
+			m[uint32(c)] = gid
+		}
+	}
+	return m
+}
+
+func (t table) cmapFormat6() map[uint32]uint16 {
+	m := map[uint32]uint16{}
+	first, count := int(t.uint16(6)), int(t.uint16(8))
+	for i := 0; i < count; i++ {
+		if gid := t.uint16(10 + 2*i); gid > 0 {
+			m[uint32(first+i)] = gid
+		}
+	}
+	return m
+}
+
+// cmapSubtables returns all cmap subtables of a format supported for glyph lookup.
+func (t table) cmapSubtables() map[string]map[uint32]uint16 {
+	mm := map[string]map[uint32]uint16{}
+	for i := 0; i < int(t.uint16(2)); i++ {
+		off := 4 + i*8
+		k := fmt.Sprintf("%d/%d", t.uint16(off), t.uint16(off+2))
+		o := t.uint32(off + 4)
+		f := t.uint16(int(o))
+		l := uint32(t.uint16(int(o) + 2))
+		if f >= 8 {
+			l = t.uint32(int(o) + 4)
+		}
+		t1 := table{off: o, size: l, data: t.data[o : o+l]}
+		fd := ttf{Chars: map[uint32]uint16{}, ToUnicode: map[uint16]uint32{}, Planes: map[int]bool{}}
+		switch f {
+		case 0:
+			mm[k] = t1.cmapFormat0()
+		case 4:
+			t1.parseCMapFormat4(&fd)
+			mm[k] = fd.Chars
+		case 6:
+			mm[k] = t1.cmapFormat6()
+		case 12:
+			t1.parseCMapFormat12(&fd)
+			mm[k] = fd.Chars
+		}
+	}
+	return mm
+}
+
+// ParseTrueTypeGlyphs returns the glyph count, the cmap subtables and the glyphs without outline of the TrueType font program bb.
+func ParseTrueTypeGlyphs(bb []byte) (g *TrueTypeGlyphs, err error) {
+	// Embedded font programs may be truncated or corrupt.
+	defer func() {
+		if r := recover(); r != nil {
+			g, err = nil, errors.New("pdfcpu: corrupt font program")
+		}
+	}()
+
+	if len(bb) < 12 {
+		return nil, errors.New("pdfcpu: corrupt font program")
+	}
+
+	tables, err := ttfTables(int(binary.BigEndian.Uint16(bb[4:])), bb)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range []string{"head", "maxp", "loca"} {
+		if _, ok := tables[tag]; !ok {
+			return nil, errors.Errorf("pdfcpu: font program without %s table", tag)
+		}
+	}
+
+	g = &TrueTypeGlyphs{
+		NumGlyphs: int(tables["maxp"].uint16(4)),
+		Cmaps:     map[string]map[uint32]uint16{},
+		empty:     map[uint16]bool{},
+	}
+
+	if t, ok := tables["cmap"]; ok {
+		g.Cmaps = t.cmapSubtables()
+	}
+
+	indexToLocFormat := int(tables["head"].int16(50))
+	loca := tables["loca"]
+	for gid := 0; gid < g.NumGlyphs; gid++ {
+		if glyfOffset(loca, gid, indexToLocFormat) == glyfOffset(loca, gid+1, indexToLocFormat) {
+			g.empty[uint16(gid)] = true
+		}
+	}
+
+	return g, nil
+}
@@ -696,8 +696,18 @@ func InstallTrueTypeCollection(fontDir, fn string) error {
 	}
 	defer f.Close()
 
+	return installTrueTypeCollection(fontDir, fn, f)
+}
+
+// InstallTrueTypeCollectionFromBytes saves an internal representation of all fonts
+// contained in TrueType collection fn to the pdfcpu config dir.
+func InstallTrueTypeCollectionFromBytes(fontDir, fn string, bb []byte) error {
+	return installTrueTypeCollection(fontDir, fn, bytes.NewReader(bb))
+}
+
+func installTrueTypeCollection(fontDir, fn string, f io.ReaderAt) error {
 	b := make([]byte, 12)
-	n, err := f.Read(b)
+	n, err := f.ReadAt(b, 0)
 	if err != nil {
 		return err
 	}
@@ -777,6 +787,41 @@ func ttfTables(tableCount int, bb []byte) (map[string]*table, error) {
 	return tables, nil
 }
 
+// GlyphUnicodes returns the Unicode code points of the glyphs of the TrueType font program bb
+// as defined by its Unicode cmap subtable.
+func GlyphUnicodes(bb []byte) (m map[uint16]uint32, err error) {
+	// Embedded font programs may be truncated or corrupt.
+	defer func() {
+		if r := recover(); r != nil {
+			m, err = nil, errors.New("pdfcpu: corrupt font program")
+		}
+	}()
+
+	if len(bb) < 12 {
+		return nil, errors.New("pdfcpu: corrupt font program")
+	}
+
+	tables, err := ttfTables(int(binary.BigEndian.Uint16(bb[4:])), bb)
+	if err != nil {
+		return nil, err
+	}
+
+	fd := ttf{}
+	if err := parse(tables, "cmap", &fd); err != nil {
+		return nil, err
+	}
+
+	// Prefer the lowest code point for glyphs mapped more than once eg. U+03A9 over U+2126 (Ohm sign).
+	m = map[uint16]uint32{}
+	for c, gid := range fd.Chars {
+		if c1, ok := m[gid]; !ok || c < c1 {
+			m[gid] = c
+		}
+	}
+
+	return m, nil
+}
+
 func glyfOffset(loca *table, gid, indexToLocFormat int) int {
 	if indexToLocFormat == 0 {
 		// short offsets
@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+// Tables a font program embedded in a PDF file may lack but a standalone TrueType font needs.
+var optionalEmbeddedTables = []string{"cmap", "name", "post", "OS/2"}
+
+type sfntWriter struct {
+	bytes.Buffer
+}
+
+func (w *sfntWriter) u16(i int) {
+	binary.Write(w, binary.BigEndian, uint16(i))
+}
+
+func (w *sfntWriter) u32(i uint32) {
+	binary.Write(w, binary.BigEndian, i)
+}
+
+func newTable(bb []byte) *table {
+	l := uint32(len(bb))
+	ll := getNext32BitAlignedLength(l)
+	bb = append(bb, make([]byte, ll-l)...)
+	return &table{size: l, padded: ll, data: bb}
+}
+
+// cmapTable returns a cmap table with a format 4 subtable for the BMP
+// and a format 12 subtable if chars contains supplementary characters.
+func cmapTable(chars map[uint32]uint16) *table {
+	cc := make([]uint32, 0, len(chars))
+	for c := range chars {
+		cc = append(cc, c)
+	}
+	sort.Slice(cc, func(i, j int) bool { return cc[i] < cc[j] })
+
+	var bmp, supp []uint32
+	for _, c := range cc {
+		if c < 0xFFFF {
+			bmp = append(bmp, c)
+		} else if c > 0xFFFF {
+			supp = append(supp, c)
+		}
+	}
+
+	// Format 4 using one segment per character plus the final segment.
+	segCount := len(bmp) + 1
+	f4 := &sfntWriter{}
+	f4.u16(4)
+	f4.u16(16 + 8*segCount)
+	f4.u16(0)
+	f4.u16(2 * segCount)
+	searchRange := 2
+	entrySelector := 0
+	for searchRange*2 <= 2*segCount {
+		searchRange *= 2
+		entrySelector++
+	}
+	f4.u16(searchRange)
+	f4.u16(entrySelector)
+	f4.u16(2*segCount - searchRange)
+	for _, c := range bmp {
+		f4.u16(int(c))
+	}
+	f4.u16(0xFFFF)
+	f4.u16(0)
+	for _, c := range bmp {
+		f4.u16(int(c))
+	}
+	f4.u16(0xFFFF)
+	for _, c := range bmp {
+		f4.u16(int(chars[c]-uint16(c)) & 0xFFFF)
+	}
+	f4.u16(1)
+	for i := 0; i < segCount; i++ {
+		f4.u16(0)
+	}
+
+	var f12 *sfntWriter
+	if len(supp) > 0 {
+		f12 = &sfntWriter{}
+		f12.u16(12)
+		f12.u16(0)
+		f12.u32(uint32(16 + 12*len(supp)))
+		f12.u32(0)
+		f12.u32(uint32(len(supp)))
+		for _, c := range supp {
+			f12.u32(c)
+			f12.u32(c)
+			f12.u32(uint32(chars[c]))
+		}
+	}
+
+	w := &sfntWriter{}
+	w.u16(0)
+	if f12 == nil {
+		w.u16(1)
+		w.u16(3)
+		w.u16(1)
+		w.u32(12)
+		w.Write(f4.Bytes())
+		return newTable(w.Bytes())
+	}
+
+	w.u16(2)
+	w.u16(3)
+	w.u16(1)
+	w.u32(20)
+	w.u16(3)
+	w.u16(10)
+	w.u32(uint32(20 + f4.Len()))
+	w.Write(f4.Bytes())
+	w.Write(f12.Bytes())
+	return newTable(w.Bytes())
+}
+
+// nameTable returns a name table with Windows Unicode family, style, unique, full and PostScript names.
+func nameTable(psName string) *table {
+	ss := []string{psName, "Regular", psName, psName, "Version 1.0", psName}
+	ids := []int{1, 2, 3, 4, 5, 6}
+
+	var strs bytes.Buffer
+	w := &sfntWriter{}
+	w.u16(0)
+	w.u16(len(ids))
+	w.u16(6 + 12*len(ids))
+	for i, id := range ids {
+		u := utf16.Encode([]rune(ss[i]))
+		w.u16(3)
+		w.u16(1)
+		w.u16(0x409)
+		w.u16(id)
+		w.u16(2 * len(u))
+		w.u16(strs.Len())
+		binary.Write(&strs, binary.BigEndian, u)
+	}
+	w.Write(strs.Bytes())
+
+	return newTable(w.Bytes())
+}
+
+// postTable returns a post table of format 3 which carries no glyph names.
+func postTable() *table {
+	w := &sfntWriter{}
+	w.u32(0x00030000)
+	for i := 0; i < 7; i++ {
+		w.u32(0)
+	}
+	return newTable(w.Bytes())
+}
+
+// os2Table returns a version 1 OS/2 table using the vertical metrics of hhea.
+func os2Table(hhea *table, chars map[uint32]uint16) *table {
+	ascent, descent, lineGap := int(hhea.int16(4)), int(hhea.int16(6)), int(hhea.int16(8))
+
+	first, last := 0xFFFF, 0
+	for c := range chars {
+		if c <= 0xFFFF {
+			first, last = min(first, int(c)), max(last, int(c))
+		}
+	}
+	if first > last {
+		first, last = 0, 0
+	}
+
+	w := &sfntWriter{}
+	w.u16(1)   // version
+	w.u16(0)   // xAvgCharWidth
+	w.u16(400) // usWeightClass
+	w.u16(5)   // usWidthClass
+	w.u16(0)   // fsType: installable
+	for i := 0; i < 11; i++ {
+		// sub- and superscript, strikeout metrics and sFamilyClass
+		w.u16(0)
+	}
+	w.Write(make([]byte, 10)) // panose
+	for i := 0; i < 4; i++ {
+		w.u32(0) // ulUnicodeRange
+	}
+	w.WriteString("PDFC")
+	w.u16(0x40) // fsSelection: regular
+	w.u16(first)
+	w.u16(last)
+	w.u16(ascent)
+	w.u16(descent)
+	w.u16(lineGap)
+	w.u16(ascent)
+	w.u16(-descent)
+	w.u32(1) // ulCodePageRange1: Latin 1
+	w.u32(0)
+
+	return newTable(w.Bytes())
+}
+
+// CompleteTrueType turns the TrueType font program bb as embedded in a PDF file into a standalone font
+// by adding the tables cmap, name, post and OS/2 if missing.
+// The cmap table gets generated from chars mapping Unicode characters to glyph ids.
+func CompleteTrueType(bb []byte, psName string, chars map[uint32]uint16) (_ []byte, err error) {
+	// Embedded font programs may be truncated or corrupt.
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("pdfcpu: corrupt font program")
+		}
+	}()
+
+	if len(bb) < 12 {
+		return nil, errors.New("pdfcpu: corrupt font program")
+	}
+
+	tables, err := ttfTables(int(binary.BigEndian.Uint16(bb[4:])), bb)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range []string{"head", "hhea", "maxp", "hmtx", "loca", "glyf"} {
+		if _, ok := tables[tag]; !ok {
+			return nil, errors.Errorf("pdfcpu: font program without %s table", tag)
+		}
+	}
+
+	complete := true
+	for _, tag := range optionalEmbeddedTables {
+		if _, ok := tables[tag]; !ok {
+			complete = false
+		}
+	}
+	if complete {
+		return bb, nil
+	}
+
+	if _, ok := tables["cmap"]; !ok {
+		tables["cmap"] = cmapTable(chars)
+	}
+	if _, ok := tables["name"]; !ok {
+		tables["name"] = nameTable(psName)
+	}
+	if _, ok := tables["post"]; !ok {
+		tables["post"] = postTable()
+	}
+	if _, ok := tables["OS/2"]; !ok {
+		tables["OS/2"] = os2Table(tables["hhea"], chars)
+	}
+
+	return writeSFNT(tables), nil
+}
+
+// writeSFNT returns a TrueType font file for tables with fresh table checksums and head.checkSumAdjustment.
+func writeSFNT(tables map[string]*table) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	head := tables["head"]
+	binary.BigEndian.PutUint32(head.data[8:], 0)
+
+	n := len(tags)
+	searchRange, entrySelector := 1, 0
+	for searchRange*2 <= n {
+		searchRange *= 2
+		entrySelector++
+	}
+
+	w := &sfntWriter{}
+	w.u32(0x00010000)
+	w.u16(n)
+	w.u16(16 * searchRange)
+	w.u16(entrySelector)
+	w.u16(16 * (n - searchRange))
+
+	var headOff uint32
+	off := uint32(12 + 16*n)
+	for _, tag := range tags {
+		t := tables[tag]
+		if tag == "head" {
+			headOff = off
+		}
+		w.WriteString(tag)
+		w.u32(calcTableChecksum("", t.data))
+		w.u32(off)
+		w.u32(t.size)
+		off += t.padded
+	}
+	for _, tag := range tags {
+		w.Write(tables[tag].data)
+	}
+
+	bb := w.Bytes()
+	binary.BigEndian.PutUint32(bb[headOff+8:], 0xB1B0AFBA-calcTableChecksum("", bb))
+
+	return bb
+}
@@ -16,7 +16,12 @@ limitations under the License.
 
 package log
 
-import "testing"
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
 
 func TestLog(t *testing.T) {
 
@@ -30,3 +35,17 @@ func TestLog(t *testing.T) {
 	Debug.Println("Testlog")
 	DisableLoggers()
 }
+
+func TestSlogLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	SetSlogLoggers(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer DisableLoggers()
+
+	Read.Printf("Test%s\n", "log")
+	Validate.Println("Testlog")
+
+	s := buf.String()
+	if !strings.Contains(s, "msg=Testlog logger=read") || !strings.Contains(s, "msg=Testlog logger=validate") {
+		t.Fatalf("unexpected log output: %s", s)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type slogLogger struct {
+	l     *slog.Logger
+	level slog.Level
+}
+
+// NewSlogLogger returns a Logger writing each message as a structured record of level to l.
+func NewSlogLogger(l *slog.Logger, level slog.Level) Logger {
+	return &slogLogger{l: l, level: level}
+}
+
+func (sl *slogLogger) log(level slog.Level, msg string) {
+	sl.l.Log(context.Background(), level, strings.TrimRight(msg, "\n"))
+}
+
+// Printf logs a formatted string.
+func (sl *slogLogger) Printf(format string, args ...interface{}) {
+	sl.log(sl.level, fmt.Sprintf(format, args...))
+}
+
+// Println logs a line.
+func (sl *slogLogger) Println(args ...interface{}) {
+	sl.log(sl.level, fmt.Sprintln(args...))
+}
+
+// Fatalf logs a formatted string as error followed by a program abort.
+func (sl *slogLogger) Fatalf(format string, args ...interface{}) {
+	sl.log(slog.LevelError, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+// Fatalln logs a line as error followed by a program abort.
+func (sl *slogLogger) Fatalln(args ...interface{}) {
+	sl.log(slog.LevelError, fmt.Sprintln(args...))
+	os.Exit(1)
+}
+
+// SetSlogLoggers routes all loggers except the CLI logger to l.
+// Each record carries the logger name as attribute "logger".
+func SetSlogLoggers(l *slog.Logger) {
+	SetDebugLogger(NewSlogLogger(l.With("logger", "debug"), slog.LevelDebug))
+	SetInfoLogger(NewSlogLogger(l.With("logger", "info"), slog.LevelInfo))
+	SetStatsLogger(NewSlogLogger(l.With("logger", "stats"), slog.LevelInfo))
+	SetTraceLogger(NewSlogLogger(l.With("logger", "trace"), slog.LevelDebug))
+	SetParseLogger(NewSlogLogger(l.With("logger", "parse"), slog.LevelDebug))
+	SetReadLogger(NewSlogLogger(l.With("logger", "read"), slog.LevelDebug))
+	SetValidateLogger(NewSlogLogger(l.With("logger", "validate"), slog.LevelDebug))
+	SetOptimizeLogger(NewSlogLogger(l.With("logger", "optimize"), slog.LevelDebug))
+	SetWriteLogger(NewSlogLogger(l.With("logger", "write"), slog.LevelDebug))
+}
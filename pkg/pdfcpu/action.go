@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var pageActionTriggers = []string{model.PageActionOpen, model.PageActionClose}
+
+func destinationFor(ctx *model.Context, arr types.Array) (*model.Destination, error) {
+	if len(arr) < 2 {
+		return nil, errors.Errorf("pdfcpu: invalid destination: %v", arr)
+	}
+
+	dest := &model.Destination{}
+
+	switch o := arr[0].(type) {
+	case types.IndirectRef:
+		pageNr, err := ctx.PageNumber(o.ObjectNumber.Value())
+		if err != nil {
+			return nil, err
+		}
+		dest.PageNr = pageNr
+	case types.Integer:
+		dest.PageNr = o.Value()
+	default:
+		return nil, errors.Errorf("pdfcpu: invalid destination page: %v", arr[0])
+	}
+
+	n, ok := arr[1].(types.Name)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: invalid destination type: %v", arr[1])
+	}
+	found := false
+	for typ, s := range model.DestinationTypeStrings {
+		if s == n.Value() {
+			dest.Typ, found = typ, true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("pdfcpu: invalid destination type: %s", n)
+	}
+
+	// Parameters are numbers or null.
+	params := make([]float64, len(arr)-2)
+	for i, o := range arr[2:] {
+		o, err := ctx.Dereference(o)
+		if err != nil {
+			return nil, err
+		}
+		params[i], _ = numberValue(o)
+	}
+	param := func(i int) int {
+		if i < len(params) {
+			return int(math.Round(params[i]))
+		}
+		return 0
+	}
+
+	switch dest.Typ {
+	case model.DestXYZ:
+		dest.Left, dest.Top = param(0), param(1)
+		if len(params) > 2 {
+			dest.Zoom = float32(params[2])
+		}
+	case model.DestFitH, model.DestFitBH:
+		dest.Top = param(0)
+	case model.DestFitV, model.DestFitBV:
+		dest.Left = param(0)
+	case model.DestFitR:
+		dest.Left, dest.Bottom, dest.Right, dest.Top = param(0), param(1), param(2), param(3)
+	}
+
+	return dest, nil
+}
+
+// actionFor returns the action represented by o, an action dict or a destination.
+func actionFor(ctx *model.Context, o types.Object) (*model.Action, error) {
+	o, err := ctx.Dereference(o)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	if arr, ok := o.(types.Array); ok {
+		dest, err := destinationFor(ctx, arr)
+		if err != nil {
+			return nil, err
+		}
+		return &model.Action{Type: "GoTo", Dest: dest}, nil
+	}
+
+	d, ok := o.(types.Dict)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: invalid action: %v", o)
+	}
+
+	s := d.NameEntry("S")
+	if s == nil {
+		return nil, errors.New("pdfcpu: action: missing S")
+	}
+
+	a := &model.Action{Type: *s}
+
+	switch *s {
+	case "GoTo":
+		dest, err := ctx.Dereference(d["D"])
+		if err != nil {
+			return nil, err
+		}
+		arr, err := destArray(ctx, dest)
+		if err != nil {
+			return nil, err
+		}
+		if a.Dest, err = destinationFor(ctx, arr); err != nil {
+			return nil, err
+		}
+	case "Named":
+		if n := d.NameEntry("N"); n != nil {
+			a.Name = *n
+		}
+	}
+
+	return a, nil
+}
+
+func validateAction(ctx *model.Context, a model.Action) error {
+	switch a.Type {
+
+	case "GoTo":
+		dest := a.Dest
+		if dest == nil {
+			return errors.New("pdfcpu: GoTo action: missing destination")
+		}
+		if dest.PageNr < 1 || dest.PageNr > ctx.PageCount {
+			return errors.Errorf("pdfcpu: GoTo action: invalid page number: %d", dest.PageNr)
+		}
+		if _, ok := model.DestinationTypeStrings[dest.Typ]; !ok {
+			return errors.Errorf("pdfcpu: GoTo action: invalid destination type: %d", dest.Typ)
+		}
+		if dest.Zoom < 0 {
+			return errors.Errorf("pdfcpu: GoTo action: invalid zoom: %.2f", dest.Zoom)
+		}
+		if dest.Typ == model.DestFitR && (dest.Left >= dest.Right || dest.Bottom >= dest.Top) {
+			return errors.Errorf("pdfcpu: GoTo action: invalid FitR rectangle: [%d %d %d %d]", dest.Left, dest.Bottom, dest.Right, dest.Top)
+		}
+
+	case "Named":
+		if !types.MemberOf(a.Name, model.NamedActions) {
+			return errors.Errorf("pdfcpu: unsupported named action: %s", a.Name)
+		}
+
+	default:
+		return errors.Errorf("pdfcpu: unsupported action type: %s", a.Type)
+	}
+
+	return nil
+}
+
+func actionDict(ctx *model.Context, a model.Action) (types.Dict, error) {
+	if err := validateAction(ctx, a); err != nil {
+		return nil, err
+	}
+
+	d := types.Dict{"Type": types.Name("Action"), "S": types.Name(a.Type)}
+
+	if a.Type == "Named" {
+		d["N"] = types.Name(a.Name)
+		return d, nil
+	}
+
+	pageIndRef, err := ctx.PageDictIndRef(a.Dest.PageNr)
+	if err != nil {
+		return nil, err
+	}
+	d["D"] = a.Dest.Array(*pageIndRef)
+
+	return d, nil
+}
+
+// OpenAction returns the document open action of ctx or nil if there is none.
+func OpenAction(ctx *model.Context) (*model.Action, error) {
+	o, found := ctx.RootDict.Find("OpenAction")
+	if !found {
+		return nil, nil
+	}
+	return actionFor(ctx, o)
+}
+
+// SetOpenAction sets the document open action of ctx to a GoTo or Named action.
+func SetOpenAction(ctx *model.Context, a model.Action) error {
+	d, err := actionDict(ctx, a)
+	if err != nil {
+		return err
+	}
+	ctx.RootDict["OpenAction"] = d
+	return nil
+}
+
+// RemoveOpenAction removes the document open action of ctx.
+func RemoveOpenAction(ctx *model.Context) (bool, error) {
+	if _, found := ctx.RootDict.Find("OpenAction"); !found {
+		return false, nil
+	}
+	ctx.RootDict.Delete("OpenAction")
+	return true, nil
+}
+
+func pageAdditionalActions(ctx *model.Context, pageNr int) (types.Dict, types.Dict, error) {
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return nil, nil, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aa, err := ctx.DereferenceDict(pageDict["AA"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pageDict, aa, nil
+}
+
+func validatePageActionTrigger(trigger string) error {
+	if !types.MemberOf(trigger, pageActionTriggers) {
+		return errors.Errorf("pdfcpu: invalid page action trigger: %s", trigger)
+	}
+	return nil
+}
+
+// PageActions returns the page open and close actions of page pageNr by trigger.
+func PageActions(ctx *model.Context, pageNr int) (map[string]model.Action, error) {
+	_, aa, err := pageAdditionalActions(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]model.Action{}
+
+	for _, trigger := range pageActionTriggers {
+		o, found := aa.Find(trigger)
+		if !found {
+			continue
+		}
+		a, err := actionFor(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			m[trigger] = *a
+		}
+	}
+
+	return m, nil
+}
+
+// SetPageAction sets the page action for trigger (model.PageActionOpen or model.PageActionClose) of page pageNr
+// to a GoTo or Named action.
+func SetPageAction(ctx *model.Context, pageNr int, trigger string, a model.Action) error {
+	if err := validatePageActionTrigger(trigger); err != nil {
+		return err
+	}
+
+	pageDict, aa, err := pageAdditionalActions(ctx, pageNr)
+	if err != nil {
+		return err
+	}
+
+	d, err := actionDict(ctx, a)
+	if err != nil {
+		return err
+	}
+
+	if aa == nil {
+		aa = types.Dict{}
+		pageDict["AA"] = aa
+	}
+	aa[trigger] = d
+
+	return nil
+}
+
+// RemovePageAction removes the page action for trigger of page pageNr.
+func RemovePageAction(ctx *model.Context, pageNr int, trigger string) (bool, error) {
+	if err := validatePageActionTrigger(trigger); err != nil {
+		return false, err
+	}
+
+	pageDict, aa, err := pageAdditionalActions(ctx, pageNr)
+	if err != nil {
+		return false, err
+	}
+
+	if _, found := aa.Find(trigger); !found {
+		return false, nil
+	}
+
+	aa.Delete(trigger)
+	if len(aa) == 0 {
+		pageDict.Delete("AA")
+	}
+
+	return true, nil
+}
@@ -531,6 +531,28 @@ func AddAnnotationToPage(ctx *model.Context, pageNr int, ar model.AnnotationRend
 	return AddAnnotation(ctx, pageDictIndRef, d, pageNr, ar, incr)
 }
 
+// AddVisualAnnotationToPage adds ar to page pageNr taking its geometry in visual coordinates,
+// ie. relative to the page as displayed considering crop box and page rotation, see model.PageCoordinates.
+func AddVisualAnnotationToPage(ctx *model.Context, pageNr int, ar model.AnnotationRenderer, incr bool) (*types.IndirectRef, types.Dict, error) {
+	ir, d, err := AddAnnotationToPage(ctx, pageNr, ar, incr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := AnnotationToUserSpace(ctx, pageNr, d); err != nil {
+		return nil, nil, err
+	}
+
+	// Refresh the page annotation cache.
+	ann, err := Annotation(ctx.XRefTable, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx.PageAnnots[pageNr][ar.Type()].Map[ir.ObjectNumber.Value()] = ann
+
+	return ir, d, nil
+}
+
 // AddAnnotations adds ar to selected pages.
 func AddAnnotations(ctx *model.Context, selectedPages types.IntSet, ar model.AnnotationRenderer, incr bool) (bool, error) {
 	var ok bool
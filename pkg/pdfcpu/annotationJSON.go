@@ -0,0 +1,720 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Annotations are exchanged as JSON using the object encoding of the JSON dump (see model.JSONValue)
+// extended by two kinds of strings:
+//
+//	"#<hash>"   a stream (eg. an appearance stream) stored in the streams section under its SHA-256 hash
+//	"@page 3"   a reference to a page dict
+//
+// All other indirect objects get inlined.
+// Widget annotations belong to form fields and are not exported.
+
+// AnnotationJSON represents an annotation.
+type AnnotationJSON struct {
+	Type       string         `json:"type"` // Annotation subtype, eg. "Text" or "Square"
+	Rect       []float64      `json:"rect"`
+	ID         string         `json:"id,omitempty"` // NM
+	Contents   string         `json:"contents,omitempty"`
+	Flags      int            `json:"flags,omitempty"`
+	Popup      *int           `json:"popup,omitempty"`     // Index of the popup annotation of this page.
+	Parent     *int           `json:"parent,omitempty"`    // Index of the parent annotation of a popup of this page.
+	InReplyTo  *int           `json:"inReplyTo,omitempty"` // Index of the annotation of this page replied to (IRT).
+	AP         any            `json:"ap,omitempty"`        // Appearance dict referencing appearance streams by hash.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// PageAnnotationsJSON represents the annotations of a page.
+type PageAnnotationsJSON struct {
+	Page        int              `json:"page"`
+	Annotations []AnnotationJSON `json:"annotations"`
+}
+
+// AnnotationStreamJSON represents a stream together with all objects it references.
+// Objects[0] is the stream itself, references use the object numbers of Objects.
+type AnnotationStreamJSON struct {
+	Objects []model.JSONObject `json:"objects"`
+}
+
+// AnnotationsJSON represents the annotations of a document.
+type AnnotationsJSON struct {
+	Header  Header                          `json:"header"`
+	Pages   []PageAnnotationsJSON           `json:"pages"`
+	Streams map[string]AnnotationStreamJSON `json:"streams,omitempty"` // keyed by SHA-256 hash
+}
+
+// annotKeys are the entries of an annotation dict not exported as properties.
+var annotKeys = []string{"Type", "Subtype", "Rect", "NM", "Contents", "F", "P", "Popup", "Parent", "IRT", "AP", "StructParent"}
+
+type annotExporter struct {
+	ctx     *model.Context
+	pageNrs map[int]int    // page dict objNr -> page number
+	hashes  map[int]string // stream objNr -> hash
+	streams map[string]AnnotationStreamJSON
+}
+
+func (e *annotExporter) value(o types.Object, visited types.IntSet) (any, error) {
+	switch o := o.(type) {
+
+	case types.IndirectRef:
+		objNr := o.ObjectNumber.Value()
+		if pageNr, ok := e.pageNrs[objNr]; ok {
+			return "@page " + strconv.Itoa(pageNr), nil
+		}
+		if visited[objNr] {
+			return nil, nil
+		}
+		o1, err := e.ctx.Dereference(o)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := o1.(types.StreamDict); ok {
+			hash, err := e.stream(objNr)
+			if err != nil {
+				return nil, err
+			}
+			return "#" + hash, nil
+		}
+		visited[objNr] = true
+		v, err := e.value(o1, visited)
+		delete(visited, objNr)
+		return v, err
+
+	case types.Dict:
+		m := map[string]any{}
+		for k, v := range o {
+			v1, err := e.value(v, visited)
+			if err != nil {
+				return nil, err
+			}
+			if v1 != nil {
+				m[k] = v1
+			}
+		}
+		return m, nil
+
+	case types.Array:
+		a := make([]any, len(o))
+		for i, v := range o {
+			v1, err := e.value(v, visited)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = v1
+		}
+		return a, nil
+	}
+
+	return model.JSONValue(o), nil
+}
+
+// collect numbers all objects reachable from o in depth first order.
+func (e *annotExporter) collect(o types.Object, objNrs map[int]int, order *[]int) error {
+	switch o := o.(type) {
+	case types.IndirectRef:
+		objNr := o.ObjectNumber.Value()
+		if _, ok := e.pageNrs[objNr]; ok {
+			return nil
+		}
+		if _, ok := objNrs[objNr]; ok {
+			return nil
+		}
+		o1, err := e.ctx.Dereference(o)
+		if err != nil || o1 == nil {
+			return err
+		}
+		objNrs[objNr] = len(*order) + 1
+		*order = append(*order, objNr)
+		return e.collect(o1, objNrs, order)
+	case types.Dict:
+		return e.collectDict(o, objNrs, order)
+	case types.StreamDict:
+		// The stream length gets inlined.
+		d := o.Dict.Clone().(types.Dict)
+		d.Delete("Length")
+		return e.collectDict(d, objNrs, order)
+	case types.Array:
+		for _, v := range o {
+			if err := e.collect(v, objNrs, order); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *annotExporter) collectDict(d types.Dict, objNrs map[int]int, order *[]int) error {
+	// Numbering must not depend on map order for stable hashes.
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := e.collect(d[k], objNrs, order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// localize returns a copy of o using the local object numbers of objNrs.
+// References to objects outside objNrs become null.
+func localize(o types.Object, objNrs map[int]int) types.Object {
+	switch o := o.(type) {
+	case types.IndirectRef:
+		objNr, ok := objNrs[o.ObjectNumber.Value()]
+		if !ok {
+			return nil
+		}
+		return *types.NewIndirectRef(objNr, 0)
+	case types.Dict:
+		d := types.NewDict()
+		for k, v := range o {
+			if v1 := localize(v, objNrs); v1 != nil {
+				d[k] = v1
+			}
+		}
+		return d
+	case types.StreamDict:
+		sd := o
+		sd.Dict = localize(o.Dict, objNrs).(types.Dict)
+		return sd
+	case types.Array:
+		a := make(types.Array, len(o))
+		for i, v := range o {
+			a[i] = localize(v, objNrs)
+		}
+		return a
+	}
+	return o
+}
+
+// stream adds the stream objNr and all objects it references to the streams section and returns its hash.
+func (e *annotExporter) stream(objNr int) (string, error) {
+	if hash, ok := e.hashes[objNr]; ok {
+		return hash, nil
+	}
+
+	objNrs := map[int]int{}
+	var order []int
+	if err := e.collect(*types.NewIndirectRef(objNr, 0), objNrs, &order); err != nil {
+		return "", err
+	}
+
+	sj := AnnotationStreamJSON{}
+	for i, objNr := range order {
+		o, err := e.ctx.Dereference(*types.NewIndirectRef(objNr, 0))
+		if err != nil {
+			return "", err
+		}
+		o = localize(o, objNrs)
+		if sd, ok := o.(types.StreamDict); ok {
+			if err := sd.Load(); err != nil {
+				return "", err
+			}
+			sd.Dict["Length"] = types.Integer(len(sd.Raw))
+			o = sd
+		}
+		jo, err := model.NewJSONObject(i+1, o)
+		if err != nil {
+			return "", err
+		}
+		sj.Objects = append(sj.Objects, jo)
+	}
+
+	bb, err := json.Marshal(sj)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bb)
+	hash := hex.EncodeToString(sum[:])
+
+	e.hashes[objNr] = hash
+	e.streams[hash] = sj
+
+	return hash, nil
+}
+
+func (e *annotExporter) annotation(d types.Dict, indices map[int]int) (*AnnotationJSON, error) {
+	a := &AnnotationJSON{Type: *d.NameEntry("Subtype")}
+
+	arr, err := e.ctx.DereferenceArray(d["Rect"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range arr {
+		f, err := e.ctx.DereferenceNumber(o)
+		if err != nil {
+			return nil, err
+		}
+		a.Rect = append(a.Rect, f)
+	}
+
+	if s := d.StringEntry("NM"); s != nil {
+		a.ID = *s
+	}
+
+	if o, found := d.Find("Contents"); found {
+		if a.Contents, err = e.ctx.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if i := d.IntEntry("F"); i != nil {
+		a.Flags = *i
+	}
+
+	index := func(k string) *int {
+		if ir := d.IndirectRefEntry(k); ir != nil {
+			if i, ok := indices[ir.ObjectNumber.Value()]; ok {
+				return &i
+			}
+		}
+		return nil
+	}
+	a.Popup, a.Parent, a.InReplyTo = index("Popup"), index("Parent"), index("IRT")
+
+	if o, found := d.Find("AP"); found {
+		if a.AP, err = e.value(o, types.IntSet{}); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range d {
+		if types.MemberOf(k, annotKeys) {
+			continue
+		}
+		v1, err := e.value(v, types.IntSet{})
+		if err != nil {
+			return nil, err
+		}
+		if v1 == nil {
+			continue
+		}
+		if a.Properties == nil {
+			a.Properties = map[string]any{}
+		}
+		a.Properties[k] = v1
+	}
+
+	return a, nil
+}
+
+func (e *annotExporter) pageAnnotations(pageNr int) (*PageAnnotationsJSON, error) {
+	pageDict, _, _, err := e.ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+
+	arr, err := e.ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil || len(arr) == 0 {
+		return nil, err
+	}
+
+	var dd []types.Dict
+	indices := map[int]int{}
+
+	for _, o := range arr {
+		d, err := e.ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		if st := d.NameEntry("Subtype"); st == nil || *st == "Widget" {
+			continue
+		}
+		if ir, ok := o.(types.IndirectRef); ok {
+			indices[ir.ObjectNumber.Value()] = len(dd)
+		}
+		dd = append(dd, d)
+	}
+
+	if len(dd) == 0 {
+		return nil, nil
+	}
+
+	pa := &PageAnnotationsJSON{Page: pageNr}
+	for _, d := range dd {
+		a, err := e.annotation(d, indices)
+		if err != nil {
+			return nil, err
+		}
+		pa.Annotations = append(pa.Annotations, *a)
+	}
+
+	return pa, nil
+}
+
+// ExportAnnotations returns the annotations of selectedPages (all pages if nil) of ctx originating from source.
+func ExportAnnotations(ctx *model.Context, selectedPages types.IntSet, source string) (*AnnotationsJSON, error) {
+	e := &annotExporter{
+		ctx:     ctx,
+		pageNrs: map[int]int{},
+		hashes:  map[int]string{},
+		streams: map[string]AnnotationStreamJSON{},
+	}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		ir, err := ctx.PageDictIndRef(i)
+		if err != nil {
+			return nil, err
+		}
+		e.pageNrs[ir.ObjectNumber.Value()] = i
+	}
+
+	aj := &AnnotationsJSON{Header: header(ctx.XRefTable, source), Pages: []PageAnnotationsJSON{}}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if selectedPages != nil && !selectedPages[i] {
+			continue
+		}
+		pa, err := e.pageAnnotations(i)
+		if err != nil {
+			return nil, err
+		}
+		if pa != nil {
+			aj.Pages = append(aj.Pages, *pa)
+		}
+	}
+
+	if len(e.streams) > 0 {
+		aj.Streams = e.streams
+	}
+
+	return aj, nil
+}
+
+// ExportAnnotationsJSON writes the annotations of selectedPages (all pages if nil) of ctx originating from source to w.
+// It returns false if there are no annotations.
+func ExportAnnotationsJSON(ctx *model.Context, selectedPages types.IntSet, source string, w io.Writer) (bool, error) {
+	aj, err := ExportAnnotations(ctx, selectedPages, source)
+	if err != nil || len(aj.Pages) == 0 {
+		return false, err
+	}
+
+	bb, err := json.MarshalIndent(aj, "", "\t")
+	if err != nil {
+		return false, err
+	}
+
+	_, err = w.Write(bb)
+
+	return true, err
+}
+
+type annotImporter struct {
+	ctx     *model.Context
+	streams map[string]AnnotationStreamJSON
+	irs     map[string]types.IndirectRef // hash -> imported stream
+}
+
+// relink replaces the local object numbers of o in place.
+func relink(o types.Object, objNrs map[int]int) types.Object {
+	switch o := o.(type) {
+	case types.IndirectRef:
+		objNr, ok := objNrs[o.ObjectNumber.Value()]
+		if !ok {
+			return nil
+		}
+		return *types.NewIndirectRef(objNr, 0)
+	case types.Dict:
+		for k, v := range o {
+			o[k] = relink(v, objNrs)
+		}
+	case types.StreamDict:
+		relink(o.Dict, objNrs)
+	case types.Array:
+		for i, v := range o {
+			o[i] = relink(v, objNrs)
+		}
+	}
+	return o
+}
+
+func (im *annotImporter) stream(hash string) (types.IndirectRef, error) {
+	if ir, ok := im.irs[hash]; ok {
+		return ir, nil
+	}
+
+	sj, ok := im.streams[hash]
+	if !ok || len(sj.Objects) == 0 {
+		return types.IndirectRef{}, errors.Errorf("pdfcpu: unknown annotation stream: %s", hash)
+	}
+
+	objNrs := map[int]int{}
+	oo := make([]types.Object, len(sj.Objects))
+
+	for i, jo := range sj.Objects {
+		o, err := model.ObjectForJSONObject(jo)
+		if err != nil {
+			return types.IndirectRef{}, err
+		}
+		ir, err := im.ctx.IndRefForNewObject(o)
+		if err != nil {
+			return types.IndirectRef{}, err
+		}
+		objNrs[jo.ObjNr] = ir.ObjectNumber.Value()
+		oo[i] = o
+	}
+
+	for i, o := range oo {
+		e := im.ctx.Table[objNrs[sj.Objects[i].ObjNr]]
+		e.Object = relink(o, objNrs)
+	}
+
+	ir := *types.NewIndirectRef(objNrs[sj.Objects[0].ObjNr], 0)
+	im.irs[hash] = ir
+
+	return ir, nil
+}
+
+func (im *annotImporter) object(v any) (types.Object, error) {
+	switch v := v.(type) {
+
+	case string:
+		if strings.HasPrefix(v, "#") {
+			return im.stream(v[1:])
+		}
+		if strings.HasPrefix(v, "@page ") {
+			pageNr, err := strconv.Atoi(v[6:])
+			if err != nil {
+				return nil, errors.Errorf("pdfcpu: invalid page reference: %s", v)
+			}
+			ir, err := im.ctx.PageDictIndRef(pageNr)
+			if err != nil {
+				return nil, err
+			}
+			return *ir, nil
+		}
+
+	case []any:
+		a := make(types.Array, len(v))
+		for i, e := range v {
+			o, err := im.object(e)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = o
+		}
+		return a, nil
+
+	case map[string]any:
+		d := types.NewDict()
+		for k, e := range v {
+			o, err := im.object(e)
+			if err != nil {
+				return nil, err
+			}
+			if o != nil {
+				d[k] = o
+			}
+		}
+		return d, nil
+	}
+
+	return model.ObjectForJSONValue(v)
+}
+
+func (im *annotImporter) annotation(a AnnotationJSON, pageIndRef types.IndirectRef) (types.Dict, error) {
+	if _, ok := model.AnnotTypes[a.Type]; !ok || a.Type == "Custom" || a.Type == "Widget" {
+		return nil, errors.Errorf("pdfcpu: unsupported annotation type: %s", a.Type)
+	}
+	if len(a.Rect) != 4 {
+		return nil, errors.Errorf("pdfcpu: invalid annotation rect: %v", a.Rect)
+	}
+
+	d := types.Dict(map[string]types.Object{
+		"Type":    types.Name("Annot"),
+		"Subtype": types.Name(a.Type),
+		"Rect":    types.NewNumberArray(a.Rect...),
+		"P":       pageIndRef,
+	})
+
+	if a.ID != "" {
+		d.InsertString("NM", a.ID)
+	}
+
+	if a.Contents != "" {
+		s, err := types.EscapedUTF16String(a.Contents)
+		if err != nil {
+			return nil, err
+		}
+		d.InsertString("Contents", *s)
+	}
+
+	if a.Flags != 0 {
+		d["F"] = types.Integer(a.Flags)
+	}
+
+	if a.AP != nil {
+		o, err := im.object(a.AP)
+		if err != nil {
+			return nil, err
+		}
+		d["AP"] = o
+	}
+
+	for k, v := range a.Properties {
+		if types.MemberOf(k, annotKeys) {
+			continue
+		}
+		o, err := im.object(v)
+		if err != nil {
+			return nil, err
+		}
+		if o != nil {
+			d[k] = o
+		}
+	}
+
+	return d, nil
+}
+
+func appendPageAnnots(ctx *model.Context, pageDict types.Dict, irs types.Array) error {
+	o, found := pageDict.Find("Annots")
+	if !found {
+		pageDict["Annots"] = irs
+		return nil
+	}
+
+	ir, ok := o.(types.IndirectRef)
+	if !ok {
+		arr, _ := o.(types.Array)
+		pageDict["Annots"] = append(arr, irs...)
+		return nil
+	}
+
+	arr, err := ctx.DereferenceArray(ir)
+	if err != nil {
+		return err
+	}
+	e, ok := ctx.FindTableEntryForIndRef(&ir)
+	if !ok {
+		return errors.Errorf("pdfcpu: can't dereference Annots indirect reference(obj#:%d)", ir.ObjectNumber)
+	}
+	e.Object = append(arr, irs...)
+
+	return nil
+}
+
+func (im *annotImporter) pageAnnotations(pa PageAnnotationsJSON) error {
+	pageDictIndRef, err := im.ctx.PageDictIndRef(pa.Page)
+	if err != nil {
+		return err
+	}
+
+	pageDict, err := im.ctx.DereferenceDict(*pageDictIndRef)
+	if err != nil {
+		return err
+	}
+
+	dd := make([]types.Dict, len(pa.Annotations))
+	irs := make(types.Array, len(pa.Annotations))
+
+	for i, a := range pa.Annotations {
+		if dd[i], err = im.annotation(a, *pageDictIndRef); err != nil {
+			return err
+		}
+		ir, err := im.ctx.IndRefForNewObject(dd[i])
+		if err != nil {
+			return err
+		}
+		irs[i] = *ir
+	}
+
+	for i, a := range pa.Annotations {
+		for k, j := range map[string]*int{"Popup": a.Popup, "Parent": a.Parent, "IRT": a.InReplyTo} {
+			if j == nil {
+				continue
+			}
+			if *j < 0 || *j >= len(irs) {
+				return errors.Errorf("pdfcpu: page %d: invalid annotation index for %s: %d", pa.Page, k, *j)
+			}
+			dd[i][k] = irs[*j]
+		}
+	}
+
+	if err := appendPageAnnots(im.ctx, pageDict, irs); err != nil {
+		return err
+	}
+
+	if im.ctx.PageAnnots == nil {
+		return nil
+	}
+
+	for i, d := range dd {
+		ar, err := Annotation(im.ctx.XRefTable, d)
+		if err != nil {
+			return err
+		}
+		if err := addAnnotationToCache(im.ctx, ar, pa.Page, irs[i].(types.IndirectRef).ObjectNumber.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportAnnotations adds the annotations of aj to ctx.
+func ImportAnnotations(ctx *model.Context, aj *AnnotationsJSON) (int, error) {
+	im := &annotImporter{ctx: ctx, streams: aj.Streams, irs: map[string]types.IndirectRef{}}
+
+	n := 0
+	for _, pa := range aj.Pages {
+		if err := im.pageAnnotations(pa); err != nil {
+			return 0, err
+		}
+		n += len(pa.Annotations)
+	}
+
+	if n > 0 {
+		ctx.EnsureVersionForWriting()
+	}
+
+	return n, nil
+}
+
+// ImportAnnotationsJSON adds the annotations read from rd to ctx and returns the number of added annotations.
+func ImportAnnotationsJSON(ctx *model.Context, rd io.Reader) (int, error) {
+	dec := json.NewDecoder(rd)
+	dec.UseNumber()
+
+	aj := &AnnotationsJSON{}
+	if err := dec.Decode(aj); err != nil {
+		return 0, errors.Wrap(err, "pdfcpu: invalid annotations JSON")
+	}
+
+	return ImportAnnotations(ctx, aj)
+}
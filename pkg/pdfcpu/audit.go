@@ -0,0 +1,379 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// AuditReport is the result of a reachability analysis of the object graph of a PDF file.
+type AuditReport struct {
+	Reachable       []int         // Objects reachable from the trailer.
+	Unreachable     []int         // Objects in use but not reachable from the trailer.
+	UnreachableSize int64         // Accumulated size of all unreachable objects.
+	Cycles          [][]int       // Strongly connected components of reachable objects, eg. page tree Parent links.
+	Size            map[int]int64 // Approximate serialized size of each object.
+	RetainedSize    map[int]int64 // Size that gets freed once a reachable object is dropped.
+}
+
+// objectGraph represents the objects in use together with their outgoing references.
+// Node 0 serves as virtual root referencing the trailer objects.
+type objectGraph struct {
+	nodes []int
+	succ  map[int][]int
+	size  map[int]int64
+}
+
+func collectRefs(o types.Object, refs *[]int) {
+	switch o := o.(type) {
+	case types.IndirectRef:
+		*refs = append(*refs, o.ObjectNumber.Value())
+	case types.Dict:
+		for _, v := range o {
+			collectRefs(v, refs)
+		}
+	case types.StreamDict:
+		collectRefs(o.Dict, refs)
+	case types.Array:
+		for _, v := range o {
+			collectRefs(v, refs)
+		}
+	}
+}
+
+func objectSize(o types.Object) int64 {
+	switch o := o.(type) {
+	case nil:
+		return 0
+	case types.StreamDict:
+		l := int64(len(o.Raw))
+		if l == 0 && o.StreamLength != nil {
+			l = *o.StreamLength
+		}
+		return int64(len(o.Dict.PDFString())) + l
+	}
+	return int64(len(o.PDFString()))
+}
+
+// structuralStream returns true for object streams and xref streams.
+func structuralStream(o types.Object) bool {
+	switch o := o.(type) {
+	case types.ObjectStreamDict, types.XRefStreamDict:
+		return true
+	case types.StreamDict:
+		t := o.Type()
+		return t != nil && (*t == "ObjStm" || *t == "XRef")
+	}
+	return false
+}
+
+func trailerRefs(ctx *model.Context) []int {
+	refs := []int{}
+	for _, ir := range []*types.IndirectRef{ctx.Root, ctx.Info, ctx.Encrypt} {
+		if ir != nil {
+			refs = append(refs, ir.ObjectNumber.Value())
+		}
+	}
+	if ctx.AdditionalStreams != nil {
+		collectRefs(*ctx.AdditionalStreams, &refs)
+	}
+	return refs
+}
+
+func newObjectGraph(ctx *model.Context, extraRoots []int) (*objectGraph, error) {
+	g := &objectGraph{succ: map[int][]int{}, size: map[int]int64{}}
+
+	inUse := func(objNr int) bool {
+		e, found := ctx.Find(objNr)
+		return found && !e.Free && objNr > 0
+	}
+
+	for objNr, e := range ctx.Table {
+		if !inUse(objNr) {
+			continue
+		}
+		// Resolve lazy object stream objects.
+		o, err := ctx.Dereference(*types.NewIndirectRef(objNr, *e.Generation))
+		if err != nil {
+			return nil, err
+		}
+		if structuralStream(o) {
+			continue
+		}
+		g.nodes = append(g.nodes, objNr)
+		g.size[objNr] = objectSize(o)
+		refs := []int{}
+		collectRefs(o, &refs)
+		for _, r := range refs {
+			if inUse(r) {
+				g.succ[objNr] = append(g.succ[objNr], r)
+			}
+		}
+	}
+	sort.Ints(g.nodes)
+
+	for _, r := range append(trailerRefs(ctx), extraRoots...) {
+		if inUse(r) {
+			g.succ[0] = append(g.succ[0], r)
+		}
+	}
+
+	return g, nil
+}
+
+// postOrder returns all nodes reachable from the virtual root in DFS post order.
+func (g *objectGraph) postOrder() []int {
+	visited := map[int]bool{0: true}
+	order := []int{}
+
+	type frame struct{ node, i int }
+	stack := []frame{{0, 0}}
+
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		if f.i < len(g.succ[f.node]) {
+			next := g.succ[f.node][f.i]
+			f.i++
+			if !visited[next] {
+				visited[next] = true
+				stack = append(stack, frame{next, 0})
+			}
+			continue
+		}
+		order = append(order, f.node)
+		stack = stack[:len(stack)-1]
+	}
+
+	return order
+}
+
+// dominators computes the immediate dominators of all reachable nodes.
+// See Cooper, Harvey, Kennedy: A Simple, Fast Dominance Algorithm.
+func (g *objectGraph) dominators(order []int) map[int]int {
+	po := map[int]int{}
+	for i, n := range order {
+		po[n] = i
+	}
+
+	pred := map[int][]int{}
+	for _, n := range order {
+		for _, s := range g.succ[n] {
+			pred[s] = append(pred[s], n)
+		}
+	}
+
+	intersect := func(idom map[int]int, a, b int) int {
+		for a != b {
+			for po[a] < po[b] {
+				a = idom[a]
+			}
+			for po[b] < po[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+
+	idom := map[int]int{0: 0}
+	for changed := true; changed; {
+		changed = false
+		// Reverse post order, skipping the root.
+		for i := len(order) - 2; i >= 0; i-- {
+			n := order[i]
+			newIdom, ok := -1, false
+			for _, p := range pred[n] {
+				if _, done := idom[p]; !done {
+					continue
+				}
+				if !ok {
+					newIdom, ok = p, true
+					continue
+				}
+				newIdom = intersect(idom, p, newIdom)
+			}
+			if !ok {
+				continue
+			}
+			if cur, found := idom[n]; !found || cur != newIdom {
+				idom[n] = newIdom
+				changed = true
+			}
+		}
+	}
+
+	return idom
+}
+
+// cycles returns all strongly connected components with more than one node or a self reference.
+func (g *objectGraph) cycles(reachable map[int]bool) [][]int {
+	index, low := map[int]int{}, map[int]int{}
+	onStack := map[int]bool{}
+	stack := []int{}
+	cc := [][]int{}
+	i := 0
+
+	var strongConnect func(n int)
+	strongConnect = func(n int) {
+		index[n], low[n] = i, i
+		i++
+		stack = append(stack, n)
+		onStack[n] = true
+
+		selfRef := false
+		for _, s := range g.succ[n] {
+			if s == n {
+				selfRef = true
+			}
+			if _, found := index[s]; !found {
+				strongConnect(s)
+				if low[s] < low[n] {
+					low[n] = low[s]
+				}
+			} else if onStack[s] && index[s] < low[n] {
+				low[n] = index[s]
+			}
+		}
+
+		if low[n] != index[n] {
+			return
+		}
+
+		c := []int{}
+		for {
+			m := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[m] = false
+			c = append(c, m)
+			if m == n {
+				break
+			}
+		}
+		if len(c) > 1 || selfRef {
+			sort.Ints(c)
+			cc = append(cc, c)
+		}
+	}
+
+	for _, n := range g.nodes {
+		if _, found := index[n]; !found && reachable[n] {
+			strongConnect(n)
+		}
+	}
+
+	sort.Slice(cc, func(i, j int) bool { return cc[i][0] < cc[j][0] })
+
+	return cc
+}
+
+// Audit analyzes the object graph of ctx starting at the trailer
+// and reports unreachable objects, reference cycles and the retained size of each reachable object.
+func Audit(ctx *model.Context) (*AuditReport, error) {
+	return audit(ctx, nil)
+}
+
+func audit(ctx *model.Context, extraRoots []int) (*AuditReport, error) {
+	if log.DebugEnabled() {
+		log.Debug.Println("Audit begin")
+	}
+
+	g, err := newObjectGraph(ctx, extraRoots)
+	if err != nil {
+		return nil, err
+	}
+	order := g.postOrder()
+
+	reachable := map[int]bool{}
+	for _, n := range order {
+		if n > 0 {
+			reachable[n] = true
+		}
+	}
+
+	r := &AuditReport{
+		Reachable:    []int{},
+		Unreachable:  []int{},
+		Size:         g.size,
+		RetainedSize: map[int]int64{},
+	}
+
+	for _, n := range g.nodes {
+		if reachable[n] {
+			r.Reachable = append(r.Reachable, n)
+			continue
+		}
+		r.Unreachable = append(r.Unreachable, n)
+		r.UnreachableSize += g.size[n]
+	}
+
+	// Accumulate sizes bottom up the dominator tree.
+	idom := g.dominators(order)
+	for _, n := range order {
+		if n == 0 {
+			continue
+		}
+		r.RetainedSize[n] += g.size[n]
+		if d := idom[n]; d > 0 {
+			r.RetainedSize[d] += r.RetainedSize[n]
+		}
+	}
+
+	r.Cycles = g.cycles(reachable)
+
+	if log.DebugEnabled() {
+		log.Debug.Printf("Audit end: %d reachable, %d unreachable objects\n", len(r.Reachable), len(r.Unreachable))
+	}
+
+	return r, nil
+}
+
+// freeUnreachableObjects frees all objects neither reachable from the trailer nor from any object in keep.
+func freeUnreachableObjects(ctx *model.Context, keep []int) error {
+	r, err := audit(ctx, keep)
+	if err != nil {
+		return err
+	}
+
+	for _, objNr := range r.Unreachable {
+		if log.WriteEnabled() {
+			log.Write.Printf("freeUnreachableObjects: obj #%d\n", objNr)
+		}
+		if err := ctx.FreeObject(objNr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeKeptObjects writes objects in keep that have not been written yet.
+func writeKeptObjects(ctx *model.Context, keep []int) error {
+	for _, objNr := range keep {
+		e, found := ctx.Find(objNr)
+		if !found || e.Free || ctx.Write.HasWriteOffset(objNr) {
+			continue
+		}
+		ir := types.NewIndirectRef(objNr, *e.Generation)
+		if _, _, err := writeDeepObject(ctx, *ir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNoPageBackground = errors.New("pdfcpu: no page background found")
+
+	// A page background is a marked content sequence filling the media box.
+	reBackgroundArtifact = regexp.MustCompile(`\s*/Artifact\s*<<\s*/Type\s*/Background\s*/Subtype\s*/PageBackground\s*>>\s*BDC(?s:.*?)EMC`)
+	reBackgroundGS       = regexp.MustCompile(`/(\S+)\s+gs`)
+)
+
+func backgroundContent(mediaBox *types.Rectangle, c color.SimpleColor, gsID string) []byte {
+	var b bytes.Buffer
+	b.WriteString(" /Artifact <</Type /Background /Subtype /PageBackground>> BDC q ")
+	if gsID != "" {
+		fmt.Fprintf(&b, "/%s gs ", gsID)
+	}
+	draw.SetFillColor(&b, c)
+	fmt.Fprintf(&b, "%.2f %.2f %.2f %.2f re f Q EMC ", mediaBox.LL.X, mediaBox.LL.Y, mediaBox.Width(), mediaBox.Height())
+	return b.Bytes()
+}
+
+func createMultiplyExtGState(ctx *model.Context) (*types.IndirectRef, error) {
+	return ctx.EnsureExtGStateFor(model.ExtGState{BlendMode: "Multiply"})
+}
+
+// addExtGState registers ir in the page resources and returns its resource name.
+func addExtGState(ctx *model.Context, d types.Dict, resDict types.Dict, ir types.IndirectRef) (string, error) {
+	if resDict == nil {
+		resDict = types.Dict{}
+	}
+	d.Update("Resources", resDict)
+
+	o, ok := resDict.Find("ExtGState")
+	if !ok {
+		resDict.Insert("ExtGState", types.Dict(map[string]types.Object{"GS0": ir}))
+		return "GS0", nil
+	}
+
+	gsDict, err := ctx.DereferenceDict(o)
+	if err != nil {
+		return "", err
+	}
+	if gsDict == nil {
+		gsDict = types.Dict{}
+		resDict.Update("ExtGState", gsDict)
+	}
+
+	for i := 0; ; i++ {
+		id := "GS" + strconv.Itoa(i)
+		if _, found := gsDict.Find(id); !found {
+			gsDict.Insert(id, ir)
+			return id, nil
+		}
+	}
+}
+
+// contentArray returns the content stream references of pageDict.
+func contentArray(ctx *model.Context, pageDict types.Dict) (types.Array, error) {
+	o, found := pageDict.Find("Contents")
+	if !found {
+		return nil, nil
+	}
+
+	if ir, ok := o.(types.IndirectRef); ok {
+		o1, err := ctx.Dereference(ir)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := o1.(types.StreamDict); ok {
+			return types.Array{ir}, nil
+		}
+		o = o1
+	}
+
+	a, ok := o.(types.Array)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: corrupt page \"Contents\"")
+	}
+
+	return a, nil
+}
+
+func newContentStream(ctx *model.Context, bb []byte) (*types.IndirectRef, error) {
+	sd, _ := ctx.NewStreamDictForBuf(bb)
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+	return ctx.IndRefForNewObject(*sd)
+}
+
+func setPageBackground(ctx *model.Context, pageNr int, c color.SimpleColor, underContent bool, gs *types.IndirectRef) error {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+	}
+
+	a, err := contentArray(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	if underContent {
+		ir, err := newContentStream(ctx, backgroundContent(inhPAttrs.MediaBox, c, ""))
+		if err != nil {
+			return err
+		}
+		d.Update("Contents", append(types.Array{*ir}, a...))
+		return nil
+	}
+
+	// Tint the page by multiplying the background color with the isolated page content.
+	gsID, err := addExtGState(ctx, d, inhPAttrs.Resources, *gs)
+	if err != nil {
+		return err
+	}
+
+	ir1, err := newContentStream(ctx, []byte("q "))
+	if err != nil {
+		return err
+	}
+
+	ir2, err := newContentStream(ctx, append([]byte(" Q"), backgroundContent(inhPAttrs.MediaBox, c, gsID)...))
+	if err != nil {
+		return err
+	}
+
+	a1 := append(types.Array{*ir1}, a...)
+	d.Update("Contents", append(a1, *ir2))
+
+	return nil
+}
+
+// SetPageBackground fills the media box of selected pages with color c.
+// If underContent is true the background is painted beneath existing content,
+// otherwise the page gets tinted by multiplying c with the page content which is useful for scans.
+func SetPageBackground(ctx *model.Context, selectedPages types.IntSet, c color.SimpleColor, underContent bool) error {
+	var (
+		gs  *types.IndirectRef
+		err error
+	)
+
+	if !underContent {
+		if gs, err = createMultiplyExtGState(ctx); err != nil {
+			return err
+		}
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if len(selectedPages) > 0 && !selectedPages[pageNr] {
+			continue
+		}
+		if err := setPageBackground(ctx, pageNr, c, underContent, gs); err != nil {
+			return err
+		}
+	}
+
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
+
+func removeBackgroundFromContentStream(sd *types.StreamDict, pageNr int) (bool, []string, error) {
+	err := sd.Decode()
+	if err == filter.ErrUnsupportedFilter {
+		if log.InfoEnabled() {
+			log.Info.Printf("unsupported filter: unable to remove background for page %d\n", pageNr)
+		}
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	var gsIDs []string
+	for _, m := range reBackgroundArtifact.FindAll(sd.Content, -1) {
+		if sm := reBackgroundGS.FindSubmatch(m); sm != nil {
+			gsIDs = append(gsIDs, string(sm[1]))
+		}
+	}
+
+	bb := reBackgroundArtifact.ReplaceAll(sd.Content, nil)
+	if len(bb) == len(sd.Content) {
+		return false, nil, nil
+	}
+
+	sd.Content = bb
+
+	return true, gsIDs, sd.Encode()
+}
+
+func removePageBackground(ctx *model.Context, pageNr int) (bool, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return false, err
+	}
+	if d == nil {
+		return false, errors.Errorf("pdfcpu: unknown page number: %d\n", pageNr)
+	}
+
+	a, err := contentArray(ctx, d)
+	if err != nil {
+		return false, err
+	}
+
+	var removed bool
+
+	for _, o := range a {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		entry, found := ctx.FindTableEntryForIndRef(&ir)
+		if !found || entry.Object == nil {
+			continue
+		}
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok {
+			continue
+		}
+
+		ok, gsIDs, err := removeBackgroundFromContentStream(&sd, pageNr)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			continue
+		}
+		entry.Object = sd
+		removed = true
+
+		if len(gsIDs) == 0 || inhPAttrs.Resources == nil {
+			continue
+		}
+		o, found := inhPAttrs.Resources.Find("ExtGState")
+		if !found {
+			continue
+		}
+		gsDict, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return false, err
+		}
+		if gsDict == nil {
+			continue
+		}
+		for _, id := range gsIDs {
+			gsDict.Delete(id)
+		}
+		d.Update("Resources", inhPAttrs.Resources)
+	}
+
+	return removed, nil
+}
+
+// RemovePageBackground removes page backgrounds set by SetPageBackground from selected pages.
+func RemovePageBackground(ctx *model.Context, selectedPages types.IntSet) error {
+	var removed bool
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if len(selectedPages) > 0 && !selectedPages[pageNr] {
+			continue
+		}
+		ok, err := removePageBackground(ctx, pageNr)
+		if err != nil {
+			return err
+		}
+		removed = removed || ok
+	}
+
+	if !removed {
+		return ErrNoPageBackground
+	}
+
+	return nil
+}
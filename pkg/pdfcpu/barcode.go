@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"image"
+	"sort"
+)
+
+// Code 39 barcode detection for separator sheets.
+
+const (
+	code39Alphabet     = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-. $/+%"
+	code39Asterisk     = 0x094 // start/stop character
+	code39MinWide      = 1.4   // minimum wide to narrow element ratio
+	barcodeScanLines   = 80    // per direction
+	barcodeMinContrast = 64
+)
+
+// code39Patterns encodes the 9 elements (bar, space, .., bar) of each character of code39Alphabet, wide elements being 1.
+var code39Patterns = []int{
+	0x034, 0x121, 0x061, 0x160, 0x031, 0x130, 0x070, 0x025, 0x124, 0x064, // 0-9
+	0x109, 0x049, 0x148, 0x019, 0x118, 0x058, 0x00D, 0x10C, 0x04C, 0x01C, // A-J
+	0x103, 0x043, 0x142, 0x013, 0x112, 0x052, 0x007, 0x106, 0x046, 0x016, // K-T
+	0x181, 0x0C1, 0x1C0, 0x091, 0x190, 0x0D0, 0x085, 0x184, 0x0C4, 0x0A8, // U-$
+	0x0A2, 0x08A, 0x02A, // /+%
+}
+
+// code39Pattern classifies 9 element widths into a wide/narrow pattern.
+func code39Pattern(ww []int) (int, bool) {
+	s := make([]int, len(ww))
+	copy(s, ww)
+	sort.Ints(s)
+
+	// 3 out of 9 elements are wide.
+	if float64(s[6]) < code39MinWide*float64(s[5]) {
+		return 0, false
+	}
+
+	var p int
+	for _, w := range ww {
+		p <<= 1
+		if w >= s[6] {
+			p |= 1
+		}
+	}
+	return p, true
+}
+
+func code39Char(p int) (byte, bool) {
+	for i, q := range code39Patterns {
+		if p == q {
+			return code39Alphabet[i], true
+		}
+	}
+	return 0, false
+}
+
+// decodeCode39Runs decodes all barcodes found in a sequence of alternating bar and space runs starting with a bar.
+func decodeCode39Runs(runs []int, found map[string]bool) {
+	for i := 0; i+9 <= len(runs); i += 2 {
+		if p, ok := code39Pattern(runs[i : i+9]); !ok || p != code39Asterisk {
+			continue
+		}
+		// Skip start character and inter-character gap.
+		var s []byte
+		for j := i + 10; j+9 <= len(runs); j += 10 {
+			p, ok := code39Pattern(runs[j : j+9])
+			if !ok {
+				break
+			}
+			if p == code39Asterisk {
+				if len(s) > 0 {
+					found[string(s)] = true
+				}
+				break
+			}
+			c, ok := code39Char(p)
+			if !ok {
+				break
+			}
+			s = append(s, c)
+		}
+	}
+}
+
+// scanBarcodeLine binarizes a line of gray values and decodes barcodes in both directions.
+func scanBarcodeLine(gg []uint8, found map[string]bool) {
+	lo, hi := uint8(255), uint8(0)
+	for _, g := range gg {
+		if g < lo {
+			lo = g
+		}
+		if g > hi {
+			hi = g
+		}
+	}
+	if int(hi)-int(lo) < barcodeMinContrast {
+		return
+	}
+	t := (int(lo) + int(hi)) / 2
+
+	// Collect runs starting with the first bar.
+	runs := []int{}
+	bar := false
+	for _, g := range gg {
+		dark := int(g) < t
+		if len(runs) == 0 {
+			if dark {
+				runs, bar = append(runs, 1), true
+			}
+			continue
+		}
+		if dark == bar {
+			runs[len(runs)-1]++
+			continue
+		}
+		runs, bar = append(runs, 1), dark
+	}
+	if !bar && len(runs) > 0 {
+		// Drop trailing quiet zone.
+		runs = runs[:len(runs)-1]
+	}
+
+	decodeCode39Runs(runs, found)
+
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+	decodeCode39Runs(runs, found)
+}
+
+// DecodeCode39 returns the values of all horizontal or vertical Code 39 barcodes found in img.
+func DecodeCode39(img *image.Gray) []string {
+	b := img.Bounds()
+	found := map[string]bool{}
+
+	row := make([]uint8, b.Dx())
+	for i := 1; i <= barcodeScanLines; i++ {
+		y := b.Min.Y + i*b.Dy()/(barcodeScanLines+1)
+		for x := b.Min.X; x < b.Max.X; x++ {
+			row[x-b.Min.X] = img.GrayAt(x, y).Y
+		}
+		scanBarcodeLine(row, found)
+	}
+
+	col := make([]uint8, b.Dy())
+	for i := 1; i <= barcodeScanLines; i++ {
+		x := b.Min.X + i*b.Dx()/(barcodeScanLines+1)
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			col[y-b.Min.Y] = img.GrayAt(x, y).Y
+		}
+		scanBarcodeLine(col, found)
+	}
+
+	ss := make([]string, 0, len(found))
+	for s := range found {
+		ss = append(ss, s)
+	}
+	sort.Strings(ss)
+
+	return ss
+}
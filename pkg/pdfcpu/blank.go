@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultBlankPageTolerance is the default fraction of non white pixels a blank page may have
+	// accounting for scanner noise, dust and bleed-through.
+	DefaultBlankPageTolerance = 0.002
+
+	// Resolution used for rendering pages during blank page detection.
+	blankPageDPI = 50
+
+	// Gray levels at least this bright are considered paper.
+	blankPageWhite = 0xD0
+)
+
+// Operators painting anything onto a page.
+var paintingOps = map[string]bool{
+	"S": true, "s": true, "f": true, "F": true, "f*": true, "B": true, "B*": true, "b": true, "b*": true,
+	"Tj": true, "TJ": true, "'": true, "\"": true,
+	"Do": true, "sh": true, "BI": true,
+}
+
+// pageContentEmpty returns true if the content of page pageNr does not paint anything.
+// Inline images are not rendered and therefore reported as content.
+func pageContentEmpty(ctx *model.Context, pageNr int) (empty, inlineImage bool, err error) {
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return false, false, err
+	}
+	if d == nil {
+		return false, false, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err == model.ErrNoContent {
+		return true, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return false, false, err
+	}
+
+	empty = true
+	for _, op := range ops {
+		if op.Operator == "BI" {
+			return false, true, nil
+		}
+		if paintingOps[op.Operator] {
+			empty = false
+		}
+	}
+
+	return empty, false, nil
+}
+
+// IsBlankPage returns true if page pageNr has no content painting anything
+// or if at most the fraction tolerance of the rendered page is not near white.
+func IsBlankPage(ctx *model.Context, pageNr int, tolerance float64) (bool, error) {
+	if tolerance < 0 || tolerance >= 1 {
+		return false, errors.Errorf("pdfcpu: invalid blank page tolerance: %.4f", tolerance)
+	}
+
+	empty, inlineImage, err := pageContentEmpty(ctx, pageNr)
+	if err != nil || empty || inlineImage {
+		return empty, err
+	}
+
+	img, err := RenderPage(ctx, pageNr, blankPageDPI)
+	if err != nil {
+		return false, err
+	}
+
+	n := 0
+	for _, v := range img.Pix {
+		if v < blankPageWhite {
+			n++
+		}
+	}
+
+	return float64(n) <= tolerance*float64(len(img.Pix)), nil
+}
+
+// DetectBlankPages returns the numbers of all blank pages of ctx, see IsBlankPage.
+func DetectBlankPages(ctx *model.Context, tolerance float64) ([]int, error) {
+	var pageNrs []int
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		blank, err := IsBlankPage(ctx, pageNr, tolerance)
+		if err != nil {
+			return nil, err
+		}
+		if blank {
+			pageNrs = append(pageNrs, pageNr)
+		}
+	}
+
+	return pageNrs, nil
+}
+
+// RemoveBlankPages returns a new context containing all pages of ctx but blank pages
+// together with the numbers of the removed pages.
+// ctx is returned unmodified if there are no blank pages.
+func RemoveBlankPages(ctx *model.Context, tolerance float64) (*model.Context, []int, error) {
+	blankPages, err := DetectBlankPages(ctx, tolerance)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(blankPages) == 0 {
+		return ctx, nil, nil
+	}
+
+	if len(blankPages) == ctx.PageCount {
+		return nil, nil, errors.New("pdfcpu: RemoveBlankPages: all pages are blank")
+	}
+
+	blank := map[int]bool{}
+	for _, pageNr := range blankPages {
+		blank[pageNr] = true
+	}
+
+	var pageNrs []int
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if !blank[pageNr] {
+			pageNrs = append(pageNrs, pageNr)
+		}
+	}
+
+	ctxDest, err := ExtractPages(ctx, pageNrs, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ctxDest, blankPages, nil
+}
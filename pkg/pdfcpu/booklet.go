@@ -387,6 +387,34 @@ func nupPerfectBound(positionNumber int, inputPageCount int, pageNumbers []int,
 	return getPageNumber(pageNumbers, p-1), rotate // p is one-indexed and we want zero-indexed
 }
 
+// insertBlankPages inserts blank pages (represented by 0) at pos in order to complete the last sheet.
+func insertBlankPages(pageNumbers []int, pageCount int, pos model.BlankPagePosition) []int {
+	n := pageCount - len(pageNumbers)
+	if n == 0 || pos == model.BlankPagesAtEnd {
+		// Missing pages at the end are treated as blank pages.
+		return pageNumbers
+	}
+
+	pp := make([]int, 0, pageCount)
+
+	switch pos {
+	case model.BlankPagesAtStart:
+		pp = append(pp, make([]int, n)...)
+		pp = append(pp, pageNumbers...)
+
+	case model.BlankPagesBeforeBackCover:
+		if len(pageNumbers) < 2 {
+			return pageNumbers
+		}
+		// Keep the last page as back cover.
+		pp = append(pp, pageNumbers[:len(pageNumbers)-1]...)
+		pp = append(pp, make([]int, n)...)
+		pp = append(pp, pageNumbers[len(pageNumbers)-1])
+	}
+
+	return pp
+}
+
 func GetBookletOrdering(pages types.IntSet, nup *model.NUp) []model.BookletPage {
 	pageNumbers := sortSelectedPages(pages)
 	pageCount := len(pageNumbers)
@@ -400,6 +428,8 @@ func GetBookletOrdering(pages types.IntSet, nup *model.NUp) []model.BookletPage
 		pageCount += sheetPageCount - pageCount%sheetPageCount
 	}
 
+	pageNumbers = insertBlankPages(pageNumbers, pageCount, nup.BlankPages)
+
 	if nup.MultiFolio {
 		bookletPages := make([]model.BookletPage, 0)
 		// folioSize is the number of sheets - each "folio" has two sides and two pages per side
@@ -467,7 +497,7 @@ func bookletPages(
 
 		if i > 0 && i%len(rr) == 0 {
 			// Wrap complete page.
-			if err := wrapUpPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef); err != nil {
+			if err := wrapUpBookletPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef); err != nil {
 				return err
 			}
 			buf.Reset()
@@ -490,7 +520,27 @@ func bookletPages(
 	}
 
 	// Wrap incomplete booklet page.
-	return wrapUpPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef)
+	return wrapUpBookletPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef)
+}
+
+// wrapUpBookletPage compensates for the duplex paper handling and wraps up a booklet page.
+func wrapUpBookletPage(ctx *model.Context, nup *model.NUp, d types.Dict, buf bytes.Buffer, pagesDict types.Dict, pagesIndRef *types.IndirectRef) error {
+	i := pagesDict.IntEntry("Count")
+	if i == nil {
+		return errors.New("pdfcpu: corrupt pages dict")
+	}
+
+	if nup.Duplex == model.DuplexFlipLongEdge && *i%2 == 1 {
+		// The impositions assume sheets flipped along the short edge.
+		// For long-edge duplex printing rotate the back sides by 180 degrees.
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "q -1 0 0 -1 %.2f %.2f cm ", nup.PageDim.Width, nup.PageDim.Height)
+		b.Write(buf.Bytes())
+		b.WriteString("Q ")
+		buf = b
+	}
+
+	return wrapUpPage(ctx, nup, d, buf, pagesDict, pagesIndRef)
 }
 
 // BookletFromImages creates a booklet version of the image sequence represented by fileNames.
@@ -516,7 +566,7 @@ func BookletFromImages(ctx *model.Context, fileNames []string, nup *model.NUp, p
 		if i > 0 && i%len(rr) == 0 {
 
 			// Wrap complete page.
-			if err := wrapUpPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef); err != nil {
+			if err := wrapUpBookletPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef); err != nil {
 				return err
 			}
 
@@ -561,7 +611,7 @@ func BookletFromImages(ctx *model.Context, fileNames []string, nup *model.NUp, p
 	}
 
 	// Wrap incomplete booklet page.
-	return wrapUpPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef)
+	return wrapUpBookletPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef)
 }
 
 // BookletFromPDF creates a booklet version of the PDF represented by xRefTable.
@@ -31,6 +31,7 @@ type pageOrderResults struct {
 	binding            string
 	useSignatures      bool
 	nPagesPerSignature int
+	blanks             string
 }
 
 var bookletTestCases = []pageOrderResults{
@@ -68,6 +69,40 @@ var bookletTestCases = []pageOrderResults{
 		bookletType: "booklet",
 		binding:     "long",
 	},
+	{
+		id:        "2up with leading blank pages",
+		nup:       2,
+		pageCount: 10,
+		expectedPageOrder: []int{
+			10, 0,
+			9, 0,
+			8, 1,
+			7, 2,
+			6, 3,
+			5, 4,
+		},
+		papersize:   "A6",
+		bookletType: "booklet",
+		binding:     "long",
+		blanks:      "start",
+	},
+	{
+		id:        "2up with blank pages before back cover",
+		nup:       2,
+		pageCount: 10,
+		expectedPageOrder: []int{
+			10, 1,
+			0, 2,
+			0, 3,
+			9, 4,
+			8, 5,
+			7, 6,
+		},
+		papersize:   "A6",
+		bookletType: "booklet",
+		binding:     "long",
+		blanks:      "back",
+	},
 	// basic booklet sidefold test cases
 	{
 		id:        "booklet portrait long edge",
@@ -350,6 +385,9 @@ func TestBookletPageOrder(t *testing.T) {
 			if test.useSignatures {
 				desc += fmt.Sprintf(", multifolio:on, foliosize:%d", test.nPagesPerSignature/4)
 			}
+			if test.blanks != "" {
+				desc += ", blanks:" + test.blanks
+			}
 			nup, err := PDFBookletConfig(test.nup, desc, nil)
 			if err != nil {
 				tt.Fatal(err)
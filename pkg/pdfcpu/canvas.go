@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Canvas collects content for a page and takes care of the resources this content refers to.
+// Text, images and paths are buffered until Flush appends them on top of the existing page content.
+type Canvas struct {
+	ctx      *model.Context
+	pageNr   int
+	mediaBox *types.Rectangle
+	resDict  types.Dict                   // The page resources at the time of the last flush.
+	buf      bytes.Buffer                 // Pending content.
+	res      map[string]types.Dict        // Pending resources by category.
+	ids      map[types.IndirectRef]string // Resource ids by object.
+	fonts    map[string]types.IndirectRef // Font dicts by font name.
+}
+
+// NewCanvas returns a canvas for page pageNr of ctx.
+func NewCanvas(ctx *model.Context, pageNr int) (*Canvas, error) {
+	_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if inhPAttrs == nil {
+		return nil, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+	if inhPAttrs.MediaBox == nil {
+		return nil, errors.Errorf("pdfcpu: page %d: missing MediaBox", pageNr)
+	}
+
+	return &Canvas{
+		ctx:      ctx,
+		pageNr:   pageNr,
+		mediaBox: inhPAttrs.MediaBox,
+		resDict:  inhPAttrs.Resources,
+		res:      map[string]types.Dict{},
+		ids:      map[types.IndirectRef]string{},
+		fonts:    map[string]types.IndirectRef{},
+	}, nil
+}
+
+// MediaBox returns the media box of the canvas page.
+func (c *Canvas) MediaBox() *types.Rectangle {
+	return c.mediaBox
+}
+
+// resourceID returns the resource id for ir of category using prefix for new ids.
+func (c *Canvas) resourceID(category, prefix string, ir types.IndirectRef) (string, error) {
+	if id, ok := c.ids[ir]; ok {
+		return id, nil
+	}
+
+	var d types.Dict
+	if c.resDict != nil {
+		var err error
+		if d, err = c.ctx.DereferenceDict(c.resDict[category]); err != nil {
+			return "", err
+		}
+	}
+
+	// Reuse an id already pointing to ir.
+	for id, o := range d {
+		if ir1, ok := o.(types.IndirectRef); ok && ir1 == ir {
+			c.ids[ir] = id
+			return id, nil
+		}
+	}
+
+	pending := c.res[category]
+	if pending == nil {
+		pending = types.NewDict()
+		c.res[category] = pending
+	}
+
+	for i := 0; ; i++ {
+		id := prefix + strconv.Itoa(i)
+		if _, found := d.Find(id); found {
+			continue
+		}
+		if _, found := pending.Find(id); found {
+			continue
+		}
+		pending[id] = ir
+		c.ids[ir] = id
+		return id, nil
+	}
+}
+
+func (c *Canvas) fontDict(fontName string) (*types.IndirectRef, error) {
+	if ir, ok := c.fonts[fontName]; ok {
+		return &ir, nil
+	}
+
+	var (
+		ir  *types.IndirectRef
+		err error
+	)
+
+	if font.IsCoreFont(fontName) {
+		ir, err = pdffont.CoreFontDict(c.ctx.XRefTable, fontName)
+	} else {
+		if !font.SupportedFont(fontName) {
+			return nil, errors.Errorf("pdfcpu: font %s not available", fontName)
+		}
+		ir, err = pdffont.EnsureFontDict(c.ctx.XRefTable, fontName, "", "", false, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.fonts[fontName] = *ir
+
+	return ir, nil
+}
+
+// prepareFont registers the font of td and sets td.FontKey.
+func (c *Canvas) prepareFont(td *model.TextDescriptor) error {
+	if td.FontName == "" {
+		return errors.New("pdfcpu: Canvas: missing font name")
+	}
+
+	ir, err := c.fontDict(td.FontName)
+	if err != nil {
+		return err
+	}
+
+	if td.FontKey, err = c.resourceID("Font", "F", *ir); err != nil {
+		return err
+	}
+
+	if font.IsUserFont(td.FontName) {
+		td.Embed = true
+	}
+
+	return nil
+}
+
+// Text renders a text column as described by td and returns its bounding box.
+// td.FontKey is taken care of. User fonts get embedded as subset.
+func (c *Canvas) Text(td model.TextDescriptor) (*types.Rectangle, error) {
+	if err := c.prepareFont(&td); err != nil {
+		return nil, err
+	}
+
+	if td.Scale == 0 {
+		td.Scale, td.ScaleAbs = 1, true
+	}
+
+	return model.WriteMultiLine(c.ctx.XRefTable, &c.buf, c.mediaBox, nil, td), nil
+}
+
+// TextOnPath renders the first line of td.Text along p glyph by glyph and returns its bounding box.
+// td.X, td.Y and any scaling are ignored.
+func (c *Canvas) TextOnPath(td model.TextDescriptor, p model.TextPath) (*types.Rectangle, error) {
+	if err := c.prepareFont(&td); err != nil {
+		return nil, err
+	}
+
+	return model.WriteTextOnPath(c.ctx.XRefTable, &c.buf, td, p)
+}
+
+// Image renders the image read from rd into r and returns the image XObject for reuse with XObject.
+func (c *Canvas) Image(rd io.Reader, r *types.Rectangle) (*types.IndirectRef, error) {
+	ir, _, _, err := model.CreateImageResource(c.ctx.XRefTable, rd)
+	if err != nil {
+		return nil, err
+	}
+
+	m := matrix.CalcTransformMatrix(r.Width(), r.Height(), 0, 1, r.LL.X, r.LL.Y)
+
+	return ir, c.XObject(*ir, m)
+}
+
+// XObject renders the image or form XObject ir using the transformation m into user space.
+// Images occupy the unit square, forms their bounding box.
+func (c *Canvas) XObject(ir types.IndirectRef, m matrix.Matrix) error {
+	id, err := c.resourceID("XObject", "Im", ir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&c.buf, "q %.5f %.5f %.5f %.5f %.5f %.5f cm /%s Do Q ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1], id)
+
+	return nil
+}
+
+// FillPattern registers a pattern filling r with fp and returns its resource id
+// for use with TextDescriptor.FillPattern or draw.FillRectWithPattern.
+func (c *Canvas) FillPattern(fp model.FillPattern, r *types.Rectangle) (string, error) {
+	ir, err := c.ctx.NewFillPattern(fp, r, matrix.IdentMatrix)
+	if err != nil {
+		return "", err
+	}
+
+	return c.resourceID("Pattern", "P", *ir)
+}
+
+// Path renders the content written by fn, eg. using the draw package, within its own graphics state.
+func (c *Canvas) Path(fn func(w io.Writer)) {
+	c.buf.WriteString("q ")
+	fn(&c.buf)
+	c.buf.WriteString("Q ")
+}
+
+// SetExtGState applies the graphics state parameter dict d to all subsequent content up to the next flush.
+// Equal dicts are shared document wide, see EnsureExtGState.
+func (c *Canvas) SetExtGState(d types.Dict) error {
+	ir, err := c.ctx.EnsureExtGState(d)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.resourceID("ExtGState", "GS", *ir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&c.buf, "/%s gs ", id)
+
+	return nil
+}
+
+// SetGState applies gs to all subsequent content up to the next flush.
+func (c *Canvas) SetGState(gs model.ExtGState) error {
+	return c.SetExtGState(gs.Dict())
+}
+
+// SetOpacity applies the fill and stroke opacity to all subsequent content up to the next flush.
+func (c *Canvas) SetOpacity(fill, stroke float64) error {
+	return c.SetGState(model.Opacity(fill, stroke))
+}
+
+// Flush appends the pending content on top of the page content
+// and registers all resources used in the page resource dict.
+func (c *Canvas) Flush() error {
+	if c.buf.Len() == 0 {
+		return nil
+	}
+
+	pageDict, _, _, err := c.ctx.PageDict(c.pageNr, false)
+	if err != nil {
+		return err
+	}
+	if pageDict == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d", c.pageNr)
+	}
+
+	resDict, err := pageOwnedResources(c.ctx, pageDict)
+	if err != nil {
+		return err
+	}
+
+	for category, pending := range c.res {
+		d, err := ownedResourceSubDict(c.ctx, resDict, category)
+		if err != nil {
+			return err
+		}
+		for id, o := range pending {
+			d[id] = o
+		}
+	}
+
+	a, err := contentArray(c.ctx, pageDict)
+	if err != nil {
+		return err
+	}
+
+	bb := append([]byte("q "), c.buf.Bytes()...)
+	bb = append(bb, 'Q')
+
+	if len(a) > 0 {
+		// Isolate the existing page content.
+		ir, err := newContentStream(c.ctx, []byte("q "))
+		if err != nil {
+			return err
+		}
+		a = append(types.Array{*ir}, a...)
+		bb = append([]byte(" Q "), bb...)
+	}
+
+	ir, err := newContentStream(c.ctx, bb)
+	if err != nil {
+		return err
+	}
+	pageDict["Contents"] = append(a, *ir)
+
+	if err := pdffont.UpdateUserfonts(c.ctx.XRefTable, c.fonts); err != nil {
+		return err
+	}
+
+	c.ctx.EnsureVersionForWriting()
+
+	c.resDict = resDict
+	c.res = map[string]types.Dict{}
+	c.buf.Reset()
+
+	return nil
+}
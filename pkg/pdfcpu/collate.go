@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Predefined collation patterns.
+const (
+	// CollateZip takes one page of each document in turn.
+	CollateZip = "zip"
+
+	// CollateDuplex interleaves the front sides of a single sided scan (doc 1)
+	// with the back sides scanned in reverse order (doc 2).
+	CollateDuplex = "duplex"
+)
+
+// ReorderPages rearranges the pages of ctx into a flat page tree.
+// order lists every page number of ctx exactly once in the desired sequence.
+func ReorderPages(ctx *model.Context, order []int) error {
+	if len(order) != ctx.PageCount {
+		return errors.Errorf("pdfcpu: ReorderPages: need %d page numbers, got %d", ctx.PageCount, len(order))
+	}
+
+	seen := make([]bool, ctx.PageCount+1)
+	for _, pageNr := range order {
+		if pageNr < 1 || pageNr > ctx.PageCount {
+			return errors.Errorf("pdfcpu: ReorderPages: invalid page number: %d", pageNr)
+		}
+		if seen[pageNr] {
+			return errors.Errorf("pdfcpu: ReorderPages: duplicate page number: %d", pageNr)
+		}
+		seen[pageNr] = true
+	}
+
+	// Collect all page dicts before touching the page tree.
+	kids := make(types.Array, len(order))
+	dd := make([]types.Dict, len(order))
+	for i, pageNr := range order {
+		d, ir, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil || ir == nil {
+			return errors.Errorf("pdfcpu: ReorderPages: unknown page number: %d", pageNr)
+		}
+		kids[i], dd[i] = *ir, d
+	}
+
+	for _, d := range dd {
+		if err := inheritPageAttrs(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	pagesDict := types.Dict(
+		map[string]types.Object{
+			"Type":  types.Name("Pages"),
+			"Count": types.Integer(len(kids)),
+			"Kids":  kids,
+		},
+	)
+
+	ir, err := ctx.IndRefForNewObject(pagesDict)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range dd {
+		d["Parent"] = *ir
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+	rootDict["Pages"] = *ir
+
+	return nil
+}
+
+type collateStream struct {
+	doc      int
+	from, to int
+}
+
+func (cs collateStream) pages() []int {
+	var pp []int
+	if cs.from <= cs.to {
+		for p := cs.from; p <= cs.to; p++ {
+			pp = append(pp, p)
+		}
+		return pp
+	}
+	for p := cs.from; p >= cs.to; p-- {
+		pp = append(pp, p)
+	}
+	return pp
+}
+
+func parseCollatePageNr(s string, pageCount int) (int, error) {
+	if s == "n" {
+		return pageCount, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func parseCollateStream(s string, pageCounts []int) (*collateStream, error) {
+	docStr, rangeStr, hasRange := strings.Cut(s, ":")
+
+	doc, err := strconv.Atoi(strings.TrimSpace(docStr))
+	if err != nil || doc < 1 || doc > len(pageCounts) {
+		return nil, errors.Errorf("pdfcpu: Collate: invalid document number: %s", docStr)
+	}
+
+	pageCount := pageCounts[doc-1]
+	cs := &collateStream{doc: doc, from: 1, to: pageCount}
+	if !hasRange {
+		return cs, nil
+	}
+
+	fromStr, toStr, found := strings.Cut(strings.TrimSpace(rangeStr), "-")
+	if !found {
+		toStr = fromStr
+	}
+
+	if cs.from, err = parseCollatePageNr(fromStr, pageCount); err != nil {
+		return nil, errors.Errorf("pdfcpu: Collate: invalid page range: %s", s)
+	}
+	if cs.to, err = parseCollatePageNr(toStr, pageCount); err != nil {
+		return nil, errors.Errorf("pdfcpu: Collate: invalid page range: %s", s)
+	}
+
+	if cs.from < 1 || cs.from > pageCount || cs.to < 1 || cs.to > pageCount {
+		return nil, errors.Errorf("pdfcpu: Collate: page range out of bounds: %s", s)
+	}
+
+	return cs, nil
+}
+
+func expandCollatePattern(pattern string, docs int) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(pattern)) {
+	case CollateZip:
+		ss := make([]string, docs)
+		for i := range ss {
+			ss[i] = strconv.Itoa(i + 1)
+		}
+		return strings.Join(ss, ","), nil
+	case CollateDuplex:
+		if docs != 2 {
+			return "", errors.New("pdfcpu: Collate: duplex needs 2 documents")
+		}
+		return "1,2:n-1", nil
+	}
+	return pattern, nil
+}
+
+// CollateOrder returns the resulting page sequence for documents with pageCounts collated by pattern.
+// Each page is identified by its page number within the concatenation of all documents.
+//
+// A pattern is a comma separated list of page streams <doc>[:<from>-<to>]
+// where doc is a 1-based document number and n denotes the last page of a document.
+// Descending ranges like 2:n-1 are allowed.
+// Pages are taken from the streams in turn until all streams are exhausted
+// and every page must be covered exactly once.
+//
+//	1,2:n-1   classic duplex scan merge of front sides with reversed back sides
+//	zip       one page of each document in turn
+//	duplex    same as 1,2:n-1
+func CollateOrder(pageCounts []int, pattern string) ([]int, error) {
+	pattern, err := expandCollatePattern(pattern, len(pageCounts))
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make([]int, len(pageCounts))
+	total := 0
+	for i, c := range pageCounts {
+		offsets[i] = total
+		total += c
+	}
+
+	var streams [][]int
+	for _, s := range strings.Split(pattern, ",") {
+		cs, err := parseCollateStream(s, pageCounts)
+		if err != nil {
+			return nil, err
+		}
+		pp := cs.pages()
+		for i := range pp {
+			pp[i] += offsets[cs.doc-1]
+		}
+		streams = append(streams, pp)
+	}
+
+	var order []int
+	seen := make([]bool, total+1)
+
+	for more := true; more; {
+		more = false
+		for i, pp := range streams {
+			if len(pp) == 0 {
+				continue
+			}
+			p := pp[0]
+			if seen[p] {
+				return nil, errors.Errorf("pdfcpu: Collate: page %d covered more than once", p)
+			}
+			seen[p] = true
+			order = append(order, p)
+			streams[i] = pp[1:]
+			more = true
+		}
+	}
+
+	if len(order) != total {
+		return nil, errors.Errorf("pdfcpu: Collate: pattern covers %d of %d pages", len(order), total)
+	}
+
+	return order, nil
+}
+
+// Collate merges ctxs into ctxs[0] and arranges the resulting pages according to pattern (see CollateOrder).
+func Collate(ctxs []*model.Context, pattern string) (*model.Context, error) {
+	if len(ctxs) == 0 {
+		return nil, errors.New("pdfcpu: Collate: missing documents")
+	}
+
+	pageCounts := make([]int, len(ctxs))
+	for i, ctx := range ctxs {
+		pageCounts[i] = ctx.PageCount
+	}
+
+	order, err := CollateOrder(pageCounts, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxDest := ctxs[0]
+	ctxDest.Configuration.CreateBookmarks = false
+
+	if len(ctxs) > 1 {
+		if err := ctxDest.RemoveSignatures(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ctxSrc := range ctxs[1:] {
+		if ctxDest.XRefTable.Version() < model.V20 && ctxSrc.XRefTable.Version() == model.V20 {
+			return nil, ErrUnsupportedVersion
+		}
+		if err := ctxSrc.RemoveSignatures(); err != nil {
+			return nil, err
+		}
+		if err := MergeXRefTables("", ctxSrc, ctxDest, false, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ReorderPages(ctxDest, order); err != nil {
+		return nil, err
+	}
+
+	return ctxDest, nil
+}
@@ -0,0 +1,1026 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/hex"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Color conversion relies on the device color space conversions as described in 10.4 of the PDF spec.
+// ICCBased color spaces using a matrix/TRC based RGB or gray profile are converted via sRGB honoring the rendering intent,
+// all other ICCBased color spaces are converted according to their number of components.
+// Output intents are ignored.
+
+var colorConversionTargets = map[string]int{
+	model.DeviceGrayCS: 1,
+	model.DeviceRGBCS:  3,
+	model.DeviceCMYKCS: 4,
+}
+
+var renderingIntents = []string{"Perceptual", "RelativeColorimetric", "Saturation", "AbsoluteColorimetric"}
+
+type colorConverter struct {
+	ctx      *model.Context
+	target   string
+	n        int
+	intent   string
+	masks    types.IntSet        // objNrs of soft masks and stencil masks which must not be touched.
+	profiles map[int]*iccProfile // supported ICC profiles by objNr, nil for unsupported ones.
+	where    string              // the page or object being converted.
+	residual types.StringSet     // colors left unconverted.
+}
+
+// colorSource is a convertible color space.
+type colorSource struct {
+	n   int         // component count, 0 for color spaces which are not convertible.
+	icc *iccProfile // the profile of an ICCBased color space if supported.
+}
+
+type colorState struct {
+	fill, stroke colorSource // the current color spaces.
+}
+
+func rgbForComponents(comp []float64) (float64, float64, float64) {
+	switch len(comp) {
+	case 1:
+		return comp[0], comp[0], comp[0]
+	case 3:
+		return comp[0], comp[1], comp[2]
+	case 4:
+		c, m, y, k := comp[0], comp[1], comp[2], comp[3]
+		return (1 - c) * (1 - k), (1 - m) * (1 - k), (1 - y) * (1 - k)
+	}
+	return 0, 0, 0
+}
+
+func clampUnit(f float64) float64 {
+	return math.Max(0, math.Min(1, f))
+}
+
+// convertComponents converts comp into a device color space having n components.
+func convertComponents(comp []float64, n int) []float64 {
+	if len(comp) == n {
+		return comp
+	}
+
+	if len(comp) == 1 && n == 4 {
+		return []float64{0, 0, 0, 1 - clampUnit(comp[0])}
+	}
+
+	r, g, b := rgbForComponents(comp)
+	r, g, b = clampUnit(r), clampUnit(g), clampUnit(b)
+
+	switch n {
+	case 1:
+		return []float64{0.3*r + 0.59*g + 0.11*b}
+	case 3:
+		return []float64{r, g, b}
+	}
+
+	k := 1 - math.Max(r, math.Max(g, b))
+	if k == 1 {
+		return []float64{0, 0, 0, 1}
+	}
+	return []float64{(1 - r - k) / (1 - k), (1 - g - k) / (1 - k), (1 - b - k) / (1 - k), k}
+}
+
+func roundComponent(f float64) float64 {
+	return math.Round(f*10000) / 10000
+}
+
+func floatOperands(ff []float64) []types.Object {
+	oo := make([]types.Object, len(ff))
+	for i, f := range ff {
+		oo[i] = types.Float(roundComponent(f))
+	}
+	return oo
+}
+
+// components returns the component count of a color space convertible by cc or 0.
+func (cc *colorConverter) components(o types.Object) int {
+	return processComponents(cc.ctx, o)
+}
+
+// profile returns the ICC profile of an ICCBased color space or nil if o is not ICCBased or its profile is not supported.
+func (cc *colorConverter) profile(o types.Object) *iccProfile {
+	o, err := cc.ctx.Dereference(o)
+	if err != nil {
+		return nil
+	}
+
+	a, ok := o.(types.Array)
+	if !ok || len(a) < 2 || a[0] != types.Name(model.ICCBasedCS) {
+		return nil
+	}
+
+	ir, ok := a[1].(types.IndirectRef)
+	if !ok {
+		return nil
+	}
+
+	objNr := ir.ObjectNumber.Value()
+	if p, ok := cc.profiles[objNr]; ok {
+		return p
+	}
+
+	var p *iccProfile
+
+	sd, _, err := cc.ctx.DereferenceStreamDict(ir)
+	if err == nil && sd != nil {
+		if err = sd.Decode(); err == nil {
+			p, err = newICCProfile(sd.Content)
+		}
+	}
+	if err != nil && log.InfoEnabled() {
+		log.Info.Printf("convertColors: obj#%d - %v, falling back to device color conversion\n", objNr, err)
+	}
+
+	cc.profiles[objNr] = p
+
+	return p
+}
+
+// source returns color space o as source for a conversion.
+func (cc *colorConverter) source(o types.Object) colorSource {
+	n := cc.components(o)
+	if n == 0 {
+		return colorSource{}
+	}
+
+	p := cc.profile(o)
+	if p != nil && p.components() != n {
+		p = nil
+	}
+
+	return colorSource{n: n, icc: p}
+}
+
+// needsConversion returns true if colors of src need to be converted into the target color space.
+func (cc *colorConverter) needsConversion(src colorSource) bool {
+	return src.n > 0 && (src.n != cc.n || src.icc != nil)
+}
+
+// convert converts comp of src into the target color space.
+func (cc *colorConverter) convert(src colorSource, comp []float64) []float64 {
+	if src.icc != nil {
+		comp = src.icc.sRGB(comp, cc.intent == "AbsoluteColorimetric")
+	}
+	return convertComponents(comp, cc.n)
+}
+
+// residue records a color which could not be converted.
+func (cc *colorConverter) residue(format string, a ...interface{}) {
+	s := cc.where + ": " + fmt.Sprintf(format, a...)
+	if log.InfoEnabled() && !cc.residual[s] {
+		log.Info.Printf("convertColors: %s\n", s)
+	}
+	cc.residual[s] = true
+}
+
+func colorSpaceFamily(o types.Object) string {
+	switch cs := o.(type) {
+	case types.Name:
+		return cs.Value()
+	case types.Array:
+		if len(cs) > 0 {
+			if n, ok := cs[0].(types.Name); ok {
+				return n.Value()
+			}
+		}
+	}
+	return "undefined"
+}
+
+// reportColorSpace records a color space which cannot be converted unless its colors get converted elsewhere.
+func (cc *colorConverter) reportColorSpace(o types.Object) {
+	o, _ = cc.ctx.Dereference(o)
+
+	family := colorSpaceFamily(o)
+
+	switch family {
+
+	case model.IndexedCS:
+		// Indexed color spaces are converted or reported by convertIndexed.
+		return
+
+	case model.PatternCS:
+		// Colored patterns are converted or reported as objects.
+		if a, ok := o.(types.Array); !ok || len(a) < 2 {
+			return
+		}
+		family = "uncolored Pattern"
+	}
+
+	cc.residue("%s color space", family)
+}
+
+// processComponents returns the component count of a device, CIE based gray or RGB or ICCBased color space or 0.
+func processComponents(ctx *model.Context, o types.Object) int {
+	o, err := ctx.Dereference(o)
+	if err != nil || o == nil {
+		return 0
+	}
+
+	switch cs := o.(type) {
+
+	case types.Name:
+		return colorConversionTargets[cs.Value()]
+
+	case types.Array:
+		if len(cs) < 2 {
+			return 0
+		}
+		switch cs[0] {
+		case types.Name(model.CalGrayCS):
+			return 1
+		case types.Name(model.CalRGBCS):
+			return 3
+		case types.Name(model.ICCBasedCS):
+			sd, _, err := ctx.DereferenceStreamDict(cs[1])
+			if err != nil || sd == nil {
+				return 0
+			}
+			if n := sd.IntEntry("N"); n != nil && types.IntMemberOf(*n, []int{1, 3, 4}) {
+				return *n
+			}
+		}
+	}
+
+	return 0
+}
+
+// resourceColorSpace returns color space name used within content having resources resDict or nil.
+func (cc *colorConverter) resourceColorSpace(resDict types.Dict, name string) types.Object {
+	if _, ok := colorConversionTargets[name]; ok || name == model.PatternCS {
+		return types.Name(name)
+	}
+
+	if resDict == nil {
+		return nil
+	}
+
+	d, err := cc.ctx.DereferenceDict(resDict["ColorSpace"])
+	if err != nil || d == nil {
+		return nil
+	}
+
+	o, _ := d.Find(name)
+
+	return o
+}
+
+func (cc *colorConverter) convertColorOp(op *model.ContentOp, src colorSource, stroke bool) bool {
+	ff := op.Numbers()
+	if len(ff) != src.n || len(op.Operands) != src.n {
+		return false
+	}
+
+	ops := map[int][2]string{1: {"g", "G"}, 3: {"rg", "RG"}, 4: {"k", "K"}}
+
+	op.Operands = floatOperands(cc.convert(src, ff))
+	if op.Operator != "sc" && op.Operator != "SC" && op.Operator != "scn" && op.Operator != "SCN" {
+		i := 0
+		if stroke {
+			i = 1
+		}
+		op.Operator = ops[cc.n][i]
+	}
+
+	return true
+}
+
+// convertColor converts the color set by op and records operators which cannot be converted.
+func (cc *colorConverter) convertColor(op *model.ContentOp, src colorSource, stroke bool) bool {
+	if !cc.needsConversion(src) {
+		return false
+	}
+	if cc.convertColorOp(op, src, stroke) {
+		return true
+	}
+	cc.residue("malformed %s operator", op.Operator)
+	return false
+}
+
+var inlineImageColorSpaces = map[string]int{
+	"G": 1, model.DeviceGrayCS: 1,
+	"RGB": 3, model.DeviceRGBCS: 3,
+	"CMYK": 4, model.DeviceCMYKCS: 4,
+}
+
+var inlineImageTargets = map[int]string{1: "G", 3: "RGB", 4: "CMYK"}
+
+func inlineImageEntry(d types.Dict, long, short string) types.Object {
+	if o, found := d[long]; found {
+		return o
+	}
+	return d[short]
+}
+
+// convertInlineImage converts uncompressed 8 bit inline images.
+func (cc *colorConverter) convertInlineImage(op *model.ContentOp) bool {
+	if len(op.Operands) == 0 {
+		return false
+	}
+	d, ok := op.Operands[0].(types.Dict)
+	if !ok {
+		return false
+	}
+
+	if im, _ := inlineImageEntry(d, "ImageMask", "IM").(types.Boolean); im.Value() {
+		return false
+	}
+
+	cs, _ := inlineImageEntry(d, "ColorSpace", "CS").(types.Name)
+	n := inlineImageColorSpaces[cs.Value()]
+	if n == cc.n {
+		return false
+	}
+	if n == 0 {
+		cc.residue("inline image using color space %s", cs.Value())
+		return false
+	}
+
+	bpc, _ := inlineImageEntry(d, "BitsPerComponent", "BPC").(types.Integer)
+	w, _ := inlineImageEntry(d, "Width", "W").(types.Integer)
+	h, _ := inlineImageEntry(d, "Height", "H").(types.Integer)
+	pixels := w.Value() * h.Value()
+	if inlineImageEntry(d, "Filter", "F") != nil || inlineImageEntry(d, "Decode", "D") != nil ||
+		bpc.Value() != 8 || pixels <= 0 || len(op.Data) < pixels*n {
+		cc.residue("unsupported inline image")
+		return false
+	}
+
+	bb := make([]byte, 0, pixels*cc.n)
+	comp := make([]float64, n)
+	for i := 0; i < pixels; i++ {
+		for j := 0; j < n; j++ {
+			comp[j] = float64(op.Data[i*n+j]) / 255
+		}
+		for _, f := range convertComponents(comp, cc.n) {
+			bb = append(bb, unitToByte(f))
+		}
+	}
+
+	for _, k := range []string{"ColorSpace", "CS"} {
+		delete(d, k)
+	}
+	d["CS"] = types.Name(inlineImageTargets[cc.n])
+
+	// ASCIIHex encoding prevents binary data from resembling the EI operator.
+	d["F"] = types.Name("AHx")
+	op.Data = []byte(hex.EncodeToString(bb) + ">")
+
+	return true
+}
+
+// convertContent rewrites color operators of content bb into the target color space.
+func (cc *colorConverter) convertContent(bb []byte, resDict types.Dict) ([]byte, bool, error) {
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return nil, false, err
+	}
+
+	var (
+		gs      colorState
+		stack   []colorState
+		changed bool
+	)
+
+	for i := range ops {
+		op := &ops[i]
+
+		switch op.Operator {
+
+		case "q":
+			stack = append(stack, gs)
+
+		case "Q":
+			if len(stack) > 0 {
+				gs = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+
+		case "g", "rg", "k":
+			gs.fill = colorSource{n: map[string]int{"g": 1, "rg": 3, "k": 4}[op.Operator]}
+			if cc.convertColor(op, gs.fill, false) {
+				changed = true
+			}
+
+		case "G", "RG", "K":
+			gs.stroke = colorSource{n: map[string]int{"G": 1, "RG": 3, "K": 4}[op.Operator]}
+			if cc.convertColor(op, gs.stroke, true) {
+				changed = true
+			}
+
+		case "cs", "CS":
+			o := cc.resourceColorSpace(resDict, op.Name(0))
+			src := cc.source(o)
+			if src.n == 0 {
+				cc.reportColorSpace(o)
+			}
+			if op.Operator == "cs" {
+				gs.fill = src
+			} else {
+				gs.stroke = src
+			}
+			if src.n > 0 && op.Name(0) != cc.target {
+				op.Operands = []types.Object{types.Name(cc.target)}
+				changed = true
+			}
+
+		case "sc", "scn":
+			if cc.convertColor(op, gs.fill, false) {
+				changed = true
+			}
+
+		case "SC", "SCN":
+			if cc.convertColor(op, gs.stroke, true) {
+				changed = true
+			}
+
+		case "ri":
+			if cc.intent != "" && op.Name(0) != cc.intent {
+				op.Operands = []types.Object{types.Name(cc.intent)}
+				changed = true
+			}
+
+		case "BI":
+			if cc.convertInlineImage(op) {
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return bb, false, nil
+	}
+
+	return model.ContentOpsBytes(ops), true, nil
+}
+
+func (cc *colorConverter) convertPageContent(pageNr int) error {
+	d, _, inhPAttrs, err := cc.ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+
+	bb, err := cc.ctx.PageContent(d, pageNr)
+	if err != nil {
+		if err == model.ErrNoContent {
+			return nil
+		}
+		return err
+	}
+
+	bb, changed, err := cc.convertContent(bb, inhPAttrs.Resources)
+	if err != nil || !changed {
+		return err
+	}
+
+	ir, err := newContentStream(cc.ctx, bb)
+	if err != nil {
+		return err
+	}
+
+	d.Update("Contents", *ir)
+
+	return nil
+}
+
+// convertContentStream converts a form XObject or tiling pattern.
+func (cc *colorConverter) convertContentStream(entry *model.XRefTableEntry, sd types.StreamDict) error {
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+
+	resDict, err := cc.ctx.DereferenceDict(sd.Dict["Resources"])
+	if err != nil {
+		return err
+	}
+
+	bb, changed, err := cc.convertContent(sd.Content, resDict)
+	if err != nil || !changed {
+		return err
+	}
+
+	sd.Content = bb
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	entry.Object = sd
+
+	return nil
+}
+
+// convertIndexed converts the base color space and lookup table of an Indexed color space array in place.
+func (cc *colorConverter) convertIndexed(o types.Object) (bool, error) {
+	o, err := cc.ctx.Dereference(o)
+	if err != nil {
+		return false, err
+	}
+
+	a, ok := o.(types.Array)
+	if !ok || len(a) < 4 || a[0] != types.Name(model.IndexedCS) {
+		return false, nil
+	}
+
+	src := cc.source(a[1])
+	if src.n == 0 {
+		base, _ := cc.ctx.Dereference(a[1])
+		cc.residue("Indexed color space based on %s", colorSpaceFamily(base))
+		return false, nil
+	}
+	if a[1] == types.Name(cc.target) {
+		return false, nil
+	}
+	n := src.n
+
+	hival, err := cc.ctx.DereferenceInteger(a[2])
+	if err != nil || hival == nil {
+		return false, err
+	}
+
+	lookup, err := colorLookupTable(cc.ctx.XRefTable, a[3])
+	if err != nil {
+		return false, err
+	}
+
+	bb := make([]byte, 0, (hival.Value()+1)*cc.n)
+	comp := make([]float64, n)
+	for i := 0; i <= hival.Value(); i++ {
+		for j := 0; j < n; j++ {
+			if k := i*n + j; k < len(lookup) {
+				comp[j] = float64(lookup[k]) / 255
+			}
+		}
+		for _, f := range cc.convert(src, comp) {
+			bb = append(bb, unitToByte(f))
+		}
+	}
+
+	a[1] = types.Name(cc.target)
+	a[3] = types.HexLiteral(hex.EncodeToString(bb))
+
+	return true, nil
+}
+
+func (cc *colorConverter) convertResourceColorSpaces(resDict types.Dict) error {
+	d, err := cc.ctx.DereferenceDict(resDict["ColorSpace"])
+	if err != nil || d == nil {
+		return err
+	}
+
+	for _, o := range d {
+		if _, err := cc.convertIndexed(o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// imageSamples converts img decoded from color space src into samples of the target color space.
+func (cc *colorConverter) imageSamples(img *image.NRGBA, src colorSource) []byte {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	bb := make([]byte, 0, w*h*cc.n)
+	comp := make([]float64, 3)
+	for i := 0; i < len(img.Pix); i += 4 {
+		r, g, b := img.Pix[i], img.Pix[i+1], img.Pix[i+2]
+		if cc.n == 1 && src.icc == nil {
+			if r == g && g == b {
+				bb = append(bb, r)
+				continue
+			}
+		}
+		comp[0], comp[1], comp[2] = float64(r)/255, float64(g)/255, float64(b)/255
+		ff := convertComponents(comp, cc.n)
+		if src.icc != nil {
+			// img holds the device values of the profile's data color space.
+			ff = cc.convert(src, comp[:src.n])
+		}
+		for _, f := range ff {
+			bb = append(bb, unitToByte(f))
+		}
+	}
+	return bb
+}
+
+// decodedImage returns image sd as NRGBA or nil if sd is not supported.
+func (cc *colorConverter) decodedImage(sd types.StreamDict, objNr int) (*image.NRGBA, error) {
+	filters, lastFilter, _, _ := prepareExtractImage(&sd)
+	if !types.MemberOf(lastFilter, []string{"", filter.Flate, filter.LZW, filter.RunLength, filter.DCT}) {
+		return nil, nil
+	}
+
+	if lastFilter == "" {
+		if err := sd.Load(); err != nil {
+			return nil, err
+		}
+		sd.Content = sd.Raw
+	} else if err := decodeImage(cc.ctx, &sd, filters, lastFilter, objNr); err != nil {
+		return nil, err
+	}
+
+	im, err := pdfImage(cc.ctx.XRefTable, &sd, false, objNr)
+	if err != nil {
+		return nil, err
+	}
+	if im.w <= 0 || im.h <= 0 {
+		return nil, nil
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, im.w, im.h))
+
+	if lastFilter == filter.DCT {
+		return img, composeDCT(im, img, nil)
+	}
+
+	conv, err := rgbConverterFor(cc.ctx.XRefTable, sd.Dict["ColorSpace"])
+	if err != nil || conv == nil {
+		return nil, err
+	}
+
+	ok, err := composeSamples(cc.ctx.XRefTable, im, conv, img, nil)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+func (cc *colorConverter) convertImage(entry *model.XRefTableEntry, sd types.StreamDict, objNr int) error {
+	if im := sd.BooleanEntry("ImageMask"); im != nil && *im {
+		return nil
+	}
+
+	o, found := sd.Find("ColorSpace")
+	if !found {
+		// JPX images may rely on the color space embedded in the image data.
+		cc.residue("image using its embedded color space")
+		return nil
+	}
+
+	if ok, err := cc.convertIndexed(o); ok || err != nil {
+		return err
+	}
+
+	src := cc.source(o)
+	if src.n == 0 {
+		cc.reportColorSpace(o)
+		return nil
+	}
+	if o == types.Name(cc.target) {
+		return nil
+	}
+
+	var img *image.NRGBA
+	if bpc := sd.IntEntry("BitsPerComponent"); bpc != nil {
+		var err error
+		if img, err = cc.decodedImage(sd, objNr); err != nil {
+			return err
+		}
+	}
+	if img == nil {
+		cc.residue("unsupported %s image", colorSpaceFamily(o))
+		return nil
+	}
+
+	sd1, err := cc.ctx.NewStreamDictForBuf(cc.imageSamples(img, src))
+	if err != nil {
+		return err
+	}
+
+	for k, v := range sd.Dict {
+		if !types.MemberOf(k, []string{"Filter", "DecodeParms", "Length", "ColorSpace", "BitsPerComponent", "Decode"}) {
+			sd1.Insert(k, v)
+		}
+	}
+
+	// Color key masks refer to the original color components.
+	if _, ok := sd1.Dict["Mask"].(types.Array); ok {
+		delete(sd1.Dict, "Mask")
+	}
+
+	sd1.InsertName("ColorSpace", cc.target)
+	sd1.InsertInt("BitsPerComponent", 8)
+	if cc.intent != "" {
+		sd1.InsertName("Intent", cc.intent)
+	}
+
+	if err := sd1.Encode(); err != nil {
+		return err
+	}
+
+	entry.Object = *sd1
+
+	return nil
+}
+
+// convertFunction converts the output of a shading function.
+// Only exponential interpolation functions and stitching functions thereof are supported.
+func (cc *colorConverter) convertFunction(o types.Object, src colorSource, apply bool) (bool, error) {
+	o, err := cc.ctx.Dereference(o)
+	if err != nil {
+		return false, err
+	}
+
+	d, ok := o.(types.Dict)
+	if !ok {
+		return false, nil
+	}
+
+	ft := d.IntEntry("FunctionType")
+	if ft == nil {
+		return false, nil
+	}
+
+	switch *ft {
+
+	case 2:
+		for _, k := range []string{"C0", "C1"} {
+			a, err := cc.ctx.DereferenceArray(d[k])
+			if err != nil {
+				return false, err
+			}
+			if a == nil {
+				// Defaults C0 = [0.0], C1 = [1.0] apply to a single output.
+				if src.n != 1 {
+					return false, nil
+				}
+				a = types.Array{types.Float(0)}
+				if k == "C1" {
+					a = types.Array{types.Float(1)}
+				}
+			}
+			if len(a) != src.n {
+				return false, nil
+			}
+			if apply {
+				d[k] = types.Array(floatOperands(cc.convert(src, arrayNumbers(a))))
+			}
+		}
+		return true, nil
+
+	case 3:
+		a, err := cc.ctx.DereferenceArray(d["Functions"])
+		if err != nil || a == nil {
+			return false, err
+		}
+		for _, f := range a {
+			if ok, err := cc.convertFunction(f, src, apply); !ok || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (cc *colorConverter) convertShading(o types.Object) error {
+	o, err := cc.ctx.Dereference(o)
+	if err != nil || o == nil {
+		return err
+	}
+
+	var d types.Dict
+	switch o := o.(type) {
+	case types.Dict:
+		d = o
+	case types.StreamDict:
+		d = o.Dict
+	default:
+		return nil
+	}
+
+	st := d.IntEntry("ShadingType")
+	if st == nil {
+		return nil
+	}
+
+	cs := d["ColorSpace"]
+	src := cc.source(cs)
+	if src.n == 0 {
+		cc.reportColorSpace(cs)
+		return nil
+	}
+	if cs == types.Name(cc.target) {
+		return nil
+	}
+
+	if *st > 3 {
+		cc.residue("mesh shading type %d", *st)
+		return nil
+	}
+
+	ok, err := cc.convertFunction(d["Function"], src, false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		cc.residue("shading type %d using an unsupported function", *st)
+		return nil
+	}
+
+	if _, err := cc.convertFunction(d["Function"], src, true); err != nil {
+		return err
+	}
+
+	if a, err := cc.ctx.DereferenceArray(d["Background"]); err == nil && len(a) == src.n {
+		d["Background"] = types.Array(floatOperands(cc.convert(src, arrayNumbers(a))))
+	}
+
+	d["ColorSpace"] = types.Name(cc.target)
+
+	return nil
+}
+
+func (cc *colorConverter) convertResourceShadings(resDict types.Dict) error {
+	d, err := cc.ctx.DereferenceDict(resDict["Shading"])
+	if err != nil || d == nil {
+		return err
+	}
+
+	for _, o := range d {
+		if err := cc.convertShading(o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cc *colorConverter) collectMasks() {
+	for _, entry := range cc.ctx.Table {
+		if entry == nil || entry.Free {
+			continue
+		}
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok {
+			continue
+		}
+		for _, k := range []string{"SMask", "Mask"} {
+			if ir, ok := sd.Dict[k].(types.IndirectRef); ok {
+				cc.masks[ir.ObjectNumber.Value()] = true
+			}
+		}
+	}
+}
+
+func (cc *colorConverter) convertObject(objNr int, entry *model.XRefTableEntry) error {
+	var d types.Dict
+
+	switch o := entry.Object.(type) {
+
+	case types.Dict:
+		d = o
+
+	case types.StreamDict:
+		d = o.Dict
+		if cc.masks[objNr] {
+			return nil
+		}
+		if st := o.Subtype(); st != nil && *st == "Image" {
+			return cc.convertImage(entry, o, objNr)
+		}
+		if st := o.Subtype(); (st != nil && *st == "Form") || o.IntEntry("PatternType") != nil {
+			if err := cc.convertContentStream(entry, o); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return nil
+	}
+
+	if ri, ok := d["RI"].(types.Name); ok && cc.intent != "" && ri.Value() != cc.intent {
+		// d is an extended graphics state parameter dict.
+		d["RI"] = types.Name(cc.intent)
+	}
+
+	if err := cc.convertShading(d); err != nil {
+		return err
+	}
+
+	if pt := d.IntEntry("PatternType"); pt != nil && *pt == 2 {
+		if err := cc.convertShading(d["Shading"]); err != nil {
+			return err
+		}
+	}
+
+	resDicts := []types.Dict{}
+	if _, ok := d["ColorSpace"].(types.Dict); ok {
+		// d is a resource dict.
+		resDicts = append(resDicts, d)
+	}
+	if _, ok := d["Shading"].(types.Dict); ok && d.IntEntry("PatternType") == nil {
+		resDicts = append(resDicts, d)
+	}
+	if res, ok := d["Resources"].(types.Dict); ok {
+		resDicts = append(resDicts, res)
+	}
+
+	for _, res := range resDicts {
+		if err := cc.convertResourceColorSpaces(res); err != nil {
+			return err
+		}
+		if err := cc.convertResourceShadings(res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newColorConverter(ctx *model.Context, target, intent string) (*colorConverter, error) {
+	n, ok := colorConversionTargets[target]
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: unsupported target color space: %s", target)
+	}
+
+	if intent != "" && !types.MemberOf(intent, renderingIntents) {
+		return nil, errors.Errorf("pdfcpu: unsupported rendering intent: %s", intent)
+	}
+
+	return &colorConverter{
+		ctx:      ctx,
+		target:   target,
+		n:        n,
+		intent:   intent,
+		masks:    types.IntSet{},
+		profiles: map[int]*iccProfile{},
+		residual: types.StringSet{},
+	}, nil
+}
+
+// ConvertColors converts all colors used by content streams, images, shadings and Indexed color spaces to target,
+// one of DeviceGray, DeviceRGB or DeviceCMYK, using the optional rendering intent.
+// ICCBased color spaces using matrix/TRC based RGB or gray profiles are converted via sRGB,
+// all other colors are converted using the device color space conversions of 10.4 of the PDF spec.
+// Separation, DeviceN and Lab color spaces, uncolored patterns, mesh shadings and unsupported images are left untouched
+// and returned as list of residual colors, the result uses colors outside of target unless this list is empty.
+func ConvertColors(ctx *model.Context, target, intent string) ([]string, error) {
+	cc, err := newColorConverter(ctx, target, intent)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.collectMasks()
+
+	objNrs := make([]int, 0, len(ctx.Table))
+	for objNr, entry := range ctx.Table {
+		if entry != nil && !entry.Free && entry.Object != nil {
+			objNrs = append(objNrs, objNr)
+		}
+	}
+	sort.Ints(objNrs)
+
+	// Convert page content first since new content streams are already in target color space.
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		cc.where = fmt.Sprintf("page %d", pageNr)
+		if err := cc.convertPageContent(pageNr); err != nil {
+			return nil, err
+		}
+	}
+
+	// Indexed color spaces are converted in place, content conversion does not depend on them.
+	for _, objNr := range objNrs {
+		cc.where = fmt.Sprintf("obj#%d", objNr)
+		if err := cc.convertObject(objNr, ctx.Table[objNr]); err != nil {
+			return nil, err
+		}
+	}
+
+	ss := make([]string, 0, len(cc.residual))
+	for s := range cc.residual {
+		ss = append(ss, s)
+	}
+	sort.Strings(ss)
+
+	return ss, nil
+}
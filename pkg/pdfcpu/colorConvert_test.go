@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// testICCProfile returns a matrix/TRC based RGB profile using the sRGB primaries, linear TRCs and media white point w.
+func testICCProfile(w [3]float64) []byte {
+	s15Fixed16 := func(f float64) []byte {
+		return binary.BigEndian.AppendUint32(nil, uint32(int32(math.Round(f*0x10000))))
+	}
+	xyzType := func(x, y, z float64) []byte {
+		bb := append([]byte("XYZ "), 0, 0, 0, 0)
+		bb = append(bb, s15Fixed16(x)...)
+		bb = append(bb, s15Fixed16(y)...)
+		return append(bb, s15Fixed16(z)...)
+	}
+	linearCurve := append([]byte("curv"), 0, 0, 0, 0, 0, 0, 0, 0)
+
+	tags := []struct {
+		sig string
+		bb  []byte
+	}{
+		{"rXYZ", xyzType(0.4360747, 0.2225045, 0.0139322)},
+		{"gXYZ", xyzType(0.3850649, 0.7168786, 0.0971045)},
+		{"bXYZ", xyzType(0.1430804, 0.0606169, 0.7141733)},
+		{"rTRC", linearCurve},
+		{"gTRC", linearCurve},
+		{"bTRC", linearCurve},
+		{"wtpt", xyzType(w[0], w[1], w[2])},
+	}
+
+	header := make([]byte, 128)
+	copy(header[12:], "mntr")
+	copy(header[16:], "RGB ")
+	copy(header[20:], "XYZ ")
+	copy(header[36:], "acsp")
+	copy(header[68:], xyzType(d50[0], d50[1], d50[2])[8:])
+
+	off := 128 + 4 + 12*len(tags)
+	table := binary.BigEndian.AppendUint32(nil, uint32(len(tags)))
+	var data []byte
+	for _, t := range tags {
+		table = append(table, t.sig...)
+		table = binary.BigEndian.AppendUint32(table, uint32(off+len(data)))
+		table = binary.BigEndian.AppendUint32(table, uint32(len(t.bb)))
+		data = append(data, t.bb...)
+	}
+
+	bb := append(append(header, table...), data...)
+	binary.BigEndian.PutUint32(bb, uint32(len(bb)))
+
+	return bb
+}
+
+func equalComponents(ff, want []float64) bool {
+	if len(ff) != len(want) {
+		return false
+	}
+	for i := range ff {
+		if math.Abs(ff[i]-want[i]) > 0.005 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestICCProfile(t *testing.T) {
+	bb := testICCProfile(d50)
+
+	p, err := newICCProfile(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.components() != 3 {
+		t.Fatalf("want 3 components, got %d", p.components())
+	}
+
+	for _, tt := range []struct {
+		comp, want []float64
+	}{
+		{[]float64{1, 1, 1}, []float64{1, 1, 1}},
+		{[]float64{1, 0, 0}, []float64{1, 0, 0}},
+		{[]float64{0, 0, 1}, []float64{0, 0, 1}},
+		// Linear mid gray is encoded using the sRGB TRC.
+		{[]float64{0.5, 0.5, 0.5}, []float64{0.7354, 0.7354, 0.7354}},
+	} {
+		if got := p.sRGB(tt.comp, false); !equalComponents(got, tt.want) {
+			t.Errorf("%v: want %v, got %v", tt.comp, tt.want, got)
+		}
+	}
+
+	if _, err := newICCProfile(bb[:200]); err == nil {
+		t.Fatal("missing error for truncated profile")
+	}
+}
+
+func TestConvertColorsICCBased(t *testing.T) {
+	// A media white point darker than the PCS illuminant only affects the absolute colorimetric intent.
+	w := [3]float64{d50[0] * 0.9, d50[1] * 0.9, d50[2] * 0.9}
+
+	for _, tt := range []struct {
+		target, intent string
+		content        string
+		want           []float64
+	}{
+		// Naive conversion would yield 0.5.
+		{model.DeviceGrayCS, "", "/CS0 cs 0.5 0.5 0.5 sc", []float64{0.7354}},
+		// Colors already having the target component count are converted too.
+		{model.DeviceRGBCS, "Perceptual", "/CS0 cs 0.5 0.5 0.5 sc", []float64{0.7354, 0.7354, 0.7354}},
+		{model.DeviceRGBCS, "RelativeColorimetric", "/CS0 cs 1 1 1 sc", []float64{1, 1, 1}},
+		{model.DeviceRGBCS, "AbsoluteColorimetric", "/CS0 cs 1 1 1 sc", []float64{0.9546, 0.9546, 0.9546}},
+	} {
+		ctx, err := CreateContextWithXRefTable(model.NewDefaultConfiguration(), types.PaperSize["A4"])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sd, err := ctx.NewStreamDictForBuf(testICCProfile(w))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sd.InsertInt("N", 3)
+		if err := sd.Encode(); err != nil {
+			t.Fatal(err)
+		}
+		ir, err := ctx.IndRefForNewObject(*sd)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resDict := types.Dict{"ColorSpace": types.Dict{"CS0": types.Array{types.Name(model.ICCBasedCS), *ir}}}
+
+		cc, err := newColorConverter(ctx, tt.target, tt.intent)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bb, changed, err := cc.convertContent([]byte(tt.content), resDict)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !changed {
+			t.Fatalf("%s %s: content unchanged", tt.target, tt.intent)
+		}
+
+		ops, err := model.ParseContentOps(string(bb))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ops) != 2 || ops[0].Name(0) != tt.target {
+			t.Fatalf("%s %s: unexpected content: %s", tt.target, tt.intent, bb)
+		}
+		if got := ops[1].Numbers(); !equalComponents(got, tt.want) {
+			t.Errorf("%s %s: want %v, got %v", tt.target, tt.intent, tt.want, got)
+		}
+		if len(cc.residual) > 0 {
+			t.Errorf("%s %s: unexpected residual colors: %v", tt.target, tt.intent, cc.residual)
+		}
+	}
+
+	if _, err := newColorConverter(nil, model.DeviceRGBCS, "Vivid"); err == nil {
+		t.Fatal("missing error for unsupported rendering intent")
+	}
+}
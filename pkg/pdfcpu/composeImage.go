@@ -0,0 +1,586 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/gob"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// rgbConverter maps the decoded color components of a pixel to RGB.
+type rgbConverter struct {
+	n      int           // number of color components per pixel
+	hival  int           // max lookup table index for Indexed color spaces
+	lookup []byte        // color lookup table for Indexed color spaces
+	base   *rgbConverter // base color space for Indexed color spaces
+}
+
+func unitToByte(f float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, f)) * 255))
+}
+
+func (c *rgbConverter) indexed() bool {
+	return c.base != nil
+}
+
+func (c *rgbConverter) rgb(comp []float64) (uint8, uint8, uint8) {
+	if c.indexed() {
+		ind := int(math.Round(comp[0]))
+		if ind < 0 {
+			ind = 0
+		}
+		if ind > c.hival {
+			ind = c.hival
+		}
+		n := c.base.n
+		baseComp := make([]float64, n)
+		for i := 0; i < n; i++ {
+			if j := ind*n + i; j < len(c.lookup) {
+				baseComp[i] = float64(c.lookup[j]) / 255
+			}
+		}
+		return c.base.rgb(baseComp)
+	}
+
+	switch c.n {
+	case 1:
+		v := unitToByte(comp[0])
+		return v, v, v
+	case 3:
+		return unitToByte(comp[0]), unitToByte(comp[1]), unitToByte(comp[2])
+	}
+
+	return color.CMYKToRGB(unitToByte(comp[0]), unitToByte(comp[1]), unitToByte(comp[2]), unitToByte(comp[3]))
+}
+
+func rgbConverterForComponents(n int) *rgbConverter {
+	if !types.IntMemberOf(n, []int{1, 3, 4}) {
+		return nil
+	}
+	return &rgbConverter{n: n}
+}
+
+// rgbConverterFor returns a converter for color space o or nil if o is not supported.
+func rgbConverterFor(xRefTable *model.XRefTable, o types.Object) (*rgbConverter, error) {
+	o, err := xRefTable.Dereference(o)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cs := o.(type) {
+
+	case types.Name:
+		switch cs {
+		case model.DeviceGrayCS:
+			return rgbConverterForComponents(1), nil
+		case model.DeviceRGBCS:
+			return rgbConverterForComponents(3), nil
+		case model.DeviceCMYKCS:
+			return rgbConverterForComponents(4), nil
+		}
+
+	case types.Array:
+		if len(cs) < 2 {
+			return nil, nil
+		}
+		csn, _ := cs[0].(types.Name)
+
+		switch csn {
+
+		case model.CalGrayCS:
+			return rgbConverterForComponents(1), nil
+
+		case model.CalRGBCS:
+			return rgbConverterForComponents(3), nil
+
+		case model.ICCBasedCS:
+			// ICC profiles are not supported.
+			// We fall back to the alternate color space or the appropriate device color space for N.
+			iccProfileStream, _, err := xRefTable.DereferenceStreamDict(cs[1])
+			if err != nil || iccProfileStream == nil {
+				return nil, err
+			}
+			if alt, found := iccProfileStream.Find("Alternate"); found {
+				if c, err := rgbConverterFor(xRefTable, alt); err != nil || c != nil {
+					return c, err
+				}
+			}
+			if n := iccProfileStream.IntEntry("N"); n != nil {
+				return rgbConverterForComponents(*n), nil
+			}
+
+		case model.IndexedCS:
+			if len(cs) < 4 {
+				return nil, nil
+			}
+			base, err := rgbConverterFor(xRefTable, cs[1])
+			if err != nil || base == nil || base.indexed() {
+				return nil, err
+			}
+			hival, err := xRefTable.DereferenceInteger(cs[2])
+			if err != nil || hival == nil {
+				return nil, err
+			}
+			lookup, err := colorLookupTable(xRefTable, cs[3])
+			if err != nil {
+				return nil, err
+			}
+			return &rgbConverter{n: 1, hival: hival.Value(), lookup: lookup, base: base}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// iccBased returns true if color space o or the base of an Indexed color space o is ICC based.
+func iccBased(xRefTable *model.XRefTable, o types.Object) bool {
+	o, err := xRefTable.Dereference(o)
+	if err != nil {
+		return false
+	}
+	cs, ok := o.(types.Array)
+	if !ok || len(cs) < 2 {
+		return false
+	}
+	csn, _ := cs[0].(types.Name)
+	switch csn {
+	case model.ICCBasedCS:
+		return true
+	case model.IndexedCS:
+		return iccBased(xRefTable, cs[1])
+	}
+	return false
+}
+
+// sampleAt returns sample i of row y for bpc deep samples with rows starting at byte boundaries.
+func sampleAt(b []byte, bpc, rowBytes, y, i int) int {
+	off := y*rowBytes*8 + i*bpc
+	j := off / 8
+	if bpc == 16 {
+		if j+1 >= len(b) {
+			return 0
+		}
+		return int(b[j])<<8 | int(b[j+1])
+	}
+	if j >= len(b) {
+		return 0
+	}
+	if bpc == 8 {
+		return int(b[j])
+	}
+	shift := 8 - bpc - off%8
+	return int(b[j]>>uint(shift)) & maxValForBits(bpc)
+}
+
+// decodeSample maps sample v into the range r.
+func decodeSample(v, bpc int, r colValRange) float64 {
+	return r.min + float64(v)*(r.max-r.min)/float64(maxValForBits(bpc))
+}
+
+func maskSize(xRefTable *model.XRefTable, sd *types.StreamDict, objNr int) (int, int, error) {
+	var dim [2]int
+	for i, k := range []string{"Width", "Height"} {
+		o, found := sd.Find(k)
+		if !found {
+			return 0, 0, errors.Errorf("pdfcpu: missing mask %s obj#%d", k, objNr)
+		}
+		v, err := xRefTable.DereferenceInteger(o)
+		if err != nil || v == nil {
+			return 0, 0, err
+		}
+		dim[i] = v.Value()
+	}
+	return dim[0], dim[1], nil
+}
+
+// maskAlpha returns the decoded samples of a (soft) mask image as alpha values scaled to w x h.
+// For stencil masks a sample decoding to 1 marks a pixel as masked out.
+func maskAlpha(xRefTable *model.XRefTable, o types.Object, w, h int, stencil bool, objNr int) ([]uint8, error) {
+	sd, _, err := xRefTable.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+
+	mw, mh, err := maskSize(xRefTable, sd, objNr)
+	if err != nil || mw <= 0 || mh <= 0 {
+		return nil, err
+	}
+
+	var gray func(x, y int) float64
+
+	fpl := sd.FilterPipeline
+	if len(fpl) > 0 && fpl[len(fpl)-1].Name == filter.DCT {
+		if err := sd.Decode(); err != nil {
+			return nil, err
+		}
+		img, err := jpeg.Decode(bytes.NewReader(sd.Content))
+		if err != nil {
+			return nil, err
+		}
+		gray = func(x, y int) float64 {
+			return float64(color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y) / 255
+		}
+	} else {
+		b, err := streamBytes(sd)
+		if err != nil || b == nil {
+			return nil, err
+		}
+
+		bpc := 1
+		if !stencil {
+			i := sd.IntEntry("BitsPerComponent")
+			if i == nil || !types.IntMemberOf(*i, []int{1, 2, 4, 8, 16}) {
+				if log.InfoEnabled() {
+					log.Info.Printf("maskAlpha: obj#%d - ignoring mask with invalid bpc\n", objNr)
+				}
+				return nil, nil
+			}
+			bpc = *i
+		}
+
+		rowBytes := (mw*bpc + 7) / 8
+		if len(b) < rowBytes*mh {
+			if log.InfoEnabled() {
+				log.Info.Printf("maskAlpha: obj#%d - ignoring corrupt mask\n", objNr)
+			}
+			return nil, nil
+		}
+
+		cvr := colValRange{0, 1}
+		if d := decodeArr(sd.ArrayEntry("Decode")); len(d) > 0 {
+			cvr = d[0]
+		}
+
+		gray = func(x, y int) float64 {
+			return decodeSample(sampleAt(b, bpc, rowBytes, y, x), bpc, cvr)
+		}
+	}
+
+	alpha := make([]uint8, w*h)
+	for y := 0; y < h; y++ {
+		my := y * mh / h
+		for x := 0; x < w; x++ {
+			v := gray(x*mw/w, my)
+			if stencil {
+				v = 1 - v
+			}
+			alpha[y*w+x] = unitToByte(v)
+		}
+	}
+
+	return alpha, nil
+}
+
+// colorKeyMatches returns true if all raw color components of a pixel lie within the color key mask ranges.
+func colorKeyMatches(raw []int, key []int) bool {
+	for i, v := range raw {
+		if 2*i+1 >= len(key) || v < key[2*i] || v > key[2*i+1] {
+			return false
+		}
+	}
+	return true
+}
+
+func colorKeyMask(xRefTable *model.XRefTable, a types.Array) ([]int, error) {
+	key := make([]int, len(a))
+	for i, o := range a {
+		n, err := xRefTable.DereferenceNumber(o)
+		if err != nil {
+			return nil, err
+		}
+		key[i] = int(n)
+	}
+	return key, nil
+}
+
+// imageAlpha returns the alpha channel for an image defined by either /SMask or an explicit /Mask stream.
+func imageAlpha(xRefTable *model.XRefTable, sd *types.StreamDict, w, h, objNr int) ([]uint8, error) {
+	if o, found := sd.Find("SMask"); found {
+		return maskAlpha(xRefTable, o, w, h, false, objNr)
+	}
+
+	o, found := sd.Find("Mask")
+	if !found {
+		return nil, nil
+	}
+	if o1, _ := xRefTable.Dereference(o); o1 != nil {
+		if _, ok := o1.(types.StreamDict); ok {
+			return maskAlpha(xRefTable, o, w, h, true, objNr)
+		}
+	}
+
+	// Color key masking is applied while composing the image samples.
+	return nil, nil
+}
+
+// matte undoes the preblending of image data with the /Matte color of its soft mask.
+type matte struct {
+	comp  []float64 // matte color components in the image color space
+	alpha []uint8   // soft mask alpha per pixel
+}
+
+// matteFor returns the matte for an image with n color components or nil if its soft mask has no /Matte.
+func matteFor(xRefTable *model.XRefTable, sd *types.StreamDict, n int, alpha []uint8, objNr int) (*matte, error) {
+	o, found := sd.Find("SMask")
+	if !found || alpha == nil {
+		return nil, nil
+	}
+
+	smask, _, err := xRefTable.DereferenceStreamDict(o)
+	if err != nil || smask == nil {
+		return nil, err
+	}
+
+	a, err := xRefTable.DereferenceArray(smask.Dict["Matte"])
+	if err != nil || a == nil {
+		return nil, err
+	}
+	if len(a) != n {
+		if log.InfoEnabled() {
+			log.Info.Printf("matteFor: obj#%d - ignoring corrupt Matte\n", objNr)
+		}
+		return nil, nil
+	}
+
+	comp := make([]float64, n)
+	for i, o := range a {
+		if comp[i], err = xRefTable.DereferenceNumber(o); err != nil {
+			return nil, err
+		}
+	}
+
+	return &matte{comp: comp, alpha: alpha}, nil
+}
+
+// unpremultiply recovers the original color components of pixel i, see 11.6.5.3 Soft-Mask Images.
+func (m *matte) unpremultiply(i int, comp []float64) {
+	if m == nil || i >= len(m.alpha) || m.alpha[i] == 0 {
+		return
+	}
+	a := float64(m.alpha[i]) / 255
+	for k := range comp {
+		comp[k] = m.comp[k] + (comp[k]-m.comp[k])/a
+	}
+}
+
+func byteToUnit(b uint8) float64 {
+	return float64(b) / 255
+}
+
+func composeDCT(im *PDFImage, img *image.NRGBA, m *matte) error {
+	if im.sd.CSComponents == 4 {
+		var cmyk image.CMYK
+		if err := gob.NewDecoder(bytes.NewReader(im.sd.Content)).Decode(&cmyk); err != nil {
+			return err
+		}
+		for y := 0; y < im.h; y++ {
+			for x := 0; x < im.w; x++ {
+				a := cmyk.At(x, y).(color.CMYK)
+				c, mag, yel, k := decodeCMYK(255-a.C, 255-a.M, 255-a.Y, 255-a.K, im.decode)
+				if m != nil {
+					comp := []float64{byteToUnit(c), byteToUnit(mag), byteToUnit(yel), byteToUnit(k)}
+					m.unpremultiply(y*im.w+x, comp)
+					c, mag, yel, k = unitToByte(comp[0]), unitToByte(comp[1]), unitToByte(comp[2]), unitToByte(comp[3])
+				}
+				r, g, b := color.CMYKToRGB(c, mag, yel, k)
+				img.Pix[img.PixOffset(x, y)+0] = r
+				img.Pix[img.PixOffset(x, y)+1] = g
+				img.Pix[img.PixOffset(x, y)+2] = b
+			}
+		}
+		return nil
+	}
+
+	src, err := jpeg.Decode(bytes.NewReader(im.sd.Content))
+	if err != nil {
+		return err
+	}
+
+	if m != nil && len(m.comp) == 1 {
+		// Gray maps linearly to RGB.
+		m = &matte{comp: []float64{m.comp[0], m.comp[0], m.comp[0]}, alpha: m.alpha}
+	}
+
+	for y := 0; y < im.h; y++ {
+		for x := 0; x < im.w; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+			i := img.PixOffset(x, y)
+			if m != nil {
+				comp := []float64{byteToUnit(c.R), byteToUnit(c.G), byteToUnit(c.B)}
+				m.unpremultiply(y*im.w+x, comp)
+				c.R, c.G, c.B = unitToByte(comp[0]), unitToByte(comp[1]), unitToByte(comp[2])
+			}
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2] = c.R, c.G, c.B
+		}
+	}
+
+	return nil
+}
+
+func composeSamples(xRefTable *model.XRefTable, im *PDFImage, conv *rgbConverter, img *image.NRGBA, m *matte) (bool, error) {
+	if !types.IntMemberOf(im.bpc, []int{1, 2, 4, 8, 16}) {
+		return false, nil
+	}
+
+	b := im.sd.Content
+	n := conv.n
+	rowBytes := (im.w*n*im.bpc + 7) / 8
+	if len(b) < rowBytes*im.h {
+		return false, errors.Errorf("pdfcpu: composeImage: objNr=%d corrupt image object\n", im.objNr)
+	}
+
+	decode := make([]colValRange, n)
+	for i := range decode {
+		decode[i] = colValRange{0, 1}
+		if conv.indexed() {
+			decode[i] = colValRange{0, float64(maxValForBits(im.bpc))}
+		}
+		if i < len(im.decode) {
+			decode[i] = im.decode[i]
+		}
+	}
+
+	var key []int
+	if a, err := xRefTable.DereferenceArray(im.sd.Dict["Mask"]); err == nil && len(a) > 0 {
+		if key, err = colorKeyMask(xRefTable, a); err != nil {
+			return false, err
+		}
+	}
+
+	raw := make([]int, n)
+	comp := make([]float64, n)
+
+	for y := 0; y < im.h; y++ {
+		for x := 0; x < im.w; x++ {
+			for k := 0; k < n; k++ {
+				raw[k] = sampleAt(b, im.bpc, rowBytes, y, x*n+k)
+				comp[k] = decodeSample(raw[k], im.bpc, decode[k])
+			}
+			m.unpremultiply(y*im.w+x, comp)
+			i := img.PixOffset(x, y)
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2] = conv.rgb(comp)
+			if key != nil && colorKeyMatches(raw, key) {
+				img.Pix[i+3] = 0
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// ComposeImage returns a reader for an RGBA PNG of an image with its /SMask or /Mask applied as alpha channel.
+// Image data preblended with the /Matte color of its soft mask gets un-premultiplied.
+// sd is expected to be decoded as done by ExtractImage.
+// If the image format or color space is not supported a nil reader is returned.
+//
+// The conversion to RGB is not color managed:
+// ICC profiles are ignored and colors of ICCBased images are approximated using the alternate color space.
+func ComposeImage(xRefTable *model.XRefTable, sd *types.StreamDict, thumb bool, objNr int) (io.Reader, string, error) {
+	if bpc := sd.IntEntry("BitsPerComponent"); bpc == nil {
+		return nil, "", nil
+	}
+
+	var lastFilter string
+	if fpl := sd.FilterPipeline; len(fpl) > 0 {
+		lastFilter = fpl[len(fpl)-1].Name
+	}
+	if lastFilter == filter.JPX {
+		return nil, "", nil
+	}
+
+	im, err := pdfImage(xRefTable, sd, thumb, objNr)
+	if err != nil {
+		return nil, "", err
+	}
+	if im.imageMask || im.w <= 0 || im.h <= 0 {
+		return nil, "", nil
+	}
+
+	if iccBased(xRefTable, sd.Dict["ColorSpace"]) && log.InfoEnabled() {
+		log.Info.Printf("ComposeImage: objNr=%d, ignoring ICC profile, colors are approximated\n", objNr)
+	}
+
+	alpha, err := imageAlpha(xRefTable, sd, im.w, im.h, objNr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, im.w, im.h))
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 255
+	}
+
+	if lastFilter == filter.DCT {
+		m, err := matteFor(xRefTable, sd, im.sd.CSComponents, alpha, objNr)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := composeDCT(im, img, m); err != nil {
+			return nil, "", err
+		}
+	} else {
+		conv, err := rgbConverterFor(xRefTable, sd.Dict["ColorSpace"])
+		if err != nil {
+			return nil, "", err
+		}
+		if conv == nil {
+			if log.InfoEnabled() {
+				log.Info.Printf("ComposeImage: objNr=%d, unsupported colorspace\n", objNr)
+			}
+			return nil, "", nil
+		}
+		m, err := matteFor(xRefTable, sd, conv.n, alpha, objNr)
+		if err != nil {
+			return nil, "", err
+		}
+		if m != nil && conv.indexed() {
+			if log.InfoEnabled() {
+				log.Info.Printf("ComposeImage: objNr=%d, ignoring Matte for indexed colorspace\n", objNr)
+			}
+			m = nil
+		}
+		ok, err := composeSamples(xRefTable, im, conv, img, m)
+		if err != nil || !ok {
+			return nil, "", err
+		}
+	}
+
+	for i, a := range alpha {
+		if j := 4*i + 3; img.Pix[j] > a {
+			img.Pix[j] = a
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+
+	return &buf, "png", nil
+}
@@ -83,7 +83,7 @@ func addPageResources(xRefTable *model.XRefTable, d types.Dict, p model.Page, fo
 		imgRes[img.Res.ID] = *img.Res.IndRef
 	}
 
-	if len(fontRes) > 0 || len(imgRes) > 0 {
+	if len(fontRes) > 0 || len(imgRes) > 0 || len(p.Patterns) > 0 {
 		resDict := types.Dict{}
 		if len(fontRes) > 0 {
 			resDict["Font"] = fontRes
@@ -91,6 +91,9 @@ func addPageResources(xRefTable *model.XRefTable, d types.Dict, p model.Page, fo
 		if len(imgRes) > 0 {
 			resDict["XObject"] = imgRes
 		}
+		if len(p.Patterns) > 0 {
+			resDict["Pattern"] = p.Patterns
+		}
 		d["Resources"] = resDict
 	}
 
@@ -128,7 +131,18 @@ func updatePageResources(xRefTable *model.XRefTable, d, resDict types.Dict, p mo
 		resDict["XObject"] = imgRes
 	}
 
-	if len(p.Fm) > 0 || len(p.Im) > 0 {
+	if len(p.Patterns) > 0 {
+		patRes, ok := resDict["Pattern"].(types.Dict)
+		if !ok {
+			patRes = types.Dict{}
+		}
+		for id, o := range p.Patterns {
+			patRes[id] = o
+		}
+		resDict["Pattern"] = patRes
+	}
+
+	if len(p.Fm) > 0 || len(p.Im) > 0 || len(p.Patterns) > 0 {
 		d["Resources"] = resDict
 	}
 
@@ -33,14 +33,16 @@ import (
 	"io"
 	"math/big"
 	"strconv"
-	"time"
+	"unicode"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 
+	"golang.org/x/text/runes"
 	"golang.org/x/text/secure/precis"
+	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
 )
 
@@ -124,13 +126,67 @@ var (
 		model.SETVIEWERPREFERENCES:    {0, 1},
 		model.RESETVIEWERPREFERENCES:  {0, 1},
 		model.ZOOM:                    {0, 1},
+		model.EXTRACTSVG:              {1, 0},
+		model.AUDIT:                   {0, 0},
+		model.PREFLIGHT:               {0, 0},
+		model.VISUALDIFF:              {1, 0},
+		model.IMPOSE:                  {0, 1},
+		model.REPAIR:                  {0, 0},
+		model.SETBACKGROUND:           {0, 1},
+		model.REMOVEBACKGROUND:        {0, 1},
+		model.EXTRACTPATHS:            {1, 0},
+		model.REPLACEIMAGE:            {0, 1},
+		model.CONVERTCOLORS:           {0, 1},
+		model.INKCOVERAGE:             {1, 0},
+		model.LISTCOLORANTS:           {1, 0},
+		model.REMAPCOLORANT:           {0, 1},
+		model.RENAMECOLORANT:          {0, 1},
+		model.GEOINFO:                 {1, 0},
+		model.SETGEOREFERENCE:         {0, 1},
+		model.LISTMEASUREMENTS:        {1, 0},
+		model.EXTRACT3D:               {1, 0},
+		model.EXTRACTMEDIA:            {1, 0},
+		model.ADDVIDEO:                {0, 1},
+		model.LISTJAVASCRIPT:          {1, 0},
+		model.REMOVEJAVASCRIPT:        {0, 1},
+		model.SANITIZE:                {0, 1},
+		model.SCRUBMETADATA:           {0, 1},
+		model.MANIFEST:                {1, 0},
+		model.REORDERPAGES:            {0, 1},
+		model.COLLATE:                 {0, 0},
+		model.DETECTBLANKPAGES:        {1, 0},
+		model.REMOVEBLANKPAGES:        {1, 1},
+		model.DESKEW:                  {0, 1},
+		model.ADDTEXTLAYER:            {1, 1},
+		model.LISTPIECEINFO:           {0, 0},
+		model.SETPIECEINFO:            {0, 1},
+		model.REMOVEPIECEINFO:         {0, 1},
+		model.LISTASSOCIATEDFILES:     {0, 0},
+		model.ADDASSOCIATEDFILES:      {0, 1},
+		model.REMOVEASSOCIATEDFILES:   {0, 1},
+		model.SETTABORDER:             {0, 1},
+		model.SETCALCULATIONORDER:     {0, 1},
+		model.SETSIGNATUREAPPEARANCE:  {0, 1},
+		model.ADDDOCTIMESTAMP:         {0, 1},
+		model.ADDVALIDATIONINFO:       {0, 1},
+		model.EXPORTANNOTATIONS:       {0, 1},
+		model.IMPORTANNOTATIONS:       {0, 1},
+		model.REPAIRTOUNICODE:         {0, 1},
+		model.EMBEDFONTS:              {0, 1},
+		model.SETVERSION:              {0, 1},
+		model.LISTACTIONS:             {0, 1},
+		model.SETACTIONS:              {0, 1},
+		model.REMOVEACTIONS:           {0, 1},
 	}
 
 	ErrUnknownEncryption = errors.New("pdfcpu: unknown encryption")
+
+	// ErrReencryptionRequired signals that the file encryption key depends on the change in progress.
+	ErrReencryptionRequired = errors.New("pdfcpu: change requires re-encryption (supported for AES-256 only)")
 )
 
 // NewEncryptDict creates a new EncryptDict using the standard security handler.
-func newEncryptDict(pdf20, needAES bool, keyLength int, permissions int16) types.Dict {
+func newEncryptDict(pdf20, needAES bool, keyLength int, permissions int16, plainMetadata, embeddedFilesOnly bool) types.Dict {
 	d := types.NewDict()
 
 	d.Insert("Filter", types.Name("Standard"))
@@ -157,7 +213,11 @@ func newEncryptDict(pdf20, needAES bool, keyLength int, permissions int16) types
 
 	if keyLength == 128 || keyLength == 256 {
 		d1 := types.NewDict()
-		d1.Insert("AuthEvent", types.Name("DocOpen"))
+		authEvent := "DocOpen"
+		if embeddedFilesOnly {
+			authEvent = "EFOpen"
+		}
+		d1.Insert("AuthEvent", types.Name(authEvent))
 		cfm := "V2"
 		if needAES {
 			if keyLength == 128 {
@@ -177,8 +237,17 @@ func newEncryptDict(pdf20, needAES bool, keyLength int, permissions int16) types
 		d2 := types.NewDict()
 		d2.Insert("StdCF", d1)
 		d.Insert("CF", d2)
-		d.Insert("StmF", types.Name("StdCF"))
-		d.Insert("StrF", types.Name("StdCF"))
+		if embeddedFilesOnly {
+			d.Insert("StmF", types.Name("Identity"))
+			d.Insert("StrF", types.Name("Identity"))
+			d.Insert("EFF", types.Name("StdCF"))
+		} else {
+			d.Insert("StmF", types.Name("StdCF"))
+			d.Insert("StrF", types.Name("StdCF"))
+		}
+		if plainMetadata {
+			d.Insert("EncryptMetadata", types.Boolean(false))
+		}
 	}
 
 	if keyLength == 256 {
@@ -518,10 +587,33 @@ func validateUserPasswordAES256(ctx *model.Context) (ok bool, err error) {
 	return true, nil
 }
 
+// saslPrepMapping maps non-ASCII space characters to SPACE
+// and removes characters commonly mapped to nothing, see RFC 4013 2.1, 2.2
+func saslPrepMapping() transform.Transformer {
+	return transform.Chain(
+		runes.Remove(runes.Predicate(func(r rune) bool {
+			switch {
+			case r == 0x00AD, r == 0x034F, r == 0x1806, r >= 0x180B && r <= 0x180D,
+				r >= 0x200B && r <= 0x200D, r == 0x2060, r >= 0xFE00 && r <= 0xFE0F, r == 0xFEFF:
+				return true
+			}
+			return false
+		})),
+		runes.Map(func(r rune) rune {
+			if r != ' ' && unicode.Is(unicode.Zs, r) {
+				return ' '
+			}
+			return r
+		}),
+	)
+}
+
+// processInput prepares a password for AES-256 (R 5, 6)
+// using the SASLprep profile (RFC 4013) of stringprep (RFC 3454)
+// and truncates the result to 127 bytes.
 func processInput(input string) ([]byte, error) {
-	// Create a new Precis profile for SASLprep
-	p := precis.NewIdentifier(
-		precis.BidiRule,
+	p := precis.NewFreeform(
+		precis.AdditionalMapping(saslPrepMapping),
 		precis.Norm(norm.NFKC),
 	)
 
@@ -530,7 +622,12 @@ func processInput(input string) ([]byte, error) {
 		return nil, err
 	}
 
-	return []byte(output), nil
+	bb := []byte(output)
+	if len(bb) > 127 {
+		bb = bb[:127]
+	}
+
+	return bb, nil
 }
 
 func hashRev6(input, pw, U []byte) ([]byte, int, error) {
@@ -791,6 +888,11 @@ func validatePermissions(ctx *model.Context) (bool, error) {
 		return false, nil
 	}
 
+	// Byte 8 mirrors EncryptMetadata.
+	if (p[8] == 'T') != ctx.E.Emd && ctx.XRefTable.ValidationMode == model.ValidationStrict {
+		return false, nil
+	}
+
 	b := binary.LittleEndian.Uint32(p[:4])
 	return int32(b) == int32(ctx.E.P), nil
 }
@@ -915,6 +1017,11 @@ func getR(ctx *model.Context, d types.Dict) (int, error) {
 func validateAlgorithm(ctx *model.Context) (ok bool) {
 	k := ctx.EncryptKeyLength
 
+	if len(ctx.EncryptRecipients) > 0 {
+		// The public-key security handler is supported for AES only.
+		return ctx.EncryptUsingAES && (k == 128 || k == 256)
+	}
+
 	if ctx.XRefTable.Version() == model.V20 {
 		return ctx.EncryptUsingAES && k == 256
 	}
@@ -1036,7 +1143,7 @@ func validateCFLength(len *int, cfm *string, pdf20, relaxed bool) error {
 }
 
 func validateCryptFilterRecipients(ctx *model.Context, d types.Dict, cfm *string) error {
-	if cfm == nil || (*cfm != "V2" && *cfm != "AESV2" && *cfm == "AESV3") {
+	if cfm == nil || (*cfm != "V2" && *cfm != "AESV2" && *cfm != "AESV3") {
 		return nil
 	}
 	obj, ok := d.Find("Recipients")
@@ -1101,7 +1208,7 @@ func validateCryptFilter(ctx *model.Context, d types.Dict, v int, pubKeySecHandl
 	}
 
 	ae := d.NameEntry("AuthEvent")
-	if ae != nil && *ae != "DocOpen" {
+	if ae != nil && *ae != "DocOpen" && *ae != "EFOpen" {
 		return false, errors.New("pdfcpu: crypt filter invalid entry \"AuthEvent\"")
 	}
 
@@ -1188,8 +1295,7 @@ func validateEncryptFilter(d types.Dict) (string, error) {
 	if filter == nil {
 		return "", errors.New("pdfcpu: encryption, missing \"Filter\"")
 	}
-	// TODO support "Adobe.PubSec"
-	if !types.MemberOf(*filter, []string{"Standard"}) {
+	if !types.MemberOf(*filter, []string{"Standard", "Adobe.PubSec"}) {
 		return "", errors.Errorf("pdfcpu: encryption, unsupported filter: %s", *filter)
 	}
 	return *filter, nil
@@ -1272,6 +1378,10 @@ func supportedEncryption(ctx *model.Context, d types.Dict) (*model.Enc, error) {
 		}
 	}
 
+	if pubKeySecHandler {
+		return supportedPubSecEncryption(ctx, d, subFilter, l, v)
+	}
+
 	// R
 	r, err := getR(ctx, d)
 	if err != nil {
@@ -1306,32 +1416,66 @@ func supportedEncryption(ctx *model.Context, d types.Dict) (*model.Enc, error) {
 		encMeta = *emd
 	}
 
-	if pubKeySecHandler && subFilter == "adbe.pkcs7.s3" || subFilter == "adbe.pkcs7.s4" {
-		obj, ok := d.Find("Recipients")
-		if !ok {
-			return nil, errors.New("pdfcpu: unsupported encryption: required entry \"Recipients\" missing")
-		}
-		arr, err := ctx.DereferenceArray(obj)
-		if err != nil {
-			return nil, err
-		}
-		if len(arr) == 0 {
-			return nil, errors.New("pdfcpu: unsupported encryption: required entry \"Recipients\" empty")
-		}
+	e := &model.Enc{
+		O:     o,
+		OE:    oe,
+		U:     u,
+		UE:    ue,
+		L:     l,
+		P:     *p,
+		Perms: perms,
+		R:     r,
+		V:     v,
+		Emd:   encMeta,
 	}
 
-	return &model.Enc{
-			O:     o,
-			OE:    oe,
-			U:     u,
-			UE:    ue,
-			L:     l,
-			P:     *p,
-			Perms: perms,
-			R:     r,
-			V:     v,
-			Emd:   encMeta},
-		nil
+	if v >= 4 {
+		e.StmF, e.StrF, e.EFF = cryptFilterNames(d)
+	}
+
+	return e, nil
+}
+
+func cryptFilterNames(d types.Dict) (stmF, strF, eff string) {
+	if n := d.NameEntry("StmF"); n != nil {
+		stmF = *n
+	}
+	if n := d.NameEntry("StrF"); n != nil {
+		strF = *n
+	}
+	if n := d.NameEntry("EFF"); n != nil {
+		eff = *n
+	}
+	return stmF, strF, eff
+}
+
+// embeddedFilesOnly returns true if e exclusively encrypts embedded files using the EFF crypt filter.
+func embeddedFilesOnly(e *model.Enc) bool {
+	return e.StmF == "Identity" && e.StrF == "Identity" && e.EFF != "" && e.EFF != "Identity"
+}
+
+// encryptStrings returns true if strings need to be en/decrypted.
+func encryptStrings(ctx *model.Context) bool {
+	return ctx.EncKey != nil && ctx.E.StrF != "Identity"
+}
+
+// streamCryptFilter returns true if sd needs to be en/decrypted and whether to use AES.
+func streamCryptFilter(ctx *model.Context, sd *types.StreamDict) (bool, bool) {
+	if ctx.EncKey == nil {
+		return false, false
+	}
+
+	t := sd.Type()
+
+	if t != nil && *t == "Metadata" && !ctx.E.Emd {
+		return false, false
+	}
+
+	if t != nil && *t == "EmbeddedFile" && ctx.E.EFF != "" {
+		return ctx.E.EFF != "Identity", ctx.AES4EmbeddedStreams
+	}
+
+	return ctx.E.StmF != "Identity", ctx.AES4Streams
 }
 
 func decryptKey(objNumber, generation int, key []byte, aes bool) []byte {
@@ -1764,7 +1908,7 @@ func fileID(ctx *model.Context) (types.HexLiteral, error) {
 	h := md5.New()
 
 	// Current timestamp.
-	h.Write([]byte(time.Now().String()))
+	h.Write([]byte(ctx.Now().String()))
 
 	// File location - ignore, we don't have this.
 
@@ -1777,8 +1921,8 @@ func fileID(ctx *model.Context) (types.HexLiteral, error) {
 		if err != nil {
 			return "", err
 		}
-		for _, v := range d {
-			o, err := ctx.Dereference(v)
+		for _, k := range ctx.DictKeys(d) {
+			o, err := ctx.Dereference(d[k])
 			if err != nil {
 				return "", err
 			}
@@ -1805,26 +1949,14 @@ func calcOAndUAES256(ctx *model.Context, d types.Dict) (err error) {
 	}
 
 	u := append(make([]byte, 32), b...)
-	upw := []byte(ctx.UserPW)
-	h := sha256.Sum256(append(upw, validationSalt(u)...))
-
-	ctx.E.U = append(h[:], b...)
-	d.Update("U", types.HexLiteral(hex.EncodeToString(ctx.E.U)))
-
-	///////////////////////////////////
-
-	b = make([]byte, 16)
-	_, err = io.ReadFull(rand.Reader, b)
+	upw, err := processInput(ctx.UserPW)
 	if err != nil {
 		return err
 	}
+	h := sha256.Sum256(append(upw, validationSalt(u)...))
 
-	o := append(make([]byte, 32), b...)
-	opw := []byte(ctx.OwnerPW)
-	c := append(opw, validationSalt(o)...)
-	h = sha256.Sum256(append(c, ctx.E.U...))
-	ctx.E.O = append(h[:], b...)
-	d.Update("O", types.HexLiteral(hex.EncodeToString(ctx.E.O)))
+	ctx.E.U = append(h[:], b...)
+	d.Update("U", types.HexLiteral(hex.EncodeToString(ctx.E.U)))
 
 	//////////////////////////////////
 
@@ -1845,16 +1977,38 @@ func calcOAndUAES256(ctx *model.Context, d types.Dict) (err error) {
 	mode.CryptBlocks(ctx.E.UE, ctx.EncKey)
 	d.Update("UE", types.HexLiteral(hex.EncodeToString(ctx.E.UE)))
 
+	return calcOAndOEAES256(ctx, d)
+}
+
+// calcOAndOEAES256 calculates O and OE for the current owner password, U and file encryption key.
+func calcOAndOEAES256(ctx *model.Context, d types.Dict) (err error) {
+	b := make([]byte, 16)
+	_, err = io.ReadFull(rand.Reader, b)
+	if err != nil {
+		return err
+	}
+
+	o := append(make([]byte, 32), b...)
+	opw, err := processInput(ctx.OwnerPW)
+	if err != nil {
+		return err
+	}
+	c := append(opw, validationSalt(o)...)
+	h := sha256.Sum256(append(c, ctx.E.U...))
+	ctx.E.O = append(h[:], b...)
+	d.Update("O", types.HexLiteral(hex.EncodeToString(ctx.E.O)))
+
 	//////////////////////////////////
 
 	c = append(opw, keySalt(o)...)
 	h = sha256.Sum256(append(c, ctx.E.U...))
-	cb, err = aes.NewCipher(h[:])
+	cb, err := aes.NewCipher(h[:])
 	if err != nil {
 		return err
 	}
 
-	mode = cipher.NewCBCEncrypter(cb, iv)
+	iv := make([]byte, 16)
+	mode := cipher.NewCBCEncrypter(cb, iv)
 	mode.CryptBlocks(ctx.E.OE, ctx.EncKey)
 	d.Update("OE", types.HexLiteral(hex.EncodeToString(ctx.E.OE)))
 
@@ -1869,33 +2023,17 @@ func calcOAndUAES256Rev6(ctx *model.Context, d types.Dict) (err error) {
 	}
 
 	u := append(make([]byte, 32), b...)
-	upw := []byte(ctx.UserPW)
-	h, _, err := hashRev6(append(upw, validationSalt(u)...), upw, nil)
-	if err != nil {
-		return err
-	}
-
-	ctx.E.U = append(h[:], b...)
-	d.Update("U", types.HexLiteral(hex.EncodeToString(ctx.E.U)))
-
-	///////////////////////////
-
-	b = make([]byte, 16)
-	_, err = io.ReadFull(rand.Reader, b)
+	upw, err := processInput(ctx.UserPW)
 	if err != nil {
 		return err
 	}
-
-	o := append(make([]byte, 32), b...)
-	opw := []byte(ctx.OwnerPW)
-	c := append(opw, validationSalt(o)...)
-	h, _, err = hashRev6(append(c, ctx.E.U...), opw, ctx.E.U)
+	h, _, err := hashRev6(append(upw, validationSalt(u)...), upw, nil)
 	if err != nil {
 		return err
 	}
 
-	ctx.E.O = append(h[:], b...)
-	d.Update("O", types.HexLiteral(hex.EncodeToString(ctx.E.O)))
+	ctx.E.U = append(h[:], b...)
+	d.Update("U", types.HexLiteral(hex.EncodeToString(ctx.E.U)))
 
 	///////////////////////////
 
@@ -1920,6 +2058,31 @@ func calcOAndUAES256Rev6(ctx *model.Context, d types.Dict) (err error) {
 	mode.CryptBlocks(ctx.E.UE, ctx.EncKey)
 	d.Update("UE", types.HexLiteral(hex.EncodeToString(ctx.E.UE)))
 
+	return calcOAndOEAES256Rev6(ctx, d)
+}
+
+// calcOAndOEAES256Rev6 calculates O and OE for the current owner password, U and file encryption key.
+func calcOAndOEAES256Rev6(ctx *model.Context, d types.Dict) (err error) {
+	b := make([]byte, 16)
+	_, err = io.ReadFull(rand.Reader, b)
+	if err != nil {
+		return err
+	}
+
+	o := append(make([]byte, 32), b...)
+	opw, err := processInput(ctx.OwnerPW)
+	if err != nil {
+		return err
+	}
+	c := append(opw, validationSalt(o)...)
+	h, _, err := hashRev6(append(c, ctx.E.U...), opw, ctx.E.U)
+	if err != nil {
+		return err
+	}
+
+	ctx.E.O = append(h[:], b...)
+	d.Update("O", types.HexLiteral(hex.EncodeToString(ctx.E.O)))
+
 	//////////////////////////////
 
 	c = append(opw, keySalt(o)...)
@@ -1928,12 +2091,13 @@ func calcOAndUAES256Rev6(ctx *model.Context, d types.Dict) (err error) {
 		return err
 	}
 
-	cb, err = aes.NewCipher(h[:])
+	cb, err := aes.NewCipher(h[:])
 	if err != nil {
 		return err
 	}
 
-	mode = cipher.NewCBCEncrypter(cb, iv)
+	iv := make([]byte, 16)
+	mode := cipher.NewCBCEncrypter(cb, iv)
 	mode.CryptBlocks(ctx.E.OE, ctx.EncKey)
 	d.Update("OE", types.HexLiteral(hex.EncodeToString(ctx.E.OE)))
 
@@ -1964,3 +2128,75 @@ func calcOAndU(ctx *model.Context, d types.Dict) (err error) {
 
 	return nil
 }
+
+func checkInPlaceEncryption(ctx *model.Context) (types.Dict, error) {
+	if ctx.Encrypt == nil || ctx.E == nil || ctx.EncKey == nil {
+		return nil, errors.New("pdfcpu: this file is not encrypted")
+	}
+
+	if ctx.E.PubSec {
+		return nil, errors.New("pdfcpu: operation not supported for public-key encrypted files")
+	}
+
+	// For R <= 4 both the permissions and the owner password are input to the file encryption key.
+	if ctx.E.R != 5 && ctx.E.R != 6 {
+		return nil, ErrReencryptionRequired
+	}
+
+	ok, err := validateOwnerPassword(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("pdfcpu: please provide the owner password with -opw")
+	}
+
+	return ctx.EncryptDict()
+}
+
+// SetPermissionsInPlace sets the user access permissions of an encrypted ctx
+// keeping passwords and the file encryption key intact.
+// Only the encryption dict is modified and registered for incremental writing.
+func SetPermissionsInPlace(ctx *model.Context, p model.PermissionFlags) error {
+	d, err := checkInPlaceEncryption(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.E.P = int(p)
+	d.Update("P", types.Integer(ctx.E.P))
+
+	if err := writePermissions(ctx, d); err != nil {
+		return err
+	}
+
+	ctx.Permissions = p
+	ctx.Write.IncrementWithObjNr(ctx.Encrypt.ObjectNumber.Value())
+
+	return nil
+}
+
+// ChangeOwnerPasswordInPlace changes the owner password of an encrypted ctx
+// keeping the user password, permissions and the file encryption key intact.
+// Only the encryption dict is modified and registered for incremental writing.
+func ChangeOwnerPasswordInPlace(ctx *model.Context, opwNew string) error {
+	d, err := checkInPlaceEncryption(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.OwnerPW = opwNew
+
+	if ctx.E.R == 5 {
+		err = calcOAndOEAES256(ctx, d)
+	} else {
+		err = calcOAndOEAES256Rev6(ctx, d)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx.Write.IncrementWithObjNr(ctx.Encrypt.ObjectNumber.Value())
+
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
@@ -32,7 +33,7 @@ import (
 )
 
 // ParseCutConfigForPoster parses a Cut command string into an internal structure.
-// formsize(=papersize) or dimensions, optionally: scalefactor, border, margin, bgcolor
+// formsize(=papersize) or dimensions, optionally: scalefactor, border, margin, bgcolor, overlap, cutmarks, glue, labels
 func ParseCutConfigForPoster(s string, u types.DisplayUnit) (*model.Cut, error) {
 
 	if s == "" {
@@ -125,6 +126,127 @@ func ParseCutConfig(s string, u types.DisplayUnit) (*model.Cut, error) {
 	return cut, nil
 }
 
+// Resource ids used for rendering tile overlays.
+const (
+	tileFontID = "FTile"
+	tileGSID   = "GSGlue"
+)
+
+func tileLabel(i, j int) string {
+	if i < 26 {
+		return fmt.Sprintf("%c%d", 'A'+i, j+1)
+	}
+	return fmt.Sprintf("%d-%d", i+1, j+1)
+}
+
+func tileOverlays(cut *model.Cut) bool {
+	return cut.Overlap > 0 || cut.CutMarks || cut.Glue || cut.Labels
+}
+
+func ensureResourceSubDict(xRefTable *model.XRefTable, d types.Dict, key string) (types.Dict, error) {
+	d1, err := xRefTable.DereferenceDict(d[key])
+	if err != nil {
+		return nil, err
+	}
+	if d1 == nil {
+		d1 = types.Dict{}
+		d[key] = d1
+	}
+	return d1, nil
+}
+
+// addTileResources adds the font and graphics state used for tile overlays to resDict.
+func addTileResources(xRefTable *model.XRefTable, resDict types.Dict, cut *model.Cut) error {
+	if cut.Labels {
+		ir, err := pdffont.EnsureFontDict(xRefTable, "Helvetica", "", "", false, nil)
+		if err != nil {
+			return err
+		}
+		d, err := ensureResourceSubDict(xRefTable, resDict, "Font")
+		if err != nil {
+			return err
+		}
+		d[tileFontID] = *ir
+	}
+
+	if cut.Glue {
+		d, err := ensureResourceSubDict(xRefTable, resDict, "ExtGState")
+		if err != nil {
+			return err
+		}
+		d[tileGSID] = types.Dict(map[string]types.Object{
+			"Type": types.Name("ExtGState"),
+			"ca":   types.Float(.25),
+		})
+	}
+
+	return nil
+}
+
+func drawTileLabel(w io.Writer, r *types.Rectangle, s string, fontSize int, centered bool) {
+	td := model.TextDescriptor{
+		FontName:  "Helvetica",
+		FontKey:   tileFontID,
+		FontSize:  fontSize,
+		Scale:     1.0,
+		ScaleAbs:  true,
+		StrokeCol: color.Gray,
+		FillCol:   color.Gray,
+		X:         4,
+		Y:         r.Height() - float64(fontSize) - 4,
+		Text:      s,
+	}
+	if centered {
+		td.X, td.Y = -1, -1
+		td.HAlign, td.VAlign = types.AlignCenter, types.AlignMiddle
+	}
+	model.WriteMultiLine(nil, w, r, nil, td)
+}
+
+// drawCutMarks draws marks extending the edges of r beyond its corners.
+func drawCutMarks(w io.Writer, r *types.Rectangle) {
+	const d, l = 3., 12.
+	fmt.Fprint(w, "q [] 0 d ")
+	draw.SetLineWidth(w, .5)
+	draw.SetStrokeColor(w, color.Black)
+	for _, x := range []float64{r.LL.X, r.UR.X} {
+		draw.DrawLineSimple(w, x, r.UR.Y+d, x, r.UR.Y+d+l)
+		draw.DrawLineSimple(w, x, r.LL.Y-d, x, r.LL.Y-d-l)
+	}
+	for _, y := range []float64{r.LL.Y, r.UR.Y} {
+		draw.DrawLineSimple(w, r.LL.X-d, y, r.LL.X-d-l, y)
+		draw.DrawLineSimple(w, r.UR.X+d, y, r.UR.X+d+l, y)
+	}
+	fmt.Fprint(w, "Q ")
+}
+
+// tileOverlay renders the glue areas of tile cbo which exceed the tile cb, cut marks and the tile label.
+func tileOverlay(cb, cbo *types.Rectangle, i, j int, cut *model.Cut) []byte {
+	var buf bytes.Buffer
+
+	if cut.Glue {
+		fmt.Fprintf(&buf, "q /%s gs ", tileGSID)
+		draw.SetFillColor(&buf, color.Gray)
+		if cbo.UR.X > cb.UR.X {
+			fmt.Fprintf(&buf, "%.2f %.2f %.2f %.2f re f ", cb.UR.X, cbo.LL.Y, cbo.UR.X-cb.UR.X, cbo.Height())
+		}
+		if cbo.LL.Y < cb.LL.Y {
+			fmt.Fprintf(&buf, "%.2f %.2f %.2f %.2f re f ", cbo.LL.X, cbo.LL.Y, cb.UR.X-cbo.LL.X, cb.LL.Y-cbo.LL.Y)
+		}
+		fmt.Fprint(&buf, "Q ")
+	}
+
+	if cut.CutMarks {
+		drawCutMarks(&buf, cb)
+	}
+
+	if cut.Labels {
+		drawTileLabel(&buf, cb, tileLabel(i, j), 9, false)
+	}
+
+	return buf.Bytes()
+}
+
 func drawOutlineCuts(w io.Writer, cropBox, cb *types.Rectangle, cut *model.Cut) {
 	for i, f := range cut.Hor {
 		if i == 0 {
@@ -149,6 +271,7 @@ func createOutline(
 	pagesDict, d types.Dict,
 	pageNr int,
 	cropBox *types.Rectangle,
+	inhPAttrs *model.InheritedPageAttrs,
 	migrated map[int]int,
 	cut *model.Cut) error {
 
@@ -178,6 +301,20 @@ func createOutline(
 
 	drawOutlineCuts(&buf, cropBox, cb, cut)
 
+	if cut.Labels {
+		// Show the tile arrangement.
+		resDict := inhPAttrs.Resources.Clone().(types.Dict)
+		if err := addTileResources(ctxSrc.XRefTable, resDict, cut); err != nil {
+			return err
+		}
+		d1["Resources"] = resDict
+		for i, r := range tileRects(cropBox, cut) {
+			for j, r := range r {
+				drawTileLabel(&buf, r, tileLabel(i, j), 24, true)
+			}
+		}
+	}
+
 	bb, err := ctxSrc.PageContent(d1, pageNr)
 	if err != nil {
 		return err
@@ -380,17 +517,9 @@ func handleCutMargin(ctxSrc *model.Context, d, d1 types.Dict, pageNr int, cropBo
 	return nil
 }
 
-func createTiles(
-	ctxSrc, ctxDest *model.Context,
-	pagesIndRef types.IndirectRef,
-	pagesDict, d types.Dict,
-	pageNr int,
-	cropBox *types.Rectangle,
-	inhPAttrs *model.InheritedPageAttrs,
-	migrated map[int]int,
-	cut *model.Cut) error {
-
-	var sc float64
+// tileRects returns the tile rectangles for cut by row and column.
+func tileRects(cropBox *types.Rectangle, cut *model.Cut) [][]*types.Rectangle {
+	var rr [][]*types.Rectangle
 
 	for i := 0; i < len(cut.Hor); i++ {
 		ury := cropBox.UR.Y - cut.Hor[i]*cropBox.Height()
@@ -402,7 +531,7 @@ func createTiles(
 			lly = cropBox.UR.Y - cut.Hor[i+1]*cropBox.Height()
 		}
 
-		h := ury - lly
+		var r []*types.Rectangle
 
 		for j := 0; j < len(cut.Vert); j++ {
 			llx := cropBox.LL.X + cut.Vert[j]*cropBox.Width()
@@ -413,22 +542,68 @@ func createTiles(
 			if j+1 < len(cut.Vert) {
 				urx = cropBox.LL.X + cut.Vert[j+1]*cropBox.Width()
 			}
-			w := urx - llx
+			r = append(r, types.NewRectangle(llx, lly, urx, ury))
+		}
+
+		rr = append(rr, r)
+	}
+
+	return rr
+}
+
+func createTiles(
+	ctxSrc, ctxDest *model.Context,
+	pagesIndRef types.IndirectRef,
+	pagesDict, d types.Dict,
+	pageNr int,
+	cropBox *types.Rectangle,
+	inhPAttrs *model.InheritedPageAttrs,
+	migrated map[int]int,
+	cut *model.Cut) error {
 
-			cb := types.NewRectangle(llx, lly, urx, ury)
+	if cut.Margin > 0 && tileOverlays(cut) {
+		return errors.New("pdfcpu: margin can't be combined with overlap, cutmarks, glue or labels")
+	}
+
+	var sc float64
+
+	for i, r := range tileRects(cropBox, cut) {
+		for j, cb := range r {
+
+			// Extend tile by glue areas overlapping the right and bottom neighbors.
+			cbo := cb.Clone()
+			if cut.Overlap > 0 {
+				cbo.UR.X = math.Min(cb.UR.X+cut.Overlap, cropBox.UR.X)
+				cbo.LL.Y = math.Max(cb.LL.Y-cut.Overlap, cropBox.LL.Y)
+			}
 
 			d1 := d.Clone().(types.Dict)
 			d1["Resources"] = inhPAttrs.Resources.Clone()
 			d1["Parent"] = pagesIndRef
-			d1["MediaBox"] = cb.Array()
-			d1["CropBox"] = cb.Array()
+			d1["MediaBox"] = cbo.Array()
+			d1["CropBox"] = cbo.Array()
 
 			if cut.Margin > 0 {
-				if err := handleCutMargin(ctxSrc, d, d1, pageNr, cropBox, cb, i, j, w, h, &sc, cut); err != nil {
+				if err := handleCutMargin(ctxSrc, d, d1, pageNr, cropBox, cb, i, j, cb.Width(), cb.Height(), &sc, cut); err != nil {
 					return err
 				}
 			}
 
+			if tileOverlays(cut) {
+				if err := addTileResources(ctxSrc.XRefTable, d1["Resources"].(types.Dict), cut); err != nil {
+					return err
+				}
+				sd, _ := ctxSrc.NewStreamDictForBuf(tileOverlay(cb, cbo, i, j, cut))
+				if err := sd.Encode(); err != nil {
+					return err
+				}
+				indRef, err := ctxSrc.IndRefForNewObject(*sd)
+				if err != nil {
+					return err
+				}
+				d1["Contents"] = types.Array{d["Contents"], *indRef}
+			}
+
 			pageIndRef, err := ctxDest.IndRefForNewObject(d1)
 			if err != nil {
 				return err
@@ -478,7 +653,7 @@ func CutPage(ctxSrc *model.Context, pageNr int, cut *model.Cut) (*model.Context,
 
 	migrated := map[int]int{}
 
-	if err := createOutline(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, migrated, cut); err != nil {
+	if err := createOutline(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut); err != nil {
 		return nil, err
 	}
 
@@ -554,7 +729,7 @@ func NDownPage(ctxSrc *model.Context, pageNr, n int, cut *model.Cut) (*model.Con
 
 	migrated := map[int]int{}
 
-	if err := createOutline(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, migrated, cut); err != nil {
+	if err := createOutline(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut); err != nil {
 		return nil, err
 	}
 
@@ -566,11 +741,13 @@ func NDownPage(ctxSrc *model.Context, pageNr, n int, cut *model.Cut) (*model.Con
 }
 
 func createPosterCuts(cropBox *types.Rectangle, cut *model.Cut) {
-	dim := cut.PageDim
+	// Tiles overlapping their neighbors need to be smaller in order to fit the selected paper size.
+	w := cut.PageDim.Width - cut.Overlap
+	h := cut.PageDim.Height - cut.Overlap
 
 	cut.Vert = []float64{0.}
-	for x := 0.; ; x += dim.Width {
-		f := (x + dim.Width) / cropBox.Width()
+	for x := 0.; ; x += w {
+		f := (x + w) / cropBox.Width()
 		fr := math.Round(f*100) / 100
 		if fr != 1 {
 			cut.Vert = append(cut.Vert, f)
@@ -581,8 +758,8 @@ func createPosterCuts(cropBox *types.Rectangle, cut *model.Cut) {
 	}
 
 	cut.Hor = []float64{0.}
-	for y := 0.; ; y += dim.Height {
-		f := (y + dim.Height) / cropBox.Height()
+	for y := 0.; ; y += h {
+		f := (y + h) / cropBox.Height()
 		fr := math.Round(f*100) / 100
 		if fr != 1 {
 			cut.Hor = append(cut.Hor, f)
@@ -596,7 +773,7 @@ func createPosterCuts(cropBox *types.Rectangle, cut *model.Cut) {
 func PosterPage(ctxSrc *model.Context, pageNr int, cut *model.Cut) (*model.Context, error) {
 
 	// required: formsize(=papersize) or dimensions
-	// optionally: scalefactor, border, margin, bgcolor
+	// optionally: scalefactor, border, margin, bgcolor, overlap, cutmarks, glue, labels
 
 	ctxDest, cropBox, pagesIndRef, pagesDict, d, inhPAttrs, err := prepForCut(ctxSrc, pageNr)
 	if err != nil {
@@ -612,6 +789,10 @@ func PosterPage(ctxSrc *model.Context, pageNr int, cut *model.Cut) (*model.Conte
 		return nil, errors.New("pdfcpu: selected poster tile dimensions too big")
 	}
 
+	if cut.Overlap >= dim.Width/2 || cut.Overlap >= dim.Height/2 {
+		return nil, errors.New("pdfcpu: selected poster tile overlap too big")
+	}
+
 	rotate := inhPAttrs.Rotate
 
 	if types.IntMemberOf(rotate, []int{+90, -90, +270, -270}) {
@@ -640,7 +821,7 @@ func PosterPage(ctxSrc *model.Context, pageNr int, cut *model.Cut) (*model.Conte
 
 	migrated := map[int]int{}
 
-	if err := createOutline(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, migrated, cut); err != nil {
+	if err := createOutline(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut); err != nil {
 		return nil, err
 	}
 
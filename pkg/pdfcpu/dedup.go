@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// resourceHasher calculates content hashes for object graphs.
+// References contribute the hash of the object they point to,
+// so identical resources copied from different documents hash identically.
+type resourceHasher struct {
+	xRefTable  *model.XRefTable
+	hashes     map[int]string
+	inProgress types.IntSet
+}
+
+func (rh *resourceHasher) write(h hash.Hash, o types.Object) {
+	switch o := o.(type) {
+
+	case nil:
+		h.Write([]byte("null"))
+
+	case types.IndirectRef:
+		h.Write([]byte("R" + rh.objHash(o.ObjectNumber.Value())))
+
+	case types.Dict:
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		h.Write([]byte("<<"))
+		for _, k := range keys {
+			h.Write([]byte("/" + k + " "))
+			rh.write(h, o[k])
+		}
+		h.Write([]byte(">>"))
+
+	case types.StreamDict:
+		rh.write(h, o.Dict)
+		h.Write([]byte("stream"))
+		if err := o.Load(); err != nil {
+			// Never match streams whose data is unavailable.
+			fmt.Fprintf(h, "%p", o.Dict)
+		}
+		h.Write(o.Raw)
+
+	case types.Array:
+		h.Write([]byte("["))
+		for _, o1 := range o {
+			rh.write(h, o1)
+			h.Write([]byte(" "))
+		}
+		h.Write([]byte("]"))
+
+	default:
+		fmt.Fprintf(h, "%T(%s)", o, o.PDFString())
+	}
+}
+
+func (rh *resourceHasher) objHash(objNr int) string {
+	if s, ok := rh.hashes[objNr]; ok {
+		return s
+	}
+
+	if rh.inProgress[objNr] {
+		// Objects taking part in a cycle are unique.
+		return fmt.Sprintf("cycle%d", objNr)
+	}
+
+	entry, found := rh.xRefTable.FindTableEntryLight(objNr)
+	if !found || entry.Free || entry.Object == nil {
+		return fmt.Sprintf("missing%d", objNr)
+	}
+
+	rh.inProgress[objNr] = true
+	h := sha256.New()
+	rh.write(h, entry.Object)
+	delete(rh.inProgress, objNr)
+
+	s := fmt.Sprintf("%x", h.Sum(nil))
+	rh.hashes[objNr] = s
+	return s
+}
+
+// dedupCandidates returns the object numbers of all fonts, embedded font files, images and forms.
+func dedupCandidates(xRefTable *model.XRefTable) []int {
+	objNrs := types.IntSet{}
+
+	for objNr, entry := range xRefTable.Table {
+		if entry == nil || entry.Free || entry.Object == nil {
+			continue
+		}
+
+		switch o := entry.Object.(type) {
+
+		case types.Dict:
+			t := o.Type()
+			if t == nil {
+				continue
+			}
+			switch *t {
+			case "Font":
+				objNrs[objNr] = true
+			case "FontDescriptor":
+				for _, k := range []string{"FontFile", "FontFile2", "FontFile3"} {
+					if ir := o.IndirectRefEntry(k); ir != nil {
+						objNrs[ir.ObjectNumber.Value()] = true
+					}
+				}
+			}
+
+		case types.StreamDict:
+			if st := o.Subtype(); st != nil && (*st == "Image" || *st == "Form") {
+				objNrs[objNr] = true
+			}
+		}
+	}
+
+	nn := make([]int, 0, len(objNrs))
+	for objNr := range objNrs {
+		nn = append(nn, objNr)
+	}
+	sort.Ints(nn)
+
+	return nn
+}
+
+func redirectRefs(o types.Object, lookup map[int]int) types.Object {
+	switch o := o.(type) {
+
+	case types.IndirectRef:
+		if objNr, ok := lookup[o.ObjectNumber.Value()]; ok {
+			return *types.NewIndirectRef(objNr, 0)
+		}
+
+	case types.Dict:
+		for k, v := range o {
+			o[k] = redirectRefs(v, lookup)
+		}
+
+	case types.StreamDict:
+		redirectRefs(o.Dict, lookup)
+
+	case types.Array:
+		for i, v := range o {
+			o[i] = redirectRefs(v, lookup)
+		}
+	}
+
+	return o
+}
+
+// deduplicateResources detects identical fonts, embedded font files, images and forms
+// by content hash and redirects all references to a single copy.
+// Unreferenced duplicates are not written.
+func deduplicateResources(ctx *model.Context) error {
+	if log.OptimizeEnabled() {
+		log.Optimize.Println("deduplicateResources begin")
+	}
+
+	rh := &resourceHasher{xRefTable: ctx.XRefTable, hashes: map[int]string{}, inProgress: types.IntSet{}}
+
+	originals := map[string]int{}
+	lookup := map[int]int{}
+
+	for _, objNr := range dedupCandidates(ctx.XRefTable) {
+		s := rh.objHash(objNr)
+		if objNr1, ok := originals[s]; ok {
+			lookup[objNr] = objNr1
+			continue
+		}
+		originals[s] = objNr
+	}
+
+	if len(lookup) > 0 {
+		for objNr, entry := range ctx.Table {
+			if entry == nil || entry.Free || entry.Object == nil {
+				continue
+			}
+			if _, ok := lookup[objNr]; ok {
+				continue
+			}
+			entry.Object = redirectRefs(entry.Object, lookup)
+		}
+	}
+
+	if log.OptimizeEnabled() {
+		log.Optimize.Printf("deduplicateResources end: %d duplicates\n", len(lookup))
+	}
+
+	return nil
+}
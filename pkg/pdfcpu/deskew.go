@@ -0,0 +1,563 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Minimum fraction of the crop box a scanned image has to cover.
+	scanMinCoverage = 0.9
+
+	// Gray levels below this threshold are considered ink.
+	scanInkThreshold = 0x80
+
+	// Skew detection works on a downsampled image about this wide.
+	skewSampleWidth = 1000
+
+	jpegQuality = 90
+)
+
+// DeskewOptions configures DeskewPages.
+type DeskewOptions struct {
+	Deskew      bool    // detect and correct the skew angle
+	MaxAngle    float64 // largest skew angle in degrees taken into account
+	MinAngle    float64 // smaller skew angles in degrees are left alone
+	Despeckle   bool    // remove speckle noise
+	SpeckleSize int     // max. area in pixels of a speckle
+}
+
+// DefaultDeskewOptions returns options for deskewing and despeckling scans of about 300 dpi.
+func DefaultDeskewOptions() DeskewOptions {
+	return DeskewOptions{
+		Deskew:      true,
+		MaxAngle:    5,
+		MinAngle:    0.1,
+		Despeckle:   true,
+		SpeckleSize: 4,
+	}
+}
+
+func (opts DeskewOptions) validate() error {
+	if opts.MaxAngle < 0 || opts.MaxAngle > 45 {
+		return errors.Errorf("pdfcpu: invalid max skew angle: %.2f", opts.MaxAngle)
+	}
+	if opts.MinAngle < 0 {
+		return errors.Errorf("pdfcpu: invalid min skew angle: %.2f", opts.MinAngle)
+	}
+	if opts.Despeckle && opts.SpeckleSize < 1 {
+		return errors.Errorf("pdfcpu: invalid speckle size: %d", opts.SpeckleSize)
+	}
+	return nil
+}
+
+// DeskewedPage represents the processing result of a scanned page.
+type DeskewedPage struct {
+	PageNr   int
+	ObjNr    int     // image object
+	Angle    float64 // corrected skew in degrees, positive for clockwise skewed content
+	Speckles int     // number of removed speckles
+}
+
+// fullPageImage returns the image XObject of page pageNr if the page content consists of this image only
+// covering most of the page.
+func fullPageImage(ctx *model.Context, pageNr int) (*types.StreamDict, int, string, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	if d == nil {
+		return nil, 0, "", errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err == model.ErrNoContent {
+		return nil, 0, "", nil
+	}
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	var (
+		name  string
+		m     matrix.Matrix
+		stack []matrix.Matrix
+	)
+
+	ctm := matrix.IdentMatrix
+	for _, op := range ops {
+		switch op.Operator {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if len(stack) > 0 {
+				ctm = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "cm":
+			ctm = matrixForOperands(op.Numbers()).Multiply(ctm)
+		case "Do":
+			if name != "" {
+				return nil, 0, "", nil
+			}
+			name, m = op.Name(0), ctm
+		default:
+			if paintingOps[op.Operator] {
+				return nil, 0, "", nil
+			}
+		}
+	}
+
+	if name == "" || inhPAttrs.Resources == nil {
+		return nil, 0, "", nil
+	}
+
+	xo, err := ctx.DereferenceDict(inhPAttrs.Resources["XObject"])
+	if err != nil || xo == nil {
+		return nil, 0, "", err
+	}
+
+	ir, ok := xo[name].(types.IndirectRef)
+	if !ok {
+		return nil, 0, "", nil
+	}
+
+	sd, _, err := ctx.DereferenceStreamDict(ir)
+	if err != nil || sd == nil {
+		return nil, 0, "", err
+	}
+
+	if st := sd.Subtype(); st == nil || *st != "Image" || hasImageMask(sd) {
+		return nil, 0, "", nil
+	}
+	if im := sd.BooleanEntry("ImageMask"); im != nil && *im {
+		return nil, 0, "", nil
+	}
+
+	cb := inhPAttrs.CropBox
+	if cb == nil {
+		cb = inhPAttrs.MediaBox
+	}
+	if cb == nil || cb.Width() <= 0 || cb.Height() <= 0 {
+		return nil, 0, "", nil
+	}
+
+	// Bounding box of the transformed unit square.
+	llx, lly, urx, ury := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range []types.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}} {
+		p = m.Transform(p)
+		llx, lly = math.Min(llx, p.X), math.Min(lly, p.Y)
+		urx, ury = math.Max(urx, p.X), math.Max(ury, p.Y)
+	}
+	w := math.Min(urx, cb.UR.X) - math.Max(llx, cb.LL.X)
+	h := math.Min(ury, cb.UR.Y) - math.Max(lly, cb.LL.Y)
+	if w <= 0 || h <= 0 || w*h < scanMinCoverage*cb.Width()*cb.Height() {
+		return nil, 0, "", nil
+	}
+
+	return sd, ir.ObjectNumber.Value(), name, nil
+}
+
+// luminance returns the gray levels of img which is either *image.Gray or *image.RGBA.
+func luminance(img image.Image) *image.Gray {
+	if g, ok := img.(*image.Gray); ok {
+		return g
+	}
+	rgba := img.(*image.RGBA)
+	b := rgba.Bounds()
+	g := image.NewGray(b)
+	for i, j := 0, 0; i < len(rgba.Pix); i, j = i+4, j+1 {
+		g.Pix[j] = uint8((299*int(rgba.Pix[i]) + 587*int(rgba.Pix[i+1]) + 114*int(rgba.Pix[i+2])) / 1000)
+	}
+	return g
+}
+
+// skewAngle detects the skew angle in degrees of the text lines in g using projection profiles.
+func skewAngle(g *image.Gray, maxAngle float64) float64 {
+	b := g.Bounds()
+	step := b.Dx()/skewSampleWidth + 1
+
+	var xx, yy []float64
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			if g.Pix[g.PixOffset(x, y)] < scanInkThreshold {
+				xx = append(xx, float64(x-b.Min.X))
+				yy = append(yy, float64(y-b.Min.Y))
+			}
+		}
+	}
+
+	if len(xx) < 100 {
+		return 0
+	}
+
+	diag := math.Hypot(float64(b.Dx()), float64(b.Dy()))
+	bins := make([]float64, int(2*diag)/step+2)
+
+	score := func(a float64) float64 {
+		sin, cos := math.Sincos(a * math.Pi / 180)
+		for i := range bins {
+			bins[i] = 0
+		}
+		for i := range xx {
+			bins[int((yy[i]*cos-xx[i]*sin+diag)/float64(step))]++
+		}
+		var s float64
+		for _, v := range bins {
+			s += v * v
+		}
+		return s
+	}
+
+	search := func(from, to, inc float64) float64 {
+		best, bestScore := 0.0, -1.0
+		for a := from; a <= to+inc/2; a += inc {
+			if s := score(a); s > bestScore {
+				best, bestScore = a, s
+			}
+		}
+		return best
+	}
+
+	a := search(-maxAngle, maxAngle, .5)
+	a = search(a-.5, a+.5, .05)
+
+	return math.Round(a*100) / 100
+}
+
+// rotateScan rotates img by angle degrees around its center and fills uncovered areas white.
+func rotateScan(img image.Image, angle float64, bilevel bool) image.Image {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	b := img.Bounds()
+	cx, cy := float64(b.Dx())/2, float64(b.Dy())/2
+
+	var (
+		pix, out []uint8
+		stride   int
+		comps    int
+		dst      image.Image
+	)
+
+	switch src := img.(type) {
+	case *image.Gray:
+		d := image.NewGray(b)
+		pix, out, stride, comps, dst = src.Pix, d.Pix, src.Stride, 1, d
+	case *image.RGBA:
+		d := image.NewRGBA(b)
+		pix, out, stride, comps, dst = src.Pix, d.Pix, src.Stride, 4, d
+	}
+
+	at := func(x, y, c int) float64 {
+		if x < 0 || y < 0 || x >= b.Dx() || y >= b.Dy() {
+			return 0xFF
+		}
+		return float64(pix[y*stride+x*comps+c])
+	}
+
+	i := 0
+	for y := 0; y < b.Dy(); y++ {
+		dy := float64(y) + .5 - cy
+		for x := 0; x < b.Dx(); x++ {
+			dx := float64(x) + .5 - cx
+			sx := cx + dx*cos - dy*sin - .5
+			sy := cy + dx*sin + dy*cos - .5
+			if bilevel {
+				x0, y0 := int(math.Round(sx)), int(math.Round(sy))
+				for c := 0; c < comps; c++ {
+					out[i+c] = uint8(at(x0, y0, c))
+				}
+				i += comps
+				continue
+			}
+			x0, y0 := int(math.Floor(sx)), int(math.Floor(sy))
+			fx, fy := sx-float64(x0), sy-float64(y0)
+			for c := 0; c < comps; c++ {
+				v := (at(x0, y0, c)*(1-fx)+at(x0+1, y0, c)*fx)*(1-fy) + (at(x0, y0+1, c)*(1-fx)+at(x0+1, y0+1, c)*fx)*fy
+				out[i+c] = uint8(math.Round(v))
+			}
+			i += comps
+		}
+	}
+
+	return dst
+}
+
+// despeckle removes all connected ink areas of at most maxSize pixels from img and returns their number.
+func despeckle(img image.Image, maxSize int) int {
+	g := luminance(img)
+	b := g.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	seen := make([]bool, w*h)
+	var comp, stack []int
+	n := 0
+
+	for i := range seen {
+		if seen[i] || g.Pix[(i/w)*g.Stride+i%w] >= scanInkThreshold {
+			continue
+		}
+
+		// Flood fill using 8-connectivity.
+		comp, stack = comp[:0], append(stack[:0], i)
+		seen[i] = true
+		for len(stack) > 0 {
+			j := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			comp = append(comp, j)
+			x, y := j%w, j/w
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					x1, y1 := x+dx, y+dy
+					if x1 < 0 || y1 < 0 || x1 >= w || y1 >= h {
+						continue
+					}
+					k := y1*w + x1
+					if !seen[k] && g.Pix[y1*g.Stride+x1] < scanInkThreshold {
+						seen[k] = true
+						stack = append(stack, k)
+					}
+				}
+			}
+		}
+
+		if len(comp) > maxSize {
+			continue
+		}
+
+		n++
+		for _, j := range comp {
+			x, y := j%w, j/w
+			switch img := img.(type) {
+			case *image.Gray:
+				img.Pix[y*img.Stride+x] = 0xFF
+			case *image.RGBA:
+				o := y*img.Stride + x*4
+				img.Pix[o], img.Pix[o+1], img.Pix[o+2], img.Pix[o+3] = 0xFF, 0xFF, 0xFF, 0xFF
+			}
+		}
+	}
+
+	return n
+}
+
+// decodeScan returns the decoded image of sd as *image.Gray or *image.RGBA.
+func decodeScan(ctx *model.Context, sd *types.StreamDict, name string, objNr int) (image.Image, error) {
+	img, err := ExtractImage(ctx, sd, false, name, objNr, false)
+	if err != nil || img == nil {
+		return nil, err
+	}
+
+	im, _, err := image.Decode(img)
+	if err != nil {
+		return nil, err
+	}
+
+	b := im.Bounds()
+
+	switch im := im.(type) {
+	case *image.Gray:
+		return im, nil
+	case *image.CMYK:
+		return nil, nil
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), im, b.Min, draw.Src)
+
+	return rgba, nil
+}
+
+// preservedColorSpace returns the color space of sd if it is compatible with a replacement image having comps color components.
+func preservedColorSpace(ctx *model.Context, sd *types.StreamDict, comps int) types.Object {
+	o, found := sd.Find("ColorSpace")
+	if !found {
+		return nil
+	}
+	a, err := ctx.DereferenceArray(o)
+	if err != nil || len(a) == 0 {
+		return nil
+	}
+	if n, ok := a[0].(types.Name); !ok || !types.MemberOf(n.Value(), []string{model.ICCBasedCS, model.CalGrayCS, model.CalRGBCS}) {
+		return nil
+	}
+	if c, err := ColorSpaceComponents(ctx.XRefTable, sd); err != nil || c != comps {
+		return nil
+	}
+	return o
+}
+
+// encodeScan returns a new image stream dict for img using DCT for JPEG encoded originals and Flate otherwise.
+func encodeScan(ctx *model.Context, img image.Image, dct, bilevel bool) (*types.StreamDict, int, error) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	g, gray := img.(*image.Gray)
+
+	cs, comps := model.DeviceRGBCS, 3
+	if gray {
+		cs, comps = model.DeviceGrayCS, 1
+	}
+
+	if dct {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, 0, err
+		}
+		sd, err := model.CreateDCTImageStreamDict(ctx.XRefTable, buf.Bytes(), w, h, 8, cs)
+		return sd, comps, err
+	}
+
+	if gray && bilevel {
+		// 1 bit per pixel, rows padded to full bytes.
+		rowLen := (w + 7) / 8
+		buf := make([]byte, rowLen*h)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if g.Pix[y*g.Stride+x] >= scanInkThreshold {
+					buf[y*rowLen+x/8] |= 0x80 >> uint(x%8)
+				}
+			}
+		}
+		sd, err := model.CreateFlateImageStreamDict(ctx.XRefTable, buf, nil, w, h, 1, cs)
+		return sd, comps, err
+	}
+
+	var buf []byte
+	if gray {
+		buf = make([]byte, 0, w*h)
+		for y := 0; y < h; y++ {
+			buf = append(buf, g.Pix[y*g.Stride:y*g.Stride+w]...)
+		}
+	} else {
+		rgba := img.(*image.RGBA)
+		buf = make([]byte, 0, 3*w*h)
+		for i := 0; i < len(rgba.Pix); i += 4 {
+			buf = append(buf, rgba.Pix[i:i+3]...)
+		}
+	}
+
+	sd, err := model.CreateFlateImageStreamDict(ctx.XRefTable, buf, nil, w, h, 8, cs)
+	return sd, comps, err
+}
+
+func deskewPage(ctx *model.Context, pageNr int, opts DeskewOptions, done map[int]bool) (*DeskewedPage, error) {
+	sd, objNr, name, err := fullPageImage(ctx, pageNr)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+
+	if done[objNr] {
+		// Image shared with a page already processed.
+		return nil, nil
+	}
+	done[objNr] = true
+
+	_, lastFilter, _, _ := prepareExtractImage(sd)
+	dct := lastFilter == filter.DCT
+
+	bilevel := false
+	if bpc := sd.IntEntry("BitsPerComponent"); bpc != nil && *bpc == 1 {
+		bilevel = true
+	}
+
+	img, err := decodeScan(ctx, sd, name, objNr)
+	if err != nil || img == nil {
+		// Unsupported image.
+		return nil, nil
+	}
+
+	dp := &DeskewedPage{PageNr: pageNr, ObjNr: objNr}
+
+	// Despeckle first since interpolation blurs speckles.
+	if opts.Despeckle {
+		dp.Speckles = despeckle(img, opts.SpeckleSize)
+	}
+
+	if opts.Deskew {
+		if a := skewAngle(luminance(img), opts.MaxAngle); a != 0 && math.Abs(a) >= opts.MinAngle {
+			img = rotateScan(img, a, bilevel)
+			dp.Angle = a
+		}
+	}
+
+	if dp.Angle == 0 && dp.Speckles == 0 {
+		return dp, nil
+	}
+
+	sd1, comps, err := encodeScan(ctx, img, dct, bilevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if o := preservedColorSpace(ctx, sd, comps); o != nil && !bilevel {
+		sd1.Dict["ColorSpace"] = o
+	}
+	for _, k := range []string{"Intent", "Metadata", "OC", "Interpolate"} {
+		if o, found := sd.Find(k); found {
+			sd1.Dict[k] = o
+		}
+	}
+
+	ctx.Table[objNr].Object = *sd1
+
+	return dp, nil
+}
+
+// DeskewPages corrects the skew and removes speckle noise of selected scanned pages of ctx.
+// A scanned page consists of a single image covering the page.
+// Processed images get reencoded using DCT for JPEG images and Flate otherwise.
+// Pages not consisting of a single supported image are skipped.
+func DeskewPages(ctx *model.Context, selectedPages types.IntSet, opts DeskewOptions) ([]DeskewedPage, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	var dpp []DeskewedPage
+	done := map[int]bool{}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		dp, err := deskewPage(ctx, pageNr, opts, done)
+		if err != nil {
+			return nil, err
+		}
+		if dp != nil {
+			dpp = append(dpp, *dp)
+		}
+	}
+
+	return dpp, nil
+}
@@ -0,0 +1,423 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultDigestAlgorithm is the hash algorithm used for document manifests.
+const DefaultDigestAlgorithm = "SHA-256"
+
+func newHash(algo string) (hash.Hash, error) {
+	switch strings.ReplaceAll(strings.ToUpper(algo), "-", "") {
+	case "SHA1":
+		return sha1.New(), nil
+	case "SHA256", "":
+		return sha256.New(), nil
+	case "SHA384":
+		return sha512.New384(), nil
+	case "SHA512":
+		return sha512.New(), nil
+	}
+	return nil, errors.Errorf("pdfcpu: unsupported digest algorithm: %s", algo)
+}
+
+// pageHasher writes a canonical representation of PDF objects independent of
+// object numbers, stream encodings, number formats and string notations.
+type pageHasher struct {
+	ctx        *model.Context
+	algo       string
+	memo       map[int][]byte // digests of indirect objects
+	inProgress map[int]bool
+}
+
+func (ph *pageHasher) number(w io.Writer, f float64) {
+	io.WriteString(w, strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func (ph *pageHasher) bytes(w io.Writer, bb []byte) {
+	io.WriteString(w, "<"+hex.EncodeToString(bb)+">")
+}
+
+func (ph *pageHasher) ref(w io.Writer, ir types.IndirectRef) error {
+	objNr := ir.ObjectNumber.Value()
+
+	if ph.inProgress[objNr] {
+		io.WriteString(w, "cycle")
+		return nil
+	}
+
+	bb, ok := ph.memo[objNr]
+	if !ok {
+		o, err := ph.ctx.Dereference(ir)
+		if err != nil {
+			return err
+		}
+		h, err := newHash(ph.algo)
+		if err != nil {
+			return err
+		}
+		ph.inProgress[objNr] = true
+		err = ph.object(h, o)
+		delete(ph.inProgress, objNr)
+		if err != nil {
+			return err
+		}
+		bb = h.Sum(nil)
+		ph.memo[objNr] = bb
+	}
+
+	io.WriteString(w, "R")
+	ph.bytes(w, bb)
+
+	return nil
+}
+
+func (ph *pageHasher) dict(w io.Writer, d types.Dict, skip []string) error {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		if !types.MemberOf(k, skip) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	io.WriteString(w, "<<")
+	for _, k := range keys {
+		io.WriteString(w, "/"+types.EncodeName(k)+" ")
+		if err := ph.object(w, d[k]); err != nil {
+			return err
+		}
+		io.WriteString(w, " ")
+	}
+	io.WriteString(w, ">>")
+
+	return nil
+}
+
+func (ph *pageHasher) stream(w io.Writer, sd types.StreamDict) error {
+	// Hash decoded content whenever possible in order to ignore the stream encoding.
+	skip := []string{"Length"}
+	if err := sd.Load(); err != nil {
+		return err
+	}
+	bb := sd.Raw
+	if sd.FilterPipeline == nil {
+		if bb == nil {
+			bb = sd.Content
+		}
+		skip = append(skip, "Filter", "DecodeParms")
+	} else if err := sd.Decode(); err == nil && sd.Content != nil {
+		bb = sd.Content
+		skip = append(skip, "Filter", "DecodeParms")
+	}
+
+	if err := ph.dict(w, sd.Dict, skip); err != nil {
+		return err
+	}
+
+	io.WriteString(w, "stream"+strconv.Itoa(len(bb))+"\n")
+	_, err := w.Write(bb)
+
+	return err
+}
+
+func (ph *pageHasher) object(w io.Writer, o types.Object) error {
+	switch o := o.(type) {
+
+	case nil:
+		io.WriteString(w, "null")
+
+	case types.Boolean:
+		io.WriteString(w, o.PDFString())
+
+	case types.Integer:
+		ph.number(w, float64(o.Value()))
+
+	case types.Float:
+		ph.number(w, o.Value())
+
+	case types.Name:
+		io.WriteString(w, "/"+types.EncodeName(o.Value()))
+
+	case types.StringLiteral:
+		bb, err := types.Unescape(o.Value())
+		if err != nil {
+			return err
+		}
+		ph.bytes(w, bb)
+
+	case types.HexLiteral:
+		bb, err := o.Bytes()
+		if err != nil {
+			return err
+		}
+		ph.bytes(w, bb)
+
+	case types.Array:
+		io.WriteString(w, "[")
+		for _, o1 := range o {
+			if err := ph.object(w, o1); err != nil {
+				return err
+			}
+			io.WriteString(w, " ")
+		}
+		io.WriteString(w, "]")
+
+	case types.Dict:
+		return ph.dict(w, o, nil)
+
+	case types.StreamDict:
+		return ph.stream(w, o)
+
+	case types.IndirectRef:
+		return ph.ref(w, o)
+
+	default:
+		io.WriteString(w, o.PDFString())
+	}
+
+	return nil
+}
+
+// usedNames collects all name operands of a content stream which may refer to a page resource.
+func usedNames(o types.Object, m map[string]bool) {
+	switch o := o.(type) {
+	case types.Name:
+		m[o.Value()] = true
+	case types.Dict:
+		for _, v := range o {
+			usedNames(v, m)
+		}
+	case types.Array:
+		for _, v := range o {
+			usedNames(v, m)
+		}
+	}
+}
+
+func (ph *pageHasher) content(w io.Writer, d types.Dict, pageNr int) (map[string]bool, error) {
+	m := map[string]bool{}
+
+	bb, err := ph.ctx.PageContent(d, pageNr)
+	if err == model.ErrNoContent {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range ops {
+		for _, o := range op.Operands {
+			if err := ph.object(w, o); err != nil {
+				return nil, err
+			}
+			io.WriteString(w, " ")
+			usedNames(o, m)
+		}
+		io.WriteString(w, op.Operator+"\n")
+		if op.Data != nil {
+			ph.bytes(w, op.Data)
+		}
+	}
+
+	return m, nil
+}
+
+// resources writes the page resources referenced by the page content.
+// Unused resources are ignored since they do not contribute to the page rendering
+// and may get removed by optimization.
+func (ph *pageHasher) resources(w io.Writer, d types.Dict, used map[string]bool) error {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		if k != "ProcSet" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	io.WriteString(w, "<<")
+	for _, k := range keys {
+		d1, err := ph.ctx.DereferenceDict(d[k])
+		if err != nil {
+			return err
+		}
+		d2 := types.Dict{}
+		for id, v := range d1 {
+			if used[id] {
+				d2[id] = v
+			}
+		}
+		if len(d2) == 0 {
+			continue
+		}
+		io.WriteString(w, "/"+types.EncodeName(k)+" ")
+		if err := ph.dict(w, d2, nil); err != nil {
+			return err
+		}
+		io.WriteString(w, " ")
+	}
+	io.WriteString(w, ">>")
+
+	return nil
+}
+
+func (ph *pageHasher) page(pageNr int) ([]byte, error) {
+	d, _, inhPAttrs, err := ph.ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: PageDigest: unknown page number: %d", pageNr)
+	}
+
+	h, err := newHash(ph.algo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Page geometry
+	io.WriteString(h, "MediaBox")
+	if err := ph.object(h, inhPAttrs.MediaBox.Array()); err != nil {
+		return nil, err
+	}
+	if inhPAttrs.CropBox != nil {
+		io.WriteString(h, "CropBox")
+		if err := ph.object(h, inhPAttrs.CropBox.Array()); err != nil {
+			return nil, err
+		}
+	}
+	fmt.Fprintf(h, "Rotate%d\n", ((inhPAttrs.Rotate%360)+360)%360)
+
+	io.WriteString(h, "Contents\n")
+	used, err := ph.content(h, d, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	io.WriteString(h, "Resources")
+	if err := ph.resources(h, inhPAttrs.Resources, used); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+func newPageHasher(ctx *model.Context, algo string) (*pageHasher, error) {
+	if _, err := newHash(algo); err != nil {
+		return nil, err
+	}
+	return &pageHasher{ctx: ctx, algo: algo, memo: map[int][]byte{}, inProgress: map[int]bool{}}, nil
+}
+
+// PageDigest returns a hash of the canonicalized content of page pageNr using algo (SHA-1, SHA-256, SHA-384 or SHA-512).
+// The digest covers the page geometry, the normalized content stream and all page resources referenced by it
+// but not annotations and is independent of object numbers and stream encodings.
+func PageDigest(ctx *model.Context, pageNr int, algo string) ([]byte, error) {
+	ph, err := newPageHasher(ctx, algo)
+	if err != nil {
+		return nil, err
+	}
+	return ph.page(pageNr)
+}
+
+// PageHash represents the digest of a single page.
+type PageHash struct {
+	PageNr int    `json:"page"`
+	Digest string `json:"digest"` // hex encoded
+}
+
+// Manifest represents the page digests of a document.
+type Manifest struct {
+	Algorithm string     `json:"algorithm"`
+	Pages     []PageHash `json:"pages"`
+}
+
+// DocumentManifest returns the page digests of ctx using DefaultDigestAlgorithm.
+func DocumentManifest(ctx *model.Context) (*Manifest, error) {
+	ph, err := newPageHasher(ctx, DefaultDigestAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Algorithm: DefaultDigestAlgorithm}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		bb, err := ph.page(pageNr)
+		if err != nil {
+			return nil, err
+		}
+		m.Pages = append(m.Pages, PageHash{PageNr: pageNr, Digest: hex.EncodeToString(bb)})
+	}
+
+	return m, nil
+}
+
+// VerifyManifest returns the numbers of all pages of ctx not matching m including pages missing on either side.
+func VerifyManifest(ctx *model.Context, m Manifest) ([]int, error) {
+	ph, err := newPageHasher(ctx, m.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var pp []int
+
+	seen := map[int]bool{}
+	for _, p := range m.Pages {
+		seen[p.PageNr] = true
+		if p.PageNr < 1 || p.PageNr > ctx.PageCount {
+			pp = append(pp, p.PageNr)
+			continue
+		}
+		bb, err := ph.page(p.PageNr)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(hex.EncodeToString(bb), p.Digest) {
+			pp = append(pp, p.PageNr)
+		}
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if !seen[pageNr] {
+			pp = append(pp, pageNr)
+		}
+	}
+
+	sort.Ints(pp)
+
+	return pp, nil
+}
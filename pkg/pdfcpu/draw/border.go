@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package draw
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Bezier control point distance for approximating quarter circles.
+const kappa = .5523
+
+// Border describes how to stroke the outline of a rectangle or an ellipse.
+type Border struct {
+	Width  float64             // Line width.
+	Color  *color.SimpleColor  // Stroke color, nil for no border.
+	Style  types.LineJoinStyle // Line join style.
+	Dash   []float64           // Dash array, empty for solid lines.
+	Radius float64             // Corner radius for rectangles.
+	Cloudy int                 // Cloudy border intensity 1 or 2, 0 for none.
+}
+
+// CloudyBumpRadius returns the radius of the bumps of a cloudy border of given intensity.
+func CloudyBumpRadius(intensity int) float64 {
+	return 4 * float64(intensity)
+}
+
+// SetDashPattern sets the line dash pattern, an empty dash array restores solid lines.
+func SetDashPattern(w io.Writer, dash []float64, phase float64) {
+	ss := make([]string, len(dash))
+	for i, f := range dash {
+		ss[i] = fmt.Sprintf("%.2f", f)
+	}
+	fmt.Fprintf(w, "[%s] %.2f d ", strings.Join(ss, " "), phase)
+}
+
+// RoundedRectPath appends a closed path for r with rounded corners of radius rad.
+func RoundedRectPath(w io.Writer, r *types.Rectangle, rad float64) {
+	rad = math.Min(rad, math.Min(r.Width(), r.Height())/2)
+	x0, y0, x1, y1 := r.LL.X, r.LL.Y, r.UR.X, r.UR.Y
+	k := kappa * rad
+
+	fmt.Fprintf(w, "%.2f %.2f m ", x0+rad, y0)
+	fmt.Fprintf(w, "%.2f %.2f l ", x1-rad, y0)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x1-rad+k, y0, x1, y0+rad-k, x1, y0+rad)
+	fmt.Fprintf(w, "%.2f %.2f l ", x1, y1-rad)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x1, y1-rad+k, x1-rad+k, y1, x1-rad, y1)
+	fmt.Fprintf(w, "%.2f %.2f l ", x0+rad, y1)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x0+rad-k, y1, x0, y1-rad+k, x0, y1-rad)
+	fmt.Fprintf(w, "%.2f %.2f l ", x0, y0+rad)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c h ", x0, y0+rad-k, x0+rad-k, y0, x0+rad, y0)
+}
+
+// EllipsePath appends a closed path for the ellipse inscribed into r.
+func EllipsePath(w io.Writer, r *types.Rectangle) {
+	cx, cy := r.LL.X+r.Width()/2, r.LL.Y+r.Height()/2
+	rx, ry := r.Width()/2, r.Height()/2
+	kx, ky := kappa*rx, kappa*ry
+
+	fmt.Fprintf(w, "%.2f %.2f m ", cx+rx, cy)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c h ", cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+}
+
+// CloudyPath appends a closed path along the counter clockwise polygon pp made of bumps of radius rad.
+// The bumps extend the polygon by rad.
+func CloudyPath(w io.Writer, pp []types.Point, rad float64) {
+	n := len(pp)
+	if n < 2 || rad <= 0 {
+		return
+	}
+
+	l := 0.
+	for i := range pp {
+		l += math.Hypot(pp[(i+1)%n].X-pp[i].X, pp[(i+1)%n].Y-pp[i].Y)
+	}
+
+	bumps := max(int(math.Ceil(l/(2*rad))), 3)
+	step := l / float64(bumps)
+
+	// Sample the bump endpoints along the polygon.
+	ss := make([]types.Point, 0, bumps)
+	i, pos := 0, 0.
+	for j := 0; j < bumps; j++ {
+		d := float64(j) * step
+		for {
+			p, q := pp[i], pp[(i+1)%n]
+			sl := math.Hypot(q.X-p.X, q.Y-p.Y)
+			if d <= pos+sl || i == n-1 {
+				t := 0.
+				if sl > 0 {
+					t = (d - pos) / sl
+				}
+				ss = append(ss, types.Point{X: p.X + t*(q.X-p.X), Y: p.Y + t*(q.Y-p.Y)})
+				break
+			}
+			pos += sl
+			i++
+		}
+	}
+
+	fmt.Fprintf(w, "%.2f %.2f m ", ss[0].X, ss[0].Y)
+	for j := range ss {
+		p, q := ss[j], ss[(j+1)%len(ss)]
+		dx, dy := q.X-p.X, q.Y-p.Y
+		c := math.Hypot(dx, dy)
+		if c == 0 {
+			continue
+		}
+		// Approximate a half circle over the chord bulging outwards.
+		nx, ny := dy/c, -dx/c
+		off := 2 * c / 3
+		fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", p.X+nx*off, p.Y+ny*off, q.X+nx*off, q.Y+ny*off, q.X, q.Y)
+	}
+	fmt.Fprint(w, "h ")
+}
+
+func rectPolygon(r *types.Rectangle) []types.Point {
+	return []types.Point{
+		{X: r.LL.X, Y: r.LL.Y},
+		{X: r.UR.X, Y: r.LL.Y},
+		{X: r.UR.X, Y: r.UR.Y},
+		{X: r.LL.X, Y: r.UR.Y},
+	}
+}
+
+func ellipsePolygon(r *types.Rectangle) []types.Point {
+	const n = 64
+	cx, cy := r.LL.X+r.Width()/2, r.LL.Y+r.Height()/2
+	pp := make([]types.Point, n)
+	for i := range pp {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / n)
+		pp[i] = types.Point{X: cx + cos*r.Width()/2, Y: cy + sin*r.Height()/2}
+	}
+	return pp
+}
+
+func insetRect(r *types.Rectangle, d float64) *types.Rectangle {
+	d = math.Min(d, math.Min(r.Width(), r.Height())/2)
+	return types.NewRectangle(r.LL.X+d, r.LL.Y+d, r.UR.X-d, r.UR.Y-d)
+}
+
+func (b Border) begin(w io.Writer, fillCol *color.SimpleColor) string {
+	fmt.Fprint(w, "q ")
+
+	stroke := b.Color != nil && b.Width > 0
+	if stroke {
+		SetLineWidth(w, b.Width)
+		SetStrokeColor(w, *b.Color)
+		SetLineJoinStyle(w, b.Style)
+		if len(b.Dash) > 0 {
+			SetDashPattern(w, b.Dash, 0)
+		}
+	}
+	if fillCol != nil {
+		SetFillColor(w, *fillCol)
+	}
+
+	switch {
+	case stroke && fillCol != nil:
+		return "B"
+	case stroke:
+		return "S"
+	case fillCol != nil:
+		return "f"
+	}
+	return "n"
+}
+
+// DrawBorderedRect paints r using border b and an optional fill color.
+// Cloudy borders stay within r.
+func DrawBorderedRect(w io.Writer, r *types.Rectangle, b Border, fillCol *color.SimpleColor) {
+	op := b.begin(w, fillCol)
+
+	switch {
+	case b.Cloudy > 0:
+		rad := CloudyBumpRadius(b.Cloudy)
+		CloudyPath(w, rectPolygon(insetRect(r, rad)), rad)
+	case b.Radius > 0:
+		RoundedRectPath(w, r, b.Radius)
+	default:
+		fmt.Fprintf(w, "%.2f %.2f %.2f %.2f re ", r.LL.X, r.LL.Y, r.Width(), r.Height())
+	}
+
+	fmt.Fprintf(w, "%s Q ", op)
+}
+
+// DrawBorderedEllipse paints the ellipse inscribed into r using border b and an optional fill color.
+// Cloudy borders stay within r.
+func DrawBorderedEllipse(w io.Writer, r *types.Rectangle, b Border, fillCol *color.SimpleColor) {
+	op := b.begin(w, fillCol)
+
+	if b.Cloudy > 0 {
+		rad := CloudyBumpRadius(b.Cloudy)
+		CloudyPath(w, ellipsePolygon(insetRect(r, rad)), rad)
+	} else {
+		EllipsePath(w, r)
+	}
+
+	fmt.Fprintf(w, "%s Q ", op)
+}
@@ -110,6 +110,28 @@ func FillRect(w io.Writer, r *types.Rectangle, lineWidth float64, strokeCol *col
 	fmt.Fprintf(w, "Q ")
 }
 
+// SetFillPattern sets the pattern registered as resource id for filling.
+func SetFillPattern(w io.Writer, id string) {
+	fmt.Fprintf(w, "/Pattern cs /%s scn ", id)
+}
+
+// FillRectWithPattern fills a rectangular path for r using the pattern id and strokes it using lineWidth, strokeCol and style.
+func FillRectWithPattern(w io.Writer, r *types.Rectangle, lineWidth float64, strokeCol *color.SimpleColor, id string, style *types.LineJoinStyle) {
+	fmt.Fprintf(w, "q ")
+	SetFillPattern(w, id)
+	op := "f"
+	if strokeCol != nil && lineWidth > 0 {
+		SetLineWidth(w, lineWidth)
+		SetStrokeColor(w, *strokeCol)
+		op = "B"
+	}
+	if style != nil {
+		SetLineJoinStyle(w, *style)
+	}
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f re %s ", r.LL.X, r.LL.Y, r.Width(), r.Height(), op)
+	fmt.Fprintf(w, "Q ")
+}
+
 // DrawCircle strokes a circle with optional filling.
 func DrawCircle(w io.Writer, x, y, r float64, strokeCol color.SimpleColor, fillCol *color.SimpleColor) {
 	f := .5523
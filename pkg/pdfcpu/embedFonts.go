@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// FontResolver returns the name of an installed TrueType font to be embedded in place of the unembedded font baseFont
+// or "" if there is no suitable font.
+// User supplied font files need to be installed beforehand (see api.InstallFonts).
+type FontResolver func(baseFont string) string
+
+// Metric compatible replacements for the 14 standard Type 1 fonts in order of preference.
+var coreFontReplacements = map[string][]string{
+	"Helvetica":             {"LiberationSans", "ArialMT", "Arial", "NimbusSans-Regular", "Helvetica"},
+	"Helvetica-Bold":        {"LiberationSans-Bold", "Arial-BoldMT", "Arial-Bold", "NimbusSans-Bold", "Helvetica-Bold"},
+	"Helvetica-Oblique":     {"LiberationSans-Italic", "Arial-ItalicMT", "Arial-Italic", "NimbusSans-Italic", "Helvetica-Oblique"},
+	"Helvetica-BoldOblique": {"LiberationSans-BoldItalic", "Arial-BoldItalicMT", "Arial-BoldItalic", "NimbusSans-BoldItalic", "Helvetica-BoldOblique"},
+	"Times-Roman":           {"LiberationSerif", "TimesNewRomanPSMT", "TimesNewRoman", "NimbusRoman-Regular", "Times-Roman"},
+	"Times-Bold":            {"LiberationSerif-Bold", "TimesNewRomanPS-BoldMT", "TimesNewRoman-Bold", "NimbusRoman-Bold", "Times-Bold"},
+	"Times-Italic":          {"LiberationSerif-Italic", "TimesNewRomanPS-ItalicMT", "TimesNewRoman-Italic", "NimbusRoman-Italic", "Times-Italic"},
+	"Times-BoldItalic":      {"LiberationSerif-BoldItalic", "TimesNewRomanPS-BoldItalicMT", "TimesNewRoman-BoldItalic", "NimbusRoman-BoldItalic", "Times-BoldItalic"},
+	"Courier":               {"LiberationMono", "CourierNewPSMT", "CourierNew", "NimbusMonoPS-Regular", "Courier"},
+	"Courier-Bold":          {"LiberationMono-Bold", "CourierNewPS-BoldMT", "CourierNew-Bold", "NimbusMonoPS-Bold", "Courier-Bold"},
+	"Courier-Oblique":       {"LiberationMono-Italic", "CourierNewPS-ItalicMT", "CourierNew-Italic", "NimbusMonoPS-Italic", "Courier-Oblique"},
+	"Courier-BoldOblique":   {"LiberationMono-BoldItalic", "CourierNewPS-BoldItalicMT", "CourierNew-BoldItalic", "NimbusMonoPS-BoldItalic", "Courier-BoldOblique"},
+}
+
+// Common aliases for the 14 standard Type 1 fonts.
+var coreFontAliases = map[string]string{
+	"Arial":            "Helvetica",
+	"Arial,Bold":       "Helvetica-Bold",
+	"Arial,Italic":     "Helvetica-Oblique",
+	"Arial,BoldItalic": "Helvetica-BoldOblique",
+	"TimesNewRoman":    "Times-Roman",
+	"Times":            "Times-Roman",
+	"CourierNew":       "Courier",
+}
+
+// DefaultFontResolver resolves baseFont to an installed font of the same name
+// or to an installed metric compatible replacement for the 14 standard Type 1 fonts.
+func DefaultFontResolver(baseFont string) string {
+	if i := strings.IndexByte(baseFont, '+'); i == 6 {
+		baseFont = baseFont[i+1:]
+	}
+
+	candidates := []string{baseFont, strings.ReplaceAll(baseFont, ",", "-")}
+
+	core := baseFont
+	if s, ok := coreFontAliases[baseFont]; ok {
+		core = s
+	}
+	candidates = append(candidates, coreFontReplacements[core]...)
+
+	for _, fontName := range candidates {
+		if font.IsUserFont(fontName) {
+			return fontName
+		}
+	}
+
+	return ""
+}
+
+// FontEmbedding describes an unembedded font processed by EmbedMissingFonts.
+type FontEmbedding struct {
+	ObjNr    int    // object number of the font dict
+	BaseFont string // base font name
+	FontName string // embedded font, empty if not embedded
+	Reason   string // why the font could not be embedded
+}
+
+// Embedded returns true if a font program got embedded for this font.
+func (fe FontEmbedding) Embedded() bool {
+	return fe.FontName != ""
+}
+
+// glyphNameForRune returns the glyph name for r following the conventions of the Adobe Glyph List.
+func glyphNameForRune(r rune) string {
+	var names []string
+	for s, r1 := range glyphNames {
+		if r1 == r {
+			names = append(names, s)
+		}
+	}
+	if len(names) == 0 {
+		if r > 0xFFFF {
+			return fmt.Sprintf("u%X", r)
+		}
+		return fmt.Sprintf("uni%04X", r)
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// trueTypeEncoding returns an encoding selecting the glyphs for the Unicode characters of tum
+// from a non-symbolic TrueType font program via its Unicode cmap.
+func trueTypeEncoding(tum *ToUnicodeMap, first, last int) types.Object {
+	var diffs types.Array
+	next := -1
+	for c := first; c <= last; c++ {
+		s, ok := tum.M[uint32(c)]
+		rr := []rune(s)
+		if !ok || len(rr) != 1 {
+			continue
+		}
+		if s1, ok := baseEncodingText("WinAnsiEncoding", byte(c)); ok && s1 == s {
+			continue
+		}
+		if c != next {
+			diffs = append(diffs, types.Integer(c))
+		}
+		diffs = append(diffs, types.Name(glyphNameForRune(rr[0])))
+		next = c + 1
+	}
+
+	if len(diffs) == 0 {
+		return types.Name("WinAnsiEncoding")
+	}
+
+	return types.Dict(map[string]types.Object{
+		"Type":         types.Name("Encoding"),
+		"BaseEncoding": types.Name("WinAnsiEncoding"),
+		"Differences":  diffs,
+	})
+}
+
+// embedTrueTypeFont turns the unembedded simple font d into a TrueType font embedding fontName.
+func embedTrueTypeFont(xRefTable *model.XRefTable, d types.Dict, ttf font.TTFLight, fontName string, fdIndRef *types.IndirectRef) (*types.IndirectRef, string, error) {
+	tum, _, err := simpleFontToUnicode(xRefTable, d)
+	if err != nil {
+		return nil, "", err
+	}
+	if tum == nil {
+		return nil, "symbolic font", nil
+	}
+
+	first, last := 0, 255
+	if i := d.IntEntry("FirstChar"); i != nil && *i >= 0 && *i < 256 {
+		first = *i
+	}
+	if i := d.IntEntry("LastChar"); i != nil && *i >= first && *i < 256 {
+		last = *i
+	}
+
+	if fdIndRef == nil {
+		var lang string
+		if fd := fontDescriptor(xRefTable, d); fd != nil && fd.NameEntry("Lang") != nil {
+			lang = *fd.NameEntry("Lang")
+		}
+		var err error
+		if fdIndRef, err = pdffont.NewFontDescriptor(xRefTable, ttf, fontName, lang); err != nil {
+			return nil, "", err
+		}
+	}
+
+	w := types.Array{}
+	for c := first; c <= last; c++ {
+		gid := uint16(0)
+		if rr := []rune(tum.M[uint32(c)]); len(rr) == 1 {
+			gid = ttf.Chars[uint32(rr[0])]
+		}
+		w = append(w, types.Integer(ttf.GlyphWidths[gid]))
+	}
+
+	// Keep text extraction working as before.
+	if _, found := d.Find("ToUnicode"); !found {
+		indRef, err := xRefTable.StreamDictIndRef(tum.Bytes())
+		if err != nil {
+			return nil, "", err
+		}
+		d["ToUnicode"] = *indRef
+	}
+
+	d["Subtype"] = types.Name("TrueType")
+	d["BaseFont"] = types.Name(fontName)
+	d["Encoding"] = trueTypeEncoding(tum, first, last)
+	d["FirstChar"] = types.Integer(first)
+	d["LastChar"] = types.Integer(last)
+	d["Widths"] = w
+	d["FontDescriptor"] = *fdIndRef
+
+	return fdIndRef, "", nil
+}
+
+// EmbedMissingFonts embeds font programs for all unembedded simple fonts (Type1, MMType1 and TrueType)
+// using the installed TrueType fonts returned by resolve and updates their font descriptors and widths.
+// DefaultFontResolver is used if resolve is nil.
+// Unembedded Type0 fonts are reported but left untouched.
+func EmbedMissingFonts(ctx *model.Context, resolve FontResolver) ([]FontEmbedding, error) {
+	if resolve == nil {
+		resolve = DefaultFontResolver
+	}
+
+	xRefTable := ctx.XRefTable
+
+	objNrs := []int{}
+	for objNr, entry := range xRefTable.Table {
+		if entry == nil || entry.Free {
+			continue
+		}
+		if d, ok := entry.Object.(types.Dict); ok && d.Type() != nil && *d.Type() == "Font" {
+			objNrs = append(objNrs, objNr)
+		}
+	}
+	sort.Ints(objNrs)
+
+	// Embed each font program once.
+	fontDescriptors := map[string]*types.IndirectRef{}
+
+	fes := []FontEmbedding{}
+
+	for _, objNr := range objNrs {
+		d := xRefTable.Table[objNr].Object.(types.Dict)
+
+		st := d.Subtype()
+		if st == nil || *st == "Type3" || *st == "CIDFontType0" || *st == "CIDFontType2" {
+			// CIDFonts are covered by their Type0 font.
+			continue
+		}
+
+		embedded, err := pdffont.Embedded(xRefTable, d, objNr)
+		if err != nil {
+			return nil, err
+		}
+		if embedded {
+			continue
+		}
+
+		fe := FontEmbedding{ObjNr: objNr}
+		if bf := d.NameEntry("BaseFont"); bf != nil {
+			fe.BaseFont = *bf
+		}
+
+		fontName := resolve(fe.BaseFont)
+
+		switch {
+
+		case *st == "Type0":
+			fe.Reason = "unsupported font type Type0"
+
+		case fontName == "":
+			fe.Reason = "no replacement font available"
+
+		default:
+			font.UserFontMetricsLock.RLock()
+			ttf, ok := font.UserFontMetrics[fontName]
+			font.UserFontMetricsLock.RUnlock()
+			if !ok {
+				fe.Reason = fmt.Sprintf("font %s not installed", fontName)
+				break
+			}
+			if ttf.Protected {
+				fe.Reason = fmt.Sprintf("font %s does not permit embedding", fontName)
+				break
+			}
+			fdIndRef, reason, err := embedTrueTypeFont(xRefTable, d, ttf, fontName, fontDescriptors[fontName])
+			if err != nil {
+				return nil, err
+			}
+			if fdIndRef != nil {
+				fontDescriptors[fontName] = fdIndRef
+				fe.FontName = fontName
+			}
+			fe.Reason = reason
+		}
+
+		fes = append(fes, fe)
+	}
+
+	return fes, nil
+}
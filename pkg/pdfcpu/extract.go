@@ -18,13 +18,15 @@ package pdfcpu
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/log"
-	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -391,6 +393,14 @@ func decodeImage(ctx *model.Context, sd *types.StreamDict, filters, lastFilter s
 	return nil
 }
 
+func hasImageMask(sd *types.StreamDict) bool {
+	if _, found := sd.Find("SMask"); found {
+		return true
+	}
+	_, found := sd.Find("Mask")
+	return found
+}
+
 func img(
 	ctx *model.Context,
 	sd *types.StreamDict,
@@ -399,6 +409,9 @@ func img(
 	objNr int) (*model.Image, error) {
 
 	if sd.FilterPipeline == nil {
+		if err := sd.Load(); err != nil {
+			return nil, err
+		}
 		sd.Content = sd.Raw
 	} else {
 		if err := decodeImage(ctx, sd, filters, lastFilter, objNr); err != nil {
@@ -406,9 +419,22 @@ func img(
 		}
 	}
 
-	r, t, err := RenderImage(ctx.XRefTable, sd, thumb, resourceID, objNr)
-	if err != nil {
-		return nil, err
+	var (
+		r   io.Reader
+		t   string
+		err error
+	)
+
+	if ctx.ComposeImageMasks && hasImageMask(sd) {
+		if r, t, err = ComposeImage(ctx.XRefTable, sd, thumb, objNr); err != nil {
+			return nil, err
+		}
+	}
+
+	if r == nil {
+		if r, t, err = RenderImage(ctx.XRefTable, sd, thumb, resourceID, objNr); err != nil {
+			return nil, err
+		}
 	}
 
 	img := &model.Image{
@@ -474,8 +500,12 @@ func ExtractPageImages(ctx *model.Context, pageNr int, stub bool) (map[int]model
 // Font is a Reader representing an embedded font.
 type Font struct {
 	io.Reader
-	Name string
-	Type string
+	Name     string // PostScript name without subset prefix
+	Type     string // File type: ttf, otf, cff, pfb or pfa
+	ObjNr    int    // Object number of the font dict
+	Prefix   string // Subset prefix eg. ABCDEF
+	Subtype  string // PDF font type eg. TrueType, Type1 or Type0
+	Encoding string // Encoding or CMap name, "built-in" for the font program's own encoding
 }
 
 // FontObjNrs returns all font dict objNrs for pageNr.
@@ -505,57 +535,215 @@ func FontObjNrs(ctx *model.Context, pageNr int) []int {
 	return objNrs
 }
 
-// ExtractFont extracts a font from fontObject.
-func ExtractFont(ctx *model.Context, fontObject model.FontObject, objNr int) (*Font, error) {
-	d, err := font.FontDescriptor(ctx.XRefTable, fontObject.FontDict, objNr)
-	if err != nil {
+func descendantFont(xRefTable *model.XRefTable, d types.Dict) (types.Dict, error) {
+	a, err := xRefTable.DereferenceArray(d["DescendantFonts"])
+	if err != nil || len(a) == 0 {
 		return nil, err
 	}
+	return xRefTable.DereferenceDict(a[0])
+}
 
-	if d == nil {
-		if log.DebugEnabled() {
-			log.Debug.Printf("ExtractFont: ignoring obj#%d - no fontDescriptor available for font: %s\n", objNr, fontObject.FontName)
+// fontEncoding returns a description of the encoding of the font dict d.
+func fontEncoding(xRefTable *model.XRefTable, d types.Dict) string {
+	o, err := xRefTable.Dereference(d["Encoding"])
+	if err != nil {
+		return ""
+	}
+
+	switch enc := o.(type) {
+
+	case types.Name:
+		return enc.Value()
+
+	case types.StreamDict:
+		// Embedded CMap
+		if n := enc.NameEntry("CMapName"); n != nil {
+			return *n
 		}
-		return nil, nil
+		return "embedded CMap"
+
+	case types.Dict:
+		s := "built-in"
+		if n := enc.NameEntry("BaseEncoding"); n != nil {
+			s = *n
+		}
+		if _, found := enc.Find("Differences"); found {
+			s += " with Differences"
+		}
+		return s
 	}
 
-	ir := fontDescriptorFontFileIndirectObjectRef(d)
+	return "built-in"
+}
+
+// fontFileLength returns the value of the integer entry key of a FontFile stream dict.
+func fontFileLength(xRefTable *model.XRefTable, sd *types.StreamDict, key string) int {
+	i, err := xRefTable.DereferenceInteger(sd.Dict[key])
+	if err != nil || i == nil {
+		return 0
+	}
+	return i.Value()
+}
+
+// type1Font returns the Type 1 font program of sd as PFB (Printer Font Binary)
+// or as is if its segments are unknown.
+func type1Font(xRefTable *model.XRefTable, sd *types.StreamDict) ([]byte, string) {
+	bb := sd.Content
+	l1, l2, l3 := fontFileLength(xRefTable, sd, "Length1"), fontFileLength(xRefTable, sd, "Length2"), fontFileLength(xRefTable, sd, "Length3")
+	if l1 <= 0 || l2 <= 0 || l3 < 0 || l1+l2+l3 > len(bb) {
+		return bb, "pfa"
+	}
+
+	trailer := bb[l1+l2 : l1+l2+l3]
+	if l3 == 0 {
+		trailer = append(bytes.Repeat([]byte("0000000000000000000000000000000000000000000000000000000000000000\n"), 8), "cleartomark\n"...)
+	}
+
+	var buf bytes.Buffer
+	segment := func(typ byte, b []byte) {
+		buf.Write([]byte{0x80, typ})
+		binary.Write(&buf, binary.LittleEndian, uint32(len(b)))
+		buf.Write(b)
+	}
+	segment(1, bb[:l1])
+	segment(2, bb[l1:l1+l2])
+	segment(1, trailer)
+	buf.Write([]byte{0x80, 3})
+
+	return buf.Bytes(), "pfb"
+}
+
+// cidFontChars returns the Unicode characters of the CIDFontType2 font d mapped to glyph ids
+// using its ToUnicode CMap and the CIDToGIDMap of its descendant font cd.
+func cidFontChars(xRefTable *model.XRefTable, d, cd types.Dict) (map[uint32]uint16, error) {
+	chars := map[uint32]uint16{}
+
+	if enc := d.NameEntry("Encoding"); enc == nil || *enc != "Identity-H" && *enc != "Identity-V" {
+		return chars, nil
+	}
+
+	tum, err := ToUnicodeMapForFontDict(xRefTable, d)
+	if err != nil || tum == nil {
+		return chars, err
+	}
+
+	// CID => GID, missing or Identity for CID == GID.
+	var cidToGID []byte
+	if sd, _, err := xRefTable.DereferenceStreamDict(cd["CIDToGIDMap"]); err == nil && sd != nil {
+		if err := sd.Decode(); err != nil {
+			return nil, err
+		}
+		cidToGID = sd.Content
+	}
+
+	for cid, s := range tum.M {
+		rr := []rune(s)
+		if len(rr) != 1 || cid > 0xFFFF {
+			continue
+		}
+		gid := uint16(cid)
+		if cidToGID != nil {
+			if int(2*cid+1) >= len(cidToGID) {
+				continue
+			}
+			gid = uint16(cidToGID[2*cid])<<8 | uint16(cidToGID[2*cid+1])
+		}
+		if g, ok := chars[uint32(rr[0])]; gid > 0 && (!ok || gid < g) {
+			chars[uint32(rr[0])] = gid
+		}
+	}
+
+	return chars, nil
+}
+
+// fontProgram returns the embedded font program of the font dict d as a font file and its file type.
+func fontProgram(ctx *model.Context, d, fd types.Dict, fontObject model.FontObject, objNr int) ([]byte, string, error) {
+	var (
+		key string
+		ir  *types.IndirectRef
+	)
+	for _, k := range []string{"FontFile", "FontFile2", "FontFile3"} {
+		if ir = fd.IndirectRefEntry(k); ir != nil {
+			key = k
+			break
+		}
+	}
 	if ir == nil {
 		if log.DebugEnabled() {
 			log.Debug.Printf("ExtractFont: ignoring obj#%d - no font file available for font: %s\n", objNr, fontObject.FontName)
 		}
-		return nil, nil
+		return nil, "", nil
 	}
 
-	var f *Font
+	sd, _, err := ctx.DereferenceStreamDict(*ir)
+	if err != nil {
+		return nil, "", err
+	}
+	if sd == nil {
+		return nil, "", errors.Errorf("extractFontData: corrupt font obj#%d for font: %s\n", objNr, fontObject.FontName)
+	}
 
-	fontType := fontObject.SubType()
+	// Decode streamDict if used filter is supported only.
+	err = sd.Decode()
+	if err == filter.ErrUnsupportedFilter {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch key {
 
-	switch fontType {
+	case "FontFile":
+		bb, typ := type1Font(ctx.XRefTable, sd)
+		return bb, typ, nil
 
-	case "TrueType":
+	case "FontFile2":
 		// ttf ... true type file
-		// ttc ... true type collection
-		sd, _, err := ctx.DereferenceStreamDict(*ir)
-		if err != nil {
-			return nil, err
+		if fontObject.SubType() != "Type0" {
+			return sd.Content, "ttf", nil
 		}
-		if sd == nil {
-			return nil, errors.Errorf("extractFontData: corrupt font obj#%d for font: %s\n", objNr, fontObject.FontName)
+		cd, err := descendantFont(ctx.XRefTable, d)
+		if err != nil || cd == nil {
+			return sd.Content, "ttf", err
 		}
-
-		// Decode streamDict if used filter is supported only.
-		err = sd.Decode()
-		if err == filter.ErrUnsupportedFilter {
-			return nil, nil
+		chars, err := cidFontChars(ctx.XRefTable, d, cd)
+		if err != nil {
+			return nil, "", err
 		}
+		// Subset font programs usually lack the tables needed for installation.
+		bb, err := font.CompleteTrueType(sd.Content, fontObject.FontName, chars)
 		if err != nil {
-			return nil, err
+			if log.DebugEnabled() {
+				log.Debug.Printf("ExtractFont: obj#%d - unable to complete font program: %v\n", objNr, err)
+			}
+			bb = sd.Content
 		}
+		return bb, "ttf", nil
+	}
 
-		f = &Font{bytes.NewReader(sd.Content), fontObject.FontName, "ttf"}
+	// FontFile3
+	st := sd.Dict.NameEntry("Subtype")
+	if st == nil {
+		return nil, "", nil
+	}
 
-	default:
+	switch *st {
+	case "Type1C", "CIDFontType0C":
+		return sd.Content, "cff", nil
+	case "OpenType":
+		return sd.Content, "otf", nil
+	}
+
+	return nil, "", nil
+}
+
+// ExtractFont extracts a font from fontObject.
+// Embedded TrueType, OpenType, CFF and Type 1 font programs are returned as font files.
+func ExtractFont(ctx *model.Context, fontObject model.FontObject, objNr int) (*Font, error) {
+	fontType := fontObject.SubType()
+
+	if fontType == "Type3" {
 		s := fmt.Sprintf("extractFontData: obj#%d - unsupported fonttype %s -  font: %s\n", objNr, fontType, fontObject.FontName)
 		if log.InfoEnabled() {
 			log.Info.Println(s)
@@ -566,7 +754,32 @@ func ExtractFont(ctx *model.Context, fontObject model.FontObject, objNr int) (*F
 		return nil, nil
 	}
 
-	return f, nil
+	fd, err := pdffont.FontDescriptor(ctx.XRefTable, fontObject.FontDict, objNr)
+	if err != nil {
+		return nil, err
+	}
+
+	if fd == nil {
+		if log.DebugEnabled() {
+			log.Debug.Printf("ExtractFont: ignoring obj#%d - no fontDescriptor available for font: %s\n", objNr, fontObject.FontName)
+		}
+		return nil, nil
+	}
+
+	bb, typ, err := fontProgram(ctx, fontObject.FontDict, fd, fontObject, objNr)
+	if err != nil || bb == nil {
+		return nil, err
+	}
+
+	return &Font{
+		Reader:   bytes.NewReader(bb),
+		Name:     fontObject.FontName,
+		Type:     typ,
+		ObjNr:    objNr,
+		Prefix:   fontObject.Prefix,
+		Subtype:  fontType,
+		Encoding: fontEncoding(ctx.XRefTable, fontObject.FontDict),
+	}, nil
 }
 
 // ExtractPageFonts extracts all fonts used by pageNr.
@@ -606,6 +819,38 @@ func ExtractFormFonts(ctx *model.Context) ([]Font, error) {
 	return ff, nil
 }
 
+// ExtractFonts extracts the embedded fonts used by selectedPages and by form fields as font files.
+// All pages are processed if selectedPages is empty.
+// Requires an optimized context.
+func ExtractFonts(ctx *model.Context, selectedPages types.IntSet) ([]Font, error) {
+	objNrs, skipped := types.IntSet{}, types.IntSet{}
+
+	ff := []Font{}
+	for i := 1; i <= ctx.PageCount; i++ {
+		if len(selectedPages) > 0 && !selectedPages[i] {
+			continue
+		}
+		ff1, err := ExtractPageFonts(ctx, i, objNrs, skipped)
+		if err != nil {
+			return nil, err
+		}
+		ff = append(ff, ff1...)
+	}
+
+	ff1, err := ExtractFormFonts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range ff1 {
+		if !objNrs[f.ObjNr] {
+			ff = append(ff, f)
+			objNrs[f.ObjNr] = true
+		}
+	}
+
+	return ff, nil
+}
+
 // ExtractPages extracts pageNrs into a new single page context.
 func ExtractPages(ctx *model.Context, pageNrs []int, usePgCache bool) (*model.Context, error) {
 	ctxDest, err := CreateContextWithXRefTable(ctx.Conf, types.PaperSize["A4"])
@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// PredefinedCMap describes a predefined CMap (see 9.7.5.2 Predefined CMaps, Table 118).
+type PredefinedCMap struct {
+	Name     string
+	Ordering string // Ordering of the Adobe character collection, "Identity" for Identity-H and Identity-V
+	Encoding string // Encoding of the character codes, "CID" for Identity-H and Identity-V
+	Vertical bool   // Writing mode
+}
+
+// Predefined CMaps by character collection and encoding, each one available for horizontal (-H) and vertical (-V) writing
+// unless noted by a trailing "-H".
+var predefinedCMapNames = []struct {
+	ordering, encoding string
+	names              []string
+}{
+	{"GB1", "GB2312", []string{"GB-EUC", "GBpc-EUC"}},
+	{"GB1", "GBK", []string{"GBK-EUC", "GBKp-EUC"}},
+	{"GB1", "GB18030", []string{"GBK2K"}},
+	{"GB1", "UCS-2", []string{"UniGB-UCS2"}},
+	{"GB1", "UTF-16", []string{"UniGB-UTF16"}},
+	{"CNS1", "Big5", []string{"B5pc", "HKscs-B5", "ETen-B5", "ETenms-B5"}},
+	{"CNS1", "EUC-TW", []string{"CNS-EUC"}},
+	{"CNS1", "UCS-2", []string{"UniCNS-UCS2"}},
+	{"CNS1", "UTF-16", []string{"UniCNS-UTF16"}},
+	{"Japan1", "Shift_JIS", []string{"83pv-RKSJ-H", "90ms-RKSJ", "90msp-RKSJ", "90pv-RKSJ-H", "Add-RKSJ", "Ext-RKSJ"}},
+	{"Japan1", "EUC-JP", []string{"EUC"}},
+	{"Japan1", "ISO-2022-JP", []string{""}},
+	{"Japan1", "UCS-2", []string{"UniJIS-UCS2", "UniJIS-UCS2-HW"}},
+	{"Japan1", "UTF-16", []string{"UniJIS-UTF16"}},
+	{"Korea1", "EUC-KR", []string{"KSC-EUC", "KSCms-UHC", "KSCms-UHC-HW", "KSCpc-EUC-H"}},
+	{"Korea1", "UCS-2", []string{"UniKS-UCS2"}},
+	{"Korea1", "UTF-16", []string{"UniKS-UTF16"}},
+	{"Identity", "CID", []string{"Identity"}},
+}
+
+var predefinedCMaps = map[string]PredefinedCMap{}
+
+func init() {
+	for _, e := range predefinedCMapNames {
+		for _, s := range e.names {
+			if strings.HasSuffix(s, "-H") {
+				predefinedCMaps[s] = PredefinedCMap{Name: s, Ordering: e.ordering, Encoding: e.encoding}
+				continue
+			}
+			h, v := s+"-H", s+"-V"
+			if s == "" {
+				// The JIS X 0208 CMaps are just called H and V.
+				h, v = "H", "V"
+			}
+			predefinedCMaps[h] = PredefinedCMap{Name: h, Ordering: e.ordering, Encoding: e.encoding}
+			predefinedCMaps[v] = PredefinedCMap{Name: v, Ordering: e.ordering, Encoding: e.encoding, Vertical: true}
+		}
+	}
+}
+
+// PredefinedCMapByName returns the predefined CMap name.
+func PredefinedCMapByName(name string) (PredefinedCMap, bool) {
+	cm, ok := predefinedCMaps[name]
+	return cm, ok
+}
+
+// IsPredefinedCMap returns true if name is the name of a predefined CMap.
+func IsPredefinedCMap(name string) bool {
+	_, ok := predefinedCMaps[name]
+	return ok
+}
+
+// codeLen returns the length of the character code starting with bb.
+func (cm PredefinedCMap) codeLen(bb []byte) int {
+	b := bb[0]
+
+	switch cm.Encoding {
+
+	case "CID", "UCS-2", "ISO-2022-JP":
+		return 2
+
+	case "UTF-16":
+		if b >= 0xD8 && b <= 0xDB {
+			return 4
+		}
+		return 2
+
+	case "GB18030":
+		if b >= 0x81 && b <= 0xFE && len(bb) > 1 && bb[1] >= 0x30 && bb[1] <= 0x39 {
+			return 4
+		}
+		if b >= 0x81 && b <= 0xFE {
+			return 2
+		}
+
+	case "GB2312", "GBK", "Big5", "EUC-KR":
+		if b >= 0x81 && b <= 0xFE {
+			return 2
+		}
+
+	case "EUC-TW":
+		if b == 0x8E {
+			return 4
+		}
+		if b >= 0xA1 && b <= 0xFE {
+			return 2
+		}
+
+	case "EUC-JP":
+		if b == 0x8F {
+			return 3
+		}
+		if b == 0x8E || b >= 0xA1 && b <= 0xFE {
+			return 2
+		}
+
+	case "Shift_JIS":
+		if b >= 0x81 && b <= 0x9F || b >= 0xE0 && b <= 0xFC {
+			return 2
+		}
+	}
+
+	return 1
+}
+
+// Codes splits the bytes of a string shown using cm into character codes according to the codespace ranges of cm.
+func (cm PredefinedCMap) Codes(bb []byte) [][]byte {
+	cc := [][]byte{}
+	for len(bb) > 0 {
+		l := min(cm.codeLen(bb), len(bb))
+		cc = append(cc, bb[:l])
+		bb = bb[l:]
+	}
+	return cc
+}
+
+// CodeBytes returns the bytes of the character code c of cm.
+func (cm PredefinedCMap) CodeBytes(c uint32) []byte {
+	for n := 1; n < 4; n++ {
+		bb := make([]byte, n)
+		for i := range bb {
+			bb[n-1-i] = byte(c >> (8 * i))
+		}
+		if c>>(8*n) == 0 && cm.codeLen(bb) == n {
+			return bb
+		}
+	}
+	return []byte{byte(c >> 24), byte(c >> 16), byte(c >> 8), byte(c)}
+}
+
+func (cm PredefinedCMap) decoder() *encoding.Decoder {
+	switch cm.Encoding {
+	case "GB2312", "GBK":
+		return simplifiedchinese.GBK.NewDecoder()
+	case "GB18030":
+		return simplifiedchinese.GB18030.NewDecoder()
+	case "Big5":
+		return traditionalchinese.Big5.NewDecoder()
+	case "Shift_JIS":
+		return japanese.ShiftJIS.NewDecoder()
+	case "EUC-JP":
+		return japanese.EUCJP.NewDecoder()
+	case "ISO-2022-JP":
+		return japanese.ISO2022JP.NewDecoder()
+	case "EUC-KR":
+		return korean.EUCKR.NewDecoder()
+	}
+	return nil
+}
+
+// Text returns the Unicode text for the character code c of cm or "" if unknown.
+// Character codes of Identity-H and Identity-V are CIDs and need a ToUnicode CMap.
+func (cm PredefinedCMap) Text(c []byte) string {
+	switch cm.Encoding {
+
+	case "CID", "EUC-TW":
+		return ""
+
+	case "UCS-2", "UTF-16":
+		u := make([]uint16, 0, len(c)/2)
+		for i := 0; i+1 < len(c); i += 2 {
+			u = append(u, uint16(c[i])<<8|uint16(c[i+1]))
+		}
+		return string(utf16.Decode(u))
+
+	case "ISO-2022-JP":
+		c = append(append([]byte("\x1b$B"), c...), "\x1b(B"...)
+	}
+
+	s, err := cm.decoder().String(string(c))
+	if err != nil || strings.ContainsRune(s, '�') {
+		return ""
+	}
+
+	return s
+}
+
+// HalfWidth returns true if the character code c of cm selects a half-width glyph
+// as is the case for the single-byte codes of multi-byte encodings and the ASCII range of Unicode based encodings.
+func (cm PredefinedCMap) HalfWidth(c []byte) bool {
+	switch cm.Encoding {
+
+	case "CID":
+		return false
+
+	case "UCS-2", "UTF-16":
+		if len(c) != 2 {
+			return false
+		}
+		u := uint16(c[0])<<8 | uint16(c[1])
+		return u >= 0x20 && u < 0x7F || u >= 0xFF61 && u <= 0xFF9F
+
+	case "EUC-JP":
+		// Half-width Katakana
+		return len(c) == 1 || len(c) == 2 && c[0] == 0x8E
+	}
+
+	return len(c) == 1
+}
@@ -0,0 +1,394 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// MissingGlyph is a character code shown using a font lacking a glyph for it.
+type MissingGlyph struct {
+	Code uint32 // character code
+	Text string // Unicode text of the character code if known
+}
+
+// FontGlyphCoverage compares the character codes of an embedded font referenced by content streams
+// with the glyphs present in its font program.
+type FontGlyphCoverage struct {
+	ObjNr      int            // object number of the font dict
+	BaseFont   string         // base font name
+	Subtype    string         // font type
+	Pages      []int          // pages using the font
+	Referenced int            // distinct character codes shown
+	Glyphs     int            // glyphs of the font program
+	Missing    []MissingGlyph // character codes rendered using the .notdef glyph
+	Reason     string         // why the font program could not be inspected
+}
+
+// Checked returns true if the glyphs of the font program could be inspected.
+func (fc FontGlyphCoverage) Checked() bool {
+	return fc.Reason == ""
+}
+
+// Complete returns true if the font program has a glyph for all character codes shown.
+func (fc FontGlyphCoverage) Complete() bool {
+	return fc.Checked() && len(fc.Missing) == 0
+}
+
+type fontUsage struct {
+	f     *svgFont
+	codes map[uint32]bool
+	pages types.IntSet
+}
+
+// fontUsageCollector records the character codes shown per font dict.
+type fontUsageCollector struct {
+	fonts  *svgRenderer // font loading is shared with the SVG backend
+	usage  map[int]*fontUsage
+	pageNr int
+	depth  int
+}
+
+func (fuc *fontUsageCollector) font(resDict types.Dict, name string) *fontUsage {
+	o, found := fuc.fonts.resource(resDict, "Font", name)
+	if !found {
+		return nil
+	}
+	ir, ok := o.(types.IndirectRef)
+	if !ok {
+		return nil
+	}
+	objNr := ir.ObjectNumber.Value()
+	fu, ok := fuc.usage[objNr]
+	if !ok {
+		f := fuc.fonts.loadFont(resDict, name)
+		if f == nil {
+			return nil
+		}
+		fu = &fontUsage{f: f, codes: map[uint32]bool{}, pages: types.IntSet{}}
+		fuc.usage[objNr] = fu
+	}
+	fu.pages[fuc.pageNr] = true
+	return fu
+}
+
+func (fuc *fontUsageCollector) show(fu *fontUsage, o types.Object) {
+	if fu == nil {
+		return
+	}
+	if a, ok := o.(types.Array); ok {
+		for _, o := range a {
+			fuc.show(fu, o)
+		}
+		return
+	}
+	if bb, ok := stringBytes(o); ok {
+		for _, c := range fu.f.codes(bb) {
+			fu.codes[c] = true
+		}
+	}
+}
+
+func (fuc *fontUsageCollector) form(resDict types.Dict, name string) error {
+	if fuc.depth >= svgMaxFormDepth {
+		return nil
+	}
+	o, found := fuc.fonts.resource(resDict, "XObject", name)
+	if !found {
+		return nil
+	}
+	sd, _, err := fuc.fonts.ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return err
+	}
+	if st := sd.Subtype(); st == nil || *st != "Form" {
+		return nil
+	}
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+	res := resDict
+	if d, err := fuc.fonts.ctx.DereferenceDict(sd.Dict["Resources"]); err == nil && d != nil {
+		res = d
+	}
+	fuc.depth++
+	err = fuc.collect(sd.Content, res)
+	fuc.depth--
+	return err
+}
+
+func (fuc *fontUsageCollector) collect(bb []byte, resDict types.Dict) error {
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return err
+	}
+
+	var (
+		fu    *fontUsage
+		stack []*fontUsage
+	)
+
+	for _, op := range ops {
+		switch op.Operator {
+		case "q":
+			stack = append(stack, fu)
+		case "Q":
+			if len(stack) > 0 {
+				fu = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "Tf":
+			fu = fuc.font(resDict, op.Name(0))
+		case "Tj", "TJ", "'", "\"":
+			if len(op.Operands) > 0 {
+				fuc.show(fu, op.Operands[len(op.Operands)-1])
+			}
+		case "Do":
+			if err := fuc.form(resDict, op.Name(0)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// trueTypeGlyph returns the glyph id selected by the character code c of a simple TrueType font
+// (see 9.6.5.4 Encodings for TrueType Fonts).
+func trueTypeGlyph(g *font.TrueTypeGlyphs, symbolic bool, c uint32, text string) (uint16, bool) {
+	lookup := func(k string, cc ...uint32) (uint16, bool) {
+		m, ok := g.Cmaps[k]
+		if !ok {
+			return 0, false
+		}
+		for _, c := range cc {
+			if gid, ok := m[c]; ok {
+				return gid, true
+			}
+		}
+		return 0, true
+	}
+
+	if rr := []rune(text); !symbolic && len(rr) == 1 {
+		if gid, ok := lookup("3/1", uint32(rr[0])); ok {
+			return gid, true
+		}
+	}
+	if gid, ok := lookup("3/0", c, 0xF000+c, 0xF100+c, 0xF200+c); ok {
+		return gid, true
+	}
+	if gid, ok := lookup("1/0", c); ok {
+		return gid, true
+	}
+
+	return 0, false
+}
+
+// cidToGID returns a function mapping CIDs to glyph ids using the CIDToGIDMap of the CIDFont d.
+func cidToGID(xRefTable *model.XRefTable, d types.Dict) (func(uint32) uint16, error) {
+	identity := func(cid uint32) uint16 { return uint16(cid) }
+	if _, ok := d["CIDToGIDMap"].(types.IndirectRef); !ok {
+		// Missing or /Identity
+		return identity, nil
+	}
+	sd, _, err := xRefTable.DereferenceStreamDict(d["CIDToGIDMap"])
+	if err != nil || sd == nil {
+		return identity, err
+	}
+	if err := sd.Decode(); err != nil {
+		return nil, err
+	}
+	m := sd.Content
+	return func(cid uint32) uint16 {
+		if int(2*cid+1) >= len(m) {
+			return 0
+		}
+		return uint16(m[2*cid])<<8 | uint16(m[2*cid+1])
+	}, nil
+}
+
+// checkFontCoverage determines the character codes of fu lacking a glyph in the embedded TrueType font program of d.
+func checkFontCoverage(xRefTable *model.XRefTable, d types.Dict, fu *fontUsage, fc *FontGlyphCoverage) error {
+	fontDict := d
+
+	var gidForCode func(c uint32) (uint16, bool)
+
+	if fc.Subtype == "Type0" {
+		if enc := fontEncoding(xRefTable, d); enc != "Identity-H" && enc != "Identity-V" {
+			// Mapping character codes to CIDs needs the CMap.
+			fc.Reason = fmt.Sprintf("unsupported CMap %s", enc)
+			return nil
+		}
+		df, err := descendantFont(xRefTable, d)
+		if err != nil || df == nil {
+			fc.Reason = "missing descendant font"
+			return err
+		}
+		fontDict = df
+		m, err := cidToGID(xRefTable, df)
+		if err != nil {
+			return err
+		}
+		gidForCode = func(c uint32) (uint16, bool) { return m(c), true }
+	}
+
+	fd := fontDescriptor(xRefTable, fontDict)
+	if fd == nil {
+		fc.Reason = "missing font descriptor"
+		return nil
+	}
+
+	sd, _, err := xRefTable.DereferenceStreamDict(fd["FontFile2"])
+	if err != nil {
+		return err
+	}
+	if sd == nil {
+		for _, k := range []string{"FontFile", "FontFile3"} {
+			if _, found := fd.Find(k); found {
+				fc.Reason = "unsupported font program " + k
+				return nil
+			}
+		}
+		fc.Reason = "font not embedded"
+		return nil
+	}
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+
+	g, err := font.ParseTrueTypeGlyphs(sd.Content)
+	if err != nil {
+		fc.Reason = err.Error()
+		return nil
+	}
+	fc.Glyphs = g.NumGlyphs
+
+	if gidForCode == nil {
+		symbolic := false
+		if f := fd.IntEntry("Flags"); f != nil {
+			symbolic = *f&4 > 0
+		}
+		gidForCode = func(c uint32) (uint16, bool) { return trueTypeGlyph(g, symbolic, c, fu.f.text(c)) }
+	}
+
+	cc := make([]uint32, 0, len(fu.codes))
+	for c := range fu.codes {
+		cc = append(cc, c)
+	}
+	sort.Slice(cc, func(i, j int) bool { return cc[i] < cc[j] })
+
+	for _, c := range cc {
+		s := fu.f.text(c)
+		gid, ok := gidForCode(c)
+		if ok && gid > 0 && int(gid) < g.NumGlyphs {
+			// Subset font programs keep unused glyphs without outline.
+			if g.Outline(gid) || s != "" && strings.TrimFunc(s, unicode.IsSpace) == "" {
+				continue
+			}
+		}
+		fc.Missing = append(fc.Missing, MissingGlyph{Code: c, Text: s})
+	}
+
+	return nil
+}
+
+// FontCoverage returns for each embedded font used on selectedPages the character codes shown
+// lacking a glyph in its font program and therefore being rendered using the .notdef glyph.
+// Glyph coverage is checked for TrueType font programs only.
+func FontCoverage(ctx *model.Context, selectedPages types.IntSet) ([]FontGlyphCoverage, error) {
+	fuc := &fontUsageCollector{fonts: &svgRenderer{ctx: ctx, fonts: map[int]*svgFont{}}, usage: map[int]*fontUsage{}}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+		d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		bb, err := ctx.PageContent(d, pageNr)
+		if err == model.ErrNoContent {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fuc.pageNr = pageNr
+		if err := fuc.collect(bb, inhPAttrs.Resources); err != nil {
+			return nil, err
+		}
+	}
+
+	objNrs := make([]int, 0, len(fuc.usage))
+	for objNr := range fuc.usage {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	fcs := []FontGlyphCoverage{}
+
+	for _, objNr := range objNrs {
+		fu := fuc.usage[objNr]
+
+		d, err := ctx.DereferenceDict(*types.NewIndirectRef(objNr, 0))
+		if err != nil {
+			return nil, err
+		}
+
+		st := d.Subtype()
+		if st == nil || *st == "Type3" {
+			continue
+		}
+
+		fontDict := d
+		if *st == "Type0" {
+			if fontDict, err = descendantFont(ctx.XRefTable, d); err != nil || fontDict == nil {
+				continue
+			}
+		}
+		if !embeddedFont(ctx.XRefTable, fontDict) {
+			continue
+		}
+
+		fc := FontGlyphCoverage{ObjNr: objNr, Subtype: *st, Referenced: len(fu.codes)}
+		if bf := d.NameEntry("BaseFont"); bf != nil {
+			fc.BaseFont = *bf
+		}
+		for pageNr := range fu.pages {
+			fc.Pages = append(fc.Pages, pageNr)
+		}
+		sort.Ints(fc.Pages)
+
+		if err := checkFontCoverage(ctx.XRefTable, d, fu, &fc); err != nil {
+			return nil, err
+		}
+
+		fcs = append(fcs, fc)
+	}
+
+	return fcs, nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/primitives"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Text field formatting is implemented by JavaScript actions calling the AcroForm helper functions
+// (AFNumber_Format, AFDate_FormatEx, ..) as provided by Acrobat compatible viewers.
+
+// FormatType represents the kind of formatting applied to a text field.
+type FormatType int
+
+const (
+	FormatNone FormatType = iota
+	FormatNumber
+	FormatPercent
+	FormatDate
+)
+
+// Separator styles for FormatNumber and FormatPercent.
+const (
+	SepCommaDot   = iota // 1,234.56
+	SepNoneDot           // 1234.56
+	SepDotComma          // 1.234,56
+	SepNoneComma         // 1234,56
+	SepApostrophe        // 1'234.56
+)
+
+// Negative number styles for FormatNumber.
+const (
+	NegMinus     = iota // -1234.56
+	NegRed              // 1234.56 in red
+	NegParens           // (1234.56)
+	NegParensRed        // (1234.56) in red
+)
+
+// FieldFormat represents the format, keystroke and validation actions of a text field.
+type FieldFormat struct {
+	Type            FormatType
+	Decimals        int      // FormatNumber, FormatPercent
+	SepStyle        int      // FormatNumber, FormatPercent
+	NegStyle        int      // FormatNumber
+	Currency        string   // FormatNumber: optional currency symbol
+	CurrencyPrepend bool     // FormatNumber: currency symbol before the number
+	DateFormat      string   // FormatDate: eg. yyyy-mm-dd, see primitives.DateFormatForFmtExt
+	Min, Max        *float64 // FormatNumber, FormatPercent: optional range validation
+}
+
+// Calculation represents a calculate action deriving the value of a text field from the values of other fields.
+type Calculation struct {
+	Op     string   // one of SUM, PRD, AVG, MIN, MAX
+	Fields []string // fully qualified names of the operands
+}
+
+func jsString(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	return "\"" + strings.ReplaceAll(s, "\"", "\\\"") + "\""
+}
+
+func (f FieldFormat) validate() error {
+	switch f.Type {
+	case FormatNone:
+		return nil
+	case FormatNumber, FormatPercent:
+		if f.Decimals < 0 || f.Decimals > 10 {
+			return errors.Errorf("pdfcpu: invalid number of decimals: %d", f.Decimals)
+		}
+		if f.SepStyle < SepCommaDot || f.SepStyle > SepApostrophe {
+			return errors.Errorf("pdfcpu: invalid separator style: %d", f.SepStyle)
+		}
+		if f.NegStyle < NegMinus || f.NegStyle > NegParensRed {
+			return errors.Errorf("pdfcpu: invalid negative number style: %d", f.NegStyle)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return errors.New("pdfcpu: invalid range")
+		}
+		return nil
+	case FormatDate:
+		_, err := primitives.DateFormatForFmtExt(f.DateFormat)
+		return err
+	}
+
+	return errors.Errorf("pdfcpu: invalid format type: %d", f.Type)
+}
+
+// validateValue checks whether v is a valid value for f.
+func (f FieldFormat) validateValue(v string) error {
+	if v == "" {
+		return nil
+	}
+
+	switch f.Type {
+
+	case FormatNumber, FormatPercent:
+		fl, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return errors.Errorf("pdfcpu: invalid number: %s", v)
+		}
+		if (f.Min != nil && fl < *f.Min) || (f.Max != nil && fl > *f.Max) {
+			return errors.Errorf("pdfcpu: value out of range: %s", v)
+		}
+
+	case FormatDate:
+		df, err := primitives.DateFormatForFmtExt(f.DateFormat)
+		if err != nil {
+			return err
+		}
+		if _, err := time.Parse(df.Int, v); err != nil {
+			return errors.Errorf("pdfcpu: invalid date: %s, want format %s", v, df.Ext)
+		}
+	}
+
+	return nil
+}
+
+func (f FieldFormat) scripts() (format, keystroke, validate string) {
+	switch f.Type {
+
+	case FormatNumber:
+		args := fmt.Sprintf("%d, %d, %d, 0, %s, %t", f.Decimals, f.SepStyle, f.NegStyle, jsString(f.Currency), f.CurrencyPrepend)
+		format = fmt.Sprintf("AFNumber_Format(%s);", args)
+		keystroke = fmt.Sprintf("AFNumber_Keystroke(%s);", args)
+
+	case FormatPercent:
+		format = fmt.Sprintf("AFPercent_Format(%d, %d);", f.Decimals, f.SepStyle)
+		keystroke = fmt.Sprintf("AFPercent_Keystroke(%d, %d);", f.Decimals, f.SepStyle)
+
+	case FormatDate:
+		df, _ := primitives.DateFormatForFmtExt(f.DateFormat)
+		format = fmt.Sprintf("AFDate_FormatEx(%s);", jsString(df.Ext))
+		keystroke = fmt.Sprintf("AFDate_KeystrokeEx(%s);", jsString(df.Ext))
+	}
+
+	if f.Min != nil || f.Max != nil {
+		var lo, hi float64
+		if f.Min != nil {
+			lo = *f.Min
+		}
+		if f.Max != nil {
+			hi = *f.Max
+		}
+		validate = fmt.Sprintf("AFRange_Validate(%t, %s, %t, %s);",
+			f.Min != nil, strconv.FormatFloat(lo, 'f', -1, 64),
+			f.Max != nil, strconv.FormatFloat(hi, 'f', -1, 64))
+	}
+
+	return format, keystroke, validate
+}
+
+func (c Calculation) validate() error {
+	if !types.MemberOf(c.Op, []string{"SUM", "PRD", "AVG", "MIN", "MAX"}) {
+		return errors.Errorf("pdfcpu: invalid calculation: %s", c.Op)
+	}
+	if len(c.Fields) == 0 {
+		return errors.New("pdfcpu: calculation without fields")
+	}
+	return nil
+}
+
+func (c Calculation) script() string {
+	ss := make([]string, len(c.Fields))
+	for i, s := range c.Fields {
+		ss[i] = jsString(s)
+	}
+	return fmt.Sprintf("AFSimple_Calculate(%s, new Array(%s));", jsString(c.Op), strings.Join(ss, ", "))
+}
+
+func javaScriptAction(js string) (types.Dict, error) {
+	escape := types.Escape
+	if strings.IndexFunc(js, func(r rune) bool { return r > 0x7F }) >= 0 {
+		// eg. a currency symbol
+		escape = types.EscapedUTF16String
+	}
+
+	s, err := escape(js)
+	if err != nil {
+		return nil, err
+	}
+	return types.Dict{
+		"S":  types.Name("JavaScript"),
+		"JS": types.StringLiteral(*s),
+	}, nil
+}
+
+// additionalActions returns the additional-actions dict for a text field using format f and calculation c.
+func additionalActions(f *FieldFormat, c *Calculation) (types.Dict, error) {
+	scripts := map[string]string{}
+
+	if f != nil {
+		scripts["F"], scripts["K"], scripts["V"] = f.scripts()
+	}
+	if c != nil {
+		scripts["C"] = c.script()
+	}
+
+	aa := types.Dict{}
+	for k, js := range scripts {
+		if js == "" {
+			continue
+		}
+		d, err := javaScriptAction(js)
+		if err != nil {
+			return nil, err
+		}
+		aa[k] = d
+	}
+
+	if len(aa) == 0 {
+		return nil, nil
+	}
+
+	return aa, nil
+}
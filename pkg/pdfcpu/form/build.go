@@ -0,0 +1,931 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/primitives"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// This file provides a Go native builder for adding form fields to a validated context.
+// Fields are added as top level fields using Helvetica (/Helv) resp. ZapfDingbats (/ZaDb) of the form's default resources.
+// Use primitives and pdfcpu create for form creation based on JSON.
+
+const (
+	defaultFontID   = "Helv"
+	defaultFontName = "Helvetica"
+	symbolFontID    = "ZaDb"
+	symbolFontName  = "ZapfDingbats"
+	defaultFontSize = 12
+)
+
+// FieldOptions represents the attributes common to all fields created by this builder.
+type FieldOptions struct {
+	ID       string // partial field name, unique among the top level fields
+	Tip      string // alternate field name, used as tool tip
+	ReadOnly bool
+	Required bool
+	Visual   bool // rectangles are given in visual coordinates of the page as displayed, see model.PageCoordinates
+}
+
+// TextFieldOptions represents the attributes of a text field.
+type TextFieldOptions struct {
+	FieldOptions
+	Value     string
+	FontSize  int // defaults to 12
+	Align     types.HAlignment
+	Multiline bool
+	Comb      bool // requires MaxLen
+	MaxLen    int
+	RichText  bool         // Value may be plain or XHTML rich text
+	Format    *FieldFormat // optional format, keystroke and validation actions
+	Calculate *Calculation // optional calculate action, also adds the field to the calculation order
+}
+
+func (opts TextFieldOptions) validate() error {
+	if opts.Comb && (opts.MaxLen <= 0 || opts.Multiline) {
+		return errors.New("pdfcpu: comb text fields need \"MaxLen\" and a single line")
+	}
+
+	if opts.RichText && opts.Comb {
+		return errors.New("pdfcpu: comb text fields don't support rich text")
+	}
+
+	if opts.Format != nil {
+		if err := opts.Format.validate(); err != nil {
+			return err
+		}
+		if err := opts.Format.validateValue(opts.Value); err != nil {
+			return err
+		}
+	}
+
+	if opts.Calculate != nil {
+		return opts.Calculate.validate()
+	}
+
+	return nil
+}
+
+// CheckBoxOptions represents the attributes of a check box.
+type CheckBoxOptions struct {
+	FieldOptions
+	Checked bool
+}
+
+// RadioButton represents a single button of a radio button group.
+type RadioButton struct {
+	Value string
+	Rect  *types.Rectangle
+}
+
+// RadioGroupOptions represents the attributes of a radio button group.
+type RadioGroupOptions struct {
+	FieldOptions
+	Buttons []RadioButton
+	Value   string // selected button
+}
+
+// ComboBoxOptions represents the attributes of a combo box.
+type ComboBoxOptions struct {
+	FieldOptions
+	Options      []string // display values
+	ExportValues []string // optional export values corresponding to Options
+	Value        string   // export or display value
+	FontSize     int      // defaults to 12
+	Editable     bool
+}
+
+// ListBoxOptions represents the attributes of a list box.
+type ListBoxOptions struct {
+	FieldOptions
+	Options      []string // display values
+	ExportValues []string // optional export values corresponding to Options
+	Values       []string // export or display values
+	FontSize     int      // defaults to 12
+	Multi        bool
+}
+
+func (fo FieldOptions) validate() error {
+	if fo.ID == "" {
+		return errors.New("pdfcpu: missing field id")
+	}
+	return nil
+}
+
+func (fo FieldOptions) flags() primitives.FieldFlags {
+	var ff primitives.FieldFlags
+	if fo.ReadOnly {
+		ff |= primitives.FieldReadOnly
+	}
+	if fo.Required {
+		ff |= primitives.FieldRequired
+	}
+	return ff
+}
+
+func encodeText(s string) (types.StringLiteral, error) {
+	s1, err := types.EscapedUTF16String(s)
+	if err != nil {
+		return "", err
+	}
+	return types.StringLiteral(*s1), nil
+}
+
+func (fo FieldOptions) prepareDict(d types.Dict, ff primitives.FieldFlags) error {
+	t, err := encodeText(fo.ID)
+	if err != nil {
+		return err
+	}
+	d["T"] = t
+
+	if fo.Tip != "" {
+		tu, err := encodeText(fo.Tip)
+		if err != nil {
+			return err
+		}
+		d["TU"] = tu
+	}
+
+	if ff |= fo.flags(); ff > 0 {
+		d["Ff"] = types.Integer(ff)
+	}
+
+	return nil
+}
+
+func appendToArrayEntry(xRefTable *model.XRefTable, d types.Dict, key string, o types.Object) error {
+	ir, ok := d[key].(types.IndirectRef)
+	if !ok {
+		a, err := xRefTable.DereferenceArray(d[key])
+		if err != nil {
+			return err
+		}
+		d[key] = append(a, o)
+		return nil
+	}
+
+	entry, ok := xRefTable.FindTableEntryForIndRef(&ir)
+	if !ok {
+		return errors.Errorf("pdfcpu: can't dereference %s (obj#:%d)", key, ir.ObjectNumber)
+	}
+	a, ok := entry.Object.(types.Array)
+	if !ok {
+		return errors.Errorf("pdfcpu: corrupt %s (obj#:%d)", key, ir.ObjectNumber)
+	}
+	entry.Object = append(a, o)
+
+	return nil
+}
+
+func ensureAcroForm(ctx *model.Context) (types.Dict, error) {
+	xRefTable := ctx.XRefTable
+
+	if xRefTable.Form != nil {
+		if _, found := xRefTable.Form.Find("DA"); !found {
+			xRefTable.Form["DA"] = types.StringLiteral(fmt.Sprintf("/%s 0 Tf 0 g", defaultFontID))
+		}
+		return xRefTable.Form, nil
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	d := types.Dict{
+		"Fields": types.Array{},
+		"DA":     types.StringLiteral(fmt.Sprintf("/%s 0 Tf 0 g", defaultFontID)),
+	}
+
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return nil, err
+	}
+
+	rootDict["AcroForm"] = *ir
+	xRefTable.Form = d
+
+	return d, nil
+}
+
+func hasField(xRefTable *model.XRefTable, id string) (bool, error) {
+	fields, err := xRefTable.DereferenceArray(xRefTable.Form["Fields"])
+	if err != nil {
+		return false, err
+	}
+
+	for _, o := range fields {
+		d, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return false, err
+		}
+		o1, found := d.Find("T")
+		if !found {
+			continue
+		}
+		s, err := xRefTable.DereferenceStringOrHexLiteral(o1, model.V10, nil)
+		if err != nil {
+			return false, err
+		}
+		if s == id {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ensureFormFont returns the indirect reference of the core font fontName registered as fontID in the form's default resources.
+func ensureFormFont(xRefTable *model.XRefTable, fontID, fontName string) (*types.IndirectRef, error) {
+	form := xRefTable.Form
+
+	resDict, err := xRefTable.DereferenceDict(form["DR"])
+	if err != nil {
+		return nil, err
+	}
+	if resDict == nil {
+		resDict = types.Dict{}
+		form["DR"] = resDict
+	}
+
+	fontDict, err := xRefTable.DereferenceDict(resDict["Font"])
+	if err != nil {
+		return nil, err
+	}
+	if fontDict == nil {
+		fontDict = types.Dict{}
+		resDict["Font"] = fontDict
+	}
+
+	ir := fontDict.IndirectRefEntry(fontID)
+	if ir == nil {
+		if ir, err = pdffont.CoreFontDict(xRefTable, fontName); err != nil {
+			return nil, err
+		}
+		fontDict[fontID] = *ir
+	}
+
+	// Make the font available for rendering appearance streams.
+	xRefTable.FillFonts[fontID] = *ir
+
+	return ir, nil
+}
+
+// prepareField ensures the form and checks for a unique field id.
+func prepareField(ctx *model.Context, pageNr int, fo FieldOptions) (*types.IndirectRef, error) {
+	if err := fo.validate(); err != nil {
+		return nil, err
+	}
+
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return nil, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	pageIndRef, err := ctx.PageDictIndRef(pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ensureAcroForm(ctx); err != nil {
+		return nil, err
+	}
+
+	found, err := hasField(ctx.XRefTable, fo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return nil, errors.Errorf("pdfcpu: duplicate form field: %s", fo.ID)
+	}
+
+	if _, err := ensureFormFont(ctx.XRefTable, defaultFontID, defaultFontName); err != nil {
+		return nil, err
+	}
+
+	return pageIndRef, nil
+}
+
+func newWidget(ft string, rect *types.Rectangle, pageIndRef types.IndirectRef) types.Dict {
+	d := types.Dict{
+		"Type":    types.Name("Annot"),
+		"Subtype": types.Name("Widget"),
+		"Rect":    rect.Array(),
+		"F":       types.Integer(model.AnnPrint),
+		"P":       pageIndRef,
+		"Border":  types.NewIntegerArray(0, 0, 1),
+		"MK": types.Dict{
+			"BG": types.NewNumberArray(1, 1, 1),
+			"BC": types.NewNumberArray(0, 0, 0),
+		},
+	}
+	if ft != "" {
+		d["FT"] = types.Name(ft)
+	}
+	return d
+}
+
+// addWidget appends the widget annotation d to the "Annots" of page pageNr and updates the page annotation cache.
+// If visual is set the geometry of d is converted from visual coordinates into default user space.
+func addWidget(ctx *model.Context, pageNr int, pageIndRef types.IndirectRef, d types.Dict, visual bool) (*types.IndirectRef, error) {
+	xRefTable := ctx.XRefTable
+
+	if visual {
+		if err := pdfcpu.AnnotationToUserSpace(ctx, pageNr, d); err != nil {
+			return nil, err
+		}
+	}
+
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return nil, err
+	}
+
+	pageDict, err := xRefTable.DereferenceDict(pageIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := appendToArrayEntry(xRefTable, pageDict, "Annots", *ir); err != nil {
+		return nil, err
+	}
+
+	ann, err := pdfcpu.Annotation(xRefTable, d)
+	if err != nil {
+		return nil, err
+	}
+
+	pgAnnots, ok := xRefTable.PageAnnots[pageNr]
+	if !ok {
+		pgAnnots = model.PgAnnots{}
+		xRefTable.PageAnnots[pageNr] = pgAnnots
+	}
+	annots, ok := pgAnnots[model.AnnWidget]
+	if !ok {
+		annots = model.Annot{IndRefs: &[]types.IndirectRef{}, Map: model.AnnotMap{}}
+		pgAnnots[model.AnnWidget] = annots
+	}
+	*(annots.IndRefs) = append(*(annots.IndRefs), *ir)
+	annots.Map[ir.ObjectNumber.Value()] = ann
+
+	return ir, nil
+}
+
+// addField registers the top level field ir with the form.
+func addField(ctx *model.Context, ir types.IndirectRef) error {
+	if err := appendToArrayEntry(ctx.XRefTable, ctx.Form, "Fields", ir); err != nil {
+		return err
+	}
+	if ctx.XRefTable.Version() < model.V17 {
+		ctx.EnsureVersionForWriting()
+	}
+	return nil
+}
+
+func newFormXObject(xRefTable *model.XRefTable, bb []byte, w, h float64, res types.Dict) (*types.IndirectRef, error) {
+	sd, err := xRefTable.NewStreamDictForBuf(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.InsertInt("FormType", 1)
+	sd.Insert("BBox", types.NewNumberArray(0, 0, w, h))
+	sd.Insert("Matrix", types.NewNumberArray(1, 0, 0, 1, 0, 0))
+	if res != nil {
+		sd.Insert("Resources", res)
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
+
+// AddTextField adds a text field to page pageNr of ctx.
+func AddTextField(ctx *model.Context, pageNr int, rect *types.Rectangle, opts TextFieldOptions) (*types.IndirectRef, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	pageIndRef, err := prepareField(ctx, pageNr, opts.FieldOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	fontSize := opts.FontSize
+	if fontSize <= 0 {
+		fontSize = defaultFontSize
+	}
+
+	var ff primitives.FieldFlags
+	if opts.Multiline {
+		ff |= primitives.FieldMultiline
+	}
+	if opts.Comb {
+		ff |= primitives.FieldComb | primitives.FieldDoNotScroll
+	}
+
+	value, rv := opts.Value, ""
+	if opts.RichText {
+		ff |= primitives.FieldRichTextAndRadiosInUnison
+		if rv = value; primitives.IsRichText(rv) {
+			if value, err = primitives.RichTextPlain(rv); err != nil {
+				return nil, err
+			}
+		} else if value != "" {
+			rv = primitives.RichTextValue(value)
+		}
+	}
+
+	d := newWidget("Tx", rect, *pageIndRef)
+	d["DA"] = types.StringLiteral(fmt.Sprintf("/%s %d Tf 0 g", defaultFontID, fontSize))
+	d["Q"] = types.Integer(opts.Align)
+	if opts.MaxLen > 0 {
+		d["MaxLen"] = types.Integer(opts.MaxLen)
+	}
+	if err := opts.prepareDict(d, ff); err != nil {
+		return nil, err
+	}
+	if value != "" {
+		v, err := encodeText(value)
+		if err != nil {
+			return nil, err
+		}
+		d["V"] = v
+	}
+	if rv != "" {
+		v, err := encodeText(rv)
+		if err != nil {
+			return nil, err
+		}
+		d["RV"] = v
+	}
+
+	aa, err := additionalActions(opts.Format, opts.Calculate)
+	if err != nil {
+		return nil, err
+	}
+	if aa != nil {
+		d["AA"] = aa
+	}
+
+	fonts := map[string]types.IndirectRef{}
+	if rv != "" {
+		err = primitives.EnsureRichTextFieldAP(ctx, d, rv, nil, opts.Multiline, opts.Comb, opts.MaxLen, nil, fonts)
+	} else {
+		err = primitives.EnsureTextFieldAP(ctx, d, value, opts.Multiline, opts.Comb, opts.MaxLen, nil, fonts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := pdffont.UpdateUserfonts(ctx.XRefTable, fonts); err != nil {
+		return nil, err
+	}
+
+	ir, err := addWidget(ctx, pageNr, *pageIndRef, d, opts.Visual)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Calculate != nil {
+		if err := appendToArrayEntry(ctx.XRefTable, ctx.Form, "CO", *ir); err != nil {
+			return nil, err
+		}
+	}
+
+	return ir, addField(ctx, *ir)
+}
+
+func checkMarkAP(xRefTable *model.XRefTable, w, h float64, on bool) (*types.IndirectRef, error) {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "q 1 g 0 0 %.2f %.2f re f 0.5 0.5 %.2f %.2f re s Q ", w, h, w-1, h-1)
+
+	if !on {
+		return newFormXObject(xRefTable, buf.Bytes(), w, h, nil)
+	}
+
+	fontIndRef, err := ensureFormFont(xRefTable, symbolFontID, symbolFontName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Center ZapfDingbats check mark "4".
+	s := min(w, h) * 14.532 / 18
+	x, y := (w-s*0.846)/2, (h-s*0.692)/2
+	fmt.Fprintf(buf, "q 1 1 %.2f %.2f re W n BT /%s %.2f Tf %.2f %.2f Td (4) Tj ET Q ", w-2, h-2, symbolFontID, s, x, y)
+
+	res := types.Dict{"Font": types.Dict{symbolFontID: *fontIndRef}}
+
+	return newFormXObject(xRefTable, buf.Bytes(), w, h, res)
+}
+
+// AddCheckBox adds a check box to page pageNr of ctx.
+func AddCheckBox(ctx *model.Context, pageNr int, rect *types.Rectangle, opts CheckBoxOptions) (*types.IndirectRef, error) {
+	pageIndRef, err := prepareField(ctx, pageNr, opts.FieldOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := rect.Width(), rect.Height()
+
+	irOff, err := checkMarkAP(ctx.XRefTable, w, h, false)
+	if err != nil {
+		return nil, err
+	}
+
+	irYes, err := checkMarkAP(ctx.XRefTable, w, h, true)
+	if err != nil {
+		return nil, err
+	}
+
+	v := types.Name("Off")
+	if opts.Checked {
+		v = "Yes"
+	}
+
+	d := newWidget("Btn", rect, *pageIndRef)
+	d["DA"] = types.StringLiteral(fmt.Sprintf("/%s 0 Tf 0 g", symbolFontID))
+	d["V"] = v
+	d["AS"] = v
+	d["AP"] = types.Dict{"N": types.Dict{"Off": *irOff, "Yes": *irYes}}
+	d["MK"].(types.Dict)["CA"] = types.StringLiteral("4")
+	if err := opts.prepareDict(d, 0); err != nil {
+		return nil, err
+	}
+
+	ir, err := addWidget(ctx, pageNr, *pageIndRef, d, opts.Visual)
+	if err != nil {
+		return nil, err
+	}
+
+	return ir, addField(ctx, *ir)
+}
+
+func circle(buf *bytes.Buffer, dx, dy, r float64, op string) {
+	// Approximate a circle by 4 bezier curves.
+	f := .5523
+	fmt.Fprintf(buf, "q 1 0 0 1 %.2f %.2f cm %.3f 0 m ", dx, dy, r)
+	fmt.Fprintf(buf, "%.3f %.3f %.3f %.3f %.3f %.3f c ", r, f*r, f*r, r, 0., r)
+	fmt.Fprintf(buf, "%.3f %.3f %.3f %.3f %.3f %.3f c ", -f*r, r, -r, f*r, -r, 0.)
+	fmt.Fprintf(buf, "%.3f %.3f %.3f %.3f %.3f %.3f c ", -r, -f*r, -f*r, -r, 0., -r)
+	fmt.Fprintf(buf, "%.3f %.3f %.3f %.3f %.3f %.3f c ", f*r, -r, r, -f*r, r, 0.)
+	fmt.Fprintf(buf, "%s Q ", op)
+}
+
+func radioButtonAP(xRefTable *model.XRefTable, w, h float64, on bool) (*types.IndirectRef, error) {
+	r := min(w, h) / 2
+
+	buf := new(bytes.Buffer)
+	fmt.Fprint(buf, "1 g ")
+	circle(buf, w/2, h/2, r, "f")
+	fmt.Fprint(buf, "0 g 0 G ")
+	circle(buf, w/2, h/2, r-.5, "s")
+	if on {
+		circle(buf, w/2, h/2, r/2, "f")
+	}
+
+	return newFormXObject(xRefTable, buf.Bytes(), w, h, nil)
+}
+
+func (opts RadioGroupOptions) validate() error {
+	if len(opts.Buttons) < 2 {
+		return errors.New("pdfcpu: radio button groups need at least 2 buttons")
+	}
+
+	vv := types.StringSet{}
+	for _, b := range opts.Buttons {
+		if b.Value == "" || b.Rect == nil {
+			return errors.New("pdfcpu: radio buttons need a value and a rectangle")
+		}
+		if vv[b.Value] {
+			return errors.Errorf("pdfcpu: duplicate radio button value: %s", b.Value)
+		}
+		vv[b.Value] = true
+	}
+
+	if opts.Value != "" && !vv[opts.Value] {
+		return errors.Errorf("pdfcpu: invalid radio button group value: %s", opts.Value)
+	}
+
+	return nil
+}
+
+// AddRadioGroup adds a radio button group to page pageNr of ctx.
+// The group is a field whose kids are the widget annotations of its buttons.
+func AddRadioGroup(ctx *model.Context, pageNr int, opts RadioGroupOptions) (*types.IndirectRef, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	pageIndRef, err := prepareField(ctx, pageNr, opts.FieldOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	v := types.Name("Off")
+	if opts.Value != "" {
+		v = types.Name(types.EncodeName(opts.Value))
+	}
+
+	d := types.Dict{"FT": types.Name("Btn"), "V": v}
+	if err := opts.prepareDict(d, primitives.FieldRadio|primitives.FieldNoToggleToOff); err != nil {
+		return nil, err
+	}
+
+	ir, err := ctx.IndRefForNewObject(d)
+	if err != nil {
+		return nil, err
+	}
+
+	kids := types.Array{}
+
+	for _, b := range opts.Buttons {
+		w, h := b.Rect.Width(), b.Rect.Height()
+
+		irOff, err := radioButtonAP(ctx.XRefTable, w, h, false)
+		if err != nil {
+			return nil, err
+		}
+
+		irOn, err := radioButtonAP(ctx.XRefTable, w, h, true)
+		if err != nil {
+			return nil, err
+		}
+
+		on := types.EncodeName(b.Value)
+
+		as := types.Name("Off")
+		if b.Value == opts.Value {
+			as = types.Name(on)
+		}
+
+		kid := newWidget("", b.Rect, *pageIndRef)
+		kid["Parent"] = *ir
+		kid["AS"] = as
+		kid["AP"] = types.Dict{"N": types.Dict{"Off": *irOff, on: *irOn}}
+
+		irKid, err := addWidget(ctx, pageNr, *pageIndRef, kid, opts.Visual)
+		if err != nil {
+			return nil, err
+		}
+		kids = append(kids, *irKid)
+	}
+
+	d["Kids"] = kids
+
+	return ir, addField(ctx, *ir)
+}
+
+// AddComboBox adds a combo box to page pageNr of ctx.
+func AddComboBox(ctx *model.Context, pageNr int, rect *types.Rectangle, opts ComboBoxOptions) (*types.IndirectRef, error) {
+	if len(opts.Options) == 0 {
+		return nil, errors.New("pdfcpu: missing combo box options")
+	}
+
+	co, err := newBuilderChoiceOptions(opts.Options, opts.ExportValues)
+	if err != nil {
+		return nil, err
+	}
+
+	v := opts.Value
+	i, found := co.lookup(v)
+	if found {
+		v = co.export[i]
+	}
+	if v != "" && !found && !opts.Editable {
+		return nil, errors.Errorf("pdfcpu: invalid combo box value: %s", opts.Value)
+	}
+
+	pageIndRef, err := prepareField(ctx, pageNr, opts.FieldOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	fontSize := opts.FontSize
+	if fontSize <= 0 {
+		fontSize = defaultFontSize
+	}
+
+	ff := primitives.FieldCombo
+	if opts.Editable {
+		ff |= primitives.FieldEdit
+	}
+
+	opt, err := optArray(opts.ExportValues, opts.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newWidget("Ch", rect, *pageIndRef)
+	d["DA"] = types.StringLiteral(fmt.Sprintf("/%s %d Tf 0 g", defaultFontID, fontSize))
+	d["Opt"] = opt
+	if err := opts.prepareDict(d, ff); err != nil {
+		return nil, err
+	}
+	if v != "" {
+		s, err := encodeText(v)
+		if err != nil {
+			return nil, err
+		}
+		d["V"] = s
+		if found {
+			d["I"] = types.Array{types.Integer(i)}
+		}
+	}
+
+	fonts := map[string]types.IndirectRef{}
+	if err := primitives.EnsureComboBoxAP(ctx, d, co.displayValue(v), nil, fonts); err != nil {
+		return nil, err
+	}
+	if err := pdffont.UpdateUserfonts(ctx.XRefTable, fonts); err != nil {
+		return nil, err
+	}
+
+	ir, err := addWidget(ctx, pageNr, *pageIndRef, d, opts.Visual)
+	if err != nil {
+		return nil, err
+	}
+
+	return ir, addField(ctx, *ir)
+}
+
+// AddListBox adds a list box to page pageNr of ctx.
+func AddListBox(ctx *model.Context, pageNr int, rect *types.Rectangle, opts ListBoxOptions) (*types.IndirectRef, error) {
+	if len(opts.Options) == 0 {
+		return nil, errors.New("pdfcpu: missing list box options")
+	}
+	if len(opts.Values) > 1 && !opts.Multi {
+		return nil, errors.New("pdfcpu: list box does not allow multiple selections")
+	}
+
+	co, err := newBuilderChoiceOptions(opts.Options, opts.ExportValues)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range opts.Values {
+		if _, ok := co.lookup(v); !ok {
+			return nil, errors.Errorf("pdfcpu: invalid list box value: %s", v)
+		}
+	}
+
+	pageIndRef, err := prepareField(ctx, pageNr, opts.FieldOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	fontSize := opts.FontSize
+	if fontSize <= 0 {
+		fontSize = defaultFontSize
+	}
+
+	var ff primitives.FieldFlags
+	if opts.Multi {
+		ff |= primitives.FieldMultiselect
+	}
+
+	opt, err := optArray(opts.ExportValues, opts.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newWidget("Ch", rect, *pageIndRef)
+	d["DA"] = types.StringLiteral(fmt.Sprintf("/%s %d Tf 0 g", defaultFontID, fontSize))
+	d["Opt"] = opt
+	if err := opts.prepareDict(d, ff); err != nil {
+		return nil, err
+	}
+
+	ind, err := updateListBoxValues(opts.Multi, d, co, opts.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	fonts := map[string]types.IndirectRef{}
+	if err := primitives.EnsureListBoxAP(ctx, d, co.display, ind, nil, fonts); err != nil {
+		return nil, err
+	}
+	if err := pdffont.UpdateUserfonts(ctx.XRefTable, fonts); err != nil {
+		return nil, err
+	}
+
+	ir, err := addWidget(ctx, pageNr, *pageIndRef, d, opts.Visual)
+	if err != nil {
+		return nil, err
+	}
+
+	return ir, addField(ctx, *ir)
+}
+
+// AddSignatureField adds an unsigned signature field to page pageNr of ctx.
+func AddSignatureField(ctx *model.Context, pageNr int, rect *types.Rectangle, opts FieldOptions) (*types.IndirectRef, error) {
+	pageIndRef, err := prepareField(ctx, pageNr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty appearance until the field gets signed.
+	irN, err := newFormXObject(ctx.XRefTable, []byte{}, rect.Width(), rect.Height(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newWidget("Sig", rect, *pageIndRef)
+	d["AP"] = types.Dict{"N": *irN}
+	if err := opts.prepareDict(d, 0); err != nil {
+		return nil, err
+	}
+
+	ir, err := addWidget(ctx, pageNr, *pageIndRef, d, opts.Visual)
+	if err != nil {
+		return nil, err
+	}
+
+	// SignaturesExist
+	sigFlags := 1
+	if i := ctx.Form.IntEntry("SigFlags"); i != nil {
+		sigFlags |= *i
+	}
+	ctx.Form["SigFlags"] = types.Integer(sigFlags)
+
+	return ir, addField(ctx, *ir)
+}
+
+// SetSignatureAppearance renders the appearance template sa into the widgets of the unsigned signature field id.
+func SetSignatureAppearance(ctx *model.Context, id string, sa *primitives.SignatureAppearance) error {
+	if sa == nil {
+		return errors.New("pdfcpu: missing signature appearance")
+	}
+	if err := sa.Validate(); err != nil {
+		return err
+	}
+
+	xRefTable := ctx.XRefTable
+
+	m, err := fieldsByName(xRefTable)
+	if err != nil {
+		return err
+	}
+	ir, ok := m[id]
+	if !ok {
+		return errors.Errorf("pdfcpu: unknown form field: %s", id)
+	}
+
+	d, err := xRefTable.DereferenceDict(ir)
+	if err != nil {
+		return err
+	}
+	if ft := d.NameEntry("FT"); ft == nil || *ft != "Sig" {
+		return errors.Errorf("pdfcpu: not a signature field: %s", id)
+	}
+	if _, found := d.Find("V"); found {
+		return errors.Errorf("pdfcpu: signature field already signed: %s", id)
+	}
+
+	kids, err := xRefTable.DereferenceArray(d["Kids"])
+	if err != nil {
+		return err
+	}
+	if len(kids) == 0 {
+		return primitives.EnsureSignatureAP(xRefTable, d, sa)
+	}
+
+	for _, o := range kids {
+		d1, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if st := d1.NameEntry("Subtype"); st == nil || *st != "Widget" {
+			continue
+		}
+		if err := primitives.EnsureSignatureAP(xRefTable, d1, sa); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
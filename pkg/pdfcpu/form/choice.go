@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// choiceOptions represents the "Opt" array of a choice field (12.7.5.4).
+// Each option has an export value and a display value which may differ.
+// Choice fields may come with thousands of options, therefore option lookup is hashed.
+type choiceOptions struct {
+	export, display []string
+	index           map[string]int // export value -> option index
+	displayIndex    map[string]int // display value -> option index
+}
+
+func newChoiceOptions(export, display []string) *choiceOptions {
+	co := &choiceOptions{
+		export:       export,
+		display:      display,
+		index:        make(map[string]int, len(export)),
+		displayIndex: make(map[string]int, len(display)),
+	}
+	for i := range export {
+		if _, ok := co.index[export[i]]; !ok {
+			co.index[export[i]] = i
+		}
+		if _, ok := co.displayIndex[display[i]]; !ok {
+			co.displayIndex[display[i]] = i
+		}
+	}
+	return co
+}
+
+func choiceOptionString(o types.Object) (string, error) {
+	switch o := o.(type) {
+	case types.StringLiteral:
+		return types.StringLiteralToString(o)
+	case types.HexLiteral:
+		return types.HexLiteralToString(o)
+	}
+	return "", errors.New("pdfcpu: corrupt choice field option")
+}
+
+// newBuilderChoiceOptions returns the choice options for display values and optional export values.
+func newBuilderChoiceOptions(display, export []string) (*choiceOptions, error) {
+	if len(export) == 0 {
+		return newChoiceOptions(display, display), nil
+	}
+	if len(export) != len(display) {
+		return nil, errors.Errorf("pdfcpu: %d export values for %d options", len(export), len(display))
+	}
+	return newChoiceOptions(export, display), nil
+}
+
+// parseChoiceOptions returns the options of the choice field d preserving option indices.
+func parseChoiceOptions(xRefTable *model.XRefTable, d types.Dict) (*choiceOptions, error) {
+	a, err := xRefTable.DereferenceArray(d["Opt"])
+	if err != nil {
+		return nil, err
+	}
+
+	export, display := make([]string, len(a)), make([]string, len(a))
+
+	for i, o := range a {
+		o, err := xRefTable.Dereference(o)
+		if err != nil {
+			return nil, err
+		}
+
+		if arr, ok := o.(types.Array); ok {
+			if len(arr) != 2 {
+				return nil, errors.New("pdfcpu: corrupt choice field option")
+			}
+			if export[i], err = choiceOptionString(arr[0]); err != nil {
+				return nil, err
+			}
+			if display[i], err = choiceOptionString(arr[1]); err != nil {
+				return nil, err
+			}
+			export[i], display[i] = strings.TrimSpace(export[i]), strings.TrimSpace(display[i])
+			continue
+		}
+
+		s, err := choiceOptionString(o)
+		if err != nil {
+			return nil, err
+		}
+		export[i] = strings.TrimSpace(s)
+		display[i] = export[i]
+	}
+
+	return newChoiceOptions(export, display), nil
+}
+
+// lookup returns the index of the option with export value v or alternatively display value v.
+func (co *choiceOptions) lookup(v string) (int, bool) {
+	if i, ok := co.index[v]; ok {
+		return i, true
+	}
+	i, ok := co.displayIndex[v]
+	return i, ok
+}
+
+// distinctExportValues returns true if some export value differs from its display value.
+func (co *choiceOptions) distinctExportValues() bool {
+	for i := range co.export {
+		if co.export[i] != co.display[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// displayValue returns the display value for the export value v.
+func (co *choiceOptions) displayValue(v string) string {
+	if i, ok := co.index[v]; ok {
+		return co.display[i]
+	}
+	return v
+}
+
+// exported returns the non empty display values and their export values if any of them differs.
+func (co *choiceOptions) exported() (display, export []string) {
+	distinct := co.distinctExportValues()
+	for i, s := range co.display {
+		if s == "" {
+			continue
+		}
+		display = append(display, s)
+		if distinct {
+			export = append(export, co.export[i])
+		}
+	}
+	return display, export
+}
+
+// listing returns the display values where distinct export values are appended in brackets.
+func (co *choiceOptions) listing() []string {
+	var ss []string
+	for i, s := range co.display {
+		if s == "" {
+			continue
+		}
+		if co.export[i] != s {
+			s += "[" + co.export[i] + "]"
+		}
+		ss = append(ss, s)
+	}
+	return ss
+}
+
+// optArray returns an "Opt" array for export and display values.
+func optArray(export, display []string) (types.Array, error) {
+	a := make(types.Array, len(display))
+	for i, s := range display {
+		s1, err := encodeText(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(export) == 0 || export[i] == s {
+			a[i] = s1
+			continue
+		}
+		s2, err := encodeText(export[i])
+		if err != nil {
+			return nil, err
+		}
+		a[i] = types.Array{s2, s1}
+	}
+	return a, nil
+}
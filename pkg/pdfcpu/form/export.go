@@ -103,28 +103,30 @@ type RadioButtonGroup struct {
 
 // ComboBox represents a form combobox.
 type ComboBox struct {
-	Pages    []int    `json:"pages"`
-	ID       string   `json:"id"`
-	Name     string   `json:"name,omitempty"`
-	AltName  string   `json:"altname,omitempty"`
-	Editable bool     `json:"editable"`
-	Options  []string `json:"options"`
-	Default  string   `json:"default,omitempty"`
-	Value    string   `json:"value"`
-	Locked   bool     `json:"locked"`
+	Pages        []int    `json:"pages"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name,omitempty"`
+	AltName      string   `json:"altname,omitempty"`
+	Editable     bool     `json:"editable"`
+	Options      []string `json:"options"`
+	ExportValues []string `json:"exportvalues,omitempty"` // Export values corresponding to Options if differing.
+	Default      string   `json:"default,omitempty"`
+	Value        string   `json:"value"`
+	Locked       bool     `json:"locked"`
 }
 
 // ListBox represents a form listbox.
 type ListBox struct {
-	Pages    []int    `json:"pages"`
-	ID       string   `json:"id"`
-	Name     string   `json:"name,omitempty"`
-	AltName  string   `json:"altname,omitempty"`
-	Multi    bool     `json:"multi"`
-	Options  []string `json:"options"`
-	Defaults []string `json:"defaults,omitempty"`
-	Values   []string `json:"values,omitempty"`
-	Locked   bool     `json:"locked"`
+	Pages        []int    `json:"pages"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name,omitempty"`
+	AltName      string   `json:"altname,omitempty"`
+	Multi        bool     `json:"multi"`
+	Options      []string `json:"options"`
+	ExportValues []string `json:"exportvalues,omitempty"` // Export values corresponding to Options if differing.
+	Defaults     []string `json:"defaults,omitempty"`
+	Values       []string `json:"values,omitempty"`
+	Locked       bool     `json:"locked"`
 }
 
 // Page is a container for page imageboxes.
@@ -374,12 +376,12 @@ func extractComboBox(xRefTable *model.XRefTable, page int, d types.Dict, id, nam
 		cb.Value = strings.TrimSpace(s)
 	}
 
-	opts, err := parseOptions(xRefTable, d, OPTIONAL)
+	opts, err := parseChoiceOptions(xRefTable, d)
 	if err != nil {
 		return nil, err
 	}
 
-	cb.Options = opts
+	cb.Options, cb.ExportValues = opts.exported()
 
 	return cb, nil
 }
@@ -531,12 +533,12 @@ func extractListBox(xRefTable *model.XRefTable, page int, d types.Dict, id, name
 		lb.Values = ss
 	}
 
-	opts, err := parseOptions(xRefTable, d, OPTIONAL)
+	opts, err := parseChoiceOptions(xRefTable, d)
 	if err != nil {
 		return nil, err
 	}
 
-	lb.Options = opts
+	lb.Options, lb.ExportValues = opts.exported()
 
 	return lb, nil
 }
@@ -659,11 +659,12 @@ func fillComboBox(
 	ctx *model.Context,
 	d types.Dict,
 	id, name string,
-	opts []string,
+	opts *choiceOptions,
 	locked bool,
 	format DataFormat,
 	fonts map[string]types.IndirectRef,
 	fillDetails func(id, name string, fieldType FieldType, format DataFormat) ([]string, bool, bool),
+	ff *int,
 	ok *bool) error {
 
 	vv, lock, found := fillDetails(id, name, FTComboBox, format)
@@ -673,7 +674,13 @@ func fillComboBox(
 
 	da := d.StringEntry("DA")
 
+	// vNew may be an export value or a display value.
 	vNew := vv[0]
+	i, known := opts.lookup(vNew)
+	if known {
+		vNew = opts.export[i]
+	}
+
 	if locked {
 		if !lock {
 			unlockFormField(d)
@@ -682,7 +689,7 @@ func fillComboBox(
 		}
 	} else if lock {
 		lockFormField(d)
-		if err := primitives.EnsureComboBoxAP(ctx, d, vNew, da, fonts); err != nil {
+		if err := primitives.EnsureComboBoxAP(ctx, d, opts.displayValue(vNew), da, fonts); err != nil {
 			return err
 		}
 		*ok = true
@@ -705,17 +712,17 @@ func fillComboBox(
 		return err
 	}
 
-	ind := types.Array{}
-	for i, o := range opts {
-		if o == vNew {
-			ind = append(ind, types.Integer(i))
-			break
-		}
-	}
-	if len(ind) > 0 {
-		d["I"] = ind
+	editable := ff != nil && primitives.FieldFlags(*ff)&primitives.FieldEdit > 0
+
+	switch {
+	case known:
+		d["I"] = types.Array{types.Integer(i)}
 		d["V"] = types.StringLiteral(*s)
-	} else {
+	case editable && vNew != "":
+		// Custom value of an editable combo box.
+		d.Delete("I")
+		d["V"] = types.StringLiteral(*s)
+	default:
 		d.Delete("I")
 		d.Delete("V")
 	}
@@ -724,51 +731,41 @@ func fillComboBox(
 	return nil
 }
 
-func updateListBoxValues(multi bool, d types.Dict, opts, vNew []string) (types.Array, error) {
+func updateListBoxValues(multi bool, d types.Dict, opts *choiceOptions, vNew []string) (types.Array, error) {
 	ind := types.Array{}
-	if multi {
-		arr := types.Array{}
-		for _, v := range vNew {
-			for i, o := range opts {
-				if o == v {
-					ind = append(ind, types.Integer(i))
-					break
-				}
-			}
-			s, err := types.EscapedUTF16String(v)
-			if err != nil {
-				return nil, err
-			}
-			arr = append(arr, types.StringLiteral(*s))
+	arr := types.Array{}
+	seen := map[int]bool{}
+
+	for _, v := range vNew {
+		i, found := opts.lookup(v)
+		if !found || seen[i] {
+			continue
 		}
-		if len(vNew) > 0 {
-			d["I"] = ind
-			d["V"] = arr
-		} else {
-			d.Delete("I")
-			d.Delete("V")
+		seen[i] = true
+		s, err := types.EscapedUTF16String(opts.export[i])
+		if err != nil {
+			return nil, err
 		}
-		return ind, nil
-	}
-
-	v := vNew[0]
-	s, err := types.EscapedUTF16String(v)
-	if err != nil {
-		return nil, err
-	}
-	for i, o := range opts {
-		if o == v {
-			ind = append(ind, types.Integer(i))
+		ind = append(ind, types.Integer(i))
+		arr = append(arr, types.StringLiteral(*s))
+		if !multi {
 			break
 		}
 	}
-	if len(ind) > 0 {
-		d["I"] = ind
-		d["V"] = types.StringLiteral(*s)
-	} else {
+
+	if len(ind) == 0 {
 		d.Delete("I")
 		d.Delete("V")
+		return ind, nil
+	}
+
+	d["I"] = ind
+	if multi {
+		d["V"] = arr
+	} else {
+		d["V"] = arr[0]
 	}
+
 	return ind, nil
 }
 
@@ -776,7 +773,7 @@ func fillListBox(
 	ctx *model.Context,
 	d types.Dict,
 	id, name string,
-	opts []string,
+	opts *choiceOptions,
 	locked bool,
 	format DataFormat,
 	fonts map[string]types.IndirectRef,
@@ -831,7 +828,7 @@ func fillListBox(
 
 	da := d.StringEntry("DA")
 
-	if err := primitives.EnsureListBoxAP(ctx, d, opts, ind, da, fonts); err != nil {
+	if err := primitives.EnsureListBoxAP(ctx, d, opts.display, ind, da, fonts); err != nil {
 		return err
 	}
 
@@ -851,13 +848,13 @@ func fillCh(
 	ff *int,
 	ok *bool) error {
 
-	opts, err := parseOptions(ctx.XRefTable, d, OPTIONAL)
+	opts, err := parseChoiceOptions(ctx.XRefTable, d)
 	if err != nil {
 		return err
 	}
 
 	if ff != nil && primitives.FieldFlags(*ff)&primitives.FieldCombo > 0 {
-		return fillComboBox(ctx, d, id, name, opts, locked, format, fonts, fillDetails, ok)
+		return fillComboBox(ctx, d, id, name, opts, locked, format, fonts, fillDetails, ff, ok)
 	}
 
 	return fillListBox(ctx, d, id, name, opts, locked, format, fonts, fillDetails, ff, ok)
@@ -962,7 +959,24 @@ func fillTextField(
 
 	vNew := vv[0]
 
-	if vNew == vOld {
+	richText := ff != nil && primitives.FieldFlags(*ff)&primitives.FieldRichTextAndRadiosInUnison > 0
+
+	// Rich text fields take plain text or rich text values.
+	var rv string
+	if richText {
+		rv = vNew
+		if !primitives.IsRichText(rv) {
+			rv = primitives.RichTextValue(vNew)
+		} else {
+			s, err := primitives.RichTextPlain(rv)
+			if err != nil {
+				return err
+			}
+			vNew = s
+		}
+	}
+
+	if vNew == vOld && (!richText || rv == richTextValue(ctx.XRefTable, d)) {
 		return nil
 	}
 
@@ -972,6 +986,23 @@ func fillTextField(
 	}
 	d["V"] = types.StringLiteral(*s)
 
+	if richText {
+		s, err := types.EscapedUTF16String(rv)
+		if err != nil {
+			return err
+		}
+		d["RV"] = types.StringLiteral(*s)
+	}
+
+	ds := d.StringEntry("DS")
+
+	ensureAP := func(d types.Dict, multiLine, comb bool, maxLen int, da *string) error {
+		if richText {
+			return primitives.EnsureRichTextFieldAP(ctx, d, rv, ds, multiLine, comb, maxLen, da, fonts)
+		}
+		return primitives.EnsureTextFieldAP(ctx, d, vNew, multiLine, comb, maxLen, da, fonts)
+	}
+
 	multiLine := ff != nil && uint(primitives.FieldFlags(*ff))&uint(primitives.FieldMultiline) > 0
 
 	comb := ff != nil && primitives.FieldFlags(*ff)&primitives.FieldComb > 0
@@ -994,7 +1025,7 @@ func fillTextField(
 				return err
 			}
 
-			if err := primitives.EnsureTextFieldAP(ctx, d, vNew, multiLine, comb, maxLen, da, fonts); err != nil {
+			if err := ensureAP(d, multiLine, comb, maxLen, da); err != nil {
 				return err
 			}
 
@@ -1004,7 +1035,7 @@ func fillTextField(
 		return nil
 	}
 
-	if err := primitives.EnsureTextFieldAP(ctx, d, vNew, multiLine, comb, maxLen, da, fonts); err != nil {
+	if err := ensureAP(d, multiLine, comb, maxLen, da); err != nil {
 		return err
 	}
 
@@ -508,12 +508,13 @@ func collectListBox(xRefTable *model.XRefTable, multi bool, d types.Dict, f *Fie
 func collectCh(xRefTable *model.XRefTable, d types.Dict, f *Field, fm *FieldMeta) error {
 	ff := d.IntEntry("Ff")
 
-	opts, err := parseOptions(xRefTable, d, OPTIONAL)
+	opts, err := parseChoiceOptions(xRefTable, d)
 	if err != nil {
 		return err
 	}
 
-	f.Opts = strings.Join(opts, ",")
+	// Display values followed by differing export values in brackets.
+	f.Opts = strings.Join(opts.listing(), ",")
 	if len(f.Opts) > 0 {
 		fm.opt = true
 	}
@@ -556,6 +557,29 @@ func getV(xRefTable *model.XRefTable, d types.Dict) (string, error) {
 	return v, nil
 }
 
+// richTextValue returns the rich text value RV of the field d which may be a text string or a text stream.
+func richTextValue(xRefTable *model.XRefTable, d types.Dict) string {
+	o, found := d.Find("RV")
+	if !found {
+		return ""
+	}
+	o, err := xRefTable.Dereference(o)
+	if err != nil || o == nil {
+		return ""
+	}
+	if sd, ok := o.(types.StreamDict); ok {
+		if err := sd.Decode(); err != nil {
+			return ""
+		}
+		return string(sd.Content)
+	}
+	s, err := types.StringOrHexLiteral(o)
+	if err != nil || s == nil {
+		return ""
+	}
+	return *s
+}
+
 func inheritedDV(xRefTable *model.XRefTable, d types.Dict) (string, error) {
 	if o, found := d.Find("DV"); found {
 		o1, err := xRefTable.Dereference(o)
@@ -1401,7 +1425,7 @@ func resetBtn(xRefTable *model.XRefTable, d types.Dict) error {
 	return nil
 }
 
-func resetComboBoxOrRegularListBox(d types.Dict, opts []string, ff *int) (types.Array, error) {
+func resetComboBoxOrRegularListBox(d types.Dict, opts *choiceOptions, ff *int) (types.Array, error) {
 	ind := types.Array{}
 	sl := d.StringLiteralEntry("DV")
 	if sl == nil {
@@ -1413,11 +1437,8 @@ func resetComboBoxOrRegularListBox(d types.Dict, opts []string, ff *int) (types.
 			return nil, err
 		}
 		// Check if dv is a valid option.
-		for i, o := range opts {
-			if o == dv {
-				ind = append(ind, types.Integer(i))
-				break
-			}
+		if i, ok := opts.lookup(dv); ok {
+			ind = append(ind, types.Integer(i))
 		}
 		if len(ind) > 0 {
 			d["I"] = ind
@@ -1433,18 +1454,15 @@ func resetComboBoxOrRegularListBox(d types.Dict, opts []string, ff *int) (types.
 	return ind, nil
 }
 
-func resetMultiListBox(xRefTable *model.XRefTable, d types.Dict, opts []string) (types.Array, error) {
+func resetMultiListBox(xRefTable *model.XRefTable, d types.Dict, opts *choiceOptions) (types.Array, error) {
 	ind := types.Array{}
 	defaults, err := parseStringLiteralArray(xRefTable, d, "DV")
 	if err != nil {
 		return nil, err
 	}
 	for _, dv := range defaults {
-		for i, o := range opts {
-			if o == dv {
-				ind = append(ind, types.Integer(i))
-				break
-			}
+		if i, ok := opts.lookup(dv); ok {
+			ind = append(ind, types.Integer(i))
 		}
 	}
 	if len(defaults) > 0 {
@@ -1461,7 +1479,7 @@ func resetMultiListBox(xRefTable *model.XRefTable, d types.Dict, opts []string)
 func resetCh(ctx *model.Context, d types.Dict, fonts map[string]types.IndirectRef) error {
 	ff := d.IntEntry("Ff")
 
-	opts, err := parseOptions(ctx.XRefTable, d, OPTIONAL)
+	opts, err := parseChoiceOptions(ctx.XRefTable, d)
 	if err != nil {
 		return err
 	}
@@ -1481,7 +1499,7 @@ func resetCh(ctx *model.Context, d types.Dict, fonts map[string]types.IndirectRe
 	da := d.StringEntry("DA")
 
 	if ff != nil && primitives.FieldFlags(*ff)&primitives.FieldCombo == 0 {
-		if err := primitives.EnsureListBoxAP(ctx, d, opts, ind, da, fonts); err != nil {
+		if err := primitives.EnsureListBoxAP(ctx, d, opts.display, ind, da, fonts); err != nil {
 			return err
 		}
 	}
@@ -1512,6 +1530,9 @@ func resetTx(ctx *model.Context, d types.Dict, fonts map[string]types.IndirectRe
 		d.Delete("V")
 	}
 
+	// A rich text value would override the default value.
+	d.Delete("RV")
+
 	isDate := false
 	if s != "" {
 		_, err := primitives.DateFormatForDate(s)
@@ -1522,6 +1543,11 @@ func resetTx(ctx *model.Context, d types.Dict, fonts map[string]types.IndirectRe
 	multiLine := ff != nil && uint(primitives.FieldFlags(*ff))&uint(primitives.FieldMultiline) > 0
 	comb := ff != nil && uint(primitives.FieldFlags(*ff))&uint(primitives.FieldComb) > 0
 
+	maxLen := 0
+	if i := d.IntEntry("MaxLen"); i != nil {
+		maxLen = *i
+	}
+
 	da := d.StringEntry("DA")
 
 	kids := d.ArrayEntry("Kids")
@@ -1537,7 +1563,7 @@ func resetTx(ctx *model.Context, d types.Dict, fonts map[string]types.IndirectRe
 			if isDate {
 				err = primitives.EnsureDateFieldAP(ctx, d, s, da, fonts)
 			} else {
-				err = primitives.EnsureTextFieldAP(ctx, d, s, multiLine, comb, 0, da, fonts)
+				err = primitives.EnsureTextFieldAP(ctx, d, s, multiLine, comb, maxLen, da, fonts)
 			}
 
 			if err != nil {
@@ -1551,7 +1577,7 @@ func resetTx(ctx *model.Context, d types.Dict, fonts map[string]types.IndirectRe
 	if isDate {
 		err = primitives.EnsureDateFieldAP(ctx, d, s, da, fonts)
 	} else {
-		err = primitives.EnsureTextFieldAP(ctx, d, s, multiLine, comb, 0, da, fonts)
+		err = primitives.EnsureTextFieldAP(ctx, d, s, multiLine, comb, maxLen, da, fonts)
 	}
 
 	return err
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package form
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TabOrders lists the valid values of a page's "Tabs" entry (12.5 Table 30):
+// row order, column order, structure order and since PDF 2.0 annotation array order and widget order.
+var TabOrders = []string{"R", "C", "S", "A", "W"}
+
+func collectFieldsByName(xRefTable *model.XRefTable, a types.Array, prefix string, m map[string]types.IndirectRef, visited types.IntSet) error {
+	for _, o := range a {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			return errors.New("pdfcpu: corrupt form field array entry")
+		}
+		objNr := ir.ObjectNumber.Value()
+		if visited[objNr] {
+			continue
+		}
+		visited[objNr] = true
+
+		d, err := xRefTable.DereferenceDict(ir)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+
+		name := prefix
+		s, err := d.StringOrHexLiteralEntry("T")
+		if err != nil {
+			return err
+		}
+		if s != nil {
+			if name != "" {
+				name += "."
+			}
+			name += *s
+			if _, ok := m[name]; !ok {
+				m[name] = ir
+			}
+		}
+
+		kids, err := xRefTable.DereferenceArray(d["Kids"])
+		if err != nil {
+			return err
+		}
+		if err := collectFieldsByName(xRefTable, kids, name, m, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldsByName returns the indirect references of all fields of the form by fully qualified field name.
+func fieldsByName(xRefTable *model.XRefTable) (map[string]types.IndirectRef, error) {
+	fields, err := Fields(xRefTable)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]types.IndirectRef{}
+	if err := collectFieldsByName(xRefTable, fields, "", m, types.IntSet{}); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// widgetFieldName returns the fully qualified name of the field owning the widget annotation d.
+func widgetFieldName(xRefTable *model.XRefTable, d types.Dict) (string, error) {
+	var ss []string
+
+	visited := types.IntSet{}
+
+	for d != nil {
+		s, err := d.StringOrHexLiteralEntry("T")
+		if err != nil {
+			return "", err
+		}
+		if s != nil {
+			ss = append([]string{*s}, ss...)
+		}
+
+		ir := d.IndirectRefEntry("Parent")
+		if ir == nil || visited[ir.ObjectNumber.Value()] {
+			break
+		}
+		visited[ir.ObjectNumber.Value()] = true
+
+		if d, err = xRefTable.DereferenceDict(*ir); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.Join(ss, "."), nil
+}
+
+// SetTabOrder sets the tab order used by viewers for navigating the annotations of page pageNr.
+// An empty tabs removes the page's tab order.
+func SetTabOrder(ctx *model.Context, pageNr int, tabs string) error {
+	if tabs != "" && !types.MemberOf(tabs, TabOrders) {
+		return errors.Errorf("pdfcpu: invalid tab order: %s, must be one of %s", tabs, strings.Join(TabOrders, ","))
+	}
+
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	if tabs == "" {
+		delete(d, "Tabs")
+		return nil
+	}
+
+	d["Tabs"] = types.Name(tabs)
+
+	if ctx.XRefTable.Version() < model.V15 {
+		ctx.EnsureVersionForWriting()
+	}
+
+	return nil
+}
+
+// OrderWidgets rearranges the widget annotations of page pageNr belonging to the fields with fully qualified names ids
+// according to the sequence of ids. All other annotations keep their position.
+// Use together with SetTabOrder "A" or "W" to control the tab order of generated forms.
+func OrderWidgets(ctx *model.Context, pageNr int, ids []string) error {
+	xRefTable := ctx.XRefTable
+
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	annots, err := xRefTable.DereferenceArray(d["Annots"])
+	if err != nil {
+		return err
+	}
+
+	order := map[string]bool{}
+	for _, id := range ids {
+		if order[id] {
+			return errors.Errorf("pdfcpu: duplicate form field: %s", id)
+		}
+		order[id] = true
+	}
+
+	var slots []int
+	widgets := map[string][]types.Object{}
+
+	for i, o := range annots {
+		d1, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			continue
+		}
+		if st := d1.NameEntry("Subtype"); st == nil || *st != "Widget" {
+			continue
+		}
+		name, err := widgetFieldName(xRefTable, d1)
+		if err != nil {
+			return err
+		}
+		if order[name] {
+			slots = append(slots, i)
+			widgets[name] = append(widgets[name], o)
+		}
+	}
+
+	var a types.Array
+	for _, id := range ids {
+		if len(widgets[id]) == 0 {
+			return errors.Errorf("pdfcpu: page %d: no widget for form field: %s", pageNr, id)
+		}
+		a = append(a, widgets[id]...)
+	}
+
+	for i, j := range slots {
+		annots[j] = a[i]
+	}
+
+	return nil
+}
+
+// CalculationOrder returns the fully qualified names of the fields in the form's calculation order.
+func CalculationOrder(xRefTable *model.XRefTable) ([]string, error) {
+	if xRefTable.Form == nil {
+		return nil, errors.New("pdfcpu: no form available")
+	}
+
+	co, err := xRefTable.DereferenceArray(xRefTable.Form["CO"])
+	if err != nil || len(co) == 0 {
+		return nil, err
+	}
+
+	m, err := fieldsByName(xRefTable)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[types.IndirectRef]string{}
+	for k, v := range m {
+		names[v] = k
+	}
+
+	var ss []string
+	for _, o := range co {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			return nil, errors.New("pdfcpu: corrupt calculation order")
+		}
+		name, ok := names[ir]
+		if !ok {
+			return nil, errors.Errorf("pdfcpu: calculation order: unknown form field (obj#:%d)", ir.ObjectNumber)
+		}
+		ss = append(ss, name)
+	}
+
+	return ss, nil
+}
+
+// SetCalculationOrder sets the order in which the values of the fields with fully qualified names ids get recalculated.
+// An empty ids removes the form's calculation order.
+func SetCalculationOrder(xRefTable *model.XRefTable, ids []string) error {
+	if len(ids) == 0 {
+		if xRefTable.Form != nil {
+			delete(xRefTable.Form, "CO")
+		}
+		return nil
+	}
+
+	m, err := fieldsByName(xRefTable)
+	if err != nil {
+		return err
+	}
+
+	co := types.Array{}
+	seen := map[string]bool{}
+
+	for _, id := range ids {
+		if seen[id] {
+			return errors.Errorf("pdfcpu: duplicate form field: %s", id)
+		}
+		seen[id] = true
+		ir, ok := m[id]
+		if !ok {
+			return errors.Errorf("pdfcpu: unknown form field: %s", id)
+		}
+		co = append(co, ir)
+	}
+
+	xRefTable.Form["CO"] = co
+
+	return nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// PageForm is a page turned into a Form XObject ready to be placed any number of times into pages of any context.
+// The form renders the visible region of the page as displayed, ie. cropped and rotated,
+// with its lower left corner at the origin of form space.
+type PageForm struct {
+	Width, Height float64 // Dimensions of the form in user space units.
+
+	ctxSrc   *model.Context
+	sd       types.StreamDict
+	irs      map[*model.Context]types.IndirectRef
+	migrated map[*model.Context]map[int]int
+}
+
+// CreateFormXObjectFromPage returns a reusable Form XObject for page pageNr of ctxSrc.
+func CreateFormXObjectFromPage(ctxSrc *model.Context, pageNr int) (*PageForm, error) {
+	d, _, inhPAttrs, err := ctxSrc.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	bb, err := ctxSrc.PageContent(d, pageNr)
+	if err != nil && err != model.ErrNoContent {
+		return nil, err
+	}
+
+	cropBox := inhPAttrs.MediaBox
+	if inhPAttrs.CropBox != nil {
+		cropBox = inhPAttrs.CropBox
+	}
+	if cropBox == nil {
+		return nil, errors.Errorf("pdfcpu: page %d: missing MediaBox", pageNr)
+	}
+	cropBox = types.NewRectangle(cropBox.LL.X, cropBox.LL.Y, cropBox.UR.X, cropBox.UR.Y)
+
+	// Account for existing rotation.
+	if rot := inhPAttrs.Rotate % 360; rot != 0 {
+		if types.IntMemberOf(rot, []int{+90, -90, +270, -270}) {
+			w := cropBox.Width()
+			cropBox.UR.X = cropBox.LL.X + cropBox.Height()
+			cropBox.UR.Y = cropBox.LL.Y + w
+		}
+		bb = append(model.ContentBytesForPageRotation(rot, cropBox.Width(), cropBox.Height()), bb...)
+	}
+
+	sd := types.StreamDict{
+		Dict: types.Dict(
+			map[string]types.Object{
+				"Type":    types.Name("XObject"),
+				"Subtype": types.Name("Form"),
+				"BBox":    cropBox.Array(),
+				"Matrix":  types.NewNumberArray(1, 0, 0, 1, -cropBox.LL.X, -cropBox.LL.Y),
+			},
+		),
+		Content:        bb,
+		FilterPipeline: []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}},
+	}
+
+	if inhPAttrs.Resources != nil {
+		sd.Insert("Resources", inhPAttrs.Resources.Clone())
+	}
+
+	sd.InsertName("Filter", filter.Flate)
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return &PageForm{
+		Width:    cropBox.Width(),
+		Height:   cropBox.Height(),
+		ctxSrc:   ctxSrc,
+		sd:       sd,
+		irs:      map[*model.Context]types.IndirectRef{},
+		migrated: map[*model.Context]map[int]int{},
+	}, nil
+}
+
+// IndRef returns the form as object of ctxDest.
+// The form and all its resources get copied into ctxDest on first use only.
+func (pf *PageForm) IndRef(ctxDest *model.Context) (*types.IndirectRef, error) {
+	if ir, ok := pf.irs[ctxDest]; ok {
+		return &ir, nil
+	}
+
+	o := pf.sd.Clone()
+
+	if ctxDest != pf.ctxSrc {
+		migrated, ok := pf.migrated[ctxDest]
+		if !ok {
+			migrated = map[int]int{}
+			pf.migrated[ctxDest] = migrated
+		}
+		var err error
+		if o, err = migrateObject(o, pf.ctxSrc, ctxDest, migrated); err != nil {
+			return nil, err
+		}
+	}
+
+	ir, err := ctxDest.IndRefForNewObject(o)
+	if err != nil {
+		return nil, err
+	}
+
+	pf.irs[ctxDest] = *ir
+
+	return ir, nil
+}
+
+// pageOwnedResources returns the resource dict of pageDict owned by this page only.
+func pageOwnedResources(ctx *model.Context, pageDict types.Dict) (types.Dict, error) {
+	o, _, err := inheritedPageAttr(ctx, pageDict, "Resources")
+	if err != nil {
+		return nil, err
+	}
+
+	resDict, err := ctx.DereferenceDict(o)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resources may be shared among pages.
+	if resDict == nil {
+		resDict = types.NewDict()
+	} else {
+		resDict = resDict.Clone().(types.Dict)
+	}
+	pageDict["Resources"] = resDict
+
+	return resDict, nil
+}
+
+// ownedResourceSubDict returns the resource sub dict for category (eg. XObject) of the page owned resDict.
+func ownedResourceSubDict(ctx *model.Context, resDict types.Dict, category string) (types.Dict, error) {
+	d, err := ctx.DereferenceDict(resDict[category])
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		d = types.NewDict()
+	} else {
+		d = d.Clone().(types.Dict)
+	}
+	resDict[category] = d
+
+	return d, nil
+}
+
+// pageXObjectResources returns the XObject resources of pageDict owned by this page only.
+func pageXObjectResources(ctx *model.Context, pageDict types.Dict) (types.Dict, error) {
+	resDict, err := pageOwnedResources(ctx, pageDict)
+	if err != nil {
+		return nil, err
+	}
+
+	return ownedResourceSubDict(ctx, resDict, "XObject")
+}
+
+// Place renders the form on page pageNr of ctxDest using the transformation m from form space into user space.
+// For example a page form scaled to half its size at position (x,y) gets placed using
+//
+//	matrix.CalcTransformMatrix(.5, .5, 0, 1, x, y)
+func (pf *PageForm) Place(ctxDest *model.Context, pageNr int, m matrix.Matrix) error {
+	ir, err := pf.IndRef(ctxDest)
+	if err != nil {
+		return err
+	}
+
+	pageDict, _, _, err := ctxDest.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if pageDict == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	d, err := pageXObjectResources(ctxDest, pageDict)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	for i := 0; ; i++ {
+		id = "Pg" + strconv.Itoa(i)
+		if _, found := d.Find(id); !found {
+			break
+		}
+	}
+	d.Insert(id, *ir)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "q %.5f %.5f %.5f %.5f %.5f %.5f cm /%s Do Q ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1], id)
+
+	return ctxDest.AppendContent(pageDict, b.Bytes())
+}
@@ -0,0 +1,399 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// GeoReference represents a geospatial viewport of a page (see 12.10 Geospatial features).
+type GeoReference struct {
+	Name    string           `json:"name,omitempty"`
+	BBox    *types.Rectangle `json:"bbox,omitempty"`    // viewport in user space, defaults to the media box
+	GCSType string           `json:"gcsType,omitempty"` // GEOGCS or PROJCS, defaults to GEOGCS
+	EPSG    int              `json:"epsg,omitempty"`    // EPSG code of the geographic coordinate system
+	WKT     string           `json:"wkt,omitempty"`     // Well Known Text of the geographic coordinate system
+	Bounds  []float64        `json:"bounds,omitempty"`  // region of the unit square of BBox covered by the map
+	GPTS    []float64        `json:"gpts"`              // lat/lon pairs
+	LPTS    []float64        `json:"lpts,omitempty"`    // x/y pairs of the unit square of BBox corresponding to GPTS
+	PDU     []string         `json:"pdu,omitempty"`     // preferred display units for linear, area and angular measurement
+}
+
+func (g GeoReference) validate() error {
+	if g.EPSG == 0 && g.WKT == "" {
+		return errors.New("pdfcpu: geo reference: missing EPSG or WKT")
+	}
+
+	if g.GCSType != "" && g.GCSType != "GEOGCS" && g.GCSType != "PROJCS" {
+		return errors.Errorf("pdfcpu: geo reference: invalid GCS type: %s", g.GCSType)
+	}
+
+	if len(g.GPTS) < 6 || len(g.GPTS)%2 != 0 {
+		return errors.New("pdfcpu: geo reference: GPTS needs at least 3 lat/lon pairs")
+	}
+
+	lpts := g.LPTS
+	if len(lpts) == 0 {
+		lpts = g.Bounds
+	}
+	if len(lpts) == 0 {
+		lpts = unitSquare
+	}
+	if len(lpts) != len(g.GPTS) {
+		return errors.New("pdfcpu: geo reference: LPTS and GPTS length mismatch")
+	}
+
+	if len(g.Bounds) > 0 && (len(g.Bounds) < 6 || len(g.Bounds)%2 != 0) {
+		return errors.New("pdfcpu: geo reference: Bounds needs at least 3 x/y pairs")
+	}
+
+	if len(g.PDU) > 0 && len(g.PDU) != 3 {
+		return errors.New("pdfcpu: geo reference: PDU needs 3 units")
+	}
+
+	return nil
+}
+
+// unitSquare is the default for Bounds and LPTS.
+var unitSquare = []float64{0, 0, 0, 1, 1, 1, 1, 0}
+
+func (g GeoReference) lpts() []float64 {
+	if len(g.LPTS) > 0 {
+		return g.LPTS
+	}
+	if len(g.Bounds) > 0 {
+		return g.Bounds
+	}
+	return unitSquare
+}
+
+// LatLon returns the geographic coordinates for user space point (x,y)
+// using an affine least squares fit of the registration points.
+func (g GeoReference) LatLon(x, y float64) (float64, float64, error) {
+	if g.BBox == nil {
+		return 0, 0, errors.New("pdfcpu: geo reference: missing bbox")
+	}
+
+	lpts := g.lpts()
+	if len(lpts) != len(g.GPTS) || len(lpts) < 6 {
+		return 0, 0, errors.New("pdfcpu: geo reference: insufficient registration points")
+	}
+
+	// Normal equations for lat = a*u + b*v + c and lon = d*u + e*v + f
+	var m [3][3]float64
+	var rLat, rLon [3]float64
+	for i := 0; i < len(lpts); i += 2 {
+		p := [3]float64{lpts[i], lpts[i+1], 1}
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				m[j][k] += p[j] * p[k]
+			}
+			rLat[j] += p[j] * g.GPTS[i]
+			rLon[j] += p[j] * g.GPTS[i+1]
+		}
+	}
+
+	cLat, ok := solve3(m, rLat)
+	if !ok {
+		return 0, 0, errors.New("pdfcpu: geo reference: degenerated registration points")
+	}
+	cLon, _ := solve3(m, rLon)
+
+	u := (x - g.BBox.LL.X) / g.BBox.Width()
+	v := (y - g.BBox.LL.Y) / g.BBox.Height()
+
+	return cLat[0]*u + cLat[1]*v + cLat[2], cLon[0]*u + cLon[1]*v + cLon[2], nil
+}
+
+// solve3 solves m*x = r using Cramer's rule.
+func solve3(m [3][3]float64, r [3]float64) ([3]float64, bool) {
+	det := func(m [3][3]float64) float64 {
+		return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+			m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+			m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	}
+
+	var x [3]float64
+	d := det(m)
+	if math.Abs(d) < 1e-12 {
+		return x, false
+	}
+
+	for i := 0; i < 3; i++ {
+		mi := m
+		for j := 0; j < 3; j++ {
+			mi[j][i] = r[j]
+		}
+		x[i] = det(mi) / d
+	}
+
+	return x, true
+}
+
+func numbers(xRefTable *model.XRefTable, o types.Object) ([]float64, error) {
+	a, err := xRefTable.DereferenceArray(o)
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	ff := make([]float64, len(a))
+	for i, o := range a {
+		if ff[i], err = xRefTable.DereferenceNumber(o); err != nil {
+			return nil, err
+		}
+	}
+
+	return ff, nil
+}
+
+func geoReference(xRefTable *model.XRefTable, vp types.Dict, mediaBox *types.Rectangle) (*GeoReference, error) {
+	d, err := xRefTable.DereferenceDict(vp["Measure"])
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	if st := d.NameEntry("Subtype"); st == nil || *st != "GEO" {
+		return nil, nil
+	}
+
+	g := &GeoReference{BBox: mediaBox}
+
+	if o, found := vp.Find("BBox"); found {
+		a, err := xRefTable.DereferenceArray(o)
+		if err != nil {
+			return nil, err
+		}
+		if g.BBox, err = xRefTable.RectForArray(a); err != nil {
+			return nil, err
+		}
+	}
+
+	if o, found := vp.Find("Name"); found {
+		if g.Name, err = xRefTable.DereferenceText(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if g.Bounds, err = numbers(xRefTable, d["Bounds"]); err != nil {
+		return nil, err
+	}
+	if g.GPTS, err = numbers(xRefTable, d["GPTS"]); err != nil {
+		return nil, err
+	}
+	if g.LPTS, err = numbers(xRefTable, d["LPTS"]); err != nil {
+		return nil, err
+	}
+
+	a, err := xRefTable.DereferenceArray(d["PDU"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range a {
+		n, err := xRefTable.DereferenceName(o, model.V10, nil)
+		if err != nil {
+			return nil, err
+		}
+		g.PDU = append(g.PDU, n.Value())
+	}
+
+	gcs, err := xRefTable.DereferenceDict(d["GCS"])
+	if err != nil || gcs == nil {
+		return g, err
+	}
+
+	if t := gcs.NameEntry("Type"); t != nil {
+		g.GCSType = *t
+	}
+	if o, found := gcs.Find("EPSG"); found {
+		i, err := xRefTable.DereferenceInteger(o)
+		if err != nil {
+			return nil, err
+		}
+		if i != nil {
+			g.EPSG = i.Value()
+		}
+	}
+	if o, found := gcs.Find("WKT"); found {
+		if g.WKT, err = xRefTable.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// GeoInfo returns the geospatial viewports of page pageNr.
+func GeoInfo(ctx *model.Context, pageNr int) ([]GeoReference, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: GeoInfo: unknown page number: %d", pageNr)
+	}
+
+	a, err := ctx.DereferenceArray(d["VP"])
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	var gg []GeoReference
+
+	for _, o := range a {
+		vp, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if vp == nil {
+			continue
+		}
+		g, err := geoReference(ctx.XRefTable, vp, inhPAttrs.MediaBox)
+		if err != nil {
+			return nil, err
+		}
+		if g != nil {
+			gg = append(gg, *g)
+		}
+	}
+
+	return gg, nil
+}
+
+func (g GeoReference) measureDict() (types.Dict, error) {
+	gcsType := g.GCSType
+	if gcsType == "" {
+		gcsType = "GEOGCS"
+	}
+
+	gcs := types.Dict(map[string]types.Object{"Type": types.Name(gcsType)})
+	if g.EPSG > 0 {
+		gcs["EPSG"] = types.Integer(g.EPSG)
+	}
+	if g.WKT != "" {
+		s, err := types.Escape(g.WKT)
+		if err != nil {
+			return nil, err
+		}
+		gcs["WKT"] = types.StringLiteral(*s)
+	}
+
+	d := types.Dict(map[string]types.Object{
+		"Type":    types.Name("Measure"),
+		"Subtype": types.Name("GEO"),
+		"GCS":     gcs,
+		"GPTS":    types.NewNumberArray(g.GPTS...),
+	})
+
+	if len(g.Bounds) > 0 {
+		d["Bounds"] = types.NewNumberArray(g.Bounds...)
+	}
+	if len(g.LPTS) > 0 {
+		d["LPTS"] = types.NewNumberArray(g.LPTS...)
+	}
+	if len(g.PDU) > 0 {
+		a := types.Array{}
+		for _, s := range g.PDU {
+			a = append(a, types.Name(s))
+		}
+		d["PDU"] = a
+	}
+
+	return d, nil
+}
+
+// SetGeoReference adds a geospatial viewport to page pageNr.
+// An existing viewport of the same name gets replaced.
+func SetGeoReference(ctx *model.Context, pageNr int, g GeoReference) error {
+	if err := g.validate(); err != nil {
+		return err
+	}
+
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: SetGeoReference: unknown page number: %d", pageNr)
+	}
+
+	bbox := g.BBox
+	if bbox == nil {
+		bbox = inhPAttrs.MediaBox
+	}
+
+	measure, err := g.measureDict()
+	if err != nil {
+		return err
+	}
+
+	vp := types.Dict(map[string]types.Object{
+		"Type":    types.Name("Viewport"),
+		"BBox":    bbox.Array(),
+		"Measure": measure,
+	})
+
+	if g.Name != "" {
+		s, err := types.EscapedUTF16String(g.Name)
+		if err != nil {
+			return err
+		}
+		vp["Name"] = types.StringLiteral(*s)
+	}
+
+	a, err := ctx.DereferenceArray(d["VP"])
+	if err != nil {
+		return err
+	}
+
+	i := -1
+	for j, o := range a {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil || g.Name == "" {
+			continue
+		}
+		if o1, found := d1.Find("Name"); found {
+			s, err := ctx.DereferenceText(o1)
+			if err != nil {
+				return err
+			}
+			if s == g.Name {
+				i = j
+				break
+			}
+		}
+	}
+
+	if i >= 0 {
+		a[i] = vp
+	} else {
+		a = append(a, vp)
+	}
+	d["VP"] = a
+
+	if ctx.XRefTable.Version() < model.V17 {
+		ctx.EnsureVersionForWriting()
+	}
+
+	return nil
+}
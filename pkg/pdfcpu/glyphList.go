@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Glyph names of WinAnsiEncoding for the codes 0x20-0xFF, "-" for unused codes (see Annex D).
+const winAnsiGlyphNames = `space exclam quotedbl numbersign dollar percent ampersand quotesingle
+parenleft parenright asterisk plus comma hyphen period slash
+zero one two three four five six seven eight nine colon semicolon less equal greater question
+at A B C D E F G H I J K L M N O P Q R S T U V W X Y Z bracketleft backslash bracketright asciicircum underscore
+grave a b c d e f g h i j k l m n o p q r s t u v w x y z braceleft bar braceright asciitilde -
+Euro - quotesinglbase florin quotedblbase ellipsis dagger daggerdbl circumflex perthousand Scaron guilsinglleft OE - Zcaron -
+- quoteleft quoteright quotedblleft quotedblright bullet endash emdash tilde trademark scaron guilsinglright oe - zcaron Ydieresis
+nbspace exclamdown cent sterling currency yen brokenbar section dieresis copyright ordfeminine guillemotleft logicalnot sfthyphen registered macron
+degree plusminus twosuperior threesuperior acute mu paragraph periodcentered cedilla onesuperior ordmasculine guillemotright onequarter onehalf threequarters questiondown
+Agrave Aacute Acircumflex Atilde Adieresis Aring AE Ccedilla Egrave Eacute Ecircumflex Edieresis Igrave Iacute Icircumflex Idieresis
+Eth Ntilde Ograve Oacute Ocircumflex Otilde Odieresis multiply Oslash Ugrave Uacute Ucircumflex Udieresis Yacute Thorn germandbls
+agrave aacute acircumflex atilde adieresis aring ae ccedilla egrave eacute ecircumflex edieresis igrave iacute icircumflex idieresis
+eth ntilde ograve oacute ocircumflex otilde odieresis divide oslash ugrave uacute ucircumflex udieresis yacute thorn ydieresis`
+
+// Glyph names of StandardEncoding deviating from WinAnsiEncoding.
+var standardEncodingGlyphNames = map[byte]string{
+	0x27: "quoteright", 0x60: "quoteleft",
+	0xA1: "exclamdown", 0xA2: "cent", 0xA3: "sterling", 0xA4: "fraction", 0xA5: "yen", 0xA6: "florin", 0xA7: "section",
+	0xA8: "currency", 0xA9: "quotesingle", 0xAA: "quotedblleft", 0xAB: "guillemotleft", 0xAC: "guilsinglleft",
+	0xAD: "guilsinglright", 0xAE: "fi", 0xAF: "fl", 0xB1: "endash", 0xB2: "dagger", 0xB3: "daggerdbl",
+	0xB4: "periodcentered", 0xB6: "paragraph", 0xB7: "bullet", 0xB8: "quotesinglbase", 0xB9: "quotedblbase",
+	0xBA: "quotedblright", 0xBB: "guillemotright", 0xBC: "ellipsis", 0xBD: "perthousand", 0xBF: "questiondown",
+	0xC1: "grave", 0xC2: "acute", 0xC3: "circumflex", 0xC4: "tilde", 0xC5: "macron", 0xC6: "breve", 0xC7: "dotaccent",
+	0xC8: "dieresis", 0xCA: "ring", 0xCB: "cedilla", 0xCD: "hungarumlaut", 0xCE: "ogonek", 0xCF: "caron", 0xD0: "emdash",
+	0xE1: "AE", 0xE3: "ordfeminine", 0xE8: "Lslash", 0xE9: "Oslash", 0xEA: "OE", 0xEB: "ordmasculine",
+	0xF1: "ae", 0xF5: "dotlessi", 0xF8: "lslash", 0xF9: "oslash", 0xFA: "oe", 0xFB: "germandbls",
+}
+
+// Glyph names of the Adobe Glyph List not covered by WinAnsiEncoding or the naming conventions below.
+var extraGlyphNames = map[string]rune{
+	"ff": 0xFB00, "fi": 0xFB01, "fl": 0xFB02, "ffi": 0xFB03, "ffl": 0xFB04,
+	"dotlessi": 0x0131, "dotlessj": 0x0237, "Lslash": 0x0141, "lslash": 0x0142, "IJ": 0x0132, "ij": 0x0133,
+	"Eng": 0x014A, "eng": 0x014B, "Dcroat": 0x0110, "dcroat": 0x0111, "Hbar": 0x0126, "hbar": 0x0127,
+	"Ldot": 0x013F, "ldot": 0x0140, "Tbar": 0x0166, "tbar": 0x0167, "napostrophe": 0x0149, "kgreenlandic": 0x0138,
+	"caron": 0x02C7, "breve": 0x02D8, "dotaccent": 0x02D9, "ring": 0x02DA, "ogonek": 0x02DB, "hungarumlaut": 0x02DD,
+	"minus": 0x2212, "fraction": 0x2044, "quotereversed": 0x201B, "figuredash": 0x2012, "onedotenleader": 0x2024,
+	"twodotenleader": 0x2025, "emspace": 0x2003, "enspace": 0x2002, "Delta": 0x2206, "Omega": 0x2126,
+	"partialdiff": 0x2202, "summation": 0x2211, "product": 0x220F, "integral": 0x222B, "radical": 0x221A,
+	"infinity": 0x221E, "notequal": 0x2260, "lessequal": 0x2264, "greaterequal": 0x2265, "approxequal": 0x2248,
+	"lozenge": 0x25CA, "arrowleft": 0x2190, "arrowup": 0x2191, "arrowright": 0x2192, "arrowdown": 0x2193,
+	"arrowboth": 0x2194, "middot": 0x00B7, "sigma1": 0x03C2,
+}
+
+// Combining diacritics by glyph name suffix, eg. "Scaron" is "S" composed with a caron.
+var glyphNameAccents = []struct {
+	name string
+	r    rune
+}{
+	{"hungarumlaut", 0x030B}, {"commaaccent", 0x0326}, {"circumflex", 0x0302}, {"dotaccent", 0x0307},
+	{"dieresis", 0x0308}, {"cedilla", 0x0327}, {"macron", 0x0304}, {"ogonek", 0x0328},
+	{"breve", 0x0306}, {"caron", 0x030C}, {"acute", 0x0301}, {"grave", 0x0300}, {"tilde", 0x0303}, {"ring", 0x030A},
+}
+
+var glyphNames = map[string]rune{}
+
+func init() {
+	for i, s := range strings.Fields(winAnsiGlyphNames) {
+		if s != "-" {
+			glyphNames[s] = charmap.Windows1252.DecodeByte(byte(0x20 + i))
+		}
+	}
+	for s, r := range extraGlyphNames {
+		glyphNames[s] = r
+	}
+	// Greek letters unless already defined eg. mu, Delta, Omega.
+	greek := func(names string, lower, upper rune) {
+		for i, s := range strings.Fields(names) {
+			if _, ok := glyphNames[s]; !ok {
+				glyphNames[s] = lower + rune(i)
+			}
+			if s = strings.ToUpper(s[:1]) + s[1:]; glyphNames[s] == 0 {
+				glyphNames[s] = upper + rune(i)
+			}
+		}
+	}
+	greek("alpha beta gamma delta epsilon zeta eta theta iota kappa lambda mu nu xi omicron pi rho", 0x03B1, 0x0391)
+	greek("sigma tau upsilon phi chi psi omega", 0x03C3, 0x03A3)
+}
+
+func glyphNameHex(s string) (rune, bool) {
+	u, err := strconv.ParseUint(s, 16, 32)
+	if err != nil || u > unicode.MaxRune || u >= 0xD800 && u <= 0xDFFF {
+		return 0, false
+	}
+	return rune(u), true
+}
+
+// glyphNameComponent returns the text of a glyph name component
+// using the conventions of the Adobe Glyph List specification.
+func glyphNameComponent(s string) (string, bool) {
+	if r, ok := glyphNames[s]; ok {
+		return string(r), true
+	}
+
+	// uni0041 or uni00410042
+	if strings.HasPrefix(s, "uni") && len(s) > 3 && (len(s)-3)%4 == 0 && strings.ToUpper(s) == "UNI"+s[3:] {
+		var sb strings.Builder
+		for i := 3; i < len(s); i += 4 {
+			r, ok := glyphNameHex(s[i : i+4])
+			if !ok {
+				return "", false
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String(), true
+	}
+
+	// u0041 up to u10FFFF
+	if strings.HasPrefix(s, "u") && len(s) >= 5 && len(s) <= 7 && strings.ToUpper(s) == "U"+s[1:] {
+		if r, ok := glyphNameHex(s[1:]); ok {
+			return string(r), true
+		}
+		return "", false
+	}
+
+	// Latin letters with diacritics eg. Scaron, gcommaaccent, Idotaccent
+	for _, acc := range glyphNameAccents {
+		base, ok := strings.CutSuffix(s, acc.name)
+		if !ok {
+			continue
+		}
+		if base == "dotlessi" {
+			base = "i"
+		}
+		if len(base) != 1 || !unicode.IsLetter(rune(base[0])) {
+			return "", false
+		}
+		if c := norm.NFC.String(base + string(acc.r)); utf8.RuneCountInString(c) == 1 {
+			return c, true
+		}
+		return "", false
+	}
+
+	return "", false
+}
+
+// glyphNameText returns the Unicode text for a glyph name eg. "A", "uni20AC", "f_f_i" or "one.oldstyle".
+func glyphNameText(name string) (string, bool) {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for _, s := range strings.Split(name, "_") {
+		t, ok := glyphNameComponent(s)
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(t)
+	}
+
+	return sb.String(), true
+}
+
+// baseEncodingText returns the Unicode text of character code c for a predefined simple font encoding.
+func baseEncodingText(encoding string, c byte) (string, bool) {
+	if c < 0x20 {
+		return "", false
+	}
+
+	var r rune
+
+	switch encoding {
+
+	case "WinAnsiEncoding":
+		r = charmap.Windows1252.DecodeByte(c)
+
+	case "MacRomanEncoding":
+		if c == 0x7F {
+			return "", false
+		}
+		r = charmap.Macintosh.DecodeByte(c)
+
+	case "StandardEncoding":
+		if s, ok := standardEncodingGlyphNames[c]; ok {
+			return glyphNameText(s)
+		}
+		if c > 0x7E {
+			return "", false
+		}
+		r = rune(c)
+
+	default:
+		return "", false
+	}
+
+	if r == utf8.RuneError || unicode.IsControl(r) {
+		return "", false
+	}
+
+	return string(r), true
+}
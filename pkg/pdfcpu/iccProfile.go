@@ -20,21 +20,30 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math"
 
 	"github.com/pkg/errors"
 )
 
-// ICC profiles are not yet supported!
+// ICC profiles are only supported for color conversion of matrix/TRC based RGB and gray profiles.
 //
-// We fall back to the alternate color space and if there is none to whatever color space makes sense.
+// Otherwise we fall back to the alternate color space and if there is none to whatever color space makes sense.
 
 // ICC profiles use big endian always.
 type iccProfile struct {
 	b          []byte
-	rX, rY, rZ float32 // redMatrixColumn; the first column in the matrix, which is used in matrix/TRC transforms.
-	gX, gY, gZ float32 // greenMatrixColumn; the second column in the matrix, which is used in matrix/TRC transforms.
-	bX, bY, bZ float32 // blueMatrixColumn; the third column in the matrix, which is used in matrix/TRC transforms.
-	//TRC = tone reproduction curve
+	rX, rY, rZ float32    // redMatrixColumn; the first column in the matrix, which is used in matrix/TRC transforms.
+	gX, gY, gZ float32    // greenMatrixColumn; the second column in the matrix, which is used in matrix/TRC transforms.
+	bX, bY, bZ float32    // blueMatrixColumn; the third column in the matrix, which is used in matrix/TRC transforms.
+	wX, wY, wZ float32    // mediaWhitePoint; used for generating the ICC-absolute colorimetric intent.
+	trc        []iccCurve // tone reproduction curves, one for gray or three for RGB.
+}
+
+// iccCurve is a tone reproduction curve of type curveType or parametricCurveType.
+type iccCurve struct {
+	table  []float64 // curveType having more than one entry.
+	fType  int       // parametricCurveType function type.
+	params []float64 // gamma for curveType having one entry or parameters g, a, b, c, d, e, f of parametricCurveType.
 }
 
 // header 128 bytes
@@ -59,7 +68,7 @@ type iccProfile struct {
 
 func (p iccProfile) tag(sig string) (int, int, error) {
 
-	for i, j := 0, 132; i < p.tagCount(); i++ {
+	for i, j := 0, 132; i < p.tagCount() && j+12 <= len(p.b); i++ {
 		s := string(p.b[j : j+4])
 		if s != sig {
 			j += 12
@@ -69,6 +78,9 @@ func (p iccProfile) tag(sig string) (int, int, error) {
 		off := binary.BigEndian.Uint32(p.b[j:])
 		j += 4
 		size := binary.BigEndian.Uint32(p.b[j:])
+		if uint64(off)+uint64(size) > uint64(len(p.b)) {
+			return 0, 0, errors.Errorf("tag %s out of bounds", sig)
+		}
 		return int(off), int(size), nil
 	}
 
@@ -91,10 +103,139 @@ func (p *iccProfile) matrixCol(sig string) (float32, float32, float32, error) {
 	return x, y, z, nil
 }
 
+func (p *iccProfile) curve(sig string) (iccCurve, error) {
+
+	off, size, err := p.tag(sig)
+	if err != nil {
+		return iccCurve{}, err
+	}
+
+	if size < 12 {
+		return iccCurve{}, errors.Errorf("tag %s too short: %d", sig, size)
+	}
+
+	switch string(p.b[off : off+4]) {
+
+	case "curv":
+		n := int(binary.BigEndian.Uint32(p.b[off+8:]))
+		if 12+2*n > size {
+			return iccCurve{}, errors.Errorf("tag %s: corrupt curve", sig)
+		}
+		if n == 0 {
+			return iccCurve{params: []float64{1}}, nil
+		}
+		if n == 1 {
+			// u8Fixed8Number
+			return iccCurve{params: []float64{float64(binary.BigEndian.Uint16(p.b[off+12:])) / 0x100}}, nil
+		}
+		c := iccCurve{table: make([]float64, n)}
+		for i := range c.table {
+			c.table[i] = float64(binary.BigEndian.Uint16(p.b[off+12+2*i:])) / 0xFFFF
+		}
+		return c, nil
+
+	case "para":
+		fType := int(binary.BigEndian.Uint16(p.b[off+8:]))
+		if fType > 4 {
+			return iccCurve{}, errors.Errorf("tag %s: unsupported parametric curve type %d", sig, fType)
+		}
+		n := []int{1, 3, 4, 5, 7}[fType]
+		if 12+4*n > size {
+			return iccCurve{}, errors.Errorf("tag %s: corrupt parametric curve", sig)
+		}
+		c := iccCurve{fType: fType, params: make([]float64, n)}
+		for i := range c.params {
+			c.params[i] = float64(p.s15Fixed16(off + 12 + 4*i))
+		}
+		return c, nil
+	}
+
+	return iccCurve{}, errors.Errorf("tag %s: unsupported curve type %s", sig, string(p.b[off:off+4]))
+}
+
+// apply maps a device component onto its linear value.
+func (c iccCurve) apply(x float64) float64 {
+	x = math.Max(0, math.Min(1, x))
+
+	if c.table != nil {
+		f := x * float64(len(c.table)-1)
+		i := int(f)
+		if i >= len(c.table)-1 {
+			return c.table[len(c.table)-1]
+		}
+		return c.table[i] + (f-float64(i))*(c.table[i+1]-c.table[i])
+	}
+
+	p := c.params
+	g := p[0]
+
+	switch c.fType {
+	case 1:
+		if p[1] != 0 && x >= -p[2]/p[1] {
+			return math.Pow(p[1]*x+p[2], g)
+		}
+		return 0
+	case 2:
+		if p[1] != 0 && x >= -p[2]/p[1] {
+			return math.Pow(p[1]*x+p[2], g) + p[3]
+		}
+		return p[3]
+	case 3:
+		if x >= p[4] {
+			return math.Pow(p[1]*x+p[2], g)
+		}
+		return p[3] * x
+	case 4:
+		if x >= p[4] {
+			return math.Pow(p[1]*x+p[2], g) + p[5]
+		}
+		return p[3]*x + p[6]
+	}
+
+	return math.Pow(x, g)
+}
+
 func (p *iccProfile) init() error {
 
 	var err error
 
+	if len(p.b) < 132 {
+		return errors.New("corrupt profile header")
+	}
+
+	if p.pcs() != "XYZ " {
+		return errors.Errorf("unsupported PCS %s", p.pcs())
+	}
+
+	// The media white point defaults to the PCS illuminant.
+	p.wX, p.wY, p.wZ = p.xyz(68)
+	if off, size, err := p.tag("wtpt"); err == nil && size >= 20 {
+		p.wX, p.wY, p.wZ = p.xyz(off + 8)
+	}
+
+	switch p.dataColorSpace() {
+
+	case "GRAY":
+		c, err := p.curve("kTRC")
+		if err != nil {
+			return err
+		}
+		p.trc = []iccCurve{c}
+		return nil
+
+	case "RGB ":
+		for _, sig := range []string{"rTRC", "gTRC", "bTRC"} {
+			c, err := p.curve(sig)
+			if err != nil {
+				return err
+			}
+			p.trc = append(p.trc, c)
+		}
+
+	default:
+		return errors.Errorf("unsupported data color space %s", p.dataColorSpace())
+	}
+
 	p.rX, p.rY, p.rZ, err = p.matrixCol("rXYZ")
 	if err != nil {
 		return err
@@ -110,6 +251,75 @@ func (p *iccProfile) init() error {
 	return err
 }
 
+// newICCProfile returns a matrix/TRC based RGB or gray profile.
+func newICCProfile(b []byte) (*iccProfile, error) {
+	p := &iccProfile{b: b}
+	if err := p.init(); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: unsupported ICC profile")
+	}
+	return p, nil
+}
+
+// components returns the number of components of the profile's data color space.
+func (p iccProfile) components() int {
+	return len(p.trc)
+}
+
+// d50 is the PCS illuminant.
+var d50 = [3]float64{0.9642, 1, 0.8249}
+
+// xyzD50ToLinearSRGB converts D50 adapted PCS XYZ to linear sRGB (Bradford adaptation).
+var xyzD50ToLinearSRGB = [3][3]float64{
+	{3.1338561, -1.6168667, -0.4906146},
+	{-0.9787684, 1.9161415, 0.0334540},
+	{0.0719453, -0.2289914, 1.4052427},
+}
+
+func srgbGamma(f float64) float64 {
+	f = math.Max(0, math.Min(1, f))
+	if f <= 0.0031308 {
+		return 12.92 * f
+	}
+	return 1.055*math.Pow(f, 1/2.4) - 0.055
+}
+
+// pcsXYZ returns the PCS XYZ of the profile's data color space components comp.
+// For the ICC-absolute colorimetric intent the media white point is applied,
+// all other intents are media-relative for matrix/TRC based profiles.
+func (p iccProfile) pcsXYZ(comp []float64, absolute bool) [3]float64 {
+	var xyz [3]float64
+
+	if len(p.trc) == 1 {
+		y := p.trc[0].apply(comp[0])
+		xyz = [3]float64{d50[0] * y, d50[1] * y, d50[2] * y}
+	} else {
+		r, g, b := p.trc[0].apply(comp[0]), p.trc[1].apply(comp[1]), p.trc[2].apply(comp[2])
+		xyz = [3]float64{
+			float64(p.rX)*r + float64(p.gX)*g + float64(p.bX)*b,
+			float64(p.rY)*r + float64(p.gY)*g + float64(p.bY)*b,
+			float64(p.rZ)*r + float64(p.gZ)*g + float64(p.bZ)*b,
+		}
+	}
+
+	if absolute {
+		xyz[0] *= float64(p.wX) / d50[0]
+		xyz[1] *= float64(p.wY) / d50[1]
+		xyz[2] *= float64(p.wZ) / d50[2]
+	}
+
+	return xyz
+}
+
+// sRGB converts the profile's data color space components comp into sRGB.
+func (p iccProfile) sRGB(comp []float64, absolute bool) []float64 {
+	xyz := p.pcsXYZ(comp, absolute)
+	rgb := make([]float64, 3)
+	for i, m := range xyzD50ToLinearSRGB {
+		rgb[i] = srgbGamma(m[0]*xyz[0] + m[1]*xyz[1] + m[2]*xyz[2])
+	}
+	return rgb
+}
+
 func (p iccProfile) size() uint32 {
 	return binary.BigEndian.Uint32(p.b[0:])
 }
@@ -181,35 +391,12 @@ func (p iccProfile) renderingIntent() string {
 	return "Perceptual"
 }
 
-func (p iccProfile) xyz(i int) (x, y, z float32) {
-
-	x = float32(binary.BigEndian.Uint16(p.b[i:]))
-	f := float32(binary.BigEndian.Uint16(p.b[i+2:])) / 0x10000
-	if x < 0 {
-		x -= f
-	} else {
-		x += f
-	}
-	i += 4
-
-	y = float32(binary.BigEndian.Uint16(p.b[i:]))
-	f = float32(binary.BigEndian.Uint16(p.b[i+2:])) / 0x10000
-	if y < 0 {
-		y -= f
-	} else {
-		y += f
-	}
-	i += 4
-
-	z = float32(binary.BigEndian.Uint16(p.b[i:]))
-	f = float32(binary.BigEndian.Uint16(p.b[i+2:])) / 0x10000
-	if z < 0 {
-		z -= f
-	} else {
-		z += f
-	}
+func (p iccProfile) s15Fixed16(i int) float32 {
+	return float32(int32(binary.BigEndian.Uint32(p.b[i:]))) / 0x10000
+}
 
-	return
+func (p iccProfile) xyz(i int) (x, y, z float32) {
+	return p.s15Fixed16(i), p.s15Fixed16(i + 4), p.s15Fixed16(i + 8)
 }
 
 func (p iccProfile) PCSIlluminant() string {
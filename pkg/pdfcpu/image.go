@@ -419,3 +419,67 @@ func UpdateImagesByPageNrAndId(ctx *model.Context, rd io.Reader, pageNr int, id
 
 	return errors.Errorf("pdfcpu: page %d: unknown resource %s\n", pageNr, id)
 }
+
+// imageObjNrForResource returns the object number of the image XObject referenced by pageNr and id.
+func imageObjNrForResource(ctx *model.Context, pageNr int, id string) (int, error) {
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return 0, errors.Errorf("pdfcpu: invalid pageNr=%d", pageNr)
+	}
+
+	if ctx.Optimize == nil {
+		return 0, errors.New("pdfcpu: missing image objects, context not optimized")
+	}
+
+	for _, objNr := range ImageObjNrs(ctx, pageNr) {
+		imgObj := ctx.Optimize.ImageObjects[objNr]
+		if imgObj != nil && imgObj.ResourceNames[pageNr-1] == id {
+			return objNr, nil
+		}
+	}
+
+	return 0, errors.Errorf("pdfcpu: page %d: unknown image resource %s\n", pageNr, id)
+}
+
+// ReplaceImage swaps the data of the image XObject identified by objNr or (pageNr and id) with the image read from rd.
+// Width, Height, ColorSpace, BitsPerComponent and Filter are taken from the new image.
+// Since all references to the XObject are retained, placement on any page using this image is left untouched.
+func ReplaceImage(ctx *model.Context, rd io.Reader, objNr, pageNr int, id string) error {
+	if objNr < 1 {
+		var err error
+		if objNr, err = imageObjNrForResource(ctx, pageNr, id); err != nil {
+			return err
+		}
+	}
+
+	entry, ok := ctx.FindTableEntry(objNr, 0)
+	if !ok || entry.Object == nil {
+		return errors.Errorf("pdfcpu: invalid objNr=%d", objNr)
+	}
+
+	old, ok := entry.Object.(types.StreamDict)
+	if !ok || old.Subtype() == nil || *old.Subtype() != "Image" {
+		return errors.Errorf("pdfcpu: objNr=%d is not an image", objNr)
+	}
+
+	sd, _, _, err := model.CreateImageStreamDict(ctx.XRefTable, rd)
+	if err != nil {
+		return err
+	}
+
+	// Retain attributes unrelated to the image data.
+	for _, k := range []string{"Metadata", "OC", "StructParent", "ID", "Interpolate"} {
+		if o, found := old.Find(k); found {
+			sd.Insert(k, o)
+		}
+	}
+
+	entry.Object = *sd
+
+	if ctx.Optimize != nil {
+		if imgObj := ctx.Optimize.ImageObjects[objNr]; imgObj != nil {
+			imgObj.ImageDict = sd
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,454 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultImpositionPaperSize     = "A3L"
+	defaultImpositionSignatureSize = 4
+	defaultImpositionMarksMargin   = 36
+
+	markOffset = 3 // Distance of crop marks from the trim edge.
+)
+
+// impositionTile is a page placed on a sheet side, pageNr 0 represents a blank page.
+type impositionTile struct {
+	pageNr int
+	r      *types.Rectangle
+	rotate bool
+}
+
+// DefaultImposition returns the default imposition.
+func DefaultImposition() *model.Imposition {
+	return &model.Imposition{
+		Scheme:        model.ImposeSignature,
+		PaperSize:     defaultImpositionPaperSize,
+		SignatureSize: defaultImpositionSignatureSize,
+	}
+}
+
+// ParseImposition parses a JSON encoded imposition.
+func ParseImposition(r io.Reader) (*model.Imposition, error) {
+	imp := &model.Imposition{}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(imp); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid imposition")
+	}
+	if err := ValidateImposition(imp); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+func validateImpositionSlots(imp *model.Imposition, sheet *types.Rectangle) error {
+	if len(imp.Slots) == 0 {
+		return errors.New("pdfcpu: invalid imposition: custom scheme requires slots")
+	}
+	seen := map[int]bool{}
+	for i, s := range imp.Slots {
+		if s.Page < 1 {
+			return errors.Errorf("pdfcpu: invalid imposition: slot %d: page must be >= 1", i+1)
+		}
+		if seen[s.Page] {
+			return errors.Errorf("pdfcpu: invalid imposition: slot %d: duplicate page %d", i+1, s.Page)
+		}
+		seen[s.Page] = true
+		if s.Width <= 0 || s.Height <= 0 {
+			return errors.Errorf("pdfcpu: invalid imposition: slot %d: width and height must be > 0", i+1)
+		}
+		if !types.NewRectangle(s.X, s.Y, s.X+s.Width, s.Y+s.Height).FitsWithin(sheet) {
+			return errors.Errorf("pdfcpu: invalid imposition: slot %d exceeds sheet", i+1)
+		}
+		if s.Rotate != 0 && s.Rotate != 180 {
+			return errors.Errorf("pdfcpu: invalid imposition: slot %d: rotate must be 0 or 180", i+1)
+		}
+	}
+	return nil
+}
+
+// ValidateImposition validates imp and applies defaults.
+func ValidateImposition(imp *model.Imposition) error {
+	if imp == nil {
+		return errors.New("pdfcpu: missing imposition")
+	}
+
+	if imp.Width < 0 || imp.Height < 0 || imp.Margin < 0 || imp.Gutter < 0 || imp.Creep < 0 || imp.Cols < 0 || imp.Rows < 0 || imp.SignatureSize < 0 {
+		return errors.New("pdfcpu: invalid imposition: negative values not allowed")
+	}
+
+	if (imp.Width > 0) != (imp.Height > 0) {
+		return errors.New("pdfcpu: invalid imposition: please provide both sheet width and height")
+	}
+
+	if imp.Width == 0 {
+		if imp.PaperSize == "" {
+			imp.PaperSize = defaultImpositionPaperSize
+		}
+		dim, _, err := types.ParsePageFormat(imp.PaperSize)
+		if err != nil {
+			return err
+		}
+		imp.Width, imp.Height = dim.Width, dim.Height
+	}
+
+	if imp.Margin == 0 && imp.PrintersMarks() && imp.Scheme != model.ImposeCustom {
+		imp.Margin = defaultImpositionMarksMargin
+	}
+
+	if 2*imp.Margin >= math.Min(imp.Width, imp.Height) {
+		return errors.New("pdfcpu: invalid imposition: margin too large for sheet")
+	}
+
+	switch imp.Scheme {
+
+	case model.ImposeCutStack:
+		if imp.Cols == 0 || imp.Rows == 0 {
+			return errors.New("pdfcpu: invalid imposition: cutStack requires cols and rows")
+		}
+
+	case model.ImposeSignature:
+		if imp.Cols > 0 && imp.Cols != 2 || imp.Rows > 1 {
+			return errors.New("pdfcpu: invalid imposition: signatures use a 2 x 1 grid")
+		}
+		imp.Cols, imp.Rows = 2, 1
+		if imp.SignatureSize == 0 {
+			imp.SignatureSize = defaultImpositionSignatureSize
+		}
+
+	case model.ImposeCustom:
+		return validateImpositionSlots(imp, types.RectForDim(imp.Width, imp.Height))
+
+	default:
+		return errors.Errorf("pdfcpu: invalid imposition scheme: %q", imp.Scheme)
+	}
+
+	return nil
+}
+
+// impositionCell returns the rectangle of a grid cell, row 0 being the top row.
+func impositionCell(imp *model.Imposition, col, row int) *types.Rectangle {
+	m, g := imp.Margin, imp.Gutter
+	w := (imp.Width - 2*m - float64(imp.Cols-1)*g) / float64(imp.Cols)
+	h := (imp.Height - 2*m - float64(imp.Rows-1)*g) / float64(imp.Rows)
+	x := m + float64(col)*(w+g)
+	y := imp.Height - m - float64(row+1)*h - float64(row)*g
+	return types.RectForWidthAndHeight(x, y, w, h)
+}
+
+func impositionPageNr(pageNumbers []int, i int) int {
+	if i < 0 || i >= len(pageNumbers) {
+		return 0
+	}
+	return pageNumbers[i]
+}
+
+// cutStackSides arranges pages so that cutting the printed stack and stacking the piles yields the original sequence.
+func cutStackSides(imp *model.Imposition, pageNumbers []int) [][]impositionTile {
+	n := imp.Cols * imp.Rows
+
+	units := len(pageNumbers)
+	if imp.Duplex {
+		// A unit is a leaf holding 2 pages.
+		units = (units + 1) / 2
+	}
+	sheets := (units + n - 1) / n
+
+	sides := [][]impositionTile{}
+
+	for s := 0; s < sheets; s++ {
+		front, back := []impositionTile{}, []impositionTile{}
+		for k := 0; k < n; k++ {
+			col, row := k%imp.Cols, k/imp.Cols
+			unit := k*sheets + s
+			if !imp.Duplex {
+				front = append(front, impositionTile{pageNr: impositionPageNr(pageNumbers, unit), r: impositionCell(imp, col, row)})
+				continue
+			}
+			front = append(front, impositionTile{pageNr: impositionPageNr(pageNumbers, 2*unit), r: impositionCell(imp, col, row)})
+			// The back side is flipped around the vertical axis.
+			back = append(back, impositionTile{pageNr: impositionPageNr(pageNumbers, 2*unit+1), r: impositionCell(imp, imp.Cols-1-col, row)})
+		}
+		sides = append(sides, front)
+		if imp.Duplex {
+			sides = append(sides, back)
+		}
+	}
+
+	return sides
+}
+
+// signatureSides arranges pages into nested folded sheets of imp.SignatureSize sheets each.
+func signatureSides(imp *model.Imposition, pageNumbers []int) [][]impositionTile {
+	sides := [][]impositionTile{}
+
+	left, right := impositionCell(imp, 0, 0), impositionCell(imp, 1, 0)
+
+	tile := func(i int, r *types.Rectangle, dx float64) impositionTile {
+		r1 := *r
+		r1.Translate(dx, 0)
+		return impositionTile{pageNr: impositionPageNr(pageNumbers, i), r: &r1}
+	}
+
+	perSignature := 4 * imp.SignatureSize
+
+	for start := 0; start < len(pageNumbers); start += perSignature {
+		// The last signature may be short.
+		sheets := imp.SignatureSize
+		if rest := len(pageNumbers) - start; rest < perSignature {
+			sheets = (rest + 3) / 4
+		}
+		n := 4 * sheets
+
+		for i := 0; i < sheets; i++ {
+			// Compensate for inner sheets pushing out at the fore edge.
+			creep := imp.Creep * float64(i)
+			sides = append(sides,
+				[]impositionTile{
+					tile(start+n-2*i-1, left, creep),
+					tile(start+2*i, right, -creep),
+				},
+				[]impositionTile{
+					tile(start+2*i+1, left, creep),
+					tile(start+n-2*i-2, right, -creep),
+				},
+			)
+		}
+	}
+
+	return sides
+}
+
+// customSides arranges pages into the slots of a custom layout.
+func customSides(imp *model.Imposition, pageNumbers []int) [][]impositionTile {
+	var perSheet int
+	var duplex bool
+	for _, s := range imp.Slots {
+		if s.Page > perSheet {
+			perSheet = s.Page
+		}
+		duplex = duplex || s.Back
+	}
+
+	sides := [][]impositionTile{}
+
+	for start := 0; start < len(pageNumbers); start += perSheet {
+		front, back := []impositionTile{}, []impositionTile{}
+		for _, s := range imp.Slots {
+			t := impositionTile{
+				pageNr: impositionPageNr(pageNumbers, start+s.Page-1),
+				r:      types.RectForWidthAndHeight(s.X, s.Y, s.Width, s.Height),
+				rotate: s.Rotate == 180,
+			}
+			if s.Back {
+				back = append(back, t)
+			} else {
+				front = append(front, t)
+			}
+		}
+		sides = append(sides, front)
+		if duplex {
+			sides = append(sides, back)
+		}
+	}
+
+	return sides
+}
+
+func impositionSides(imp *model.Imposition, pageNumbers []int) [][]impositionTile {
+	switch imp.Scheme {
+	case model.ImposeCutStack:
+		return cutStackSides(imp, pageNumbers)
+	case model.ImposeSignature:
+		return signatureSides(imp, pageNumbers)
+	}
+	return customSides(imp, pageNumbers)
+}
+
+func trimEdges(tt []impositionTile) ([]float64, []float64) {
+	xm, ym := map[float64]bool{}, map[float64]bool{}
+	for _, t := range tt {
+		xm[t.r.LL.X], xm[t.r.UR.X] = true, true
+		ym[t.r.LL.Y], ym[t.r.UR.Y] = true, true
+	}
+	xx, yy := make([]float64, 0, len(xm)), make([]float64, 0, len(ym))
+	for x := range xm {
+		xx = append(xx, x)
+	}
+	for y := range ym {
+		yy = append(yy, y)
+	}
+	sort.Float64s(xx)
+	sort.Float64s(yy)
+	return xx, yy
+}
+
+// drawCropMarks draws crop marks for all trim edges into the inner half of the sheet margin.
+func drawCropMarks(w io.Writer, imp *model.Imposition, tt []impositionTile) {
+	xx, yy := trimEdges(tt)
+	m := imp.Margin
+	l := (m - markOffset) / 2
+	if l <= 0 {
+		return
+	}
+
+	fmt.Fprint(w, "q [] 0 d 0.25 w 1 1 1 1 K ")
+	for _, x := range xx {
+		fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l S ", x, m-markOffset, x, m-markOffset-l)
+		fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l S ", x, imp.Height-m+markOffset, x, imp.Height-m+markOffset+l)
+	}
+	for _, y := range yy {
+		fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l S ", m-markOffset, y, m-markOffset-l, y)
+		fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l S ", imp.Width-m+markOffset, y, imp.Width-m+markOffset+l, y)
+	}
+	fmt.Fprint(w, "Q ")
+}
+
+func drawRegistrationMark(w io.Writer, x, y, r float64) {
+	// Approximate the circle by 4 bezier curves.
+	k := 0.5523 * r
+	fmt.Fprintf(w, "%.2f %.2f m ", x+r, y)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x+r, y+k, x+k, y+r, x, y+r)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x-k, y+r, x-r, y+k, x-r, y)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c ", x-r, y-k, x-k, y-r, x, y-r)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c S ", x+k, y-r, x+r, y-k, x+r, y)
+	fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l S ", x-1.5*r, y, x+1.5*r, y)
+	fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l S ", x, y-1.5*r, x, y+1.5*r)
+}
+
+// drawRegistrationMarks draws registration marks centered in the outer half of each sheet margin.
+func drawRegistrationMarks(w io.Writer, imp *model.Imposition) {
+	d := imp.Margin / 4
+	r := math.Min(d-1, 6)
+	if r <= 0 {
+		return
+	}
+
+	fmt.Fprint(w, "q [] 0 d 0.25 w 1 1 1 1 K ")
+	drawRegistrationMark(w, imp.Width/2, d, r)
+	drawRegistrationMark(w, imp.Width/2, imp.Height-d, r)
+	drawRegistrationMark(w, d, imp.Height/2, r)
+	drawRegistrationMark(w, imp.Width-d, imp.Height/2, r)
+	fmt.Fprint(w, "Q ")
+}
+
+var colorBarPatches = [][4]float64{
+	{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1},
+	{1, 1, 0, 0}, {1, 0, 1, 0}, {0, 1, 1, 0},
+	{0, 0, 0, .75}, {0, 0, 0, .5}, {0, 0, 0, .25},
+}
+
+// drawColorBars draws CMYK control patches into the outer half of the bottom sheet margin.
+func drawColorBars(w io.Writer, imp *model.Imposition) {
+	s := math.Min(imp.Margin/2-2, 10)
+	if s <= 0 {
+		return
+	}
+
+	y := (imp.Margin/2 - s) / 2
+	fmt.Fprint(w, "q ")
+	for i, c := range colorBarPatches {
+		fmt.Fprintf(w, "%.2f %.2f %.2f %.2f k %.2f %.2f %.2f %.2f re f ", c[0], c[1], c[2], c[3], imp.Margin+float64(i)*s, y, s, s)
+	}
+	fmt.Fprint(w, "Q ")
+}
+
+func drawPrintersMarks(w io.Writer, imp *model.Imposition, tt []impositionTile) {
+	if imp.CropMarks {
+		drawCropMarks(w, imp, tt)
+	}
+	if imp.RegistrationMarks {
+		drawRegistrationMarks(w, imp)
+	}
+	if imp.ColorBars {
+		drawColorBars(w, imp)
+	}
+}
+
+func imposePages(ctx *model.Context, selectedPages types.IntSet, imp *model.Imposition, pagesDict types.Dict, pagesIndRef *types.IndirectRef) error {
+	// Tiles get scaled to fit their slot preserving orientation.
+	nup := &model.NUp{PageDim: &types.Dim{Width: imp.Width, Height: imp.Height}}
+
+	for _, tt := range impositionSides(imp, sortSelectedPages(selectedPages)) {
+		var buf bytes.Buffer
+		formsResDict := types.NewDict()
+
+		for _, t := range tt {
+			if t.pageNr == 0 {
+				continue
+			}
+			if err := ctx.NUpTilePDFBytesForPDF(t.pageNr, formsResDict, &buf, t.r, nup, t.rotate); err != nil {
+				return err
+			}
+		}
+
+		drawPrintersMarks(&buf, imp, tt)
+
+		if err := wrapUpPage(ctx, nup, formsResDict, buf, pagesDict, pagesIndRef); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImposeFromPDF arranges selected pages of ctx on press sheets according to imp.
+func ImposeFromPDF(ctx *model.Context, selectedPages types.IntSet, imp *model.Imposition) error {
+	if err := ValidateImposition(imp); err != nil {
+		return err
+	}
+
+	mb := types.RectForDim(imp.Width, imp.Height)
+
+	pagesDict := types.Dict(
+		map[string]types.Object{
+			"Type":     types.Name("Pages"),
+			"Count":    types.Integer(0),
+			"MediaBox": mb.Array(),
+		},
+	)
+
+	pagesIndRef, err := ctx.IndRefForNewObject(pagesDict)
+	if err != nil {
+		return err
+	}
+
+	if err = imposePages(ctx, selectedPages, imp, pagesDict, pagesIndRef); err != nil {
+		return err
+	}
+
+	// Replace original pagesDict.
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	rootDict.Update("Pages", *pagesIndRef)
+	return nil
+}
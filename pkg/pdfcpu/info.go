@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
@@ -116,7 +115,7 @@ func ensureInfoDict(ctx *model.Context) error {
 	// ModDate		        modified by pdfcpu
 	// Trapped              -
 
-	now := types.DateString(time.Now())
+	now := types.DateString(ctx.Now())
 
 	v := "pdfcpu " + model.VersionStr
 
@@ -0,0 +1,297 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Process colorants, other colorants are spot colors.
+const (
+	InkCyan    = "Cyan"
+	InkMagenta = "Magenta"
+	InkYellow  = "Yellow"
+	InkBlack   = "Black"
+)
+
+var processColorants = []string{InkCyan, InkMagenta, InkYellow, InkBlack}
+
+// Pages having less coverage for all colorants except black are considered grayscale.
+const inkColorThreshold = 0.01 // percent
+
+const (
+	inkChannelNone = -1
+	inkChannelAll  = -2
+)
+
+// inkColorSpace maps color components to ink layer channels.
+type inkColorSpace struct {
+	n        int   // number of process color components (1, 3 or 4)
+	channels []int // ink layer channel for each component of Separation and DeviceN color spaces
+	base     *inkColorSpace
+	hival    int
+	lookup   []byte
+}
+
+func (cs *inkColorSpace) components() int {
+	if cs.base != nil {
+		return 1
+	}
+	if cs.n > 0 {
+		return cs.n
+	}
+	return len(cs.channels)
+}
+
+// inkLayer tracks the tint of each colorant per pixel.
+type inkLayer struct {
+	names []string
+	index map[string]int
+	pix   [][]float32
+	size  int
+}
+
+func newInkLayer(size int) *inkLayer {
+	l := &inkLayer{index: map[string]int{}, size: size}
+	for _, s := range processColorants {
+		l.channel(s)
+	}
+	return l
+}
+
+func (l *inkLayer) channel(name string) int {
+	switch name {
+	case "None":
+		return inkChannelNone
+	case "All":
+		return inkChannelAll
+	}
+	if i, ok := l.index[name]; ok {
+		return i
+	}
+	l.index[name] = len(l.names)
+	l.names = append(l.names, name)
+	l.pix = append(l.pix, make([]float32, l.size))
+	return len(l.names) - 1
+}
+
+func (l *inkLayer) blend(i int, ink []float64, alpha float64) {
+	for c, pix := range l.pix {
+		var t float64
+		if c < len(ink) {
+			t = ink[c]
+		}
+		pix[i] = float32(float64(pix[i])*(1-alpha) + t*alpha)
+	}
+}
+
+func (l *inkLayer) colorantNames(ctx *model.Context, o types.Object) []int {
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return nil
+	}
+	switch o := o.(type) {
+	case types.Name:
+		return []int{l.channel(o.Value())}
+	case types.Array:
+		cc := make([]int, 0, len(o))
+		for _, o1 := range o {
+			n, _ := o1.(types.Name)
+			cc = append(cc, l.channel(n.Value()))
+		}
+		return cc
+	}
+	return nil
+}
+
+// parseColorSpace returns the ink mapping for color space o or nil if o is not supported.
+func (l *inkLayer) parseColorSpace(ctx *model.Context, o types.Object) *inkColorSpace {
+	if n := processComponents(ctx, o); n > 0 {
+		return &inkColorSpace{n: n}
+	}
+
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return nil
+	}
+
+	a, ok := o.(types.Array)
+	if !ok || len(a) < 2 {
+		return nil
+	}
+
+	switch a[0] {
+
+	case types.Name(model.SeparationCS), types.Name(model.DeviceNCS):
+		if cc := l.colorantNames(ctx, a[1]); len(cc) > 0 {
+			return &inkColorSpace{channels: cc}
+		}
+
+	case types.Name(model.IndexedCS):
+		if len(a) < 4 {
+			return nil
+		}
+		base := l.parseColorSpace(ctx, a[1])
+		if base == nil || base.base != nil {
+			return nil
+		}
+		hival, err := ctx.DereferenceInteger(a[2])
+		if err != nil || hival == nil {
+			return nil
+		}
+		lookup, err := colorLookupTable(ctx.XRefTable, a[3])
+		if err != nil {
+			return nil
+		}
+		return &inkColorSpace{base: base, hival: hival.Value(), lookup: lookup}
+	}
+
+	return nil
+}
+
+func (l *inkLayer) colorSpace(ctx *model.Context, res *svgRenderer, resDict types.Dict, name string) *inkColorSpace {
+	if n, ok := colorConversionTargets[name]; ok {
+		return &inkColorSpace{n: n}
+	}
+	o, found := res.resource(resDict, "ColorSpace", name)
+	if !found {
+		return nil
+	}
+	return l.parseColorSpace(ctx, o)
+}
+
+func (l *inkLayer) initialTints(cs *inkColorSpace) []float64 {
+	if cs == nil {
+		return nil
+	}
+	switch {
+	case cs.base != nil:
+		return l.appendTints(nil, cs, []float64{0})
+	case cs.n == 4:
+		return []float64{0, 0, 0, 1}
+	case cs.n > 0:
+		return l.appendTints(nil, cs, make([]float64, cs.n))
+	}
+	ff := make([]float64, len(cs.channels))
+	for i := range ff {
+		ff[i] = 1
+	}
+	return l.appendTints(nil, cs, ff)
+}
+
+func (l *inkLayer) tints(cs *inkColorSpace, ff []float64) []float64 {
+	return l.appendTints(nil, cs, ff)
+}
+
+// appendTints appends the colorant tints for color components ff in color space cs to buf.
+// A nil cs denotes a device color space determined by the number of components.
+func (l *inkLayer) appendTints(buf []float64, cs *inkColorSpace, ff []float64) []float64 {
+	if cs != nil && len(ff) != cs.components() {
+		return buf
+	}
+
+	if cs == nil || cs.n > 0 {
+		if !types.IntMemberOf(len(ff), []int{1, 3, 4}) {
+			return buf
+		}
+		for _, f := range convertComponents(ff, 4) {
+			buf = append(buf, clampUnit(f))
+		}
+		return buf
+	}
+
+	if cs.base != nil {
+		i := int(math.Round(ff[0]))
+		i = int(math.Max(0, math.Min(float64(cs.hival), float64(i))))
+		n := cs.base.components()
+		comp := make([]float64, n)
+		for j := 0; j < n; j++ {
+			if k := i*n + j; k < len(cs.lookup) {
+				comp[j] = float64(cs.lookup[k]) / 255
+			}
+		}
+		return l.appendTints(buf, cs.base, comp)
+	}
+
+	start := len(buf)
+	for range l.names {
+		buf = append(buf, 0)
+	}
+	tt := buf[start:]
+	for i, c := range cs.channels {
+		t := clampUnit(ff[i])
+		switch c {
+		case inkChannelNone:
+		case inkChannelAll:
+			for j := range tt {
+				tt[j] = t
+			}
+		default:
+			tt[c] = t
+		}
+	}
+	return buf
+}
+
+// PageInkCoverage represents the estimated ink coverage of a page.
+type PageInkCoverage struct {
+	PageNr    int                `json:"page"`
+	Colorants map[string]float64 `json:"colorants"` // average coverage in percent of the page area by colorant name.
+	Total     float64            `json:"total"`     // total area coverage in percent, may exceed 100.
+	Color     bool               `json:"color"`     // false if the page is effectively grayscale.
+}
+
+// InkCoverage estimates the coverage of process and spot colorants of page pageNr by rendering at the given resolution.
+// RGB and gray colors are separated into CMYK using the device color conversions, overprint is not taken into account.
+func InkCoverage(ctx *model.Context, pageNr int, dpi float64) (*PageInkCoverage, error) {
+	r, bb, resDict, err := newPageRasterizer(ctx, pageNr, dpi)
+	if err != nil {
+		return nil, err
+	}
+
+	r.inks = newInkLayer(len(r.img.Pix))
+
+	// The initial color is black.
+	r.gs.fillInk = []float64{0, 0, 0, 1}
+	r.gs.strokeInk = r.gs.fillInk
+
+	if len(bb) > 0 {
+		if err := r.render(bb, resDict); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &PageInkCoverage{PageNr: pageNr, Colorants: map[string]float64{}}
+
+	for i, name := range r.inks.names {
+		var sum float64
+		for _, t := range r.inks.pix[i] {
+			sum += float64(t)
+		}
+		v := sum / float64(r.inks.size) * 100
+		c.Colorants[name] = v
+		c.Total += v
+		if name != InkBlack && v >= inkColorThreshold {
+			c.Color = true
+		}
+	}
+
+	return c, nil
+}
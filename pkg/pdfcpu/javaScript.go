@@ -0,0 +1,688 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// JSScope represents a set of locations JavaScript may be attached to.
+type JSScope int
+
+// JavaScript scopes.
+const (
+	JSDocument   JSScope = 1 << iota // document level name tree, open action and catalog additional actions
+	JSPage                           // page additional actions
+	JSField                          // form field actions and additional actions
+	JSAnnotation                     // actions of non widget annotations eg. links
+	JSOutline                        // outline item actions
+	JSAll        = JSDocument | JSPage | JSField | JSAnnotation | JSOutline
+)
+
+var jsScopeNames = map[JSScope]string{
+	JSDocument:   "document",
+	JSPage:       "page",
+	JSField:      "field",
+	JSAnnotation: "annotation",
+	JSOutline:    "outline",
+}
+
+func (s JSScope) String() string {
+	var ss []string
+	for _, sc := range []JSScope{JSDocument, JSPage, JSField, JSAnnotation, JSOutline} {
+		if s&sc > 0 {
+			ss = append(ss, jsScopeNames[sc])
+		}
+	}
+	return strings.Join(ss, ",")
+}
+
+// ParseJSScope parses a comma separated list of JavaScript scopes.
+func ParseJSScope(s string) (JSScope, error) {
+	var scope JSScope
+
+	for _, s1 := range strings.Split(s, ",") {
+		s1 = strings.ToLower(strings.TrimSpace(s1))
+		if s1 == "all" {
+			scope |= JSAll
+			continue
+		}
+		found := false
+		for k, v := range jsScopeNames {
+			if v == s1 {
+				scope |= k
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, errors.Errorf("pdfcpu: invalid JavaScript scope: %s", s1)
+		}
+	}
+
+	return scope, nil
+}
+
+// JavaScript represents a JavaScript action.
+type JavaScript struct {
+	Scope   JSScope `json:"scope"`
+	PageNr  int     `json:"page,omitempty"`  // page number for page and annotation scope
+	ObjNr   int     `json:"objNr,omitempty"` // objNr of the dict owning the action
+	Name    string  `json:"name,omitempty"`  // document level name, fully qualified field name or outline title
+	Trigger string  `json:"trigger"`         // eg. OpenAction, A, AA/K, Names
+	Script  string  `json:"script"`
+}
+
+func (js JavaScript) location() string {
+	loc := js.Scope.String()
+	if js.PageNr > 0 {
+		loc += fmt.Sprintf(" p%d", js.PageNr)
+	}
+	if js.ObjNr > 0 {
+		loc += fmt.Sprintf(" obj#%d", js.ObjNr)
+	}
+	if js.Name != "" {
+		loc += fmt.Sprintf(" %q", js.Name)
+	}
+	return loc + " " + js.Trigger
+}
+
+func (js JavaScript) String() string {
+	return fmt.Sprintf("%s: %s", js.location(), js.Script)
+}
+
+// JSRewriter returns the replacement for a script or nil for removal.
+type JSRewriter func(js JavaScript) *string
+
+// maxActionChain limits the depth of action chains following Next.
+const maxActionChain = 32
+
+// actionVisitor walks all actions within scope.
+// It either collects JavaScript or rewrites scripts using f and removes actions of types marked for removal.
+type actionVisitor struct {
+	ctx     *model.Context
+	scope   JSScope
+	list    bool
+	f       JSRewriter
+	remove  map[string]bool // action types to be removed
+	jj      []JavaScript
+	removed []string // removed actions and their location
+	n       int
+}
+
+func (v *actionVisitor) script(o types.Object) (string, error) {
+	o, err := v.ctx.Dereference(o)
+	if err != nil || o == nil {
+		return "", err
+	}
+
+	if sd, ok := o.(types.StreamDict); ok {
+		// Work on a copy in order to keep the original stream untouched.
+		if err := sd.Decode(); err != nil {
+			return "", err
+		}
+		return string(sd.Content), nil
+	}
+
+	return model.Text(o)
+}
+
+// chain returns the action replacing an action which has been removed from its chain.
+func (v *actionVisitor) chain(next types.Object) (types.Object, error) {
+	o, err := v.ctx.Dereference(next)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	a, ok := o.(types.Array)
+	if !ok {
+		return next, nil
+	}
+
+	if len(a) == 0 {
+		return nil, nil
+	}
+
+	if len(a) == 1 {
+		return a[0], nil
+	}
+
+	// Promote the first action and append the remaining ones to its own chain
+	// which preserves the depth first execution order.
+	d, err := v.ctx.DereferenceDict(a[0])
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	rest := a[1:]
+	if o, found := d.Find("Next"); found {
+		o1, err := v.ctx.Dereference(o)
+		if err != nil {
+			return nil, err
+		}
+		if a1, ok := o1.(types.Array); ok {
+			rest = append(append(types.Array{}, a1...), rest...)
+		} else {
+			rest = append(types.Array{o}, rest...)
+		}
+	}
+	d["Next"] = rest
+
+	return a[0], nil
+}
+
+// action visits the action o including its Next chain.
+// It returns the object that takes the place of o in its parent which is nil if o has been removed.
+func (v *actionVisitor) action(o types.Object, js JavaScript, depth int) (types.Object, error) {
+	if depth > maxActionChain {
+		return nil, errors.New("pdfcpu: action chain too long")
+	}
+
+	d, err := v.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return o, err
+	}
+
+	if next, found := d.Find("Next"); found {
+		o1, err := v.ctx.Dereference(next)
+		if err != nil {
+			return nil, err
+		}
+		if a, ok := o1.(types.Array); ok {
+			a1 := types.Array{}
+			for _, o2 := range a {
+				o3, err := v.action(o2, js, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				if o3 != nil {
+					a1 = append(a1, o3)
+				}
+			}
+			if len(a1) == 0 {
+				d.Delete("Next")
+			} else {
+				d["Next"] = a1
+			}
+		} else {
+			o2, err := v.action(next, js, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if o2 == nil {
+				d.Delete("Next")
+			} else {
+				d["Next"] = o2
+			}
+		}
+	}
+
+	s := d.NameEntry("S")
+	if s == nil {
+		return o, nil
+	}
+
+	if _, found := d.Find("JS"); found && (*s == "JavaScript" || *s == "Rendition") {
+		if js.Script, err = v.script(d["JS"]); err != nil {
+			return nil, err
+		}
+
+		if v.list {
+			v.jj = append(v.jj, js)
+			return o, nil
+		}
+
+		if v.f != nil {
+			if s1 := v.f(js); s1 != nil {
+				s2, err := types.EscapedUTF16String(*s1)
+				if err != nil {
+					return nil, err
+				}
+				d["JS"] = types.StringLiteral(*s2)
+				v.n++
+				return o, nil
+			}
+		}
+
+		if *s == "Rendition" && v.remove["JavaScript"] {
+			// Keep the rendition but drop its script.
+			d.Delete("JS")
+			v.n++
+			v.removed = append(v.removed, fmt.Sprintf("JavaScript of Rendition action: %s", js.location()))
+			return o, nil
+		}
+	}
+
+	if !v.remove[*s] {
+		return o, nil
+	}
+
+	v.n++
+	v.removed = append(v.removed, fmt.Sprintf("%s action: %s", *s, js.location()))
+
+	return v.chain(d["Next"])
+}
+
+// additionalActions visits the actions of an additional-actions dictionary.
+func (v *actionVisitor) additionalActions(d types.Dict, js JavaScript) error {
+	o, found := d.Find("AA")
+	if !found {
+		return nil
+	}
+
+	aa, err := v.ctx.DereferenceDict(o)
+	if err != nil || aa == nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(aa))
+	for k := range aa {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		js.Trigger = "AA/" + k
+		o, err := v.action(aa[k], js, 0)
+		if err != nil {
+			return err
+		}
+		if o == nil {
+			delete(aa, k)
+		} else {
+			aa[k] = o
+		}
+	}
+
+	if len(aa) == 0 {
+		d.Delete("AA")
+	}
+
+	return nil
+}
+
+// actions visits the action and the additional actions of d.
+func (v *actionVisitor) actions(d types.Dict, js JavaScript) error {
+	if o, found := d.Find("A"); found {
+		js.Trigger = "A"
+		o, err := v.action(o, js, 0)
+		if err != nil {
+			return err
+		}
+		if o == nil {
+			d.Delete("A")
+		} else {
+			d["A"] = o
+		}
+	}
+
+	return v.additionalActions(d, js)
+}
+
+func (v *actionVisitor) documentNameTree() error {
+	xRefTable := v.ctx.XRefTable
+
+	if err := xRefTable.LocateNameTree("JavaScript", false); err != nil {
+		return err
+	}
+
+	root := xRefTable.Names["JavaScript"]
+	if root == nil {
+		return nil
+	}
+
+	var removed []string
+
+	handler := func(xRefTable *model.XRefTable, k string, o *types.Object) error {
+		js := JavaScript{Scope: JSDocument, Name: k, Trigger: "Names"}
+		o1, err := v.action(*o, js, 0)
+		if err != nil {
+			return err
+		}
+		if o1 == nil {
+			removed = append(removed, k)
+			return nil
+		}
+		*o = o1
+		return nil
+	}
+
+	if err := root.Process(xRefTable, handler); err != nil {
+		return err
+	}
+
+	for _, k := range removed {
+		empty, _, err := root.Remove(xRefTable, k)
+		if err != nil {
+			return err
+		}
+		if empty {
+			delete(xRefTable.Names, "JavaScript")
+			return xRefTable.RemoveNameTree("JavaScript")
+		}
+	}
+
+	return nil
+}
+
+func (v *actionVisitor) document() error {
+	if err := v.documentNameTree(); err != nil {
+		return err
+	}
+
+	rootDict, err := v.ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	if o, found := rootDict.Find("OpenAction"); found {
+		o1, err := v.ctx.Dereference(o)
+		if err != nil {
+			return err
+		}
+		// An open action may also be a destination.
+		if _, ok := o1.(types.Dict); ok {
+			o, err := v.action(o, JavaScript{Scope: JSDocument, Trigger: "OpenAction"}, 0)
+			if err != nil {
+				return err
+			}
+			if o == nil {
+				rootDict.Delete("OpenAction")
+			} else {
+				rootDict["OpenAction"] = o
+			}
+		}
+	}
+
+	return v.additionalActions(rootDict, JavaScript{Scope: JSDocument})
+}
+
+func (v *actionVisitor) pages() error {
+	for pageNr := 1; pageNr <= v.ctx.PageCount; pageNr++ {
+
+		d, _, _, err := v.ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+
+		if v.scope&JSPage > 0 {
+			if err := v.additionalActions(d, JavaScript{Scope: JSPage, PageNr: pageNr}); err != nil {
+				return err
+			}
+		}
+
+		if v.scope&JSAnnotation == 0 {
+			continue
+		}
+
+		a, err := v.ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return err
+		}
+
+		for _, o := range a {
+			d1, err := v.ctx.DereferenceDict(o)
+			if err != nil {
+				return err
+			}
+			if d1 == nil {
+				continue
+			}
+			if st := d1.NameEntry("Subtype"); st != nil && *st == "Widget" {
+				// Covered by field scope.
+				continue
+			}
+			js := JavaScript{Scope: JSAnnotation, PageNr: pageNr}
+			if ir, ok := o.(types.IndirectRef); ok {
+				js.ObjNr = ir.ObjectNumber.Value()
+			}
+			if err := v.actions(d1, js); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *actionVisitor) field(o types.Object, prefix string, depth int) error {
+	if depth > 32 {
+		return errors.New("pdfcpu: field hierarchy too deep")
+	}
+
+	d, err := v.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return err
+	}
+
+	name := prefix
+	if o1, found := d.Find("T"); found {
+		s, err := v.ctx.DereferenceText(o1)
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			name += "."
+		}
+		name += s
+	}
+
+	js := JavaScript{Scope: JSField, Name: name}
+	if ir, ok := o.(types.IndirectRef); ok {
+		js.ObjNr = ir.ObjectNumber.Value()
+	}
+	if err := v.actions(d, js); err != nil {
+		return err
+	}
+
+	kids, err := v.ctx.DereferenceArray(d["Kids"])
+	if err != nil {
+		return err
+	}
+
+	for _, o := range kids {
+		if err := v.field(o, name, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *actionVisitor) fields() error {
+	rootDict, err := v.ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	d, err := v.ctx.DereferenceDict(rootDict["AcroForm"])
+	if err != nil || d == nil {
+		return err
+	}
+
+	a, err := v.ctx.DereferenceArray(d["Fields"])
+	if err != nil {
+		return err
+	}
+
+	for _, o := range a {
+		if err := v.field(o, "", 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *actionVisitor) outlines() error {
+	rootDict, err := v.ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	d, err := v.ctx.DereferenceDict(rootDict["Outlines"])
+	if err != nil || d == nil {
+		return err
+	}
+
+	visited := map[int]bool{}
+
+	var walk func(o types.Object) error
+	walk = func(o types.Object) error {
+		for o != nil {
+			ir, ok := o.(types.IndirectRef)
+			if !ok {
+				return nil
+			}
+			objNr := ir.ObjectNumber.Value()
+			if visited[objNr] {
+				return nil
+			}
+			visited[objNr] = true
+
+			d, err := v.ctx.DereferenceDict(ir)
+			if err != nil || d == nil {
+				return err
+			}
+
+			js := JavaScript{Scope: JSOutline, ObjNr: objNr}
+			if o1, found := d.Find("Title"); found {
+				if js.Name, err = v.ctx.DereferenceText(o1); err != nil {
+					return err
+				}
+			}
+
+			if err := v.actions(d, js); err != nil {
+				return err
+			}
+
+			if err := walk(d["First"]); err != nil {
+				return err
+			}
+
+			o = d["Next"]
+		}
+		return nil
+	}
+
+	return walk(d["First"])
+}
+
+func removeJavaScriptRequirements(ctx *model.Context) error {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	a, err := ctx.DereferenceArray(rootDict["Requirements"])
+	if err != nil || a == nil {
+		return err
+	}
+
+	a1 := types.Array{}
+	for _, o := range a {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d != nil {
+			if s := d.NameEntry("S"); s != nil && *s == "EnableJavaScripts" {
+				continue
+			}
+		}
+		a1 = append(a1, o)
+	}
+
+	if len(a1) == 0 {
+		rootDict.Delete("Requirements")
+	} else {
+		rootDict["Requirements"] = a1
+	}
+
+	return nil
+}
+
+func (v *actionVisitor) visit() error {
+	if v.scope&JSDocument > 0 {
+		if err := v.document(); err != nil {
+			return err
+		}
+	}
+
+	if v.scope&(JSPage|JSAnnotation) > 0 {
+		if err := v.pages(); err != nil {
+			return err
+		}
+	}
+
+	if v.scope&JSField > 0 {
+		if err := v.fields(); err != nil {
+			return err
+		}
+	}
+
+	if v.scope&JSOutline > 0 {
+		if err := v.outlines(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListJavaScript returns all JavaScript actions of ctx.
+func ListJavaScript(ctx *model.Context) ([]JavaScript, error) {
+	v := &actionVisitor{ctx: ctx, scope: JSAll, list: true}
+	if err := v.visit(); err != nil {
+		return nil, err
+	}
+	return v.jj, nil
+}
+
+// RewriteJavaScript applies f to all JavaScript actions within scope.
+// Scripts for which f returns nil are removed from their action chains.
+// It returns the number of affected JavaScript actions.
+func RewriteJavaScript(ctx *model.Context, scope JSScope, f JSRewriter) (int, error) {
+	v := &actionVisitor{ctx: ctx, scope: scope, f: f, remove: map[string]bool{"JavaScript": true}}
+	if err := v.visit(); err != nil {
+		return 0, err
+	}
+
+	if f == nil && scope == JSAll {
+		// Nothing left that needs a JavaScript capable viewer.
+		if err := removeJavaScriptRequirements(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	return v.n, nil
+}
+
+// RemoveJavaScript removes all JavaScript actions within scope.
+// It returns the number of removed JavaScript actions.
+func RemoveJavaScript(ctx *model.Context, scope JSScope) (int, error) {
+	return RewriteJavaScript(ctx, scope, nil)
+}
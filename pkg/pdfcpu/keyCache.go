@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"sync"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// encKeyCacheSize limits the number of cached file encryption keys.
+const encKeyCacheSize = 1024
+
+// encKeyCache holds file encryption keys derived from passwords (see Configuration.CacheEncryptionKeys).
+// Only keys of successfully validated passwords are cached.
+var encKeyCache = struct {
+	sync.Mutex
+	m map[[32]byte][]byte
+}{m: map[[32]byte][]byte{}}
+
+// ClearEncryptionKeyCache removes all cached file encryption keys.
+func ClearEncryptionKeyCache() {
+	encKeyCache.Lock()
+	defer encKeyCache.Unlock()
+	for k, v := range encKeyCache.m {
+		clear(v)
+		delete(encKeyCache.m, k)
+	}
+}
+
+func writeLenPrefixed(h hash.Hash, bb []byte) {
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(bb))))
+	h.Write(bb)
+}
+
+// encKeyCacheKey identifies a key derivation by file ID, revision, password role, passwords
+// and the password related entries of the encryption dict.
+// Passwords are never stored in clear.
+func encKeyCacheKey(ctx *model.Context, owner bool) [32]byte {
+	e := ctx.E
+	h := sha256.New()
+	role := byte(0)
+	if owner {
+		role = 1
+	}
+	h.Write([]byte{byte(e.R), byte(e.V), role})
+	for _, bb := range [][]byte{e.ID, e.O, e.U, e.OE, e.UE, []byte(ctx.OwnerPW), []byte(ctx.UserPW)} {
+		writeLenPrefixed(h, bb)
+	}
+	var k [32]byte
+	copy(k[:], h.Sum(nil))
+	return k
+}
+
+func cachedEncKey(k [32]byte) ([]byte, bool) {
+	encKeyCache.Lock()
+	defer encKeyCache.Unlock()
+	key, ok := encKeyCache.m[k]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte(nil), key...), true
+}
+
+func cacheEncKey(k [32]byte, key []byte) {
+	encKeyCache.Lock()
+	defer encKeyCache.Unlock()
+	if len(encKeyCache.m) >= encKeyCacheSize {
+		// Evict an arbitrary entry.
+		for k1 := range encKeyCache.m {
+			delete(encKeyCache.m, k1)
+			break
+		}
+	}
+	encKeyCache.m[k] = append([]byte(nil), key...)
+}
+
+// validatePassword validates the owner or user password of ctx
+// and takes the file encryption key from the key cache if enabled.
+func validatePassword(ctx *model.Context, owner bool) (bool, error) {
+	validate := validateUserPassword
+	if owner {
+		validate = validateOwnerPassword
+	}
+
+	if ctx.Configuration == nil || !ctx.CacheEncryptionKeys {
+		return validate(ctx)
+	}
+
+	k := encKeyCacheKey(ctx, owner)
+	if key, ok := cachedEncKey(k); ok {
+		ctx.EncKey = key
+		ctx.Configuration.Count(model.MetricKeyCacheHits, 1)
+		return true, nil
+	}
+
+	ok, err := validate(ctx)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	cacheEncKey(k, ctx.EncKey)
+
+	return true, nil
+}
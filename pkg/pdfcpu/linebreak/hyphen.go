@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linebreak
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// PatternDir is the directory hyphenation patterns get loaded from lazily.
+// Pattern files follow the naming of the hyph-utf8 project:
+//
+//	hyph-<lang>.pat.txt ... patterns, one per line
+//	hyph-<lang>.hyp.txt ... optional exceptions like hy-phen-ation
+//
+// TeX pattern files hyph-<lang>.tex using \patterns{} and \hyphenation{} are supported as well.
+var PatternDir string
+
+var (
+	hyphenators     = map[string]*Hyphenator{}
+	hyphenatorsLock sync.Mutex
+)
+
+// Hyphenator hyphenates words using Liang's algorithm as known from TeX.
+type Hyphenator struct {
+	LeftMin, RightMin int              // Minimum number of characters before and after a hyphen.
+	patterns          map[string][]int // Inter letter values by pattern letters.
+	exceptions        map[string][]int // Hyphen positions by word.
+	maxLen            int              // Length of the longest pattern in runes.
+}
+
+// NewHyphenator returns a hyphenator for patterns and optional exceptions.
+func NewHyphenator(patterns, exceptions io.Reader) (*Hyphenator, error) {
+	h := &Hyphenator{
+		LeftMin:    2,
+		RightMin:   3,
+		patterns:   map[string][]int{},
+		exceptions: map[string][]int{},
+	}
+
+	if err := h.parse(patterns, false); err != nil {
+		return nil, err
+	}
+
+	if exceptions != nil {
+		if err := h.parse(exceptions, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(h.patterns) == 0 && len(h.exceptions) == 0 {
+		return nil, errors.New("pdfcpu: no hyphenation patterns found")
+	}
+
+	return h, nil
+}
+
+// parse reads whitespace separated patterns or exceptions.
+// Comments, TeX commands and braces are skipped, within a TeX file \hyphenation{} switches to exceptions.
+func (h *Hyphenator) parse(r io.Reader, exceptions bool) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.IndexRune(line, '%'); i >= 0 {
+			line = line[:i]
+		}
+		for _, s := range strings.Fields(line) {
+			if strings.HasPrefix(s, `\`) {
+				if strings.HasPrefix(s, `\hyphenation`) {
+					exceptions = true
+				}
+				if strings.HasPrefix(s, `\patterns`) {
+					exceptions = false
+				}
+				// Keep anything following the opening brace.
+				i := strings.IndexRune(s, '{')
+				if i < 0 {
+					continue
+				}
+				s = s[i+1:]
+			}
+			s = strings.Trim(s, "{}")
+			if s == "" {
+				continue
+			}
+			if exceptions {
+				h.addException(s)
+			} else {
+				h.addPattern(s)
+			}
+		}
+	}
+	return sc.Err()
+}
+
+func (h *Hyphenator) addPattern(s string) {
+	var (
+		letters []rune
+		values  = []int{0}
+	)
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			values[len(values)-1] = int(r - '0')
+			continue
+		}
+		letters = append(letters, unicode.ToLower(r))
+		values = append(values, 0)
+	}
+	if len(letters) == 0 {
+		return
+	}
+	h.patterns[string(letters)] = values
+	if len(letters) > h.maxLen {
+		h.maxLen = len(letters)
+	}
+}
+
+func (h *Hyphenator) addException(s string) {
+	var (
+		letters []rune
+		pos     []int
+	)
+	for _, r := range s {
+		if r == '-' {
+			pos = append(pos, len(letters))
+			continue
+		}
+		letters = append(letters, unicode.ToLower(r))
+	}
+	h.exceptions[string(letters)] = pos
+}
+
+// Hyphenate returns the rune positions within word where it may be hyphenated.
+func (h *Hyphenator) Hyphenate(word string) []int {
+	rr := []rune(strings.ToLower(word))
+	n := len(rr)
+
+	if n < h.LeftMin+h.RightMin {
+		return nil
+	}
+
+	if pos, ok := h.exceptions[string(rr)]; ok {
+		return pos
+	}
+
+	// Apply all matching patterns to the word including its boundaries.
+	w := append(append([]rune{'.'}, rr...), '.')
+	values := make([]int, len(w)+1)
+	for i := range w {
+		for j := i + 1; j <= len(w) && j-i <= h.maxLen; j++ {
+			pv, ok := h.patterns[string(w[i:j])]
+			if !ok {
+				continue
+			}
+			for k, v := range pv {
+				if v > values[i+k] {
+					values[i+k] = v
+				}
+			}
+		}
+	}
+
+	// values[i+1] is the value between rr[i-1] and rr[i].
+	var pos []int
+	for i := h.LeftMin; i <= n-h.RightMin; i++ {
+		if values[i+1]%2 == 1 {
+			pos = append(pos, i)
+		}
+	}
+
+	return pos
+}
+
+// RegisterHyphenator makes h available for lang.
+func RegisterHyphenator(lang string, h *Hyphenator) {
+	hyphenatorsLock.Lock()
+	defer hyphenatorsLock.Unlock()
+	hyphenators[strings.ToLower(lang)] = h
+}
+
+func loadHyphenator(lang string) (*Hyphenator, error) {
+	var patterns, exceptions io.Reader
+
+	for _, fn := range []string{"hyph-" + lang + ".pat.txt", "hyph-" + lang + ".tex"} {
+		f, err := os.Open(filepath.Join(PatternDir, fn))
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+		patterns = f
+		break
+	}
+	if patterns == nil {
+		return nil, nil
+	}
+
+	if f, err := os.Open(filepath.Join(PatternDir, "hyph-"+lang+".hyp.txt")); err == nil {
+		defer f.Close()
+		exceptions = f
+	}
+
+	return NewHyphenator(patterns, exceptions)
+}
+
+// HyphenatorFor returns the hyphenator for lang eg. "en-us" or nil if there are no patterns available.
+// Unless registered patterns get loaded from PatternDir.
+func HyphenatorFor(lang string) (*Hyphenator, error) {
+	lang = strings.ToLower(lang)
+	if lang == "" {
+		return nil, nil
+	}
+
+	hyphenatorsLock.Lock()
+	defer hyphenatorsLock.Unlock()
+
+	if h, ok := hyphenators[lang]; ok {
+		return h, nil
+	}
+
+	if PatternDir == "" {
+		return nil, nil
+	}
+
+	h, err := loadHyphenator(lang)
+	if err != nil {
+		return nil, errors.Wrapf(err, "pdfcpu: hyphenation patterns for %s", lang)
+	}
+
+	// Also remember missing patterns.
+	hyphenators[lang] = h
+
+	return h, nil
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package linebreak provides line break opportunities following the Unicode line breaking algorithm (UAX #14),
+// pattern based hyphenation and line wrapping for text layout.
+package linebreak
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// class is a Unicode line breaking class.
+type class int
+
+// The line breaking classes relevant for the rules implemented.
+// Classes resolved by rule LB1 (AI, SA, SG, XX, CJ, CB) are mapped to AL or NS.
+const (
+	clAL  class = iota // Alphabetic
+	clBA               // Break after
+	clBB               // Break before
+	clB2               // Break opportunity before and after
+	clBK               // Mandatory break
+	clCL               // Close punctuation
+	clCM               // Combining mark
+	clCP               // Close parenthesis
+	clCR               // Carriage return
+	clEX               // Exclamation/Interrogation
+	clGL               // Non-breaking glue
+	clHY               // Hyphen
+	clID               // Ideographic
+	clIN               // Inseparable
+	clIS               // Infix numeric separator
+	clLF               // Line feed
+	clNL               // Next line
+	clNS               // Nonstarter
+	clNU               // Numeric
+	clOP               // Open punctuation
+	clPO               // Postfix numeric
+	clPR               // Prefix numeric
+	clQU               // Quotation
+	clSP               // Space
+	clSY               // Symbols allowing break after
+	clWJ               // Word joiner
+	clZW               // Zero width space
+	clZWJ              // Zero width joiner
+)
+
+var runeClasses = map[rune]class{
+	'\t': clBA, '\n': clLF, '\v': clBK, '\f': clBK, '\r': clCR, ' ': clSP,
+	'!': clEX, '"': clQU, '$': clPR, '%': clPO, '\'': clQU, '(': clOP, ')': clCP, '+': clPR,
+	',': clIS, '-': clHY, '.': clIS, '/': clSY, ':': clIS, ';': clIS, '?': clEX,
+	'[': clOP, '\\': clPR, ']': clCP, '{': clOP, '|': clBA, '}': clCL,
+	0x0085: clNL, 0x00A0: clGL, 0x00A1: clOP, 0x00A2: clPO, 0x00A3: clPR, 0x00A5: clPR,
+	0x00AB: clQU, 0x00AD: clBA, 0x00B0: clPO, 0x00B1: clPR, 0x00B4: clBB, 0x00BB: clQU, 0x00BF: clOP,
+	0x02C8: clBB, 0x02CC: clBB, 0x02DF: clBB, 0x037E: clIS, 0x0589: clIS, 0x05BE: clBA,
+	0x060C: clIS, 0x060D: clIS, 0x0F0B: clBA, 0x0F0C: clGL, 0x1680: clBA, 0x180E: clGL, 0x1FFD: clBB,
+	0x2007: clGL, 0x2010: clBA, 0x2011: clGL, 0x2012: clBA, 0x2013: clBA, 0x2014: clB2,
+	0x2018: clQU, 0x2019: clQU, 0x201A: clOP, 0x201B: clQU, 0x201C: clQU, 0x201D: clQU, 0x201E: clOP, 0x201F: clQU,
+	0x2024: clIN, 0x2025: clIN, 0x2026: clIN, 0x2027: clBA, 0x2028: clBK, 0x2029: clBK,
+	0x202F: clGL, 0x2030: clPO, 0x2031: clPO, 0x2032: clPO, 0x2033: clPO, 0x2039: clQU, 0x203A: clQU,
+	0x203C: clNS, 0x203D: clNS, 0x2044: clIS, 0x2047: clNS, 0x2048: clNS, 0x2049: clNS,
+	0x205F: clBA, 0x2060: clWJ, 0x20AC: clPR, 0x2103: clPO, 0x2116: clPR, 0x2212: clPR,
+	0x2E3A: clB2, 0x2E3B: clB2,
+	0x3000: clBA, 0x3001: clCL, 0x3002: clCL, 0x3005: clNS, 0x301C: clNS, 0x303B: clNS,
+	0x309B: clNS, 0x309C: clNS, 0x309D: clNS, 0x309E: clNS, 0x30A0: clNS, 0x30FB: clNS,
+	0x30FC: clNS, 0x30FD: clNS, 0x30FE: clNS,
+	0xFE10: clIS, 0xFE13: clIS, 0xFE14: clIS, 0xFE50: clCL, 0xFE52: clCL,
+	0xFEFF: clWJ, 0xFF01: clEX, 0xFF04: clPR, 0xFF05: clPO, 0xFF08: clOP, 0xFF09: clCP,
+	0xFF0C: clCL, 0xFF0E: clCL, 0xFF1A: clNS, 0xFF1B: clNS, 0xFF1F: clEX,
+	0xFF3B: clOP, 0xFF3D: clCP, 0xFF5B: clOP, 0xFF5D: clCL, 0xFF5F: clOP, 0xFF60: clCL,
+	0xFF61: clCL, 0xFF62: clOP, 0xFF63: clCL, 0xFF64: clCL, 0xFF65: clNS,
+	0xFFE0: clPO, 0xFFE1: clPR, 0xFFE5: clPR, 0xFFE6: clPR,
+	0x200B: clZW, 0x200D: clZWJ,
+}
+
+// Small kana (CJ) get resolved to NS.
+var smallKana = []rune("ぁぃぅぇぉっゃゅょゎゕゖァィゥェォッャュョヮヵヶｧｨｩｪｫｬｭｮｯ")
+
+func isSmallKana(r rune) bool {
+	for _, r1 := range smallKana {
+		if r == r1 {
+			return true
+		}
+	}
+	return r >= 0x31F0 && r <= 0x31FF
+}
+
+func isIdeographic(r rune) bool {
+	switch {
+	case r >= 0x2E80 && r <= 0x2FFF,
+		r >= 0x3040 && r <= 0x30FF,
+		r >= 0x3130 && r <= 0x318F,
+		r >= 0x3190 && r <= 0x9FFF,
+		r >= 0xA000 && r <= 0xA4CF,
+		r >= 0xAC00 && r <= 0xD7AF,
+		r >= 0xF900 && r <= 0xFAFF,
+		r >= 0xFF01 && r <= 0xFF60,
+		r >= 0x1F000 && r <= 0x1FAFF,
+		r >= 0x20000 && r <= 0x3FFFD:
+		return true
+	}
+	return false
+}
+
+func classOf(r rune) class {
+	if c, ok := runeClasses[r]; ok {
+		return c
+	}
+
+	switch {
+	case isSmallKana(r):
+		return clNS
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return clCM
+	case unicode.IsControl(r):
+		return clCM
+	case unicode.Is(unicode.Nd, r):
+		return clNU
+	case isIdeographic(r):
+		return clID
+	case unicode.Is(unicode.Zs, r):
+		return clBA
+	case unicode.Is(unicode.Ps, r):
+		return clOP
+	case unicode.Is(unicode.Pe, r):
+		return clCL
+	case unicode.In(r, unicode.Pi, unicode.Pf):
+		return clQU
+	case unicode.Is(unicode.Sc, r):
+		return clPR
+	}
+
+	return clAL
+}
+
+func in(c class, cc ...class) bool {
+	for _, c1 := range cc {
+		if c == c1 {
+			return true
+		}
+	}
+	return false
+}
+
+// classes returns the resolved line breaking classes for rr
+// and flags for combining marks attached to their predecessor (LB9, LB10).
+func classes(rr []rune) ([]class, []bool) {
+	cc := make([]class, len(rr))
+	attached := make([]bool, len(rr))
+	for i, r := range rr {
+		c := classOf(r)
+		if c == clCM || c == clZWJ {
+			if i > 0 && !in(cc[i-1], clBK, clCR, clLF, clNL, clSP, clZW) {
+				cc[i] = cc[i-1]
+				attached[i] = true
+				continue
+			}
+			c = clAL
+		}
+		cc[i] = c
+	}
+	return cc, attached
+}
+
+// breakBefore returns true if a line may be broken between cc[i-1] and cc[i].
+func breakBefore(rr []rune, cc []class, attached []bool, i int) bool {
+	a, b := cc[i-1], cc[i]
+
+	// LB4, LB5
+	if a == clCR && b == clLF {
+		return false
+	}
+	if in(a, clBK, clCR, clLF, clNL) {
+		return true
+	}
+
+	// LB6, LB7
+	if in(b, clBK, clCR, clLF, clNL, clSP, clZW) {
+		return false
+	}
+
+	// Skip spaces for the rules LB8, LB14 - LB17.
+	j := i - 1
+	for j > 0 && cc[j] == clSP {
+		j--
+	}
+	p := cc[j]
+
+	// LB8
+	if p == clZW {
+		return true
+	}
+
+	// LB8a, LB9
+	if attached[i] || classOf(rr[i-1]) == clZWJ {
+		return false
+	}
+
+	// LB11, LB12, LB12a
+	if a == clWJ || b == clWJ || a == clGL {
+		return false
+	}
+	if b == clGL && !in(a, clSP, clBA, clHY) {
+		return false
+	}
+
+	// LB13
+	if in(b, clCL, clCP, clEX, clIS, clSY) {
+		return false
+	}
+
+	// LB14 - LB17
+	if p == clOP ||
+		p == clQU && b == clOP ||
+		in(p, clCL, clCP) && b == clNS ||
+		p == clB2 && b == clB2 {
+		return false
+	}
+
+	// LB18
+	if a == clSP {
+		return true
+	}
+
+	// LB19, LB21, LB22
+	if a == clQU || b == clQU || in(b, clBA, clHY, clNS, clIN) || a == clBB {
+		return false
+	}
+
+	// LB23, LB23a, LB24
+	if a == clAL && b == clNU || a == clNU && b == clAL ||
+		a == clPR && b == clID || a == clID && b == clPO ||
+		in(a, clPR, clPO) && b == clAL || a == clAL && in(b, clPR, clPO) {
+		return false
+	}
+
+	// LB25
+	if in(a, clCL, clCP, clNU) && in(b, clPO, clPR) ||
+		in(a, clPO, clPR) && in(b, clOP, clNU) ||
+		in(a, clHY, clIS, clNU, clSY) && b == clNU {
+		return false
+	}
+
+	// LB28, LB29
+	if a == clAL && b == clAL || a == clIS && b == clAL {
+		return false
+	}
+
+	// LB30 for non East Asian parentheses.
+	if in(a, clAL, clNU) && b == clOP && rr[i] < 0x2E80 || a == clCP && in(b, clAL, clNU) {
+		return false
+	}
+
+	// LB31
+	return true
+}
+
+// Breaks returns the byte offsets into s of all line break opportunities, excluding the start and end of s.
+// A line may be broken right before each of these offsets.
+func Breaks(s string) []int {
+	rr := make([]rune, 0, utf8.RuneCountInString(s))
+	offsets := make([]int, 0, cap(rr))
+	for i, r := range s {
+		rr = append(rr, r)
+		offsets = append(offsets, i)
+	}
+
+	cc, attached := classes(rr)
+
+	var bb []int
+	for i := 1; i < len(rr); i++ {
+		if breakBefore(rr, cc, attached, i) {
+			bb = append(bb, offsets[i])
+		}
+	}
+
+	return bb
+}
+
+// Segments splits s into the shortest pieces which may not be broken across lines.
+// Trailing spaces stay with the preceding piece.
+func Segments(s string) []string {
+	var ss []string
+	i := 0
+	for _, j := range Breaks(s) {
+		ss = append(ss, s[i:j])
+		i = j
+	}
+	if i < len(s) {
+		ss = append(ss, s[i:])
+	}
+	return ss
+}
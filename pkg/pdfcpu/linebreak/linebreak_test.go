@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linebreak
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSegments(t *testing.T) {
+	testcases := []struct {
+		Text string
+		Want []string
+	}{
+		{"", nil},
+		{"Hello world", []string{"Hello ", "world"}},
+		{"Hello   world", []string{"Hello   ", "world"}},
+		{"self-aware", []string{"self-", "aware"}},
+		{"(foo bar), baz!", []string{"(foo ", "bar), ", "baz!"}},
+		{"no\u00A0break here", []string{"no\u00A0break ", "here"}},
+		{"costs $100.00 today", []string{"costs ", "$100.00 ", "today"}},
+		{"-5 degrees", []string{"-5 ", "degrees"}},
+		{"日本語。テスト", []string{"日", "本", "語。", "テ", "ス", "ト"}},
+		{"ちょっと", []string{"ちょっ", "と"}},
+		{"「引用」です", []string{"「引", "用」", "で", "す"}},
+		{"wait… what", []string{"wait… ", "what"}},
+		{"zero\u200Bwidth", []string{"zero\u200B", "width"}},
+		{"hy\u00ADphen", []string{"hy\u00AD", "phen"}},
+	}
+
+	for _, tc := range testcases {
+		if got := Segments(tc.Text); !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("segments for %q: want %q, got %q", tc.Text, tc.Want, got)
+		}
+	}
+}
+
+func TestHyphenate(t *testing.T) {
+	// The patterns of Liang's thesis.
+	patterns := "hy3ph he2n hena4 hen5at 1na n2at 1tio 2io o2n"
+	exceptions := "ta-ble"
+
+	h, err := NewHyphenator(strings.NewReader(patterns), strings.NewReader(exceptions))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for word, want := range map[string][]int{
+		"hyphenation": {2, 6},
+		"Hyphenation": {2, 6},
+		"table":       {2},
+		"hyph":        nil,
+	} {
+		if got := h.Hyphenate(word); !reflect.DeepEqual(got, want) {
+			t.Errorf("hyphenate %s: want %v, got %v", word, want, got)
+		}
+	}
+
+	// TeX pattern file syntax.
+	tex := `% comment
+\patterns{
+hy3ph he2n hena4 hen5at 1na n2at 1tio 2io o2n
+}
+\hyphenation{ta-ble}`
+	if h, err = NewHyphenator(strings.NewReader(tex), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.Hyphenate("table"); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("hyphenate table: want [2], got %v", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	// One unit per rune.
+	textWidth := func(s string) float64 { return float64(utf8.RuneCountInString(s)) }
+
+	h, err := NewHyphenator(strings.NewReader("hy3ph he2n hena4 hen5at 1na n2at 1tio 2io o2n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		Text  string
+		Width float64
+		H     *Hyphenator
+		Want  []string
+	}{
+		{"", 10, nil, []string{""}},
+		{"the quick brown fox", 10, nil, []string{"the quick", "brown fox"}},
+		{"a well-known fact", 8, nil, []string{"a well-", "known", "fact"}},
+		{"about hyphenation rules", 12, nil, []string{"about", "hyphenation", "rules"}},
+		{"about hyphenation rules", 13, h, []string{"about hyphen-", "ation rules"}},
+		{"about hyphenation rules", 9, h, []string{"about hy-", "phenation", "rules"}},
+		{"soft hy\u00ADphen here", 8, nil, []string{"soft hy-", "phen", "here"}},
+		{"日本語のテキスト", 3, nil, []string{"日本語", "のテキ", "スト"}},
+	}
+
+	for _, tc := range testcases {
+		if got := Wrap(tc.Text, tc.Width, textWidth, tc.H); !reflect.DeepEqual(got, tc.Want) {
+			t.Errorf("wrap %q at %.0f: want %q, got %q", tc.Text, tc.Width, tc.Want, got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linebreak
+
+import (
+	"strings"
+	"unicode"
+)
+
+const softHyphen = "\u00AD"
+
+// finish returns the visible form of a line: trailing spaces removed,
+// soft hyphens dropped except for a trailing one which turns into a hyphen.
+func finish(s string) string {
+	s = strings.TrimRightFunc(s, unicode.IsSpace)
+	hyphen := strings.HasSuffix(s, softHyphen)
+	s = strings.ReplaceAll(s, softHyphen, "")
+	if hyphen {
+		s += "-"
+	}
+	return s
+}
+
+// hyphenate splits seg at the rightmost hyphenation point for which head plus a hyphen satisfies fits.
+func hyphenate(seg string, h *Hyphenator, fits func(head string) bool) (string, string, bool) {
+	// Locate the word within seg, eg. skip leading quotes and trailing punctuation.
+	i := strings.IndexFunc(seg, unicode.IsLetter)
+	if i < 0 {
+		return "", "", false
+	}
+	j := strings.IndexFunc(seg[i:], func(r rune) bool { return !unicode.IsLetter(r) })
+	if j < 0 {
+		j = len(seg)
+	} else {
+		j += i
+	}
+
+	word := []rune(seg[i:j])
+	pos := h.Hyphenate(string(word))
+
+	for k := len(pos) - 1; k >= 0; k-- {
+		head := seg[:i] + string(word[:pos[k]])
+		if fits(head + "-") {
+			return head + "-", string(word[pos[k]:]) + seg[j:], true
+		}
+	}
+
+	return "", "", false
+}
+
+// Wrap breaks the paragraph s into lines not exceeding width as measured by textWidth
+// using Unicode line break opportunities and hyphenation if h is not nil.
+// Words not fitting into a line on their own overflow.
+func Wrap(s string, width float64, textWidth func(string) float64, h *Hyphenator) []string {
+	fits := func(s string) bool {
+		return textWidth(finish(s)) <= width
+	}
+
+	var (
+		lines []string
+		line  string
+	)
+
+	for _, seg := range Segments(s) {
+		for {
+			if fits(line + seg) {
+				line += seg
+				break
+			}
+
+			if h != nil {
+				if head, tail, ok := hyphenate(seg, h, func(head string) bool { return fits(line + head) }); ok {
+					lines = append(lines, finish(line+head))
+					line, seg = "", tail
+					continue
+				}
+			}
+
+			if line == "" {
+				// seg overflows.
+				line = seg
+				break
+			}
+
+			lines = append(lines, finish(line))
+			line = ""
+		}
+	}
+
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, finish(line))
+	}
+
+	return lines
+}
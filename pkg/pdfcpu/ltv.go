@@ -0,0 +1,363 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/sign"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+// dssCategories are the DSS arrays (12.8.4.3) paired with the corresponding VRI entries.
+var dssCategories = []struct{ dss, vri string }{
+	{"Certs", "Cert"},
+	{"OCSPs", "OCSP"},
+	{"CRLs", "CRL"},
+}
+
+// dss collects the content of a Document Security Store avoiding duplicate streams.
+type dss struct {
+	ctx    *model.Context
+	arrays map[string]types.Array
+	seen   map[[32]byte]types.IndirectRef
+}
+
+func newDSS(ctx *model.Context, d types.Dict) (*dss, error) {
+	s := &dss{ctx: ctx, arrays: map[string]types.Array{}, seen: map[[32]byte]types.IndirectRef{}}
+
+	for _, c := range dssCategories {
+		arr, err := ctx.DereferenceArray(d[c.dss])
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range arr {
+			ir, ok := o.(types.IndirectRef)
+			if !ok {
+				return nil, errors.Errorf("pdfcpu: DSS: corrupt %s entry", c.dss)
+			}
+			sd, _, err := ctx.DereferenceStreamDict(ir)
+			if err != nil {
+				return nil, err
+			}
+			if sd == nil {
+				return nil, errors.Errorf("pdfcpu: DSS: corrupt %s entry", c.dss)
+			}
+			if err := sd.Decode(); err != nil {
+				return nil, err
+			}
+			s.seen[sha256.Sum256(sd.Content)] = ir
+		}
+		s.arrays[c.dss] = arr
+	}
+
+	return s, nil
+}
+
+// add returns an indirect reference to a stream containing bb and registers it with the DSS array key.
+func (s *dss) add(key string, bb []byte) (*types.IndirectRef, error) {
+	h := sha256.Sum256(bb)
+	if ir, ok := s.seen[h]; ok {
+		return &ir, nil
+	}
+
+	sd, err := s.ctx.NewStreamDictForBuf(bb)
+	if err != nil {
+		return nil, err
+	}
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	ir, err := newIndirectObjectForIncrement(s.ctx, *sd)
+	if err != nil {
+		return nil, err
+	}
+
+	s.seen[h] = *ir
+	s.arrays[key] = append(s.arrays[key], *ir)
+
+	return ir, nil
+}
+
+// signatureContents returns the signature value of sigDict.
+func signatureContents(sigDict types.Dict) ([]byte, error) {
+	var (
+		bb  []byte
+		err error
+	)
+
+	switch o := sigDict["Contents"].(type) {
+	case types.HexLiteral:
+		bb, err = o.Bytes()
+	case types.StringLiteral:
+		bb, err = types.Unescape(o.Value())
+	default:
+		return nil, errors.New("pdfcpu: DSS: missing signature contents")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if sf := sigDict.NameEntry("SubFilter"); sf != nil && *sf == "ETSI.RFC3161" {
+		// Time-stamp tokens are identified by their DER encoding excluding any padding.
+		var raw asn1.RawValue
+		if _, err := asn1.Unmarshal(bb, &raw); err == nil {
+			bb = raw.FullBytes
+		}
+	}
+
+	return bb, nil
+}
+
+// vriKey returns the VRI key for a signature: the uppercase hex encoded SHA-1 of its contents.
+func vriKey(contents []byte) string {
+	h := sha1.Sum(contents)
+	return strings.ToUpper(hex.EncodeToString(h[:]))
+}
+
+func containsIndRef(arr types.Array, ir types.IndirectRef) bool {
+	for _, o := range arr {
+		if ir1, ok := o.(types.IndirectRef); ok && ir1.ObjectNumber == ir.ObjectNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// validationInfo gathers revocation information for the certificate chain certs.
+func validationInfo(s *dss, certs []*x509.Certificate, fetcher sign.RevocationFetcher, vri map[string]types.Array) error {
+	for _, cert := range certs {
+		ir, err := s.add("Certs", cert.Raw)
+		if err != nil {
+			return err
+		}
+		vri["Cert"] = append(vri["Cert"], *ir)
+	}
+
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawSubject, cert.RawIssuer) {
+			// Trust anchors need no revocation information.
+			continue
+		}
+
+		issuer := sign.CertificateIssuer(cert, certs)
+		if issuer == nil {
+			return errors.Errorf("pdfcpu: DSS: missing issuer certificate for: %s", cert.Subject)
+		}
+
+		bb, err := fetcher.OCSP(cert, issuer)
+		if err == nil {
+			ir, err := s.add("OCSPs", bb)
+			if err != nil {
+				return err
+			}
+			vri["OCSP"] = append(vri["OCSP"], *ir)
+
+			// Include a delegated OCSP responder certificate.
+			if resp, err := ocsp.ParseResponse(bb, issuer); err == nil && resp.Certificate != nil {
+				ir, err := s.add("Certs", resp.Certificate.Raw)
+				if err != nil {
+					return err
+				}
+				if !containsIndRef(vri["Cert"], *ir) {
+					vri["Cert"] = append(vri["Cert"], *ir)
+				}
+			}
+			continue
+		}
+
+		crls, err1 := fetcher.CRLs(cert)
+		if err1 != nil {
+			return errors.Errorf("pdfcpu: DSS: no revocation information for: %s (OCSP: %v, CRL: %v)", cert.Subject, err, err1)
+		}
+		for _, crl := range crls {
+			ir, err := s.add("CRLs", crl)
+			if err != nil {
+				return err
+			}
+			vri["CRL"] = append(vri["CRL"], *ir)
+		}
+	}
+
+	return nil
+}
+
+// dssDict returns the DSS of ctx marking it for incremental writing.
+func dssDict(ctx *model.Context, rootDict types.Dict) (types.Dict, error) {
+	o, found := rootDict.Find("DSS")
+	if !found || o == nil {
+		d := types.Dict{"Type": types.Name("DSS")}
+		ir, err := newIndirectObjectForIncrement(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		rootDict["DSS"] = *ir
+		ctx.Write.IncrementWithObjNr(ctx.Root.ObjectNumber.Value())
+		return d, nil
+	}
+
+	d, err := ctx.DereferenceDict(o)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.New("pdfcpu: DSS: corrupt dict")
+	}
+	if !markForIncrement(ctx, o) {
+		ctx.Write.IncrementWithObjNr(ctx.Root.ObjectNumber.Value())
+	}
+
+	return d, nil
+}
+
+func signatureDicts(ctx *model.Context) ([]types.Dict, error) {
+	var objNrs []int
+	for _, m := range ctx.Signatures {
+		for objNr, sig := range m {
+			if sig.Signed {
+				objNrs = append(objNrs, objNr)
+			}
+		}
+	}
+	sort.Ints(objNrs)
+
+	var sigDicts []types.Dict
+
+	for _, objNr := range objNrs {
+		d, err := ctx.DereferenceDict(*types.NewIndirectRef(objNr, 0))
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		sigDict, err := ctx.DereferenceDict(d["V"])
+		if err != nil {
+			return nil, err
+		}
+		if sigDict != nil {
+			sigDicts = append(sigDicts, sigDict)
+		}
+	}
+
+	return sigDicts, nil
+}
+
+// AddValidationInfo embeds the certificates of all signatures of ctx and revocation information
+// obtained from fetcher into the Document Security Store (PAdES-LTV).
+// Each signature gets a VRI entry listing its validation related information.
+// All touched objects are marked for writing as increment.
+func AddValidationInfo(ctx *model.Context, fetcher sign.RevocationFetcher) error {
+	if fetcher == nil {
+		return errors.New("pdfcpu: DSS: missing revocation fetcher")
+	}
+
+	if ctx.Encrypt != nil {
+		return errors.New("pdfcpu: DSS: encrypted files unsupported")
+	}
+
+	sigDicts, err := signatureDicts(ctx)
+	if err != nil {
+		return err
+	}
+	if len(sigDicts) == 0 {
+		return errors.New("pdfcpu: DSS: no signatures available")
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	ctx.Write.Increment = true
+	ctx.Write.Offset = ctx.Read.FileSize
+
+	d, err := dssDict(ctx, rootDict)
+	if err != nil {
+		return err
+	}
+
+	s, err := newDSS(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	vriDict := types.Dict{}
+	if o, found := d.Find("VRI"); found {
+		vd, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		for k, v := range vd {
+			vriDict[k] = v
+		}
+	}
+
+	tu := types.StringLiteral(types.DateString(time.Now()))
+
+	for _, sigDict := range sigDicts {
+		contents, err := signatureContents(sigDict)
+		if err != nil {
+			return err
+		}
+
+		certs, err := sign.SignatureCertificates(sigDict)
+		if err != nil {
+			return err
+		}
+
+		vri := map[string]types.Array{}
+		if err := validationInfo(s, certs, fetcher, vri); err != nil {
+			return err
+		}
+
+		e := types.Dict{"TU": tu}
+		for _, c := range dssCategories {
+			if arr := vri[c.vri]; len(arr) > 0 {
+				e[c.vri] = arr
+			}
+		}
+		vriDict[vriKey(contents)] = e
+	}
+
+	for _, c := range dssCategories {
+		if arr := s.arrays[c.dss]; len(arr) > 0 {
+			d[c.dss] = arr
+		}
+	}
+	d["VRI"] = vriDict
+
+	// The DSS has been introduced with PDF 2.0 and before as PAdES extension of PDF 1.7.
+	if ctx.XRefTable.Version() < model.V17 {
+		rootDict["Version"] = types.Name(model.V17.String())
+		ctx.Write.IncrementWithObjNr(ctx.Root.ObjectNumber.Value())
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Measurement represents the real world dimensions of a Line, PolyLine or Polygon annotation carrying a measure dictionary.
+type Measurement struct {
+	ObjNr   int            `json:"objNr,omitempty"`
+	ID      string         `json:"id,omitempty"`
+	Subtype string         `json:"subtype"`
+	Intent  string         `json:"intent,omitempty"`
+	Measure *model.Measure `json:"measure"`
+	Length  float64        `json:"length"`         // in units of Measure.D[0]
+	Area    float64        `json:"area,omitempty"` // in units of Measure.A[0], Polygon only
+}
+
+// FormattedLength returns the length formatted according to the distance number format array.
+func (m Measurement) FormattedLength() string {
+	return model.FormatMeasurement(m.Length, m.Measure.D)
+}
+
+// FormattedArea returns the area formatted according to the area number format array.
+func (m Measurement) FormattedArea() string {
+	return model.FormatMeasurement(m.Area, m.Measure.A)
+}
+
+func annotationPoints(xRefTable *model.XRefTable, d types.Dict, subtype string) ([]types.Point, error) {
+	key := "Vertices"
+	if subtype == "Line" {
+		key = "L"
+	}
+
+	ff, err := numbers(xRefTable, d[key])
+	if err != nil {
+		return nil, err
+	}
+
+	if ff == nil && subtype != "Line" {
+		// Path: array of arrays each supplying the operands of m, l or c.
+		a, err := xRefTable.DereferenceArray(d["Path"])
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range a {
+			ff1, err := numbers(xRefTable, o)
+			if err != nil {
+				return nil, err
+			}
+			if len(ff1) >= 2 {
+				// Use the end point of each segment.
+				ff = append(ff, ff1[len(ff1)-2:]...)
+			}
+		}
+	}
+
+	if len(ff) < 4 || len(ff)%2 != 0 {
+		return nil, errors.Errorf("pdfcpu: invalid %s annotation coordinates", subtype)
+	}
+
+	pp := make([]types.Point, len(ff)/2)
+	for i := range pp {
+		pp[i] = types.Point{X: ff[2*i], Y: ff[2*i+1]}
+	}
+
+	return pp, nil
+}
+
+// AnnotationMeasurement returns the real world dimensions of a Line, PolyLine or Polygon annotation
+// or nil if d does not carry a measure dictionary.
+func AnnotationMeasurement(xRefTable *model.XRefTable, d types.Dict) (*Measurement, error) {
+	subtype := d.NameEntry("Subtype")
+	if subtype == nil || !types.MemberOf(*subtype, []string{"Line", "PolyLine", "Polygon"}) {
+		return nil, nil
+	}
+
+	d1, err := xRefTable.DereferenceDict(d["Measure"])
+	if err != nil || d1 == nil {
+		return nil, err
+	}
+
+	if st := d1.NameEntry("Subtype"); st != nil && *st != "RL" {
+		// Geospatial measures are covered by GeoInfo.
+		return nil, nil
+	}
+
+	m, err := xRefTable.ParseMeasure(d1)
+	if err != nil {
+		return nil, err
+	}
+
+	pp, err := annotationPoints(xRefTable, d, *subtype)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &Measurement{Subtype: *subtype, Measure: m}
+
+	if s := d.NameEntry("IT"); s != nil {
+		ms.Intent = *s
+	}
+
+	if o, found := d.Find("NM"); found {
+		if ms.ID, err = xRefTable.DereferenceText(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if *subtype == "Polygon" {
+		if ms.Area, err = m.Area(pp); err != nil {
+			return nil, err
+		}
+		// Close the path for the perimeter.
+		pp = append(pp, pp[0])
+	}
+
+	if ms.Length, err = m.Length(pp); err != nil {
+		return nil, err
+	}
+
+	return ms, nil
+}
+
+// Measurements returns the real world dimensions of all measuring annotations of page pageNr.
+func Measurements(ctx *model.Context, pageNr int) ([]Measurement, error) {
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: Measurements: unknown page number: %d", pageNr)
+	}
+
+	a, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	var mm []Measurement
+
+	for _, o := range a {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d1 == nil {
+			continue
+		}
+		m, err := AnnotationMeasurement(ctx.XRefTable, d1)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			continue
+		}
+		if ir, ok := o.(types.IndirectRef); ok {
+			m.ObjNr = ir.ObjectNumber.Value()
+		}
+		mm = append(mm, *m)
+	}
+
+	return mm, nil
+}
@@ -0,0 +1,414 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// MediaAsset represents a media file embedded by a RichMedia or Screen annotation.
+type MediaAsset struct {
+	io.Reader          // media data
+	PageNr      int    // page number of the annotation
+	ObjNr       int    // annotation objNr
+	Annotation  string // RichMedia or Screen
+	FileName    string // file name of the asset
+	ContentType string // MIME type if available
+}
+
+// fileSpecData returns the file name and the decoded embedded file stream of file specification o.
+func fileSpecData(xRefTable *model.XRefTable, o types.Object) (string, []byte, error) {
+	o, err := xRefTable.Dereference(o)
+	if err != nil || o == nil {
+		return "", nil, err
+	}
+
+	if sd, ok := o.(types.StreamDict); ok {
+		// Media clip data may be a stream.
+		if err := sd.Decode(); err != nil {
+			return "", nil, err
+		}
+		return "", sd.Content, nil
+	}
+
+	d, ok := o.(types.Dict)
+	if !ok {
+		// External file
+		return "", nil, nil
+	}
+
+	var fileName string
+	for _, k := range []string{"UF", "F"} {
+		if o, found := d.Find(k); found {
+			if fileName, err = xRefTable.DereferenceText(o); err != nil {
+				return "", nil, err
+			}
+			break
+		}
+	}
+
+	ef, err := xRefTable.DereferenceDict(d["EF"])
+	if err != nil || ef == nil {
+		return fileName, nil, err
+	}
+
+	for _, k := range []string{"UF", "F"} {
+		o, found := ef.Find(k)
+		if !found {
+			continue
+		}
+		sd, _, err := xRefTable.DereferenceStreamDict(o)
+		if err != nil || sd == nil {
+			return fileName, nil, err
+		}
+		if sd.FilterPipeline == nil {
+			return fileName, sd.Raw, nil
+		}
+		if err := sd.Decode(); err != nil {
+			return fileName, nil, err
+		}
+		return fileName, sd.Content, nil
+	}
+
+	return fileName, nil, nil
+}
+
+func richMediaAssets(xRefTable *model.XRefTable, d types.Dict) ([]MediaAsset, error) {
+	rmc, err := xRefTable.DereferenceDict(d["RichMediaContent"])
+	if err != nil || rmc == nil {
+		return nil, err
+	}
+
+	assets, err := xRefTable.DereferenceDict(rmc["Assets"])
+	if err != nil || assets == nil {
+		return nil, err
+	}
+
+	var mm []MediaAsset
+
+	err = xRefTable.EachNameTreeEntry(assets, func(k string, v types.Object) error {
+		fileName, bb, err := fileSpecData(xRefTable, v)
+		if err != nil || bb == nil {
+			return err
+		}
+		if fileName == "" {
+			fileName = k
+		}
+		mm = append(mm, MediaAsset{Reader: bytes.NewReader(bb), Annotation: "RichMedia", FileName: fileName})
+		return nil
+	})
+
+	return mm, err
+}
+
+func renditionAssets(xRefTable *model.XRefTable, o types.Object, depth int) ([]MediaAsset, error) {
+	d, err := xRefTable.DereferenceDict(o)
+	if err != nil || d == nil || depth > 8 {
+		return nil, err
+	}
+
+	if s := d.NameEntry("S"); s != nil && *s == "SR" {
+		// Selector rendition
+		a, err := xRefTable.DereferenceArray(d["R"])
+		if err != nil {
+			return nil, err
+		}
+		var mm []MediaAsset
+		for _, o := range a {
+			mm1, err := renditionAssets(xRefTable, o, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			mm = append(mm, mm1...)
+		}
+		return mm, nil
+	}
+
+	clip, err := xRefTable.DereferenceDict(d["C"])
+	if err != nil || clip == nil {
+		return nil, err
+	}
+
+	fileName, bb, err := fileSpecData(xRefTable, clip["D"])
+	if err != nil || bb == nil {
+		return nil, err
+	}
+
+	m := MediaAsset{Reader: bytes.NewReader(bb), Annotation: "Screen", FileName: fileName}
+
+	if o, found := clip.Find("CT"); found {
+		if m.ContentType, err = xRefTable.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.FileName == "" {
+		if o, found := clip.Find("N"); found {
+			if m.FileName, err = xRefTable.DereferenceText(o); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return []MediaAsset{m}, nil
+}
+
+func screenAssets(xRefTable *model.XRefTable, d types.Dict) ([]MediaAsset, error) {
+	actions := []types.Object{d["A"]}
+
+	if aa, err := xRefTable.DereferenceDict(d["AA"]); err == nil && aa != nil {
+		for _, k := range []string{"E", "X", "D", "U", "Fo", "Bl", "PO", "PC", "PV", "PI"} {
+			actions = append(actions, aa[k])
+		}
+	}
+
+	var mm []MediaAsset
+
+	for _, o := range actions {
+		a, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if a == nil {
+			continue
+		}
+		if s := a.NameEntry("S"); s == nil || *s != "Rendition" {
+			continue
+		}
+		mm1, err := renditionAssets(xRefTable, a["R"], 0)
+		if err != nil {
+			return nil, err
+		}
+		mm = append(mm, mm1...)
+	}
+
+	return mm, nil
+}
+
+// MediaAssets returns the embedded media files of all RichMedia and Screen annotations of page pageNr.
+func MediaAssets(ctx *model.Context, pageNr int) ([]MediaAsset, error) {
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: MediaAssets: unknown page number: %d", pageNr)
+	}
+
+	a, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	var mm []MediaAsset
+
+	for _, o := range a {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d1 == nil {
+			continue
+		}
+
+		var mm1 []MediaAsset
+
+		switch st := d1.NameEntry("Subtype"); {
+		case st == nil:
+			continue
+		case *st == "RichMedia":
+			mm1, err = richMediaAssets(ctx.XRefTable, d1)
+		case *st == "Screen":
+			mm1, err = screenAssets(ctx.XRefTable, d1)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		objNr := 0
+		if ir, ok := o.(types.IndirectRef); ok {
+			objNr = ir.ObjectNumber.Value()
+		}
+
+		for i := range mm1 {
+			mm1[i].PageNr = pageNr
+			mm1[i].ObjNr = objNr
+		}
+
+		mm = append(mm, mm1...)
+	}
+
+	return mm, nil
+}
+
+// Video represents a video to be embedded into a page using a Screen annotation.
+type Video struct {
+	io.Reader                   // video data
+	Rect        types.Rectangle // annotation rectangle
+	FileName    string          // file name of the video
+	ContentType string          // MIME type, defaults to video/mp4
+	Title       string          // optional annotation title
+	ID          string          // optional annotation name
+	Poster      io.Reader       // optional image displayed while the video is not playing
+}
+
+func videoAppearance(ctx *model.Context, v Video) (*types.IndirectRef, error) {
+	w, h := v.Rect.Width(), v.Rect.Height()
+
+	var buf bytes.Buffer
+	d := types.Dict(map[string]types.Object{})
+
+	if v.Poster != nil {
+		imgIndRef, _, _, err := model.CreateImageResource(ctx.XRefTable, v.Poster)
+		if err != nil {
+			return nil, err
+		}
+		d["XObject"] = types.Dict(map[string]types.Object{"Im0": *imgIndRef})
+		fmt.Fprintf(&buf, "q %.2f 0 0 %.2f 0 0 cm /Im0 Do Q ", w, h)
+	}
+
+	// Frame
+	fmt.Fprintf(&buf, "q 0.5 G 1 w 0.5 0.5 %.2f %.2f re S Q", w-1, h-1)
+
+	sd, err := ctx.NewStreamDictForBuf(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.Insert("BBox", types.NewNumberArray(0, 0, w, h))
+	if len(d) > 0 {
+		sd.Insert("Resources", d)
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return ctx.IndRefForNewObject(*sd)
+}
+
+// AddVideoAnnotation embeds a video into page pageNr using a Screen annotation
+// with a rendition action playing the video on activation.
+func AddVideoAnnotation(ctx *model.Context, pageNr int, v Video) (*types.IndirectRef, error) {
+	if v.Reader == nil {
+		return nil, errors.New("pdfcpu: AddVideoAnnotation: missing video")
+	}
+
+	if v.FileName == "" {
+		return nil, errors.New("pdfcpu: AddVideoAnnotation: missing file name")
+	}
+
+	contentType := v.ContentType
+	if contentType == "" {
+		contentType = "video/mp4"
+	}
+
+	fileName := filepath.Base(v.FileName)
+
+	sdIndRef, err := ctx.NewEmbeddedStreamDict(v.Reader, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := ctx.NewFileSpecDict(fileName, fileName, "", *sdIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	fsIndRef, err := ctx.IndRefForNewObject(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.EscapedUTF16String(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	clip := types.Dict(map[string]types.Object{
+		"Type": types.Name("MediaClip"),
+		"S":    types.Name("MCD"),
+		"N":    types.StringLiteral(*s),
+		"CT":   types.StringLiteral(contentType),
+		"D":    *fsIndRef,
+		"P":    types.Dict(map[string]types.Object{"TF": types.StringLiteral("TEMPACCESS")}),
+	})
+
+	rendition := types.Dict(map[string]types.Object{
+		"Type": types.Name("Rendition"),
+		"S":    types.Name("MR"),
+		"N":    types.StringLiteral(*s),
+		"C":    clip,
+	})
+
+	apIndRef, err := videoAppearance(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	ann := model.NewAnnotation(model.AnnScreen, "", v.Rect, apIndRef.ObjectNumber.Value(), "", v.ID, "", model.AnnPrint, nil, 0, 0, 0)
+
+	annIndRef, d, err := AddAnnotationToPage(ctx, pageNr, screenAnnotation{Annotation: ann, title: v.Title}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rendition action refers to its own screen annotation.
+	d["A"] = types.Dict(map[string]types.Object{
+		"Type": types.Name("Action"),
+		"S":    types.Name("Rendition"),
+		"OP":   types.Integer(0),
+		"R":    rendition,
+		"AN":   *annIndRef,
+	})
+
+	return annIndRef, nil
+}
+
+type screenAnnotation struct {
+	model.Annotation
+	title string
+}
+
+// RenderDict renders ann into a PDF annotation dict.
+func (ann screenAnnotation) RenderDict(xRefTable *model.XRefTable, pageIndRef *types.IndirectRef) (types.Dict, error) {
+	d, err := ann.Annotation.RenderDict(xRefTable, pageIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if ann.title != "" {
+		s, err := types.EscapedUTF16String(ann.title)
+		if err != nil {
+			return nil, err
+		}
+		d.InsertString("T", *s)
+	}
+
+	d["AP"] = types.Dict(map[string]types.Object{"N": *types.NewIndirectRef(ann.APObjNr, 0)})
+
+	return d, nil
+}
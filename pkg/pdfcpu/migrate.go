@@ -39,6 +39,7 @@ func migrateIndRef(ir *types.IndirectRef, ctxSource, ctxDest *model.Context, mig
 	objNr := ir.ObjectNumber.Value()
 	migrated[objNr] = objNrNew
 	ir.ObjectNumber = types.Integer(objNrNew)
+	ir.GenerationNumber = 0
 	return o, nil
 }
 
@@ -49,6 +50,7 @@ func migrateObject(o types.Object, ctxSource, ctxDest *model.Context, migrated m
 		objNr := o.ObjectNumber.Value()
 		if migrated[objNr] > 0 {
 			o.ObjectNumber = types.Integer(migrated[objNr])
+			o.GenerationNumber = 0
 			return o, nil
 		}
 		o1, err := migrateIndRef(&o, ctxSource, ctxDest, migrated)
@@ -88,6 +90,40 @@ func migrateObject(o types.Object, ctxSource, ctxDest *model.Context, migrated m
 	return o, nil
 }
 
+// CopyObjects deep copies the objects referenced by roots from src into dst and returns the references to the copies.
+// Everything reachable from roots gets copied, shared objects and reference cycles are copied once.
+// Use CopyObjects for resources like fonts, XObjects or optional content groups,
+// a page dict would drag along its parent page tree.
+func CopyObjects(dst, src *model.Context, roots []types.IndirectRef) ([]types.IndirectRef, error) {
+	if dst.ReadOnly() {
+		return nil, model.ErrReadOnly
+	}
+
+	migrated := map[int]int{}
+	refs := make([]types.IndirectRef, len(roots))
+
+	for i, ir := range roots {
+		o, err := migrateObject(ir, src, dst, migrated)
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = o.(types.IndirectRef)
+	}
+
+	// Stream data must not depend on src.
+	for _, objNr := range migrated {
+		entry := dst.Table[objNr]
+		if sd, ok := entry.Object.(types.StreamDict); ok {
+			if err := sd.Load(); err != nil {
+				return nil, err
+			}
+			entry.Object = sd
+		}
+	}
+
+	return refs, nil
+}
+
 func migrateAnnots(o types.Object, pageIndRef types.IndirectRef, ctxSrc, ctxDest *model.Context, migrated map[int]int) (types.Object, error) {
 	arr := o.(types.Array)
 	for i, v := range o.(types.Array) {
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "fmt"
+
+// Page action triggers, see 12.6.3
+const (
+	PageActionOpen  = "O" // page opened
+	PageActionClose = "C" // page closed
+)
+
+// NamedActions are the standard named actions, see 12.6.4.11
+var NamedActions = []string{"NextPage", "PrevPage", "FirstPage", "LastPage"}
+
+// Action represents a document open action or a page action.
+// GoTo and Named actions may be set, any other action type is reported by its type only.
+type Action struct {
+	Type string       // GoTo, Named, ...
+	Dest *Destination // target of a GoTo action
+	Name string       // named action, one of NamedActions
+}
+
+// NewGoToAction returns an action going to dest.
+func NewGoToAction(dest Destination) Action {
+	return Action{Type: "GoTo", Dest: &dest}
+}
+
+// NewNamedAction returns the named action name.
+func NewNamedAction(name string) Action {
+	return Action{Type: "Named", Name: name}
+}
+
+func (a Action) String() string {
+	switch a.Type {
+	case "GoTo":
+		if a.Dest == nil {
+			return "GoTo"
+		}
+		d := a.Dest
+		switch d.Typ {
+		case DestXYZ:
+			return fmt.Sprintf("GoTo page %d %s left:%d top:%d zoom:%.2f", d.PageNr, d, d.Left, d.Top, d.Zoom)
+		case DestFitH, DestFitBH:
+			return fmt.Sprintf("GoTo page %d %s top:%d", d.PageNr, d, d.Top)
+		case DestFitV, DestFitBV:
+			return fmt.Sprintf("GoTo page %d %s left:%d", d.PageNr, d, d.Left)
+		case DestFitR:
+			return fmt.Sprintf("GoTo page %d %s [%d %d %d %d]", d.PageNr, d, d.Left, d.Bottom, d.Right, d.Top)
+		}
+		return fmt.Sprintf("GoTo page %d %s", d.PageNr, d)
+	case "Named":
+		return "Named " + a.Name
+	}
+	return a.Type
+}
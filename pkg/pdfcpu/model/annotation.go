@@ -17,10 +17,12 @@ limitations under the License.
 package model
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 )
@@ -146,7 +148,7 @@ const (
 	BSUnderline
 )
 
-func borderStyleDict(width float64, style BorderStyle) types.Dict {
+func borderStyleDict(width float64, style BorderStyle, dash []float64) types.Dict {
 	d := types.Dict(map[string]types.Object{
 		"Type": types.Name("Border"),
 		"W":    types.Float(width),
@@ -169,6 +171,10 @@ func borderStyleDict(width float64, style BorderStyle) types.Dict {
 
 	d["S"] = types.Name(s)
 
+	if style == BSDashed && len(dash) > 0 {
+		d["D"] = types.NewNumberArray(dash...)
+	}
+
 	return d
 }
 
@@ -184,8 +190,12 @@ func borderEffectDict(cloudyBorder bool, intensity int) types.Dict {
 	})
 }
 
-func borderArray(rx, ry, width float64) types.Array {
-	return types.NewNumberArray(rx, ry, width)
+func borderArray(rx, ry, width float64, dash []float64) types.Array {
+	a := types.NewNumberArray(rx, ry, width)
+	if len(dash) > 0 {
+		a = append(a, types.NewNumberArray(dash...))
+	}
+	return a
 }
 
 // LineEndingStyle (see table 179)
@@ -257,6 +267,7 @@ type Annotation struct {
 	BorderRadX       float64            // Border radius X
 	BorderRadY       float64            // Border radius Y
 	BorderWidth      float64            // Border width
+	BorderDash       []float64          // Optional dash array for dashed borders.
 	Hash             uint32
 	// StructParent int
 	// OC types.dict
@@ -397,7 +408,7 @@ func (ann Annotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.Indirec
 	}
 
 	if ann.BorderWidth > 0 {
-		d["Border"] = borderArray(ann.BorderRadX, ann.BorderRadY, ann.BorderWidth)
+		d["Border"] = borderArray(ann.BorderRadX, ann.BorderRadY, ann.BorderWidth, ann.BorderDash)
 	}
 
 	return d, nil
@@ -557,7 +568,7 @@ func (ann LinkAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.Ind
 		}
 	}
 
-	d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle)
+	d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle, ann.BorderDash)
 
 	return d, nil
 }
@@ -880,7 +891,7 @@ func (ann FreeTextAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types
 	}
 
 	if ann.BorderWidth > 0 {
-		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle)
+		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle, ann.BorderDash)
 	}
 
 	if ann.CloudyBorder && ann.CloudyBorderIntensity > 0 {
@@ -1056,7 +1067,7 @@ func (ann LineAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.Ind
 	}
 
 	if ann.BorderWidth > 0 {
-		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle)
+		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle, ann.BorderDash)
 	}
 
 	if len(ann.LineEndings) == 2 {
@@ -1135,13 +1146,29 @@ func (ann SquareAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.I
 	}
 
 	if ann.BorderWidth > 0 {
-		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle)
+		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle, ann.BorderDash)
 	}
 
 	if ann.CloudyBorder && ann.CloudyBorderIntensity > 0 {
 		d["BE"] = borderEffectDict(ann.CloudyBorder, ann.CloudyBorderIntensity)
 	}
 
+	if ann.BorderRadX > 0 || ann.BorderRadY > 0 {
+		d["Border"] = borderArray(ann.BorderRadX, ann.BorderRadY, ann.BorderWidth, ann.BorderDash)
+	}
+
+	if ann.APObjNr == 0 && (ann.C != nil || ann.FillCol != nil) {
+		intensity := 0
+		if ann.CloudyBorder {
+			intensity = ann.CloudyBorderIntensity
+		}
+		ir, err := shapeAppearance(xRefTable, ann.MarkupAnnotation, ann.FillCol, ann.Margins, ann.BorderWidth, ann.BorderStyle, intensity, false)
+		if err != nil {
+			return nil, err
+		}
+		d["AP"] = types.Dict(map[string]types.Object{"N": *ir})
+	}
+
 	return d, nil
 }
 
@@ -1214,16 +1241,90 @@ func (ann CircleAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.I
 	}
 
 	if ann.BorderWidth > 0 {
-		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle)
+		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle, ann.BorderDash)
 	}
 
 	if ann.CloudyBorder && ann.CloudyBorderIntensity > 0 {
 		d["BE"] = borderEffectDict(ann.CloudyBorder, ann.CloudyBorderIntensity)
 	}
 
+	if ann.APObjNr == 0 && (ann.C != nil || ann.FillCol != nil) {
+		intensity := 0
+		if ann.CloudyBorder {
+			intensity = ann.CloudyBorderIntensity
+		}
+		ir, err := shapeAppearance(xRefTable, ann.MarkupAnnotation, ann.FillCol, ann.Margins, ann.BorderWidth, ann.BorderStyle, intensity, true)
+		if err != nil {
+			return nil, err
+		}
+		d["AP"] = types.Dict(map[string]types.Object{"N": *ir})
+	}
+
 	return d, nil
 }
 
+// shapeAppearance returns a normal appearance stream for square and circle annotations
+// honoring the border style, dash array, corner radii, cloudy border effect and fill color.
+func shapeAppearance(
+	xRefTable *XRefTable,
+	ann MarkupAnnotation,
+	fillCol *color.SimpleColor,
+	margins types.Array,
+	borderWidth float64,
+	borderStyle BorderStyle,
+	cloudyIntensity int,
+	ellipse bool) (*types.IndirectRef, error) {
+
+	w, h := ann.Rect.Width(), ann.Rect.Height()
+
+	// Apply RD: left, top, right, bottom.
+	r := types.NewRectangle(0, 0, w, h)
+	if len(margins) == 4 {
+		var m [4]float64
+		for i, o := range margins {
+			f, err := xRefTable.DereferenceNumber(o)
+			if err != nil {
+				return nil, err
+			}
+			m[i] = f
+		}
+		r = types.NewRectangle(m[0], m[3], w-m[2], h-m[1])
+	}
+
+	b := draw.Border{Width: borderWidth, Color: ann.C, Radius: ann.BorderRadX}
+	if ann.C != nil && borderWidth > 0 {
+		r = types.NewRectangle(r.LL.X+borderWidth/2, r.LL.Y+borderWidth/2, r.UR.X-borderWidth/2, r.UR.Y-borderWidth/2)
+	}
+	if borderStyle == BSDashed {
+		b.Dash = ann.BorderDash
+		if len(b.Dash) == 0 {
+			b.Dash = []float64{3}
+		}
+	}
+	b.Cloudy = cloudyIntensity
+
+	var buf bytes.Buffer
+	if ellipse {
+		draw.DrawBorderedEllipse(&buf, r, b, fillCol)
+	} else {
+		draw.DrawBorderedRect(&buf, r, b, fillCol)
+	}
+
+	sd, err := xRefTable.NewStreamDictForBuf(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.Insert("BBox", types.NewNumberArray(0, 0, w, h))
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
+
 // PolygonIntent represents the various polygon annotation intents.
 type PolygonIntent int
 
@@ -1339,7 +1440,7 @@ func (ann PolygonAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.
 	}
 
 	if ann.BorderWidth > 0 {
-		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle)
+		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle, ann.BorderDash)
 	}
 
 	if ann.CloudyBorder && ann.CloudyBorderIntensity > 0 {
@@ -1462,7 +1563,7 @@ func (ann PolyLineAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types
 	}
 
 	if ann.BorderWidth > 0 {
-		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle)
+		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle, ann.BorderDash)
 	}
 
 	if len(ann.LineEndings) == 2 {
@@ -1719,8 +1820,194 @@ func (ann InkAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.Indi
 	d["InkList"] = ink
 
 	if ann.BorderWidth > 0 {
-		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle)
+		d["BS"] = borderStyleDict(ann.BorderWidth, ann.BorderStyle, ann.BorderDash)
+	}
+
+	return d, nil
+}
+
+// ThreeDView represents a 3D view (see 13.6.4).
+type ThreeDView struct {
+	Name string    // External name of the view.
+	C2W  []float64 // 12 element camera to world transformation matrix.
+	CO   float64   // Distance from the camera to the center of orbit.
+}
+
+// ThreeDAnnotation represents a 3D annotation carrying U3D or PRC data.
+type ThreeDAnnotation struct {
+	Annotation
+	Data         []byte       // U3D or PRC data
+	Format       string       // U3D or PRC, sniffed from Data if empty
+	Views        []ThreeDView // Optional views, the first one is the default view.
+	Activation   string       // Optional activation: XA (explicit, default), PO (page open), PV (page visible)
+	Deactivation string       // Optional deactivation: XD (explicit), PC (page close, default), PI (page invisible)
+	Interactive  bool         // Enable user interaction.
+}
+
+// ThreeDFormat returns the 3D data format of bb: U3D, PRC or an empty string.
+func ThreeDFormat(bb []byte) string {
+	switch {
+	case bytes.HasPrefix(bb, []byte("U3D\x00")):
+		return "U3D"
+	case bytes.HasPrefix(bb, []byte("PRC")):
+		return "PRC"
+	}
+	return ""
+}
+
+// NewThreeDAnnotation returns a new 3D annotation.
+func NewThreeDAnnotation(
+	rect types.Rectangle,
+	apObjNr int,
+	contents, id string,
+	modDate string,
+	f AnnotationFlags,
+	col *color.SimpleColor,
+
+	data []byte,
+	format string,
+	views []ThreeDView,
+	activation, deactivation string,
+	interactive bool) ThreeDAnnotation {
+
+	ann := NewAnnotation(Ann3D, "", rect, apObjNr, contents, id, modDate, f, col, 0, 0, 0)
+
+	if format == "" {
+		format = ThreeDFormat(data)
+	}
+
+	return ThreeDAnnotation{
+		Annotation:   ann,
+		Data:         data,
+		Format:       format,
+		Views:        views,
+		Activation:   activation,
+		Deactivation: deactivation,
+		Interactive:  interactive,
+	}
+}
+
+func (ann ThreeDAnnotation) validate() error {
+	if len(ann.Data) == 0 {
+		return errors.New("pdfcpu: ThreeDAnnotation: missing 3D data")
+	}
+	if ann.Format != "U3D" && ann.Format != "PRC" {
+		return errors.Errorf("pdfcpu: ThreeDAnnotation: unsupported 3D format: %s", ann.Format)
+	}
+	if ann.Activation != "" && !types.MemberOf(ann.Activation, []string{"XA", "PO", "PV"}) {
+		return errors.Errorf("pdfcpu: ThreeDAnnotation: invalid activation: %s", ann.Activation)
+	}
+	if ann.Deactivation != "" && !types.MemberOf(ann.Deactivation, []string{"XD", "PC", "PI"}) {
+		return errors.Errorf("pdfcpu: ThreeDAnnotation: invalid deactivation: %s", ann.Deactivation)
+	}
+	for _, v := range ann.Views {
+		if len(v.C2W) != 0 && len(v.C2W) != 12 {
+			return errors.Errorf("pdfcpu: ThreeDAnnotation: view %s: C2W needs 12 numbers", v.Name)
+		}
+	}
+	return nil
+}
+
+func (ann ThreeDAnnotation) streamDict(xRefTable *XRefTable) (*types.IndirectRef, error) {
+	sd, err := xRefTable.NewStreamDictForBuf(ann.Data)
+	if err != nil {
+		return nil, err
+	}
+	sd.InsertName("Type", "3D")
+	sd.InsertName("Subtype", ann.Format)
+
+	if len(ann.Views) > 0 {
+		va := types.Array{}
+		for _, v := range ann.Views {
+			d := types.Dict(map[string]types.Object{"Type": types.Name("3DView")})
+			if v.Name != "" {
+				s, err := types.EscapedUTF16String(v.Name)
+				if err != nil {
+					return nil, err
+				}
+				d.InsertString("XN", *s)
+			}
+			if len(v.C2W) == 12 {
+				d["MS"] = types.Name("M")
+				d["C2W"] = types.NewNumberArray(v.C2W...)
+			}
+			if v.CO > 0 {
+				d["CO"] = types.Float(v.CO)
+			}
+			va = append(va, d)
+		}
+		sd.Insert("VA", va)
+		sd.Insert("DV", types.Integer(0))
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
+
+// appearance returns a form XObject framing the annotation rectangle which is displayed while the 3D artwork is inactive.
+func (ann ThreeDAnnotation) appearance(xRefTable *XRefTable) (*types.IndirectRef, error) {
+	w, h := ann.Rect.Width(), ann.Rect.Height()
+	bb := []byte(fmt.Sprintf("q 0.5 G 1 w 0.5 0.5 %.2f %.2f re S Q", w-1, h-1))
+
+	sd, err := xRefTable.NewStreamDictForBuf(bb)
+	if err != nil {
+		return nil, err
+	}
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.Insert("BBox", types.NewNumberArray(0, 0, w, h))
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
+
+// RenderDict renders ann into a PDF annotation dict.
+func (ann ThreeDAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.IndirectRef) (types.Dict, error) {
+	if err := ann.validate(); err != nil {
+		return nil, err
+	}
+
+	d, err := ann.Annotation.RenderDict(xRefTable, pageIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	indRef, err := ann.streamDict(xRefTable)
+	if err != nil {
+		return nil, err
+	}
+	d["3DD"] = *indRef
+
+	if len(ann.Views) > 0 {
+		d["3DV"] = types.Name("D")
+	}
+
+	if ann.Activation != "" || ann.Deactivation != "" {
+		d1 := types.Dict{}
+		if ann.Activation != "" {
+			d1["A"] = types.Name(ann.Activation)
+		}
+		if ann.Deactivation != "" {
+			d1["D"] = types.Name(ann.Deactivation)
+		}
+		d["3DA"] = d1
+	}
+
+	d["3DI"] = types.Boolean(ann.Interactive)
+
+	apIndRef := types.NewIndirectRef(ann.APObjNr, 0)
+	if ann.APObjNr == 0 {
+		if apIndRef, err = ann.appearance(xRefTable); err != nil {
+			return nil, err
+		}
 	}
+	d["AP"] = types.Dict(map[string]types.Object{"N": *apIndRef})
 
 	return d, nil
 }
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// AFRelationships lists the valid relationships between an associated file and its owner (14.13.2).
+var AFRelationships = []string{"Source", "Data", "Alternative", "Supplement", "EncryptedPayload", "FormData", "Schema", "Unspecified"}
+
+// AssociatedFile represents a file associated with the document, a page or an object (14.13).
+type AssociatedFile struct {
+	Attachment
+	Relationship string // one of AFRelationships
+}
+
+func (af AssociatedFile) String() string {
+	return fmt.Sprintf("AssociatedFile: id:%s desc:%s relationship:%s modTime:%s", af.ID, af.Desc, af.Relationship, af.ModTime)
+}
+
+// AFOwner identifies the owner of associated files.
+// The zero value denotes the document.
+type AFOwner struct {
+	PageNr int // page number, if > 0
+	ObjNr  int // object number of eg. an annotation, an XObject or a structure element, if > 0
+}
+
+func (o AFOwner) String() string {
+	switch {
+	case o.PageNr > 0:
+		return fmt.Sprintf("page %d", o.PageNr)
+	case o.ObjNr > 0:
+		return fmt.Sprintf("object %d", o.ObjNr)
+	}
+	return "document"
+}
+
+func (ctx *Context) afOwnerDict(owner AFOwner) (types.Dict, error) {
+	if owner.PageNr > 0 && owner.ObjNr > 0 {
+		return nil, errors.New("pdfcpu: associated files owner is either a page or an object")
+	}
+
+	if owner.PageNr > 0 {
+		d, _, _, err := ctx.PageDict(owner.PageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			return nil, errors.Errorf("pdfcpu: unknown page number: %d", owner.PageNr)
+		}
+		return d, nil
+	}
+
+	if owner.ObjNr > 0 {
+		o, err := ctx.Dereference(*types.NewIndirectRef(owner.ObjNr, 0))
+		if err != nil {
+			return nil, err
+		}
+		switch o := o.(type) {
+		case types.Dict:
+			return o, nil
+		case types.StreamDict:
+			return o.Dict, nil
+		}
+		return nil, errors.Errorf("pdfcpu: object %d is not a dict", owner.ObjNr)
+	}
+
+	return ctx.Catalog()
+}
+
+func (xRefTable *XRefTable) associatedFile(o types.Object) (*AssociatedFile, error) {
+	d, err := xRefTable.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	af := &AssociatedFile{Relationship: "Unspecified"}
+	if n := d.NameEntry("AFRelationship"); n != nil {
+		af.Relationship = *n
+	}
+
+	sd, err := fileSpecStreamDict(xRefTable, d)
+	if err != nil {
+		return nil, err
+	}
+
+	if sd != nil {
+		_, desc, fileName, modTime, err := fileSpecStreamDictInfo(xRefTable, "", d, false)
+		if err != nil {
+			return nil, err
+		}
+		af.ID, af.FileName, af.Desc, af.ModTime = fileName, fileName, desc, modTime
+		return af, nil
+	}
+
+	// Reference to an external file.
+	if af.FileName, err = fileSpecStreamFileName(xRefTable, d); err != nil {
+		return nil, err
+	}
+	af.ID = af.FileName
+
+	if o, found := d.Find("Desc"); found {
+		if af.Desc, err = xRefTable.DereferenceStringOrHexLiteral(o, V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return af, nil
+}
+
+func (ctx *Context) associatedFiles(owner AFOwner) (types.Dict, types.Array, error) {
+	d, err := ctx.afOwnerDict(owner)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a, err := ctx.DereferenceArray(d["AF"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, a, nil
+}
+
+// ListAssociatedFiles returns stubs (w/o data) of all files associated with owner.
+func (ctx *Context) ListAssociatedFiles(owner AFOwner) ([]AssociatedFile, error) {
+	_, a, err := ctx.associatedFiles(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var aff []AssociatedFile
+
+	for _, o := range a {
+		af, err := ctx.associatedFile(o)
+		if err != nil {
+			return nil, err
+		}
+		if af != nil {
+			aff = append(aff, *af)
+		}
+	}
+
+	return aff, nil
+}
+
+func (ctx *Context) associatedFileIndex(a types.Array, id string) (int, error) {
+	for i, o := range a {
+		af, err := ctx.associatedFile(o)
+		if err != nil {
+			return -1, err
+		}
+		if af != nil && af.ID == id {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// AddAssociatedFile embeds a and associates it with owner using relationship.
+// Files associated with the document are also added to the EmbeddedFiles name tree.
+func (ctx *Context) AddAssociatedFile(owner AFOwner, a Attachment, relationship string) error {
+	if relationship == "" {
+		relationship = "Unspecified"
+	}
+	if !types.MemberOf(relationship, AFRelationships) {
+		return errors.Errorf("pdfcpu: invalid associated file relationship: %s", relationship)
+	}
+
+	d, aa, err := ctx.associatedFiles(owner)
+	if err != nil {
+		return err
+	}
+
+	i, err := ctx.associatedFileIndex(aa, a.ID)
+	if err != nil {
+		return err
+	}
+	if i >= 0 {
+		return errors.Errorf("pdfcpu: %s already has an associated file %s", owner, a.ID)
+	}
+
+	xRefTable := ctx.XRefTable
+
+	fsDict, err := xRefTable.NewFileSpecDictForAttachment(a)
+	if err != nil {
+		return err
+	}
+	fsDict["AFRelationship"] = types.Name(relationship)
+
+	ir, err := xRefTable.IndRefForNewObject(fsDict)
+	if err != nil {
+		return err
+	}
+
+	if owner.PageNr == 0 && owner.ObjNr == 0 {
+		if err := xRefTable.LocateNameTree("EmbeddedFiles", true); err != nil {
+			return err
+		}
+		m := NameMap{a.ID: []types.Dict{fsDict}}
+		if err := xRefTable.Names["EmbeddedFiles"].Add(xRefTable, a.ID, *ir, m, []string{"F", "UF"}); err != nil {
+			return err
+		}
+	}
+
+	d["AF"] = append(aa, *ir)
+
+	if xRefTable.Version() < V17 {
+		xRefTable.EnsureVersionForWriting()
+	}
+
+	return nil
+}
+
+// RemoveAssociatedFile removes the file id associated with owner and returns false if there is no such file.
+func (ctx *Context) RemoveAssociatedFile(owner AFOwner, id string) (bool, error) {
+	d, aa, err := ctx.associatedFiles(owner)
+	if err != nil {
+		return false, err
+	}
+
+	i, err := ctx.associatedFileIndex(aa, id)
+	if err != nil || i < 0 {
+		return false, err
+	}
+
+	ir, _ := aa[i].(types.IndirectRef)
+
+	if aa = append(aa[:i], aa[i+1:]...); len(aa) == 0 {
+		delete(d, "AF")
+	} else {
+		d["AF"] = aa
+	}
+
+	if owner.PageNr > 0 || owner.ObjNr > 0 {
+		return true, nil
+	}
+
+	// Remove the corresponding entry of the EmbeddedFiles name tree.
+
+	xRefTable := ctx.XRefTable
+	if !xRefTable.Valid {
+		if err := xRefTable.LocateNameTree("EmbeddedFiles", false); err != nil {
+			return false, err
+		}
+	}
+	if xRefTable.Names["EmbeddedFiles"] == nil {
+		return true, nil
+	}
+
+	o, found := xRefTable.Names["EmbeddedFiles"].Value(id)
+	if ir1, ok := o.(types.IndirectRef); !found || !ok || ir1.ObjectNumber != ir.ObjectNumber {
+		return true, nil
+	}
+
+	empty, _, err := xRefTable.Names["EmbeddedFiles"].Remove(xRefTable, id)
+	if err != nil {
+		return false, err
+	}
+	if empty {
+		if err := xRefTable.RemoveEmbeddedFilesNameTree(); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
@@ -18,9 +18,11 @@ package model
 
 import (
 	"bytes"
+	"crypto/md5"
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
@@ -45,6 +47,9 @@ func decodeFileSpecStreamDict(sd *types.StreamDict) error {
 	fpl := sd.FilterPipeline
 
 	if fpl == nil {
+		if err := sd.Load(); err != nil {
+			return err
+		}
 		sd.Content = sd.Raw
 		return nil
 	}
@@ -161,6 +166,14 @@ func fileSpecStreamDictInfo(xRefTable *XRefTable, id string, o types.Object, dec
 		}
 	}
 
+	if !decode {
+		return sd, desc, fileName, modDate, nil
+	}
+
+	if xRefTable.EmbeddedFilesLocked {
+		return nil, "", "", nil, ErrEmbeddedFilesLocked
+	}
+
 	err = decodeFileSpecStreamDict(sd)
 
 	return sd, desc, fileName, modDate, err
@@ -229,6 +242,9 @@ func (ctx *Context) AddAttachment(a Attachment, useCollection bool) error {
 
 var errContentMatch = errors.New("name tree content match")
 
+// ErrEmbeddedFilesLocked signals embedded files protected by the user password.
+var ErrEmbeddedFilesLocked = errors.New("pdfcpu: embedded files are encrypted, please provide the user password")
+
 // SearchEmbeddedFilesNameTreeNodeByContent tries to identify a name tree by content.
 func (ctx *Context) SearchEmbeddedFilesNameTreeNodeByContent(s string) (*string, types.Object, error) {
 
@@ -366,6 +382,18 @@ func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 		if err != nil {
 			return err
 		}
+		ac, err := verifyEmbeddedFile(xRefTable, sd)
+		if err != nil {
+			return err
+		}
+		if !ac.OK() {
+			if log.CLIEnabled() {
+				log.CLI.Printf("attachment %s: %s", id, ac.Mismatch())
+			}
+			if log.InfoEnabled() {
+				log.Info.Printf("pdfcpu: extractAttachments: %s: %s", id, ac.Mismatch())
+			}
+		}
 		a := Attachment{Reader: bytes.NewReader(sd.Content), ID: id, FileName: fileName, Desc: desc, ModTime: modTime}
 		aa = append(aa, a)
 		return nil
@@ -407,6 +435,99 @@ func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 	return aa, nil
 }
 
+// AttachmentCheck reports the integrity of an embedded file.
+type AttachmentCheck struct {
+	ID               string
+	FileName         string
+	Size             int  // size of the extracted file
+	SizeMismatch     bool // Params Size differs from Size
+	CheckSum         bool // Params CheckSum is present
+	CheckSumMismatch bool // Params CheckSum differs from the MD5 checksum of the extracted file
+}
+
+// OK returns true if the embedded file matches its size and checksum where specified.
+func (ac AttachmentCheck) OK() bool {
+	return !ac.SizeMismatch && !ac.CheckSumMismatch
+}
+
+// Mismatch describes the integrity violations of an embedded file.
+func (ac AttachmentCheck) Mismatch() string {
+	var ss []string
+	if ac.SizeMismatch {
+		ss = append(ss, "size mismatch")
+	}
+	if ac.CheckSumMismatch {
+		ss = append(ss, "checksum mismatch")
+	}
+	return strings.Join(ss, ", ")
+}
+
+// verifyEmbeddedFile checks the decoded embedded file sd against the size and MD5 checksum of its parameters.
+func verifyEmbeddedFile(xRefTable *XRefTable, sd *types.StreamDict) (*AttachmentCheck, error) {
+	ac := &AttachmentCheck{Size: len(sd.Content)}
+
+	d, err := xRefTable.DereferenceDict(sd.Dict["Params"])
+	if err != nil || d == nil {
+		return ac, err
+	}
+
+	if o, found := d.Find("Size"); found {
+		i, err := xRefTable.DereferenceInteger(o)
+		if err != nil {
+			return nil, err
+		}
+		ac.SizeMismatch = i != nil && i.Value() != ac.Size
+	}
+
+	bb, err := xRefTable.DereferenceStringEntryBytes(d, "CheckSum")
+	if err != nil {
+		return nil, err
+	}
+	if bb != nil {
+		sum := md5.Sum(sd.Content)
+		ac.CheckSum = true
+		ac.CheckSumMismatch = !bytes.Equal(bb, sum[:])
+	}
+
+	return ac, nil
+}
+
+// VerifyAttachments extracts all embedded files and verifies them against their specified size and checksum.
+func (ctx *Context) VerifyAttachments() ([]AttachmentCheck, error) {
+	xRefTable := ctx.XRefTable
+	if !xRefTable.Valid {
+		if err := xRefTable.LocateNameTree("EmbeddedFiles", false); err != nil {
+			return nil, err
+		}
+	}
+	if xRefTable.Names["EmbeddedFiles"] == nil {
+		return nil, nil
+	}
+
+	acs := []AttachmentCheck{}
+
+	verifyAttachment := func(xRefTable *XRefTable, id string, o *types.Object) error {
+		decode := true
+		sd, _, fileName, _, err := fileSpecStreamDictInfo(xRefTable, id, *o, decode)
+		if err != nil {
+			return err
+		}
+		ac, err := verifyEmbeddedFile(xRefTable, sd)
+		if err != nil {
+			return err
+		}
+		ac.ID, ac.FileName = id, fileName
+		acs = append(acs, *ac)
+		return nil
+	}
+
+	if err := ctx.Names["EmbeddedFiles"].Process(xRefTable, verifyAttachment); err != nil {
+		return nil, err
+	}
+
+	return acs, nil
+}
+
 // ExtractAttachment extracts a fully populated attachment.
 func (ctx *Context) ExtractAttachment(a Attachment) (*Attachment, error) {
 	aa, err := ctx.ExtractAttachments([]string{a.ID})
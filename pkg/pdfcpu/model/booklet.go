@@ -64,6 +64,28 @@ func (b BookletBinding) String() string {
 	return ""
 }
 
+// BlankPagePosition represents the insertion position of blank filler pages.
+type BlankPagePosition int
+
+// These are the positions for blank filler pages completing the last sheet of a booklet.
+const (
+	BlankPagesAtEnd BlankPagePosition = iota
+	BlankPagesAtStart
+	BlankPagesBeforeBackCover
+)
+
+func (p BlankPagePosition) String() string {
+	switch p {
+	case BlankPagesAtEnd:
+		return "end"
+	case BlankPagesAtStart:
+		return "start"
+	case BlankPagesBeforeBackCover:
+		return "before back cover"
+	}
+	return ""
+}
+
 type BookletPage struct {
 	Number int
 	Rotate bool
@@ -199,6 +221,58 @@ func drawGuideVertical(w io.Writer, x, height float64, cutOrFold cutOrFold, nup
 	}
 }
 
+// SheetLabel returns the label for output page i of a booklet.
+func SheetLabel(nup *NUp, i int) string {
+	side := "front"
+	if i%2 == 1 {
+		side = "back"
+	}
+
+	if !nup.MultiFolio || nup.FolioSize <= 0 {
+		return fmt.Sprintf("Sheet %d %s", i/2+1, side)
+	}
+
+	// A signature consists of 4*folioSize input pages.
+	n := nup.N()
+	pagesPerSignature := (4*nup.FolioSize + n - 1) / n
+	if pagesPerSignature%2 == 1 {
+		pagesPerSignature++
+	}
+
+	return fmt.Sprintf("Signature %d Sheet %d %s", i/pagesPerSignature+1, (i%pagesPerSignature)/2+1, side)
+}
+
+// DrawBookletSheetLabel prints label into the gutter of a booklet sheet.
+func DrawBookletSheetLabel(nup *NUp, w io.Writer, fm FontMap, label string) {
+	width := nup.PageDim.Width
+	height := nup.PageDim.Height
+	mb := types.RectForDim(width, height)
+
+	x, y, rot := 4., height/2+2, 0
+	if horz, vert := getCutFolds(nup); horz != fold && vert == fold {
+		x, y, rot = width/2-2, 4, 90
+	} else if horz != fold && vert != fold {
+		// No fold, eg. perfect bound.
+		x, y = 4, 4
+	}
+
+	fontName := "Helvetica"
+	td := TextDescriptor{
+		FontName:  fontName,
+		FontKey:   fm.EnsureKey(fontName),
+		FontSize:  6,
+		Scale:     1.0,
+		ScaleAbs:  true,
+		StrokeCol: color.Gray,
+		FillCol:   color.Gray,
+		X:         x,
+		Y:         y,
+		Rotation:  float64(rot),
+		Text:      label,
+	}
+	WriteMultiLine(nil, w, mb, nil, td)
+}
+
 // DrawBookletGuides draws guides according to corresponding nup value.
 func DrawBookletGuides(nup *NUp, w io.Writer) FontMap {
 	width := nup.PageDim.Width
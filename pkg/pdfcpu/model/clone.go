@@ -0,0 +1,364 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ErrReadOnly indicates an attempt to modify a read-only context.
+var ErrReadOnly = errors.New("pdfcpu: context is read-only")
+
+// SetReadOnly puts xRefTable into read-only mode.
+//
+// In read-only mode all Dereference* methods as well as Catalog are safe for concurrent use.
+// Lazy decoding of objects embedded in object streams is synchronized and
+// neither the current object nor any validation findings get recorded.
+// Inserting or freeing objects fails with ErrReadOnly.
+//
+// Read-only mode cannot be left, use Context.Clone to obtain a modifiable copy.
+// Direct access to xRefTable.Table bypasses synchronization.
+func (xRefTable *XRefTable) SetReadOnly() error {
+	if xRefTable.readOnly {
+		return nil
+	}
+
+	// Prime the catalog cache.
+	if _, err := xRefTable.Catalog(); err != nil {
+		return err
+	}
+
+	xRefTable.mu = &sync.RWMutex{}
+	xRefTable.readOnly = true
+
+	return nil
+}
+
+// ReadOnly returns true if xRefTable is in read-only mode.
+func (xRefTable *XRefTable) ReadOnly() bool {
+	return xRefTable.readOnly
+}
+
+func (xRefTable *XRefTable) syncedEntryObject(entry *XRefTableEntry, decodeLazy bool) (types.Object, int, error) {
+	xRefTable.mu.RLock()
+	o := entry.Object
+	xRefTable.mu.RUnlock()
+
+	if _, ok := o.(types.LazyObjectStreamObject); !ok || !decodeLazy {
+		return o, entry.Incr, nil
+	}
+
+	xRefTable.mu.Lock()
+	defer xRefTable.mu.Unlock()
+
+	// Another goroutine may have decoded this object in the meantime.
+	if l, ok := entry.Object.(types.LazyObjectStreamObject); ok {
+		ob, err := l.DecodedObject(context.TODO())
+		if err != nil {
+			return nil, 0, err
+		}
+		ProcessRefCounts(xRefTable, ob)
+		entry.Object = ob
+	}
+
+	return entry.Object, entry.Incr, nil
+}
+
+func dictID(d types.Dict) uintptr {
+	return reflect.ValueOf(d).Pointer()
+}
+
+// cloner deep copies the objects of an xRefTable and keeps track of all cloned dicts
+// so that cached references to table objects may be mapped to their clones.
+type cloner struct {
+	dicts map[uintptr]types.Dict
+}
+
+func (c cloner) streamDict(sd types.StreamDict) types.StreamDict {
+	sd1 := sd.Clone().(types.StreamDict)
+	sd1.Dict = c.object(sd.Dict).(types.Dict)
+	if sd.StreamLength != nil {
+		l := *sd.StreamLength
+		sd1.StreamLength = &l
+	}
+	if sd.StreamLengthObjNr != nil {
+		objNr := *sd.StreamLengthObjNr
+		sd1.StreamLengthObjNr = &objNr
+	}
+	sd1.Raw = bytes.Clone(sd.Raw)
+	sd1.Content = bytes.Clone(sd.Content)
+	return sd1
+}
+
+func (c cloner) object(o types.Object) types.Object {
+	switch o := o.(type) {
+	case nil:
+		return nil
+
+	case types.Dict:
+		d := make(types.Dict, len(o))
+		c.dicts[dictID(o)] = d
+		for k, v := range o {
+			d[k] = c.object(v)
+		}
+		return d
+
+	case types.Array:
+		a := make(types.Array, len(o))
+		for i, v := range o {
+			a[i] = c.object(v)
+		}
+		return a
+
+	case types.StreamDict:
+		return c.streamDict(o)
+
+	case types.ObjectStreamDict:
+		osd := o
+		osd.StreamDict = c.streamDict(o.StreamDict)
+		osd.Prolog = bytes.Clone(o.Prolog)
+		if o.ObjArray != nil {
+			osd.ObjArray = c.object(o.ObjArray).(types.Array)
+		}
+		return osd
+
+	case types.XRefStreamDict:
+		xsd := o
+		xsd.StreamDict = c.streamDict(o.StreamDict)
+		xsd.Objects = slices.Clone(o.Objects)
+		xsd.PreviousOffset = clonePtr(o.PreviousOffset)
+		return xsd
+	}
+
+	return o.Clone()
+}
+
+// dict returns the clone of d if d is a table object, a fresh copy otherwise.
+func (c cloner) dict(d types.Dict) types.Dict {
+	if d == nil {
+		return nil
+	}
+	if d1, ok := c.dicts[dictID(d)]; ok {
+		return d1
+	}
+	return c.object(d).(types.Dict)
+}
+
+func (c cloner) node(n *Node) *Node {
+	if n == nil {
+		return nil
+	}
+	n1 := &Node{Kmin: n.Kmin, Kmax: n.Kmax, D: c.dict(n.D)}
+	for _, k := range n.Kids {
+		n1.Kids = append(n1.Kids, c.node(k))
+	}
+	for _, e := range n.Names {
+		v := e.v
+		if d, ok := v.(types.Dict); ok {
+			v = c.dict(d)
+		} else {
+			v = c.object(v)
+		}
+		n1.Names = append(n1.Names, entry{k: e.k, v: v})
+	}
+	return n1
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneNestedMap[K1, K2 comparable, V any](m map[K1]map[K2]V) map[K1]map[K2]V {
+	if m == nil {
+		return nil
+	}
+	m1 := make(map[K1]map[K2]V, len(m))
+	for k, v := range m {
+		m1[k] = maps.Clone(v)
+	}
+	return m1
+}
+
+func (stats PDFStats) clone() PDFStats {
+	return PDFStats{rootAttrs: maps.Clone(stats.rootAttrs), pageAttrs: maps.Clone(stats.pageAttrs)}
+}
+
+func (xRefTable *XRefTable) clone(conf *Configuration) (*XRefTable, error) {
+	c := cloner{dicts: map[uintptr]types.Dict{}}
+
+	xt := *xRefTable
+	xt.readOnly, xt.mu = false, nil
+	xt.Conf = conf
+
+	var decoded []types.Object
+
+	xt.Table = make(map[int]*XRefTableEntry, len(xRefTable.Table))
+	for objNr, e := range xRefTable.Table {
+		if e == nil {
+			xt.Table[objNr] = nil
+			continue
+		}
+		e1 := copyXRefTableEntry(e)
+		e1.ObjectStream = clonePtr(e.ObjectStream)
+		e1.ObjectStreamInd = clonePtr(e.ObjectStreamInd)
+		if l, ok := e.Object.(types.LazyObjectStreamObject); ok {
+			// Don't share the underlying object stream.
+			o, err := l.DecodedObject(context.TODO())
+			if err != nil {
+				return nil, err
+			}
+			e1.Object = o
+			decoded = append(decoded, o)
+		} else {
+			e1.Object = c.object(e.Object)
+		}
+		xt.Table[objNr] = e1
+	}
+
+	for _, o := range decoded {
+		ProcessRefCounts(&xt, o)
+	}
+
+	xt.Size = clonePtr(xRefTable.Size)
+	xt.Root = clonePtr(xRefTable.Root)
+	xt.RootDict = c.dict(xRefTable.RootDict)
+	xt.Dests = c.dict(xRefTable.Dests)
+	xt.Encrypt = clonePtr(xRefTable.Encrypt)
+	xt.E = clonePtr(xRefTable.E)
+	xt.EncKey = bytes.Clone(xRefTable.EncKey)
+
+	xt.Names = make(map[string]*Node, len(xRefTable.Names))
+	for k, n := range xRefTable.Names {
+		xt.Names[k] = c.node(n)
+	}
+
+	xt.NameRefs = make(map[string]NameMap, len(xRefTable.NameRefs))
+	for k, m := range xRefTable.NameRefs {
+		m1 := NameMap{}
+		for k1, dd := range m {
+			for _, d := range dd {
+				m1.Add(k1, c.dict(d))
+			}
+		}
+		xt.NameRefs[k] = m1
+	}
+
+	xt.HeaderVersion = clonePtr(xRefTable.HeaderVersion)
+	xt.RootVersion = clonePtr(xRefTable.RootVersion)
+	xt.DeclaredVersion = clonePtr(xRefTable.DeclaredVersion)
+
+	if xRefTable.ID != nil {
+		xt.ID = xRefTable.ID.Clone().(types.Array)
+	}
+	xt.Info = clonePtr(xRefTable.Info)
+	xt.KeywordList = maps.Clone(xRefTable.KeywordList)
+	xt.Properties = maps.Clone(xRefTable.Properties)
+	xt.CatalogXMPMeta = clonePtr(xRefTable.CatalogXMPMeta)
+
+	xt.PageLayout = clonePtr(xRefTable.PageLayout)
+	xt.PageMode = clonePtr(xRefTable.PageMode)
+	xt.ViewerPref = clonePtr(xRefTable.ViewerPref)
+
+	xt.OffsetPrimaryHintTable = clonePtr(xRefTable.OffsetPrimaryHintTable)
+	xt.OffsetOverflowHintTable = clonePtr(xRefTable.OffsetOverflowHintTable)
+	xt.LinearizationObjs = maps.Clone(xRefTable.LinearizationObjs)
+
+	xt.PageAnnots = make(map[int]PgAnnots, len(xRefTable.PageAnnots))
+	for pageNr, pgAnnots := range xRefTable.PageAnnots {
+		pgAnnots1 := PgAnnots{}
+		for annType, annots := range pgAnnots {
+			annots1 := Annot{Map: maps.Clone(annots.Map)}
+			if annots.IndRefs != nil {
+				indRefs := slices.Clone(*annots.IndRefs)
+				annots1.IndRefs = &indRefs
+			}
+			pgAnnots1[annType] = annots1
+		}
+		xt.PageAnnots[pageNr] = pgAnnots1
+	}
+
+	xt.PageThumbs = maps.Clone(xRefTable.PageThumbs)
+	xt.Signatures = cloneNestedMap(xRefTable.Signatures)
+	xt.URSignature = c.dict(xRefTable.URSignature)
+	xt.DSS = c.dict(xRefTable.DSS)
+
+	if xRefTable.AdditionalStreams != nil {
+		a := xRefTable.AdditionalStreams.Clone().(types.Array)
+		xt.AdditionalStreams = &a
+	}
+
+	xt.Stats = xRefTable.Stats.clone()
+	xt.Damage = slices.Clone(xRefTable.Damage)
+	xt.Findings = slices.Clone(xRefTable.Findings)
+	xt.URIs = cloneNestedMap(xRefTable.URIs)
+
+	xt.Form = c.dict(xRefTable.Form)
+	xt.Outlines = c.dict(xRefTable.Outlines)
+
+	xt.UsedGIDs = cloneNestedMap(xRefTable.UsedGIDs)
+	xt.FillFonts = maps.Clone(xRefTable.FillFonts)
+
+	return &xt, nil
+}
+
+func (rc *ReadContext) clone() *ReadContext {
+	rc1 := *rc
+	rc1.ObjectStreams = maps.Clone(rc.ObjectStreams)
+	rc1.XRefStreams = maps.Clone(rc.XRefStreams)
+	return &rc1
+}
+
+// Clone returns a deep copy of ctx which may be modified independently of ctx.
+// The clone is never read-only and shares the read seeker of ctx.
+// Clone is safe for concurrent use if ctx is read-only, see XRefTable.SetReadOnly.
+func (ctx *Context) Clone() (*Context, error) {
+	if ctx.readOnly {
+		// Decoding lazy objects modifies their shared object streams.
+		ctx.mu.Lock()
+		defer ctx.mu.Unlock()
+	}
+
+	conf := *ctx.Configuration
+	conf.FindingSeverity = maps.Clone(ctx.FindingSeverity)
+
+	xRefTable, err := ctx.XRefTable.clone(&conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Context{
+		&conf,
+		xRefTable,
+		ctx.Read.clone(),
+		newOptimizationContext(),
+		NewWriteContext(ctx.Write.Eol),
+		false,
+		false,
+	}, nil
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package model
 
 import (
+	"crypto"
+	"crypto/x509"
 	"embed"
 	_ "embed"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,6 +30,7 @@ import (
 
 	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/linebreak"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -160,6 +164,57 @@ const (
 	INSPECTCERTIFICATES
 	IMPORTCERTIFICATES
 	VALIDATESIGNATURES
+	EXTRACTSVG
+	AUDIT
+	PREFLIGHT
+	VISUALDIFF
+	IMPOSE
+	REPAIR
+	SETBACKGROUND
+	REMOVEBACKGROUND
+	EXTRACTPATHS
+	REPLACEIMAGE
+	CONVERTCOLORS
+	INKCOVERAGE
+	LISTCOLORANTS
+	REMAPCOLORANT
+	RENAMECOLORANT
+	GEOINFO
+	SETGEOREFERENCE
+	LISTMEASUREMENTS
+	EXTRACT3D
+	EXTRACTMEDIA
+	ADDVIDEO
+	LISTJAVASCRIPT
+	REMOVEJAVASCRIPT
+	SANITIZE
+	SCRUBMETADATA
+	MANIFEST
+	REORDERPAGES
+	COLLATE
+	DETECTBLANKPAGES
+	REMOVEBLANKPAGES
+	DESKEW
+	ADDTEXTLAYER
+	LISTPIECEINFO
+	SETPIECEINFO
+	REMOVEPIECEINFO
+	LISTASSOCIATEDFILES
+	ADDASSOCIATEDFILES
+	REMOVEASSOCIATEDFILES
+	SETTABORDER
+	SETCALCULATIONORDER
+	SETSIGNATUREAPPEARANCE
+	ADDDOCTIMESTAMP
+	ADDVALIDATIONINFO
+	EXPORTANNOTATIONS
+	IMPORTANNOTATIONS
+	REPAIRTOUNICODE
+	EMBEDFONTS
+	SETVERSION
+	LISTACTIONS
+	SETACTIONS
+	REMOVEACTIONS
 )
 
 // Configuration of a Context.
@@ -180,6 +235,10 @@ type Configuration struct {
 	// Enables decoding of all streams (fontfiles, images..) for logging purposes.
 	DecodeAllStreams bool
 
+	// Truncate streams failing to decode to their longest decodable prefix instead of failing.
+	// Any damage gets recorded in the damage report, see Context.DamageReport.
+	TolerateCorruptStreams bool
+
 	// Validate against ISO-32000: strict or relaxed.
 	ValidationMode int
 
@@ -189,6 +248,12 @@ type Configuration struct {
 	// Check for broken links in LinkedAnnotations/URIActions.
 	ValidateLinks bool
 
+	// Severity overrides for validation findings keyed by finding id, eg. "version": SeverityWarning.
+	FindingSeverity map[string]Severity
+
+	// Validation fails on findings of at least this severity, default: SeverityError.
+	FatalSeverity Severity
+
 	// End of line char sequence for writing.
 	Eol string
 
@@ -227,6 +292,32 @@ type Configuration struct {
 	// Supplied user access permissions, see Table 22.
 	Permissions PermissionFlags // int16
 
+	// Leave XMP metadata streams unencrypted (EncryptMetadata false).
+	// Needs a key length of 128 or 256.
+	PlaintextMetadata bool
+
+	// Encrypt embedded files only using the EFF crypt filter.
+	// Needs a key length of 128 or 256.
+	EncryptEmbeddedFilesOnly bool
+
+	// Recipient certificates for the public-key security handler (Adobe.PubSec).
+	// If present encryption is based on these certificates instead of passwords.
+	EncryptRecipients []*x509.Certificate
+
+	// Certificate and matching private key for decrypting public-key encrypted files.
+	DecryptCert *x509.Certificate
+	DecryptKey  crypto.PrivateKey
+
+	// Cache file encryption keys derived from passwords across files.
+	// Speeds up processing of many files encrypted using the same password, especially for AES-256 (R6).
+	// Derived keys stay in memory until pdfcpu.ClearEncryptionKeyCache.
+	CacheEncryptionKeys bool
+
+	// Optional hook called for each rejected password, eg. for throttling brute-force attempts.
+	// id is the first element of the file identifier.
+	// A non nil error aborts processing and is returned instead of the wrong password error.
+	PasswordRejected func(id []byte) error
+
 	// Command being executed.
 	Cmd CommandMode
 
@@ -246,12 +337,25 @@ type Configuration struct {
 	// TODO add to config.yml
 	OptimizeBeforeWriting bool
 
+	// Free all objects unreachable from the trailer right before writing.
+	GCUnreachable bool
+
+	// Object numbers to be kept despite being unreachable. (assuming GCUnreachable == true)
+	GCKeep []int
+
+	// Renumber objects to a dense range, drop free list chains and minimize xref stream field widths when writing.
+	// Implies GCUnreachable. Object numbers of the context become invalid. Does not apply to incremental writing.
+	CompactXRef bool
+
 	// Optimize page resources via content stream analysis. (assuming Optimize == true || OptimizeBeforeWriting == true)
 	OptimizeResourceDicts bool
 
 	// Optimize duplicate content streams across pages. (assuming Optimize == true || OptimizeBeforeWriting == true)
 	OptimizeDuplicateContentStreams bool
 
+	// Rebuild degenerate page trees into balanced page trees. (assuming Optimize == true || OptimizeBeforeWriting == true)
+	OptimizePageTree bool
+
 	// Merge creates bookmarks.
 	CreateBookmarks bool
 
@@ -273,9 +377,42 @@ type Configuration struct {
 	// Preferred certificate revocation checking mechanism: CRL, OSCP
 	PreferredCertRevocationChecker int
 
+	// Extract images having a /SMask or /Mask as RGBA PNG with the mask applied as alpha channel.
+	ComposeImageMasks bool
+
 	// Limit form field content for display purposes when using pdfcpu form list.
 	// If > 0 affects the columns AltName, Default and Value.
 	FormFieldListMaxColWidth int
+
+	// Optional hook for reporting progress of reading, optimizing, writing and extracting.
+	Progress ProgressFunc
+
+	// Optional structured logger for events like object counts, repairs, optimization savings and validation findings.
+	Logger *slog.Logger
+
+	// Optional sink for counters and timers like objects parsed, streams decoded, bytes written and durations.
+	Metrics Metrics
+
+	// Resource limits for reading untrusted PDF files.
+	Limits Limits
+
+	// Budget in bytes for stream data held in memory while reading, 0 for no limit.
+	// Streams read beyond this budget are moved to a temporary file and loaded on demand.
+	MaxMemory int64
+
+	// Load stream data on demand and copy streams left untouched by processing directly from the input when writing.
+	// The input needs to remain open until writing has finished. Does not apply to encrypted files.
+	ZeroCopyStreams bool
+
+	// Produce byte identical output for identical input and options.
+	// Does not apply to encryption which relies on random keys.
+	Deterministic bool
+
+	// CreationDate and ModDate used in deterministic mode, defaults to the Unix epoch.
+	DeterministicTime time.Time
+
+	// Optional file identifier, see 14.4 File Identifiers.
+	FileID []byte
 }
 
 // ConfigPath defines the location of pdfcpu's configuration directory.
@@ -437,6 +574,13 @@ func EnsureDefaultConfigAt(path string, override bool) error {
 		return err
 	}
 
+	// Initialize pdfcpu config/hyphenation dir for hyphenation patterns as provided by the hyph-utf8 project.
+	// Patterns are loaded into memory lazily.
+	linebreak.PatternDir = filepath.Join(configDir, "hyphenation")
+	if err := os.MkdirAll(linebreak.PatternDir, os.ModePerm); err != nil {
+		return err
+	}
+
 	// Initialize pdfcpu config/cert dir, then extract and install certificates.
 	// Certificates are loaded into memory lazily.
 	CertDir = filepath.Join(configDir, "certs")
@@ -466,6 +610,7 @@ func newDefaultConfiguration() *Configuration {
 		DecodeAllStreams:                false,
 		ValidationMode:                  ValidationRelaxed,
 		ValidateLinks:                   false,
+		FatalSeverity:                   SeverityError,
 		Eol:                             types.EolLF,
 		WriteObjectStream:               true,
 		WriteXRefStream:                 true,
@@ -0,0 +1,329 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var errContentOperandCorrupt = errors.New("pdfcpu: corrupt content stream operand")
+
+// ContentOp represents a content stream operator together with its operands.
+type ContentOp struct {
+	Operator string
+	Operands []types.Object
+	Data     []byte // Raw image data of an inline image (BI), operand 0 holds the image dict.
+}
+
+// Number returns operand i as float64.
+func (op ContentOp) Number(i int) float64 {
+	if i < 0 || i >= len(op.Operands) {
+		return 0
+	}
+	switch v := op.Operands[i].(type) {
+	case types.Integer:
+		return float64(v.Value())
+	case types.Float:
+		return v.Value()
+	}
+	return 0
+}
+
+// Numbers returns all numeric operands.
+func (op ContentOp) Numbers() []float64 {
+	ff := []float64{}
+	for _, o := range op.Operands {
+		switch v := o.(type) {
+		case types.Integer:
+			ff = append(ff, float64(v.Value()))
+		case types.Float:
+			ff = append(ff, v.Value())
+		}
+	}
+	return ff
+}
+
+// Name returns operand i as name.
+func (op ContentOp) Name(i int) string {
+	if i < 0 || i >= len(op.Operands) {
+		return ""
+	}
+	if n, ok := op.Operands[i].(types.Name); ok {
+		return n.Value()
+	}
+	return ""
+}
+
+func contentDelimiter(c byte) bool {
+	return strings.IndexByte("/<([]>%{}", c) >= 0
+}
+
+func contentToken(s string) (string, string) {
+	i := 0
+	for i < len(s) && !whitespaceOrEOL(rune(s[i])) && !contentDelimiter(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func numericToken(t string) bool {
+	if len(t) == 0 {
+		return false
+	}
+	c := t[0]
+	return c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9')
+}
+
+func parseContentNumber(t string) (types.Object, error) {
+	if i, err := strconv.Atoi(t); err == nil {
+		return types.Integer(i), nil
+	}
+	f, err := strconv.ParseFloat(t, 64)
+	if err != nil {
+		// Be tolerant, eg. "--1" or "1.-2"
+		f, err = strconv.ParseFloat(strings.Replace(strings.TrimLeft(t, "+-"), ".-", ".", 1), 64)
+		if err != nil {
+			return nil, errContentOperandCorrupt
+		}
+	}
+	return types.Float(f), nil
+}
+
+// parseContentArray parses arrays like [(a) 120 (b)] or [3 0].
+func parseContentArray(l *string) (types.Array, error) {
+	s := (*l)[1:]
+	a := types.Array{}
+	for {
+		s = strings.TrimLeftFunc(s, whitespaceOrEOL)
+		if len(s) == 0 {
+			return nil, errContentOperandCorrupt
+		}
+		if s[0] == ']' {
+			*l = s[1:]
+			return a, nil
+		}
+		o, err := parseContentOperand(&s)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, o)
+	}
+}
+
+func parseContentOperand(l *string) (types.Object, error) {
+	s := *l
+	switch s[0] {
+	case '[':
+		return parseContentArray(l)
+	case '/', '<', '(':
+		return ParseObject(l)
+	}
+	t, rest := contentToken(s)
+	if len(t) == 0 {
+		return nil, errContentOperandCorrupt
+	}
+	*l = rest
+	switch t {
+	case "true":
+		return types.Boolean(true), nil
+	case "false":
+		return types.Boolean(false), nil
+	case "null":
+		return nil, nil
+	}
+	if !numericToken(t) {
+		return nil, errContentOperandCorrupt
+	}
+	return parseContentNumber(t)
+}
+
+func parseInlineImage(l *string) (types.Dict, []byte, error) {
+	s := *l
+	d := types.NewDict()
+	for {
+		s = strings.TrimLeftFunc(s, whitespaceOrEOL)
+		if len(s) == 0 {
+			return nil, nil, errBIExpressionCorrupt
+		}
+		if strings.HasPrefix(s, "ID") && (len(s) == 2 || whitespaceOrEOL(rune(s[2]))) {
+			break
+		}
+		if s[0] != '/' {
+			return nil, nil, errBIExpressionCorrupt
+		}
+		k, err := parseName(&s)
+		if err != nil {
+			return nil, nil, err
+		}
+		s = strings.TrimLeftFunc(s, whitespaceOrEOL)
+		if len(s) == 0 {
+			return nil, nil, errBIExpressionCorrupt
+		}
+		v, err := parseContentOperand(&s)
+		if err != nil {
+			return nil, nil, err
+		}
+		d[k.Value()] = v
+	}
+
+	// Skip "ID" and the single white space char separating the image data.
+	if len(s) < 3 {
+		return nil, nil, errBIExpressionCorrupt
+	}
+	s = s[3:]
+	i, err := lookupEI(&s)
+	if err != nil {
+		return nil, nil, err
+	}
+	data := []byte(strings.TrimRightFunc(s[:i], whitespaceOrEOL))
+	*l = s[i+2:]
+	return d, data, nil
+}
+
+// ParseContentOps parses content stream s into a sequence of content operations.
+func ParseContentOps(s string) ([]ContentOp, error) {
+	ops := []ContentOp{}
+	operands := []types.Object{}
+	for {
+		s = strings.TrimLeftFunc(s, whitespaceOrEOL)
+		if len(s) == 0 {
+			break
+		}
+		c := s[0]
+		if c == '%' {
+			s, _ = positionToNextEOL(s)
+			continue
+		}
+		if c == '[' || c == '/' || c == '<' || c == '(' {
+			o, err := parseContentOperand(&s)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, o)
+			continue
+		}
+		t, rest := contentToken(s)
+		if len(t) == 0 {
+			// Skip stray delimiters like ']', '>', '{', '}'.
+			s = s[1:]
+			continue
+		}
+		if numericToken(t) || t == "true" || t == "false" || t == "null" {
+			o, err := parseContentOperand(&s)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, o)
+			continue
+		}
+		s = rest
+		if t == "BI" {
+			d, data, err := parseInlineImage(&s)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, ContentOp{Operator: t, Operands: []types.Object{d}, Data: data})
+			operands = []types.Object{}
+			continue
+		}
+		ops = append(ops, ContentOp{Operator: t, Operands: operands})
+		operands = []types.Object{}
+	}
+	return ops, nil
+}
+
+func contentNumber(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.ContainsRune(s, '.') {
+		// Preserve the operand type when parsed again.
+		s += ".0"
+	}
+	return s
+}
+
+func contentOperand(o types.Object) string {
+	switch o := o.(type) {
+	case nil:
+		return "null"
+	case types.Float:
+		return contentNumber(o.Value())
+	case types.Array:
+		ss := make([]string, len(o))
+		for i, o1 := range o {
+			ss[i] = contentOperand(o1)
+		}
+		return "[" + strings.Join(ss, " ") + "]"
+	case types.Dict:
+		var sb strings.Builder
+		sb.WriteString("<<")
+		for _, k := range sortedDictKeys(o) {
+			sb.WriteString(types.Name(k).PDFString() + " " + contentOperand(o[k]))
+		}
+		sb.WriteString(">>")
+		return sb.String()
+	}
+	return o.PDFString()
+}
+
+func sortedDictKeys(d types.Dict) []string {
+	kk := make([]string, 0, len(d))
+	for k := range d {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+	return kk
+}
+
+// PDFString returns op in content stream syntax.
+func (op ContentOp) PDFString() string {
+	var sb strings.Builder
+	if op.Operator == "BI" {
+		sb.WriteString("BI")
+		if len(op.Operands) > 0 {
+			if d, ok := op.Operands[0].(types.Dict); ok {
+				for _, k := range sortedDictKeys(d) {
+					sb.WriteString(" " + types.Name(k).PDFString() + " " + contentOperand(d[k]))
+				}
+			}
+		}
+		sb.WriteString(" ID ")
+		sb.Write(op.Data)
+		sb.WriteString("\nEI")
+		return sb.String()
+	}
+	for _, o := range op.Operands {
+		sb.WriteString(contentOperand(o))
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(op.Operator)
+	return sb.String()
+}
+
+// ContentOpsBytes returns ops serialized as content stream.
+func ContentOpsBytes(ops []ContentOp) []byte {
+	var sb strings.Builder
+	for _, op := range ops {
+		sb.WriteString(op.PDFString())
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String())
+}
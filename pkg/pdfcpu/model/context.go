@@ -65,8 +65,33 @@ func NewContext(rs io.ReadSeeker, conf *Configuration) (*Context, error) {
 }
 
 // ResetWriteContext prepares an existing WriteContext for a new file to be written.
+// Object packing options are retained.
 func (ctx *Context) ResetWriteContext() {
-	ctx.Write = NewWriteContext(ctx.Write.Eol)
+	wc := NewWriteContext(ctx.Write.Eol)
+	wc.XRefMode = ctx.Write.XRefMode
+	wc.ObjStmTypes = ctx.Write.ObjStmTypes
+	wc.ObjStmMaxObjects = ctx.Write.ObjStmMaxObjects
+	ctx.Write = wc
+}
+
+// UseXRefStream returns true if a cross reference stream gets written.
+func (ctx *Context) UseXRefStream() bool {
+	switch ctx.Write.XRefMode {
+	case XRefModeTable:
+		return false
+	case XRefModeStream:
+		return true
+	}
+	return ctx.WriteXRefStream
+}
+
+// UseObjectStream returns true if objects of type t get written into object streams.
+func (ctx *Context) UseObjectStream(t ObjectStreamType) bool {
+	// Object streams assume an xref stream to be generated.
+	if !ctx.UseXRefStream() || !ctx.WriteObjectStream {
+		return false
+	}
+	return ctx.Write.ObjStmTypes == 0 || ctx.Write.ObjStmTypes&t != 0
 }
 
 func (rc *ReadContext) logReadContext(logStr *[]string) {
@@ -189,6 +214,7 @@ type ReadContext struct {
 	BinaryFontSize      int64        // total font stream data (fontfiles)
 	BinaryImageDuplSize int64        // total obsolet image stream data after optimization
 	BinaryFontDuplSize  int64        // total obsolet font stream data after optimization
+	StreamMemory        int64        // stream data held in memory, see Configuration.MaxMemory
 	Linearized          bool         // File is linearized.
 	Hybrid              bool         // File is a hybrid PDF file.
 	UsingObjectStreams  bool         // File is using object streams.
@@ -575,8 +601,8 @@ type WriteContext struct {
 	*bufio.Writer                     // A writer associated with Fp.
 	Fp                  *os.File      // A file pointer needed for detecting FileSize.
 	FileSize            int64         // The size of the written file.
-	DirName             string        // The output directory.
-	FileName            string        // The output file name.
+	FS                  FS            // The output file system used if no writer is supplied, defaults to the working directory.
+	FileName            string        // The output file name within FS.
 	SelectedPages       types.IntSet  // For split, trim and extract.
 	BinaryTotalSize     int64         // total stream data, counts 100% all stream data written.
 	BinaryImageSize     int64         // total image stream data written = Read.BinaryImageSize.
@@ -591,13 +617,73 @@ type WriteContext struct {
 	Increment           bool          // Write context as PDF increment.
 	ObjNrs              []int         // Increment candidate object numbers.
 	OffsetPrevXRef      *int64        // Increment trailer entry "Prev".
+	BytesWritten        int64         // Bytes flushed to the writer set by SetWriter.
+
+	// Object packing options.
+	XRefMode         XRefMode         // Cross reference format, defaults to Configuration.WriteXRefStream.
+	ObjStmTypes      ObjectStreamType // Object types eligible for object streams, 0 means all.
+	ObjStmMaxObjects int              // Max objects per object stream, 0 means ObjectStreamMaxObjects.
+
+	// Result of Configuration.CompactXRef.
+	XRefCompaction *XRefCompaction
+}
+
+// XRefCompaction reports the effect of renumbering objects and compacting the cross reference section.
+type XRefCompaction struct {
+	ObjectsRenumbered  int   // Objects with a new object number.
+	FreeEntriesDropped int   // Free or unused entries removed from the cross reference section.
+	XRefBytesBefore    int64 // Uncompressed cross reference size without compaction.
+	XRefBytesAfter     int64 // Uncompressed cross reference size.
 }
 
+// BytesSaved returns the number of uncompressed cross reference bytes saved.
+func (xc XRefCompaction) BytesSaved() int64 {
+	return xc.XRefBytesBefore - xc.XRefBytesAfter
+}
+
+// XRefMode represents the cross reference format of a written file.
+type XRefMode int
+
+const (
+	XRefModeAuto   XRefMode = iota // Obey Configuration.WriteXRefStream.
+	XRefModeTable                  // Force a classic cross reference table, implies no object streams.
+	XRefModeStream                 // Force a cross reference stream.
+)
+
+// ObjectStreamType is a bit set of object types eligible for object streams.
+type ObjectStreamType int
+
+const (
+	ObjStmDict   ObjectStreamType = 1 << iota // Dicts.
+	ObjStmArray                               // Arrays.
+	ObjStmString                              // String and hex literals.
+	ObjStmScalar                              // Booleans, names and floats.
+
+	ObjStmAll = ObjStmDict | ObjStmArray | ObjStmString | ObjStmScalar
+)
+
 // NewWriteContext returns a new WriteContext.
 func NewWriteContext(eol string) *WriteContext {
 	return &WriteContext{SelectedPages: types.IntSet{}, Table: map[int]int64{}, Eol: eol, ObjNrs: []int{}}
 }
 
+type byteCounter struct {
+	w io.Writer
+	n *int64
+}
+
+func (bc byteCounter) Write(p []byte) (int, error) {
+	n, err := bc.w.Write(p)
+	*bc.n += int64(n)
+	return n, err
+}
+
+// SetWriter sets up buffered writing to w keeping track of BytesWritten.
+func (wc *WriteContext) SetWriter(w io.Writer) {
+	wc.BytesWritten = 0
+	wc.Writer = bufio.NewWriter(byteCounter{w: w, n: &wc.BytesWritten})
+}
+
 // SetWriteOffset saves the current write offset to the PDFDestination.
 func (wc *WriteContext) SetWriteOffset(objNumber int) {
 	wc.Table[objNumber] = wc.Offset
@@ -37,6 +37,10 @@ type Cut struct {
 	Margin   float64            // glue area in display unit
 	BgColor  *color.SimpleColor // background color
 	Origin   types.Corner       // one of 4 page corners, default = UpperLeft
+	Overlap  float64            // tiles overlap their right and bottom neighbors by this glue area
+	CutMarks bool               // true to render cut marks at the tile corners
+	Glue     bool               // true to shade the overlapping glue areas
+	Labels   bool               // true to label tiles on the outline page and on each tile
 }
 
 type cutParameterMap map[string]func(string, *Cut) error
@@ -189,6 +193,46 @@ func parseMarginCut(s string, cut *Cut) error {
 	return nil
 }
 
+func parseOverlapCut(s string, cut *Cut) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+
+	if f < 0 {
+		return errors.New("pdfcpu: cut overlap, Please provide a positive value")
+	}
+
+	cut.Overlap = types.ToUserSpace(f, cut.Unit)
+
+	return nil
+}
+
+func parseOnOffCut(s, param string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		return true, nil
+	case "off", "false", "f":
+		return false, nil
+	}
+	return false, errors.Errorf("pdfcpu: cut %s, please provide one of: on/off true/false t/f", param)
+}
+
+func parseCutMarksCut(s string, cut *Cut) (err error) {
+	cut.CutMarks, err = parseOnOffCut(s, "cutmarks")
+	return err
+}
+
+func parseGlueCut(s string, cut *Cut) (err error) {
+	cut.Glue, err = parseOnOffCut(s, "glue")
+	return err
+}
+
+func parseLabelsCut(s string, cut *Cut) (err error) {
+	cut.Labels, err = parseOnOffCut(s, "labels")
+	return err
+}
+
 var CutParamMap = cutParameterMap{
 	"horizontalCut": parseHorCut,
 	"verticalCut":   parseVertCut,
@@ -199,6 +243,10 @@ var CutParamMap = cutParameterMap{
 	"border":        parseBorderCut,
 	"margin":        parseMarginCut,
 	"bgcolor":       parseBackgroundColorCut,
+	"overlap":       parseOverlapCut,
+	"cutmarks":      parseCutMarksCut,
+	"glue":          parseGlueCut,
+	"labels":        parseLabelsCut,
 }
 
 // Handle applies parameter completion and on success parse parameter values into resize.
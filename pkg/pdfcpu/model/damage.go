@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// StreamDamage describes a stream that failed to decode and got repaired.
+type StreamDamage struct {
+	ObjNr       int    // Object number of the stream.
+	Reason      string // The original decoding error.
+	LengthFixed bool   // Stream data has been reread up to "endstream" ignoring a wrong "Length".
+	RawLength   int    // Length of the encoded stream data found.
+	ValidLength int    // Length of the encoded stream data prefix that could be decoded.
+	Decoded     int    // Length of the decoded stream data kept.
+}
+
+func (sd StreamDamage) String() string {
+	if sd.LengthFixed && sd.ValidLength == sd.RawLength {
+		return fmt.Sprintf("obj#%d: fixed stream length: %s", sd.ObjNr, sd.Reason)
+	}
+	return fmt.Sprintf("obj#%d: truncated to %d of %d bytes (%d bytes decoded): %s",
+		sd.ObjNr, sd.ValidLength, sd.RawLength, sd.Decoded, sd.Reason)
+}
+
+// AddDamage records a repaired stream.
+func (xRefTable *XRefTable) AddDamage(sd StreamDamage) {
+	xRefTable.Damage = append(xRefTable.Damage, sd)
+	ShowRepaired(fmt.Sprintf("stream obj#%d", sd.ObjNr))
+	xRefTable.Conf.LogEvent(slog.LevelWarn, "pdfcpu: repaired stream",
+		"obj", sd.ObjNr, "reason", sd.Reason, "lengthFixed", sd.LengthFixed, "rawLength", sd.RawLength, "validLength", sd.ValidLength)
+}
+
+// DamageReport returns all streams which failed to decode and got repaired while reading, sorted by object number.
+func (ctx *Context) DamageReport() []StreamDamage {
+	dd := make([]StreamDamage, len(ctx.Damage))
+	copy(dd, ctx.Damage)
+	sort.Slice(dd, func(i, j int) bool { return dd[i].ObjNr < dd[j].ObjNr })
+	return dd
+}
@@ -74,6 +74,10 @@ func (xRefTable *XRefTable) indRefToObject(ir *types.IndirectRef, decodeLazy boo
 		return nil, 0, nil
 	}
 
+	if xRefTable.readOnly {
+		return xRefTable.syncedEntryObject(entry, decodeLazy)
+	}
+
 	xRefTable.CurObj = int(ir.ObjectNumber)
 
 	if l, ok := entry.Object.(types.LazyObjectStreamObject); ok && decodeLazy {
@@ -441,10 +445,12 @@ func (xRefTable *XRefTable) dereferenceDestArray(o types.Object) (types.Array, e
 
 // DereferenceDestArray resolves the destination for key.
 func (xRefTable *XRefTable) DereferenceDestArray(key string) (types.Array, error) {
-	if dNames := xRefTable.Names["Dests"]; dNames != nil {
-		if o, ok := dNames.Value(key); ok {
-			return xRefTable.dereferenceDestArray(o)
-		}
+	o, ok, err := xRefTable.NameTree(NameTreeDests).Lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return xRefTable.dereferenceDestArray(o)
 	}
 
 	if o, ok := xRefTable.Dests[key]; ok {
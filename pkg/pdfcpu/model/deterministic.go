@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// IsDeterministic returns true if c asks for reproducible output.
+func (c *Configuration) IsDeterministic() bool {
+	return c != nil && c.Deterministic
+}
+
+// Now returns the current time or the fixed time used in deterministic mode.
+func (c *Configuration) Now() time.Time {
+	if !c.IsDeterministic() {
+		return time.Now()
+	}
+	if c.DeterministicTime.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+	return c.DeterministicTime
+}
+
+// DictKeys returns the keys of d, sorted in deterministic mode.
+func (c *Configuration) DictKeys(d types.Dict) []string {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	if c.IsDeterministic() {
+		sort.Strings(keys)
+	}
+	return keys
+}
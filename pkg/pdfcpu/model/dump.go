@@ -0,0 +1,483 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// The JSON dump uses the following object encoding:
+//
+//	null, true, false       PDF null and booleans
+//	123                     Integer
+//	1.5, 2.0                Float (always carries a decimal point)
+//	"/Name"                 Name
+//	"(text)"                StringLiteral (escaped as in PDF syntax)
+//	"<0A1B>"                HexLiteral
+//	"12 0 R"                IndirectRef
+//	[...]                   Array
+//	{...}                   Dict (keys without leading slash)
+
+var indRefRE = regexp.MustCompile(`^(\d+) (\d+) R$`)
+
+// JSONFilter represents an element of a stream's filter pipeline.
+type JSONFilter struct {
+	Name        string `json:"name"`
+	DecodeParms any    `json:"decodeParms,omitempty"`
+}
+
+// JSONStream represents the stream part of a stream dict.
+type JSONStream struct {
+	Filters []JSONFilter `json:"filters,omitempty"`
+	Length  int          `json:"length"`
+	Data    string       `json:"data,omitempty"` // base64 encoded raw (encoded) stream data
+}
+
+// JSONObject represents an entry of the cross reference table.
+type JSONObject struct {
+	ObjNr  int         `json:"objNr"`
+	GenNr  int         `json:"genNr"`
+	Free   bool        `json:"free,omitempty"`
+	Value  any         `json:"value,omitempty"`
+	Stream *JSONStream `json:"stream,omitempty"`
+}
+
+// JSONDump represents the object graph of a PDF file.
+type JSONDump struct {
+	Header  string         `json:"header"`
+	Trailer map[string]any `json:"trailer"`
+	Objects []JSONObject   `json:"objects"`
+}
+
+func jsonFloat(f float64) json.Number {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return json.Number(s)
+}
+
+// JSONValue returns the JSON representation of o.
+func JSONValue(o types.Object) any {
+	switch o := o.(type) {
+	case nil:
+		return nil
+	case types.Boolean:
+		return o.Value()
+	case types.Integer:
+		return o.Value()
+	case types.Float:
+		return jsonFloat(o.Value())
+	case types.Name:
+		return "/" + o.Value()
+	case types.StringLiteral:
+		return "(" + o.Value() + ")"
+	case types.HexLiteral:
+		return "<" + o.Value() + ">"
+	case types.IndirectRef:
+		return o.PDFString()
+	case types.Array:
+		a := make([]any, len(o))
+		for i, v := range o {
+			a[i] = JSONValue(v)
+		}
+		return a
+	case types.Dict:
+		m := map[string]any{}
+		for k, v := range o {
+			m[k] = JSONValue(v)
+		}
+		return m
+	case types.StreamDict:
+		return JSONValue(o.Dict)
+	case types.ObjectStreamDict:
+		return JSONValue(o.Dict)
+	case types.XRefStreamDict:
+		return JSONValue(o.Dict)
+	}
+	return nil
+}
+
+// ObjectForJSONValue returns the PDF object for a JSON value as produced by JSONValue.
+func ObjectForJSONValue(v any) (types.Object, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return types.Boolean(v), nil
+	case json.Number:
+		s := v.String()
+		if !strings.ContainsAny(s, ".eE") {
+			i, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, err
+			}
+			return types.Integer(i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return types.Float(f), nil
+	case float64:
+		if v == float64(int(v)) {
+			return types.Integer(int(v)), nil
+		}
+		return types.Float(v), nil
+	case string:
+		return objectForJSONString(v)
+	case []any:
+		a := make(types.Array, len(v))
+		for i, e := range v {
+			o, err := ObjectForJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = o
+		}
+		return a, nil
+	case map[string]any:
+		d := types.NewDict()
+		for k, e := range v {
+			o, err := ObjectForJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			d[k] = o
+		}
+		return d, nil
+	}
+	return nil, errors.Errorf("pdfcpu: unexpected JSON value: %v", v)
+}
+
+func objectForJSONString(s string) (types.Object, error) {
+	switch {
+	case strings.HasPrefix(s, "/"):
+		return types.Name(s[1:]), nil
+	case strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"):
+		return types.StringLiteral(s[1 : len(s)-1]), nil
+	case strings.HasPrefix(s, "<") && strings.HasSuffix(s, ">"):
+		return types.HexLiteral(s[1 : len(s)-1]), nil
+	}
+	if m := indRefRE.FindStringSubmatch(s); m != nil {
+		objNr, _ := strconv.Atoi(m[1])
+		genNr, _ := strconv.Atoi(m[2])
+		return *types.NewIndirectRef(objNr, genNr), nil
+	}
+	return nil, errors.Errorf("pdfcpu: unexpected JSON string: %s", s)
+}
+
+func jsonStream(sd types.StreamDict, withData bool) *JSONStream {
+	js := &JSONStream{Length: len(sd.Raw)}
+	for _, f := range sd.FilterPipeline {
+		jf := JSONFilter{Name: f.Name}
+		if f.DecodeParms != nil {
+			jf.DecodeParms = JSONValue(f.DecodeParms)
+		}
+		js.Filters = append(js.Filters, jf)
+	}
+	if withData {
+		js.Data = base64.StdEncoding.EncodeToString(sd.Raw)
+	}
+	return js
+}
+
+func (xRefTable *XRefTable) jsonObject(objNr int, entry *XRefTableEntry, withData bool) (JSONObject, error) {
+	jo := JSONObject{ObjNr: objNr}
+	if entry.Generation != nil {
+		jo.GenNr = *entry.Generation
+	}
+	if entry.Free {
+		jo.Free = true
+		return jo, nil
+	}
+
+	o, err := xRefTable.Dereference(*types.NewIndirectRef(objNr, jo.GenNr))
+	if err != nil {
+		return jo, err
+	}
+
+	err = jo.setValue(o, withData)
+
+	return jo, err
+}
+
+func (jo *JSONObject) setValue(o types.Object, withData bool) error {
+	jo.Value = JSONValue(o)
+
+	switch sd := o.(type) {
+	case types.StreamDict:
+		if err := sd.Load(); err != nil {
+			return err
+		}
+		jo.Stream = jsonStream(sd, withData)
+	case types.ObjectStreamDict:
+		jo.Stream = jsonStream(sd.StreamDict, withData)
+	case types.XRefStreamDict:
+		jo.Stream = jsonStream(sd.StreamDict, withData)
+	}
+
+	return nil
+}
+
+// NewJSONObject returns the JSON representation of o as object objNr including any stream data.
+func NewJSONObject(objNr int, o types.Object) (JSONObject, error) {
+	jo := JSONObject{ObjNr: objNr}
+	err := jo.setValue(o, true)
+
+	return jo, err
+}
+
+// DumpJSON writes the object graph of ctx as JSON to w.
+// withData includes base64 encoded raw stream data.
+func (ctx *Context) DumpJSON(w io.Writer, withData bool) error {
+	jd := JSONDump{Trailer: map[string]any{}}
+
+	if ctx.HeaderVersion != nil {
+		jd.Header = ctx.HeaderVersion.String()
+	}
+
+	if ctx.Root != nil {
+		jd.Trailer["Root"] = JSONValue(*ctx.Root)
+	}
+	if ctx.Info != nil {
+		jd.Trailer["Info"] = JSONValue(*ctx.Info)
+	}
+	if ctx.Encrypt != nil {
+		jd.Trailer["Encrypt"] = JSONValue(*ctx.Encrypt)
+	}
+	if ctx.ID != nil {
+		jd.Trailer["ID"] = JSONValue(ctx.ID)
+	}
+	if ctx.Size != nil {
+		jd.Trailer["Size"] = *ctx.Size
+	}
+
+	objNrs := make([]int, 0, len(ctx.Table))
+	for objNr := range ctx.Table {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	for _, objNr := range objNrs {
+		jo, err := ctx.jsonObject(objNr, ctx.Table[objNr], withData)
+		if err != nil {
+			return err
+		}
+		jd.Objects = append(jd.Objects, jo)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jd)
+}
+
+func filterPipelineForJSON(jff []JSONFilter) ([]types.PDFFilter, error) {
+	var fpl []types.PDFFilter
+	for _, jf := range jff {
+		f := types.PDFFilter{Name: jf.Name}
+		if jf.DecodeParms != nil {
+			o, err := ObjectForJSONValue(jf.DecodeParms)
+			if err != nil {
+				return nil, err
+			}
+			d, ok := o.(types.Dict)
+			if !ok {
+				return nil, errors.Errorf("pdfcpu: corrupt decodeParms for filter %s", jf.Name)
+			}
+			f.DecodeParms = d
+		}
+		fpl = append(fpl, f)
+	}
+	return fpl, nil
+}
+
+// ObjectForJSONObject returns the PDF object for jo as produced by NewJSONObject.
+func ObjectForJSONObject(jo JSONObject) (types.Object, error) {
+	o, err := ObjectForJSONValue(jo.Value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "pdfcpu: obj#%d", jo.ObjNr)
+	}
+	if jo.Stream == nil {
+		return o, nil
+	}
+
+	d, ok := o.(types.Dict)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: obj#%d: stream without dict", jo.ObjNr)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(jo.Stream.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "pdfcpu: obj#%d", jo.ObjNr)
+	}
+
+	fpl, err := filterPipelineForJSON(jo.Stream.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	l := int64(len(raw))
+	d["Length"] = types.Integer(l)
+	sd := types.NewStreamDict(d, 0, &l, nil, fpl)
+	sd.Raw = raw
+
+	return sd, nil
+}
+
+func (xRefTable *XRefTable) bindJSONTrailer(trailer map[string]any) error {
+	for k, v := range trailer {
+		o, err := ObjectForJSONValue(v)
+		if err != nil {
+			return err
+		}
+		switch k {
+		case "Root", "Info", "Encrypt":
+			ir, ok := o.(types.IndirectRef)
+			if !ok {
+				return errors.Errorf("pdfcpu: trailer: %s must be an indirect reference", k)
+			}
+			switch k {
+			case "Root":
+				xRefTable.Root = &ir
+			case "Info":
+				xRefTable.Info = &ir
+			case "Encrypt":
+				xRefTable.Encrypt = &ir
+			}
+		case "ID":
+			a, ok := o.(types.Array)
+			if !ok {
+				return errors.New("pdfcpu: trailer: ID must be an array")
+			}
+			xRefTable.ID = a
+		}
+	}
+	if xRefTable.Root == nil {
+		return errors.New("pdfcpu: trailer: missing Root")
+	}
+	return nil
+}
+
+func (xRefTable *XRefTable) insertJSONObject(jo JSONObject) error {
+	genNr := jo.GenNr
+	var off int64
+
+	if jo.Free || jo.ObjNr == 0 {
+		entry := &XRefTableEntry{Free: true, Generation: &genNr, Offset: &off}
+		if jo.ObjNr == 0 {
+			entry = NewFreeHeadXRefTableEntry()
+		}
+		xRefTable.Table[jo.ObjNr] = entry
+		return nil
+	}
+
+	o, err := ObjectForJSONObject(jo)
+	if err != nil {
+		return err
+	}
+
+	// Object streams and xref streams get regenerated on write.
+	if sd, ok := o.(types.StreamDict); ok {
+		if t := sd.Type(); t != nil && (*t == "ObjStm" || *t == "XRef") {
+			xRefTable.Table[jo.ObjNr] = &XRefTableEntry{Free: true, Generation: &genNr, Offset: &off}
+			return nil
+		}
+	}
+
+	xRefTable.Table[jo.ObjNr] = &XRefTableEntry{Generation: &genNr, Object: o}
+	return nil
+}
+
+// ContextFromJSON builds a Context from a JSON dump as written by Context.DumpJSON.
+// Stream data is only available if the dump was written including stream data.
+func ContextFromJSON(r io.Reader, conf *Configuration) (*Context, error) {
+	if conf == nil {
+		conf = NewDefaultConfiguration()
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var jd JSONDump
+	if err := dec.Decode(&jd); err != nil {
+		return nil, err
+	}
+
+	xRefTable := newXRefTable(conf)
+	xRefTable.Table[0] = NewFreeHeadXRefTableEntry()
+
+	v := V17
+	if jd.Header != "" {
+		var err error
+		if v, err = PDFVersion(jd.Header); err != nil {
+			return nil, err
+		}
+	}
+	xRefTable.HeaderVersion = &v
+
+	maxObjNr := 0
+	for _, jo := range jd.Objects {
+		if err := xRefTable.insertJSONObject(jo); err != nil {
+			return nil, err
+		}
+		if jo.ObjNr > maxObjNr {
+			maxObjNr = jo.ObjNr
+		}
+	}
+
+	size := maxObjNr + 1
+	xRefTable.Size = &size
+	xRefTable.MaxObjNr = maxObjNr
+
+	if err := xRefTable.bindJSONTrailer(jd.Trailer); err != nil {
+		return nil, err
+	}
+
+	if err := xRefTable.EnsureValidFreeList(); err != nil {
+		return nil, err
+	}
+
+	if err := xRefTable.EnsurePageCount(); err != nil {
+		return nil, err
+	}
+
+	rdCtx, err := newReadContext(bytes.NewReader(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := &Context{
+		Configuration: conf,
+		XRefTable:     xRefTable,
+		Read:          rdCtx,
+		Optimize:      newOptimizationContext(),
+		Write:         NewWriteContext(conf.Eol),
+	}
+
+	return ctx, nil
+}
@@ -120,6 +120,13 @@ func EqualStreamDicts(sd1, sd2 *types.StreamDict, xRefTable *XRefTable) (bool, e
 		return false, nil
 	}
 
+	if err := sd1.Load(); err != nil {
+		return false, err
+	}
+	if err := sd2.Load(); err != nil {
+		return false, err
+	}
+
 	if sd1.Raw == nil || sd2 == nil {
 		return false, errors.New("pdfcpu: EqualStreamDicts: stream dict not loaded")
 	}
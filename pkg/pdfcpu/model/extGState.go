@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// SoftMask describes a soft mask dict (11.6.5.2).
+type SoftMask struct {
+	Luminosity bool              // Derive the mask from the luminosity instead of the alpha of Group.
+	Group      types.IndirectRef // Transparency group XObject.
+	Backdrop   []float64         // Backdrop color for luminosity masks.
+}
+
+// ExtGState describes frequently used entries of a graphics state parameter dict (8.4.5).
+type ExtGState struct {
+	FillOpacity   *float64  // ca
+	StrokeOpacity *float64  // CA
+	BlendMode     string    // BM eg. Multiply
+	LineWidth     *float64  // LW
+	Dash          []float64 // D dash array, an empty non nil slice represents a solid line.
+	DashPhase     float64   // D dash phase
+	SoftMask      *SoftMask // SMask
+	NoSoftMask    bool      // SMask None, clears any soft mask in effect.
+}
+
+// Opacity returns a graphics state for fill and stroke opacity.
+func Opacity(fill, stroke float64) ExtGState {
+	return ExtGState{FillOpacity: &fill, StrokeOpacity: &stroke}
+}
+
+// Dict returns the graphics state parameter dict for gs.
+func (gs ExtGState) Dict() types.Dict {
+	d := types.Dict{"Type": types.Name("ExtGState")}
+
+	if gs.FillOpacity != nil {
+		d["ca"] = types.Float(*gs.FillOpacity)
+	}
+	if gs.StrokeOpacity != nil {
+		d["CA"] = types.Float(*gs.StrokeOpacity)
+	}
+	if gs.BlendMode != "" {
+		d["BM"] = types.Name(gs.BlendMode)
+	}
+	if gs.LineWidth != nil {
+		d["LW"] = types.Float(*gs.LineWidth)
+	}
+	if gs.Dash != nil {
+		d["D"] = types.Array{types.NewNumberArray(gs.Dash...), types.Float(gs.DashPhase)}
+	}
+
+	if gs.NoSoftMask {
+		d["SMask"] = types.Name("None")
+	} else if sm := gs.SoftMask; sm != nil {
+		s := "Alpha"
+		if sm.Luminosity {
+			s = "Luminosity"
+		}
+		smd := types.Dict{"Type": types.Name("Mask"), "S": types.Name(s), "G": sm.Group}
+		if sm.Luminosity && len(sm.Backdrop) > 0 {
+			smd["BC"] = types.NewNumberArray(sm.Backdrop...)
+		}
+		d["SMask"] = smd
+	}
+
+	return d
+}
+
+func extGStateHash(d types.Dict) string {
+	d1 := d.Clone().(types.Dict)
+	d1["Type"] = types.Name("ExtGState")
+	h := sha256.Sum256([]byte(d1.PDFString()))
+	return hex.EncodeToString(h[:])
+}
+
+// seedExtGStates registers all graphics state parameter dicts of xRefTable which are identified by their Type.
+func (xRefTable *XRefTable) seedExtGStates() {
+	xRefTable.ExtGStates = map[string]types.IndirectRef{}
+	for objNr, e := range xRefTable.Table {
+		if e == nil || e.Free || e.Object == nil {
+			continue
+		}
+		d, ok := e.Object.(types.Dict)
+		if !ok || d.Type() == nil || *d.Type() != "ExtGState" {
+			continue
+		}
+		k := extGStateHash(d)
+		if ir, ok := xRefTable.ExtGStates[k]; ok && ir.ObjectNumber.Value() < objNr {
+			continue
+		}
+		xRefTable.ExtGStates[k] = *types.NewIndirectRef(objNr, *e.Generation)
+	}
+}
+
+// EnsureExtGState returns an indirect reference to a graphics state parameter dict equal to d.
+// Equal dicts are created once only and get shared, which also applies to equal dicts already present.
+func (xRefTable *XRefTable) EnsureExtGState(d types.Dict) (*types.IndirectRef, error) {
+	if d == nil {
+		return nil, errors.New("pdfcpu: EnsureExtGState: missing graphics state parameter dict")
+	}
+
+	if xRefTable.ExtGStates == nil {
+		xRefTable.seedExtGStates()
+	}
+
+	k := extGStateHash(d)
+
+	if ir, ok := xRefTable.ExtGStates[k]; ok {
+		// Make sure the cached dict has not been modified or freed since.
+		d1, err := xRefTable.DereferenceDict(ir)
+		if err == nil && d1 != nil && extGStateHash(d1) == k {
+			return &ir, nil
+		}
+	}
+
+	d = d.Clone().(types.Dict)
+	d["Type"] = types.Name("ExtGState")
+
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return nil, err
+	}
+
+	xRefTable.ExtGStates[k] = *ir
+
+	return ir, nil
+}
+
+// EnsureExtGStateFor returns an indirect reference to a shared graphics state parameter dict for gs.
+func (xRefTable *XRefTable) EnsureExtGStateFor(gs ExtGState) (*types.IndirectRef, error) {
+	return xRefTable.EnsureExtGState(gs.Dict())
+}
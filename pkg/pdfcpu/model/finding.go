@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Severity represents the severity of a validation finding.
+type Severity int
+
+// The zero value denotes the default severity of a finding.
+const (
+	SeverityInfo Severity = iota + 1
+	SeverityWarning
+	SeverityError
+)
+
+var severityStr = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+func (s Severity) String() string {
+	return severityStr[s]
+}
+
+// MarshalJSON encodes s as its name.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// ParseSeverity returns the severity for s.
+func ParseSeverity(s string) (Severity, error) {
+	for k, v := range severityStr {
+		if v == s {
+			return k, nil
+		}
+	}
+	return 0, errors.Errorf("pdfcpu: invalid severity: %s", s)
+}
+
+// Validation finding ids.
+const (
+	FindingInvalid                = "invalid"                // Validation failed.
+	FindingVersion                = "version"                // Feature not supported by the PDF version of the document.
+	FindingBrokenLink             = "brokenLink"             // URI not reachable, see Configuration.ValidateLinks.
+	FindingUnresolvedLinkDest     = "unresolvedLinkDest"     // Link annotation with unresolved destination.
+	FindingUnresolvedOutlineDest  = "unresolvedOutlineDest"  // Outline item with unresolved destination.
+	FindingFontName               = "fontName"               // Font descriptor with invalid "FontName" or "FontFamily".
+	FindingFontType               = "fontType"               // Font dict with missing or invalid "Type".
+	FindingCharProcs              = "charProcs"              // Type3 font with invalid "CharProcs".
+	FindingInfoModDate            = "infoModDate"            // Info dict missing "ModDate" required by "PieceInfo".
+	FindingNumberTree             = "numberTree"             // Corrupt number tree.
+	FindingViewerPreferencesArray = "viewerPreferencesArray" // Viewer preferences array instead of dict.
+	FindingDeprecatedEncryption   = "deprecatedEncryption"   // PDF 2.0 document using a deprecated encryption.
+	FindingEncryptedPayload       = "encryptedPayload"       // PDF 2.0 unencrypted wrapper document with invalid encrypted payload.
+	FindingCalculationOrder       = "calculationOrder"       // Form calculation order referring to an unknown field.
+	FindingPageTree               = "pageTree"               // Degenerate page tree slowing down page access.
+	FindingCatalog                = "catalog"                // Invalid document catalog entry.
+	FindingPages                  = "pages"                  // Invalid page tree node or page dict.
+	FindingResources              = "resources"              // Invalid resource dict.
+	FindingAnnotation             = "annotation"             // Invalid annotation.
+	FindingAction                 = "action"                 // Invalid action.
+	FindingDestination            = "destination"            // Invalid destination.
+	FindingColorSpace             = "colorSpace"             // Invalid color space.
+	FindingExtGState              = "extGState"              // Invalid graphics state parameter dict.
+	FindingFileSpec               = "fileSpec"               // Invalid file specification.
+	FindingFont                   = "font"                   // Invalid font dict or font descriptor.
+	FindingForm                   = "form"                   // Invalid form or form field.
+	FindingInfo                   = "info"                   // Invalid document information dict.
+	FindingMetadata               = "metadata"               // Invalid metadata stream.
+	FindingNameTree               = "nameTree"               // Corrupt name tree.
+	FindingPageLabels             = "pageLabels"             // Invalid page labels.
+	FindingOutline                = "outline"                // Corrupt outline tree.
+	FindingThreads                = "threads"                // Invalid article threads.
+	FindingStructTree             = "structTree"             // Invalid structure tree.
+	FindingOptionalContent        = "optionalContent"        // Invalid optional content properties.
+	FindingViewerPreferences      = "viewerPreferences"      // Invalid viewer preferences.
+	FindingExtensions             = "extensions"             // Invalid developer extensions.
+	FindingOutputIntents          = "outputIntents"          // Invalid output intents.
+	FindingPieceInfo              = "pieceInfo"              // Invalid page-piece dict.
+	FindingPermissions            = "permissions"            // Invalid permissions or legal attestation.
+	FindingCollection             = "collection"             // Invalid collection dict.
+	FindingSignature              = "signature"              // Invalid document security store.
+	FindingAssociatedFiles        = "associatedFiles"        // Invalid associated files.
+	FindingXObject                = "xObject"                // Invalid XObject.
+)
+
+// Finding represents a single validation finding.
+type Finding struct {
+	ID       string   `json:"id"`
+	Severity Severity `json:"severity"`
+	ObjNr    int      `json:"obj,omitempty"`
+	Message  string   `json:"message"`
+	Fatal    bool     `json:"fatal,omitempty"` // This finding failed validation.
+}
+
+func (f Finding) String() string {
+	s := fmt.Sprintf("%s %s: %s", f.Severity, f.ID, f.Message)
+	if f.ObjNr > 0 {
+		s = fmt.Sprintf("%s (obj#%d)", s, f.ObjNr)
+	}
+	return s
+}
+
+func (xRefTable *XRefTable) fatalSeverity() Severity {
+	if xRefTable.Conf == nil || xRefTable.Conf.FatalSeverity == 0 {
+		return SeverityError
+	}
+	return xRefTable.Conf.FatalSeverity
+}
+
+// ReportFinding records f.
+// f.Severity serves as default and may be overridden by Configuration.FindingSeverity.
+// If the resulting severity is fatal, see Configuration.FatalSeverity, ReportFinding returns err or,
+// if err is nil, an error carrying the finding message.
+func (xRefTable *XRefTable) ReportFinding(f Finding, err error) error {
+	if xRefTable.Conf != nil {
+		if sev, ok := xRefTable.Conf.FindingSeverity[f.ID]; ok {
+			f.Severity = sev
+		}
+	}
+	f.Fatal = f.Severity >= xRefTable.fatalSeverity()
+	xRefTable.Conf.LogFinding(f)
+	if !xRefTable.readOnly {
+		xRefTable.Findings = append(xRefTable.Findings, f)
+	}
+
+	if !f.Fatal {
+		ShowDigestedSpecViolation(f.Message)
+		return nil
+	}
+
+	if err == nil {
+		err = errors.New("pdfcpu: " + f.Message)
+	}
+
+	return err
+}
+
+// ReportSpecViolation records a spec violation for the current object.
+// Spec violations are errors in strict validation mode and warnings in relaxed validation mode.
+func (xRefTable *XRefTable) ReportSpecViolation(id string, err error, msg string) error {
+	sev := SeverityWarning
+	if xRefTable.ValidationMode == ValidationStrict {
+		sev = SeverityError
+	}
+	return xRefTable.ReportFinding(Finding{ID: id, Severity: sev, ObjNr: xRefTable.CurObj, Message: msg}, err)
+}
+
+// ReportInvalid records err as fatal finding id and returns it.
+// Errors already recorded as fatal finding, eg. by ReportSpecViolation, are not recorded twice.
+func (xRefTable *XRefTable) ReportInvalid(id string, err error) error {
+	if err == nil || xRefTable.HasFatalFinding() {
+		return err
+	}
+	f := Finding{ID: id, Severity: SeverityError, ObjNr: xRefTable.CurObj, Message: err.Error(), Fatal: true}
+	xRefTable.Conf.LogFinding(f)
+	if !xRefTable.readOnly {
+		xRefTable.Findings = append(xRefTable.Findings, f)
+	}
+	return err
+}
+
+// HasFatalFinding returns true if validation failed because of a recorded finding.
+func (xRefTable *XRefTable) HasFatalFinding() bool {
+	for _, f := range xRefTable.Findings {
+		if f.Fatal {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// FS is a write target for PDF files like a local directory, memory, a zip archive or cloud storage.
+// A FS for eg. S3 would start a multipart upload in Create and complete it on Close.
+type FS interface {
+	// Create creates or truncates the named file.
+	// The file is complete once it has been closed.
+	Create(name string) (io.WriteCloser, error)
+}
+
+type dirFS string
+
+// DirFS returns a FS for the local directory dir.
+func DirFS(dir string) FS {
+	return dirFS(dir)
+}
+
+func (dir dirFS) Create(name string) (io.WriteCloser, error) {
+	return os.Create(filepath.Join(string(dir), name))
+}
+
+// MemFS is an in-memory FS safe for concurrent use.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemFS returns a new empty MemFS.
+func NewMemFS() *MemFS {
+	return &MemFS{files: map[string][]byte{}}
+}
+
+type memFile struct {
+	bytes.Buffer
+	fs   *MemFS
+	name string
+}
+
+func (f *memFile) Close() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	f.fs.files[f.name] = f.Bytes()
+	return nil
+}
+
+// Create returns a file which gets stored under name when closed.
+func (fs *MemFS) Create(name string) (io.WriteCloser, error) {
+	return &memFile{fs: fs, name: name}, nil
+}
+
+// ReadFile returns the content of the named file.
+func (fs *MemFS) ReadFile(name string) ([]byte, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	bb, ok := fs.files[name]
+	if !ok {
+		return nil, errors.Wrap(os.ErrNotExist, name)
+	}
+	return bb, nil
+}
+
+// Names returns the sorted names of all files in fs.
+func (fs *MemFS) Names() []string {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	ss := make([]string, 0, len(fs.files))
+	for k := range fs.files {
+		ss = append(ss, k)
+	}
+	sort.Strings(ss)
+	return ss
+}
+
+// ZipFS is a FS writing files as entries of a zip archive.
+// Files need to be written one after another.
+type ZipFS struct {
+	zw   *zip.Writer
+	open bool
+}
+
+// NewZipFS returns a ZipFS writing a zip archive to w.
+// Call Close in order to complete the archive.
+func NewZipFS(w io.Writer) *ZipFS {
+	return &ZipFS{zw: zip.NewWriter(w)}
+}
+
+type zipFile struct {
+	io.Writer
+	fs *ZipFS
+}
+
+func (f *zipFile) Close() error {
+	f.fs.open = false
+	return nil
+}
+
+// Create adds the named entry to the zip archive.
+func (fs *ZipFS) Create(name string) (io.WriteCloser, error) {
+	if fs.open {
+		return nil, errors.Errorf("pdfcpu: ZipFS: can't create %s while another file is open", name)
+	}
+	w, err := fs.zw.Create(filepath.ToSlash(name))
+	if err != nil {
+		return nil, err
+	}
+	fs.open = true
+	return &zipFile{Writer: w, fs: fs}, nil
+}
+
+// Close completes the zip archive.
+func (fs *ZipFS) Close() error {
+	return fs.zw.Close()
+}
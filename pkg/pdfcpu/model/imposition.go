@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// Imposition schemes.
+const (
+	ImposeCutStack  = "cutStack"  // Cut the printed stack, then stack the piles.
+	ImposeSignature = "signature" // Folded and sewn signatures of 4 pages per sheet.
+	ImposeCustom    = "custom"    // Slot layout defined by Imposition.Slots.
+)
+
+// ImpositionSlot is a page position on a sheet side of a custom layout.
+type ImpositionSlot struct {
+	Page   int     `json:"page"`           // 1-based index of the page within the sheet.
+	Back   bool    `json:"back,omitempty"` // Slot is on the back side of the sheet.
+	X      float64 `json:"x"`              // Lower left corner in points.
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Rotate int     `json:"rotate,omitempty"` // 0 or 180 eg. for head to head layouts.
+}
+
+// Imposition represents the command details for the command "Impose".
+// All lengths are in points.
+type Imposition struct {
+	Name              string           `json:"name,omitempty"`
+	Scheme            string           `json:"scheme"`
+	PaperSize         string           `json:"paperSize,omitempty"` // Sheet size eg. A3L, see paperSize.go
+	Width             float64          `json:"width,omitempty"`     // Sheet dimensions overriding PaperSize.
+	Height            float64          `json:"height,omitempty"`
+	Cols              int              `json:"cols,omitempty"` // Grid for cutStack.
+	Rows              int              `json:"rows,omitempty"`
+	Duplex            bool             `json:"duplex,omitempty"`        // Print cutStack on both sides.
+	SignatureSize     int              `json:"signatureSize,omitempty"` // Sheets per signature, default 4.
+	Creep             float64          `json:"creep,omitempty"`         // Shift towards the fold per sheet nested in a signature.
+	Margin            float64          `json:"margin,omitempty"`        // Sheet margin holding the printer's marks.
+	Gutter            float64          `json:"gutter,omitempty"`        // Space between adjacent slots.
+	CropMarks         bool             `json:"cropMarks,omitempty"`
+	RegistrationMarks bool             `json:"registrationMarks,omitempty"`
+	ColorBars         bool             `json:"colorBars,omitempty"`
+	Slots             []ImpositionSlot `json:"slots,omitempty"`
+}
+
+// PrintersMarks returns true if any printer's marks are configured.
+func (imp Imposition) PrintersMarks() bool {
+	return imp.CropMarks || imp.RegistrationMarks || imp.ColorBars
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TokenType is the type of a PDF token (see 7.2 Lexical conventions).
+type TokenType int
+
+// PDF token types.
+const (
+	TokenInteger TokenType = iota
+	TokenReal
+	TokenName
+	TokenString    // (...)
+	TokenHexString // <...>
+	TokenArrayStart
+	TokenArrayEnd
+	TokenDictStart
+	TokenDictEnd
+	TokenProcStart // { of PostScript calculator functions
+	TokenProcEnd
+	TokenKeyword // true, false, null, R, obj, endobj, stream, endstream, xref, trailer, startxref, content stream operators...
+	TokenComment
+)
+
+func (t TokenType) String() string {
+	return [...]string{
+		"Integer", "Real", "Name", "String", "HexString", "ArrayStart", "ArrayEnd",
+		"DictStart", "DictEnd", "ProcStart", "ProcEnd", "Keyword", "Comment"}[t]
+}
+
+// Token is a lexical PDF token and its position.
+type Token struct {
+	Type   TokenType
+	Raw    string // source bytes
+	Offset int64  // byte offset of the first byte
+}
+
+// End returns the byte offset following t.
+func (t Token) End() int64 {
+	return t.Offset + int64(len(t.Raw))
+}
+
+// Object returns the PDF object represented by t or nil for delimiters, keywords other than true, false and null and comments.
+func (t Token) Object() (types.Object, error) {
+	switch t.Type {
+	case TokenInteger:
+		i, err := strconv.Atoi(t.Raw)
+		if err != nil {
+			return nil, err
+		}
+		return types.Integer(i), nil
+	case TokenReal:
+		f, err := strconv.ParseFloat(t.Raw, 64)
+		if err != nil {
+			return nil, err
+		}
+		return types.Float(f), nil
+	case TokenName:
+		s, err := decodeNameHexSequence(t.Raw[1:])
+		if err != nil {
+			return nil, err
+		}
+		return types.Name(s), nil
+	case TokenString:
+		return types.StringLiteral(t.Raw[1 : len(t.Raw)-1]), nil
+	case TokenHexString:
+		s, ok := hexString(strings.TrimSpace(t.Raw[1 : len(t.Raw)-1]))
+		if !ok {
+			return nil, errHexLiteralCorrupt
+		}
+		return types.HexLiteral(*s), nil
+	case TokenKeyword:
+		switch t.Raw {
+		case "true":
+			return types.Boolean(true), nil
+		case "false":
+			return types.Boolean(false), nil
+		}
+	}
+	return nil, nil
+}
+
+// LexError is an error at a byte offset of the input of a Lexer.
+type LexError struct {
+	Offset int64
+	Err    error
+}
+
+func (e *LexError) Error() string {
+	return fmt.Sprintf("pdfcpu: offset %d: %v", e.Offset, e.Err)
+}
+
+func (e *LexError) Unwrap() error {
+	return e.Err
+}
+
+// Lexer splits PDF syntax into tokens and objects reporting their byte offsets.
+type Lexer struct {
+	s    string
+	pos  int
+	base int64
+}
+
+// NewLexer returns a Lexer for bb located at byte offset base of its file.
+func NewLexer(bb []byte, base int64) *Lexer {
+	return &Lexer{s: string(bb), base: base}
+}
+
+// Offset returns the byte offset of the next byte to be read.
+func (l *Lexer) Offset() int64 {
+	return l.base + int64(l.pos)
+}
+
+// SetOffset positions l at the byte offset off.
+func (l *Lexer) SetOffset(off int64) error {
+	i := off - l.base
+	if i < 0 || i > int64(len(l.s)) {
+		return &LexError{Offset: off, Err: errors.New("offset out of range")}
+	}
+	l.pos = int(i)
+	return nil
+}
+
+func whitespace(b byte) bool {
+	switch b {
+	case 0x00, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func (l *Lexer) skipWhitespace() {
+	for l.pos < len(l.s) && whitespace(l.s[l.pos]) {
+		l.pos++
+	}
+}
+
+// regularEnd returns the index of the first whitespace or delimiter of s at or after i.
+func regularEnd(s string, i int) int {
+	for i < len(s) && !whitespace(s[i]) && !strings.ContainsRune("()<>[]{}/%", rune(s[i])) {
+		i++
+	}
+	return i
+}
+
+func (l *Lexer) token(t TokenType, end int) Token {
+	tok := Token{Type: t, Raw: l.s[l.pos:end], Offset: l.Offset()}
+	l.pos = end
+	return tok
+}
+
+// Next returns the next token or io.EOF.
+func (l *Lexer) Next() (Token, error) {
+	l.skipWhitespace()
+	if l.pos >= len(l.s) {
+		return Token{}, io.EOF
+	}
+
+	s, i := l.s, l.pos
+
+	switch s[i] {
+
+	case '%':
+		j := strings.IndexAny(s[i:], "\r\n")
+		if j < 0 {
+			return l.token(TokenComment, len(s)), nil
+		}
+		return l.token(TokenComment, i+j), nil
+
+	case '(':
+		j := balancedParenthesesPrefix(s[i:])
+		if j < 0 {
+			return Token{}, &LexError{Offset: l.Offset(), Err: errStringLiteralCorrupt}
+		}
+		return l.token(TokenString, i+j+1), nil
+
+	case '<':
+		if strings.HasPrefix(s[i:], "<<") {
+			return l.token(TokenDictStart, i+2), nil
+		}
+		j := strings.IndexByte(s[i:], '>')
+		if j < 0 {
+			return Token{}, &LexError{Offset: l.Offset(), Err: errHexLiteralNotTerminated}
+		}
+		return l.token(TokenHexString, i+j+1), nil
+
+	case '>':
+		if !strings.HasPrefix(s[i:], ">>") {
+			return Token{}, &LexError{Offset: l.Offset(), Err: errors.New("unexpected '>'")}
+		}
+		return l.token(TokenDictEnd, i+2), nil
+
+	case '[':
+		return l.token(TokenArrayStart, i+1), nil
+
+	case ']':
+		return l.token(TokenArrayEnd, i+1), nil
+
+	case '{':
+		return l.token(TokenProcStart, i+1), nil
+
+	case '}':
+		return l.token(TokenProcEnd, i+1), nil
+
+	case ')':
+		return Token{}, &LexError{Offset: l.Offset(), Err: errors.New("unexpected ')'")}
+
+	case '/':
+		return l.token(TokenName, regularEnd(s, i+1)), nil
+	}
+
+	end := regularEnd(s, i)
+	t := TokenKeyword
+	if _, err := strconv.Atoi(s[i:end]); err == nil {
+		t = TokenInteger
+	} else if realNumber(s[i:end]) {
+		t = TokenReal
+	}
+
+	return l.token(t, end), nil
+}
+
+// realNumber returns true if s is a real number like 34.5, -.002 or 4. (see 7.3.3 Numeric Objects).
+func realNumber(s string) bool {
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	return len(s) > 1 && strings.Trim(s, "0123456789") == "."
+}
+
+// NextObject parses the next object including indirect references and returns it together with its byte offset and the offset following it.
+// Stream data is not processed, use SetOffset to skip it.
+func (l *Lexer) NextObject() (types.Object, int64, int64, error) {
+	// Skip whitespace and comments.
+	for {
+		l.skipWhitespace()
+		if l.pos >= len(l.s) || l.s[l.pos] != '%' {
+			break
+		}
+		if _, err := l.Next(); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	if l.pos >= len(l.s) {
+		return nil, 0, 0, io.EOF
+	}
+
+	start := l.Offset()
+	rest := l.s[l.pos:]
+
+	o, err := ParseObjectContext(context.Background(), &rest)
+	if err != nil {
+		return nil, 0, 0, &LexError{Offset: start, Err: err}
+	}
+
+	l.pos = len(l.s) - len(rest)
+
+	// The object ends with its last non whitespace byte.
+	end := l.pos
+	for end > 0 && whitespace(l.s[end-1]) {
+		end--
+	}
+
+	return o, start, l.base + int64(end), nil
+}
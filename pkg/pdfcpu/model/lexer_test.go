@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const lexerInput = "12 0 obj\n<</Type/Sig/Contents <0A0B> /ByteRange [0 10 20 -.5] /M (D:(2020)) /F 3 0 R>>\nendobj % done\n"
+
+func TestLexerTokens(t *testing.T) {
+	l := NewLexer([]byte(lexerInput), 100)
+
+	want := []struct {
+		typ TokenType
+		raw string
+	}{
+		{TokenInteger, "12"}, {TokenInteger, "0"}, {TokenKeyword, "obj"},
+		{TokenDictStart, "<<"}, {TokenName, "/Type"}, {TokenName, "/Sig"},
+		{TokenName, "/Contents"}, {TokenHexString, "<0A0B>"},
+		{TokenName, "/ByteRange"}, {TokenArrayStart, "["}, {TokenInteger, "0"}, {TokenInteger, "10"}, {TokenInteger, "20"}, {TokenReal, "-.5"}, {TokenArrayEnd, "]"},
+		{TokenName, "/M"}, {TokenString, "(D:(2020))"},
+		{TokenName, "/F"}, {TokenInteger, "3"}, {TokenInteger, "0"}, {TokenKeyword, "R"},
+		{TokenDictEnd, ">>"}, {TokenKeyword, "endobj"}, {TokenComment, "% done"},
+	}
+
+	for i, w := range want {
+		tok, err := l.Next()
+		if err != nil {
+			t.Fatalf("token %d: %v\n", i, err)
+		}
+		if tok.Type != w.typ || tok.Raw != w.raw {
+			t.Fatalf("token %d: want %s %q, got %s %q\n", i, w.typ, w.raw, tok.Type, tok.Raw)
+		}
+		if off := int64(strings.Index(lexerInput, w.raw)); w.raw == "<0A0B>" && tok.Offset != 100+off {
+			t.Fatalf("token %d: want offset %d, got %d\n", i, 100+off, tok.Offset)
+		}
+		if tok.End()-tok.Offset != int64(len(w.raw)) {
+			t.Fatalf("token %d: corrupt end %d\n", i, tok.End())
+		}
+	}
+
+	if _, err := l.Next(); err != io.EOF {
+		t.Fatalf("want EOF, got %v\n", err)
+	}
+}
+
+func TestLexerObjects(t *testing.T) {
+	l := NewLexer([]byte(lexerInput), 100)
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.Next(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	o, start, end, err := l.NextObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, ok := o.(types.Dict)
+	if !ok || d.NameEntry("Type") == nil || *d.NameEntry("Type") != "Sig" || d.IndirectRefEntry("F") == nil {
+		t.Fatalf("want signature dict, got %v\n", o)
+	}
+	if wantStart, wantEnd := int64(100+9), int64(100+strings.Index(lexerInput, ">>")+2); start != wantStart || end != wantEnd {
+		t.Fatalf("want object at [%d,%d), got [%d,%d)\n", wantStart, wantEnd, start, end)
+	}
+
+	tok, err := l.Next()
+	if err != nil || tok.Raw != "endobj" {
+		t.Fatalf("want endobj, got %v %v\n", tok, err)
+	}
+	if _, _, _, err := l.NextObject(); err != io.EOF {
+		t.Fatalf("want EOF, got %v\n", err)
+	}
+
+	// Revisit the hex string.
+	if err := l.SetOffset(100 + int64(strings.Index(lexerInput, "<0A0B>"))); err != nil {
+		t.Fatal(err)
+	}
+	if o, _, _, err = l.NextObject(); err != nil || o != types.HexLiteral("0A0B") {
+		t.Fatalf("want <0A0B>, got %v %v\n", o, err)
+	}
+}
+
+func TestLexerErrorOffset(t *testing.T) {
+	l := NewLexer([]byte("[1 2] (unbalanced"), 10)
+
+	if _, _, _, err := l.NextObject(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := l.Next()
+	var le *LexError
+	if !errors.As(err, &le) || le.Offset != 16 {
+		t.Fatalf("want error at offset 16, got %v\n", err)
+	}
+}
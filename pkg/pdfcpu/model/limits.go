@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Limits bounds the resources consumed by reading untrusted PDF files.
+// Zero values mean unlimited.
+// Exceeding a limit results in a *types.LimitError regardless of the validation mode.
+type Limits struct {
+	MaxObjects      int   // Max number of objects.
+	MaxNestingDepth int   // Max nesting depth of arrays and dicts.
+	MaxStreamSize   int64 // Max size of a single decoded stream.
+	MaxXRefSections int   // Max number of cross reference sections including incremental updates.
+}
+
+type nestingKey struct{}
+
+type nesting struct {
+	depth, max int
+}
+
+// WithMaxNestingDepth returns a copy of c limiting the nesting depth of arrays and dicts parsed with c to max.
+func WithMaxNestingDepth(c context.Context, max int) context.Context {
+	if max <= 0 {
+		return c
+	}
+	return context.WithValue(c, nestingKey{}, nesting{max: max})
+}
+
+// MaxNestingDepth returns the nesting depth limit of c or 0.
+func MaxNestingDepth(c context.Context) int {
+	n, _ := c.Value(nestingKey{}).(nesting)
+	return n.max
+}
+
+// nest returns a context for parsing the elements of an array or dict one level deeper.
+func nest(c context.Context) (context.Context, error) {
+	n, ok := c.Value(nestingKey{}).(nesting)
+	if !ok {
+		return c, nil
+	}
+	if n.depth >= n.max {
+		return nil, &types.LimitError{Limit: types.LimitNestingDepth, Max: int64(n.max)}
+	}
+	n.depth++
+	return context.WithValue(c, nestingKey{}, n), nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LogEnabled returns true if c has a structured logger handling level.
+func (c *Configuration) LogEnabled(level slog.Level) bool {
+	return c != nil && c.Logger != nil && c.Logger.Enabled(context.Background(), level)
+}
+
+// LogEvent emits a structured event to the configured Logger, if any.
+// args are key value pairs or slog.Attrs, see slog.Logger.Log.
+func (c *Configuration) LogEvent(level slog.Level, msg string, args ...any) {
+	if !c.LogEnabled(level) {
+		return
+	}
+	c.Logger.Log(context.Background(), level, msg, args...)
+}
+
+// TraceOp emits a debug event for the start of operation op and
+// returns a func emitting its completion including the elapsed time and a non nil err.
+// The elapsed time is also recorded for the timer op+"_duration", see Metrics.
+func (c *Configuration) TraceOp(op string) func(err error) {
+	logging := c.LogEnabled(slog.LevelDebug)
+	if !logging && (c == nil || c.Metrics == nil) {
+		return func(error) {}
+	}
+	if logging {
+		c.Logger.Debug("pdfcpu: begin", "op", op)
+	}
+	start := time.Now()
+	return func(err error) {
+		d := time.Since(start)
+		c.Observe(op+"_duration", d)
+		if !logging {
+			return
+		}
+		if err != nil {
+			c.Logger.Debug("pdfcpu: end", "op", op, "duration", d, "error", err)
+			return
+		}
+		c.Logger.Debug("pdfcpu: end", "op", op, "duration", d)
+	}
+}
+
+// LogFinding emits a structured event for the validation finding f.
+func (c *Configuration) LogFinding(f Finding) {
+	c.LogEvent(f.Severity.slogLevel(), "pdfcpu: validation finding",
+		"id", f.ID, "severity", f.Severity.String(), "obj", f.ObjNr, "fatal", f.Fatal, "msg", f.Message)
+}
+
+func (s Severity) slogLevel() slog.Level {
+	switch s {
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarning:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
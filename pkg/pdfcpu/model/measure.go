@@ -0,0 +1,431 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// NumberFormat represents a number format dictionary (see 12.9 Measurement properties, table 267).
+type NumberFormat struct {
+	Unit        string  `json:"unit"`                  // U, label for the units
+	Factor      float64 `json:"factor"`                // C, conversion factor from the preceding units
+	Format      string  `json:"format,omitempty"`      // F, D (decimal), F (fraction), R (round), T (truncate)
+	Denominator int     `json:"denominator,omitempty"` // D, precision (decimal) or denominator (fraction)
+	NoReduce    bool    `json:"noReduce,omitempty"`    // FD, do not reduce fractions
+	ThousandSep string  `json:"thousandSep,omitempty"` // RT, defaults to ","
+	DecimalSep  string  `json:"decimalSep,omitempty"`  // RD, defaults to "."
+	Prefix      string  `json:"prefix,omitempty"`      // PS, defaults to " "
+	Suffix      string  `json:"suffix,omitempty"`      // SS, defaults to " "
+	LabelPrefix bool    `json:"labelPrefix,omitempty"` // O, true for units as prefix
+}
+
+// Measure represents a rectilinear measure dictionary.
+type Measure struct {
+	Ratio  string         `json:"ratio"`            // R, scale ratio eg. "1in = 0.1m"
+	X      []NumberFormat `json:"x"`                // changes along the x axis
+	Y      []NumberFormat `json:"y,omitempty"`      // changes along the y axis if different from X
+	D      []NumberFormat `json:"d"`                // distances
+	A      []NumberFormat `json:"a"`                // areas
+	T      []NumberFormat `json:"t,omitempty"`      // angles
+	S      []NumberFormat `json:"s,omitempty"`      // slopes
+	Origin *types.Point   `json:"origin,omitempty"` // O, origin of the measurement coordinate system
+	CYX    float64        `json:"cyx,omitempty"`    // conversion factor from y units to x units
+}
+
+// NewScaleMeasure returns a measure for a drawing scale of 1 default user space unit = factor unit.
+func NewScaleMeasure(ratio, unit string, factor float64) Measure {
+	return Measure{
+		Ratio: ratio,
+		X:     []NumberFormat{{Unit: unit, Factor: factor, Denominator: 100}},
+		D:     []NumberFormat{{Unit: unit, Factor: 1, Denominator: 100}},
+		A:     []NumberFormat{{Unit: "sq " + unit, Factor: 1, Denominator: 100}},
+	}
+}
+
+func (m Measure) validate() error {
+	if m.Ratio == "" {
+		return errors.New("pdfcpu: measure: missing scale ratio")
+	}
+	for _, nff := range [][]NumberFormat{m.X, m.D, m.A} {
+		if len(nff) == 0 {
+			return errors.New("pdfcpu: measure: X, D and A are required")
+		}
+	}
+	for _, nff := range [][]NumberFormat{m.X, m.Y, m.D, m.A, m.T, m.S} {
+		for _, nf := range nff {
+			if nf.Unit == "" || nf.Factor <= 0 {
+				return errors.Errorf("pdfcpu: measure: invalid number format: %v", nf)
+			}
+			if nf.Format != "" && !types.MemberOf(nf.Format, []string{"D", "F", "R", "T"}) {
+				return errors.Errorf("pdfcpu: measure: invalid number format type: %s", nf.Format)
+			}
+		}
+	}
+	return nil
+}
+
+func (nf NumberFormat) dict() types.Dict {
+	d := types.Dict(map[string]types.Object{
+		"Type": types.Name("NumberFormat"),
+		"U":    types.StringLiteral(types.EncodeUTF16String(nf.Unit)),
+		"C":    types.Float(nf.Factor),
+	})
+	if nf.Format != "" {
+		d["F"] = types.Name(nf.Format)
+	}
+	if nf.Denominator > 0 {
+		d["D"] = types.Integer(nf.Denominator)
+	}
+	if nf.NoReduce {
+		d["FD"] = types.Boolean(true)
+	}
+	for k, v := range map[string]string{"RT": nf.ThousandSep, "RD": nf.DecimalSep, "PS": nf.Prefix, "SS": nf.Suffix} {
+		if v != "" {
+			d[k] = types.StringLiteral(types.EncodeUTF16String(v))
+		}
+	}
+	if nf.LabelPrefix {
+		d["O"] = types.Name("P")
+	}
+	return d
+}
+
+func numberFormatArray(nff []NumberFormat) types.Array {
+	a := types.Array{}
+	for _, nf := range nff {
+		a = append(a, nf.dict())
+	}
+	return a
+}
+
+// Dict renders m into a measure dictionary.
+func (m Measure) Dict() (types.Dict, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	d := types.Dict(map[string]types.Object{
+		"Type":    types.Name("Measure"),
+		"Subtype": types.Name("RL"),
+		"R":       types.StringLiteral(types.EncodeUTF16String(m.Ratio)),
+		"X":       numberFormatArray(m.X),
+		"D":       numberFormatArray(m.D),
+		"A":       numberFormatArray(m.A),
+	})
+
+	for k, nff := range map[string][]NumberFormat{"Y": m.Y, "T": m.T, "S": m.S} {
+		if len(nff) > 0 {
+			d[k] = numberFormatArray(nff)
+		}
+	}
+
+	if m.Origin != nil {
+		d["O"] = types.NewNumberArray(m.Origin.X, m.Origin.Y)
+	}
+
+	if m.CYX > 0 {
+		d["CYX"] = types.Float(m.CYX)
+	}
+
+	return d, nil
+}
+
+func (xRefTable *XRefTable) numberFormats(o types.Object) ([]NumberFormat, error) {
+	a, err := xRefTable.DereferenceArray(o)
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	var nff []NumberFormat
+
+	for _, o := range a {
+		d, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+
+		var nf NumberFormat
+
+		for k, p := range map[string]*string{"U": &nf.Unit, "RT": &nf.ThousandSep, "RD": &nf.DecimalSep, "PS": &nf.Prefix, "SS": &nf.Suffix} {
+			if o, found := d.Find(k); found {
+				if *p, err = xRefTable.DereferenceText(o); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if nf.Factor, err = xRefTable.DereferenceNumber(d["C"]); err != nil {
+			return nil, err
+		}
+
+		if s := d.NameEntry("F"); s != nil {
+			nf.Format = *s
+		}
+
+		if o, found := d.Find("D"); found {
+			i, err := xRefTable.DereferenceInteger(o)
+			if err != nil {
+				return nil, err
+			}
+			if i != nil {
+				nf.Denominator = i.Value()
+			}
+		}
+
+		if b := d.BooleanEntry("FD"); b != nil {
+			nf.NoReduce = *b
+		}
+
+		if s := d.NameEntry("O"); s != nil {
+			nf.LabelPrefix = *s == "P"
+		}
+
+		nff = append(nff, nf)
+	}
+
+	return nff, nil
+}
+
+// ParseMeasure returns the rectilinear measure represented by measure dict d.
+func (xRefTable *XRefTable) ParseMeasure(d types.Dict) (*Measure, error) {
+	if st := d.NameEntry("Subtype"); st != nil && *st != "RL" {
+		return nil, errors.Errorf("pdfcpu: unsupported measure subtype: %s", *st)
+	}
+
+	m := &Measure{}
+
+	var err error
+
+	if o, found := d.Find("R"); found {
+		if m.Ratio, err = xRefTable.DereferenceText(o); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, p := range map[string]*[]NumberFormat{"X": &m.X, "Y": &m.Y, "D": &m.D, "A": &m.A, "T": &m.T, "S": &m.S} {
+		if *p, err = xRefTable.numberFormats(d[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	a, err := xRefTable.DereferenceArray(d["O"])
+	if err != nil {
+		return nil, err
+	}
+	if len(a) == 2 {
+		x, err := xRefTable.DereferenceNumber(a[0])
+		if err != nil {
+			return nil, err
+		}
+		y, err := xRefTable.DereferenceNumber(a[1])
+		if err != nil {
+			return nil, err
+		}
+		m.Origin = &types.Point{X: x, Y: y}
+	}
+
+	if o, found := d.Find("CYX"); found {
+		if m.CYX, err = xRefTable.DereferenceNumber(o); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// scale returns the x and y conversion factors from default user space units to X units.
+func (m Measure) scale() (float64, float64, error) {
+	if len(m.X) == 0 {
+		return 0, 0, errors.New("pdfcpu: measure: missing X")
+	}
+
+	sx := m.X[0].Factor
+	sy := sx
+	if len(m.Y) > 0 {
+		cyx := m.CYX
+		if cyx == 0 {
+			cyx = 1
+		}
+		sy = m.Y[0].Factor * cyx
+	}
+
+	return sx, sy, nil
+}
+
+// Length returns the real world length of the polyline defined by the user space coordinates pp
+// expressed in the largest distance units.
+func (m Measure) Length(pp []types.Point) (float64, error) {
+	sx, sy, err := m.scale()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(m.D) == 0 {
+		return 0, errors.New("pdfcpu: measure: missing D")
+	}
+
+	var l float64
+	for i := 1; i < len(pp); i++ {
+		l += math.Hypot((pp[i].X-pp[i-1].X)*sx, (pp[i].Y-pp[i-1].Y)*sy)
+	}
+
+	return l * m.D[0].Factor, nil
+}
+
+// Area returns the real world area of the polygon defined by the user space coordinates pp
+// expressed in the largest area units.
+func (m Measure) Area(pp []types.Point) (float64, error) {
+	sx, sy, err := m.scale()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(m.A) == 0 {
+		return 0, errors.New("pdfcpu: measure: missing A")
+	}
+
+	// Shoelace formula
+	var a float64
+	for i := range pp {
+		j := (i + 1) % len(pp)
+		a += pp[i].X*pp[j].Y - pp[j].X*pp[i].Y
+	}
+
+	return math.Abs(a) / 2 * sx * sy * m.A[0].Factor, nil
+}
+
+func (nf NumberFormat) formatNumber(f float64) string {
+	denom := nf.Denominator
+	if denom <= 0 {
+		denom = 100
+	}
+
+	var s string
+
+	switch nf.Format {
+	case "F":
+		i, frac := math.Modf(f)
+		n := int(math.Round(frac * float64(denom)))
+		d := denom
+		if n == d {
+			i, n = i+1, 0
+		}
+		if !nf.NoReduce {
+			for g := gcd(n, d); g > 1; g = gcd(n, d) {
+				n, d = n/g, d/g
+			}
+		}
+		s = strconv.Itoa(int(i))
+		if n > 0 {
+			s += fmt.Sprintf(" %d/%d", n, d)
+		}
+		return s
+	case "R":
+		s = strconv.Itoa(int(math.Round(f)))
+	case "T":
+		s = strconv.Itoa(int(f))
+	default:
+		s = strconv.FormatFloat(math.Round(f*float64(denom))/float64(denom), 'f', -1, 64)
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+
+	sep := nf.ThousandSep
+	if sep == "" && nf.Format != "R" && nf.Format != "T" {
+		sep = ","
+	}
+	if len(intPart) > 3 {
+		var sb strings.Builder
+		for i, r := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				sb.WriteString(sep)
+			}
+			sb.WriteRune(r)
+		}
+		intPart = sb.String()
+	}
+
+	if fracPart == "" {
+		return intPart
+	}
+
+	dec := nf.DecimalSep
+	if dec == "" {
+		dec = "."
+	}
+
+	return intPart + dec + fracPart
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// FormatMeasurement formats a value given in units of the first element of nff
+// according to the number format array nff eg. "1 mi 320 ft".
+func FormatMeasurement(f float64, nff []NumberFormat) string {
+	var ss []string
+
+	for i, nf := range nff {
+		if i > 0 {
+			f *= nf.Factor
+		}
+
+		last := i == len(nff)-1
+		v := f
+		if !last {
+			v = math.Trunc(f)
+			f -= v
+			if v == 0 {
+				continue
+			}
+		}
+		if last && len(ss) > 0 && math.Round(v*float64(max(nf.Denominator, 1))) == 0 {
+			break
+		}
+
+		prefix, suffix := nf.Prefix, nf.Suffix
+		if prefix == "" {
+			prefix = " "
+		}
+		if suffix == "" {
+			suffix = " "
+		}
+
+		num := nf.formatNumber(v)
+		if nf.LabelPrefix {
+			ss = append(ss, nf.Unit+prefix+num)
+		} else {
+			ss = append(ss, num+suffix+nf.Unit)
+		}
+	}
+
+	return strings.Join(ss, " ")
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "time"
+
+// Metrics receives counters and timers, eg. for exporting to Prometheus.
+// Implementations need to be safe for concurrent use when shared between configurations.
+type Metrics interface {
+	// Count adds n to the counter name.
+	Count(name string, n int64)
+
+	// Observe records d for the timer name.
+	Observe(name string, d time.Duration)
+}
+
+// Metric names.
+const (
+	MetricObjectsParsed    = "objects_parsed"    // Counter: objects parsed while reading.
+	MetricStreamsDecoded   = "streams_decoded"   // Counter: streams decoded while reading.
+	MetricBytesWritten     = "bytes_written"     // Counter: bytes written.
+	MetricReadDuration     = "read_duration"     // Timer: reading a PDF.
+	MetricValidateDuration = "validate_duration" // Timer: validating a PDF.
+	MetricOptimizeDuration = "optimize_duration" // Timer: optimizing a PDF.
+	MetricWriteDuration    = "write_duration"    // Timer: writing a PDF.
+	MetricKeyCacheHits     = "key_cache_hits"    // Counter: file encryption keys taken from the key cache.
+	MetricStreamsSpilled   = "streams_spilled"   // Counter: streams moved out of memory, see Configuration.MaxMemory.
+)
+
+// Count adds n to the counter name of the configured Metrics, if any.
+func (c *Configuration) Count(name string, n int64) {
+	if c != nil && c.Metrics != nil {
+		c.Metrics.Count(name, n)
+	}
+}
+
+// Observe records d for the timer name of the configured Metrics, if any.
+func (c *Configuration) Observe(name string, d time.Duration) {
+	if c != nil && c.Metrics != nil {
+		c.Metrics.Observe(name, d)
+	}
+}
@@ -610,3 +610,253 @@ func (n Node) String() string {
 
 	return strings.Join(a, ",")
 }
+
+// Names of the name trees of the name dictionary (7.7.4).
+const (
+	NameTreeDests                  = "Dests"
+	NameTreeAP                     = "AP"
+	NameTreeJavaScript             = "JavaScript"
+	NameTreePages                  = "Pages"
+	NameTreeTemplates              = "Templates"
+	NameTreeIDS                    = "IDS"
+	NameTreeURLS                   = "URLS"
+	NameTreeEmbeddedFiles          = "EmbeddedFiles"
+	NameTreeAlternatePresentations = "AlternatePresentations"
+	NameTreeRenditions             = "Renditions"
+)
+
+// treeMaxKids is the max number of kids or entries per node of a rebalanced name or number tree.
+const treeMaxKids = 32
+
+// NameTree provides access to a name tree of the name dictionary backed by the name tree cache.
+type NameTree struct {
+	xRefTable *XRefTable
+	name      string
+}
+
+// NameTree returns the name tree called name, eg. NameTreeDests.
+func (xRefTable *XRefTable) NameTree(name string) *NameTree {
+	return &NameTree{xRefTable: xRefTable, name: name}
+}
+
+func (xRefTable *XRefTable) internalizeNameTree(d types.Dict, visited types.IntSet) (*Node, error) {
+	n := &Node{D: d}
+
+	if o, found := d.Find("Kids"); found {
+		a, err := xRefTable.DereferenceArray(o)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range a {
+			if ir, ok := o.(types.IndirectRef); ok {
+				if visited[ir.ObjectNumber.Value()] {
+					return nil, errors.Errorf("pdfcpu: name tree node referenced twice: obj #%d", ir.ObjectNumber.Value())
+				}
+				visited[ir.ObjectNumber.Value()] = true
+			}
+			d1, err := xRefTable.DereferenceDict(o)
+			if err != nil {
+				return nil, err
+			}
+			if d1 == nil {
+				continue
+			}
+			kid, err := xRefTable.internalizeNameTree(d1, visited)
+			if err != nil {
+				return nil, err
+			}
+			if kid.leaf() && len(kid.Names) == 0 {
+				continue
+			}
+			if len(n.Kids) == 0 {
+				n.Kmin = kid.Kmin
+			}
+			n.Kmax = kid.Kmax
+			n.Kids = append(n.Kids, kid)
+		}
+		return n, nil
+	}
+
+	a, err := xRefTable.DereferenceArray(d["Names"])
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(a); i += 2 {
+		o, err := xRefTable.Dereference(a[i])
+		if err != nil {
+			return nil, err
+		}
+		k, err := types.StringOrHexLiteral(o)
+		if err != nil {
+			return nil, err
+		}
+		n.AppendToNames(*k, a[i+1])
+	}
+	if len(n.Names) > 0 {
+		n.Kmin, n.Kmax = n.Names[0].k, n.Names[len(n.Names)-1].k
+	}
+
+	return n, nil
+}
+
+// root returns the cached root node of t, loading it from the name dictionary if necessary.
+func (t *NameTree) root(ensure bool) (*Node, error) {
+	xRefTable := t.xRefTable
+
+	if n := xRefTable.Names[t.name]; n != nil {
+		return n, nil
+	}
+
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	namesDict, err := xRefTable.DereferenceDict(rootDict["Names"])
+	if err != nil {
+		return nil, err
+	}
+
+	var d types.Dict
+	if namesDict != nil {
+		if d, err = xRefTable.DereferenceDict(namesDict[t.name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if d == nil {
+		if !ensure {
+			return nil, nil
+		}
+		if err := xRefTable.LocateNameTree(t.name, true); err != nil {
+			return nil, err
+		}
+		return xRefTable.Names[t.name], nil
+	}
+
+	n, err := xRefTable.internalizeNameTree(d, types.IntSet{})
+	if err != nil {
+		return nil, err
+	}
+	if xRefTable.Names == nil {
+		xRefTable.Names = map[string]*Node{}
+	}
+	xRefTable.Names[t.name] = n
+
+	return n, nil
+}
+
+// Lookup returns the value for k.
+func (t *NameTree) Lookup(k string) (types.Object, bool, error) {
+	n, err := t.root(false)
+	if err != nil || n == nil {
+		return nil, false, err
+	}
+	v, ok := n.Value(k)
+	return v, ok, nil
+}
+
+// Insert adds the entry (k,v) replacing any existing value for k.
+// The name tree gets created if necessary.
+func (t *NameTree) Insert(k string, v types.Object) error {
+	n, err := t.root(true)
+	if err != nil {
+		return err
+	}
+	if _, ok := n.Value(k); ok {
+		if _, _, err := n.Remove(nil, k); err != nil {
+			return err
+		}
+	}
+	return n.Add(t.xRefTable, k, v, nil, nil)
+}
+
+// Delete removes the entry for k including the object graph of its value.
+// An empty name tree gets removed from the name dictionary.
+func (t *NameTree) Delete(k string) (bool, error) {
+	n, err := t.root(false)
+	if err != nil || n == nil {
+		return false, err
+	}
+
+	empty, ok, err := n.Remove(t.xRefTable, k)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if empty {
+		delete(t.xRefTable.Names, t.name)
+		if err := t.xRefTable.RemoveNameTree(t.name); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Each calls fn for all entries in key order.
+func (t *NameTree) Each(fn func(k string, v types.Object) error) error {
+	n, err := t.root(false)
+	if err != nil || n == nil {
+		return err
+	}
+	return n.Process(t.xRefTable, func(_ *XRefTable, k string, v *types.Object) error {
+		return fn(k, *v)
+	})
+}
+
+// EachNameTreeEntry calls fn for all entries of the name tree rooted at d in key order.
+// Use this for name trees outside of the name dictionary, eg. the Assets of a RichMediaContent dict.
+func (xRefTable *XRefTable) EachNameTreeEntry(d types.Dict, fn func(k string, v types.Object) error) error {
+	n, err := xRefTable.internalizeNameTree(d, types.IntSet{})
+	if err != nil {
+		return err
+	}
+	return n.Process(xRefTable, func(_ *XRefTable, k string, v *types.Object) error {
+		return fn(k, *v)
+	})
+}
+
+// Rebalance rebuilds the name tree into a balanced tree
+// with up to 32 kids per intermediate node and 32 entries per leaf.
+func (t *NameTree) Rebalance() error {
+	n, err := t.root(false)
+	if err != nil || n == nil {
+		return err
+	}
+
+	var nodes []*Node
+
+	if err := t.Each(func(k string, v types.Object) error {
+		if len(nodes) == 0 || len(nodes[len(nodes)-1].Names) == treeMaxKids {
+			nodes = append(nodes, &Node{Names: make([]entry, 0, treeMaxKids), Kmin: k})
+		}
+		leaf := nodes[len(nodes)-1]
+		leaf.Names = append(leaf.Names, entry{k, v})
+		leaf.Kmax = k
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for len(nodes) > 1 {
+		var parents []*Node
+		for i := 0; i < len(nodes); i += treeMaxKids {
+			kids := nodes[i:min(i+treeMaxKids, len(nodes))]
+			parents = append(parents, &Node{Kids: kids, Kmin: kids[0].Kmin, Kmax: kids[len(kids)-1].Kmax})
+		}
+		nodes = parents
+	}
+
+	// Reuse the root dict.
+	d := n.D
+	d.Delete("Kids")
+	d.Delete("Names")
+	*n = Node{D: d, Names: []entry{}}
+	if len(nodes) == 1 {
+		*n = *nodes[0]
+		n.D = d
+	}
+
+	return nil
+}
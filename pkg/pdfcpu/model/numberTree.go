@@ -0,0 +1,454 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// NumberTree provides access to a number tree (7.9.7) operating directly on its PDF dicts.
+type NumberTree struct {
+	xRefTable *XRefTable
+	D         types.Dict // The root node.
+}
+
+// NumberTree returns the number tree rooted at d.
+func (xRefTable *XRefTable) NumberTree(d types.Dict) *NumberTree {
+	return &NumberTree{xRefTable: xRefTable, D: d}
+}
+
+// PageLabels returns the page labels number tree of the catalog.
+// If ensure is true a missing page labels number tree gets created, otherwise nil is returned.
+func (xRefTable *XRefTable) PageLabels(ensure bool) (*NumberTree, error) {
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := xRefTable.DereferenceDict(rootDict["PageLabels"])
+	if err != nil {
+		return nil, err
+	}
+
+	if d == nil {
+		if !ensure {
+			return nil, nil
+		}
+		d = types.Dict{"Nums": types.Array{}}
+		ir, err := xRefTable.IndRefForNewObject(d)
+		if err != nil {
+			return nil, err
+		}
+		rootDict["PageLabels"] = *ir
+	}
+
+	return xRefTable.NumberTree(d), nil
+}
+
+type numberTreeEntry struct {
+	k int
+	v types.Object
+}
+
+func (t *NumberTree) kids(d types.Dict) ([]types.Dict, error) {
+	o, found := d.Find("Kids")
+	if !found {
+		return nil, nil
+	}
+
+	a, err := t.xRefTable.DereferenceArray(o)
+	if err != nil {
+		return nil, err
+	}
+
+	dd := make([]types.Dict, 0, len(a))
+	for _, o := range a {
+		d1, err := t.xRefTable.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d1 == nil {
+			return nil, errors.New("pdfcpu: number tree: corrupt \"Kids\" entry")
+		}
+		dd = append(dd, d1)
+	}
+
+	return dd, nil
+}
+
+func (t *NumberTree) nums(d types.Dict) ([]numberTreeEntry, error) {
+	a, err := t.xRefTable.DereferenceArray(d["Nums"])
+	if err != nil {
+		return nil, err
+	}
+
+	ee := make([]numberTreeEntry, 0, len(a)/2)
+	for i := 0; i+1 < len(a); i += 2 {
+		k, err := t.xRefTable.DereferenceInteger(a[i])
+		if err != nil {
+			return nil, err
+		}
+		if k == nil {
+			return nil, errors.New("pdfcpu: number tree: corrupt \"Nums\" entry")
+		}
+		ee = append(ee, numberTreeEntry{k.Value(), a[i+1]})
+	}
+
+	return ee, nil
+}
+
+func setNums(d types.Dict, ee []numberTreeEntry, root bool) {
+	a := make(types.Array, 0, 2*len(ee))
+	for _, e := range ee {
+		a = append(a, types.Integer(e.k), e.v)
+	}
+	d["Nums"] = a
+	if !root && len(ee) > 0 {
+		d["Limits"] = types.Array{types.Integer(ee[0].k), types.Integer(ee[len(ee)-1].k)}
+	}
+}
+
+// limits returns the smallest and largest key of the subtree rooted at d.
+func (t *NumberTree) limits(d types.Dict) (kmin, kmax int, ok bool, err error) {
+	if a := d.ArrayEntry("Limits"); len(a) == 2 {
+		i, ok1 := a[0].(types.Integer)
+		j, ok2 := a[1].(types.Integer)
+		if ok1 && ok2 {
+			return i.Value(), j.Value(), true, nil
+		}
+	}
+	return t.KeyRange(d)
+}
+
+// KeyRange returns the smallest and largest key of the subtree rooted at node d
+// as found in its "Nums" or else in the limits of its kids, ignoring the "Limits" of d.
+func (t *NumberTree) KeyRange(d types.Dict) (kmin, kmax int, ok bool, err error) {
+	kids, err := t.kids(d)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if kids != nil {
+		for _, kid := range kids {
+			kmin1, kmax1, ok1, err := t.limits(kid)
+			if err != nil {
+				return 0, 0, false, err
+			}
+			if !ok1 {
+				continue
+			}
+			if !ok || kmin1 < kmin {
+				kmin = kmin1
+			}
+			if !ok || kmax1 > kmax {
+				kmax = kmax1
+			}
+			ok = true
+		}
+		return kmin, kmax, ok, nil
+	}
+
+	ee, err := t.nums(d)
+	if err != nil || len(ee) == 0 {
+		return 0, 0, false, err
+	}
+
+	return ee[0].k, ee[len(ee)-1].k, true, nil
+}
+
+func (t *NumberTree) updateLimits(d types.Dict, root bool) error {
+	if root {
+		return nil
+	}
+	d.Delete("Limits")
+	kmin, kmax, ok, err := t.limits(d)
+	if err != nil || !ok {
+		return err
+	}
+	d["Limits"] = types.Array{types.Integer(kmin), types.Integer(kmax)}
+	return nil
+}
+
+func (t *NumberTree) lookup(d types.Dict, k int) (types.Object, bool, error) {
+	kids, err := t.kids(d)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if kids == nil {
+		ee, err := t.nums(d)
+		if err != nil {
+			return nil, false, err
+		}
+		i := sort.Search(len(ee), func(i int) bool { return ee[i].k >= k })
+		if i < len(ee) && ee[i].k == k {
+			return ee[i].v, true, nil
+		}
+		return nil, false, nil
+	}
+
+	for _, kid := range kids {
+		kmin, kmax, ok, err := t.limits(kid)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok && kmin <= k && k <= kmax {
+			return t.lookup(kid, k)
+		}
+	}
+
+	return nil, false, nil
+}
+
+// Lookup returns the value for k.
+func (t *NumberTree) Lookup(k int) (types.Object, bool, error) {
+	return t.lookup(t.D, k)
+}
+
+func (t *NumberTree) insert(d types.Dict, k int, v types.Object, root bool) error {
+	kids, err := t.kids(d)
+	if err != nil {
+		return err
+	}
+
+	if len(kids) == 0 {
+		ee, err := t.nums(d)
+		if err != nil {
+			return err
+		}
+		i := sort.Search(len(ee), func(i int) bool { return ee[i].k >= k })
+		if i < len(ee) && ee[i].k == k {
+			ee[i].v = v
+		} else {
+			ee = append(ee, numberTreeEntry{})
+			copy(ee[i+1:], ee[i:])
+			ee[i] = numberTreeEntry{k, v}
+		}
+		d.Delete("Kids")
+		setNums(d, ee, root)
+		return nil
+	}
+
+	// Insert into the first kid whose range extends to k, or else into the last kid.
+	kid := kids[len(kids)-1]
+	for _, d1 := range kids {
+		_, kmax, ok, err := t.limits(d1)
+		if err != nil {
+			return err
+		}
+		if ok && k <= kmax {
+			kid = d1
+			break
+		}
+	}
+
+	if err := t.insert(kid, k, v, false); err != nil {
+		return err
+	}
+
+	return t.updateLimits(d, root)
+}
+
+// Insert adds the entry (k,v) replacing any existing value for k.
+func (t *NumberTree) Insert(k int, v types.Object) error {
+	return t.insert(t.D, k, v, true)
+}
+
+// delete removes k from the subtree rooted at d.
+// empty returns true if d has no entries left.
+func (t *NumberTree) delete(d types.Dict, k int, root bool) (empty, ok bool, err error) {
+	kids, err := t.kids(d)
+	if err != nil {
+		return false, false, err
+	}
+
+	if kids == nil {
+		ee, err := t.nums(d)
+		if err != nil {
+			return false, false, err
+		}
+		i := sort.Search(len(ee), func(i int) bool { return ee[i].k >= k })
+		if i == len(ee) || ee[i].k != k {
+			return false, false, nil
+		}
+		ee = append(ee[:i], ee[i+1:]...)
+		setNums(d, ee, root)
+		return len(ee) == 0, true, nil
+	}
+
+	a, err := t.xRefTable.DereferenceArray(d["Kids"])
+	if err != nil {
+		return false, false, err
+	}
+
+	for i, kid := range kids {
+		kmin, kmax, ok, err := t.limits(kid)
+		if err != nil {
+			return false, false, err
+		}
+		if !ok || k < kmin || k > kmax {
+			continue
+		}
+		empty, ok, err := t.delete(kid, k, false)
+		if err != nil || !ok {
+			return false, ok, err
+		}
+		if empty {
+			if err := t.xRefTable.DeleteObject(a[i]); err != nil {
+				return false, false, err
+			}
+			a = append(a[:i], a[i+1:]...)
+			d["Kids"] = a
+		}
+		if len(a) == 0 {
+			d.Delete("Kids")
+			setNums(d, nil, root)
+			return true, true, nil
+		}
+		return false, true, t.updateLimits(d, root)
+	}
+
+	return false, false, nil
+}
+
+// Delete removes the entry for k.
+func (t *NumberTree) Delete(k int) (bool, error) {
+	_, ok, err := t.delete(t.D, k, true)
+	return ok, err
+}
+
+func (t *NumberTree) eachNode(d types.Dict, root bool, fn func(d types.Dict, root bool) error, visited types.IntSet) error {
+	if err := fn(d, root); err != nil {
+		return err
+	}
+
+	o, found := d.Find("Kids")
+	if !found {
+		return nil
+	}
+
+	a, err := t.xRefTable.DereferenceArray(o)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range a {
+		if ir, ok := o.(types.IndirectRef); ok {
+			if visited[ir.ObjectNumber.Value()] {
+				return errors.Errorf("pdfcpu: number tree node referenced twice: obj #%d", ir.ObjectNumber.Value())
+			}
+			visited[ir.ObjectNumber.Value()] = true
+		}
+		kid, err := t.xRefTable.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if kid == nil {
+			return errors.New("pdfcpu: number tree: corrupt \"Kids\" entry")
+		}
+		if err := t.eachNode(kid, false, fn, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EachNode calls fn for all nodes in depth-first order starting with the root node.
+// A node referenced more than once results in an error.
+func (t *NumberTree) EachNode(fn func(d types.Dict, root bool) error) error {
+	return t.eachNode(t.D, true, fn, types.IntSet{})
+}
+
+// Each calls fn for all entries in key order.
+func (t *NumberTree) Each(fn func(k int, v types.Object) error) error {
+	return t.EachNode(func(d types.Dict, _ bool) error {
+		if _, found := d.Find("Kids"); found {
+			return nil
+		}
+		ee, err := t.nums(d)
+		if err != nil {
+			return err
+		}
+		for _, e := range ee {
+			if err := fn(e.k, e.v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Rebalance rebuilds the number tree into a balanced tree
+// with up to 32 kids per intermediate node and 32 entries per leaf.
+func (t *NumberTree) Rebalance() error {
+	var ee []numberTreeEntry
+	if err := t.Each(func(k int, v types.Object) error {
+		ee = append(ee, numberTreeEntry{k, v})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	sort.SliceStable(ee, func(i, j int) bool { return ee[i].k < ee[j].k })
+
+	t.D.Delete("Kids")
+	t.D.Delete("Nums")
+
+	if len(ee) <= treeMaxKids {
+		setNums(t.D, ee, true)
+		return nil
+	}
+
+	type node struct {
+		d          types.Dict
+		kmin, kmax int
+	}
+
+	var nodes []node
+
+	for i := 0; i < len(ee); i += treeMaxKids {
+		leaf := ee[i:min(i+treeMaxKids, len(ee))]
+		d := types.Dict{}
+		setNums(d, leaf, false)
+		nodes = append(nodes, node{d, leaf[0].k, leaf[len(leaf)-1].k})
+	}
+
+	for {
+		var parents []node
+		for i := 0; i < len(nodes); i += treeMaxKids {
+			kids := nodes[i:min(i+treeMaxKids, len(nodes))]
+			a := make(types.Array, len(kids))
+			for j, kid := range kids {
+				ir, err := t.xRefTable.IndRefForNewObject(kid.d)
+				if err != nil {
+					return err
+				}
+				a[j] = *ir
+			}
+			kmin, kmax := kids[0].kmin, kids[len(kids)-1].kmax
+			parents = append(parents, node{types.Dict{"Kids": a, "Limits": types.Array{types.Integer(kmin), types.Integer(kmax)}}, kmin, kmax})
+		}
+		if len(parents) == 1 {
+			t.D["Kids"] = parents[0].d["Kids"]
+			return nil
+		}
+		nodes = parents
+	}
+}
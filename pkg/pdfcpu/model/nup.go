@@ -61,6 +61,7 @@ const (
 	DownLeft
 )
 
+// BorderStyling represents the styling of a border.
 type BorderStyling struct {
 	Color     *color.SimpleColor
 	LineStyle *types.LineJoinStyle
@@ -78,13 +79,19 @@ type NUp struct {
 	PageGrid        bool               // Create a m x n grid of pages for PDF inputfiles only (think "extra page n-Up").
 	ImgInputFile    bool               // Process image or PDF input files.
 	Margin          float64            // Cropbox for n-Up content.
+	Spacing         float64            // Padding around each grid cell.
 	Border          bool               // Draw bounding box.
+	CellBorder      *BorderStyling     // Styling of the bounding box, defaults to a thin black line.
+	Anchor          *types.Anchor      // Align content within grid cell instead of centering.
 	BorderOnCropbox *BorderStyling     // Draw bounding box around crop box.
 	BookletGuides   bool               // Draw folding and cutting lines.
 	MultiFolio      bool               // Render booklet as sequence of folios.
 	FolioSize       int                // Booklet multifolio folio size: default: 8
 	BookletType     BookletType        // Is this a booklet or booklet cover layout
 	BookletBinding  BookletBinding     // Does the booklet have short or long-edge binding
+	Duplex          PaperHandling      // Booklet duplex paper handling, back sides get rotated for DuplexFlipLongEdge.
+	BlankPages      BlankPagePosition  // Booklet filler page insertion position.
+	SheetLabels     bool               // Print sheet and signature labels into the gutter of booklet sheets.
 	InpUnit         types.DisplayUnit  // input display unit.
 	BgColor         *color.SimpleColor // background color
 }
@@ -214,11 +221,20 @@ func NUpTilePDFBytes(wr io.Writer, rSrc, rDest *types.Rectangle, formResID strin
 	// enforceOrient:
 	//			indicates if we need to enforce dest's orientation.
 
+	// Apply spacing to rDest which leaves space between the grid cells.
+	if nup.Spacing > 0 {
+		rDest = rDest.CroppedCopy(nup.Spacing)
+	}
+
 	// Draw bounding box.
 	if nup.Border {
-		fmt.Fprintf(wr, "[]0 d 0.1 w %.2f %.2f m %.2f %.2f l %.2f %.2f l %.2f %.2f l s ",
-			rDest.LL.X, rDest.LL.Y, rDest.UR.X, rDest.LL.Y, rDest.UR.X, rDest.UR.Y, rDest.LL.X, rDest.UR.Y,
-		)
+		if bs := nup.CellBorder; bs != nil {
+			draw.DrawRect(wr, rDest, bs.Width, bs.Color, bs.LineStyle)
+		} else {
+			fmt.Fprintf(wr, "[]0 d 0.1 w %.2f %.2f m %.2f %.2f l %.2f %.2f l %.2f %.2f l s ",
+				rDest.LL.X, rDest.LL.Y, rDest.UR.X, rDest.LL.Y, rDest.UR.X, rDest.UR.Y, rDest.LL.X, rDest.UR.Y,
+			)
+		}
 	}
 
 	// Apply margin to rDest which potentially makes it smaller.
@@ -231,6 +247,15 @@ func NUpTilePDFBytes(wr io.Writer, rSrc, rDest *types.Rectangle, formResID strin
 	// whereas in cases where the original orientation needs to be preserved eg. for booklets, we don't.
 	w, h, dx, dy, r := types.BestFitRectIntoRect(rSrc, rDestCr, nup.Enforce, false)
 
+	if nup.Anchor != nil {
+		// Keep the aspect ratio but align to anchor instead of centering.
+		w1, h1 := w, h
+		if r == 90 {
+			w1, h1 = h, w
+		}
+		dx, dy = types.AnchorPosition(*nup.Anchor, rDestCr, w1, h1)
+	}
+
 	if nup.BgColor != nil {
 		if nup.ImgInputFile {
 			// Fill background.
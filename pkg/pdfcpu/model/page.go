@@ -80,6 +80,7 @@ type Page struct {
 	CropBox    *types.Rectangle
 	Fm         FontMap
 	Im         ImageMap
+	Patterns   types.Dict // Pattern resources by id.
 	Annots     []FieldAnnotation
 	AnnotTabs  map[int]FieldAnnotation
 	LinkAnnots []LinkAnnotation
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// PageCoordinates converts between the visual coordinates of a page as displayed by a viewer
+// and its default user space.
+//
+// Visual coordinates have their origin in the lower left corner of the displayed crop box,
+// x pointing right and y pointing up after applying the page rotation.
+type PageCoordinates struct {
+	CropBox *types.Rectangle // visible region in default user space
+	Rot     int              // clockwise page rotation: 0, 90, 180 or 270
+}
+
+// NewPageCoordinates returns the coordinate conversion for a page with crop box cropBox and rotation rot.
+func NewPageCoordinates(cropBox *types.Rectangle, rot int) (*PageCoordinates, error) {
+	if cropBox == nil {
+		return nil, errors.New("pdfcpu: missing crop box")
+	}
+	if rot%90 != 0 {
+		return nil, errors.Errorf("pdfcpu: invalid page rotation: %d", rot)
+	}
+	rot %= 360
+	if rot < 0 {
+		rot += 360
+	}
+	return &PageCoordinates{CropBox: cropBox, Rot: rot}, nil
+}
+
+// PageCoordinates returns the coordinate conversion for page pageNr.
+func (xRefTable *XRefTable) PageCoordinates(pageNr int) (*PageCoordinates, error) {
+	_, _, inhPAttrs, err := xRefTable.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if inhPAttrs == nil {
+		return nil, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	cropBox := inhPAttrs.CropBox
+	if cropBox == nil {
+		cropBox = inhPAttrs.MediaBox
+	}
+
+	return NewPageCoordinates(cropBox, inhPAttrs.Rotate)
+}
+
+// VisualDim returns the dimensions of the displayed page.
+func (pc PageCoordinates) VisualDim() types.Dim {
+	w, h := pc.CropBox.Width(), pc.CropBox.Height()
+	if pc.Rot == 90 || pc.Rot == 270 {
+		w, h = h, w
+	}
+	return types.Dim{Width: w, Height: h}
+}
+
+// Matrix returns the transform from visual coordinates into default user space.
+func (pc PageCoordinates) Matrix() matrix.Matrix {
+	llx, lly := pc.CropBox.LL.X, pc.CropBox.LL.Y
+	w, h := pc.CropBox.Width(), pc.CropBox.Height()
+
+	switch pc.Rot {
+	case 90:
+		return matrix.Matrix{{0, 1, 0}, {-1, 0, 0}, {llx + w, lly, 1}}
+	case 180:
+		return matrix.Matrix{{-1, 0, 0}, {0, -1, 0}, {llx + w, lly + h, 1}}
+	case 270:
+		return matrix.Matrix{{0, -1, 0}, {1, 0, 0}, {llx, lly + h, 1}}
+	}
+
+	return matrix.Matrix{{1, 0, 0}, {0, 1, 0}, {llx, lly, 1}}
+}
+
+// ToUserSpace converts the visual point p into default user space.
+func (pc PageCoordinates) ToUserSpace(p types.Point) types.Point {
+	return pc.Matrix().Transform(p)
+}
+
+// ToVisual converts the point p given in default user space into visual coordinates.
+func (pc PageCoordinates) ToVisual(p types.Point) types.Point {
+	dx, dy := p.X-pc.CropBox.LL.X, p.Y-pc.CropBox.LL.Y
+	w, h := pc.CropBox.Width(), pc.CropBox.Height()
+
+	switch pc.Rot {
+	case 90:
+		return types.Point{X: dy, Y: w - dx}
+	case 180:
+		return types.Point{X: w - dx, Y: h - dy}
+	case 270:
+		return types.Point{X: h - dy, Y: dx}
+	}
+
+	return types.Point{X: dx, Y: dy}
+}
+
+func normalizedRect(p1, p2 types.Point) *types.Rectangle {
+	return types.NewRectangle(math.Min(p1.X, p2.X), math.Min(p1.Y, p2.Y), math.Max(p1.X, p2.X), math.Max(p1.Y, p2.Y))
+}
+
+// RectToUserSpace converts the visual rectangle r into default user space.
+func (pc PageCoordinates) RectToUserSpace(r *types.Rectangle) *types.Rectangle {
+	return normalizedRect(pc.ToUserSpace(r.LL), pc.ToUserSpace(r.UR))
+}
+
+// RectToVisual converts the rectangle r given in default user space into visual coordinates.
+func (pc PageCoordinates) RectToVisual(r *types.Rectangle) *types.Rectangle {
+	return normalizedRect(pc.ToVisual(r.LL), pc.ToVisual(r.UR))
+}
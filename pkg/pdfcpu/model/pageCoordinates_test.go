@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestPageCoordinates(t *testing.T) {
+	cropBox := types.NewRectangle(10, 20, 210, 120) // 200 x 100
+
+	for _, tt := range []struct {
+		rot  int
+		w, h float64
+		ll   types.Point // visual origin in user space
+	}{
+		{0, 200, 100, types.Point{X: 10, Y: 20}},
+		{90, 100, 200, types.Point{X: 210, Y: 20}},
+		{-270, 100, 200, types.Point{X: 210, Y: 20}},
+		{180, 200, 100, types.Point{X: 210, Y: 120}},
+		{270, 100, 200, types.Point{X: 10, Y: 120}},
+	} {
+		pc, err := NewPageCoordinates(cropBox, tt.rot)
+		if err != nil {
+			t.Fatalf("rot %d: %v\n", tt.rot, err)
+		}
+
+		if dim := pc.VisualDim(); dim.Width != tt.w || dim.Height != tt.h {
+			t.Fatalf("rot %d: want %.0fx%.0f, got %v\n", tt.rot, tt.w, tt.h, dim)
+		}
+
+		if p := pc.ToUserSpace(types.Point{}); p != tt.ll {
+			t.Fatalf("rot %d: want visual origin at %v, got %v\n", tt.rot, tt.ll, p)
+		}
+
+		for _, p := range []types.Point{{X: 0, Y: 0}, {X: 5, Y: 7}, {X: tt.w, Y: tt.h}} {
+			if p1 := pc.ToVisual(pc.ToUserSpace(p)); p1 != p {
+				t.Fatalf("rot %d: want %v, got %v\n", tt.rot, p, p1)
+			}
+		}
+
+		// The displayed page covers the crop box.
+		r := pc.RectToUserSpace(types.NewRectangle(0, 0, tt.w, tt.h))
+		if !r.Equals(*cropBox) {
+			t.Fatalf("rot %d: want %v, got %v\n", tt.rot, cropBox, r)
+		}
+	}
+
+	if _, err := NewPageCoordinates(cropBox, 45); err == nil {
+		t.Fatal("want error for rotation 45")
+	}
+}
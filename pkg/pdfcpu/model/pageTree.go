@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// PageTreeMaxKids is the max number of kids per page tree node of a balanced page tree.
+const PageTreeMaxKids = 32
+
+// PageTreeShape describes the structure of a page tree.
+type PageTreeShape struct {
+	Depth   int // Max number of page tree nodes on the path from the root to a page.
+	MaxKids int // Max number of kids of a page tree node.
+	Nodes   int // Number of page tree nodes.
+	Pages   int // Number of pages.
+}
+
+// BalancedPageTreeDepth returns the depth of a balanced page tree for pageCount pages.
+func BalancedPageTreeDepth(pageCount int) int {
+	depth := 1
+	for n := PageTreeMaxKids; n < pageCount; n *= PageTreeMaxKids {
+		depth++
+	}
+	return depth
+}
+
+// Degenerate returns true if page access suffers from the shape of the page tree,
+// eg. thousands of kids of a single node or a linked list shaped tree.
+func (s PageTreeShape) Degenerate() bool {
+	return s.MaxKids > 8*PageTreeMaxKids || s.Depth > 2*BalancedPageTreeDepth(s.Pages)+2
+}
+
+func (xRefTable *XRefTable) pageTreeShape(ir types.IndirectRef, depth int, s *PageTreeShape, visited types.IntSet) error {
+	objNr := ir.ObjectNumber.Value()
+	if visited[objNr] {
+		return errors.Errorf("pdfcpu: page tree node referenced twice: obj #%d", objNr)
+	}
+	visited[objNr] = true
+
+	d, err := xRefTable.DereferenceDict(ir)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: corrupt page tree node: obj #%d", objNr)
+	}
+
+	kids := d.ArrayEntry("Kids")
+	if t := d.Type(); (t == nil || *t != "Pages") && kids == nil {
+		s.Pages++
+		return nil
+	}
+
+	s.Nodes++
+	if depth > s.Depth {
+		s.Depth = depth
+	}
+	if len(kids) > s.MaxKids {
+		s.MaxKids = len(kids)
+	}
+
+	for _, o := range kids {
+		kid, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		if err := xRefTable.pageTreeShape(kid, depth+1, s, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PageTreeShape analyzes the structure of the page tree.
+func (xRefTable *XRefTable) PageTreeShape() (*PageTreeShape, error) {
+	root, err := xRefTable.Pages()
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, errors.New("pdfcpu: missing page tree root")
+	}
+
+	s := &PageTreeShape{}
+	if err := xRefTable.pageTreeShape(*root, 1, s, types.IntSet{}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -355,6 +355,11 @@ func parseArray(c context.Context, line *string) (*types.Array, error) {
 		return nil, errArrayNotTerminated
 	}
 
+	c, err := nest(c)
+	if err != nil {
+		return nil, err
+	}
+
 	a := types.Array{}
 
 	for !strings.HasPrefix(l, "]") {
@@ -608,7 +613,7 @@ func processDictKeys(c context.Context, line *string, relaxed bool) (types.Dict,
 			// #252: For dicts with kv pairs terminated by eol we accept a missing value as an empty string.
 			val = types.StringLiteral("")
 		} else {
-			if val, err = ParseObject(&l); err != nil {
+			if val, err = ParseObjectContext(c, &l); err != nil {
 				return nil, err
 			}
 		}
@@ -663,6 +668,11 @@ func parseDict(c context.Context, line *string, relaxed bool) (types.Dict, error
 		return nil, errDictionaryNotTerminated
 	}
 
+	c, err := nest(c)
+	if err != nil {
+		return nil, err
+	}
+
 	d, err := processDictKeys(c, &l, relaxed)
 	if err != nil {
 		return nil, err
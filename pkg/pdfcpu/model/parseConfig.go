@@ -42,6 +42,7 @@ type configuration struct {
 	WriteXRefStream                 bool   `yaml:"writeXRefStream"`
 	EncryptUsingAES                 bool   `yaml:"encryptUsingAES"`
 	EncryptKeyLength                int    `yaml:"encryptKeyLength"`
+	EncryptEmbeddedFilesOnly        bool   `yaml:"encryptEmbeddedFilesOnly"`
 	Permissions                     int    `yaml:"permissions"`
 	Unit                            string `yaml:"unit"`
 	TimestampFormat                 string `yaml:"timestampFormat"`
@@ -73,6 +74,7 @@ func loadedConfig(c configuration, configPath string) *Configuration {
 	conf.WriteXRefStream = c.WriteXRefStream
 	conf.EncryptUsingAES = c.EncryptUsingAES
 	conf.EncryptKeyLength = c.EncryptKeyLength
+	conf.EncryptEmbeddedFilesOnly = c.EncryptEmbeddedFilesOnly
 	conf.Permissions = PermissionFlags(c.Permissions)
 
 	switch c.ValidationMode {
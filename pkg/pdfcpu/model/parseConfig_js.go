@@ -129,6 +129,15 @@ func handleConfEncryptUsingAES(k, v string, c *Configuration) error {
 	return nil
 }
 
+func handleConfEncryptEmbeddedFilesOnly(k, v string, c *Configuration) error {
+	v = strings.ToLower(v)
+	if v != "true" && v != "false" {
+		return errors.Errorf("config key %s is boolean", k)
+	}
+	c.EncryptEmbeddedFilesOnly = v == "true"
+	return nil
+}
+
 func handleConfEncryptKeyLength(v string, c *Configuration) error {
 	i, err := strconv.Atoi(v)
 	if err != nil {
@@ -282,6 +291,9 @@ func parseKeysPart2(k, v string, c *Configuration) (bool, error) {
 	case "encryptKeyLength":
 		return true, handleConfEncryptKeyLength(v, c)
 
+	case "encryptEmbeddedFilesOnly":
+		return true, handleConfEncryptEmbeddedFilesOnly(k, v, c)
+
 	case "permissions":
 		return true, handleConfPermissions(v, c)
 
@@ -50,3 +50,23 @@ func TestParseContent(t *testing.T) {
 		t.Fatalf("want:\n%s\ngot:\n%s\n", want, got)
 	}
 }
+
+func TestContentOpsRoundTrip(t *testing.T) {
+	s := `q 1 0 0 1 10.5 -2 cm /CS0 cs 0.2 0.4 0.6 scn/P0 scn [(a)-120(b)] TJ <00150015> Tj /a5 <</A <FEFF> /B [1 2.5]>> BDC
+	BI /IM true/W 1/CS/CS2/H 1/BPC 1 ID x
+EI Q [3 0] 0 d null true false 1 0 0 RG`
+
+	ops, err := ParseContentOps(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ParseContentOps(string(ContentOpsBytes(ops)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ops, got) {
+		t.Fatalf("want:\n%v\ngot:\n%v\n", ops, got)
+	}
+}
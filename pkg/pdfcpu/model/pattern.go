@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// PatternType represents the kind of a fill pattern.
+type PatternType int
+
+// These are the supported fill patterns.
+const (
+	PatternAxial   PatternType = iota // Axial gradient from C0 to C1 in direction Angle.
+	PatternRadial                     // Radial gradient from C0 in the center to C1.
+	PatternStripes                    // Tiling pattern of alternating stripes in C0 and C1.
+)
+
+var patternTypes = map[string]PatternType{
+	"axial":   PatternAxial,
+	"radial":  PatternRadial,
+	"stripes": PatternStripes,
+}
+
+// FillPattern describes a pattern used for filling text and shapes instead of a solid color.
+type FillPattern struct {
+	Type   PatternType
+	C0, C1 color.SimpleColor
+	Angle  float64 // Direction of axial gradients and stripes in degrees, 0 means left to right.
+	Width  float64 // Stripe width.
+}
+
+// ParseFillPattern parses a gradient fill spec:
+//
+//	axial <startColor> <endColor> [angle]
+//	radial <centerColor> <outerColor>
+//	stripes <color> <color> [angle [width]]
+//
+// Colors are color names or hex codes like #FF0000.
+func ParseFillPattern(s string) (*FillPattern, error) {
+	ss := strings.Fields(strings.ToLower(s))
+	if len(ss) < 3 {
+		return nil, errors.Errorf("pdfcpu: invalid gradient: %s", s)
+	}
+
+	t, ok := patternTypes[ss[0]]
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: invalid gradient type: %s, please use one of axial, radial, stripes", ss[0])
+	}
+
+	fp := FillPattern{Type: t, Width: 5}
+
+	var err error
+	if fp.C0, err = color.ParseColor(ss[1]); err != nil {
+		return nil, err
+	}
+	if fp.C1, err = color.ParseColor(ss[2]); err != nil {
+		return nil, err
+	}
+
+	maxArgs := map[PatternType]int{PatternAxial: 4, PatternRadial: 3, PatternStripes: 5}[t]
+	if len(ss) > maxArgs {
+		return nil, errors.Errorf("pdfcpu: invalid gradient: %s", s)
+	}
+
+	if len(ss) > 3 {
+		if fp.Angle, err = strconv.ParseFloat(ss[3], 64); err != nil {
+			return nil, errors.Errorf("pdfcpu: invalid gradient angle: %s", ss[3])
+		}
+	}
+
+	if len(ss) > 4 {
+		if fp.Width, err = strconv.ParseFloat(ss[4], 64); err != nil || fp.Width <= 0 {
+			return nil, errors.Errorf("pdfcpu: invalid stripe width: %s", ss[4])
+		}
+	}
+
+	return &fp, nil
+}
+
+func colorArray(c color.SimpleColor) types.Array {
+	return types.NewNumberArray(float64(c.R), float64(c.G), float64(c.B))
+}
+
+func (fp FillPattern) shadingDict(r *types.Rectangle) types.Dict {
+	var coords types.Array
+
+	cx, cy := r.LL.X+r.Width()/2, r.LL.Y+r.Height()/2
+
+	if fp.Type == PatternRadial {
+		coords = types.NewNumberArray(cx, cy, 0, cx, cy, math.Hypot(r.Width(), r.Height())/2)
+	} else {
+		// Span r in direction Angle.
+		sin, cos := math.Sincos(fp.Angle * matrix.DegToRad)
+		h := (r.Width()*math.Abs(cos) + r.Height()*math.Abs(sin)) / 2
+		coords = types.NewNumberArray(cx-h*cos, cy-h*sin, cx+h*cos, cy+h*sin)
+	}
+
+	st := 2
+	if fp.Type == PatternRadial {
+		st = 3
+	}
+
+	return types.Dict{
+		"ShadingType": types.Integer(st),
+		"ColorSpace":  types.Name("DeviceRGB"),
+		"Coords":      coords,
+		"Function": types.Dict{
+			"FunctionType": types.Integer(2),
+			"Domain":       types.NewNumberArray(0, 1),
+			"C0":           colorArray(fp.C0),
+			"C1":           colorArray(fp.C1),
+			"N":            types.Float(1),
+		},
+		"Extend": types.Array{types.Boolean(true), types.Boolean(true)},
+	}
+}
+
+func (fp FillPattern) tilingPattern(xRefTable *XRefTable, m matrix.Matrix) (*types.IndirectRef, error) {
+	w := fp.Width
+
+	// A tile holds one stripe of either color.
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%.2f %.2f %.2f rg 0 0 %.2f %.2f re f ", fp.C0.R, fp.C0.G, fp.C0.B, w, 2*w)
+	fmt.Fprintf(&b, "%.2f %.2f %.2f rg %.2f 0 %.2f %.2f re f", fp.C1.R, fp.C1.G, fp.C1.B, w, w, 2*w)
+
+	sd, err := xRefTable.NewStreamDictForBuf(b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// Stripes run perpendicular to the pattern direction.
+	sin, cos := math.Sincos(fp.Angle * matrix.DegToRad)
+	m = matrix.CalcTransformMatrix(1, 1, sin, cos, 0, 0).Multiply(m)
+
+	sd.InsertName("Type", "Pattern")
+	sd.InsertInt("PatternType", 1)
+	sd.InsertInt("PaintType", 1)
+	sd.InsertInt("TilingType", 1)
+	sd.Insert("BBox", types.NewNumberArray(0, 0, 2*w, 2*w))
+	sd.InsertFloat("XStep", float32(2*w))
+	sd.InsertFloat("YStep", float32(2*w))
+	sd.Insert("Resources", types.Dict{})
+	sd.Insert("Matrix", types.NewNumberArray(m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1]))
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
+
+// NewFillPattern creates a pattern dict for filling r with fp.
+// m is the transformation from the space of r into the default coordinate space of the page or form using the pattern.
+func (xRefTable *XRefTable) NewFillPattern(fp FillPattern, r *types.Rectangle, m matrix.Matrix) (*types.IndirectRef, error) {
+	if fp.Type == PatternStripes {
+		return fp.tilingPattern(xRefTable, m)
+	}
+
+	d := types.Dict{
+		"Type":        types.Name("Pattern"),
+		"PatternType": types.Integer(2),
+		"Shading":     fp.shadingDict(r),
+	}
+	if m != matrix.IdentMatrix {
+		d["Matrix"] = types.NewNumberArray(m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
+	}
+
+	return xRefTable.IndRefForNewObject(d)
+}
@@ -0,0 +1,500 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// PortfolioField is a column of a portfolio's details view.
+type PortfolioField struct {
+	Key      string // Schema key, also used for custom values in PortfolioItem.Fields.
+	Name     string // Column header.
+	Subtype  string // S, D, N for custom text, date and number fields; F, Desc, ModDate, CreationDate, Size for file properties.
+	Order    int    // Relative column order.
+	Hidden   bool
+	Editable bool
+}
+
+// PortfolioItem is a document embedded into a portfolio.
+type PortfolioItem struct {
+	Attachment
+	Fields map[string]interface{} // Custom field values: string, time.Time or float64.
+}
+
+// Portfolio represents a PDF collection made up of a cover sheet and embedded documents.
+type Portfolio struct {
+	View      string // Initial view: D(etails), T(ile), H(idden)
+	Initial   string // ID of the document initially presented instead of the cover sheet.
+	Fields    []PortfolioField
+	Sort      []string // Field keys to sort by.
+	Ascending []bool   // Sort order for each key of Sort, defaults to ascending.
+	Items     []PortfolioItem
+}
+
+// DefaultPortfolioFields is the schema used when no fields are configured.
+var DefaultPortfolioFields = []PortfolioField{
+	{Key: "FileName", Name: "Filename", Subtype: "F", Order: 1},
+	{Key: "Description", Name: "Description", Subtype: "Desc", Order: 2},
+	{Key: "Size", Name: "Size", Subtype: "Size", Order: 3},
+	{Key: "ModDate", Name: "Last Modification", Subtype: "ModDate", Order: 4},
+}
+
+var portfolioFieldSubtypes = []string{"S", "D", "N", "F", "Desc", "ModDate", "CreationDate", "Size"}
+
+func (p *Portfolio) field(key string) *PortfolioField {
+	for i := range p.Fields {
+		if p.Fields[i].Key == key {
+			return &p.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Validate checks p and applies defaults.
+func (p *Portfolio) Validate() error {
+	if p.View == "" {
+		p.View = "D"
+	}
+	if !types.MemberOf(p.View, []string{"D", "T", "H"}) {
+		return errors.Errorf("pdfcpu: portfolio: invalid view: %s", p.View)
+	}
+
+	if len(p.Fields) == 0 {
+		p.Fields = append([]PortfolioField(nil), DefaultPortfolioFields...)
+	}
+
+	keys := types.StringSet{}
+	for _, f := range p.Fields {
+		if f.Key == "" {
+			return errors.New("pdfcpu: portfolio: missing field key")
+		}
+		if keys[f.Key] {
+			return errors.Errorf("pdfcpu: portfolio: duplicate field: %s", f.Key)
+		}
+		keys[f.Key] = true
+		if !types.MemberOf(f.Subtype, portfolioFieldSubtypes) {
+			return errors.Errorf("pdfcpu: portfolio: field %s: invalid subtype: %s", f.Key, f.Subtype)
+		}
+	}
+
+	for _, k := range p.Sort {
+		if !keys[k] {
+			return errors.Errorf("pdfcpu: portfolio: unknown sort field: %s", k)
+		}
+	}
+	if len(p.Ascending) > len(p.Sort) {
+		return errors.New("pdfcpu: portfolio: more sort directions than sort fields")
+	}
+
+	ids := types.StringSet{}
+	for _, it := range p.Items {
+		if it.ID == "" {
+			return errors.New("pdfcpu: portfolio: missing item id")
+		}
+		if ids[it.ID] {
+			return errors.Errorf("pdfcpu: portfolio: duplicate item: %s", it.ID)
+		}
+		ids[it.ID] = true
+		for k, v := range it.Fields {
+			f := p.field(k)
+			if f == nil {
+				return errors.Errorf("pdfcpu: portfolio: item %s: unknown field: %s", it.ID, k)
+			}
+			if _, err := collectionItemValue(f.Subtype, v); err != nil {
+				return errors.Wrapf(err, "pdfcpu: portfolio: item %s", it.ID)
+			}
+		}
+	}
+
+	if p.Initial != "" && !ids[p.Initial] {
+		return errors.Errorf("pdfcpu: portfolio: unknown initial document: %s", p.Initial)
+	}
+
+	return nil
+}
+
+func collectionItemValue(subtype string, v interface{}) (types.Object, error) {
+	switch subtype {
+
+	case "S":
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.Errorf("text expected: %v", v)
+		}
+		s1, err := types.EscapedUTF16String(s)
+		if err != nil {
+			return nil, err
+		}
+		return types.StringLiteral(*s1), nil
+
+	case "D":
+		t, ok := v.(time.Time)
+		if !ok {
+			return nil, errors.Errorf("date expected: %v", v)
+		}
+		return types.StringLiteral(types.DateString(t)), nil
+
+	case "N":
+		switch n := v.(type) {
+		case int:
+			return types.Integer(n), nil
+		case float64:
+			return types.Float(n), nil
+		}
+		return nil, errors.Errorf("number expected: %v", v)
+	}
+
+	return nil, errors.Errorf("no custom values for field subtype: %s", subtype)
+}
+
+func (xRefTable *XRefTable) newCollectionDict(p *Portfolio) (types.Dict, error) {
+	schemaDict := types.NewDict()
+	schemaDict.InsertName("Type", "CollectionSchema")
+	for _, f := range p.Fields {
+		d := types.NewDict()
+		d.InsertName("Type", "CollectionField")
+		d.InsertName("Subtype", f.Subtype)
+		s, err := types.EscapedUTF16String(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		d.InsertString("N", *s)
+		if f.Order != 0 {
+			d.InsertInt("O", f.Order)
+		}
+		if f.Hidden {
+			d.Insert("V", types.Boolean(false))
+		}
+		if f.Editable {
+			d.Insert("E", types.Boolean(true))
+		}
+		schemaDict.Insert(f.Key, d)
+	}
+
+	ir, err := xRefTable.IndRefForNewObject(schemaDict)
+	if err != nil {
+		return nil, err
+	}
+
+	d := types.NewDict()
+	d.InsertName("Type", "Collection")
+	d.Insert("Schema", *ir)
+	d.InsertName("View", p.View)
+
+	if p.Initial != "" {
+		s, err := types.EscapedUTF16String(p.Initial)
+		if err != nil {
+			return nil, err
+		}
+		d.InsertString("D", *s)
+	}
+
+	if len(p.Sort) > 0 {
+		sortDict := types.NewDict()
+		if len(p.Sort) == 1 {
+			sortDict.InsertName("S", p.Sort[0])
+		} else {
+			sortDict.Insert("S", types.NewNameArray(p.Sort...))
+		}
+		a := types.Array{}
+		for i := range p.Sort {
+			a = append(a, types.Boolean(i >= len(p.Ascending) || p.Ascending[i]))
+		}
+		if len(a) == 1 {
+			sortDict.Insert("A", a[0])
+		} else {
+			sortDict.Insert("A", a)
+		}
+		d.Insert("Sort", sortDict)
+	}
+
+	return d, nil
+}
+
+func (ctx *Context) addPortfolioItem(p *Portfolio, it PortfolioItem) error {
+	xRefTable := ctx.XRefTable
+
+	d, err := xRefTable.NewFileSpecDictForAttachment(it.Attachment)
+	if err != nil {
+		return err
+	}
+
+	ciDict := types.NewDict()
+	ciDict.InsertName("Type", "CollectionItem")
+	for k, v := range it.Fields {
+		o, err := collectionItemValue(p.field(k).Subtype, v)
+		if err != nil {
+			return err
+		}
+		ciDict.Insert(k, o)
+	}
+	d["CI"] = ciDict
+
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+
+	m := NameMap{it.ID: []types.Dict{d}}
+
+	return xRefTable.Names["EmbeddedFiles"].Add(xRefTable, it.ID, *ir, m, []string{"F", "UF"})
+}
+
+// AddPortfolio turns ctx into a portfolio using ctx's pages as cover sheet.
+// Any existing collection gets replaced.
+func (ctx *Context) AddPortfolio(p *Portfolio) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	xRefTable := ctx.XRefTable
+	if err := xRefTable.LocateNameTree("EmbeddedFiles", true); err != nil {
+		return err
+	}
+
+	for _, it := range p.Items {
+		if it.Reader == nil {
+			return errors.Errorf("pdfcpu: portfolio: item %s: missing data", it.ID)
+		}
+		if err := ctx.addPortfolioItem(p, it); err != nil {
+			return err
+		}
+	}
+
+	d, err := xRefTable.newCollectionDict(p)
+	if err != nil {
+		return err
+	}
+
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return err
+	}
+	rootDict["Collection"] = *ir
+
+	// Collections are available since PDF 1.7
+	xRefTable.EnsureVersionForWriting()
+
+	return nil
+}
+
+func (xRefTable *XRefTable) portfolioFields(d types.Dict) ([]PortfolioField, error) {
+	ff := []PortfolioField{}
+
+	for k, v := range d {
+		if k == "Type" {
+			continue
+		}
+		d1, err := xRefTable.DereferenceDict(v)
+		if err != nil {
+			return nil, err
+		}
+		if d1 == nil {
+			continue
+		}
+		f := PortfolioField{Key: k}
+		if st := d1.NameEntry("Subtype"); st != nil {
+			f.Subtype = *st
+		}
+		if o, found := d1.Find("N"); found {
+			if f.Name, err = xRefTable.DereferenceStringOrHexLiteral(o, V10, nil); err != nil {
+				return nil, err
+			}
+		}
+		if i := d1.IntEntry("O"); i != nil {
+			f.Order = *i
+		}
+		if b := d1.BooleanEntry("V"); b != nil {
+			f.Hidden = !*b
+		}
+		if b := d1.BooleanEntry("E"); b != nil {
+			f.Editable = *b
+		}
+		ff = append(ff, f)
+	}
+
+	sort.Slice(ff, func(i, j int) bool {
+		if ff[i].Order != ff[j].Order {
+			return ff[i].Order < ff[j].Order
+		}
+		return ff[i].Key < ff[j].Key
+	})
+
+	return ff, nil
+}
+
+func (xRefTable *XRefTable) portfolioSort(p *Portfolio, d types.Dict) error {
+	o, err := xRefTable.Dereference(d["S"])
+	if err != nil {
+		return err
+	}
+	switch o := o.(type) {
+	case types.Name:
+		p.Sort = []string{o.Value()}
+	case types.Array:
+		for _, o1 := range o {
+			if n, ok := o1.(types.Name); ok {
+				p.Sort = append(p.Sort, n.Value())
+			}
+		}
+	}
+
+	if o, err = xRefTable.Dereference(d["A"]); err != nil {
+		return err
+	}
+	switch o := o.(type) {
+	case types.Boolean:
+		p.Ascending = []bool{o.Value()}
+	case types.Array:
+		for _, o1 := range o {
+			if b, ok := o1.(types.Boolean); ok {
+				p.Ascending = append(p.Ascending, b.Value())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (xRefTable *XRefTable) portfolioItemValue(subtype string, o types.Object) (interface{}, error) {
+	o, err := xRefTable.Dereference(o)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	if d, ok := o.(types.Dict); ok {
+		// Collection subitem
+		if o, err = xRefTable.Dereference(d["D"]); err != nil || o == nil {
+			return nil, err
+		}
+	}
+
+	switch o := o.(type) {
+	case types.Integer:
+		return float64(o.Value()), nil
+	case types.Float:
+		return o.Value(), nil
+	}
+
+	s, err := xRefTable.DereferenceStringOrHexLiteral(o, V10, nil)
+	if err != nil {
+		return nil, err
+	}
+	if subtype == "D" {
+		if t, ok := types.DateTime(s, true); ok {
+			return t, nil
+		}
+	}
+
+	return s, nil
+}
+
+// Portfolio returns ctx's collection including stubs for all embedded documents.
+func (ctx *Context) Portfolio() (*Portfolio, error) {
+	xRefTable := ctx.XRefTable
+
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := xRefTable.DereferenceDict(rootDict["Collection"])
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.New("pdfcpu: no portfolio available")
+	}
+
+	p := &Portfolio{View: "D"}
+
+	if n := d.NameEntry("View"); n != nil {
+		p.View = *n
+	}
+
+	if o, found := d.Find("D"); found {
+		if p.Initial, err = xRefTable.DereferenceStringOrHexLiteral(o, V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	d1, err := xRefTable.DereferenceDict(d["Schema"])
+	if err != nil {
+		return nil, err
+	}
+	if d1 != nil {
+		if p.Fields, err = xRefTable.portfolioFields(d1); err != nil {
+			return nil, err
+		}
+	}
+
+	if d1, err = xRefTable.DereferenceDict(d["Sort"]); err != nil {
+		return nil, err
+	}
+	if d1 != nil {
+		if err := xRefTable.portfolioSort(p, d1); err != nil {
+			return nil, err
+		}
+	}
+
+	aa, err := ctx.ListAttachments()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range aa {
+		it := PortfolioItem{Attachment: a, Fields: map[string]interface{}{}}
+
+		o, _ := ctx.Names["EmbeddedFiles"].Value(a.ID)
+		fsDict, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		ciDict, err := xRefTable.DereferenceDict(fsDict["CI"])
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range ciDict {
+			f := p.field(k)
+			if f == nil || !types.MemberOf(f.Subtype, []string{"S", "D", "N"}) {
+				continue
+			}
+			val, err := xRefTable.portfolioItemValue(f.Subtype, v)
+			if err != nil {
+				return nil, err
+			}
+			if val != nil {
+				it.Fields[k] = val
+			}
+		}
+
+		p.Items = append(p.Items, it)
+	}
+
+	return p, nil
+}
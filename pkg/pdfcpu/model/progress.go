@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// ProgressFunc gets called repeatedly during a processing stage with done out of total units processed.
+// Units are objects for reading and writing and pages for optimizing and extracting.
+// A ProgressFunc is called synchronously and should return quickly.
+type ProgressFunc func(stage string, done, total int)
+
+// Processing stages reported to ProgressFunc.
+const (
+	StageRead     = "read"
+	StageOptimize = "optimize"
+	StageWrite    = "write"
+	StageExtract  = "extract"
+)
+
+// ReportProgress passes progress of stage to the configured ProgressFunc, if any.
+func (c *Configuration) ReportProgress(stage string, done, total int) {
+	if c != nil && c.Progress != nil {
+		c.Progress(stage, done, total)
+	}
+}
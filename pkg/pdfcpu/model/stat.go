@@ -50,6 +50,7 @@ const (
 	RootRequirements
 	RootCollection
 	RootNeedsRendering
+	RootAF
 )
 
 // The PDF page object fields.
@@ -82,6 +83,7 @@ const (
 	PagePresSteps
 	PageUserUnit
 	PageVP
+	PageAF
 )
 
 // PDFStats is a container for stats.
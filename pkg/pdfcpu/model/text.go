@@ -23,6 +23,7 @@ import (
 	"math"
 	"strings"
 	"unicode"
+	"unicode/utf16"
 	"unicode/utf8"
 
 	"github.com/pdfcpu/pdfcpu/pkg/font"
@@ -53,6 +54,7 @@ type TextDescriptor struct {
 	RMode          draw.RenderMode     // Text render mode
 	StrokeCol      color.SimpleColor   // Stroke color to be used for rendering text corresponding to RMode.
 	FillCol        color.SimpleColor   // Fill color to be used for rendering text corresponding to RMode.
+	FillPattern    string              // Resource id of a pattern used for filling text instead of FillCol.
 	ShowTextBB     bool                // Render bounding box including BackgroundCol, border and margins.
 	ShowBackground bool                // Render background of bounding box using BackgroundCol.
 	BackgroundCol  color.SimpleColor   // Bounding box fill color.
@@ -61,6 +63,8 @@ type TextDescriptor struct {
 	BorderStyle    types.LineJoinStyle // Border style, also visible if ShowBorder is false as long as ShowBackground is true.
 	BorderCol      color.SimpleColor   // Border color.
 	ParIndent      bool                // Indent first line of paragraphs or space between paragraphs.
+	Wrap           bool                // Break lines at Unicode line break opportunities to fit the column width.
+	Hyphenation    string              // Language used for hyphenating wrapped lines eg. "en-us".
 	ShowLineBB     bool                // Render line bounding boxes in black (for HAlign != AlignJustify only)
 	ShowMargins    bool                // Render margins in light gray.
 	ShowPosition   bool                // Highlight position.
@@ -181,9 +185,10 @@ func PrepBytes(xRefTable *XRefTable, s, fontName string, embed, rtl, fillFont bo
 		}
 		bb := []byte{}
 		if !embed {
-			for _, r := range s {
+			// UTF-16 as expected by the Uni*-UTF16-H CMaps, characters beyond the BMP take surrogate pairs.
+			for _, u := range utf16.Encode([]rune(s)) {
 				b := make([]byte, 2)
-				binary.BigEndian.PutUint16(b, uint16(r))
+				binary.BigEndian.PutUint16(b, u)
 				bb = append(bb, b...)
 			}
 		} else {
@@ -213,10 +218,18 @@ func PrepBytes(xRefTable *XRefTable, s, fontName string, embed, rtl, fillFont bo
 	return *s1
 }
 
+// textFill returns the operators setting the fill color or pattern for td.
+func textFill(td TextDescriptor) string {
+	if td.FillPattern != "" {
+		return fmt.Sprintf("/Pattern cs /%s scn", td.FillPattern)
+	}
+	return fmt.Sprintf("%.2f %.2f %.2f rg", td.FillCol.R, td.FillCol.G, td.FillCol.B)
+}
+
 func writeStringToBuf(xRefTable *XRefTable, w io.Writer, s string, x, y float64, td TextDescriptor) {
 	s = PrepBytes(xRefTable, s, td.FontName, td.Embed, td.RTL, false)
-	fmt.Fprintf(w, "BT 0 Tw %.2f %.2f %.2f RG %.2f %.2f %.2f rg %.2f %.2f Td %d Tr (%s) Tj ET ",
-		td.StrokeCol.R, td.StrokeCol.G, td.StrokeCol.B, td.FillCol.R, td.FillCol.G, td.FillCol.B, x, y, td.RMode, s)
+	fmt.Fprintf(w, "BT 0 Tw %.2f %.2f %.2f RG %s %.2f %.2f Td %d Tr (%s) Tj ET ",
+		td.StrokeCol.R, td.StrokeCol.G, td.StrokeCol.B, textFill(td), x, y, td.RMode, s)
 }
 
 func setFont(w io.Writer, fontID string, fontSize float32) {
@@ -363,18 +376,7 @@ func preRenderJustifiedText(
 	mLeft, mRight, borderWidth float64,
 	fontSize *int) float64 {
 
-	var ww float64
-	if !td.ScaleAbs {
-		ww = r.Width() * td.Scale
-	} else {
-		if width > 0 {
-			ww = width * td.Scale
-		} else {
-			box, _ := calcBoundingBoxForLines(*lines, x, y, td.FontName, *fontSize)
-			ww = box.Width() * td.Scale
-		}
-	}
-	ww -= mLeft + mRight + 2*borderWidth
+	ww := justifiedWidth(*lines, r, x, y, width, td, mLeft, mRight, borderWidth, *fontSize)
 	prepJustifiedString := newPrepJustifiedString(xRefTable, td.FontName, *fontSize)
 	l := []string{}
 	for i, s := range *lines {
@@ -391,6 +393,22 @@ func preRenderJustifiedText(
 	return ww
 }
 
+// justifiedWidth returns the net line width for justified text.
+func justifiedWidth(lines []string, r *types.Rectangle, x, y, width float64, td TextDescriptor, mLeft, mRight, borderWidth float64, fontSize int) float64 {
+	var ww float64
+	if !td.ScaleAbs {
+		ww = r.Width() * td.Scale
+	} else {
+		if width > 0 {
+			ww = width * td.Scale
+		} else {
+			box, _ := calcBoundingBoxForLines(lines, x, y, td.FontName, fontSize)
+			ww = box.Width() * td.Scale
+		}
+	}
+	return ww - (mLeft + mRight + 2*borderWidth)
+}
+
 func scaleFontSize(r *types.Rectangle, lines []string, scaleAbs bool,
 	scale, width, x, y, mLeft, mRight, borderWidth float64,
 	fontName string, fontSize *int) {
@@ -458,11 +476,22 @@ func createBoundingBoxForColumn(xRefTable *XRefTable, r *types.Rectangle, x, y *
 
 	var ww float64
 	if td.HAlign == types.AlignJustify {
-		ww = preRenderJustifiedText(xRefTable, lines, r, *x, *y, width, td, mLeft, mRight, borderWidth, fontSize)
+		if td.Wrap {
+			ww = justifiedWidth(*lines, r, *x, *y, width, td, mLeft, mRight, borderWidth, *fontSize)
+			*lines = prepWrappedJustifiedText(xRefTable, *lines, ww, td, *fontSize)
+		} else {
+			ww = preRenderJustifiedText(xRefTable, lines, r, *x, *y, width, td, mLeft, mRight, borderWidth, fontSize)
+		}
 	}
 
 	if td.HAlign != types.AlignJustify {
 		scaleFontSize(r, *lines, td.ScaleAbs, td.Scale, width, *x, *y, mLeft, mRight, borderWidth, td.FontName, fontSize)
+		if td.Wrap {
+			*lines, _ = wrapLines(*lines, td.FontName, *fontSize, width-mLeft-mRight-2*borderWidth, td.Hyphenation, false, false)
+			for i, s := range *lines {
+				(*lines)[i] = decodeForFont(s, td.FontName)
+			}
+		}
 	}
 
 	// Apply vertical alignment.
@@ -493,9 +522,9 @@ func createBoundingBoxForColumn(xRefTable *XRefTable, r *types.Rectangle, x, y *
 	return box
 }
 
-func flushJustifiedStringToBuf(w io.Writer, s string, x, y float64, strokeCol, fillCol color.SimpleColor, rm draw.RenderMode) {
-	fmt.Fprintf(w, "BT 0 Tw %.2f %.2f %.2f RG %.2f %.2f %.2f rg %.2f %.2f Td %d Tr %s ET ",
-		strokeCol.R, strokeCol.G, strokeCol.B, fillCol.R, fillCol.G, fillCol.B, x, y, rm, s)
+func flushJustifiedStringToBuf(w io.Writer, s string, x, y float64, td TextDescriptor) {
+	fmt.Fprintf(w, "BT 0 Tw %.2f %.2f %.2f RG %s %.2f %.2f Td %d Tr %s ET ",
+		td.StrokeCol.R, td.StrokeCol.G, td.StrokeCol.B, textFill(td), x, y, td.RMode, s)
 }
 
 func scaleXForRegion(x float64, mediaBox, region *types.Rectangle) float64 {
@@ -573,7 +602,7 @@ func renderText(xRefTable *XRefTable, w io.Writer, lines []string, td TextDescri
 		}
 
 		if len(s) > 0 {
-			flushJustifiedStringToBuf(w, s, x, y, td.StrokeCol, td.FillCol, td.RMode)
+			flushJustifiedStringToBuf(w, s, x, y, td)
 		}
 		y -= lh
 	}
@@ -774,7 +803,10 @@ func WriteColumn(xRefTable *XRefTable, w io.Writer, mediaBox, region *types.Rect
 	// Cache haircross coordinates.
 	x0, y0 := x, y
 
-	if font.IsCoreFont(td.FontName) && utf8.ValidString(s) {
+	// Wrapping needs a column width and takes care of decoding.
+	td.Wrap = td.Wrap && (width > 0 || td.HAlign == types.AlignJustify && !td.ScaleAbs)
+
+	if !td.Wrap && font.IsCoreFont(td.FontName) && utf8.ValidString(s) {
 		s = DecodeUTF8ToByte(s)
 	}
 
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"unicode/utf8"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TextPath describes a baseline along which text gets laid out glyph by glyph.
+//
+// The baseline is either a circle (Radius > 0) or a polyline through Points.
+type TextPath struct {
+	Points    []types.Point // Polyline baseline.
+	Center    types.Point   // Center of a circular baseline.
+	Radius    float64       // Radius of a circular baseline.
+	Angle     float64       // Position of the text center on a circular baseline in degrees, 90 means top.
+	Inside    bool          // Run counter clockwise with glyph tops pointing to the center, eg. for the bottom line of a seal.
+	Rotations []float64     // Optional additional rotation in degrees for each glyph.
+}
+
+// glyph is a single glyph ready to be placed.
+type glyph struct {
+	s string  // The glyph as it is passed to PrepBytes.
+	w float64 // Glyph width in user space.
+}
+
+func glyphs(s, fontName string, fontSize int, rtl bool) []glyph {
+	var gg []glyph
+
+	if font.IsCoreFont(fontName) {
+		if utf8.ValidString(s) {
+			s = DecodeUTF8ToByte(s)
+		}
+		for i := 0; i < len(s); i++ {
+			gg = append(gg, glyph{s: s[i : i+1], w: font.TextWidth(s[i:i+1], fontName, fontSize)})
+		}
+	} else {
+		for _, r := range s {
+			gg = append(gg, glyph{s: string(r), w: font.TextWidth(string(r), fontName, fontSize)})
+		}
+	}
+
+	if rtl {
+		for i, j := 0, len(gg)-1; i < j; i, j = i+1, j-1 {
+			gg[i], gg[j] = gg[j], gg[i]
+		}
+	}
+
+	return gg
+}
+
+func (p TextPath) length() float64 {
+	if p.Radius > 0 {
+		return 2 * math.Pi * p.Radius
+	}
+	l := 0.
+	for i := 1; i < len(p.Points); i++ {
+		l += math.Hypot(p.Points[i].X-p.Points[i-1].X, p.Points[i].Y-p.Points[i-1].Y)
+	}
+	return l
+}
+
+// at returns the point and the baseline direction in radians at arc length s from the start of the polyline.
+// Positions beyond either end extend the first or last segment.
+func (p TextPath) at(s float64) (types.Point, float64) {
+	pp := p.Points
+	for i := 1; i < len(pp); i++ {
+		p0, p1 := pp[i-1], pp[i]
+		l := math.Hypot(p1.X-p0.X, p1.Y-p0.Y)
+		if l == 0 {
+			continue
+		}
+		if s <= l || i == len(pp)-1 {
+			dx, dy := (p1.X-p0.X)/l, (p1.Y-p0.Y)/l
+			return types.Point{X: p0.X + s*dx, Y: p0.Y + s*dy}, math.Atan2(dy, dx)
+		}
+		s -= l
+	}
+	return pp[0], 0
+}
+
+// atCircle returns the point and the baseline direction in radians at arc length s
+// for a text of width w centered around p.Angle.
+func (p TextPath) atCircle(s, w float64) (types.Point, float64) {
+	a := p.Angle * matrix.DegToRad
+	if p.Inside {
+		a += (s - w/2) / p.Radius
+	} else {
+		a -= (s - w/2) / p.Radius
+	}
+
+	sin, cos := math.Sincos(a)
+	pt := types.Point{X: p.Center.X + p.Radius*cos, Y: p.Center.Y + p.Radius*sin}
+
+	if p.Inside {
+		return pt, a + math.Pi/2
+	}
+	return pt, a - math.Pi/2
+}
+
+// WriteTextOnPath renders the first line of td.Text along p placing each glyph according to its font metrics.
+// For polylines td.HAlign aligns the text with the start, center or end of the path.
+// td.FontKey has to be set. It returns the bounding box of all glyphs.
+func WriteTextOnPath(xRefTable *XRefTable, w io.Writer, td TextDescriptor, p TextPath) (*types.Rectangle, error) {
+	if td.FontKey == "" {
+		return nil, errors.New("pdfcpu: WriteTextOnPath: missing font key")
+	}
+	if p.Radius <= 0 && len(p.Points) < 2 {
+		return nil, errors.New("pdfcpu: WriteTextOnPath: text path needs a radius or at least 2 points")
+	}
+
+	lines := SplitMultilineStr(td.Text)
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, nil
+	}
+
+	gg := glyphs(lines[0], td.FontName, td.FontSize, td.RTL)
+	if len(p.Rotations) > 0 && len(p.Rotations) != len(gg) {
+		return nil, errors.Errorf("pdfcpu: WriteTextOnPath: want %d glyph rotations, got %d", len(gg), len(p.Rotations))
+	}
+
+	tw := 0.
+	for _, g := range gg {
+		tw += g.w
+	}
+
+	var s float64
+	if p.Radius <= 0 {
+		switch td.HAlign {
+		case types.AlignCenter:
+			s = (p.length() - tw) / 2
+		case types.AlignRight:
+			s = p.length() - tw
+		}
+	}
+
+	asc, desc := font.Ascent(td.FontName, td.FontSize), font.Descent(td.FontName, td.FontSize)
+
+	var bb *types.Rectangle
+
+	fmt.Fprintf(w, "q BT /%s %d Tf %.2f %.2f %.2f RG %s %d Tr ",
+		td.FontKey, td.FontSize, td.StrokeCol.R, td.StrokeCol.G, td.StrokeCol.B, textFill(td), td.RMode)
+
+	for i, g := range gg {
+		// Position the glyph center on the path.
+		var (
+			pt types.Point
+			a  float64
+		)
+		if p.Radius > 0 {
+			pt, a = p.atCircle(s+g.w/2, tw)
+		} else {
+			pt, a = p.at(s + g.w/2)
+		}
+		if len(p.Rotations) > 0 {
+			a += p.Rotations[i] * matrix.DegToRad
+		}
+
+		sin, cos := math.Sincos(a)
+		x, y := pt.X-g.w/2*cos, pt.Y-g.w/2*sin
+
+		// Include the glyph corners.
+		for _, c := range [][2]float64{{0, -desc}, {g.w, -desc}, {g.w, asc}, {0, asc}} {
+			q := types.Point{X: x + c[0]*cos - c[1]*sin, Y: y + c[0]*sin + c[1]*cos}
+			if bb == nil {
+				bb = types.NewRectangle(q.X, q.Y, q.X, q.Y)
+				continue
+			}
+			bb = calcBoundingBoxForRectAndPoint(bb, q)
+		}
+
+		fmt.Fprintf(w, "%.5f %.5f %.5f %.5f %.2f %.2f Tm (%s) Tj ",
+			cos, sin, -sin, cos, x, y, PrepBytes(xRefTable, g.s, td.FontName, td.Embed, false, false))
+
+		s += g.w
+	}
+
+	fmt.Fprint(w, "ET Q ")
+
+	return bb, nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ErrTransactionClosed indicates the use of a transaction that has already been committed or rolled back.
+var ErrTransactionClosed = errors.New("pdfcpu: transaction already committed or rolled back")
+
+// Transaction is a set of object level edits applied to a Context.
+// It keeps track of all touched objects so that an incremental writer knows exactly what changed
+// and validation may be restricted to the touched subtrees.
+type Transaction struct {
+	ctx     *Context
+	saved   map[int]*XRefTableEntry // original entries for rollback
+	dirty   types.IntSet            // replaced or deleted objects
+	deleted types.IntSet            // deleted objects
+	closed  bool
+}
+
+// Begin starts a new transaction on ctx.
+func (ctx *Context) Begin() *Transaction {
+	return &Transaction{
+		ctx:     ctx,
+		saved:   map[int]*XRefTableEntry{},
+		dirty:   types.IntSet{},
+		deleted: types.IntSet{},
+	}
+}
+
+func copyXRefTableEntry(e *XRefTableEntry) *XRefTableEntry {
+	e1 := *e
+	if e.Offset != nil {
+		off := *e.Offset
+		e1.Offset = &off
+	}
+	if e.Generation != nil {
+		gen := *e.Generation
+		e1.Generation = &gen
+	}
+	return &e1
+}
+
+func (tx *Transaction) save(objNr int, e *XRefTableEntry) {
+	if _, ok := tx.saved[objNr]; !ok {
+		tx.saved[objNr] = copyXRefTableEntry(e)
+	}
+}
+
+func (tx *Transaction) entry(objNr int) (*XRefTableEntry, error) {
+	if tx.closed {
+		return nil, ErrTransactionClosed
+	}
+	if tx.ctx.readOnly {
+		return nil, ErrReadOnly
+	}
+	if objNr <= 0 {
+		return nil, errors.Errorf("pdfcpu: invalid object number: %d", objNr)
+	}
+	e, found := tx.ctx.Find(objNr)
+	if !found {
+		return nil, errors.Errorf("pdfcpu: obj#%d not registered in xRefTable", objNr)
+	}
+	return e, nil
+}
+
+// ReplaceObject replaces the object with objNr by o.
+func (tx *Transaction) ReplaceObject(objNr int, o types.Object) error {
+	e, err := tx.entry(objNr)
+	if err != nil {
+		return err
+	}
+	if e.Free {
+		return errors.Errorf("pdfcpu: ReplaceObject: obj#%d is free", objNr)
+	}
+	if _, ok := o.(types.IndirectRef); ok {
+		return errors.Errorf("pdfcpu: ReplaceObject: obj#%d: indirect reference not allowed", objNr)
+	}
+	tx.save(objNr, e)
+	e.Object = o
+	e.Compressed = false
+	e.ObjectStream = nil
+	e.ObjectStreamInd = nil
+	tx.dirty[objNr] = true
+	return nil
+}
+
+// DeleteObject frees the object with objNr.
+// Objects referenced by the deleted object are left untouched.
+func (tx *Transaction) DeleteObject(objNr int) error {
+	e, err := tx.entry(objNr)
+	if err != nil {
+		return err
+	}
+	if e.Free {
+		return nil
+	}
+	head, found := tx.ctx.Find(0)
+	if !found {
+		return errors.New("pdfcpu: DeleteObject: missing free list head")
+	}
+	tx.save(0, head)
+	tx.save(objNr, e)
+	if err := tx.ctx.FreeObject(objNr); err != nil {
+		return err
+	}
+	tx.dirty[0] = true
+	tx.dirty[objNr] = true
+	tx.deleted[objNr] = true
+	return nil
+}
+
+// ObjNrs returns the sorted numbers of all objects touched by tx.
+func (tx *Transaction) ObjNrs() []int {
+	objNrs := make([]int, 0, len(tx.dirty))
+	for objNr := range tx.dirty {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+	return objNrs
+}
+
+// checkRefs ensures o does not reference any object deleted within tx.
+func (tx *Transaction) checkRefs(objNr int, o types.Object) error {
+	switch o := o.(type) {
+	case types.IndirectRef:
+		if tx.deleted[o.ObjectNumber.Value()] {
+			return errors.Errorf("pdfcpu: obj#%d references deleted obj#%d", objNr, o.ObjectNumber.Value())
+		}
+	case types.Dict:
+		for _, v := range o {
+			if err := tx.checkRefs(objNr, v); err != nil {
+				return err
+			}
+		}
+	case types.StreamDict:
+		return tx.checkRefs(objNr, o.Dict)
+	case types.Array:
+		for _, v := range o {
+			if err := tx.checkRefs(objNr, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Commit applies tx to its Context.
+// All touched objects are marked for incremental writing and their validation state gets reset.
+// Commit fails and rolls back if any replaced object references an object deleted within tx.
+func (tx *Transaction) Commit() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	for objNr := range tx.dirty {
+		if tx.deleted[objNr] || objNr == 0 {
+			continue
+		}
+		e, _ := tx.ctx.Find(objNr)
+		if err := tx.checkRefs(objNr, e.Object); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, objNr := range tx.ObjNrs() {
+		e, _ := tx.ctx.Find(objNr)
+		e.Valid = false
+		e.BeingValidated = false
+		tx.ctx.Write.IncrementWithObjNr(objNr)
+	}
+
+	tx.closed = true
+	return nil
+}
+
+// Rollback discards all changes made within tx.
+func (tx *Transaction) Rollback() {
+	if tx.closed {
+		return
+	}
+	for objNr, e := range tx.saved {
+		if cur, found := tx.ctx.Find(objNr); found {
+			*cur = *e
+		}
+	}
+	tx.closed = true
+}
@@ -48,10 +48,17 @@ const (
 
 type formCache map[types.Rectangle]*types.IndirectRef
 
+// tileCache caches tiled forms by underlying form and view port.
+type tileCache map[types.IndirectRef]formCache
+
+// PdfNoFallback disables stamping of destination pages beyond a PDF multi stamp sequence.
+const PdfNoFallback = -1
+
 type PdfResources struct {
 	Content []byte
 	ResDict *types.IndirectRef
 	Bb      *types.Rectangle // visible region in user space
+	Rot     int              // rotation of the source page
 }
 
 // Watermark represents the basic structure and command details for the commands "Stamp" and "Watermark".
@@ -76,12 +83,14 @@ type Watermark struct {
 	Color                     color.SimpleColor   // text fill color(=non stroking color) for backwards compatibility.
 	FillColor                 color.SimpleColor   // text fill color(=non stroking color).
 	StrokeColor               color.SimpleColor   // text stroking color
+	FillPattern               *FillPattern        // text fill gradient or pattern overriding FillColor.
 	BgColor                   *color.SimpleColor  // text bounding box background color
 	MLeft, MRight             float64             // left and right bounding box margin
 	MTop, MBot                float64             // top and bottom bounding box margin
 	BorderWidth               float64             // Border width, visible if BgColor is set.
 	BorderStyle               types.LineJoinStyle // Border style (bounding box corner style), visible if BgColor is set.
 	BorderColor               *color.SimpleColor  // border color
+	CircleRadius              float64             // if > 0 lay out text along a circle of this radius like a seal.
 	Rotation                  float64             // rotation to apply in degrees. -180 <= x <= 180
 	Diagonal                  int                 // paint along the diagonal.
 	UserRotOrDiagonal         bool                // true if one of rotation or diagonal provided overriding the default.
@@ -91,6 +100,10 @@ type Watermark struct {
 	ScaleEff                  float64             // effective scale factor
 	ScaleAbs                  bool                // true for absolute scaling.
 	Update                    bool                // true for updating instead of adding a page watermark.
+	ID                        string              // optional identification for selective removal or update.
+	Tile                      bool                // if true repeat the watermark across the whole page.
+	TileGapX, TileGapY        float64             // horizontal and vertical gap between tiles.
+	TileStagger               float64             // horizontal shift of every other tile row relative to the horizontal tile step: 0 <= x < 1
 	Ocg, ExtGState, Font, Img *types.IndirectRef  // resources
 	Width, Height             int                 // image or page dimensions
 
@@ -100,6 +113,8 @@ type Watermark struct {
 	PdfPageNrSrc            int                  // page number of the source PDF file serving as stamp provider, 0 for multi stamping
 	PdfMultiStartPageNrSrc  int                  // start page number of the source PDF file serving as stamp provider.
 	PdfMultiStartPageNrDest int                  // start page number of the destination PDF file.
+	PdfMultiEndPageNrSrc    int                  // end page number of the source PDF file, 0 for its last page.
+	PdfMultiFallbackPageNr  int                  // source page number for destination pages beyond the sequence, 0 for the last page of the sequence or PdfNoFallback.
 
 	// page specific
 	Bb      *types.Rectangle   // bounding box of the form representing this watermark.
@@ -111,6 +126,7 @@ type Watermark struct {
 	// house keeping
 	Objs   types.IntSet // objects for which wm has been applied already.
 	FCache formCache    // form cache.
+	TCache tileCache    // tiled form cache.
 }
 
 // DefaultWatermarkConfig returns the default configuration.
@@ -134,6 +150,7 @@ func DefaultWatermarkConfig() *Watermark {
 		PdfRes:                  map[int]PdfResources{},
 		Objs:                    types.IntSet{},
 		FCache:                  formCache{},
+		TCache:                  tileCache{},
 		TextLines:               []string{},
 	}
 }
@@ -142,6 +159,28 @@ func DefaultWatermarkConfig() *Watermark {
 func (wm *Watermark) Recycle() {
 	wm.Objs = types.IntSet{}
 	wm.FCache = formCache{}
+	wm.TCache = tileCache{}
+}
+
+// CachedTileForm returns the tiled form for the current form and view port if available.
+func (wm Watermark) CachedTileForm() *types.IndirectRef {
+	if wm.Form == nil || wm.Vp == nil {
+		return nil
+	}
+	return wm.TCache[*wm.Form][*wm.Vp]
+}
+
+// CacheTileForm caches ir as tiled form for the current form and view port.
+func (wm Watermark) CacheTileForm(ir *types.IndirectRef) {
+	if wm.TCache == nil || wm.Form == nil || wm.Vp == nil {
+		return
+	}
+	fc, ok := wm.TCache[*wm.Form]
+	if !ok {
+		fc = formCache{}
+		wm.TCache[*wm.Form] = fc
+	}
+	fc[*wm.Vp] = ir
 }
 
 // IsText returns true if the watermark content is text.
@@ -205,6 +244,7 @@ func (wm Watermark) String() string {
 		"diagonal: %d\n"+
 		"opacity: %.1f\n"+
 		"renderMode: %d\n"+
+		"tile: %t gap: %.1f %.1f stagger: %.2f\n"+
 		"bbox:%s\n"+
 		"vp:%s\n"+
 		"pageRotation: %d\n",
@@ -217,6 +257,7 @@ func (wm Watermark) String() string {
 		wm.Diagonal,
 		wm.Opacity,
 		wm.RenderMode,
+		wm.Tile, wm.TileGapX, wm.TileGapY, wm.TileStagger,
 		bbox,
 		vp,
 		wm.PageRot,
@@ -448,14 +489,44 @@ func (wm *Watermark) CalcTransformMatrix() matrix.Matrix {
 	return matrix.CalcTransformMatrix(1, 1, sin, cos, dx, dy)
 }
 
+// MaxStampPageNr returns the last destination page number covered by a PDF multi stamp sequence.
+func (wm Watermark) MaxStampPageNr() int {
+	n := len(wm.PdfRes)
+	if _, ok := wm.PdfRes[0]; ok {
+		// Fallback page.
+		n--
+	}
+	return wm.PdfMultiStartPageNrDest + n - 1
+}
+
+// PdfResIndex returns the index into PdfRes for pageNr.
 func (wm *Watermark) PdfResIndex(pageNr int) int {
 	if !wm.MultiStamp() {
 		return wm.PdfPageNrSrc
 	}
-	maxStampPageNr := wm.PdfMultiStartPageNrDest + len(wm.PdfRes) - 1
-	i := pageNr
-	if pageNr > maxStampPageNr {
-		i = maxStampPageNr
+	maxStampPageNr := wm.MaxStampPageNr()
+	if pageNr <= maxStampPageNr {
+		return pageNr
+	}
+	if _, ok := wm.PdfRes[0]; ok {
+		return 0
+	}
+	return maxStampPageNr
+}
+
+// PdfResAvailable returns true if there is PDF content to be stamped onto pageNr.
+func (wm *Watermark) PdfResAvailable(pageNr int) bool {
+	if !wm.IsPDF() {
+		return true
+	}
+	if wm.MultiStamp() {
+		if pageNr < wm.PdfMultiStartPageNrDest {
+			return false
+		}
+		if pageNr > wm.MaxStampPageNr() && wm.PdfMultiFallbackPageNr == PdfNoFallback {
+			return false
+		}
 	}
-	return i
+	_, ok := wm.PdfRes[wm.PdfResIndex(pageNr)]
+	return ok
 }
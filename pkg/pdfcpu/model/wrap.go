@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/linebreak"
+)
+
+// decodeForFont returns s ready for PrepBytes.
+func decodeForFont(s, fontName string) string {
+	if font.IsCoreFont(fontName) {
+		return DecodeUTF8ToByte(s)
+	}
+	return s
+}
+
+// WrapText breaks each line of the multi line string s into lines fitting into width
+// using Unicode line break opportunities and hyphenation for lang if patterns are available.
+func WrapText(s, fontName string, fontSize int, width float64, lang string) string {
+	lines, _ := wrapLines(SplitMultilineStr(s), fontName, fontSize, width, lang, false, false)
+	return strings.Join(lines, "\n")
+}
+
+// wrapLines breaks lines into lines fitting into w using Unicode line break opportunities
+// and hyphenation for lang if patterns are available.
+// For justified text consecutive lines form a paragraph, otherwise each line is a paragraph.
+// parEnd flags the last line of a paragraph.
+func wrapLines(lines []string, fontName string, fontSize int, w float64, lang string, justify, parIndent bool) (wrapped []string, parEnd []bool) {
+	textWidth := func(s string) float64 {
+		return font.TextWidth(decodeForFont(s, fontName), fontName, fontSize)
+	}
+
+	// Missing patterns for lang turn off hyphenation.
+	h, _ := linebreak.HyphenatorFor(lang)
+
+	wrap := func(par string) {
+		if w <= 0 {
+			wrapped = append(wrapped, par)
+			parEnd = append(parEnd, true)
+			return
+		}
+		ll := linebreak.Wrap(par, w, textWidth, h)
+		for i, s := range ll {
+			wrapped = append(wrapped, s)
+			parEnd = append(parEnd, i == len(ll)-1)
+		}
+	}
+
+	if !justify {
+		for _, s := range lines {
+			wrap(s)
+		}
+		return wrapped, parEnd
+	}
+
+	var par []string
+
+	flush := func() {
+		if len(par) == 0 {
+			return
+		}
+		s := strings.Join(par, " ")
+		if parIndent {
+			s = "    " + s
+		}
+		wrap(s)
+		par = nil
+	}
+
+	for _, s := range lines {
+		if s != "" {
+			par = append(par, strings.TrimSpace(s))
+			continue
+		}
+		flush()
+		if !parIndent {
+			wrapped = append(wrapped, "")
+			parEnd = append(parEnd, true)
+		}
+	}
+	flush()
+
+	return wrapped, parEnd
+}
+
+// prepWrappedJustifiedText wraps lines into w and prerenders them as justified text.
+// The last line of each paragraph and lines without blanks are aligned to the start of the line.
+func prepWrappedJustifiedText(xRefTable *XRefTable, lines []string, w float64, td TextDescriptor, fontSize int) []string {
+	wrapped, parEnd := wrapLines(lines, td.FontName, fontSize, w, td.Hyphenation, true, td.ParIndent)
+
+	var ll []string
+
+	for i, s := range wrapped {
+		s = decodeForFont(s, td.FontName)
+		if s == "" {
+			ll = append(ll, "")
+			continue
+		}
+
+		words := strings.Split(s, " ")
+		if !parEnd[i] && len(words) > 1 {
+			// Indentation is part of the first word.
+			if strings.HasPrefix(s, "    ") {
+				words = append([]string{"    " + words[4]}, words[5:]...)
+			}
+			if len(words) > 1 {
+				prepJustifiedLine(xRefTable, &ll, words, font.TextWidth(s, td.FontName, fontSize), w, fontSize, td.FontName, td.Embed, td.RTL)
+				continue
+			}
+		}
+
+		s1 := PrepBytes(xRefTable, s, td.FontName, td.Embed, td.RTL, false)
+		if td.RTL {
+			dx := font.GlyphSpaceUnits(w-font.TextWidth(s, td.FontName, fontSize), fontSize)
+			ll = append(ll, fmt.Sprintf("[ %d (%s) ] TJ ", -int(dx), s1))
+			continue
+		}
+		ll = append(ll, fmt.Sprintf("(%s) Tj", s1))
+	}
+
+	return ll
+}
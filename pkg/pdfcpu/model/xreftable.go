@@ -19,6 +19,7 @@ package model
 import (
 	"bufio"
 	"bytes"
+	"crypto/md5"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -27,6 +28,7 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/filter"
@@ -86,6 +88,10 @@ type Enc struct {
 	L, P, R, V int
 	Emd        bool // encrypt meta data
 	ID         []byte
+	PubSec     bool   // public-key security handler
+	StmF       string // crypt filter for streams, V >= 4
+	StrF       string // crypt filter for strings, V >= 4
+	EFF        string // crypt filter for embedded files, V >= 4
 }
 
 // AnnotMap represents annotations by object number of the corresponding annotation dict.
@@ -116,10 +122,14 @@ type XRefTable struct {
 	AES4Strings         bool
 	AES4Streams         bool
 	AES4EmbeddedStreams bool
+	EmbeddedFilesLocked bool // Embedded files are encrypted exclusively and the user password is missing.
+
+	SpillFile *types.SpillFile // Stream data moved out of memory, see Configuration.MaxMemory.
 
 	// PDF Version
-	HeaderVersion *Version // The PDF version the source is claiming to us as per its header.
-	RootVersion   *Version // Optional PDF version taking precedence over the header version.
+	HeaderVersion   *Version // The PDF version the source is claiming to us as per its header.
+	RootVersion     *Version // Optional PDF version taking precedence over the header version.
+	DeclaredVersion *Version // Optional PDF version to be written into the header, see pdfcpu.SetVersion.
 
 	// Document information section
 	ID             types.Array        // from trailer
@@ -163,6 +173,9 @@ type XRefTable struct {
 	// Statistics
 	Stats PDFStats
 
+	// Streams repaired while reading, see Configuration.TolerateCorruptStreams.
+	Damage []StreamDamage
+
 	Tagged           bool // File is using tags.
 	CustomExtensions bool // File is using custom extensions for annotations and/or keywords.
 
@@ -173,6 +186,7 @@ type XRefTable struct {
 	ValidationMode int                       // see Configuration
 	ValidateLinks  bool                      // check for broken links in LinkAnnotations/URIDicts.
 	Valid          bool                      // true means successful validated against ISO 32000.
+	Findings       []Finding                 // Validation findings, see Configuration.FindingSeverity.
 	URIs           map[int]map[string]string // URIs for link checking
 
 	Optimized      bool
@@ -185,6 +199,13 @@ type XRefTable struct {
 	// Fonts
 	UsedGIDs  map[string]map[uint16]bool
 	FillFonts map[string]types.IndirectRef
+
+	// Graphics state parameter dicts by content hash, see EnsureExtGState.
+	ExtGStates map[string]types.IndirectRef
+
+	// Concurrent read access, see SetReadOnly.
+	readOnly bool
+	mu       *sync.RWMutex
 }
 
 // NewXRefTable creates a new XRefTable.
@@ -246,7 +267,8 @@ func (xRefTable *XRefTable) ParseRootVersion() (v *string, err error) {
 // ValidateVersion validates against the xRefTable's version.
 func (xRefTable *XRefTable) ValidateVersion(element string, sinceVersion Version) error {
 	if xRefTable.Version() < sinceVersion {
-		return errors.Errorf("%s: unsupported in version %s", element, xRefTable.VersionString())
+		msg := fmt.Sprintf("%s: unsupported in version %s", element, xRefTable.VersionString())
+		return xRefTable.ReportFinding(Finding{ID: FindingVersion, Severity: SeverityError, ObjNr: xRefTable.CurObj, Message: msg}, errors.New(msg))
 	}
 
 	return nil
@@ -267,6 +289,9 @@ func (xRefTable *XRefTable) IsMerging() bool {
 func (xRefTable *XRefTable) EnsureVersionForWriting() {
 	v := V17
 	xRefTable.RootVersion = &v
+	if xRefTable.DeclaredVersion != nil && *xRefTable.DeclaredVersion < v {
+		xRefTable.DeclaredVersion = nil
+	}
 }
 
 // IsLinearizationObject returns true if object #i is a a linearization object.
@@ -381,6 +406,10 @@ func (xRefTable *XRefTable) InsertNew(xRefTableEntry XRefTableEntry) (objNr int)
 
 // InsertAndUseRecycled adds given xRefTableEntry into the cross reference table utilizing the freelist.
 func (xRefTable *XRefTable) InsertAndUseRecycled(xRefTableEntry XRefTableEntry) (objNr int, err error) {
+	if xRefTable.readOnly {
+		return 0, ErrReadOnly
+	}
+
 	// see 7.5.4 Cross-Reference Table
 
 	// Hacky:
@@ -437,6 +466,9 @@ func (xRefTable *XRefTable) InsertAndUseRecycled(xRefTableEntry XRefTableEntry)
 
 // InsertObject inserts an object into the xRefTable.
 func (xRefTable *XRefTable) InsertObject(obj types.Object) (objNr int, err error) {
+	if xRefTable.readOnly {
+		return 0, ErrReadOnly
+	}
 	xRefTableEntry := NewXRefTableEntryGen0(obj)
 	xRefTableEntry.RefCount = 1
 	return xRefTable.InsertNew(*xRefTableEntry), nil
@@ -444,6 +476,9 @@ func (xRefTable *XRefTable) InsertObject(obj types.Object) (objNr int, err error
 
 // IndRefForNewObject inserts object at objNr into the xRefTable and returns an indirect reference to it.
 func (xRefTable *XRefTable) IndRefForObject(objNr int, obj types.Object) (*types.IndirectRef, error) {
+	if xRefTable.readOnly {
+		return nil, ErrReadOnly
+	}
 	xRefTable.Table[objNr] = NewXRefTableEntryGen0(obj)
 	return types.NewIndirectRef(objNr, 0), nil
 }
@@ -498,6 +533,8 @@ func (xRefTable *XRefTable) NewEmbeddedStreamDict(r io.Reader, modDate time.Time
 	d := types.NewDict()
 	d.InsertInt("Size", len(bb))
 	d.Insert("ModDate", types.StringLiteral(types.DateString(modDate)))
+	sum := md5.Sum(bb)
+	d.Insert("CheckSum", types.HexLiteral(hex.EncodeToString(sum[:])))
 	sd.Insert("Params", d)
 	if err = sd.Encode(); err != nil {
 		return nil, err
@@ -529,6 +566,10 @@ func (xRefTable *XRefTable) locateObjForIndRef(ir types.IndirectRef) (types.Obje
 func (xRefTable *XRefTable) FreeObject(objNr int) error {
 	// see 7.5.4 Cross-Reference Table
 
+	if xRefTable.readOnly {
+		return ErrReadOnly
+	}
+
 	if log.DebugEnabled() {
 		log.Debug.Printf("FreeObject: begin %d\n", objNr)
 	}
@@ -585,16 +626,16 @@ func (xRefTable *XRefTable) DeleteObject(o types.Object) error {
 	switch o := o.(type) {
 
 	case types.Dict:
-		for _, v := range o {
-			err := xRefTable.DeleteObject(v)
+		for _, k := range xRefTable.Conf.DictKeys(o) {
+			err := xRefTable.DeleteObject(o[k])
 			if err != nil {
 				return err
 			}
 		}
 
 	case types.StreamDict:
-		for _, v := range o.Dict {
-			err := xRefTable.DeleteObject(v)
+		for _, k := range xRefTable.Conf.DictKeys(o.Dict) {
+			err := xRefTable.DeleteObject(o.Dict[k])
 			if err != nil {
 				return err
 			}
@@ -725,15 +766,28 @@ func (xRefTable *XRefTable) freeObjects() types.IntSet {
 	return m
 }
 
+// anyKey returns the smallest key of m for reproducible repairs.
 func anyKey(m types.IntSet) int {
+	k := -1
+	for i := range m {
+		if k < 0 || i < k {
+			k = i
+		}
+	}
+	return k
+}
+
+func sortedKeys(m types.IntSet) []int {
+	kk := make([]int, 0, len(m))
 	for k := range m {
-		return k
+		kk = append(kk, k)
 	}
-	return -1
+	sort.Ints(kk)
+	return kk
 }
 
 func (xRefTable *XRefTable) handleDanglingFree(m types.IntSet, head *XRefTableEntry) error {
-	for i := range m {
+	for _, i := range sortedKeys(m) {
 
 		entry, found := xRefTable.FindTableEntryLight(i)
 		if !found {
@@ -991,6 +1045,9 @@ func (xRefTable *XRefTable) DereferenceStreamDict(o types.Object) (*types.Stream
 		if !ok {
 			return nil, false, errors.Errorf("pdfcpu: DereferenceStreamDict: wrong type <%v> %T", o, o)
 		}
+		if err := sd.Load(); err != nil {
+			return nil, false, err
+		}
 		return &sd, false, nil
 	}
 
@@ -1010,6 +1067,11 @@ func (xRefTable *XRefTable) DereferenceStreamDict(o types.Object) (*types.Stream
 		return nil, false, errors.Errorf("pdfcpu: DereferenceStreamDict: wrong type <%v> %T", o, entry.Object)
 	}
 
+	// Spilled stream data is loaded into the copy only and keeps residing on disk for entry.
+	if err := sd.Load(); err != nil {
+		return nil, false, err
+	}
+
 	return &sd, ev, nil
 }
 
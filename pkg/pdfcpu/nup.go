@@ -63,6 +63,8 @@ var nupParamMap = nUpParamMap{
 	"border":          parseElementBorder,
 	"cropboxborder":   parseElementBorderOnCropbox,
 	"margin":          parseElementMargin,
+	"spacing":         parseElementSpacing,
+	"anchor":          parseElementAnchor,
 	"backgroundcolor": parseSheetBackgroundColor,
 	"bgcolor":         parseSheetBackgroundColor,
 	"guides":          parseBookletGuides,
@@ -70,6 +72,9 @@ var nupParamMap = nUpParamMap{
 	"foliosize":       parseBookletFolioSize,
 	"btype":           parseBookletType,
 	"binding":         parseBookletBinding,
+	"flip":            parseBookletDuplexFlip,
+	"blanks":          parseBookletBlankPages,
+	"labels":          parseBookletSheetLabels,
 	"enforce":         parseEnforce,
 }
 
@@ -152,13 +157,18 @@ func parseElementBorder(s string, nup *model.NUp) error {
 	case "off", "false", "f":
 		nup.Border = false
 	default:
-		return errors.New("pdfcpu: nUp border, please provide one of: on/off true/false t/f")
+		// Styled border: width [round] [color]
+		bs, err := parseBorderStyling(s)
+		if err != nil {
+			return errors.New("pdfcpu: nUp border, please provide one of: on/off true/false t/f or width [round] [color]")
+		}
+		nup.Border, nup.CellBorder = true, bs
 	}
 
 	return nil
 }
 
-func parseElementBorderOnCropbox(s string, nup *model.NUp) error {
+func parseBorderStyling(s string) (*model.BorderStyling, error) {
 	// w
 	// w r g b
 	// w #c
@@ -166,47 +176,49 @@ func parseElementBorderOnCropbox(s string, nup *model.NUp) error {
 	// w round r g b
 	// w round #c
 
-	var err error
-
 	b := strings.Split(s, " ")
 	if len(b) == 0 || len(b) > 5 {
-		return errors.Errorf("pdfcpu: borders: need 1,2,3,4 or 5 int values, %s\n", s)
+		return nil, errors.Errorf("pdfcpu: borders: need 1,2,3,4 or 5 int values, %s\n", s)
 	}
 
 	switch b[0] {
 	case "off", "false", "f":
-		return nil
+		return nil, nil
 	case "on", "true", "t":
-		nup.BorderOnCropbox = &model.BorderStyling{Width: 1}
-		return nil
+		return &model.BorderStyling{Width: 1}, nil
 	}
 
-	nup.BorderOnCropbox = &model.BorderStyling{}
+	bs := &model.BorderStyling{}
 	width, err := strconv.ParseFloat(b[0], 64)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if width == 0 {
-		return errors.New("pdfcpu: borders: need width > 0")
+		return nil, errors.New("pdfcpu: borders: need width > 0")
 	}
-	nup.BorderOnCropbox.Width = width
+	bs.Width = width
 
 	if len(b) == 1 {
-		return nil
+		return bs, nil
 	}
 	if strings.HasPrefix("round", b[1]) {
 		style := types.LJRound
-		nup.BorderOnCropbox.LineStyle = &style
+		bs.LineStyle = &style
 		if len(b) == 2 {
-			return nil
+			return bs, nil
 		}
 		c, err := color.ParseColor(strings.Join(b[2:], " "))
-		nup.BorderOnCropbox.Color = &c
-		return err
+		bs.Color = &c
+		return bs, err
 	}
 
 	c, err := color.ParseColor(strings.Join(b[1:], " "))
-	nup.BorderOnCropbox.Color = &c
+	bs.Color = &c
+	return bs, err
+}
+
+func parseElementBorderOnCropbox(s string, nup *model.NUp) (err error) {
+	nup.BorderOnCropbox, err = parseBorderStyling(s)
 	return err
 }
 
@@ -272,6 +284,45 @@ func parseBookletBinding(s string, nup *model.NUp) error {
 	return nil
 }
 
+func parseBookletDuplexFlip(s string, nup *model.NUp) error {
+	switch strings.ToLower(s) {
+	case "short":
+		nup.Duplex = model.DuplexFlipShortEdge
+	case "long":
+		nup.Duplex = model.DuplexFlipLongEdge
+	default:
+		return errors.New("pdfcpu: booklet duplex flip, please provide one of: short long")
+	}
+	return nil
+}
+
+func parseBookletBlankPages(s string, nup *model.NUp) error {
+	switch strings.ToLower(s) {
+	case "end":
+		nup.BlankPages = model.BlankPagesAtEnd
+	case "start":
+		nup.BlankPages = model.BlankPagesAtStart
+	case "back":
+		nup.BlankPages = model.BlankPagesBeforeBackCover
+	default:
+		return errors.New("pdfcpu: booklet blank pages, please provide one of: end start back")
+	}
+	return nil
+}
+
+func parseBookletSheetLabels(s string, nup *model.NUp) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		nup.SheetLabels = true
+	case "off", "false", "f":
+		nup.SheetLabels = false
+	default:
+		return errors.New("pdfcpu: booklet sheet labels, please provide one of: on/off true/false t/f")
+	}
+
+	return nil
+}
+
 func parseElementMargin(s string, nup *model.NUp) error {
 	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
@@ -287,6 +338,30 @@ func parseElementMargin(s string, nup *model.NUp) error {
 	return nil
 }
 
+func parseElementSpacing(s string, nup *model.NUp) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+
+	if f < 0 {
+		return errors.New("pdfcpu: nUp spacing, Please provide a positive value")
+	}
+
+	nup.Spacing = types.ToUserSpace(f, nup.InpUnit)
+
+	return nil
+}
+
+func parseElementAnchor(s string, nup *model.NUp) error {
+	a, err := types.ParseAnchor(s)
+	if err != nil {
+		return err
+	}
+	nup.Anchor = &a
+	return nil
+}
+
 func parseSheetBackgroundColor(s string, nup *model.NUp) error {
 	c, err := color.ParseColor(s)
 	if err != nil {
@@ -344,6 +419,31 @@ func PDFNUpConfig(val int, desc string, conf *model.Configuration) (*model.NUp,
 	return nup, ParseNUpValue(val, nup)
 }
 
+// PDFNUpGridConfig returns an NUp configuration for Nup-ing PDF files using an arbitrary grid of rows x cols.
+func PDFNUpGridConfig(rows, cols int, desc string, conf *model.Configuration) (*model.NUp, error) {
+	nup := model.DefaultNUpConfig()
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	nup.InpUnit = conf.Unit
+	if desc != "" {
+		if err := ParseNUpDetails(desc, nup); err != nil {
+			return nil, err
+		}
+	}
+	return nup, ParseNUpGridDefinition(rows, cols, nup)
+}
+
+// ImageNUpGridConfig returns an NUp configuration for Nup-ing image files using an arbitrary grid of rows x cols.
+func ImageNUpGridConfig(rows, cols int, desc string, conf *model.Configuration) (*model.NUp, error) {
+	nup, err := PDFNUpGridConfig(rows, cols, desc, conf)
+	if err != nil {
+		return nil, err
+	}
+	nup.ImgInputFile = true
+	return nup, nil
+}
+
 // ImageNUpConfig returns an NUp configuration for Nup-ing image files.
 func ImageNUpConfig(val int, desc string, conf *model.Configuration) (*model.NUp, error) {
 	nup, err := PDFNUpConfig(val, desc, conf)
@@ -402,6 +502,26 @@ func ParseNUpValue(n int, nUp *model.NUp) error {
 	return nil
 }
 
+// ParseNUpCustomGrid parses an n-Up grid definition of the form "colsxrows" eg. "3x5" into an internal structure.
+func ParseNUpCustomGrid(s string, nUp *model.NUp) error {
+	ss := strings.Split(strings.ToLower(s), "x")
+	if len(ss) != 2 {
+		return errors.Errorf("pdfcpu: invalid n-Up grid: %s, please provide colsxrows eg. 3x5", s)
+	}
+
+	cols, err := strconv.Atoi(strings.TrimSpace(ss[0]))
+	if err != nil {
+		return errors.Errorf("pdfcpu: invalid n-Up grid: %s, please provide colsxrows eg. 3x5", s)
+	}
+
+	rows, err := strconv.Atoi(strings.TrimSpace(ss[1]))
+	if err != nil {
+		return errors.Errorf("pdfcpu: invalid n-Up grid: %s, please provide colsxrows eg. 3x5", s)
+	}
+
+	return ParseNUpGridDefinition(rows, cols, nUp)
+}
+
 // ParseNUpGridDefinition parses NUp grid dimensions into an internal structure.
 func ParseNUpGridDefinition(rows, cols int, nUp *model.NUp) error {
 	m := cols
@@ -559,6 +679,18 @@ func wrapUpPage(ctx *model.Context, nup *model.NUp, d types.Dict, buf bytes.Buff
 		fm = model.DrawBookletGuides(nup, &buf)
 	}
 
+	if nup.SheetLabels {
+		// For booklets only.
+		if fm == nil {
+			fm = model.FontMap{}
+		}
+		i := pagesDict.IntEntry("Count")
+		if i == nil {
+			return errors.New("pdfcpu: corrupt pages dict")
+		}
+		model.DrawBookletSheetLabel(nup, &buf, fm, model.SheetLabel(nup, *i))
+	}
+
 	resourceDict := types.Dict(
 		map[string]types.Object{
 			"XObject": d,
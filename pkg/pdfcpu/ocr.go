@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	ocrFontID = "FOCR"
+
+	// Images smaller than this in either dimension are not recognized.
+	ocrMinImageSize = 100
+)
+
+// OCRWord represents a recognized word.
+type OCRWord struct {
+	Text string
+	Box  image.Rectangle // in pixels of the recognized image with origin at the upper left corner
+}
+
+// OCRProvider recognizes the words of a page raster.
+type OCRProvider interface {
+	Recognize(img image.Image) ([]OCRWord, error)
+}
+
+// pageImage represents an image XObject painted on a page.
+type pageImage struct {
+	name string
+	m    matrix.Matrix // maps the unit square into user space
+}
+
+// paintedImages returns all image XObjects painted by the page content.
+// showsText is true if the content shows any text.
+func paintedImages(bb []byte) (ii []pageImage, showsText bool, err error) {
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return nil, false, err
+	}
+
+	var stack []matrix.Matrix
+
+	ctm := matrix.IdentMatrix
+	for _, op := range ops {
+		switch op.Operator {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if len(stack) > 0 {
+				ctm = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "cm":
+			ctm = matrixForOperands(op.Numbers()).Multiply(ctm)
+		case "Do":
+			ii = append(ii, pageImage{name: op.Name(0), m: ctm})
+		case "Tj", "TJ", "'", "\"":
+			showsText = true
+		}
+	}
+
+	return ii, showsText, nil
+}
+
+// ocrTextOps writes invisible text for all words recognized in an image of size w x h painted using m.
+func ocrTextOps(buf *bytes.Buffer, f *ocrFont, ww []OCRWord, w, h int, m matrix.Matrix) int {
+	n := 0
+
+	for _, word := range ww {
+		s := strings.TrimSpace(word.Text)
+		if s == "" || word.Box.Empty() {
+			continue
+		}
+
+		bb, ok := f.encode(s)
+		if !ok {
+			if log.InfoEnabled() {
+				log.Info.Printf("OCR: skipping word %q, out of character codes\n", s)
+			}
+			continue
+		}
+
+		tw := float64(len(bb)/2*ocrGlyphWidth) / 1000
+
+		x0, x1 := float64(word.Box.Min.X)/float64(w), float64(word.Box.Max.X)/float64(w)
+		y0, y1 := 1-float64(word.Box.Max.Y)/float64(h), 1-float64(word.Box.Min.Y)/float64(h)
+
+		// Lower left corner and directions of the word box in user space.
+		p := m.Transform(types.Point{X: x0, Y: y0})
+		px := m.Transform(types.Point{X: x1, Y: y0})
+		py := m.Transform(types.Point{X: x0, Y: y1})
+
+		fmt.Fprintf(buf, "%.4f %.4f %.4f %.4f %.2f %.2f Tm <%s> Tj\n",
+			(px.X-p.X)/tw, (px.Y-p.Y)/tw, py.X-p.X, py.Y-p.Y, p.X, p.Y, hex.EncodeToString(bb))
+		n++
+	}
+
+	return n
+}
+
+func ensureOCRFont(ctx *model.Context, f *ocrFont, d types.Dict, resDict types.Dict) (string, error) {
+	if resDict == nil {
+		resDict = types.Dict{}
+	}
+	d.Update("Resources", resDict)
+
+	fontDict, err := ensureResourceSubDict(ctx.XRefTable, resDict, "Font")
+	if err != nil {
+		return "", err
+	}
+
+	if f.ir == nil {
+		if err := f.create(ctx.XRefTable); err != nil {
+			return "", err
+		}
+	}
+
+	id := ocrFontID
+	for i := 1; ; i++ {
+		o, found := fontDict.Find(id)
+		if !found {
+			fontDict.Insert(id, *f.ir)
+			return id, nil
+		}
+		if ir1, ok := o.(types.IndirectRef); ok && ir1.ObjectNumber == f.ir.ObjectNumber {
+			return id, nil
+		}
+		id = fmt.Sprintf("%s%d", ocrFontID, i)
+	}
+}
+
+func addPageTextLayer(ctx *model.Context, pageNr int, provider OCRProvider, f *ocrFont) (int, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return 0, err
+	}
+	if d == nil {
+		return 0, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err == model.ErrNoContent {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ii, showsText, err := paintedImages(bb)
+	if err != nil || showsText || len(ii) == 0 || inhPAttrs.Resources == nil {
+		return 0, err
+	}
+
+	xo, err := ctx.DereferenceDict(inhPAttrs.Resources["XObject"])
+	if err != nil || xo == nil {
+		return 0, err
+	}
+
+	var buf bytes.Buffer
+	n := 0
+
+	for _, pi := range ii {
+		ir, ok := xo[pi.name].(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		sd, _, err := ctx.DereferenceStreamDict(ir)
+		if err != nil {
+			return 0, err
+		}
+		if sd == nil {
+			continue
+		}
+		if st := sd.Subtype(); st == nil || *st != "Image" {
+			continue
+		}
+		if im := sd.BooleanEntry("ImageMask"); im != nil && *im {
+			continue
+		}
+
+		img, err := decodeScan(ctx, sd, pi.name, ir.ObjectNumber.Value())
+		if err != nil || img == nil {
+			if log.DebugEnabled() {
+				log.Debug.Printf("OCR: page %d: skipping image %s\n", pageNr, pi.name)
+			}
+			continue
+		}
+
+		b := img.Bounds()
+		if b.Dx() < ocrMinImageSize || b.Dy() < ocrMinImageSize {
+			continue
+		}
+
+		ww, err := provider.Recognize(img)
+		if err != nil {
+			return 0, err
+		}
+
+		n += ocrTextOps(&buf, f, ww, b.Dx(), b.Dy(), pi.m)
+	}
+
+	if n == 0 {
+		return 0, nil
+	}
+
+	fontID, err := ensureOCRFont(ctx, f, d, inhPAttrs.Resources)
+	if err != nil {
+		return 0, err
+	}
+
+	a, err := contentArray(ctx, d)
+	if err != nil {
+		return 0, err
+	}
+
+	// Isolate the original content from the text layer.
+	ir1, err := newContentStream(ctx, []byte("q "))
+	if err != nil {
+		return 0, err
+	}
+
+	bb = []byte(fmt.Sprintf("\nQ q BT 3 Tr /%s 1 Tf\n%sET Q", fontID, buf.String()))
+	ir2, err := newContentStream(ctx, bb)
+	if err != nil {
+		return 0, err
+	}
+
+	a1 := append(types.Array{*ir1}, a...)
+	d.Update("Contents", append(a1, *ir2))
+
+	return n, nil
+}
+
+// AddTextLayer makes selected scanned pages of ctx searchable.
+// All images painted by a page get recognized by provider and the resulting words
+// are inserted as invisible text aligned with the images using an embedded glyphless font
+// whose ToUnicode CMap covers words of any script.
+// Pages already showing text are skipped.
+// AddTextLayer returns the number of inserted words.
+func AddTextLayer(ctx *model.Context, provider OCRProvider, selectedPages types.IntSet) (int, error) {
+	if provider == nil {
+		return 0, errors.New("pdfcpu: AddTextLayer: missing OCR provider")
+	}
+
+	n := 0
+	f := newOCRFont()
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+		i, err := addPageTextLayer(ctx, pageNr, provider, f)
+		if err != nil {
+			return 0, err
+		}
+		n += i
+	}
+
+	return n, f.finish(ctx.XRefTable)
+}
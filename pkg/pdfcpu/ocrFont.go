@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/binary"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	ocrFontName = "GlyphLessFont"
+
+	// The advance width of every glyph in glyph space.
+	ocrGlyphWidth = 500
+)
+
+// ocrFont is an embedded glyphless Type0 font using the Identity-H encoding for the invisible OCR text layer.
+// Character codes get assigned to runes as they occur,
+// the ToUnicode CMap of the font recovers the recognized text of any script.
+type ocrFont struct {
+	ir        *types.IndirectRef // Type0 font dict
+	toUnicode *types.IndirectRef // ToUnicode CMap stream, written by finish.
+	codes     map[rune]uint16
+	tum       ToUnicodeMap
+}
+
+func newOCRFont() *ocrFont {
+	return &ocrFont{codes: map[rune]uint16{}, tum: ToUnicodeMap{CodeLen: 2, M: map[uint32]string{}}}
+}
+
+// encode returns the character codes for s or false if the font has run out of codes.
+func (f *ocrFont) encode(s string) ([]byte, bool) {
+	bb := make([]byte, 0, 2*len(s))
+	for _, r := range s {
+		c, ok := f.codes[r]
+		if !ok {
+			// Code 0 maps to .notdef.
+			if len(f.codes) == 0xFFFF {
+				return nil, false
+			}
+			c = uint16(len(f.codes) + 1)
+			f.codes[r] = c
+			f.tum.M[uint32(c)] = string(r)
+		}
+		bb = binary.BigEndian.AppendUint16(bb, c)
+	}
+	return bb, true
+}
+
+func sfntChecksum(bb []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(bb); i += 4 {
+		var w [4]byte
+		copy(w[:], bb[i:])
+		sum += binary.BigEndian.Uint32(w[:])
+	}
+	return sum
+}
+
+// glyphlessTrueType returns a TrueType font program having a single empty glyph.
+func glyphlessTrueType() []byte {
+	be := binary.BigEndian
+
+	head := make([]byte, 54)
+	be.PutUint32(head[0:], 0x00010000)  // version
+	be.PutUint32(head[4:], 0x00010000)  // fontRevision
+	be.PutUint32(head[12:], 0x5F0F3CF5) // magicNumber
+	be.PutUint16(head[16:], 0x000B)     // flags
+	be.PutUint16(head[18:], 1000)       // unitsPerEm
+	be.PutUint16(head[40:], 1000)       // xMax
+	be.PutUint16(head[42:], 1000)       // yMax
+	be.PutUint16(head[46:], 8)          // lowestRecPPEM
+	be.PutUint16(head[48:], 2)          // fontDirectionHint
+
+	hhea := make([]byte, 36)
+	be.PutUint32(hhea[0:], 0x00010000)     // version
+	be.PutUint16(hhea[4:], 1000)           // ascender
+	be.PutUint16(hhea[10:], ocrGlyphWidth) // advanceWidthMax
+	be.PutUint16(hhea[18:], 1)             // caretSlopeRise
+	be.PutUint16(hhea[34:], 1)             // numberOfHMetrics
+
+	maxp := make([]byte, 32)
+	be.PutUint32(maxp[0:], 0x00010000) // version
+	be.PutUint16(maxp[4:], 1)          // numGlyphs
+	be.PutUint16(maxp[14:], 2)         // maxZones
+
+	hmtx := be.AppendUint16(nil, ocrGlyphWidth)
+	hmtx = be.AppendUint16(hmtx, 0)
+
+	// Short offsets for numGlyphs+1 empty glyph descriptions.
+	loca := make([]byte, 4)
+
+	tables := []struct {
+		tag string
+		bb  []byte
+	}{
+		{"glyf", nil},
+		{"head", head},
+		{"hhea", hhea},
+		{"hmtx", hmtx},
+		{"loca", loca},
+		{"maxp", maxp},
+	}
+
+	n := len(tables)
+	bb := be.AppendUint32(nil, 0x00010000)
+	bb = be.AppendUint16(bb, uint16(n))
+	bb = be.AppendUint16(bb, 64) // searchRange
+	bb = be.AppendUint16(bb, 2)  // entrySelector
+	bb = be.AppendUint16(bb, uint16(16*n-64))
+
+	off := 12 + 16*n
+	headOff := 0
+	var data []byte
+	for _, t := range tables {
+		if t.tag == "head" {
+			headOff = off + len(data)
+		}
+		bb = append(bb, t.tag...)
+		bb = be.AppendUint32(bb, sfntChecksum(t.bb))
+		bb = be.AppendUint32(bb, uint32(off+len(data)))
+		bb = be.AppendUint32(bb, uint32(len(t.bb)))
+		data = append(data, t.bb...)
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+	}
+	bb = append(bb, data...)
+
+	be.PutUint32(bb[headOff+8:], 0xB1B0AFBA-sfntChecksum(bb)) // head.checkSumAdjustment
+
+	return bb
+}
+
+func (f *ocrFont) create(xRefTable *model.XRefTable) error {
+	bb := glyphlessTrueType()
+	sd, err := xRefTable.NewStreamDictForBuf(bb)
+	if err != nil {
+		return err
+	}
+	sd.InsertInt("Length1", len(bb))
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+	fontFile, err := xRefTable.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	fd, err := xRefTable.IndRefForNewObject(types.Dict{
+		"Type":        types.Name("FontDescriptor"),
+		"FontName":    types.Name(ocrFontName),
+		"Flags":       types.Integer(5), // FixedPitch, Symbolic
+		"FontBBox":    types.NewNumberArray(0, 0, ocrGlyphWidth, 1000),
+		"ItalicAngle": types.Integer(0),
+		"Ascent":      types.Integer(1000),
+		"Descent":     types.Integer(0),
+		"CapHeight":   types.Integer(1000),
+		"StemV":       types.Integer(80),
+		"FontFile2":   *fontFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	cidFont, err := xRefTable.IndRefForNewObject(types.Dict{
+		"Type":     types.Name("Font"),
+		"Subtype":  types.Name("CIDFontType2"),
+		"BaseFont": types.Name(ocrFontName),
+		"CIDSystemInfo": types.Dict{
+			"Registry":   types.StringLiteral("Adobe"),
+			"Ordering":   types.StringLiteral("Identity"),
+			"Supplement": types.Integer(0),
+		},
+		"FontDescriptor": *fd,
+		"DW":             types.Integer(ocrGlyphWidth),
+		"CIDToGIDMap":    types.Name("Identity"),
+	})
+	if err != nil {
+		return err
+	}
+
+	if sd, err = xRefTable.NewStreamDictForBuf(f.tum.Bytes()); err != nil {
+		return err
+	}
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+	if f.toUnicode, err = xRefTable.IndRefForNewObject(*sd); err != nil {
+		return err
+	}
+
+	f.ir, err = xRefTable.IndRefForNewObject(types.Dict{
+		"Type":            types.Name("Font"),
+		"Subtype":         types.Name("Type0"),
+		"BaseFont":        types.Name(ocrFontName),
+		"Encoding":        types.Name("Identity-H"),
+		"DescendantFonts": types.Array{*cidFont},
+		"ToUnicode":       *f.toUnicode,
+	})
+
+	return err
+}
+
+// finish writes the ToUnicode CMap for all encoded runes.
+func (f *ocrFont) finish(xRefTable *model.XRefTable) error {
+	if f.toUnicode == nil {
+		return nil
+	}
+
+	entry, ok := xRefTable.FindTableEntryForIndRef(f.toUnicode)
+	if !ok {
+		return errors.New("pdfcpu: missing OCR font ToUnicode CMap")
+	}
+
+	sd, err := xRefTable.NewStreamDictForBuf(f.tum.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	entry.Object = *sd
+
+	return nil
+}
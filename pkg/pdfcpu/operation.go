@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Operation is a processing step applied to a context that has been read and validated.
+// Page numbers are 1-based, nil selects all pages.
+type Operation interface {
+	// Cmd returns the command mode in effect while applying the operation.
+	Cmd() model.CommandMode
+
+	// Apply processes ctx and returns the resulting context which may be a new one.
+	Apply(ctx *model.Context) (*model.Context, error)
+}
+
+// Operations is a pipeline of operations applied to the same context in order.
+// Operations is an Operation itself and may be nested.
+// Run a pipeline using api.ApplyOperations or batch.Process to share a single read, validate and write cycle
+// across all of its operations.
+type Operations []Operation
+
+// Pipeline returns a pipeline applying ops in order, eg.
+//
+//	ops := Pipeline(Optimize{}, Watermark{Watermark: wm}, Encrypt{OwnerPW: "opw"})
+//	ctx, err := ops.Apply(ctx)
+func Pipeline(ops ...Operation) Operations {
+	return ops
+}
+
+// Cmd returns the command mode of the first operation.
+func (ops Operations) Cmd() model.CommandMode {
+	if len(ops) == 0 || ops[0] == nil {
+		return model.OPTIMIZE
+	}
+	return ops[0].Cmd()
+}
+
+// Apply applies ops to ctx in order.
+// The resulting context is ready for writing.
+func (ops Operations) Apply(ctx *model.Context) (*model.Context, error) {
+	writeCmd := ctx.Cmd
+
+	for _, op := range ops {
+		if op == nil {
+			return nil, errors.New("pdfcpu: missing operation")
+		}
+		ctx.Cmd = op.Cmd()
+		ctx1, err := op.Apply(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ctx = ctx1
+		if ctx.Cmd == model.ENCRYPT {
+			writeCmd = model.ENCRYPT
+		}
+	}
+
+	ctx.Cmd = writeCmd
+
+	return ctx, nil
+}
+
+func operationPages(ctx *model.Context, pages []int) (types.IntSet, error) {
+	m := types.IntSet{}
+	if len(pages) == 0 {
+		for i := 1; i <= ctx.PageCount; i++ {
+			m[i] = true
+		}
+		return m, nil
+	}
+	for _, i := range pages {
+		if i < 1 || i > ctx.PageCount {
+			return nil, errors.Errorf("pdfcpu: invalid page number: %d", i)
+		}
+		m[i] = true
+	}
+	return m, nil
+}
+
+// Optimize optimizes the cross reference table.
+type Optimize struct{}
+
+// Cmd returns model.OPTIMIZE.
+func (Optimize) Cmd() model.CommandMode {
+	return model.OPTIMIZE
+}
+
+// Apply optimizes the cross reference table of ctx.
+func (Optimize) Apply(ctx *model.Context) (*model.Context, error) {
+	return ctx, OptimizeXRefTable(ctx)
+}
+
+// Rotate rotates pages clockwise by a multiple of 90 degrees.
+type Rotate struct {
+	Pages    []int
+	Rotation int
+}
+
+// Cmd returns model.ROTATE.
+func (Rotate) Cmd() model.CommandMode {
+	return model.ROTATE
+}
+
+// Apply rotates the selected pages of ctx.
+func (op Rotate) Apply(ctx *model.Context) (*model.Context, error) {
+	if op.Rotation%90 != 0 {
+		return nil, errors.Errorf("pdfcpu: rotation must be a multiple of 90: %d", op.Rotation)
+	}
+	pages, err := operationPages(ctx, op.Pages)
+	if err != nil {
+		return nil, err
+	}
+	return ctx, RotatePages(ctx, pages, op.Rotation)
+}
+
+// Watermark adds a watermark or stamp to pages.
+type Watermark struct {
+	Pages     []int
+	Watermark *model.Watermark
+}
+
+// Cmd returns model.ADDWATERMARKS.
+func (Watermark) Cmd() model.CommandMode {
+	return model.ADDWATERMARKS
+}
+
+// Apply adds the watermark to the selected pages of ctx.
+func (op Watermark) Apply(ctx *model.Context) (*model.Context, error) {
+	if op.Watermark == nil {
+		return nil, errors.New("pdfcpu: missing watermark")
+	}
+	pages, err := operationPages(ctx, op.Pages)
+	if err != nil {
+		return nil, err
+	}
+	return ctx, AddWatermarks(ctx, pages, op.Watermark)
+}
+
+// RemovePages removes pages.
+type RemovePages struct {
+	Pages []int
+}
+
+// Cmd returns model.REMOVEPAGES.
+func (RemovePages) Cmd() model.CommandMode {
+	return model.REMOVEPAGES
+}
+
+// Apply returns a new context made up of the pages of ctx not selected for removal.
+func (op RemovePages) Apply(ctx *model.Context) (*model.Context, error) {
+	if len(op.Pages) == 0 {
+		return nil, errors.New("pdfcpu: missing pages for removal")
+	}
+	pages, err := operationPages(ctx, op.Pages)
+	if err != nil {
+		return nil, err
+	}
+
+	var pageNrs []int
+	for i := 1; i <= ctx.PageCount; i++ {
+		if !pages[i] {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	if len(pageNrs) == 0 {
+		return nil, errors.New("pdfcpu: cannot remove all pages")
+	}
+
+	ctxDest, err := ExtractPages(ctx, pageNrs, false)
+	if err != nil {
+		return nil, err
+	}
+	ctxDest.Cmd = ctx.Cmd
+
+	return ctxDest, nil
+}
+
+// AddKeywords adds keywords to the document info dict.
+type AddKeywords struct {
+	Keywords []string
+}
+
+// Cmd returns model.ADDKEYWORDS.
+func (AddKeywords) Cmd() model.CommandMode {
+	return model.ADDKEYWORDS
+}
+
+// Apply adds the keywords to ctx.
+func (op AddKeywords) Apply(ctx *model.Context) (*model.Context, error) {
+	return ctx, KeywordsAdd(ctx, op.Keywords)
+}
+
+// SetProperties adds properties to the document info dict.
+type SetProperties struct {
+	Properties map[string]string
+}
+
+// Cmd returns model.ADDPROPERTIES.
+func (SetProperties) Cmd() model.CommandMode {
+	return model.ADDPROPERTIES
+}
+
+// Apply adds the properties to ctx.
+func (op SetProperties) Apply(ctx *model.Context) (*model.Context, error) {
+	return ctx, PropertiesAdd(ctx, op.Properties)
+}
+
+// Decrypt removes the encryption of the context.
+// The current passwords need to be supplied by the configuration used for reading.
+type Decrypt struct{}
+
+// Cmd returns model.DECRYPT.
+func (Decrypt) Cmd() model.CommandMode {
+	return model.DECRYPT
+}
+
+// Apply removes the encryption of ctx.
+func (Decrypt) Apply(ctx *model.Context) (*model.Context, error) {
+	if ctx.Encrypt == nil {
+		return nil, errors.New("pdfcpu: Decrypt: this file is not encrypted")
+	}
+	ctx.Encrypt, ctx.EncKey, ctx.E = nil, nil, nil
+	return ctx, nil
+}
+
+// Encrypt encrypts the context on writing using AES.
+type Encrypt struct {
+	UserPW, OwnerPW string
+	KeyLength       int                   // 128 or 256 (default)
+	Permissions     model.PermissionFlags // defaults to model.PermissionsNone
+}
+
+// Cmd returns model.ENCRYPT.
+func (Encrypt) Cmd() model.CommandMode {
+	return model.ENCRYPT
+}
+
+// Apply sets up ctx for AES encryption on writing.
+func (op Encrypt) Apply(ctx *model.Context) (*model.Context, error) {
+	if ctx.Encrypt != nil {
+		return nil, errors.New("pdfcpu: Encrypt: this file is already encrypted")
+	}
+	if op.OwnerPW == "" {
+		return nil, errors.New("pdfcpu: Encrypt: missing owner password")
+	}
+
+	kl := op.KeyLength
+	if kl == 0 {
+		kl = 256
+	}
+	if kl != 128 && kl != 256 {
+		return nil, errors.Errorf("pdfcpu: Encrypt: unsupported key length: %d", kl)
+	}
+
+	p := op.Permissions
+	if p == 0 {
+		p = model.PermissionsNone
+	}
+
+	ctx.UserPW, ctx.OwnerPW = op.UserPW, op.OwnerPW
+	ctx.EncryptUsingAES = true
+	ctx.EncryptKeyLength = kl
+	ctx.Permissions = p
+
+	return ctx, nil
+}
@@ -19,6 +19,7 @@ package pdfcpu
 import (
 	"bytes"
 	"fmt"
+	"log/slog"
 	"sort"
 	"strings"
 
@@ -52,8 +53,15 @@ func optimizeContentStreamUsage(ctx *model.Context, sd *types.StreamDict, objNr
 		return nil, nil
 	}
 
+	if err := sd.Load(); err != nil {
+		return nil, err
+	}
+
 	for _, objNr := range cachedObjNrs {
 		sd1 := f[objNr]
+		if err := sd1.Load(); err != nil {
+			return nil, err
+		}
 		if bytes.Equal(sd.Raw, sd1.Raw) {
 			ir := types.NewIndirectRef(objNr, 0)
 			ctx.IncrementRefCount(ir)
@@ -357,7 +365,8 @@ func optimizeFontResourcesDict(ctx *model.Context, rDict types.Dict, pageNr int,
 	recordedCorrupt := false
 
 	// Iterate over font resource dict.
-	for rName, v := range rDict {
+	for _, rName := range ctx.DictKeys(rDict) {
+		v := rDict[rName]
 
 		if v == nil {
 			if !recordedCorrupt {
@@ -718,7 +727,8 @@ func optimizeExtGStateResourcesDict(ctx *model.Context, rDict types.Dict, pageNr
 		log.Optimize.Printf("optimizeExtGStateResourcesDict page#%dbegin: %s\n", pageObjNumber, rDict)
 	}
 
-	for rName, v := range rDict {
+	for _, rName := range ctx.DictKeys(rDict) {
+		v := rDict[rName]
 
 		indRef, ok := v.(types.IndirectRef)
 		if !ok {
@@ -770,7 +780,8 @@ func optimizeXObjectResourcesDict(ctx *model.Context, rDict types.Dict, pageNr,
 
 	pageImages := pageImages(ctx, pageNr)
 
-	for rName, v := range rDict {
+	for _, rName := range ctx.DictKeys(rDict) {
+		v := rDict[rName]
 
 		indRef, ok := v.(types.IndirectRef)
 		if !ok {
@@ -1015,6 +1026,7 @@ func parsePagesDict(ctx *model.Context, pagesDict types.Dict, pageNr int) (int,
 		}
 
 		pageNr++
+		ctx.ReportProgress(model.StageOptimize, pageNr, ctx.PageCount)
 	}
 
 	if log.OptimizeEnabled() {
@@ -1543,7 +1555,8 @@ func CacheFormFonts(ctx *model.Context) error {
 	}
 
 	// Iterate over font resource dict.
-	for rName, v := range d {
+	for _, rName := range ctx.DictKeys(d) {
+		v := d[rName]
 
 		indRef, ok := v.(types.IndirectRef)
 		if !ok {
@@ -1639,22 +1652,32 @@ func ensureDirectWidthForXObjs(ctx *model.Context) error {
 }
 
 // OptimizeXRefTable optimizes an xRefTable by locating and getting rid of redundant embedded fonts and images.
-func OptimizeXRefTable(ctx *model.Context) error {
+func OptimizeXRefTable(ctx *model.Context) (err error) {
 	if ctx.PageCount == 0 {
 		return nil
 	}
 
+	end := ctx.Conf.TraceOp("optimize")
+	defer func() { end(err) }()
+
 	// Sometimes free objects are used although they are part of the free object list.
 	// Replace references to free xref table entries with a reference to a NULL object.
 	if err := fixReferencesToFreeObjects(ctx); err != nil {
 		return err
 	}
 
+	if ctx.Conf.OptimizePageTree {
+		if err := rebalancePageTree(ctx); err != nil {
+			return err
+		}
+	}
+
 	if (ctx.Cmd == model.VALIDATE ||
 		ctx.Cmd == model.OPTIMIZE ||
 		ctx.Cmd == model.LISTIMAGES ||
 		ctx.Cmd == model.EXTRACTIMAGES ||
-		ctx.Cmd == model.UPDATEIMAGES) &&
+		ctx.Cmd == model.UPDATEIMAGES ||
+		ctx.Cmd == model.REPLACEIMAGE) &&
 		ctx.Conf.OptimizeResourceDicts {
 		// Extra step with potential for performance hit when processing large files.
 		if err := optimizeResourceDicts(ctx); err != nil {
@@ -1662,6 +1685,15 @@ func OptimizeXRefTable(ctx *model.Context) error {
 		}
 	}
 
+	if ctx.Cmd == model.MERGECREATE ||
+		ctx.Cmd == model.MERGEAPPEND ||
+		ctx.Cmd == model.MERGECREATEZIP {
+		// Merged documents often share resources eg. when produced from the same template.
+		if err := deduplicateResources(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Get rid of duplicate embedded fonts and images.
 	if err := optimizeFontAndImages(ctx); err != nil {
 		return err
@@ -1677,7 +1709,7 @@ func OptimizeXRefTable(ctx *model.Context) error {
 	}
 
 	// Calculate memory usage of binary content for stats.
-	if log.StatsEnabled() {
+	if log.StatsEnabled() || (ctx.Read != nil && ctx.Conf.LogEnabled(slog.LevelInfo)) {
 		if err := calcBinarySizes(ctx); err != nil {
 			return err
 		}
@@ -1685,5 +1717,13 @@ func OptimizeXRefTable(ctx *model.Context) error {
 
 	ctx.Optimized = true
 
+	if ctx.Read != nil {
+		ctx.Conf.LogEvent(slog.LevelInfo, "pdfcpu: optimized",
+			"pages", ctx.PageCount,
+			"duplicateFonts", len(ctx.Optimize.DuplicateFonts),
+			"duplicateImages", len(ctx.Optimize.DuplicateImages),
+			"savedBytes", ctx.Read.BinaryFontDuplSize+ctx.Read.BinaryImageDuplSize)
+	}
+
 	return nil
 }
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// OutlineItem represents an outline item resolved to its target.
+type OutlineItem struct {
+	Title  string        `json:"title"`
+	Dest   string        `json:"dest,omitempty"`   // name of a named destination
+	Action string        `json:"action,omitempty"` // action type other than GoTo, eg. URI
+	PageNr int           `json:"page,omitempty"`   // target page, 0 if none
+	Fit    string        `json:"fit,omitempty"`    // destination type, eg. XYZ or FitH
+	Y      *float64      `json:"y,omitempty"`      // top of the target region in default user space if specified
+	Open   bool          `json:"open,omitempty"`   // kids are displayed
+	Kids   []OutlineItem `json:"kids,omitempty"`
+}
+
+func destName(o types.Object) (string, bool) {
+	switch o := o.(type) {
+	case types.Name:
+		return o.Value(), true
+	case types.StringLiteral:
+		s, err := types.StringLiteralToString(o)
+		return s, err == nil
+	case types.HexLiteral:
+		s, err := types.HexLiteralToString(o)
+		return s, err == nil
+	}
+	return "", false
+}
+
+// top returns the top coordinate of an explicit destination if specified.
+func top(ctx *model.Context, arr types.Array, fit string) *float64 {
+	i := -1
+	switch fit {
+	case "XYZ":
+		i = 3
+	case "FitH", "FitBH":
+		i = 2
+	case "FitR":
+		i = 5
+	}
+	if i < 0 || i >= len(arr) {
+		return nil
+	}
+
+	f, err := ctx.DereferenceNumber(arr[i])
+	if err != nil {
+		// null means unchanged.
+		return nil
+	}
+
+	return &f
+}
+
+func (item *OutlineItem) resolveDest(ctx *model.Context, dest types.Object) error {
+	dest, err := ctx.Dereference(dest)
+	if err != nil || dest == nil {
+		return err
+	}
+
+	if s, ok := destName(dest); ok {
+		item.Dest = s
+	}
+
+	arr, err := destArray(ctx, dest)
+	if err != nil || len(arr) == 0 {
+		if ctx.XRefTable.ValidationMode == model.ValidationRelaxed {
+			return nil
+		}
+		if err == nil {
+			err = errors.New("pdfcpu: empty destination")
+		}
+		return err
+	}
+
+	switch o := arr[0].(type) {
+	case types.IndirectRef:
+		if item.PageNr, err = ctx.PageNumber(o.ObjectNumber.Value()); err != nil {
+			return err
+		}
+	case types.Integer:
+		item.PageNr = o.Value()
+	}
+
+	if len(arr) > 1 {
+		if n, ok := arr[1].(types.Name); ok {
+			item.Fit = n.Value()
+			item.Y = top(ctx, arr, item.Fit)
+		}
+	}
+
+	return nil
+}
+
+func (item *OutlineItem) resolve(ctx *model.Context, d types.Dict) error {
+	if dest, found := d["Dest"]; found {
+		return item.resolveDest(ctx, dest)
+	}
+
+	act, err := ctx.DereferenceDict(d["A"])
+	if err != nil || act == nil {
+		return err
+	}
+
+	s := act.NameEntry("S")
+	if s == nil {
+		return nil
+	}
+	if *s != "GoTo" {
+		item.Action = *s
+		return nil
+	}
+
+	return item.resolveDest(ctx, act["D"])
+}
+
+func outlineItems(ctx *model.Context, first *types.IndirectRef, visited types.IntSet) ([]OutlineItem, error) {
+	items := []OutlineItem{}
+
+	for ir := first; ir != nil; {
+
+		objNr := ir.ObjectNumber.Value()
+		if visited[objNr] {
+			return nil, errors.Errorf("pdfcpu: circular outline at obj#%d", objNr)
+		}
+		visited[objNr] = true
+
+		d, err := ctx.DereferenceDict(*ir)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			break
+		}
+
+		title, err := title(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+
+		item := OutlineItem{Title: title}
+
+		if err := item.resolve(ctx, d); err != nil {
+			return nil, err
+		}
+
+		if count := d.IntEntry("Count"); count != nil && *count > 0 {
+			item.Open = true
+		}
+
+		if kids := d.IndirectRefEntry("First"); kids != nil {
+			if item.Kids, err = outlineItems(ctx, kids, visited); err != nil {
+				return nil, err
+			}
+		}
+
+		items = append(items, item)
+
+		ir = d.IndirectRefEntry("Next")
+	}
+
+	return items, nil
+}
+
+// ExtractOutline returns the outline tree of ctx with all items resolved to their target pages and positions.
+func ExtractOutline(ctx *model.Context) ([]OutlineItem, error) {
+	if err := ctx.LocateNameTree("Dests", false); err != nil {
+		return nil, err
+	}
+
+	first, err := positionToFirstBookmark(ctx)
+	if err != nil {
+		if err != errNoBookmarks {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	return outlineItems(ctx, first, types.IntSet{})
+}
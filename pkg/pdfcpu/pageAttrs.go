@@ -0,0 +1,311 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Inheritable page attributes (7.7.3.4).
+var inheritedPageAttrKeys = []string{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+func inheritablePageAttrKeys(keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return inheritedPageAttrKeys, nil
+	}
+	for _, k := range keys {
+		if !types.MemberOf(k, inheritedPageAttrKeys) {
+			return nil, errors.Errorf("pdfcpu: page attribute not inheritable: %s", k)
+		}
+	}
+	return keys, nil
+}
+
+// inheritedPageAttr returns the value for k of page tree node d as resolved via the Parent chain.
+func inheritedPageAttr(ctx *model.Context, d types.Dict, k string) (types.Object, bool, error) {
+	for d1 := d; d1 != nil; {
+		if o, found := d1.Find(k); found {
+			return o, true, nil
+		}
+		ir := d1.IndirectRefEntry("Parent")
+		if ir == nil {
+			break
+		}
+		var err error
+		if d1, err = ctx.DereferenceDict(*ir); err != nil {
+			return nil, false, err
+		}
+	}
+	return nil, false, nil
+}
+
+// inheritPageAttrs copies inherited page attributes of the page tree into d.
+func inheritPageAttrs(ctx *model.Context, d types.Dict) error {
+	for _, k := range inheritedPageAttrKeys {
+		if _, found := d.Find(k); found {
+			continue
+		}
+		o, found, err := inheritedPageAttr(ctx, d, k)
+		if err != nil {
+			return err
+		}
+		if found {
+			d[k] = o
+		}
+	}
+	return nil
+}
+
+// pagesNode returns the page tree node for ir or the page tree root if ir is nil.
+func pagesNode(ctx *model.Context, ir *types.IndirectRef) (types.Dict, error) {
+	if ir == nil {
+		root, err := ctx.Pages()
+		if err != nil {
+			return nil, err
+		}
+		if root == nil {
+			return nil, errors.New("pdfcpu: missing page tree root")
+		}
+		ir = root
+	}
+
+	d, err := ctx.DereferenceDict(*ir)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil || d.Type() == nil || *d.Type() != "Pages" {
+		return nil, errors.Errorf("pdfcpu: obj #%d is not a page tree node", ir.ObjectNumber.Value())
+	}
+
+	return d, nil
+}
+
+func pageTreeKids(ctx *model.Context, d types.Dict) ([]types.Dict, error) {
+	kids := d.ArrayEntry("Kids")
+	if kids == nil {
+		return nil, nil
+	}
+	dd := make([]types.Dict, 0, len(kids))
+	for _, o := range kids {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d1 != nil {
+			dd = append(dd, d1)
+		}
+	}
+	return dd, nil
+}
+
+func isPageTreeNode(d types.Dict) bool {
+	t := d.Type()
+	return (t != nil && *t == "Pages") || d.ArrayEntry("Kids") != nil
+}
+
+// walkPageTree calls fn for all page tree nodes and pages of the subtree rooted at d in post order.
+func walkPageTree(ctx *model.Context, d types.Dict, fn func(d types.Dict, kids []types.Dict) error) error {
+	if !isPageTreeNode(d) {
+		return fn(d, nil)
+	}
+
+	kids, err := pageTreeKids(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	for _, kid := range kids {
+		if err := walkPageTree(ctx, kid, fn); err != nil {
+			return err
+		}
+	}
+
+	return fn(d, kids)
+}
+
+// SetInheritedPageAttr sets the inheritable page attribute k to o for the page tree node ir (nil for the page tree root).
+// Overriding values of descendants are removed so all pages of this subtree resolve k to o.
+func SetInheritedPageAttr(ctx *model.Context, ir *types.IndirectRef, k string, o types.Object) error {
+	if _, err := inheritablePageAttrKeys([]string{k}); err != nil {
+		return err
+	}
+	if o == nil {
+		return errors.Errorf("pdfcpu: SetInheritedPageAttr: missing value for %s", k)
+	}
+
+	d, err := pagesNode(ctx, ir)
+	if err != nil {
+		return err
+	}
+
+	if err := walkPageTree(ctx, d, func(d1 types.Dict, _ []types.Dict) error {
+		d1.Delete(k)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	d[k] = o
+
+	return nil
+}
+
+// ClearInheritedPageAttr removes the inheritable page attribute k from the page tree node ir (nil for the page tree root).
+// Pages of this subtree without their own value for k resolve k by the remaining ancestors.
+// A MediaBox may only be cleared as long as every page is able to resolve one.
+func ClearInheritedPageAttr(ctx *model.Context, ir *types.IndirectRef, k string) error {
+	if _, err := inheritablePageAttrKeys([]string{k}); err != nil {
+		return err
+	}
+
+	d, err := pagesNode(ctx, ir)
+	if err != nil {
+		return err
+	}
+
+	o, found := d.Find(k)
+	if !found {
+		return nil
+	}
+
+	d.Delete(k)
+
+	if k != "MediaBox" {
+		return nil
+	}
+
+	if err := walkPageTree(ctx, d, func(d1 types.Dict, _ []types.Dict) error {
+		if isPageTreeNode(d1) {
+			return nil
+		}
+		if _, found, err := inheritedPageAttr(ctx, d1, k); err != nil || found {
+			return err
+		}
+		return errors.New("pdfcpu: ClearInheritedPageAttr: pages need a MediaBox")
+	}); err != nil {
+		d[k] = o
+		return err
+	}
+
+	return nil
+}
+
+// PushDownPageAttrs moves the inheritable page attributes keys (all if empty)
+// of the subtree rooted at the page tree node ir (nil for the page tree root) into its pages.
+// Afterwards no page tree node of this subtree carries any of these attributes.
+func PushDownPageAttrs(ctx *model.Context, ir *types.IndirectRef, keys ...string) error {
+	keys, err := inheritablePageAttrKeys(keys)
+	if err != nil {
+		return err
+	}
+
+	d, err := pagesNode(ctx, ir)
+	if err != nil {
+		return err
+	}
+
+	// Resolve all pages before touching any node.
+	var pages []types.Dict
+	if err := walkPageTree(ctx, d, func(d1 types.Dict, _ []types.Dict) error {
+		if !isPageTreeNode(d1) {
+			pages = append(pages, d1)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, d1 := range pages {
+		for _, k := range keys {
+			if _, found := d1.Find(k); found {
+				continue
+			}
+			o, found, err := inheritedPageAttr(ctx, d1, k)
+			if err != nil {
+				return err
+			}
+			if found {
+				d1[k] = o
+			}
+		}
+	}
+
+	return walkPageTree(ctx, d, func(d1 types.Dict, _ []types.Dict) error {
+		if isPageTreeNode(d1) {
+			for _, k := range keys {
+				d1.Delete(k)
+			}
+		}
+		return nil
+	})
+}
+
+// PullUpPageAttrs moves the inheritable page attributes keys (all if empty) shared by all kids
+// of a page tree node into this node bottom up starting at the page tree node ir (nil for the page tree root).
+func PullUpPageAttrs(ctx *model.Context, ir *types.IndirectRef, keys ...string) error {
+	keys, err := inheritablePageAttrKeys(keys)
+	if err != nil {
+		return err
+	}
+
+	if err := PushDownPageAttrs(ctx, ir, keys...); err != nil {
+		return err
+	}
+
+	d, err := pagesNode(ctx, ir)
+	if err != nil {
+		return err
+	}
+
+	return walkPageTree(ctx, d, func(d1 types.Dict, kids []types.Dict) error {
+		if len(kids) == 0 {
+			return nil
+		}
+		for _, k := range keys {
+			o, found := kids[0].Find(k)
+			if !found {
+				continue
+			}
+			shared := true
+			for _, kid := range kids[1:] {
+				o1, found := kid.Find(k)
+				if !found {
+					shared = false
+					break
+				}
+				ok, err := model.EqualObjects(o, o1, ctx.XRefTable)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					shared = false
+					break
+				}
+			}
+			if !shared {
+				continue
+			}
+			d1[k] = o
+			for _, kid := range kids {
+				kid.Delete(k)
+			}
+		}
+		return nil
+	})
+}
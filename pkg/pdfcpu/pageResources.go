@@ -0,0 +1,609 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const pageResourcesMaxDepth = 16
+
+// PageFont describes a font referenced by a page.
+type PageFont struct {
+	ResourceName string // qualified by the names of enclosing form XObjects, eg. "Fm0.F1"
+	ObjNr        int
+	Name         string
+	Type         string
+	Encoding     string
+	Embedded     bool
+	Subset       bool
+}
+
+// ImagePlacement describes a single placement of an image on a page.
+type ImagePlacement struct {
+	Width  float64 // placed width in points
+	Height float64 // placed height in points
+	HorDPI float64
+	VerDPI float64
+}
+
+// PageImage describes an image referenced by a page.
+type PageImage struct {
+	ResourceName string // empty for inline images
+	ObjNr        int
+	Inline       bool
+	Width        int // in pixels
+	Height       int // in pixels
+	Bpc          int
+	Cs           string
+	Comp         int // color component count
+	SMask        bool
+	Filter       string
+	Size         int64
+	Placements   []ImagePlacement
+}
+
+// PageExtGState describes a graphics state parameter dict referenced by a page.
+type PageExtGState struct {
+	ResourceName string
+	ObjNr        int
+	Keys         []string
+	Transparency bool // true if any of CA, ca, SMask, BM introduces transparency
+}
+
+// PageShading describes a shading referenced by a page.
+type PageShading struct {
+	ResourceName string
+	ObjNr        int
+	ShadingType  int
+	Cs           string
+}
+
+// PagePattern describes a pattern referenced by a page.
+type PagePattern struct {
+	ResourceName string
+	ObjNr        int
+	PatternType  int // 1 = tiling pattern, 2 = shading pattern
+	ShadingType  int // for shading patterns only
+}
+
+// PageResourceUsage details the resources referenced by a page.
+type PageResourceUsage struct {
+	PageNr     int
+	Fonts      []PageFont
+	Images     []PageImage
+	ExtGStates []PageExtGState
+	Shadings   []PageShading
+	Patterns   []PagePattern
+}
+
+type pageResourceWalker struct {
+	ctx    *model.Context
+	u      *PageResourceUsage
+	images map[string]int // qualified resource name -> index into u.Images
+	forms  types.IntSet   // visited form XObjects
+}
+
+func qualifiedResName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func objNrForObject(o types.Object) int {
+	if ir, ok := o.(types.IndirectRef); ok {
+		return ir.ObjectNumber.Value()
+	}
+	return 0
+}
+
+func sortedDictKeys(d types.Dict) []string {
+	kk := make([]string, 0, len(d))
+	for k := range d {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+	return kk
+}
+
+func (w *pageResourceWalker) colorSpaceName(o types.Object) string {
+	o, err := w.ctx.Dereference(o)
+	if err != nil {
+		return ""
+	}
+	switch o := o.(type) {
+	case types.Name:
+		return o.Value()
+	case types.Array:
+		if len(o) > 0 {
+			if n, ok := o[0].(types.Name); ok {
+				return n.Value()
+			}
+		}
+	}
+	return ""
+}
+
+func (w *pageResourceWalker) category(resDict types.Dict, category string) (types.Dict, error) {
+	o, found := resDict.Find(category)
+	if !found {
+		return nil, nil
+	}
+	return w.ctx.DereferenceDict(o)
+}
+
+func (w *pageResourceWalker) fonts(resDict types.Dict, prefix string) error {
+	d, err := w.category(resDict, "Font")
+	if err != nil || d == nil {
+		return err
+	}
+	for _, name := range sortedDictKeys(d) {
+		objNr := objNrForObject(d[name])
+		fontDict, err := w.ctx.DereferenceDict(d[name])
+		if err != nil {
+			return err
+		}
+		if fontDict == nil {
+			continue
+		}
+		fontPrefix, fName, err := font.Name(w.ctx.XRefTable, fontDict, objNr)
+		if err != nil {
+			return err
+		}
+		embedded, err := font.Embedded(w.ctx.XRefTable, fontDict, objNr)
+		if err != nil {
+			return err
+		}
+		fo := model.FontObject{FontDict: fontDict}
+		w.u.Fonts = append(w.u.Fonts, PageFont{
+			ResourceName: qualifiedResName(prefix, name),
+			ObjNr:        objNr,
+			Name:         fName,
+			Type:         fo.SubType(),
+			Encoding:     fo.Encoding(),
+			Embedded:     embedded,
+			Subset:       fontPrefix != "",
+		})
+	}
+	return nil
+}
+
+func (w *pageResourceWalker) extGStates(resDict types.Dict, prefix string) error {
+	d, err := w.category(resDict, "ExtGState")
+	if err != nil || d == nil {
+		return err
+	}
+	for _, name := range sortedDictKeys(d) {
+		gs, err := w.ctx.DereferenceDict(d[name])
+		if err != nil {
+			return err
+		}
+		kk := []string{}
+		for _, k := range sortedDictKeys(gs) {
+			if k != "Type" {
+				kk = append(kk, k)
+			}
+		}
+		w.u.ExtGStates = append(w.u.ExtGStates, PageExtGState{
+			ResourceName: qualifiedResName(prefix, name),
+			ObjNr:        objNrForObject(d[name]),
+			Keys:         kk,
+			Transparency: w.transparentExtGState(gs),
+		})
+	}
+	return nil
+}
+
+func (w *pageResourceWalker) transparentExtGState(gs types.Dict) bool {
+	for _, k := range []string{"CA", "ca"} {
+		o, found := gs.Find(k)
+		if !found {
+			continue
+		}
+		if f, err := w.ctx.DereferenceNumber(o); err == nil && f < 1 {
+			return true
+		}
+	}
+	if o, found := gs.Find("SMask"); found {
+		if n, ok := o.(types.Name); !ok || n.Value() != "None" {
+			return true
+		}
+	}
+	if o, found := gs.Find("BM"); found {
+		o, _ = w.ctx.Dereference(o)
+		switch o := o.(type) {
+		case types.Name:
+			return o.Value() != "Normal" && o.Value() != "Compatible"
+		case types.Array:
+			for _, bm := range o {
+				if n, ok := bm.(types.Name); ok && n.Value() != "Normal" && n.Value() != "Compatible" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// shadingDict returns the dict of a shading which may be a dict or a stream dict.
+func (w *pageResourceWalker) shadingDict(o types.Object) (types.Dict, error) {
+	o, err := w.ctx.Dereference(o)
+	if err != nil {
+		return nil, err
+	}
+	switch o := o.(type) {
+	case types.Dict:
+		return o, nil
+	case types.StreamDict:
+		return o.Dict, nil
+	}
+	return nil, nil
+}
+
+func (w *pageResourceWalker) shadings(resDict types.Dict, prefix string) error {
+	d, err := w.category(resDict, "Shading")
+	if err != nil || d == nil {
+		return err
+	}
+	for _, name := range sortedDictKeys(d) {
+		sh, err := w.shadingDict(d[name])
+		if err != nil {
+			return err
+		}
+		if sh == nil {
+			continue
+		}
+		ps := PageShading{ResourceName: qualifiedResName(prefix, name), ObjNr: objNrForObject(d[name])}
+		if i := sh.IntEntry("ShadingType"); i != nil {
+			ps.ShadingType = *i
+		}
+		if o, found := sh.Find("ColorSpace"); found {
+			ps.Cs = w.colorSpaceName(o)
+		}
+		w.u.Shadings = append(w.u.Shadings, ps)
+	}
+	return nil
+}
+
+func (w *pageResourceWalker) patterns(resDict types.Dict, prefix string, depth int) error {
+	d, err := w.category(resDict, "Pattern")
+	if err != nil || d == nil {
+		return err
+	}
+	for _, name := range sortedDictKeys(d) {
+		qName := qualifiedResName(prefix, name)
+		o, err := w.ctx.Dereference(d[name])
+		if err != nil {
+			return err
+		}
+		var (
+			pd  types.Dict
+			res types.Dict
+		)
+		switch o := o.(type) {
+		case types.Dict:
+			pd = o
+		case types.StreamDict:
+			pd = o.Dict
+			// Tiling patterns carry their own resources.
+			if res, err = w.category(o.Dict, "Resources"); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+		pp := PagePattern{ResourceName: qName, ObjNr: objNrForObject(d[name])}
+		if i := pd.IntEntry("PatternType"); i != nil {
+			pp.PatternType = *i
+		}
+		if o, found := pd.Find("Shading"); found {
+			sh, err := w.shadingDict(o)
+			if err != nil {
+				return err
+			}
+			if i := sh.IntEntry("ShadingType"); i != nil {
+				pp.ShadingType = *i
+			}
+		}
+		w.u.Patterns = append(w.u.Patterns, pp)
+		if res != nil && depth < pageResourcesMaxDepth {
+			if err := w.resources(res, qName, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *pageResourceWalker) xObjects(resDict types.Dict, prefix string, depth int) error {
+	d, err := w.category(resDict, "XObject")
+	if err != nil || d == nil {
+		return err
+	}
+	for _, name := range sortedDictKeys(d) {
+		qName := qualifiedResName(prefix, name)
+		objNr := objNrForObject(d[name])
+		sd, _, err := w.ctx.DereferenceStreamDict(d[name])
+		if err != nil {
+			return err
+		}
+		if sd == nil || sd.Subtype() == nil {
+			continue
+		}
+		switch *sd.Subtype() {
+		case "Image":
+			img, err := ExtractImage(w.ctx, sd, false, name, objNr, true)
+			if err != nil {
+				return err
+			}
+			w.images[qName] = len(w.u.Images)
+			w.u.Images = append(w.u.Images, PageImage{
+				ResourceName: qName,
+				ObjNr:        objNr,
+				Width:        img.Width,
+				Height:       img.Height,
+				Bpc:          img.Bpc,
+				Cs:           img.Cs,
+				Comp:         img.Comp,
+				SMask:        img.HasSMask,
+				Filter:       img.Filter,
+				Size:         img.Size,
+			})
+		case "Form":
+			if objNr > 0 {
+				if w.forms[objNr] {
+					continue
+				}
+				w.forms[objNr] = true
+			}
+			res, err := w.category(sd.Dict, "Resources")
+			if err != nil {
+				return err
+			}
+			if res != nil && depth < pageResourcesMaxDepth {
+				if err := w.resources(res, qName, depth+1); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resources collects all resources of resDict including the resources of nested form XObjects and tiling patterns.
+func (w *pageResourceWalker) resources(resDict types.Dict, prefix string, depth int) error {
+	if err := w.fonts(resDict, prefix); err != nil {
+		return err
+	}
+	if err := w.extGStates(resDict, prefix); err != nil {
+		return err
+	}
+	if err := w.shadings(resDict, prefix); err != nil {
+		return err
+	}
+	if err := w.patterns(resDict, prefix, depth); err != nil {
+		return err
+	}
+	return w.xObjects(resDict, prefix, depth)
+}
+
+func imagePlacement(ctm matrix.Matrix, w, h int) ImagePlacement {
+	// An image gets mapped onto the unit square of user space.
+	pw := math.Hypot(ctm[0][0], ctm[0][1])
+	ph := math.Hypot(ctm[1][0], ctm[1][1])
+	p := ImagePlacement{Width: pw, Height: ph}
+	if pw > 0 {
+		p.HorDPI = float64(w) * 72 / pw
+	}
+	if ph > 0 {
+		p.VerDPI = float64(h) * 72 / ph
+	}
+	return p
+}
+
+func (w *pageResourceWalker) inlineImage(op model.ContentOp, ctm matrix.Matrix) {
+	d, ok := op.Operands[0].(types.Dict)
+	if !ok {
+		return
+	}
+	intEntry := func(k1, k2 string) int {
+		if i := d.IntEntry(k1); i != nil {
+			return *i
+		}
+		if i := d.IntEntry(k2); i != nil {
+			return *i
+		}
+		return 0
+	}
+	img := PageImage{
+		Inline: true,
+		Width:  intEntry("W", "Width"),
+		Height: intEntry("H", "Height"),
+		Bpc:    intEntry("BPC", "BitsPerComponent"),
+		Size:   int64(len(op.Data)),
+	}
+	for _, k := range []string{"CS", "ColorSpace"} {
+		if o, found := d.Find(k); found {
+			img.Cs = w.colorSpaceName(o)
+		}
+	}
+	for _, k := range []string{"F", "Filter"} {
+		o, found := d.Find(k)
+		if !found {
+			continue
+		}
+		switch o := o.(type) {
+		case types.Name:
+			img.Filter = o.Value()
+		case types.Array:
+			ss := []string{}
+			for _, f := range o {
+				if n, ok := f.(types.Name); ok {
+					ss = append(ss, n.Value())
+				}
+			}
+			img.Filter = strings.Join(ss, ",")
+		}
+	}
+	img.Placements = []ImagePlacement{imagePlacement(ctm, img.Width, img.Height)}
+	w.u.Images = append(w.u.Images, img)
+}
+
+func (w *pageResourceWalker) imageForObjNr(objNr int) (int, bool) {
+	if objNr == 0 {
+		return 0, false
+	}
+	for i, img := range w.u.Images {
+		if img.ObjNr == objNr {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (w *pageResourceWalker) doXObject(resDict types.Dict, prefix, name string, ctm matrix.Matrix, depth int) error {
+	d, err := w.category(resDict, "XObject")
+	if err != nil || d == nil {
+		return err
+	}
+	o, found := d.Find(name)
+	if !found {
+		return nil
+	}
+	sd, _, err := w.ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil || sd.Subtype() == nil {
+		return err
+	}
+	qName := qualifiedResName(prefix, name)
+	switch *sd.Subtype() {
+	case "Image":
+		i, ok := w.images[qName]
+		if !ok {
+			// Image of a form XObject shared under different resource names.
+			if i, ok = w.imageForObjNr(objNrForObject(o)); !ok {
+				return nil
+			}
+		}
+		img := &w.u.Images[i]
+		img.Placements = append(img.Placements, imagePlacement(ctm, img.Width, img.Height))
+	case "Form":
+		if depth >= pageResourcesMaxDepth {
+			return nil
+		}
+		if err := sd.Decode(); err != nil {
+			return err
+		}
+		res, err := w.category(sd.Dict, "Resources")
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			res, qName = resDict, prefix
+		}
+		if o, found := sd.Find("Matrix"); found {
+			if a, err := w.ctx.DereferenceArray(o); err == nil {
+				ctm = matrixForOperands(arrayNumbers(a)).Multiply(ctm)
+			}
+		}
+		return w.placements(sd.Content, res, qName, ctm, depth+1)
+	}
+	return nil
+}
+
+// placements tracks the CTM through content in order to determine the placed size of images.
+func (w *pageResourceWalker) placements(content []byte, resDict types.Dict, prefix string, ctm matrix.Matrix, depth int) error {
+	ops, err := model.ParseContentOps(string(content))
+	if err != nil {
+		return err
+	}
+	stack := []matrix.Matrix{}
+	for _, op := range ops {
+		switch op.Operator {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if len(stack) > 0 {
+				ctm = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "cm":
+			ctm = matrixForOperands(op.Numbers()).Multiply(ctm)
+		case "BI":
+			w.inlineImage(op, ctm)
+		case "Do":
+			if err := w.doXObject(resDict, prefix, op.Name(0), ctm, depth); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PageResources returns the fonts, images, graphics states, shadings and patterns referenced by pageNr
+// including the resources of nested form XObjects.
+func PageResources(ctx *model.Context, pageNr int) (*PageResourceUsage, error) {
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return nil, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, true)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	w := &pageResourceWalker{
+		ctx:    ctx,
+		u:      &PageResourceUsage{PageNr: pageNr},
+		images: map[string]int{},
+		forms:  types.IntSet{},
+	}
+
+	resDict := inhPAttrs.Resources
+	if resDict == nil {
+		return w.u, nil
+	}
+
+	if err := w.resources(resDict, "", 0); err != nil {
+		return nil, err
+	}
+
+	content, err := ctx.PageContent(d, pageNr)
+	if err != nil {
+		if err == model.ErrNoContent {
+			return w.u, nil
+		}
+		return nil, err
+	}
+
+	if err := w.placements(content, resDict, "", matrix.IdentMatrix, 0); err != nil {
+		return nil, err
+	}
+
+	return w.u, nil
+}
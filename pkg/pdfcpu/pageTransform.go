@@ -0,0 +1,310 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// pageTransform maps page geometry from the original user space of a page into a new one.
+type pageTransform struct {
+	m   matrix.Matrix
+	rot int // clockwise rotation applied to annotation appearances, a multiple of 90.
+}
+
+func (pt pageTransform) point(x, y float64) types.Point {
+	return pt.m.Transform(types.Point{X: x, Y: y})
+}
+
+func (pt pageTransform) rect(r *types.Rectangle) *types.Rectangle {
+	p1, p2 := pt.point(r.LL.X, r.LL.Y), pt.point(r.UR.X, r.UR.Y)
+	return types.NewRectangle(math.Min(p1.X, p2.X), math.Min(p1.Y, p2.Y), math.Max(p1.X, p2.X), math.Max(p1.Y, p2.Y))
+}
+
+func numberValue(o types.Object) (float64, bool) {
+	switch o := o.(type) {
+	case types.Integer:
+		return float64(o.Value()), true
+	case types.Float:
+		return o.Value(), true
+	}
+	return 0, false
+}
+
+// coords transforms a flat array of x,y coordinates.
+func (pt pageTransform) coords(a types.Array) types.Array {
+	a1 := make(types.Array, len(a))
+	copy(a1, a)
+	for i := 0; i+1 < len(a); i += 2 {
+		x, ok1 := numberValue(a[i])
+		y, ok2 := numberValue(a[i+1])
+		if !ok1 || !ok2 {
+			continue
+		}
+		p := pt.point(x, y)
+		a1[i], a1[i+1] = types.Float(p.X), types.Float(p.Y)
+	}
+	return a1
+}
+
+// rotateAppearance rotates an appearance stream within its annotation rectangle.
+func (pt pageTransform) rotateAppearance(ctx *model.Context, o types.Object, visited types.IntSet) error {
+	if ir, ok := o.(types.IndirectRef); ok {
+		objNr := ir.ObjectNumber.Value()
+		if visited[objNr] {
+			return nil
+		}
+		visited[objNr] = true
+	}
+
+	o, err := ctx.Dereference(o)
+	if err != nil || o == nil {
+		return err
+	}
+
+	switch o := o.(type) {
+
+	case types.Dict:
+		// Appearance subdictionary of states.
+		for _, v := range o {
+			if err := pt.rotateAppearance(ctx, v, visited); err != nil {
+				return err
+			}
+		}
+
+	case types.StreamDict:
+		m := matrix.IdentMatrix
+		if a, err := ctx.DereferenceArray(o.Dict["Matrix"]); err == nil && len(a) == 6 {
+			m = matrixForOperands(arrayNumbers(a))
+		}
+		// The resulting bounding box gets fitted into the transformed annotation rectangle.
+		m = m.Multiply(matrix.CalcRotateTransformMatrix(float64(-pt.rot), types.RectForDim(0, 0)))
+		o.Dict["Matrix"] = types.NewNumberArray(m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
+	}
+
+	return nil
+}
+
+func (pt pageTransform) annotation(ctx *model.Context, d types.Dict, visited types.IntSet) error {
+	if o, found := d.Find("Rect"); found {
+		a, err := ctx.DereferenceArray(o)
+		if err != nil {
+			return err
+		}
+		r, err := ctx.RectForArray(a)
+		if err != nil {
+			return err
+		}
+		d["Rect"] = pt.rect(r).Array()
+	}
+
+	for _, k := range []string{"QuadPoints", "Vertices", "L", "CL"} {
+		a, err := ctx.DereferenceArray(d[k])
+		if err != nil {
+			return err
+		}
+		if a != nil {
+			d[k] = pt.coords(a)
+		}
+	}
+
+	a, err := ctx.DereferenceArray(d["InkList"])
+	if err != nil {
+		return err
+	}
+	if a != nil {
+		a1 := make(types.Array, len(a))
+		for i, o := range a {
+			path, err := ctx.DereferenceArray(o)
+			if err != nil {
+				return err
+			}
+			a1[i] = pt.coords(path)
+		}
+		d["InkList"] = a1
+	}
+
+	if pt.rot == 0 {
+		return nil
+	}
+
+	// Annotations flagged NoRotate are kept upright by viewers anyway.
+	if f := d.IntEntry("F"); f != nil && *f&16 > 0 {
+		return nil
+	}
+
+	// Widgets: rotate subsequently generated appearances.
+	if mk, err := ctx.DereferenceDict(d["MK"]); err == nil && mk != nil {
+		r := 0
+		if i := mk.IntEntry("R"); i != nil {
+			r = *i
+		}
+		mk["R"] = types.Integer((r + pt.rot) % 360)
+	}
+
+	if ap, err := ctx.DereferenceDict(d["AP"]); err == nil && ap != nil {
+		for _, k := range []string{"N", "R", "D"} {
+			if o, found := ap.Find(k); found {
+				if err := pt.rotateAppearance(ctx, o, visited); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (pt pageTransform) annotations(ctx *model.Context, pageDict types.Dict, visited types.IntSet) error {
+	a, err := ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil || a == nil {
+		return err
+	}
+
+	for _, o := range a {
+		if ir, ok := o.(types.IndirectRef); ok {
+			objNr := ir.ObjectNumber.Value()
+			if visited[objNr] {
+				continue
+			}
+			visited[objNr] = true
+		}
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+		if err := pt.annotation(ctx, d, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// apply transforms all page boundaries, the annotations and the content of a page.
+// visited tracks processed objects shared between pages.
+func (pt pageTransform) apply(ctx *model.Context, d types.Dict, pageNr int, inhPAttrs *model.InheritedPageAttrs, visited types.IntSet) error {
+	boxes := map[string]*types.Rectangle{"MediaBox": inhPAttrs.MediaBox, "CropBox": inhPAttrs.CropBox}
+	for _, k := range []string{"BleedBox", "TrimBox", "ArtBox"} {
+		a, err := ctx.DereferenceArray(d[k])
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			continue
+		}
+		if boxes[k], err = ctx.RectForArray(a); err != nil {
+			return err
+		}
+	}
+
+	for k, r := range boxes {
+		if r != nil {
+			d.Update(k, pt.rect(r).Array())
+		}
+	}
+
+	if err := pt.annotations(ctx, d, visited); err != nil {
+		return err
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err == model.ErrNoContent {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	m := pt.m
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "q %.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
+	buf.Write(bb)
+	buf.WriteString(" Q")
+
+	sd, _ := ctx.NewStreamDictForBuf(buf.Bytes())
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	d["Contents"] = *ir
+
+	return nil
+}
+
+// AnnotationToUserSpace converts the geometry of the annotation d given in visual coordinates of page pageNr
+// into default user space, see model.PageCoordinates.
+// Appearances get rotated against the page rotation in order to display upright.
+func AnnotationToUserSpace(ctx *model.Context, pageNr int, d types.Dict) error {
+	pc, err := ctx.PageCoordinates(pageNr)
+	if err != nil {
+		return err
+	}
+
+	visited := types.IntSet{}
+	if err := (pageTransform{m: pc.Matrix()}).annotation(ctx, d, visited); err != nil {
+		return err
+	}
+
+	if pc.Rot == 0 {
+		return nil
+	}
+
+	// Annotations flagged NoRotate are kept upright by viewers anyway.
+	if f := d.IntEntry("F"); f != nil && *f&16 > 0 {
+		return nil
+	}
+
+	// Widgets: R is the counterclockwise rotation relative to the page.
+	if mk, err := ctx.DereferenceDict(d["MK"]); err == nil && mk != nil {
+		r := 0
+		if i := mk.IntEntry("R"); i != nil {
+			r = *i
+		}
+		mk["R"] = types.Integer((r + pc.Rot) % 360)
+	}
+
+	ap, err := ctx.DereferenceDict(d["AP"])
+	if err != nil || ap == nil {
+		return err
+	}
+
+	pt := pageTransform{rot: 360 - pc.Rot}
+	for _, k := range []string{"N", "R", "D"} {
+		if o, found := ap.Find(k); found {
+			if err := pt.rotateAppearance(ctx, o, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"log/slog"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// collectPages appends the page dicts of the page tree rooted at ir to pages in page order.
+func collectPages(ctx *model.Context, ir types.IndirectRef, pages *[]types.IndirectRef, dd *[]types.Dict, visited types.IntSet) error {
+	objNr := ir.ObjectNumber.Value()
+	if visited[objNr] {
+		return errors.Errorf("pdfcpu: page tree node referenced twice: obj #%d", objNr)
+	}
+	visited[objNr] = true
+
+	d, err := ctx.DereferenceDict(ir)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: corrupt page tree node: obj #%d", objNr)
+	}
+
+	kids := d.ArrayEntry("Kids")
+	if t := d.Type(); (t == nil || *t != "Pages") && kids == nil {
+		*pages = append(*pages, ir)
+		*dd = append(*dd, d)
+		return nil
+	}
+
+	for _, o := range kids {
+		kid, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		if err := collectPages(ctx, kid, pages, dd, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pageTreeLevel groups kids into page tree nodes of at most model.PageTreeMaxKids kids each.
+// counts holds the number of pages of each kid.
+func pageTreeLevel(ctx *model.Context, kids types.Array, dd []types.Dict, counts []int) (types.Array, []types.Dict, []int, error) {
+	n := (len(kids) + model.PageTreeMaxKids - 1) / model.PageTreeMaxKids
+
+	var (
+		nodes       types.Array
+		nodeDicts   []types.Dict
+		nodeCounts  []int
+		first, last int
+	)
+
+	for i := 0; i < n; i++ {
+		// Distribute kids evenly.
+		first, last = last, (i+1)*len(kids)/n
+
+		count := 0
+		for _, c := range counts[first:last] {
+			count += c
+		}
+
+		d := types.Dict(
+			map[string]types.Object{
+				"Type":  types.Name("Pages"),
+				"Count": types.Integer(count),
+				"Kids":  append(types.Array(nil), kids[first:last]...),
+			},
+		)
+
+		ir, err := ctx.IndRefForNewObject(d)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, d1 := range dd[first:last] {
+			d1["Parent"] = *ir
+		}
+
+		nodes = append(nodes, *ir)
+		nodeDicts = append(nodeDicts, d)
+		nodeCounts = append(nodeCounts, count)
+	}
+
+	return nodes, nodeDicts, nodeCounts, nil
+}
+
+// RebuildPageTree replaces the page tree of ctx by a balanced page tree
+// with at most model.PageTreeMaxKids kids per node preserving the page order.
+// Inherited page attributes are moved into the page dicts.
+func RebuildPageTree(ctx *model.Context) error {
+	root, err := ctx.Pages()
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		return errors.New("pdfcpu: RebuildPageTree: missing page tree root")
+	}
+
+	var (
+		pages []types.IndirectRef
+		dd    []types.Dict
+	)
+
+	if err := collectPages(ctx, *root, &pages, &dd, types.IntSet{}); err != nil {
+		return err
+	}
+	if len(pages) == 0 {
+		return errors.New("pdfcpu: RebuildPageTree: no pages available")
+	}
+
+	for _, d := range dd {
+		if err := inheritPageAttrs(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	kids := make(types.Array, len(pages))
+	counts := make([]int, len(pages))
+	for i, ir := range pages {
+		kids[i], counts[i] = ir, 1
+	}
+
+	for len(kids) > model.PageTreeMaxKids {
+		if kids, dd, counts, err = pageTreeLevel(ctx, kids, dd, counts); err != nil {
+			return err
+		}
+	}
+
+	pagesDict := types.Dict(
+		map[string]types.Object{
+			"Type":  types.Name("Pages"),
+			"Count": types.Integer(len(pages)),
+			"Kids":  kids,
+		},
+	)
+
+	ir, err := ctx.IndRefForNewObject(pagesDict)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range dd {
+		d["Parent"] = *ir
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+	rootDict["Pages"] = *ir
+
+	ctx.PageCount = len(pages)
+
+	return nil
+}
+
+// rebalancePageTree rebuilds degenerate page trees.
+func rebalancePageTree(ctx *model.Context) error {
+	s, err := ctx.PageTreeShape()
+	if err != nil {
+		return err
+	}
+	if !s.Degenerate() {
+		return nil
+	}
+
+	if err := RebuildPageTree(ctx); err != nil {
+		return err
+	}
+
+	ctx.Conf.LogEvent(slog.LevelInfo, "pdfcpu: page tree rebuilt", "depth", s.Depth, "maxKids", s.MaxKids, "pages", s.Pages)
+
+	return nil
+}
@@ -0,0 +1,405 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const pathMaxFormDepth = 16
+
+// Path segment operators
+const (
+	PathMoveTo    = "m"
+	PathLineTo    = "l"
+	PathCurveTo   = "c" // cubic Bézier curve, "v" and "y" are normalized to "c".
+	PathClosePath = "h"
+)
+
+// PathSegment is a move, line, curve or close segment of a path.
+// Coordinates are in the user space the path was constructed in, see Path.Matrix.
+type PathSegment struct {
+	Op     string        `json:"op"`
+	Points []types.Point `json:"points,omitempty"`
+}
+
+// Path is a vector path painted or used for clipping on a page.
+type Path struct {
+	Segments         []PathSegment `json:"segments"`
+	Matrix           [6]float64    `json:"matrix"` // transformation into default user space in effect when the path was painted.
+	Stroke           bool          `json:"stroke"`
+	Fill             bool          `json:"fill"`
+	EvenOdd          bool          `json:"evenOdd,omitempty"` // fill rule
+	Clip             bool          `json:"clip,omitempty"`    // path used for clipping.
+	LineWidth        float64       `json:"lineWidth"`
+	LineCap          int           `json:"lineCap"`
+	LineJoin         int           `json:"lineJoin"`
+	MiterLimit       float64       `json:"miterLimit"`
+	DashArray        []float64     `json:"dashArray,omitempty"`
+	DashPhase        float64       `json:"dashPhase,omitempty"`
+	StrokeColorSpace string        `json:"strokeColorSpace"`
+	StrokeColor      []float64     `json:"strokeColor"`
+	FillColorSpace   string        `json:"fillColorSpace"`
+	FillColor        []float64     `json:"fillColor"`
+	StrokeAlpha      float64       `json:"strokeAlpha"`
+	FillAlpha        float64       `json:"fillAlpha"`
+}
+
+// UserSpace returns the segments of p transformed into default user space.
+func (p Path) UserSpace() []PathSegment {
+	m := matrixForOperands(p.Matrix[:])
+	ss := make([]PathSegment, len(p.Segments))
+	for i, s := range p.Segments {
+		pp := make([]types.Point, len(s.Points))
+		for j, pt := range s.Points {
+			pp[j] = m.Transform(pt)
+		}
+		ss[i] = PathSegment{Op: s.Op, Points: pp}
+	}
+	return ss
+}
+
+type pathGState struct {
+	ctm              matrix.Matrix
+	lineWidth        float64
+	lineCap          int
+	lineJoin         int
+	miterLimit       float64
+	dashArray        []float64
+	dashPhase        float64
+	strokeColorSpace string
+	strokeColor      []float64
+	fillColorSpace   string
+	fillColor        []float64
+	strokeAlpha      float64
+	fillAlpha        float64
+}
+
+type pathExtractor struct {
+	ctx      *model.Context
+	res      *svgRenderer // resource lookup is shared with the SVG backend
+	gs       pathGState
+	stack    []pathGState
+	segments []PathSegment
+	start    types.Point // start of current subpath
+	cur      types.Point
+	clip     bool
+	evenOdd  bool
+	paths    []Path
+	depth    int
+}
+
+func (x *pathExtractor) add(op string, pp ...types.Point) {
+	x.segments = append(x.segments, PathSegment{Op: op, Points: pp})
+	if len(pp) > 0 {
+		x.cur = pp[len(pp)-1]
+	}
+	if op == PathMoveTo {
+		x.start = x.cur
+	}
+	if op == PathClosePath {
+		x.cur = x.start
+	}
+}
+
+func (x *pathExtractor) paint(fill, evenOdd, stroke, close bool) {
+	if close {
+		x.add(PathClosePath)
+	}
+	if len(x.segments) > 0 && (fill || stroke || x.clip) {
+		gs := x.gs
+		m := gs.ctm
+		p := Path{
+			Segments:         x.segments,
+			Matrix:           [6]float64{m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1]},
+			Stroke:           stroke,
+			Fill:             fill,
+			EvenOdd:          fill && evenOdd || x.clip && x.evenOdd,
+			Clip:             x.clip,
+			LineWidth:        gs.lineWidth,
+			LineCap:          gs.lineCap,
+			LineJoin:         gs.lineJoin,
+			MiterLimit:       gs.miterLimit,
+			DashArray:        gs.dashArray,
+			DashPhase:        gs.dashPhase,
+			StrokeColorSpace: gs.strokeColorSpace,
+			StrokeColor:      gs.strokeColor,
+			FillColorSpace:   gs.fillColorSpace,
+			FillColor:        gs.fillColor,
+			StrokeAlpha:      gs.strokeAlpha,
+			FillAlpha:        gs.fillAlpha,
+		}
+		x.paths = append(x.paths, p)
+	}
+	x.segments = nil
+	x.clip, x.evenOdd = false, false
+}
+
+func (x *pathExtractor) pathOp(op model.ContentOp) bool {
+	ff := op.Numbers()
+	pt := func(i int) types.Point { return types.Point{X: ff[i], Y: ff[i+1]} }
+	switch op.Operator {
+	case "m":
+		if len(ff) >= 2 {
+			x.add(PathMoveTo, pt(0))
+		}
+	case "l":
+		if len(ff) >= 2 {
+			x.add(PathLineTo, pt(0))
+		}
+	case "c":
+		if len(ff) >= 6 {
+			x.add(PathCurveTo, pt(0), pt(2), pt(4))
+		}
+	case "v":
+		if len(ff) >= 4 {
+			x.add(PathCurveTo, x.cur, pt(0), pt(2))
+		}
+	case "y":
+		if len(ff) >= 4 {
+			x.add(PathCurveTo, pt(0), pt(2), pt(2))
+		}
+	case "h":
+		x.add(PathClosePath)
+	case "re":
+		if len(ff) >= 4 {
+			px, py, w, h := ff[0], ff[1], ff[2], ff[3]
+			x.add(PathMoveTo, types.Point{X: px, Y: py})
+			x.add(PathLineTo, types.Point{X: px + w, Y: py})
+			x.add(PathLineTo, types.Point{X: px + w, Y: py + h})
+			x.add(PathLineTo, types.Point{X: px, Y: py + h})
+			x.add(PathClosePath)
+		}
+	case "S":
+		x.paint(false, false, true, false)
+	case "s":
+		x.paint(false, false, true, true)
+	case "f", "F":
+		x.paint(true, false, false, false)
+	case "f*":
+		x.paint(true, true, false, false)
+	case "B":
+		x.paint(true, false, true, false)
+	case "B*":
+		x.paint(true, true, true, false)
+	case "b":
+		x.paint(true, false, true, true)
+	case "b*":
+		x.paint(true, true, true, true)
+	case "n":
+		x.paint(false, false, false, false)
+	case "W":
+		x.clip = true
+	case "W*":
+		x.clip, x.evenOdd = true, true
+	default:
+		return false
+	}
+	return true
+}
+
+func (x *pathExtractor) colorOp(op model.ContentOp) bool {
+	switch op.Operator {
+	case "g":
+		x.gs.fillColorSpace, x.gs.fillColor = "DeviceGray", op.Numbers()
+	case "rg":
+		x.gs.fillColorSpace, x.gs.fillColor = "DeviceRGB", op.Numbers()
+	case "k":
+		x.gs.fillColorSpace, x.gs.fillColor = "DeviceCMYK", op.Numbers()
+	case "G":
+		x.gs.strokeColorSpace, x.gs.strokeColor = "DeviceGray", op.Numbers()
+	case "RG":
+		x.gs.strokeColorSpace, x.gs.strokeColor = "DeviceRGB", op.Numbers()
+	case "K":
+		x.gs.strokeColorSpace, x.gs.strokeColor = "DeviceCMYK", op.Numbers()
+	case "cs":
+		x.gs.fillColorSpace, x.gs.fillColor = op.Name(0), nil
+	case "CS":
+		x.gs.strokeColorSpace, x.gs.strokeColor = op.Name(0), nil
+	case "sc", "scn":
+		x.gs.fillColor = op.Numbers()
+	case "SC", "SCN":
+		x.gs.strokeColor = op.Numbers()
+	default:
+		return false
+	}
+	return true
+}
+
+func (x *pathExtractor) extGState(resDict types.Dict, name string) {
+	o, found := x.res.resource(resDict, "ExtGState", name)
+	if !found {
+		return
+	}
+	d, err := x.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return
+	}
+	if f, err := x.ctx.DereferenceNumber(d["CA"]); err == nil && d["CA"] != nil {
+		x.gs.strokeAlpha = svgClamp(f)
+	}
+	if f, err := x.ctx.DereferenceNumber(d["ca"]); err == nil && d["ca"] != nil {
+		x.gs.fillAlpha = svgClamp(f)
+	}
+	if f, err := x.ctx.DereferenceNumber(d["LW"]); err == nil && d["LW"] != nil {
+		x.gs.lineWidth = f
+	}
+	if f, err := x.ctx.DereferenceNumber(d["ML"]); err == nil && d["ML"] != nil {
+		x.gs.miterLimit = f
+	}
+}
+
+func (x *pathExtractor) stateOp(op model.ContentOp, resDict types.Dict) bool {
+	switch op.Operator {
+	case "q":
+		x.stack = append(x.stack, x.gs)
+	case "Q":
+		if len(x.stack) > 0 {
+			x.gs = x.stack[len(x.stack)-1]
+			x.stack = x.stack[:len(x.stack)-1]
+		}
+	case "cm":
+		x.gs.ctm = matrixForOperands(op.Numbers()).Multiply(x.gs.ctm)
+	case "w":
+		x.gs.lineWidth = op.Number(0)
+	case "J":
+		x.gs.lineCap = int(op.Number(0))
+	case "j":
+		x.gs.lineJoin = int(op.Number(0))
+	case "M":
+		x.gs.miterLimit = op.Number(0)
+	case "d":
+		x.gs.dashArray = nil
+		if len(op.Operands) > 0 {
+			if a, ok := op.Operands[0].(types.Array); ok {
+				x.gs.dashArray = arrayNumbers(a)
+			}
+		}
+		x.gs.dashPhase = op.Number(1)
+	case "gs":
+		x.extGState(resDict, op.Name(0))
+	case "ri", "i":
+	default:
+		return false
+	}
+	return true
+}
+
+func (x *pathExtractor) xObject(resDict types.Dict, name string) error {
+	o, found := x.res.resource(resDict, "XObject", name)
+	if !found {
+		return nil
+	}
+	sd, _, err := x.ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return err
+	}
+	if st := sd.Subtype(); st == nil || *st != "Form" || x.depth >= pathMaxFormDepth {
+		return nil
+	}
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+	res := resDict
+	if d, err := x.ctx.DereferenceDict(sd.Dict["Resources"]); err == nil && d != nil {
+		res = d
+	}
+	x.stack = append(x.stack, x.gs)
+	if o, found := sd.Find("Matrix"); found {
+		x.gs.ctm = matrixForOperands(x.res.numberArray(o)).Multiply(x.gs.ctm)
+	}
+	x.depth++
+	err = x.extract(sd.Content, res)
+	x.depth--
+	x.gs = x.stack[len(x.stack)-1]
+	x.stack = x.stack[:len(x.stack)-1]
+	return err
+}
+
+func (x *pathExtractor) extract(bb []byte, resDict types.Dict) error {
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return err
+	}
+	stackSize := len(x.stack)
+	for _, op := range ops {
+		if x.pathOp(op) || x.colorOp(op) || x.stateOp(op, resDict) {
+			continue
+		}
+		if op.Operator == "Do" {
+			if err := x.xObject(resDict, op.Name(0)); err != nil {
+				return err
+			}
+			continue
+		}
+		if log.DebugEnabled() {
+			log.Debug.Printf("paths: skipping operator %s\n", op.Operator)
+		}
+	}
+	// Balance any unmatched q operators.
+	if len(x.stack) > stackSize {
+		x.gs = x.stack[stackSize]
+		x.stack = x.stack[:stackSize]
+	}
+	return nil
+}
+
+// ExtractPaths returns the vector paths painted or used for clipping on page pageNr including paths of form XObjects.
+// Text, images and shadings are ignored.
+func ExtractPaths(ctx *model.Context, pageNr int) ([]Path, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err != nil {
+		if err == model.ErrNoContent {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	x := &pathExtractor{
+		ctx: ctx,
+		res: &svgRenderer{ctx: ctx, fonts: map[int]*svgFont{}},
+		gs: pathGState{
+			ctm:              matrix.IdentMatrix,
+			lineWidth:        1,
+			miterLimit:       10,
+			strokeColorSpace: "DeviceGray",
+			strokeColor:      []float64{0},
+			fillColorSpace:   "DeviceGray",
+			fillColor:        []float64{0},
+			strokeAlpha:      1,
+			fillAlpha:        1,
+		},
+	}
+
+	if err := x.extract(bb, inhPAttrs.Resources); err != nil {
+		return nil, err
+	}
+
+	return x.paths, nil
+}
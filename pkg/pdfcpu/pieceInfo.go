@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// PieceInfo represents the private data of an application stored in a page-piece dict (14.5).
+type PieceInfo struct {
+	App          string
+	LastModified *time.Time
+	Private      types.Object // optional
+}
+
+// pieceInfoOwner returns the page dict for pageNr or the catalog for pageNr 0.
+func pieceInfoOwner(ctx *model.Context, pageNr int) (types.Dict, error) {
+	if pageNr == 0 {
+		return ctx.Catalog()
+	}
+
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	return d, nil
+}
+
+func pieceInfoDict(ctx *model.Context, pageNr int, create bool) (types.Dict, types.Dict, error) {
+	d, err := pieceInfoOwner(ctx, pageNr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pi, err := ctx.DereferenceDict(d["PieceInfo"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if pi == nil && create {
+		pi = types.Dict{}
+		d["PieceInfo"] = pi
+	}
+
+	return d, pi, nil
+}
+
+func pieceInfo(ctx *model.Context, app string, o types.Object) (*PieceInfo, error) {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	p := &PieceInfo{App: app}
+
+	o, err = ctx.Dereference(d["LastModified"])
+	if err != nil {
+		return nil, err
+	}
+	if o != nil {
+		s, err := types.StringOrHexLiteral(o)
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := types.DateTime(*s, ctx.XRefTable.ValidationMode == model.ValidationRelaxed); ok {
+			p.LastModified = &t
+		}
+	}
+
+	if p.Private, err = ctx.Dereference(d["Private"]); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ListPieceInfo returns the private data of all applications stored for page pageNr
+// or for the document if pageNr is 0.
+func ListPieceInfo(ctx *model.Context, pageNr int) ([]PieceInfo, error) {
+	_, pi, err := pieceInfoDict(ctx, pageNr, false)
+	if err != nil || pi == nil {
+		return nil, err
+	}
+
+	apps := make([]string, 0, len(pi))
+	for k := range pi {
+		apps = append(apps, k)
+	}
+	sort.Strings(apps)
+
+	var pp []PieceInfo
+	for _, app := range apps {
+		p, err := pieceInfo(ctx, app, pi[app])
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			pp = append(pp, *p)
+		}
+	}
+
+	return pp, nil
+}
+
+// GetPieceInfo returns the private data of app stored for page pageNr or for the document if pageNr is 0.
+// GetPieceInfo returns nil if there is no data for app.
+func GetPieceInfo(ctx *model.Context, pageNr int, app string) (*PieceInfo, error) {
+	_, pi, err := pieceInfoDict(ctx, pageNr, false)
+	if err != nil || pi == nil {
+		return nil, err
+	}
+
+	return pieceInfo(ctx, app, pi[app])
+}
+
+// SetPieceInfo stores private data of app for page pageNr or for the document if pageNr is 0
+// and updates the corresponding modification dates.
+// Please note that optimization removes page-piece dicts of pages.
+func SetPieceInfo(ctx *model.Context, pageNr int, app string, private types.Object) error {
+	if app == "" {
+		return errors.New("pdfcpu: SetPieceInfo: missing application name")
+	}
+
+	d, pi, err := pieceInfoDict(ctx, pageNr, true)
+	if err != nil {
+		return err
+	}
+
+	ts := types.StringLiteral(types.DateString(time.Now()))
+
+	d1 := types.Dict{"LastModified": ts}
+	if private != nil {
+		d1["Private"] = private
+	}
+	pi[app] = d1
+
+	if pageNr > 0 {
+		// Required for pages having a page-piece dict.
+		d["LastModified"] = ts
+	}
+
+	return nil
+}
+
+// RemovePieceInfo removes the private data of app stored for page pageNr or for the document if pageNr is 0.
+// RemovePieceInfo returns false if there is no data for app.
+func RemovePieceInfo(ctx *model.Context, pageNr int, app string) (bool, error) {
+	d, pi, err := pieceInfoDict(ctx, pageNr, false)
+	if err != nil || pi == nil {
+		return false, err
+	}
+
+	if _, found := pi.Find(app); !found {
+		return false, nil
+	}
+
+	if err := ctx.DeleteDictEntry(pi, app); err != nil {
+		return false, err
+	}
+
+	if len(pi) == 0 {
+		if err := ctx.DeleteDictEntry(d, "PieceInfo"); err != nil {
+			return false, err
+		}
+	}
+
+	if pageNr > 0 {
+		d["LastModified"] = types.StringLiteral(types.DateString(time.Now()))
+	}
+
+	return true, nil
+}
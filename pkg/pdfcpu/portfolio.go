@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/create"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const portfolioCoverFontSize = 12
+
+// PortfolioCoverSheet returns a context with a single page listing the documents of p
+// for the benefit of viewers not supporting portfolios.
+func PortfolioCoverSheet(conf *model.Configuration, p *model.Portfolio) (*model.Context, error) {
+	dim := types.PaperSize["A4"]
+
+	ctx, err := CreateContextWithXRefTable(conf, dim)
+	if err != nil {
+		return nil, err
+	}
+
+	font := map[string]interface{}{"name": defaultTOCFont, "size": portfolioCoverFontSize}
+	headFont := map[string]interface{}{"name": defaultTOCFont, "size": 2 * portfolioCoverFontSize}
+
+	lh := 1.8 * portfolioCoverFontSize
+	y := dim.Height - tocMargin
+	tt := []interface{}{
+		tocText("PDF Portfolio", tocMargin, y, headFont, "Left"),
+		tocText("This document contains the following embedded documents:", tocMargin, y-3*lh, font, "Left"),
+	}
+	y -= 5 * lh
+
+	for i, it := range p.Items {
+		if y < tocMargin+lh {
+			tt = append(tt, tocText(fmt.Sprintf("... and %d more", len(p.Items)-i), tocMargin, y, font, "Left"))
+			break
+		}
+		tt = append(tt, tocText(it.ID, tocMargin, y, font, "Left"))
+		y -= lh
+	}
+
+	bb, err := json.Marshal(map[string]interface{}{
+		"paper":  "A4",
+		"origin": "LowerLeft",
+		"pages":  map[string]interface{}{"1": map[string]interface{}{"content": map[string]interface{}{"text": tt}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create.FromJSON(ctx, bytes.NewReader(bb)); err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
@@ -23,13 +23,16 @@ import (
 )
 
 type Border struct {
-	pdf   *PDF
-	Name  string
-	Width int
-	Color string `json:"col"`
-	col   *color.SimpleColor
-	Style string
-	style types.LineJoinStyle
+	pdf    *PDF
+	Name   string
+	Width  int
+	Color  string `json:"col"`
+	col    *color.SimpleColor
+	Style  string
+	style  types.LineJoinStyle
+	Dash   []float64 // Dash array for dashed borders.
+	Radius float64   // Corner radius for rounded borders.
+	Cloudy int       // Cloudy border intensity 1 or 2.
 }
 
 func (b *Border) validate() error {
@@ -60,6 +63,20 @@ func (b *Border) validate() error {
 		}
 	}
 
+	for _, f := range b.Dash {
+		if f < 0 {
+			return errors.Errorf("pdfcpu: invalid border dash array: %v", b.Dash)
+		}
+	}
+
+	if b.Radius < 0 {
+		return errors.Errorf("pdfcpu: invalid border radius: %.2f", b.Radius)
+	}
+
+	if b.Cloudy < 0 || b.Cloudy > 2 {
+		return errors.Errorf("pdfcpu: invalid cloudy border intensity: %d (should be 1 or 2)", b.Cloudy)
+	}
+
 	return nil
 }
 
@@ -73,6 +90,20 @@ func (b *Border) mergeIn(b0 *Border) {
 	if b.style == types.LJMiter {
 		b.style = b0.style
 	}
+	if len(b.Dash) == 0 {
+		b.Dash = b0.Dash
+	}
+	if b.Radius == 0 {
+		b.Radius = b0.Radius
+	}
+	if b.Cloudy == 0 {
+		b.Cloudy = b0.Cloudy
+	}
+}
+
+// styled returns true if b needs to be drawn as dashed, rounded or cloudy border.
+func (b Border) styled() bool {
+	return len(b.Dash) > 0 || b.Radius > 0 || b.Cloudy > 0
 }
 
 // func (b *Border) SetCol(c color.SimpleColor) {
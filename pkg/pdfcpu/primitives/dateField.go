@@ -413,8 +413,9 @@ func (df *DateField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 
 	df.renderBackground(buf, bgCol, boCol, boWidth, w, h)
 
+	c := max(boWidth, 1)
 	fmt.Fprint(buf, "/Tx BMC q ")
-	fmt.Fprintf(buf, "1 1 %.1f %.1f re W n ", w-2, h-2)
+	fmt.Fprintf(buf, "%.1f %.1f %.1f %.1f re W n ", c, c, w-2*c, h-2*c)
 
 	v := ""
 	if df.dateFormat != nil {
@@ -428,21 +429,21 @@ func (df *DateField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 	}
 
 	f := df.Font
-	if float64(f.Size) > h {
+	pad := textPadding(boWidth)
+	if f.autoSize {
+		f.Size = autoFontSizeSingleLine(v, f.Name, w-2*pad, h-2*pad)
+	} else if float64(f.Size) > h {
 		f.Size = font.SizeForLineHeight(f.Name, h)
 	}
 
 	lineBB := model.CalcBoundingBox(v, 0, 0, f.Name, f.Size)
 	s := model.PrepBytes(xRefTable, v, f.Name, true, false, f.FillFont)
-	x := 2 * boWidth
-	if x == 0 {
-		x = 2
-	}
+	x := pad
 	switch df.HorAlign {
 	case types.AlignCenter:
 		x = w/2 - lineBB.Width()/2
 	case types.AlignRight:
-		x = w - lineBB.Width() - 2
+		x = w - lineBB.Width() - pad
 	}
 
 	y := (df.BoundingBox.Height()-font.LineHeight(f.Name, f.Size))/2 + font.Descent(f.Name, f.Size)
@@ -37,6 +37,7 @@ type FormFont struct {
 	Color    string `json:"col"`
 	col      *color.SimpleColor
 	FillFont bool
+	autoSize bool // DA font size 0: derive the font size from the field's dimensions.
 }
 
 // ISO-639 country codes
@@ -322,7 +323,8 @@ func fontFromDA(s string) (string, FormFont, error) {
 				return fontID, f, err
 			}
 			if fl == 0 {
-				// TODO derive size from acroDict DA and then use a default form font size (add to pdfcpu config)
+				// Auto size, fields capable of it derive their font size from their dimensions.
+				f.autoSize = true
 				fl = 12
 			}
 			f.Size = int(fl)
@@ -28,6 +28,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -151,6 +152,21 @@ func (pdf *PDF) parseColor(s string) (*color.SimpleColor, error) {
 	return sc1, nil
 }
 
+// fillPattern registers a pattern filling r with fp on page p and returns its resource id.
+// m is the transformation in effect for r.
+func (pdf *PDF) fillPattern(p *model.Page, fp model.FillPattern, r *types.Rectangle, m matrix.Matrix) (string, error) {
+	ir, err := pdf.XRefTable.NewFillPattern(fp, r, m)
+	if err != nil {
+		return "", err
+	}
+	if p.Patterns == nil {
+		p.Patterns = types.Dict{}
+	}
+	id := "P" + strconv.Itoa(len(p.Patterns))
+	p.Patterns[id] = *ir
+	return id, nil
+}
+
 func (pdf *PDF) resolveFileName(s string) (string, error) {
 	filePath, ok := pdf.FileNames[s]
 	if !ok {
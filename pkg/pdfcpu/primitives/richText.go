@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package primitives
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// richTextStyle is the subset of a rich text style (see 12.7.3.4 Rich Text Strings) applied to field appearances.
+type richTextStyle struct {
+	fontSize int
+	col      *color.SimpleColor
+	hAlign   *types.HAlignment
+}
+
+var (
+	reFontSize = regexp.MustCompile(`^(\d+(?:\.\d+)?)pt$`)
+	reRGB      = regexp.MustCompile(`^rgb\(\s*(\d+)\s*,\s*(\d+)\s*,\s*(\d+)\s*\)$`)
+)
+
+func parseFontSize(s string) int {
+	if m := reFontSize.FindStringSubmatch(s); m != nil {
+		f, _ := strconv.ParseFloat(m[1], 64)
+		return int(f + .5)
+	}
+	return 0
+}
+
+func parseCSSColor(s string) *color.SimpleColor {
+	if m := reRGB.FindStringSubmatch(s); m != nil {
+		r, _ := strconv.Atoi(m[1])
+		g, _ := strconv.Atoi(m[2])
+		b, _ := strconv.Atoi(m[3])
+		return &color.SimpleColor{R: float32(r) / 255, G: float32(g) / 255, B: float32(b) / 255}
+	}
+	if c, err := color.ParseColor(s); err == nil {
+		return &c
+	}
+	return nil
+}
+
+// parse applies the CSS2 declarations of s eg. "font: 12pt Helvetica; color: #FF0000; text-align: center".
+func (rts *richTextStyle) parse(s string) {
+	for _, decl := range strings.Split(s, ";") {
+		k, v, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		k, v = strings.ToLower(strings.TrimSpace(k)), strings.TrimSpace(v)
+
+		switch k {
+
+		case "font":
+			for _, s := range strings.Fields(v) {
+				if fs := parseFontSize(s); fs > 0 {
+					rts.fontSize = fs
+					break
+				}
+			}
+
+		case "font-size":
+			if fs := parseFontSize(v); fs > 0 {
+				rts.fontSize = fs
+			}
+
+		case "color":
+			if c := parseCSSColor(v); c != nil {
+				rts.col = c
+			}
+
+		case "text-align":
+			var a types.HAlignment
+			switch strings.ToLower(v) {
+			case "left":
+				a = types.AlignLeft
+			case "center":
+				a = types.AlignCenter
+			case "right":
+				a = types.AlignRight
+			default:
+				continue
+			}
+			rts.hAlign = &a
+		}
+	}
+}
+
+// IsRichText returns true if s is an XHTML rich text string as used for the RV entry of fields.
+func IsRichText(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "<?xml") || strings.HasPrefix(s, "<body")
+}
+
+// RichTextValue returns a rich text string for the plain text s, one paragraph per line.
+func RichTextValue(s string) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0"?><body xmlns="http://www.w3.org/1999/xhtml" xmlns:xfa="http://www.xfa.org/schema/xfa-data/1.0/" xfa:APIVersion="Acrobat:11.0.0" xfa:spec="2.0.2">`)
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\\n", "\n"), "\n") {
+		sb.WriteString("<p>")
+		xml.EscapeText(&sb, []byte(line))
+		sb.WriteString("</p>")
+	}
+	sb.WriteString("</body>")
+	return sb.String()
+}
+
+// parseRichText returns the plain text of the rich text string rv
+// and applies the style in effect at the beginning of the text to style if not nil.
+func parseRichText(rv string, style *richTextStyle) (string, error) {
+	dec := xml.NewDecoder(strings.NewReader(rv))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	if style == nil {
+		style = &richTextStyle{}
+	}
+
+	var sb strings.Builder
+
+	newLine := func() {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+	}
+
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "pdfcpu: invalid rich text")
+		}
+
+		switch t := t.(type) {
+
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p", "div":
+				newLine()
+			case "br":
+				sb.WriteString("\n")
+			}
+			if sb.Len() > 0 {
+				continue
+			}
+			for _, a := range t.Attr {
+				if a.Name.Local == "style" {
+					style.parse(a.Value)
+				}
+			}
+
+		case xml.CharData:
+			// Skip formatting whitespace between elements.
+			if strings.TrimSpace(string(t)) == "" && strings.ContainsAny(string(t), "\n\r") {
+				continue
+			}
+			sb.Write(t)
+		}
+	}
+
+	return strings.Trim(sb.String(), "\n"), nil
+}
+
+// RichTextPlain returns the plain text of the rich text string rv.
+func RichTextPlain(rv string) (string, error) {
+	return parseRichText(rv, nil)
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package primitives
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	sigDefaultFontName   = "Helvetica"
+	sigDefaultDateFormat = "2006-01-02 15:04:05 -07:00"
+	sigFontID            = "F0"
+	sigImageID           = "Im0"
+	sigMinFontSize       = 4
+	sigMaxFontSize       = 12
+	sigPadding           = 2.
+)
+
+// SignatureAppearance is a reusable template for the visible appearance of a signature field.
+//
+// The appearance is split into a graphic area on the left showing the logo and/or the graph of the signature
+// and a text area on the right listing signer name, reason, location and date.
+// Without logo and graph the text area occupies the whole field.
+type SignatureAppearance struct {
+	Name       string
+	Reason     string
+	Location   string
+	Date       time.Time // omitted if zero
+	DateFormat string    // Go time layout, defaults to 2006-01-02 15:04:05 -07:00
+
+	Logo []byte // optional JPG, PNG, TIFF or WEBP image
+
+	// Graph is an optional handwritten signature represented by a sequence of strokes.
+	// Point coordinates are relative to the graphic area with 0,0 = lower left and 1,1 = upper right.
+	Graph      [][]types.Point
+	GraphColor *color.SimpleColor // defaults to black
+	GraphWidth float64            // stroke width, defaults to 1
+
+	FontName  string // core font, defaults to Helvetica
+	FontSize  int    // 0 = fit text into text area
+	TextColor *color.SimpleColor
+
+	BackgroundColor *color.SimpleColor
+	BorderColor     *color.SimpleColor
+}
+
+func (sa *SignatureAppearance) lines() []string {
+	var ss []string
+	if sa.Name != "" {
+		ss = append(ss, "Signed by: "+sa.Name)
+	}
+	if sa.Reason != "" {
+		ss = append(ss, "Reason: "+sa.Reason)
+	}
+	if sa.Location != "" {
+		ss = append(ss, "Location: "+sa.Location)
+	}
+	if !sa.Date.IsZero() {
+		df := sa.DateFormat
+		if df == "" {
+			df = sigDefaultDateFormat
+		}
+		ss = append(ss, "Date: "+sa.Date.Format(df))
+	}
+	return ss
+}
+
+func (sa *SignatureAppearance) graphic() bool {
+	return len(sa.Logo) > 0 || len(sa.Graph) > 0
+}
+
+// Validate checks sa for consistency.
+func (sa *SignatureAppearance) Validate() error {
+	if len(sa.lines()) == 0 && !sa.graphic() {
+		return errors.New("pdfcpu: empty signature appearance")
+	}
+	if sa.FontName != "" && !font.IsCoreFont(sa.FontName) {
+		return errors.Errorf("pdfcpu: signature appearance: unsupported core font: %s", sa.FontName)
+	}
+	if sa.FontSize < 0 {
+		return errors.Errorf("pdfcpu: signature appearance: invalid font size: %d", sa.FontSize)
+	}
+	if sa.GraphWidth < 0 {
+		return errors.Errorf("pdfcpu: signature appearance: invalid graph width: %.2f", sa.GraphWidth)
+	}
+	for _, stroke := range sa.Graph {
+		for _, p := range stroke {
+			if p.X < 0 || p.X > 1 || p.Y < 0 || p.Y > 1 {
+				return errors.Errorf("pdfcpu: signature appearance: graph point out of range: %.2f %.2f", p.X, p.Y)
+			}
+		}
+	}
+	return nil
+}
+
+func (sa *SignatureAppearance) fontName() string {
+	if sa.FontName != "" {
+		return sa.FontName
+	}
+	return sigDefaultFontName
+}
+
+// fontSize returns the font size used for rendering lines into a w x h text area.
+func (sa *SignatureAppearance) fontSize(lines []string, w, h float64) int {
+	if sa.FontSize > 0 {
+		return sa.FontSize
+	}
+	fontName := sa.fontName()
+	fontSize := sigMaxFontSize
+	for ; fontSize > sigMinFontSize; fontSize-- {
+		if float64(len(lines))*font.LineHeight(fontName, fontSize) > h {
+			continue
+		}
+		fits := true
+		for _, s := range lines {
+			if font.TextWidth(s, fontName, fontSize) > w {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			break
+		}
+	}
+	return fontSize
+}
+
+func (sa *SignatureAppearance) renderLogo(xRefTable *model.XRefTable, w io.Writer, r *types.Rectangle) (*types.IndirectRef, error) {
+	ir, iw, ih, err := model.CreateImageResource(xRefTable, bytes.NewReader(sa.Logo))
+	if err != nil {
+		return nil, err
+	}
+
+	// Scale to fit preserving the aspect ratio and center.
+	s := r.Width() / float64(iw)
+	if s1 := r.Height() / float64(ih); s1 < s {
+		s = s1
+	}
+	dx, dy := float64(iw)*s, float64(ih)*s
+	x, y := r.LL.X+(r.Width()-dx)/2, r.LL.Y+(r.Height()-dy)/2
+
+	fmt.Fprintf(w, "q %.2f 0 0 %.2f %.2f %.2f cm /%s Do Q ", dx, dy, x, y, sigImageID)
+
+	return ir, nil
+}
+
+func (sa *SignatureAppearance) renderGraph(w io.Writer, r *types.Rectangle) {
+	c := color.Black
+	if sa.GraphColor != nil {
+		c = *sa.GraphColor
+	}
+	lw := sa.GraphWidth
+	if lw == 0 {
+		lw = 1
+	}
+
+	fmt.Fprintf(w, "q %.2f %.2f %.2f RG %.2f w 1 J 1 j ", c.R, c.G, c.B, lw)
+	for _, stroke := range sa.Graph {
+		for i, p := range stroke {
+			op := "l"
+			if i == 0 {
+				op = "m"
+			}
+			fmt.Fprintf(w, "%.2f %.2f %s ", r.LL.X+p.X*r.Width(), r.LL.Y+p.Y*r.Height(), op)
+		}
+		if len(stroke) > 0 {
+			fmt.Fprint(w, "S ")
+		}
+	}
+	fmt.Fprint(w, "Q ")
+}
+
+func (sa *SignatureAppearance) renderText(w io.Writer, lines []string, r *types.Rectangle) {
+	fontName := sa.fontName()
+	fontSize := sa.fontSize(lines, r.Width(), r.Height())
+	lh := font.LineHeight(fontName, fontSize)
+
+	c := color.Black
+	if sa.TextColor != nil {
+		c = *sa.TextColor
+	}
+
+	// Vertically centered text block.
+	y := r.LL.Y + (r.Height()+float64(len(lines))*lh)/2 - font.Ascent(fontName, fontSize)
+
+	fmt.Fprintf(w, "q %.2f %.2f %.2f %.2f re W n ", r.LL.X, r.LL.Y, r.Width(), r.Height())
+	fmt.Fprintf(w, "BT /%s %d Tf %.2f %.2f %.2f rg ", sigFontID, fontSize, c.R, c.G, c.B)
+	for i, s := range lines {
+		s1, _ := types.Escape(model.DecodeUTF8ToByte(s))
+		if i == 0 {
+			fmt.Fprintf(w, "%.2f %.2f Td (%s) Tj ", r.LL.X, y, *s1)
+			continue
+		}
+		fmt.Fprintf(w, "0 %.2f Td (%s) Tj ", -lh, *s1)
+	}
+	fmt.Fprint(w, "ET Q ")
+}
+
+// Render creates a form XObject of size w x h representing sa.
+func (sa *SignatureAppearance) Render(xRefTable *model.XRefTable, w, h float64) (*types.IndirectRef, error) {
+	if err := sa.Validate(); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	bbox := types.NewRectangle(0, 0, w, h)
+
+	if sa.BackgroundColor != nil {
+		c := sa.BackgroundColor
+		fmt.Fprintf(buf, "q %.2f %.2f %.2f rg 0 0 %.2f %.2f re f Q ", c.R, c.G, c.B, w, h)
+	}
+	if sa.BorderColor != nil {
+		c := sa.BorderColor
+		fmt.Fprintf(buf, "q %.2f %.2f %.2f RG 1 w 0.5 0.5 %.2f %.2f re S Q ", c.R, c.G, c.B, w-1, h-1)
+	}
+
+	lines := sa.lines()
+	rText := types.NewRectangle(sigPadding, sigPadding, w-sigPadding, h-sigPadding)
+
+	resDict := types.Dict{}
+
+	if sa.graphic() {
+		rGraphic := rText
+		if len(lines) > 0 {
+			rGraphic = types.NewRectangle(sigPadding, sigPadding, w*0.4-sigPadding, h-sigPadding)
+			rText = types.NewRectangle(w*0.4+sigPadding, sigPadding, w-sigPadding, h-sigPadding)
+		}
+		if len(sa.Logo) > 0 {
+			ir, err := sa.renderLogo(xRefTable, buf, rGraphic)
+			if err != nil {
+				return nil, err
+			}
+			resDict["XObject"] = types.Dict{sigImageID: *ir}
+		}
+		if len(sa.Graph) > 0 {
+			sa.renderGraph(buf, rGraphic)
+		}
+	}
+
+	if len(lines) > 0 {
+		ir, err := pdffont.EnsureFontDict(xRefTable, sa.fontName(), "", "", false, nil)
+		if err != nil {
+			return nil, err
+		}
+		resDict["Font"] = types.Dict{sigFontID: *ir}
+		sa.renderText(buf, lines, rText)
+	}
+
+	sd, err := xRefTable.NewStreamDictForBuf(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.InsertInt("FormType", 1)
+	sd.Insert("BBox", bbox.Array())
+	sd.Insert("Matrix", types.NewNumberArray(1, 0, 0, 1, 0, 0))
+	sd.Insert("Resources", resDict)
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
+
+// EnsureSignatureAP renders sa as the normal appearance of the signature field widget d.
+func EnsureSignatureAP(xRefTable *model.XRefTable, d types.Dict, sa *SignatureAppearance) error {
+	arr, err := xRefTable.DereferenceArray(d["Rect"])
+	if err != nil {
+		return err
+	}
+	r, err := xRefTable.RectForArray(arr)
+	if err != nil {
+		return err
+	}
+	if r == nil || r.Width() <= 0 || r.Height() <= 0 {
+		return errors.New("pdfcpu: signature field without visible area")
+	}
+
+	// Render upright relative to the widget rotation.
+	rot := 0
+	if mk, err := xRefTable.DereferenceDict(d["MK"]); err == nil && mk != nil {
+		if i := mk.IntEntry("R"); i != nil {
+			rot = (*i%360 + 360) % 360
+		}
+	}
+
+	w, h := r.Width(), r.Height()
+	if rot == 90 || rot == 270 {
+		w, h = h, w
+	}
+
+	ir, err := sa.Render(xRefTable, w, h)
+	if err != nil {
+		return err
+	}
+
+	if rot != 0 {
+		sd, _, err := xRefTable.DereferenceStreamDict(*ir)
+		if err != nil {
+			return err
+		}
+		m := matrix.CalcRotateAndTranslateTransformMatrix(float64(rot), 0, 0)
+		sd.Dict["Matrix"] = types.NewNumberArray(m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
+	}
+
+	d["AP"] = types.Dict{"N": *ir}
+
+	return nil
+}
@@ -45,6 +45,8 @@ type SimpleBox struct {
 	Border    *Border
 	FillColor string `json:"fillCol"`
 	fillCol   *color.SimpleColor
+	Gradient  string `json:"gradient"` // Fill gradient or pattern overriding FillColor, see model.ParseFillPattern.
+	gradient  *model.FillPattern
 	Rotation  float64 `json:"rot"`
 	Hide      bool
 }
@@ -91,6 +93,14 @@ func (sb *SimpleBox) validate() error {
 		sb.fillCol = sc
 	}
 
+	if sb.Gradient != "" {
+		fp, err := model.ParseFillPattern(sb.Gradient)
+		if err != nil {
+			return err
+		}
+		sb.gradient = fp
+	}
+
 	return nil
 }
 
@@ -135,6 +145,10 @@ func (sb *SimpleBox) mergeIn(sb0 *SimpleBox) {
 		sb.fillCol = sb0.fillCol
 	}
 
+	if sb.gradient == nil {
+		sb.gradient = sb0.gradient
+	}
+
 	if sb.Rotation == 0 {
 		sb.Rotation = sb0.Rotation
 	}
@@ -277,6 +291,32 @@ func (sb *SimpleBox) render(p *model.Page) error {
 
 	fmt.Fprintf(p.Buf, "q %.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
 
+	if sb.gradient != nil {
+		id, err := sb.pdf.fillPattern(p, *sb.gradient, r, m)
+		if err != nil {
+			return err
+		}
+		draw.FillRectWithPattern(p.Buf, r, bWidth, bCol, id, &bStyle)
+		if sb.pdf.Debug {
+			draw.DrawCircle(p.Buf, r.LL.X, r.LL.Y, 5, color.Black, &color.Red)
+		}
+		fmt.Fprint(p.Buf, "Q ")
+		return nil
+	}
+
+	if sb.Border != nil && sb.Border.styled() {
+		b := draw.Border{Width: bWidth, Color: bCol, Style: bStyle, Dash: sb.Border.Dash, Radius: sb.Border.Radius, Cloudy: sb.Border.Cloudy}
+		if b.Color == nil {
+			b.Color = &color.Black
+		}
+		draw.DrawBorderedRect(p.Buf, r, b, sb.fillCol)
+		if sb.pdf.Debug {
+			draw.DrawCircle(p.Buf, r.LL.X, r.LL.Y, 5, color.Black, &color.Red)
+		}
+		fmt.Fprint(p.Buf, "Q ")
+		return nil
+	}
+
 	if sb.fillCol != nil {
 		draw.FillRect(p.Buf, r, bWidth, bCol, *sb.fillCol, &bStyle)
 		if sb.pdf.Debug {
@@ -164,6 +164,8 @@ type Table struct {
 	oddCol          *color.SimpleColor
 	evenCol         *color.SimpleColor
 	RTL             bool
+	Wrap            bool    // Break cell lines to fit column widths.
+	Hyphenation     string  // Language for hyphenating wrapped cell lines eg. "en-us".
 	Rotation        float64 `json:"rot"`
 	Grid            bool
 	Hide            bool
@@ -416,6 +418,10 @@ func (t *Table) validate() error {
 		return err
 	}
 
+	if err := validateHyphenation(t.Hyphenation); err != nil {
+		return err
+	}
+
 	return t.validateColors()
 }
 
@@ -487,6 +493,14 @@ func (t *Table) mergeIn(t0 *Table) {
 		t.evenCol = t0.evenCol
 	}
 
+	if !t.Wrap {
+		t.Wrap = t0.Wrap
+	}
+
+	if t.Hyphenation == "" {
+		t.Hyphenation = t0.Hyphenation
+	}
+
 	if t.Rotation == 0 {
 		t.Rotation = t0.Rotation
 	}
@@ -752,6 +766,12 @@ func (t *Table) calcTextDescriptorPadding(td *model.TextDescriptor, p *Padding)
 	return nil
 }
 
+func (t *Table) wrapCell(td *model.TextDescriptor, w float64) {
+	if t.Wrap {
+		td.Text = model.WrapText(td.Text, td.FontName, td.FontSize, w-td.MLeft-td.MRight, t.Hyphenation)
+	}
+}
+
 func (t *Table) renderValues(p *model.Page, pageNr int, fonts model.FontMap, colWidths []float64, td model.TextDescriptor, ll func(row, col int) (float64, float64)) error {
 	pdf := t.pdf
 
@@ -795,6 +815,7 @@ func (t *Table) renderValues(p *model.Page, pageNr int, fonts model.FontMap, col
 			}
 
 			colTd.Text, _ = format.Text(s, pdf.TimestampFormat, pageNr, pdf.pageCount())
+			t.wrapCell(&colTd, colWidths[j])
 
 			row := i
 			if t.Header != nil {
@@ -869,6 +890,7 @@ func (t *Table) renderHeader(p *model.Page, pageNr int, fonts model.FontMap, col
 		colTd := td
 		th.calcColumnPadding(&colTd, i)
 		colTd.Text, _ = format.Text(s, pdf.TimestampFormat, pageNr, pdf.pageCount())
+		t.wrapCell(&colTd, colWidths[i])
 
 		x, y := ll(0, i)
 		r := types.RectForWidthAndHeight(x, y, colWidths[i], float64(th.LineHeight))
@@ -21,6 +21,7 @@ import (
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/format"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/linebreak"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -50,10 +51,26 @@ type TextBox struct {
 	Alignment       string `json:"align"` // "Left", "Center", "Right"
 	horAlign        types.HAlignment
 	RTL             bool
+	Wrap            bool    // Break lines to fit Width.
+	Hyphenation     string  // Language for hyphenating wrapped lines eg. "en-us".
 	Rotation        float64 `json:"rot"`
 	Hide            bool
 }
 
+func validateHyphenation(lang string) error {
+	if lang == "" {
+		return nil
+	}
+	h, err := linebreak.HyphenatorFor(lang)
+	if err != nil {
+		return err
+	}
+	if h == nil {
+		return errors.Errorf("pdfcpu: no hyphenation patterns available for \"%s\"", lang)
+	}
+	return nil
+}
+
 func (tb *TextBox) validateAnchor() error {
 	if tb.Anchor != "" {
 		if tb.Position[0] != 0 || tb.Position[1] != 0 {
@@ -161,6 +178,10 @@ func (tb *TextBox) validate() error {
 		return err
 	}
 
+	if err := validateHyphenation(tb.Hyphenation); err != nil {
+		return err
+	}
+
 	return tb.validateHorAlign()
 }
 
@@ -233,6 +254,14 @@ func (tb *TextBox) mergeIn(tb0 *TextBox) {
 		tb.bgCol = tb0.bgCol
 	}
 
+	if !tb.Wrap {
+		tb.Wrap = tb0.Wrap
+	}
+
+	if tb.Hyphenation == "" {
+		tb.Hyphenation = tb0.Hyphenation
+	}
+
 	if tb.Rotation == 0 {
 		tb.Rotation = tb0.Rotation
 	}
@@ -305,19 +334,21 @@ func (tb *TextBox) prepareTextDescriptor(p *model.Page, pageNr int, fonts model.
 	dx, dy := types.NormalizeOffset(tb.Dx, tb.Dy, pdf.origin)
 
 	td := model.TextDescriptor{
-		Text:     t,
-		Dx:       dx,
-		Dy:       dy,
-		HAlign:   tb.horAlign,
-		VAlign:   types.AlignBottom,
-		FontName: fontName,
-		Embed:    true,
-		FontKey:  id,
-		FontSize: fontSize,
-		Scale:    1.,
-		ScaleAbs: true,
-		Rotation: tb.Rotation,
-		RTL:      tb.RTL, // for user fonts only!
+		Text:        t,
+		Dx:          dx,
+		Dy:          dy,
+		HAlign:      tb.horAlign,
+		VAlign:      types.AlignBottom,
+		FontName:    fontName,
+		Embed:       true,
+		FontKey:     id,
+		FontSize:    fontSize,
+		Scale:       1.,
+		ScaleAbs:    true,
+		Rotation:    tb.Rotation,
+		RTL:         tb.RTL, // for user fonts only!
+		Wrap:        tb.Wrap,
+		Hyphenation: tb.Hyphenation,
 	}
 
 	if col != nil {
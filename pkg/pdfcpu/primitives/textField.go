@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"strings"
 
 	"unicode/utf8"
@@ -369,22 +370,140 @@ func (tf *TextField) renderBackground(w io.Writer, bgCol, boCol *color.SimpleCol
 	}
 }
 
+// textPadding returns the horizontal and vertical distance of text from the field's edges.
+func textPadding(boWidth float64) float64 {
+	if boWidth > 0 {
+		return 2 * boWidth
+	}
+	return 2
+}
+
+const minAutoFontSize = 4
+
+// autoFontSizeSingleLine returns the font size for a single line value s fitting into w and h.
+func autoFontSizeSingleLine(s, fontName string, w, h float64) int {
+	fs := font.SizeForLineHeight(fontName, h)
+	if s != "" {
+		if fs1 := font.Size(s, fontName, w); fs1 < fs {
+			fs = fs1
+		}
+	}
+	return max(fs, minAutoFontSize)
+}
+
+// combGlyphs splits s into glyphs, bytes for core fonts and runes otherwise.
+func combGlyphs(s, fontName string) []string {
+	var gg []string
+	if font.IsCoreFont(fontName) {
+		for i := 0; i < len(s); i++ {
+			gg = append(gg, s[i:i+1])
+		}
+		return gg
+	}
+	for _, r := range s {
+		gg = append(gg, string(r))
+	}
+	return gg
+}
+
+func (tf *TextField) comb() bool {
+	return tf.Comb && tf.MaxLen > 0 && !tf.Multiline
+}
+
+// autoFontSize returns the font size to be used for font size 0 in DA like Acrobat does:
+// Single line values fit the field height and width, comb values fit each glyph into its cell,
+// multiline values use the largest size up to 12 points for which all wrapped lines fit into the field.
+func (tf *TextField) autoFontSize(s string, w, h float64) int {
+	const maxMultilineSize = 12
+
+	f := tf.Font
+
+	if tf.comb() {
+		fs := font.SizeForLineHeight(f.Name, h)
+		cw := tf.BoundingBox.Width() / float64(tf.MaxLen)
+		for _, g := range combGlyphs(s, f.Name) {
+			if font.TextWidth(g, f.Name, 1000) == 0 {
+				continue
+			}
+			if fs1 := font.Size(g, f.Name, cw); fs1 < fs {
+				fs = fs1
+			}
+		}
+		return max(fs, minAutoFontSize)
+	}
+
+	if !tf.Multiline {
+		return autoFontSizeSingleLine(s, f.Name, w, h)
+	}
+
+	for fs := maxMultilineSize; fs > minAutoFontSize; fs-- {
+		lines := model.WordWrap(s, f.Name, fs, w)
+		if float64(len(lines))*font.LineHeight(f.Name, fs) <= h {
+			return fs
+		}
+	}
+
+	return minAutoFontSize
+}
+
+// renderComb renders s using one of MaxLen equally spaced cells per glyph.
+// The quadding determines the cells used if s is shorter than MaxLen.
+func (tf *TextField) renderComb(xRefTable *model.XRefTable, w, y float64, s string, buf io.Writer) {
+	f := tf.Font
+	cjk := pdffont.CJK(f.Script, f.Lang)
+
+	gg := combGlyphs(s, f.Name)
+	if len(gg) > tf.MaxLen {
+		gg = gg[:tf.MaxLen]
+	}
+
+	first := 0
+	switch tf.HorAlign {
+	case types.AlignCenter:
+		first = (tf.MaxLen - len(gg)) / 2
+	case types.AlignRight:
+		first = tf.MaxLen - len(gg)
+	}
+
+	cw := w / float64(tf.MaxLen)
+
+	fmt.Fprintf(buf, "BT /%s %d Tf %.2f %.2f %.2f RG %.2f %.2f %.2f rg ",
+		tf.fontID, f.Size,
+		f.col.R, f.col.G, f.col.B,
+		f.col.R, f.col.G, f.col.B)
+
+	// Td is relative to the start of the previous glyph.
+	var x0 float64
+	for i, g := range gg {
+		x := float64(first+i)*cw + (cw-font.TextWidth(g, f.Name, f.Size))/2
+		fmt.Fprintf(buf, "%.2f %.2f Td (%s) Tj ", x-x0, y, model.PrepBytes(xRefTable, g, f.Name, !cjk, false, f.FillFont))
+		x0, y = x, 0
+	}
+
+	fmt.Fprint(buf, "ET ")
+}
+
 func (tf *TextField) renderLines(xRefTable *model.XRefTable, boWidth, lh, w, y float64, lines []string, buf io.Writer) {
+	if tf.comb() {
+		if len(lines) > 0 {
+			tf.renderComb(xRefTable, w, y, lines[0], buf)
+		}
+		return
+	}
+
 	f := tf.Font
 	cjk := pdffont.CJK(f.Script, f.Lang)
+	pad := textPadding(boWidth)
 	for i := 0; i < len(lines); i++ {
 		s := lines[i]
 		lineBB := model.CalcBoundingBox(s, 0, 0, f.Name, f.Size)
 		s = model.PrepBytes(xRefTable, s, f.Name, !cjk, f.RTL(), f.FillFont)
-		x := 2 * boWidth
-		if x == 0 {
-			x = 2
-		}
+		x := pad
 		switch tf.HorAlign {
 		case types.AlignCenter:
 			x = w/2 - lineBB.Width()/2
 		case types.AlignRight:
-			x = w - lineBB.Width() - 2
+			x = w - lineBB.Width() - pad
 		}
 		fmt.Fprint(buf, "BT ")
 		if i == 0 {
@@ -394,19 +513,7 @@ func (tf *TextField) renderLines(xRefTable *model.XRefTable, boWidth, lh, w, y f
 				f.col.R, f.col.G, f.col.B)
 		}
 
-		if tf.Comb && tf.MaxLen > 0 && tf.HorAlign == types.AlignLeft {
-			x = 0.5
-			dx := w / float64(tf.MaxLen)
-			y0 := y
-			for j := 0; j < len(s) && j < tf.MaxLen; j++ {
-				fmt.Fprintf(buf, "%.2f %.2f Td (%c) Tj ", x, y0, s[j])
-				y0 = 0
-				x = dx
-			}
-			fmt.Fprint(buf, "ET ")
-		} else {
-			fmt.Fprintf(buf, "%.2f %.2f Td (%s) Tj ET ", x, y, s)
-		}
+		fmt.Fprintf(buf, "%.2f %.2f Td (%s) Tj ET ", x, y, s)
 
 		y -= lh
 	}
@@ -421,10 +528,7 @@ func (tf *TextField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 	tf.renderBackground(buf, bgCol, boCol, boWidth, w, h)
 
 	f := tf.Font
-
-	if !tf.Multiline && float64(f.Size) > h {
-		f.Size = font.SizeForLineHeight(f.Name, h)
-	}
+	pad := textPadding(boWidth)
 
 	s := tf.Value
 	if s == "" {
@@ -435,24 +539,39 @@ func (tf *TextField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 		s = model.DecodeUTF8ToByte(s)
 	}
 
-	var lines []string
-	if tf.Multiline {
-		lines = model.WordWrap(s, f.Name, f.Size, w-2*boWidth)
-	} else {
-		ll := strings.ReplaceAll(s, "\\n", "\n")
-		lines = append(lines, strings.ReplaceAll(ll, "\n", " "))
+	if !tf.Multiline {
+		s = strings.ReplaceAll(strings.ReplaceAll(s, "\\n", "\n"), "\n", " ")
 	}
 
-	fmt.Fprint(buf, "/Tx BMC ")
+	if f.autoSize {
+		f.Size = tf.autoFontSize(s, w-2*pad, h-2*pad)
+	} else if !tf.Multiline && float64(f.Size) > h {
+		f.Size = font.SizeForLineHeight(f.Name, h)
+	}
 
 	lh := font.LineHeight(f.Name, f.Size)
-	y := (tf.BoundingBox.Height()-lh)/2 + font.Descent(f.Name, f.Size)
+
+	// Center single lines vertically, start multiple lines at the top.
+	y := (h-lh)/2 + font.Descent(f.Name, f.Size)
+
+	var lines []string
 	if tf.Multiline {
-		y = tf.BoundingBox.Height() - lh
+		lines = model.WordWrap(s, f.Name, f.Size, w-2*pad)
+		y = h - pad - font.Ascent(f.Name, f.Size)
+		// Skip lines below the visible area.
+		if n := int(math.Ceil((h - 2*pad) / lh)); n < len(lines) {
+			lines = lines[:max(n, 1)]
+		}
+	} else {
+		lines = append(lines, s)
 	}
 
+	fmt.Fprint(buf, "/Tx BMC ")
+
+	// Clip to the area inside the border.
 	if len(lines) > 0 {
-		fmt.Fprintf(buf, "q 1 1 %.1f %.1f re W n ", w-2, h-2)
+		c := max(boWidth, 1)
+		fmt.Fprintf(buf, "q %.1f %.1f %.1f %.1f re W n ", c, c, w-2*c, h-2*c)
 	}
 
 	tf.renderLines(xRefTable, boWidth, lh, w, y, lines, buf)
@@ -464,8 +583,17 @@ func (tf *TextField) renderN(xRefTable *model.XRefTable) ([]byte, error) {
 	fmt.Fprint(buf, "EMC ")
 
 	if boCol != nil && boWidth > 0 {
-		fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s Q ",
+		fmt.Fprintf(buf, "q %.2f %.2f %.2f RG %.2f w %.2f %.2f %.2f %.2f re s ",
 			boCol.R, boCol.G, boCol.B, boWidth-1, boWidth/2, boWidth/2, w-boWidth, h-boWidth)
+		if tf.comb() {
+			// Cell dividers
+			for i := 1; i < tf.MaxLen; i++ {
+				x := float64(i) * w / float64(tf.MaxLen)
+				fmt.Fprintf(buf, "%.2f 0 m %.2f %.2f l ", x, x, h)
+			}
+			fmt.Fprint(buf, "S ")
+		}
+		fmt.Fprint(buf, "Q ")
 	}
 
 	return buf.Bytes(), nil
@@ -958,7 +1086,23 @@ func NewTextField(
 	return tf, fontIndRef, nil
 }
 
-func renderTextFieldAP(ctx *model.Context, d types.Dict, v string, multiLine, comb bool, maxLen int, da *string, fonts map[string]types.IndirectRef) error {
+func (tf *TextField) applyRichTextStyle(rts *richTextStyle) {
+	if rts == nil {
+		return
+	}
+	if rts.fontSize > 0 {
+		tf.Font.Size = rts.fontSize
+		tf.Font.autoSize = false
+	}
+	if rts.col != nil {
+		tf.Font.col = rts.col
+	}
+	if rts.hAlign != nil {
+		tf.HorAlign = *rts.hAlign
+	}
+}
+
+func renderTextFieldAP(ctx *model.Context, d types.Dict, v string, multiLine, comb bool, maxLen int, da *string, fonts map[string]types.IndirectRef, rts *richTextStyle) error {
 	if ap := d.DictEntry("AP"); ap != nil {
 		if err := ctx.DeleteObject(ap); err != nil {
 			return err
@@ -970,6 +1114,8 @@ func renderTextFieldAP(ctx *model.Context, d types.Dict, v string, multiLine, co
 		return err
 	}
 
+	tf.applyRichTextStyle(rts)
+
 	bb, err := tf.renderN(ctx.XRefTable)
 	if err != nil {
 		return err
@@ -1031,15 +1177,36 @@ func fontAttrs(ctx *model.Context, fd types.Dict, fontID, text string, fonts map
 	return fontID, name, lang, script, fontIndRef, nil
 }
 
+// EnsureTextFieldAP renders the normal appearance of the text field widget d for text.
 func EnsureTextFieldAP(ctx *model.Context, d types.Dict, text string, multiLine, comb bool, maxLen int, da *string, fonts map[string]types.IndirectRef) error {
+	return ensureTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts, nil)
+}
+
+// EnsureRichTextFieldAP renders the normal appearance of the rich text field widget d for the rich text string rv.
+// The default style string ds and the style in effect at the beginning of rv override font size, color and alignment.
+func EnsureRichTextFieldAP(ctx *model.Context, d types.Dict, rv string, ds *string, multiLine, comb bool, maxLen int, da *string, fonts map[string]types.IndirectRef) error {
+	rts := &richTextStyle{}
+	if ds != nil {
+		rts.parse(*ds)
+	}
+
+	text, err := parseRichText(rv, rts)
+	if err != nil {
+		return err
+	}
+
+	return ensureTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts, rts)
+}
+
+func ensureTextFieldAP(ctx *model.Context, d types.Dict, text string, multiLine, comb bool, maxLen int, da *string, fonts map[string]types.IndirectRef, rts *richTextStyle) error {
 	ap := d.DictEntry("AP")
 	if ap == nil {
-		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts)
+		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts, rts)
 	}
 
 	irN := ap.IndirectRefEntry("N")
 	if irN == nil {
-		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts)
+		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts, rts)
 	}
 
 	sd, _, err := ctx.DereferenceStreamDict(*irN)
@@ -1049,7 +1216,7 @@ func EnsureTextFieldAP(ctx *model.Context, d types.Dict, text string, multiLine,
 
 	obj, ok := sd.Find("Resources")
 	if !ok {
-		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts)
+		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts, rts)
 	}
 
 	d1, err := ctx.DereferenceDict(obj)
@@ -1057,12 +1224,12 @@ func EnsureTextFieldAP(ctx *model.Context, d types.Dict, text string, multiLine,
 		return err
 	}
 	if d1 == nil {
-		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts)
+		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts, rts)
 	}
 
 	fd := d1.DictEntry("Font")
 	if fd == nil {
-		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts)
+		return renderTextFieldAP(ctx, d, text, multiLine, comb, maxLen, da, fonts, rts)
 	}
 
 	s := locateDA(ctx, d, da)
@@ -1095,6 +1262,7 @@ func EnsureTextFieldAP(ctx *model.Context, d types.Dict, text string, multiLine,
 	tf.fontID = fontID
 	tf.Font = &f
 	tf.RTL = pdffont.RTL(lang)
+	tf.applyRichTextStyle(rts)
 
 	if !font.SupportedFont(name) {
 		return errors.Errorf("pdfcpu: font unavailable: %s", name)
@@ -0,0 +1,291 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+// Functions dealing with the public-key security handler (Adobe.PubSec), see 7.6.5
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// The enveloped data of each recipient consists of a 20 byte seed followed by 4 bytes of permissions.
+const pubSecSeedLength = 20
+
+const pubSecCryptFilter = "DefaultCryptFilter"
+
+// pkcs7 configures the content encryption algorithm via a package variable.
+var pkcs7Mu sync.Mutex
+
+func newPubSecEncryptDict(pdf20 bool, keyLength int, recipients types.Array, embeddedFilesOnly bool) types.Dict {
+	d := types.NewDict()
+
+	d.Insert("Filter", types.Name("Adobe.PubSec"))
+	d.Insert("SubFilter", types.Name("adbe.pkcs7.s5"))
+
+	v, cfm := 4, "AESV2"
+	if keyLength == 256 {
+		v, cfm = 5, "AESV3"
+	}
+	d.Insert("V", types.Integer(v))
+	d.Insert("Length", types.Integer(keyLength))
+
+	d1 := types.NewDict()
+	authEvent := "DocOpen"
+	if embeddedFilesOnly {
+		authEvent = "EFOpen"
+	}
+	d1.Insert("AuthEvent", types.Name(authEvent))
+	d1.Insert("CFM", types.Name(cfm))
+	kl := keyLength
+	if pdf20 {
+		kl /= 8
+	}
+	d1.Insert("Length", types.Integer(kl))
+	d1.Insert("Recipients", recipients)
+
+	d2 := types.NewDict()
+	d2.Insert(pubSecCryptFilter, d1)
+	d.Insert("CF", d2)
+	if embeddedFilesOnly {
+		d.Insert("StmF", types.Name("Identity"))
+		d.Insert("StrF", types.Name("Identity"))
+		d.Insert("EFF", types.Name(pubSecCryptFilter))
+	} else {
+		d.Insert("StmF", types.Name(pubSecCryptFilter))
+		d.Insert("StrF", types.Name(pubSecCryptFilter))
+	}
+
+	return d
+}
+
+func supportedPubSecEncryption(ctx *model.Context, d types.Dict, subFilter string, l, v int) (*model.Enc, error) {
+	if subFilter == "" {
+		return nil, errors.New("pdfcpu: unsupported encryption: required entry \"SubFilter\" missing")
+	}
+
+	if subFilter == "adbe.pkcs7.s3" || subFilter == "adbe.pkcs7.s4" {
+		obj, ok := d.Find("Recipients")
+		if !ok {
+			return nil, errors.New("pdfcpu: unsupported encryption: required entry \"Recipients\" missing")
+		}
+		arr, err := ctx.DereferenceArray(obj)
+		if err != nil {
+			return nil, err
+		}
+		if len(arr) == 0 {
+			return nil, errors.New("pdfcpu: unsupported encryption: required entry \"Recipients\" empty")
+		}
+	}
+
+	// There is no R for the public-key security handler.
+	// We use the revision of the corresponding standard security handler.
+	r := v + 1
+	if v >= 4 {
+		r = v
+	}
+
+	encMeta := true
+	if emd := d.BooleanEntry("EncryptMetadata"); emd != nil {
+		encMeta = *emd
+	}
+
+	e := &model.Enc{L: l, R: r, V: v, Emd: encMeta, PubSec: true}
+
+	if v >= 4 {
+		e.StmF, e.StrF, e.EFF = cryptFilterNames(d)
+	}
+
+	return e, nil
+}
+
+func recipientBytes(o types.Object) ([]byte, error) {
+	switch o := o.(type) {
+	case types.StringLiteral:
+		return types.Unescape(o.Value())
+	case types.HexLiteral:
+		return o.Bytes()
+	}
+	return nil, errors.Errorf("pdfcpu: invalid recipient: %T", o)
+}
+
+// pubSecRecipients returns the PKCS#7 envelopes of all recipients.
+func pubSecRecipients(ctx *model.Context, d types.Dict) ([][]byte, error) {
+	o, found := d.Find("Recipients")
+	if !found {
+		if cfDict := d.DictEntry("CF"); cfDict != nil {
+			for _, k := range []string{"StmF", "EFF"} {
+				if n := d.NameEntry(k); n != nil && *n != "Identity" {
+					if d1 := cfDict.DictEntry(*n); d1 != nil {
+						if o, found = d1.Find("Recipients"); found {
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+	if !found {
+		return nil, errors.New("pdfcpu: encryption: missing \"Recipients\"")
+	}
+
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return nil, err
+	}
+
+	arr, ok := o.(types.Array)
+	if !ok {
+		arr = types.Array{o}
+	}
+
+	bbb := [][]byte{}
+	for _, o := range arr {
+		if o, err = ctx.Dereference(o); err != nil {
+			return nil, err
+		}
+		bb, err := recipientBytes(o)
+		if err != nil {
+			return nil, err
+		}
+		bbb = append(bbb, bb)
+	}
+
+	return bbb, nil
+}
+
+// pubSecKey computes the file encryption key (Algorithm 7.6.5.3).
+func pubSecKey(seed []byte, recipients [][]byte, e *model.Enc) []byte {
+	var bb []byte
+	bb = append(bb, seed...)
+	for _, r := range recipients {
+		bb = append(bb, r...)
+	}
+	if e.V >= 4 && !e.Emd {
+		bb = append(bb, 0xff, 0xff, 0xff, 0xff)
+	}
+
+	if e.V == 5 {
+		key := sha256.Sum256(bb)
+		return key[:]
+	}
+
+	key := sha1.Sum(bb)
+	return key[:e.L/8]
+}
+
+func setupPubSecEncryption(ctx *model.Context) error {
+	seed := make([]byte, pubSecSeedLength, pubSecSeedLength+4)
+	if _, err := rand.Read(seed); err != nil {
+		return err
+	}
+
+	p := int32(ctx.Permissions)
+	content := binary.BigEndian.AppendUint32(seed, uint32(p))
+
+	alg := pkcs7.EncryptionAlgorithmAES128CBC
+	if ctx.EncryptKeyLength == 256 {
+		alg = pkcs7.EncryptionAlgorithmAES256CBC
+	}
+
+	pkcs7Mu.Lock()
+	pkcs7.ContentEncryptionAlgorithm = alg
+	envelope, err := pkcs7.Encrypt(content, ctx.EncryptRecipients)
+	pkcs7Mu.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "pdfcpu: encrypt recipients")
+	}
+
+	d := newPubSecEncryptDict(ctx.PDF20(), ctx.EncryptKeyLength, types.Array{types.NewHexLiteral(envelope)}, ctx.EncryptEmbeddedFilesOnly)
+
+	if ctx.E, err = supportedEncryption(ctx, d); err != nil {
+		return err
+	}
+	ctx.E.P = int(p)
+
+	ctx.EncKey = pubSecKey(seed, [][]byte{envelope}, ctx.E)
+
+	xRefTableEntry := model.NewXRefTableEntryGen0(d)
+
+	// Reuse free objects (including recycled objects from this run).
+	objNumber, err := ctx.InsertAndUseRecycled(*xRefTableEntry)
+	if err != nil {
+		return err
+	}
+
+	ctx.Encrypt = types.NewIndirectRef(objNumber, 0)
+
+	return nil
+}
+
+func setupPubSecEncryptionKey(ctx *model.Context, d types.Dict) error {
+	if needsOwnerAndUserPassword(ctx.Cmd) {
+		return errors.New("pdfcpu: operation not supported for public-key encrypted files")
+	}
+
+	if ctx.DecryptCert == nil || ctx.DecryptKey == nil {
+		if lockEmbeddedFiles(ctx) {
+			return nil
+		}
+		return errors.New("pdfcpu: please provide certificate and private key for decryption")
+	}
+
+	recipients, err := pubSecRecipients(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	for _, bb := range recipients {
+		p7, err := pkcs7.Parse(bb)
+		if err != nil {
+			return errors.Wrap(err, "pdfcpu: invalid recipient")
+		}
+		if content, err = p7.Decrypt(ctx.DecryptCert, ctx.DecryptKey); err == nil {
+			break
+		}
+		if log.ReadEnabled() {
+			log.Read.Printf("setupPubSecEncryptionKey: %v\n", err)
+		}
+	}
+
+	if content == nil {
+		return errors.New("pdfcpu: certificate is not a recipient of this file")
+	}
+
+	if len(content) < pubSecSeedLength+4 {
+		return errors.New("pdfcpu: invalid recipient content")
+	}
+
+	ctx.E.P = int(int32(binary.BigEndian.Uint32(content[pubSecSeedLength:])))
+	ctx.EncKey = pubSecKey(content[:pubSecSeedLength], recipients, ctx.E)
+
+	if !hasNeededPermissions(ctx.Cmd, ctx.E) {
+		return errors.New("pdfcpu: operation restricted via pdfcpu's permission bits setting")
+	}
+
+	return nil
+}
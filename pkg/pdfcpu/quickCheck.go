@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"context"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// QuickCheckResult summarizes the file structure inspected by QuickCheck.
+type QuickCheckResult struct {
+	Version     string // effective PDF version
+	Encrypted   bool
+	Linearized  bool
+	XRefStreams bool // cross reference streams in use
+	PageCount   int  // page count as claimed by the root of the page tree, 0 if unknown
+	Updates     int  // number of incremental updates
+}
+
+// errEncryptedObjectStream means an object stream cannot be read without decryption.
+var errEncryptedObjectStream = errors.New("pdfcpu: encrypted object stream")
+
+// quickCheckXRefChain reads the chain of cross reference sections starting at offset
+// and returns the number of sections read.
+// Unlike buildXRefTableStartingAt this does not fall back to scanning the whole file.
+func quickCheckXRefChain(c context.Context, ctx *model.Context, offset *int64, offExtra int64) (int, error) {
+	rs := ctx.Read.RS
+	offs := map[int64]bool{}
+	xrefSectionCount := 0
+	incr := 0
+
+	for offset != nil {
+
+		incr++
+
+		if max := ctx.Limits.MaxXRefSections; max > 0 && incr > max {
+			return 0, &types.LimitError{Limit: types.LimitXRefSections, Max: int64(max)}
+		}
+
+		if offs[*offset] {
+			return 0, errors.Errorf("pdfcpu: circular xref chain at offset %d", *offset)
+		}
+		offs[*offset] = true
+
+		off, err := tryXRefSection(c, ctx, rs, offset, offExtra, &xrefSectionCount, incr)
+		if err != nil {
+			return 0, errors.Wrapf(err, "pdfcpu: corrupt xref section at offset %d", *offset)
+		}
+
+		if off == nil || *off != 0 {
+			offset = off
+			continue
+		}
+
+		ctx.Read.UsingXRefStreams = true
+		rd, err := newPositionedReader(rs, offset)
+		if err != nil {
+			return 0, err
+		}
+
+		off = offset
+		if offset, err = parseXRefStream(c, ctx, rd, offset, offExtra, incr); err != nil {
+			return 0, errors.Wrapf(err, "pdfcpu: corrupt xref stream at offset %d", *off)
+		}
+	}
+
+	return incr, nil
+}
+
+// firstObjectLinearized returns true if the object located closest to the header is a linearization parameter dict.
+func firstObjectLinearized(c context.Context, ctx *model.Context) bool {
+	objNr := -1
+	var offset int64
+	for i, entry := range ctx.Table {
+		if entry == nil || entry.Free || entry.Compressed || entry.Offset == nil || *entry.Offset <= 0 {
+			continue
+		}
+		if objNr < 0 || *entry.Offset < offset {
+			objNr, offset = i, *entry.Offset
+		}
+	}
+	if objNr < 0 {
+		return false
+	}
+
+	d, err := dereferencedDict(c, ctx, objNr)
+	return err == nil && d.IsLinearizationParmDict()
+}
+
+// quickCheckDict returns the dict objNr decoding its object stream if necessary.
+func quickCheckDict(c context.Context, ctx *model.Context, objNr int) (types.Dict, error) {
+	entry, found := ctx.Find(objNr)
+	if !found || entry.Free {
+		return nil, errors.Errorf("pdfcpu: unregistered object %d", objNr)
+	}
+
+	if entry.Compressed {
+		if ctx.Encrypt != nil {
+			return nil, errEncryptedObjectStream
+		}
+		osEntry, found := ctx.Find(*entry.ObjectStream)
+		if !found {
+			return nil, errors.Errorf("pdfcpu: unregistered object stream %d", *entry.ObjectStream)
+		}
+		if _, ok := osEntry.Object.(types.ObjectStreamDict); !ok {
+			if err := decodeObjectStream(c, ctx, *entry.ObjectStream); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return dereferencedDict(c, ctx, objNr)
+}
+
+// pageTreeCount returns the Count of the page tree root referenced by the catalog.
+func pageTreeCount(c context.Context, ctx *model.Context, rootDict types.Dict) (int, error) {
+	ir, ok := rootDict["Pages"].(types.IndirectRef)
+	if !ok {
+		return 0, errors.New("pdfcpu: catalog: missing page tree")
+	}
+
+	d, err := quickCheckDict(c, ctx, ir.ObjectNumber.Value())
+	if err == errEncryptedObjectStream {
+		return 0, err
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "pdfcpu: page tree unreachable")
+	}
+
+	switch o := d["Count"].(type) {
+	case types.Integer:
+		return o.Value(), nil
+	case types.IndirectRef:
+		i, err := dereferencedInteger(c, ctx, o.ObjectNumber.Value())
+		if err != nil {
+			return 0, err
+		}
+		return i.Value(), nil
+	}
+
+	return 0, errors.New("pdfcpu: page tree: missing Count")
+}
+
+// QuickCheck verifies the header, startxref, the cross reference sections, the trailer
+// and the reachability of the catalog and the page tree root of rs.
+// Only the objects involved are read, which makes QuickCheck suitable for the triage of large batches of files.
+// Passing QuickCheck does not imply a file is valid, use Validate for that.
+func QuickCheck(rs io.ReadSeeker, conf *model.Configuration) (*QuickCheckResult, error) {
+	c := context.Background()
+
+	ctx, err := model.NewContext(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Read.FileSize == 0 {
+		return nil, errors.New("pdfcpu: empty file")
+	}
+
+	c = model.WithMaxNestingDepth(c, ctx.Limits.MaxNestingDepth)
+
+	hv, eolCount, offExtra, err := headerVersion(rs)
+	if err != nil {
+		return nil, err
+	}
+	ctx.HeaderVersion = hv
+	ctx.Read.EolCount = eolCount
+
+	offset, err := offsetLastXRefSection(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	if *offset == 0 {
+		return nil, errors.New("pdfcpu: invalid startxref offset")
+	}
+	*offset += offExtra
+
+	sections, err := quickCheckXRefChain(c, ctx, offset, offExtra)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Root == nil {
+		return nil, errors.New("pdfcpu: trailer: missing Root")
+	}
+
+	res := &QuickCheckResult{
+		Encrypted:   ctx.Encrypt != nil,
+		XRefStreams: ctx.Read.UsingXRefStreams,
+		Updates:     sections - 1,
+	}
+
+	rootDict, err := quickCheckDict(c, ctx, ctx.Root.ObjectNumber.Value())
+	if err != nil && err != errEncryptedObjectStream {
+		return nil, errors.Wrap(err, "pdfcpu: catalog unreachable")
+	}
+
+	// PageCount and Version of encrypted files using object streams remain unknown.
+	if err == nil {
+		if t := rootDict.Type(); t != nil && *t != "Catalog" {
+			return nil, errors.Errorf("pdfcpu: catalog: invalid Type %s", *t)
+		}
+
+		if res.PageCount, err = pageTreeCount(c, ctx, rootDict); err != nil && err != errEncryptedObjectStream {
+			return nil, err
+		}
+
+		// Since PDF 1.4 the catalog may claim a later version than the header.
+		if s := rootDict.NameEntry("Version"); s != nil {
+			if v, err := model.PDFVersionRelaxed(*s); err == nil && v > *hv {
+				ctx.RootVersion = &v
+			}
+		}
+	}
+
+	res.Version = ctx.VersionString()
+	res.Linearized = firstObjectLinearized(c, ctx)
+
+	// The first page cross reference section of linearized files is not an update.
+	if res.Linearized && res.Updates > 0 {
+		res.Updates--
+	}
+
+	return res, nil
+}
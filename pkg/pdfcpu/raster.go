@@ -0,0 +1,775 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// The raster backend renders a grayscale approximation of a page
+// good enough for visual comparison:
+//
+// - paths are filled and stroked, clipping is ignored.
+// - text is drawn using a fixed bitmap font scaled into each glyph box.
+// - images are sampled if decodable, otherwise painted gray.
+//
+// Optionally the tints of all colorants are tracked in an ink layer, see InkCoverage.
+
+const (
+	rasterMaxFormDepth = 16
+	rasterBezierSteps  = 12
+	rasterMaxPixels    = 50000000
+)
+
+type rasterGState struct {
+	ctm         matrix.Matrix
+	fill        float64 // gray level 0..1
+	stroke      float64
+	fillInk     []float64 // colorant tints indexed by ink layer channel
+	strokeInk   []float64
+	fillCS      *inkColorSpace
+	strokeCS    *inkColorSpace
+	fillAlpha   float64
+	strokeAlpha float64
+	lineWidth   float64
+
+	// Text state
+	font       *svgFont
+	fontSize   float64
+	charSpace  float64
+	wordSpace  float64
+	hScale     float64
+	leading    float64
+	rise       float64
+	renderMode int
+}
+
+type rasterEdge struct {
+	x0, y0, x1, y1 float64
+	dir            int
+}
+
+type rasterizer struct {
+	ctx      *model.Context
+	img      *image.Gray
+	base     matrix.Matrix // default user space to device space
+	gs       rasterGState
+	stack    []rasterGState
+	subpaths [][]types.Point // device space
+	cur      types.Point     // user space
+	tm, tlm  matrix.Matrix
+	fonts    *svgRenderer // font loading is shared with the SVG backend
+	depth    int
+	inks     *inkLayer // optional
+}
+
+func rasterGray(ff []float64) (float64, bool) {
+	switch len(ff) {
+	case 1:
+		return svgClamp(ff[0]), true
+	case 3:
+		return svgClamp(0.299*ff[0] + 0.587*ff[1] + 0.114*ff[2]), true
+	case 4:
+		k := svgClamp(ff[3])
+		r := (1 - svgClamp(ff[0])) * (1 - k)
+		g := (1 - svgClamp(ff[1])) * (1 - k)
+		b := (1 - svgClamp(ff[2])) * (1 - k)
+		return svgClamp(0.299*r + 0.587*g + 0.114*b), true
+	}
+	return 0, false
+}
+
+func invertMatrix(m matrix.Matrix) (matrix.Matrix, bool) {
+	det := m[0][0]*m[1][1] - m[0][1]*m[1][0]
+	if math.Abs(det) < 1e-12 {
+		return matrix.Matrix{}, false
+	}
+	a, b := m[1][1]/det, -m[0][1]/det
+	c, d := -m[1][0]/det, m[0][0]/det
+	e := -(m[2][0]*a + m[2][1]*c)
+	f := -(m[2][0]*b + m[2][1]*d)
+	return matrix.Matrix{{a, b, 0}, {c, d, 0}, {e, f, 1}}, true
+}
+
+func (r *rasterizer) device() matrix.Matrix {
+	return r.gs.ctm.Multiply(r.base)
+}
+
+func (r *rasterizer) blend(x, y int, gray float64, ink []float64, alpha float64) {
+	i := r.img.PixOffset(x, y)
+	v := float64(r.img.Pix[i])/255*(1-alpha) + gray*alpha
+	r.img.Pix[i] = uint8(svgClamp(v)*255 + 0.5)
+	if r.inks != nil {
+		r.inks.blend(i, ink, alpha)
+	}
+}
+
+// fillPolygons scan converts pp using the nonzero or even-odd winding rule.
+func (r *rasterizer) fillPolygons(pp [][]types.Point, evenOdd bool, gray float64, ink []float64, alpha float64) {
+	var ee []rasterEdge
+	minY, maxY := math.MaxFloat64, -math.MaxFloat64
+	for _, p := range pp {
+		for i := range p {
+			p0, p1 := p[i], p[(i+1)%len(p)]
+			if p0.Y == p1.Y {
+				continue
+			}
+			dir := 1
+			if p0.Y > p1.Y {
+				p0, p1, dir = p1, p0, -1
+			}
+			ee = append(ee, rasterEdge{p0.X, p0.Y, p1.X, p1.Y, dir})
+			minY, maxY = math.Min(minY, p0.Y), math.Max(maxY, p1.Y)
+		}
+	}
+	if len(ee) == 0 {
+		return
+	}
+
+	b := r.img.Bounds()
+	y0 := int(math.Max(math.Floor(minY), float64(b.Min.Y)))
+	y1 := int(math.Min(math.Ceil(maxY), float64(b.Max.Y)))
+
+	type crossing struct {
+		x   float64
+		dir int
+	}
+	cc := []crossing{}
+
+	for y := y0; y < y1; y++ {
+		yc := float64(y) + .5
+		cc = cc[:0]
+		for _, e := range ee {
+			if yc < e.y0 || yc >= e.y1 {
+				continue
+			}
+			x := e.x0 + (yc-e.y0)*(e.x1-e.x0)/(e.y1-e.y0)
+			cc = append(cc, crossing{x, e.dir})
+		}
+		sort.Slice(cc, func(i, j int) bool { return cc[i].x < cc[j].x })
+		w := 0
+		for i := 0; i+1 < len(cc); i++ {
+			if evenOdd {
+				w ^= 1
+			} else {
+				w += cc[i].dir
+			}
+			if w == 0 {
+				continue
+			}
+			xs := int(math.Max(math.Ceil(cc[i].x-.5), float64(b.Min.X)))
+			xe := int(math.Min(math.Ceil(cc[i+1].x-.5), float64(b.Max.X)))
+			for x := xs; x < xe; x++ {
+				r.blend(x, y, gray, ink, alpha)
+			}
+		}
+	}
+}
+
+func (r *rasterizer) strokePath() {
+	m := r.device()
+	scale := math.Sqrt(math.Abs(m[0][0]*m[1][1] - m[0][1]*m[1][0]))
+	hw := math.Max(r.gs.lineWidth*scale, 1) / 2
+	var pp [][]types.Point
+	for _, sp := range r.subpaths {
+		for i := 0; i+1 < len(sp); i++ {
+			p0, p1 := sp[i], sp[i+1]
+			dx, dy := p1.X-p0.X, p1.Y-p0.Y
+			l := math.Hypot(dx, dy)
+			if l == 0 {
+				continue
+			}
+			nx, ny := -dy/l*hw, dx/l*hw
+			pp = append(pp, []types.Point{
+				{X: p0.X + nx, Y: p0.Y + ny},
+				{X: p1.X + nx, Y: p1.Y + ny},
+				{X: p1.X - nx, Y: p1.Y - ny},
+				{X: p0.X - nx, Y: p0.Y - ny},
+			})
+		}
+	}
+	r.fillPolygons(pp, false, r.gs.stroke, r.gs.strokeInk, r.gs.strokeAlpha)
+}
+
+func (r *rasterizer) paint(fill, evenOdd, stroke, close bool) {
+	if close {
+		r.closePath()
+	}
+	if fill {
+		r.fillPolygons(r.subpaths, evenOdd, r.gs.fill, r.gs.fillInk, r.gs.fillAlpha)
+	}
+	if stroke {
+		r.strokePath()
+	}
+	r.subpaths = nil
+}
+
+func (r *rasterizer) moveTo(p types.Point) {
+	r.cur = p
+	r.subpaths = append(r.subpaths, []types.Point{r.device().Transform(p)})
+}
+
+func (r *rasterizer) lineTo(p types.Point) {
+	if len(r.subpaths) == 0 {
+		r.moveTo(p)
+		return
+	}
+	r.cur = p
+	i := len(r.subpaths) - 1
+	r.subpaths[i] = append(r.subpaths[i], r.device().Transform(p))
+}
+
+func (r *rasterizer) curveTo(p1, p2, p3 types.Point) {
+	p0 := r.cur
+	for i := 1; i <= rasterBezierSteps; i++ {
+		t := float64(i) / rasterBezierSteps
+		u := 1 - t
+		a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		r.lineTo(types.Point{
+			X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+			Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+		})
+	}
+}
+
+func (r *rasterizer) closePath() {
+	if i := len(r.subpaths) - 1; i >= 0 && len(r.subpaths[i]) > 1 {
+		r.subpaths[i] = append(r.subpaths[i], r.subpaths[i][0])
+	}
+}
+
+func (r *rasterizer) pathOp(op model.ContentOp) bool {
+	ff := op.Numbers()
+	pt := func(i int) types.Point { return types.Point{X: ff[i], Y: ff[i+1]} }
+	switch op.Operator {
+	case "m":
+		if len(ff) >= 2 {
+			r.moveTo(pt(0))
+		}
+	case "l":
+		if len(ff) >= 2 {
+			r.lineTo(pt(0))
+		}
+	case "c":
+		if len(ff) >= 6 {
+			r.curveTo(pt(0), pt(2), pt(4))
+		}
+	case "v":
+		if len(ff) >= 4 {
+			r.curveTo(r.cur, pt(0), pt(2))
+		}
+	case "y":
+		if len(ff) >= 4 {
+			r.curveTo(pt(0), pt(2), pt(2))
+		}
+	case "h":
+		r.closePath()
+	case "re":
+		if len(ff) >= 4 {
+			x, y, w, h := ff[0], ff[1], ff[2], ff[3]
+			r.moveTo(types.Point{X: x, Y: y})
+			r.lineTo(types.Point{X: x + w, Y: y})
+			r.lineTo(types.Point{X: x + w, Y: y + h})
+			r.lineTo(types.Point{X: x, Y: y + h})
+			r.closePath()
+			r.cur = types.Point{X: x, Y: y}
+		}
+	case "S":
+		r.paint(false, false, true, false)
+	case "s":
+		r.paint(false, false, true, true)
+	case "f", "F":
+		r.paint(true, false, false, false)
+	case "f*":
+		r.paint(true, true, false, false)
+	case "B":
+		r.paint(true, false, true, false)
+	case "B*":
+		r.paint(true, true, true, false)
+	case "b":
+		r.paint(true, false, true, true)
+	case "b*":
+		r.paint(true, true, true, true)
+	case "n":
+		r.subpaths = nil
+	case "W", "W*":
+		// Clipping is not supported.
+	default:
+		return false
+	}
+	return true
+}
+
+func (r *rasterizer) colorOp(op model.ContentOp, resDict types.Dict) bool {
+	switch op.Operator {
+	case "g", "rg", "k", "sc", "scn":
+		if g, ok := rasterGray(op.Numbers()); ok {
+			r.gs.fill = g
+		}
+		if r.inks != nil {
+			cs := r.gs.fillCS
+			if len(op.Operator) < 3 && op.Operator[0] != 's' {
+				cs, r.gs.fillCS = nil, nil
+			}
+			r.gs.fillInk = r.inks.tints(cs, op.Numbers())
+		}
+	case "G", "RG", "K", "SC", "SCN":
+		if g, ok := rasterGray(op.Numbers()); ok {
+			r.gs.stroke = g
+		}
+		if r.inks != nil {
+			cs := r.gs.strokeCS
+			if len(op.Operator) < 3 && op.Operator[0] != 'S' {
+				cs, r.gs.strokeCS = nil, nil
+			}
+			r.gs.strokeInk = r.inks.tints(cs, op.Numbers())
+		}
+	case "cs":
+		r.gs.fill = 0
+		if r.inks != nil {
+			r.gs.fillCS = r.inks.colorSpace(r.ctx, r.fonts, resDict, op.Name(0))
+			r.gs.fillInk = r.inks.initialTints(r.gs.fillCS)
+		}
+	case "CS":
+		r.gs.stroke = 0
+		if r.inks != nil {
+			r.gs.strokeCS = r.inks.colorSpace(r.ctx, r.fonts, resDict, op.Name(0))
+			r.gs.strokeInk = r.inks.initialTints(r.gs.strokeCS)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (r *rasterizer) extGState(resDict types.Dict, name string) {
+	o, found := r.fonts.resource(resDict, "ExtGState", name)
+	if !found {
+		return
+	}
+	d, err := r.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return
+	}
+	if f, err := r.ctx.DereferenceNumber(d["CA"]); err == nil && d["CA"] != nil {
+		r.gs.strokeAlpha = svgClamp(f)
+	}
+	if f, err := r.ctx.DereferenceNumber(d["ca"]); err == nil && d["ca"] != nil {
+		r.gs.fillAlpha = svgClamp(f)
+	}
+	if f, err := r.ctx.DereferenceNumber(d["LW"]); err == nil && d["LW"] != nil {
+		r.gs.lineWidth = f
+	}
+}
+
+func (r *rasterizer) stateOp(op model.ContentOp, resDict types.Dict) bool {
+	switch op.Operator {
+	case "q":
+		r.stack = append(r.stack, r.gs)
+	case "Q":
+		if len(r.stack) > 0 {
+			r.gs = r.stack[len(r.stack)-1]
+			r.stack = r.stack[:len(r.stack)-1]
+		}
+	case "cm":
+		r.gs.ctm = matrixForOperands(op.Numbers()).Multiply(r.gs.ctm)
+	case "w":
+		r.gs.lineWidth = op.Number(0)
+	case "gs":
+		r.extGState(resDict, op.Name(0))
+	case "J", "j", "M", "d", "ri", "i":
+	default:
+		return false
+	}
+	return true
+}
+
+// drawMapped paints all pixels covered by the unit square transformed by m
+// using sample, which maps unit square coordinates to a gray level and coverage.
+func (r *rasterizer) drawMapped(m matrix.Matrix, sample func(u, v float64) (float64, []float64, bool), alpha float64) {
+	inv, ok := invertMatrix(m)
+	if !ok {
+		return
+	}
+	minX, minY := math.MaxFloat64, math.MaxFloat64
+	maxX, maxY := -math.MaxFloat64, -math.MaxFloat64
+	for _, p := range []types.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}} {
+		q := m.Transform(p)
+		minX, minY = math.Min(minX, q.X), math.Min(minY, q.Y)
+		maxX, maxY = math.Max(maxX, q.X), math.Max(maxY, q.Y)
+	}
+	b := r.img.Bounds()
+	x0, x1 := int(math.Max(math.Floor(minX), float64(b.Min.X))), int(math.Min(math.Ceil(maxX), float64(b.Max.X)))
+	y0, y1 := int(math.Max(math.Floor(minY), float64(b.Min.Y))), int(math.Min(math.Ceil(maxY), float64(b.Max.Y)))
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			p := inv.Transform(types.Point{X: float64(x) + .5, Y: float64(y) + .5})
+			if p.X < 0 || p.X >= 1 || p.Y < 0 || p.Y >= 1 {
+				continue
+			}
+			if g, ink, ok := sample(p.X, p.Y); ok {
+				r.blend(x, y, g, ink, alpha)
+			}
+		}
+	}
+}
+
+func (r *rasterizer) glyph(trm matrix.Matrix, w float64, s string) {
+	if w <= 0 {
+		return
+	}
+	face := basicfont.Face7x13
+	var mask image.Image
+	var mr image.Rectangle
+	for _, ch := range s {
+		dr, m, mp, _, ok := face.Glyph(fixed.Point26_6{}, ch)
+		if !ok {
+			dr, m, mp, _, _ = face.Glyph(fixed.Point26_6{}, '?')
+		}
+		mask, mr = m, dr.Sub(dr.Min).Add(mp)
+		break
+	}
+	if mask == nil {
+		return
+	}
+
+	// The glyph box spans the advance width and the bitmap font's ascent and descent.
+	asc := float64(face.Ascent) / float64(face.Height)
+	desc := float64(face.Descent) / float64(face.Height)
+	box := matrix.Matrix{{w, 0, 0}, {0, asc + desc, 0}, {0, -desc, 1}}
+
+	fill, ink := r.gs.fill, r.gs.fillInk
+	r.drawMapped(box.Multiply(trm), func(u, v float64) (float64, []float64, bool) {
+		x := mr.Min.X + int(u*float64(mr.Dx()))
+		y := mr.Min.Y + int((1-v)*float64(mr.Dy()))
+		_, _, _, a := mask.At(x, y).RGBA()
+		return fill, ink, a >= 0x8000
+	}, r.gs.fillAlpha)
+}
+
+func (r *rasterizer) showText(bb []byte) {
+	f := r.gs.font
+	if f == nil {
+		return
+	}
+	fs, th := r.gs.fontSize, r.gs.hScale
+	for _, c := range f.codes(bb) {
+		w := f.width(c) / 1000
+		var w1y, vx, vy float64
+		if f.vertical {
+			w1y, vx, vy = f.verticalMetrics(c)
+		}
+		if r.gs.renderMode != 3 && r.gs.renderMode != 7 {
+			m := matrix.Matrix{{fs * th, 0, 0}, {0, fs, 0}, {-vx / 1000 * fs * th, -vy/1000*fs + r.gs.rise, 1}}
+			trm := m.Multiply(r.tm).Multiply(r.device())
+			if s := f.text(c); s != "" && s != " " {
+				r.glyph(trm, w, s)
+			}
+		}
+		d := r.gs.charSpace
+		if f.space(c) {
+			d += r.gs.wordSpace
+		}
+		if f.vertical {
+			r.advance(0, w1y/1000*fs+d)
+			continue
+		}
+		r.advance((w*fs+d)*th, 0)
+	}
+}
+
+func (r *rasterizer) advance(tx, ty float64) {
+	m := matrix.IdentMatrix
+	m[2][0], m[2][1] = tx, ty
+	r.tm = m.Multiply(r.tm)
+}
+
+func (r *rasterizer) nextLine(tx, ty float64) {
+	m := matrix.IdentMatrix
+	m[2][0], m[2][1] = tx, ty
+	r.tlm = m.Multiply(r.tlm)
+	r.tm = r.tlm
+}
+
+func (r *rasterizer) textOp(op model.ContentOp, resDict types.Dict) bool {
+	ff := op.Numbers()
+	switch op.Operator {
+	case "BT":
+		r.tm, r.tlm = matrix.IdentMatrix, matrix.IdentMatrix
+	case "ET":
+	case "Tf":
+		r.gs.font = r.fonts.loadFont(resDict, op.Name(0))
+		r.gs.fontSize = op.Number(1)
+	case "Tc":
+		r.gs.charSpace = op.Number(0)
+	case "Tw":
+		r.gs.wordSpace = op.Number(0)
+	case "Tz":
+		r.gs.hScale = op.Number(0) / 100
+	case "TL":
+		r.gs.leading = op.Number(0)
+	case "Ts":
+		r.gs.rise = op.Number(0)
+	case "Tr":
+		r.gs.renderMode = int(op.Number(0))
+	case "Td":
+		if len(ff) >= 2 {
+			r.nextLine(ff[0], ff[1])
+		}
+	case "TD":
+		if len(ff) >= 2 {
+			r.gs.leading = -ff[1]
+			r.nextLine(ff[0], ff[1])
+		}
+	case "Tm":
+		r.tlm = matrixForOperands(ff)
+		r.tm = r.tlm
+	case "T*":
+		r.nextLine(0, -r.gs.leading)
+	case "Tj":
+		if len(op.Operands) > 0 {
+			if bb, ok := stringBytes(op.Operands[0]); ok {
+				r.showText(bb)
+			}
+		}
+	case "'", "\"":
+		if op.Operator == "\"" && len(ff) >= 2 {
+			r.gs.wordSpace, r.gs.charSpace = ff[0], ff[1]
+		}
+		r.nextLine(0, -r.gs.leading)
+		if len(op.Operands) > 0 {
+			if bb, ok := stringBytes(op.Operands[len(op.Operands)-1]); ok {
+				r.showText(bb)
+			}
+		}
+	case "TJ":
+		if len(op.Operands) == 0 {
+			break
+		}
+		a, _ := op.Operands[0].(types.Array)
+		for _, o := range a {
+			if bb, ok := stringBytes(o); ok {
+				r.showText(bb)
+				continue
+			}
+			n := arrayNumbers(types.Array{o})
+			if len(n) != 1 {
+				continue
+			}
+			if r.gs.font != nil && r.gs.font.vertical {
+				r.advance(0, -n[0]/1000*r.gs.fontSize)
+				continue
+			}
+			r.advance(-n[0]/1000*r.gs.fontSize*r.gs.hScale, 0)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (r *rasterizer) image(sd *types.StreamDict, name string, objNr int) {
+	var ink []float64
+	if r.inks != nil {
+		ink = r.inks.tints(nil, []float64{.5})
+	}
+	sample := func(u, v float64) (float64, []float64, bool) { return .5, ink, true }
+
+	if img, err := ExtractImage(r.ctx, sd, false, name, objNr, false); err == nil && img != nil {
+		if im, _, err := image.Decode(img); err == nil {
+			b := im.Bounds()
+			rgb := make([]float64, 3)
+			var buf []float64
+			sample = func(u, v float64) (float64, []float64, bool) {
+				x := b.Min.X + int(u*float64(b.Dx()))
+				y := b.Min.Y + int((1-v)*float64(b.Dy()))
+				cr, cg, cb, _ := im.At(x, y).RGBA()
+				rgb[0], rgb[1], rgb[2] = float64(cr)/0xffff, float64(cg)/0xffff, float64(cb)/0xffff
+				if r.inks != nil {
+					buf = r.inks.appendTints(buf[:0], nil, rgb)
+				}
+				return 0.299*rgb[0] + 0.587*rgb[1] + 0.114*rgb[2], buf, true
+			}
+		}
+	}
+
+	r.drawMapped(r.device(), sample, r.gs.fillAlpha)
+}
+
+func (r *rasterizer) xObject(resDict types.Dict, name string) error {
+	o, found := r.fonts.resource(resDict, "XObject", name)
+	if !found {
+		return nil
+	}
+	var objNr int
+	if ir, ok := o.(types.IndirectRef); ok {
+		objNr = ir.ObjectNumber.Value()
+	}
+	sd, _, err := r.ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return err
+	}
+	st := sd.Subtype()
+	if st == nil {
+		return nil
+	}
+	switch *st {
+	case "Image":
+		r.image(sd, name, objNr)
+	case "Form":
+		if r.depth >= rasterMaxFormDepth {
+			return nil
+		}
+		if err := sd.Decode(); err != nil {
+			return err
+		}
+		res := resDict
+		if d, err := r.ctx.DereferenceDict(sd.Dict["Resources"]); err == nil && d != nil {
+			res = d
+		}
+		r.stack = append(r.stack, r.gs)
+		if o, found := sd.Find("Matrix"); found {
+			r.gs.ctm = matrixForOperands(r.fonts.numberArray(o)).Multiply(r.gs.ctm)
+		}
+		r.depth++
+		err := r.render(sd.Content, res)
+		r.depth--
+		r.gs = r.stack[len(r.stack)-1]
+		r.stack = r.stack[:len(r.stack)-1]
+		return err
+	}
+	return nil
+}
+
+func (r *rasterizer) render(bb []byte, resDict types.Dict) error {
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return err
+	}
+	stackSize := len(r.stack)
+	for _, op := range ops {
+		if r.pathOp(op) || r.colorOp(op, resDict) || r.stateOp(op, resDict) || r.textOp(op, resDict) {
+			continue
+		}
+		if op.Operator == "Do" {
+			if err := r.xObject(resDict, op.Name(0)); err != nil {
+				return err
+			}
+			continue
+		}
+		if log.DebugEnabled() {
+			log.Debug.Printf("raster: skipping operator %s\n", op.Operator)
+		}
+	}
+	// Balance any unmatched q operators.
+	if len(r.stack) > stackSize {
+		r.gs = r.stack[stackSize]
+		r.stack = r.stack[:stackSize]
+	}
+	return nil
+}
+
+// newPageRasterizer returns a rasterizer for page pageNr at the given resolution together with the page content and resources.
+func newPageRasterizer(ctx *model.Context, pageNr int, dpi float64) (*rasterizer, []byte, types.Dict, error) {
+	if dpi <= 0 {
+		return nil, nil, nil, errors.Errorf("pdfcpu: invalid resolution: %.2f", dpi)
+	}
+
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if d == nil {
+		return nil, nil, nil, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	cb := inhPAttrs.CropBox
+	if cb == nil {
+		cb = inhPAttrs.MediaBox
+	}
+	if cb == nil {
+		return nil, nil, nil, errors.Errorf("pdfcpu: page %d: missing mediaBox", pageNr)
+	}
+
+	rot := inhPAttrs.Rotate % 360
+	if rot < 0 {
+		rot += 360
+	}
+	m, w, h := svgPageTransform(cb, rot)
+
+	s := dpi / 72
+	m = m.Multiply(matrix.Matrix{{s, 0, 0}, {0, s, 0}, {0, 0, 1}})
+	iw, ih := int(math.Ceil(w*s)), int(math.Ceil(h*s))
+	if iw <= 0 || ih <= 0 || iw*ih > rasterMaxPixels {
+		return nil, nil, nil, errors.Errorf("pdfcpu: page %d: invalid raster size %d x %d", pageNr, iw, ih)
+	}
+
+	img := image.NewGray(image.Rect(0, 0, iw, ih))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err != nil && err != model.ErrNoContent {
+		return nil, nil, nil, err
+	}
+
+	r := &rasterizer{
+		ctx:  ctx,
+		img:  img,
+		base: m,
+		gs: rasterGState{
+			ctm:         matrix.IdentMatrix,
+			fillAlpha:   1,
+			strokeAlpha: 1,
+			lineWidth:   1,
+			hScale:      1,
+		},
+		tm:    matrix.IdentMatrix,
+		tlm:   matrix.IdentMatrix,
+		fonts: &svgRenderer{ctx: ctx, fonts: map[int]*svgFont{}},
+	}
+
+	return r, bb, inhPAttrs.Resources, nil
+}
+
+// RenderPage renders page pageNr at the given resolution into a grayscale image.
+// The result is an approximation intended for visual comparison, see VisualDiff.
+func RenderPage(ctx *model.Context, pageNr int, dpi float64) (*image.Gray, error) {
+	r, bb, resDict, err := newPageRasterizer(ctx, pageNr, dpi)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bb) > 0 {
+		if err := r.render(bb, resDict); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.img, nil
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"sort"
 	"strconv"
@@ -74,6 +75,44 @@ func ReadFileWithContext(c context.Context, inFile string, conf *model.Configura
 	return ReadWithContext(c, f, conf)
 }
 
+// limitExceeded returns true if err has been caused by exceeding a resource limit, see model.Limits.
+func limitExceeded(err error) bool {
+	var e *types.LimitError
+	return errors.As(err, &e)
+}
+
+func checkObjectLimit(ctx *model.Context) error {
+	if max := ctx.Limits.MaxObjects; max > 0 && len(ctx.Table) > max {
+		return &types.LimitError{Limit: types.LimitObjects, Max: int64(max)}
+	}
+	return nil
+}
+
+// checkXRefStreamSize fails for xref streams declaring more objects than allowed.
+func checkXRefStreamSize(ctx *model.Context, sd *types.StreamDict) error {
+	max := ctx.Limits.MaxObjects
+	if max <= 0 {
+		return nil
+	}
+
+	n := 0
+	if a := sd.Index(); a != nil {
+		for i := 1; i < len(a) && n <= max; i += 2 {
+			if count, ok := a[i].(types.Integer); ok && count > 0 {
+				n += min(count.Value(), max+1)
+			}
+		}
+	} else if size := sd.Size(); size != nil {
+		n = *size
+	}
+
+	if n > max {
+		return &types.LimitError{Limit: types.LimitObjects, Max: int64(max)}
+	}
+
+	return nil
+}
+
 // Read takes a readSeeker and generates a PDF model context,
 // an in-memory representation containing a cross reference table.
 func Read(rs io.ReadSeeker, conf *model.Configuration) (*model.Context, error) {
@@ -83,12 +122,15 @@ func Read(rs io.ReadSeeker, conf *model.Configuration) (*model.Context, error) {
 // Read takes a readSeeker and generates a PDF model context,
 // an in-memory representation containing a cross reference table.
 // If the passed Go context is cancelled, reading will be interrupted.
-func ReadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configuration) (*model.Context, error) {
+func ReadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configuration) (ctx *model.Context, err error) {
 	if log.ReadEnabled() {
 		log.Read.Println("Read: begin")
 	}
 
-	ctx, err := model.NewContext(rs, conf)
+	end := conf.TraceOp("read")
+	defer func() { end(err) }()
+
+	ctx, err = model.NewContext(rs, conf)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +139,8 @@ func ReadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configurat
 		return nil, errors.New("The file could not be opened because it is empty.")
 	}
 
+	c = model.WithMaxNestingDepth(c, ctx.Limits.MaxNestingDepth)
+
 	if log.InfoEnabled() {
 		if ctx.Reader15 {
 			log.Info.Println("PDF Version 1.5 conforming reader")
@@ -110,6 +154,10 @@ func ReadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configurat
 		return nil, errors.Wrap(err, "Read: xRefTable failed")
 	}
 
+	if err = checkObjectLimit(ctx); err != nil {
+		return nil, err
+	}
+
 	// Make all objects explicitly available (load into memory) in corresponding xRefTable entries.
 	// Also decode any involved object streams.
 	if err = dereferenceXRefTable(c, ctx); err != nil {
@@ -121,6 +169,13 @@ func ReadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configurat
 		maxObjNr := ctx.MaxObjNr + 1
 		ctx.XRefTable.Size = &maxObjNr
 		model.ShowRepaired("trailer size")
+		ctx.Conf.LogEvent(slog.LevelWarn, "pdfcpu: repaired trailer size", "size", maxObjNr)
+	}
+
+	if ctx.Conf.LogEnabled(slog.LevelInfo) && ctx.HeaderVersion != nil {
+		ctx.Conf.LogEvent(slog.LevelInfo, "pdfcpu: read",
+			"version", ctx.VersionString(), "fileSize", ctx.Read.FileSize, "objects", len(ctx.Table),
+			"objectStreams", len(ctx.Read.ObjectStreams), "xrefStreams", len(ctx.Read.XRefStreams), "damagedStreams", len(ctx.Damage))
 	}
 
 	if log.ReadEnabled() {
@@ -453,6 +508,9 @@ func parseObjectStream(c context.Context, osd *types.ObjectStreamDict) error {
 			return err
 		}
 	}
+	if len(decodedContent) < osd.FirstObjOffset {
+		return errors.New("pdfcpu: parseObjectStream: corrupt object stream prolog")
+	}
 	prolog := decodedContent[:osd.FirstObjOffset]
 
 	// Remove inline comment.
@@ -475,6 +533,13 @@ func parseObjectStream(c context.Context, osd *types.ObjectStreamDict) error {
 
 	var offsetOld int
 
+	decode := compressedObject
+	if max := model.MaxNestingDepth(c); max > 0 {
+		decode = func(c context.Context, s string) (types.Object, error) {
+			return compressedObject(model.WithMaxNestingDepth(c, max), s)
+		}
+	}
+
 	for i := 0; i < len(objs); i += 2 {
 
 		if err := c.Err(); err != nil {
@@ -489,12 +554,12 @@ func parseObjectStream(c context.Context, osd *types.ObjectStreamDict) error {
 		offset += osd.FirstObjOffset
 
 		if i > 0 {
-			o := types.NewLazyObjectStreamObject(osd, offsetOld, offset, compressedObject)
+			o := types.NewLazyObjectStreamObject(osd, offsetOld, offset, decode)
 			objArray = append(objArray, o)
 		}
 
 		if i == len(objs)-2 {
-			o := types.NewLazyObjectStreamObject(osd, offset, -1, compressedObject)
+			o := types.NewLazyObjectStreamObject(osd, offset, -1, decode)
 			objArray = append(objArray, o)
 		}
 
@@ -686,6 +751,11 @@ func xRefStreamDict(c context.Context, ctx *model.Context, o types.Object, objNr
 	if err = saveDecodedStreamContent(nil, &sd, 0, 0, true); err != nil {
 		return nil, errors.Wrapf(err, "xRefStreamDict: cannot decode stream for obj#:%d\n", objNr)
 	}
+	ctx.Conf.Count(model.MetricStreamsDecoded, 1)
+
+	if err := checkXRefStreamSize(ctx, &sd); err != nil {
+		return nil, err
+	}
 
 	return model.ParseXRefStreamDict(&sd)
 }
@@ -1642,6 +1712,10 @@ func buildXRefTableStartingAt(c context.Context, ctx *model.Context, offset *int
 			return err
 		}
 
+		if max := ctx.Limits.MaxXRefSections; max > 0 && incr > max {
+			return &types.LimitError{Limit: types.LimitXRefSections, Max: int64(max)}
+		}
+
 		if offs[*offset] {
 			if offset, err = offsetLastXRefSection(ctx, ctx.Read.FileSize-*offset); err != nil {
 				return err
@@ -1658,6 +1732,10 @@ func buildXRefTableStartingAt(c context.Context, ctx *model.Context, offset *int
 			return err
 		}
 
+		if err := checkObjectLimit(ctx); err != nil {
+			return err
+		}
+
 		if off == nil || *off != 0 {
 			offset = off
 			continue
@@ -1673,10 +1751,17 @@ func buildXRefTableStartingAt(c context.Context, ctx *model.Context, offset *int
 		}
 
 		if offset, err = parseXRefStream(c, ctx, rd, offset, offExtra, incr); err != nil {
+			if limitExceeded(err) {
+				return err
+			}
 			// Try fix for corrupt single xref section.
 			return bypassXrefSection(c, ctx, offExtra, err, incr)
 		}
 
+		if err := checkObjectLimit(ctx); err != nil {
+			return err
+		}
+
 	}
 
 	postProcess(ctx, xrefSectionCount)
@@ -2065,7 +2150,7 @@ func streamDictForObject(c context.Context, ctx *model.Context, d types.Dict, ob
 }
 
 func dict(ctx *model.Context, d1 types.Dict, objNr, genNr, endInd, streamInd int) (d2 types.Dict, err error) {
-	if ctx.EncKey != nil {
+	if encryptStrings(ctx) {
 		if _, err := decryptDeepObject(d1, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return nil, err
 		}
@@ -2184,7 +2269,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return streamDictForObject(c, ctx, o, objNr, streamInd, streamOffset, offset)
 
 	case types.Array:
-		if ctx.EncKey != nil {
+		if encryptStrings(ctx) {
 			if _, err := decryptDeepObject(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 				return nil, err
 			}
@@ -2192,7 +2277,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return o, nil
 
 	case types.StringLiteral:
-		if ctx.EncKey != nil {
+		if encryptStrings(ctx) {
 			sl, err := decryptStringLiteral(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 			if err != nil {
 				return nil, err
@@ -2202,7 +2287,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return o, nil
 
 	case types.HexLiteral:
-		if ctx.EncKey != nil {
+		if encryptStrings(ctx) {
 			hl, err := decryptHexLiteral(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 			if err != nil {
 				return nil, err
@@ -2413,8 +2498,42 @@ func ensureStreamLength(sd *types.StreamDict, fixLength bool) {
 	}
 }
 
+// deferStreamContent leaves the encoded stream content of sd in the input for loading on demand, see Configuration.ZeroCopyStreams.
+func deferStreamContent(c context.Context, ctx *model.Context, sd *types.StreamDict) bool {
+	if !ctx.ZeroCopyStreams || ctx.Encrypt != nil || ctx.DecodeAllStreams || ctx.TolerateCorruptStreams || sd.Raw != nil {
+		return false
+	}
+
+	ra, ok := ctx.Read.RS.(io.ReaderAt)
+	if !ok {
+		return false
+	}
+
+	if sd.StreamLength == nil && sd.StreamLengthObjNr != nil {
+		sd.StreamLength, _ = int64Object(c, ctx, *sd.StreamLengthObjNr)
+	}
+	if sd.StreamLength == nil {
+		return false
+	}
+
+	// Streams with a corrupt length need to be read in order to fix it.
+	l := *sd.StreamLength
+	if l <= 0 || sd.StreamOffset+l > ctx.Read.FileSize {
+		return false
+	}
+
+	sd.MaxDecodedLen = ctx.Limits.MaxStreamSize
+	sd.Defer(ra, sd.StreamOffset, l)
+
+	return true
+}
+
 // loadEncodedStreamContent loads the encoded stream content into sd.
 func loadEncodedStreamContent(c context.Context, ctx *model.Context, sd *types.StreamDict, fixLength bool) error {
+	if ctx.Configuration != nil {
+		sd.MaxDecodedLen = ctx.Limits.MaxStreamSize
+	}
+
 	if sd.Raw != nil {
 		return nil
 	}
@@ -2489,11 +2608,13 @@ func saveDecodedStreamContent(ctx *model.Context, sd *types.StreamDict, objNr, g
 
 	// ctx gets created after XRefStream parsing.
 	// XRefStreams are not encrypted.
-	if ctx != nil && ctx.EncKey != nil {
-		if sd.Raw, err = decryptStream(sd.Raw, objNr, genNr, ctx.EncKey, ctx.AES4Streams, ctx.E.R); err != nil {
-			return err
+	if ctx != nil {
+		if ok, aes := streamCryptFilter(ctx, sd); ok {
+			if sd.Raw, err = decryptStream(sd.Raw, objNr, genNr, ctx.EncKey, aes, ctx.E.R); err != nil {
+				return err
+			}
+			ensureStreamLength(sd, true)
 		}
-		ensureStreamLength(sd, true)
 	}
 
 	if !decode {
@@ -2508,6 +2629,8 @@ func saveDecodedStreamContent(ctx *model.Context, sd *types.StreamDict, objNr, g
 	err = sd.Decode()
 	if err == filter.ErrUnsupportedFilter {
 		err = nil
+	} else if err == nil && ctx != nil {
+		ctx.Conf.Count(model.MetricStreamsDecoded, 1)
 	}
 	if err != nil {
 		return err
@@ -2549,6 +2672,7 @@ func decompressXRefTableEntry(xRefTable *model.XRefTable, objNr int, entry *mode
 	entry.Object = o
 	entry.Generation = &g
 	entry.Compressed = false
+	xRefTable.Conf.Count(model.MetricObjectsParsed, 1)
 
 	if log.ReadEnabled() {
 		log.Read.Printf("decompressXRefTableEntry: end, Obj %d[%d]:\n<%s>\n", *entry.ObjectStream, *entry.ObjectStreamInd, o)
@@ -2762,22 +2886,141 @@ func handleLinearizationParmDict(ctx *model.Context, obj types.Object, objNr int
 	return nil
 }
 
+// repairStreamLength rereads the stream data of sd up to "endstream" ignoring "Length".
+func repairStreamLength(c context.Context, ctx *model.Context, sd *types.StreamDict, objNr, genNr int) bool {
+	sd1 := *sd
+	sd1.Raw, sd1.Content = nil, nil
+
+	if err := loadEncodedStreamContent(c, ctx, &sd1, true); err != nil {
+		return false
+	}
+	if err := saveDecodedStreamContent(ctx, &sd1, objNr, genNr, false); err != nil {
+		return false
+	}
+	if err := sd1.Decode(); err != nil {
+		return false
+	}
+
+	*sd = sd1
+	return true
+}
+
+// repairableStream returns true if sd is encoded using general purpose filters only.
+func repairableStream(sd *types.StreamDict) bool {
+	if len(sd.FilterPipeline) == 0 {
+		return false
+	}
+	for _, f := range sd.FilterPipeline {
+		if !types.MemberOf(f.Name, filter.List()) {
+			return false
+		}
+	}
+	return true
+}
+
+// repairStream ensures sd decodes, see Configuration.TolerateCorruptStreams.
+// Streams failing to decode get reread ignoring their "Length"
+// and as a last resort truncated to the longest decodable prefix of their stream data.
+func repairStream(c context.Context, ctx *model.Context, sd *types.StreamDict, objNr, genNr int, fixLength bool) error {
+	if !repairableStream(sd) {
+		return nil
+	}
+
+	err := sd.Decode()
+	if err == nil {
+		ctx.Conf.Count(model.MetricStreamsDecoded, 1)
+		if !ctx.DecodeAllStreams {
+			sd.Content = nil
+		}
+		return nil
+	}
+
+	if limitExceeded(err) {
+		return err
+	}
+
+	dmg := model.StreamDamage{ObjNr: objNr, Reason: err.Error(), RawLength: len(sd.Raw)}
+
+	if !fixLength && repairStreamLength(c, ctx, sd, objNr, genNr) {
+		dmg.LengthFixed = true
+		dmg.RawLength, dmg.ValidLength, dmg.Decoded = len(sd.Raw), len(sd.Raw), len(sd.Content)
+		ctx.AddDamage(dmg)
+		return nil
+	}
+
+	dmg.ValidLength = sd.DecodeValidPrefix()
+	dmg.Decoded = len(sd.Content)
+
+	// Content has been decoded including any predictor, so reencode using plain Flate.
+	sd.Delete("DecodeParms")
+	sd.Update("Filter", types.Name(filter.Flate))
+	sd.FilterPipeline = []types.PDFFilter{{Name: filter.Flate}}
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ctx.AddDamage(dmg)
+
+	return nil
+}
+
 func loadStreamDict(c context.Context, ctx *model.Context, sd *types.StreamDict, objNr, genNr int, fixLength bool) error {
+	if !fixLength && deferStreamContent(c, ctx, sd) {
+		ctx.Read.BinaryTotalSize += *sd.StreamLength
+		return nil
+	}
+
 	// Load encoded stream content for stream dicts into xRefTable entry.
 	if err := loadEncodedStreamContent(c, ctx, sd, fixLength); err != nil {
 		return errors.Wrapf(err, "dereferenceObject: problem dereferencing stream %d", objNr)
 	}
 
 	// Decode stream content.
-	if err := saveDecodedStreamContent(ctx, sd, objNr, genNr, ctx.DecodeAllStreams); err != nil {
+	decode := ctx.DecodeAllStreams && !ctx.TolerateCorruptStreams
+	if err := saveDecodedStreamContent(ctx, sd, objNr, genNr, decode); err != nil {
 		return err
 	}
 
+	if ctx.TolerateCorruptStreams {
+		if err := repairStream(c, ctx, sd, objNr, genNr, fixLength); err != nil {
+			return err
+		}
+	}
+
 	ctx.Read.BinaryTotalSize += *sd.StreamLength
 
 	return nil
 }
 
+// spillStreamDict moves the stream data of sd to the spill file once Configuration.MaxMemory is exhausted.
+func spillStreamDict(ctx *model.Context, sd *types.StreamDict) error {
+	if ctx.Conf.MaxMemory <= 0 {
+		return nil
+	}
+
+	n := int64(len(sd.Raw) + len(sd.Content))
+	if ctx.Read.StreamMemory+n <= ctx.Conf.MaxMemory {
+		ctx.Read.StreamMemory += n
+		return nil
+	}
+
+	if ctx.SpillFile == nil {
+		sf, err := types.NewSpillFile("")
+		if err != nil {
+			return err
+		}
+		ctx.SpillFile = sf
+	}
+
+	if _, err := sd.Spill(ctx.SpillFile); err != nil {
+		return err
+	}
+
+	ctx.Conf.Count(model.MetricStreamsSpilled, 1)
+
+	return nil
+}
+
 func updateBinaryTotalSize(ctx *model.Context, o types.Object) {
 	switch o := o.(type) {
 	case types.StreamDict:
@@ -2804,6 +3047,9 @@ func dereferenceAndLoad(c context.Context, ctx *model.Context, objNr int, entry
 			o, err = ParseObjectWithContext(c, ctx, *entry.Offset+ctx.Read.RepairOffset, objNr, *entry.Generation)
 		}
 		if err != nil {
+			if limitExceeded(err) {
+				return err
+			}
 			model.ShowSkipped(fmt.Sprintf("missing obj #%d", objNr))
 		}
 		if err == model.ErrCorruptObjectOffset {
@@ -2815,6 +3061,7 @@ func dereferenceAndLoad(c context.Context, ctx *model.Context, objNr int, entry
 	}
 
 	entry.Object = o
+	ctx.Conf.Count(model.MetricObjectsParsed, 1)
 
 	// Linearization dicts are validated and recorded for stats only.
 	if err = handleLinearizationParmDict(ctx, o, objNr); err != nil {
@@ -2835,6 +3082,9 @@ func dereferenceAndLoad(c context.Context, ctx *model.Context, objNr int, entry
 		if err = loadStreamDict(c, ctx, &sd, objNr, *entry.Generation, false); err != nil {
 			return err
 		}
+		if err = spillStreamDict(ctx, &sd); err != nil {
+			return err
+		}
 		entry.Object = sd
 	}
 
@@ -2912,13 +3162,14 @@ func dereferenceObjectsSorted(c context.Context, ctx *model.Context) error {
 	}
 	sort.Ints(keys)
 
-	for _, objNr := range keys {
+	for i, objNr := range keys {
 		if err := c.Err(); err != nil {
 			return err
 		}
 		if err := dereferenceObject(c, ctx, objNr); err != nil {
 			return err
 		}
+		ctx.ReportProgress(model.StageRead, i+1, len(keys))
 	}
 
 	for _, objNr := range keys {
@@ -2937,6 +3188,7 @@ func dereferenceObjectsSorted(c context.Context, ctx *model.Context) error {
 
 func dereferenceObjectsRaw(c context.Context, ctx *model.Context) error {
 	xRefTable := ctx.XRefTable
+	i, total := 0, len(xRefTable.Table)
 	for objNr := range xRefTable.Table {
 		if err := c.Err(); err != nil {
 			return err
@@ -2944,6 +3196,8 @@ func dereferenceObjectsRaw(c context.Context, ctx *model.Context) error {
 		if err := dereferenceObject(c, ctx, objNr); err != nil {
 			return err
 		}
+		i++
+		ctx.ReportProgress(model.StageRead, i, total)
 	}
 
 	for objNr := range xRefTable.Table {
@@ -3079,7 +3333,7 @@ func handleUnencryptedFile(ctx *model.Context) error {
 
 	// Encrypt subcommand found.
 
-	if ctx.OwnerPW == "" {
+	if ctx.OwnerPW == "" && len(ctx.EncryptRecipients) == 0 {
 		return errors.New("pdfcpu: please provide owner password and optional user password")
 	}
 
@@ -3090,6 +3344,30 @@ func needsOwnerAndUserPassword(cmd model.CommandMode) bool {
 	return cmd == model.CHANGEOPW || cmd == model.CHANGEUPW || cmd == model.SETPERMISSIONS
 }
 
+// embeddedFilesOptional returns true for commands not depending on the content of embedded files.
+func embeddedFilesOptional(cmd model.CommandMode) bool {
+	switch cmd {
+	case model.VALIDATE, model.LISTINFO, model.LISTATTACHMENTS, model.EXTRACTATTACHMENTS, model.LISTPERMISSIONS,
+		model.LISTKEYWORDS, model.LISTPROPERTIES, model.LISTBOXES, model.LISTANNOTATIONS, model.LISTBOOKMARKS,
+		model.LISTIMAGES, model.LISTFORMFIELDS, model.LISTFONTS, model.LISTPAGELAYOUT, model.LISTPAGEMODE,
+		model.LISTVIEWERPREFERENCES, model.EXTRACTIMAGES, model.EXTRACTFONTS, model.EXTRACTCONTENT, model.EXTRACTMETADATA:
+		return true
+	}
+	return false
+}
+
+// lockEmbeddedFiles returns true if ctx may be processed without decryption key
+// because encryption is restricted to embedded files (AuthEvent EFOpen).
+// Embedded files remain encrypted and cannot be extracted.
+func lockEmbeddedFiles(ctx *model.Context) bool {
+	if !embeddedFilesOnly(ctx.E) || !embeddedFilesOptional(ctx.Cmd) {
+		return false
+	}
+	ctx.EncKey = nil
+	ctx.EmbeddedFilesLocked = true
+	return true
+}
+
 func handlePermissions(ctx *model.Context) error {
 	// AES256 Validate permissions
 	ok, err := validatePermissions(ctx)
@@ -3118,6 +3396,10 @@ func setupEncryptionKey(ctx *model.Context, d types.Dict) (err error) {
 		return err
 	}
 
+	if ctx.E.PubSec {
+		return setupPubSecEncryptionKey(ctx, d)
+	}
+
 	if ctx.E.ID, err = ctx.IDFirstElement(); err != nil {
 		return err
 	}
@@ -3127,7 +3409,7 @@ func setupEncryptionKey(ctx *model.Context, d types.Dict) (err error) {
 	//fmt.Printf("opw: <%s> upw: <%s> \n", ctx.OwnerPW, ctx.UserPW)
 
 	// Validate the owner password aka. permissions/master password.
-	if ok, err = validateOwnerPassword(ctx); err != nil {
+	if ok, err = validatePassword(ctx, true); err != nil {
 		return err
 	}
 
@@ -3151,10 +3433,18 @@ func setupEncryptionKey(ctx *model.Context, d types.Dict) (err error) {
 	}
 
 	// Validate the user password aka. document open password.
-	if ok, err = validateUserPassword(ctx); err != nil {
+	if ok, err = validatePassword(ctx, false); err != nil {
 		return err
 	}
 	if !ok {
+		if lockEmbeddedFiles(ctx) {
+			return nil
+		}
+		if ctx.PasswordRejected != nil {
+			if err := ctx.PasswordRejected(ctx.E.ID); err != nil {
+				return err
+			}
+		}
 		return ErrWrongPassword
 	}
 
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// renumberer patches indirect references according to a mapping of object numbers.
+type renumberer struct {
+	objNrs map[int]int
+	seen   map[uintptr]bool // Dicts and arrays already patched, since they may be shared.
+}
+
+func (rn *renumberer) visited(o any) bool {
+	p := reflect.ValueOf(o).Pointer()
+	if p == 0 {
+		return false
+	}
+	if rn.seen[p] {
+		return true
+	}
+	rn.seen[p] = true
+	return false
+}
+
+// indRef returns the renumbered reference for ir.
+// References to objects not in use become null as per 7.3.10.
+func (rn *renumberer) indRef(ir types.IndirectRef) types.Object {
+	objNr, ok := rn.objNrs[ir.ObjectNumber.Value()]
+	if !ok {
+		return nil
+	}
+	return *types.NewIndirectRef(objNr, 0)
+}
+
+func (rn *renumberer) object(o types.Object) types.Object {
+	switch o := o.(type) {
+	case types.IndirectRef:
+		return rn.indRef(o)
+	case types.Dict:
+		rn.dict(o)
+	case types.StreamDict:
+		rn.dict(o.Dict)
+	case types.Array:
+		rn.array(o)
+	}
+	return o
+}
+
+func (rn *renumberer) dict(d types.Dict) {
+	if len(d) == 0 || rn.visited(d) {
+		return
+	}
+	for k, v := range d {
+		d[k] = rn.object(v)
+	}
+}
+
+func (rn *renumberer) array(a types.Array) {
+	if len(a) == 0 || rn.visited(a) {
+		return
+	}
+	for i, v := range a {
+		a[i] = rn.object(v)
+	}
+}
+
+func (rn *renumberer) trailerRef(ir *types.IndirectRef) *types.IndirectRef {
+	if ir == nil {
+		return nil
+	}
+	ir1, ok := rn.indRef(*ir).(types.IndirectRef)
+	if !ok {
+		return nil
+	}
+	return &ir1
+}
+
+func (rn *renumberer) intSet(m types.IntSet) types.IntSet {
+	m1 := types.IntSet{}
+	for objNr, v := range m {
+		if objNr1, ok := rn.objNrs[objNr]; ok {
+			m1[objNr1] = v
+		}
+	}
+	return m1
+}
+
+func (rn *renumberer) nameTreeDicts(n *model.Node) {
+	if n == nil {
+		return
+	}
+	rn.dict(n.D)
+	for _, k := range n.Kids {
+		rn.nameTreeDicts(k)
+	}
+}
+
+// renumberObjects assigns the dense range 1..n to all objects reachable from the trailer or keep
+// preserving their order, resets all generation numbers and drops all free entries.
+// It returns keep using the new object numbers.
+func renumberObjects(ctx *model.Context, keep []int) ([]int, error) {
+	r, err := audit(ctx, keep)
+	if err != nil {
+		return nil, err
+	}
+
+	objNrs := r.Reachable
+	sort.Ints(objNrs)
+
+	rn := &renumberer{objNrs: map[int]int{}, seen: map[uintptr]bool{}}
+
+	renumbered := 0
+	for i, objNr := range objNrs {
+		rn.objNrs[objNr] = i + 1
+		if objNr != i+1 {
+			renumbered++
+		}
+	}
+
+	table := map[int]*model.XRefTableEntry{0: model.NewFreeHeadXRefTableEntry()}
+
+	for _, objNr := range objNrs {
+		e := ctx.Table[objNr]
+		e.Object = rn.object(e.Object)
+		gen := 0
+		e.Generation = &gen
+		table[rn.objNrs[objNr]] = e
+	}
+
+	if log.WriteEnabled() {
+		log.Write.Printf("renumberObjects: %d objects renumbered, size %d -> %d\n", renumbered, *ctx.Size, len(table))
+	}
+
+	xc := &model.XRefCompaction{ObjectsRenumbered: renumbered, FreeEntriesDropped: *ctx.Size - len(table)}
+	if xc.FreeEntriesDropped < 0 {
+		xc.FreeEntriesDropped = 0
+	}
+	ctx.Write.XRefCompaction = xc
+
+	size := len(table)
+	ctx.Table = table
+	ctx.Size = &size
+
+	ctx.Root = rn.trailerRef(ctx.Root)
+	ctx.Info = rn.trailerRef(ctx.Info)
+	ctx.Encrypt = rn.trailerRef(ctx.Encrypt)
+	if ctx.AdditionalStreams != nil {
+		rn.array(*ctx.AdditionalStreams)
+	}
+
+	// Name tree caches mirror their values.
+	for _, n := range ctx.Names {
+		rn.nameTreeDicts(n)
+		patch := func(_ *model.XRefTable, _ string, v *types.Object) error {
+			*v = rn.object(*v)
+			return nil
+		}
+		if err := n.Process(ctx.XRefTable, patch); err != nil {
+			return nil, err
+		}
+	}
+
+	if ctx.Optimize != nil {
+		ctx.Optimize.DuplicateFontObjs = rn.intSet(ctx.Optimize.DuplicateFontObjs)
+		ctx.Optimize.DuplicateImageObjs = rn.intSet(ctx.Optimize.DuplicateImageObjs)
+		ctx.Optimize.DuplicateInfoObjects = rn.intSet(ctx.Optimize.DuplicateInfoObjects)
+	}
+	ctx.LinearizationObjs = rn.intSet(ctx.LinearizationObjs)
+	if ctx.Read != nil {
+		ctx.Read.ObjectStreams = rn.intSet(ctx.Read.ObjectStreams)
+		ctx.Read.XRefStreams = rn.intSet(ctx.Read.XRefStreams)
+	}
+
+	keep1 := make([]int, 0, len(keep))
+	for _, objNr := range keep {
+		if objNr1, ok := rn.objNrs[objNr]; ok {
+			keep1 = append(keep1, objNr1)
+		}
+	}
+
+	return keep1, nil
+}
@@ -0,0 +1,443 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// RepairReport summarizes the recovery of a PDF file with a broken cross reference table.
+type RepairReport struct {
+	HeaderFound       bool  // A valid PDF header was found.
+	TrailerFound      bool  // A trailer dict or xref stream dict could be salvaged.
+	RootRecovered     bool  // The catalog had to be located by scanning.
+	Objects           int   // Objects recovered from the file body.
+	ObjectStreams     int   // Object streams salvaged.
+	CompressedObjects int   // Objects recovered from object streams.
+	Damaged           []int // Objects dropped because they could not be parsed.
+}
+
+// reRepair matches object headers, stream keywords and trailer keywords.
+var reRepair = regexp.MustCompile(`\b(\d+)\s+(\d+)\s+obj\b|\bstream(?:\r\n|\n|\r)|\btrailer\b`)
+
+type objLocation struct {
+	offset int64
+	genNr  int
+}
+
+// scanFile locates all object headers outside of stream data along with all trailer keywords.
+// Later definitions of an object number supersede earlier ones.
+func scanFile(buf []byte) (map[int]objLocation, []int64) {
+	objs := map[int]objLocation{}
+	trailers := []int64{}
+
+	for pos := 0; pos < len(buf); {
+		m := reRepair.FindSubmatchIndex(buf[pos:])
+		if m == nil {
+			break
+		}
+		start, end := pos+m[0], pos+m[1]
+
+		switch {
+
+		case m[2] >= 0:
+			objNr, err1 := strconv.Atoi(string(buf[pos+m[2] : pos+m[3]]))
+			genNr, err2 := strconv.Atoi(string(buf[pos+m[4] : pos+m[5]]))
+			if err1 == nil && err2 == nil && objNr > 0 {
+				objs[objNr] = objLocation{offset: int64(start), genNr: genNr}
+			}
+
+		case buf[start] == 's':
+			// Skip stream data.
+			i := strings.Index(string(buf[end:]), "endstream")
+			if i < 0 {
+				return objs, trailers
+			}
+			end += i + len("endstream")
+
+		default:
+			trailers = append(trailers, int64(end))
+		}
+
+		pos = end
+	}
+
+	return objs, trailers
+}
+
+func trailerDictAt(c context.Context, buf []byte, off int64) types.Dict {
+	s := string(buf[off:])
+	if i := strings.Index(s, "startxref"); i > 0 {
+		s = s[:i]
+	}
+	o, err := model.ParseObjectContext(c, &s)
+	if err != nil {
+		return nil
+	}
+	d, _ := o.(types.Dict)
+	return d
+}
+
+type repairTrailer struct {
+	offset int64
+	d      types.Dict
+}
+
+func applyRepairTrailers(ctx *model.Context, tt []repairTrailer) {
+	// Process the most recent trailer first.
+	sort.Slice(tt, func(i, j int) bool { return tt[i].offset > tt[j].offset })
+
+	for _, t := range tt {
+		d := t.d
+		if ctx.Root == nil {
+			ctx.Root = d.IndirectRefEntry("Root")
+		}
+		if ctx.Info == nil {
+			ctx.Info = d.IndirectRefEntry("Info")
+		}
+		if ctx.Encrypt == nil {
+			ctx.Encrypt = d.IndirectRefEntry("Encrypt")
+		}
+		if ctx.ID == nil {
+			if arr := d.ArrayEntry("ID"); len(arr) > 0 {
+				if len(arr) == 1 {
+					arr = append(arr, arr[0])
+				}
+				ctx.ID = arr[:2]
+			}
+		}
+	}
+}
+
+func isCatalog(o types.Object) bool {
+	d, ok := o.(types.Dict)
+	if !ok {
+		return false
+	}
+	t := d.Type()
+	_, found := d.Find("Pages")
+	return t != nil && *t == "Catalog" && found
+}
+
+// repairRoot ensures the trailer references a catalog and an info dict that have been recovered.
+// A missing catalog gets replaced by the most recent catalog found.
+func repairRoot(ctx *model.Context, catalog int, rep *RepairReport) error {
+	if ctx.Info != nil {
+		if e, ok := ctx.Table[ctx.Info.ObjectNumber.Value()]; !ok || e.Free {
+			ctx.Info = nil
+		} else if _, ok := e.Object.(types.Dict); !ok {
+			ctx.Info = nil
+		}
+	}
+
+	if ctx.Root != nil {
+		if e, ok := ctx.Table[ctx.Root.ObjectNumber.Value()]; ok && !e.Free && isCatalog(e.Object) {
+			return nil
+		}
+	}
+
+	if catalog == 0 {
+		// Look for a catalog within object streams.
+		for objNr, e := range ctx.Table {
+			if !e.Free && isCatalog(e.Object) && objNr > catalog {
+				catalog = objNr
+			}
+		}
+	}
+
+	if e, ok := ctx.Table[catalog]; !ok || e.Free || !isCatalog(e.Object) {
+		return errors.New("pdfcpu: repair: no catalog found")
+	}
+
+	ctx.Root = types.NewIndirectRef(catalog, 0)
+	rep.RootRecovered = true
+	model.ShowRepaired("catalog")
+
+	return nil
+}
+
+// objectStreamObjNrs returns the object numbers listed in the prolog of osd.
+func objectStreamObjNrs(osd *types.ObjectStreamDict) ([]int, error) {
+	bb := osd.Content
+	if bb == nil {
+		var err error
+		if bb, err = osd.DecodeLength(int64(osd.FirstObjOffset)); err != nil {
+			return nil, err
+		}
+	}
+	if len(bb) < osd.FirstObjOffset {
+		return nil, errors.New("pdfcpu: corrupt object stream prolog")
+	}
+
+	ss := strings.Fields(strings.ReplaceAll(string(bb[:osd.FirstObjOffset]), "\x00", " "))
+	objNrs := []int{}
+	for i := 0; i+1 < len(ss); i += 2 {
+		objNr, err := strconv.Atoi(ss[i])
+		if err != nil {
+			return nil, err
+		}
+		objNrs = append(objNrs, objNr)
+	}
+
+	return objNrs, nil
+}
+
+// salvageObjectStreams decodes all intact object streams and registers their objects
+// unless a more recent definition has been found in the file body.
+func salvageObjectStreams(c context.Context, ctx *model.Context, rep *RepairReport) {
+	keys := make([]int, 0, len(ctx.Read.ObjectStreams))
+	for objNr := range ctx.Read.ObjectStreams {
+		keys = append(keys, objNr)
+	}
+	sort.Ints(keys)
+
+	for _, objNr := range keys {
+		if err := decodeObjectStream(c, ctx, objNr); err != nil {
+			delete(ctx.Table, objNr)
+			delete(ctx.Read.ObjectStreams, objNr)
+			rep.Damaged = append(rep.Damaged, objNr)
+			continue
+		}
+
+		e := ctx.Table[objNr]
+		osd := e.Object.(types.ObjectStreamDict)
+		objNrs, err := objectStreamObjNrs(&osd)
+		if err != nil {
+			delete(ctx.Table, objNr)
+			delete(ctx.Read.ObjectStreams, objNr)
+			rep.Damaged = append(rep.Damaged, objNr)
+			continue
+		}
+		rep.ObjectStreams++
+
+		for i, objNr1 := range objNrs {
+			if e1, ok := ctx.Table[objNr1]; ok && (e1.Compressed || *e1.Offset > *e.Offset) {
+				continue
+			}
+			objStm, ind := objNr, i
+			ctx.Table[objNr1] = &model.XRefTableEntry{Compressed: true, ObjectStream: &objStm, ObjectStreamInd: &ind}
+			rep.CompressedObjects++
+		}
+	}
+}
+
+// loadRepairObjects parses all uncompressed objects and drops the ones that are damaged.
+func loadRepairObjects(c context.Context, ctx *model.Context, rep *RepairReport) error {
+	keys := []int{}
+	for objNr, e := range ctx.Table {
+		if !e.Free && !e.Compressed && !ctx.Read.ObjectStreams[objNr] {
+			keys = append(keys, objNr)
+		}
+	}
+	sort.Ints(keys)
+
+	for _, objNr := range keys {
+		if err := c.Err(); err != nil {
+			return err
+		}
+
+		e := ctx.Table[objNr]
+		o, err := ParseObjectWithContext(c, ctx, *e.Offset, objNr, *e.Generation)
+		if err == nil {
+			if sd, ok := o.(types.StreamDict); ok {
+				if err = loadStreamDict(c, ctx, &sd, objNr, *e.Generation, true); err == nil {
+					o = sd
+				}
+			}
+		}
+		if err != nil {
+			delete(ctx.Table, objNr)
+			rep.Damaged = append(rep.Damaged, objNr)
+			model.ShowSkipped(fmt.Sprintf("damaged obj #%d", objNr))
+			continue
+		}
+
+		e.Object = o
+		rep.Objects++
+	}
+
+	return nil
+}
+
+// classifyRepairObjects drops unparsable objects and collects xref streams, object streams and catalogs.
+func classifyRepairObjects(c context.Context, ctx *model.Context, rep *RepairReport) ([]repairTrailer, int) {
+	keys := make([]int, 0, len(ctx.Table))
+	for objNr := range ctx.Table {
+		keys = append(keys, objNr)
+	}
+	sort.Ints(keys)
+
+	tt := []repairTrailer{}
+	catalog := 0
+	var catalogOff int64
+
+	for _, objNr := range keys {
+		e := ctx.Table[objNr]
+		if e.Free {
+			continue
+		}
+
+		o, err := ParseObjectWithContext(c, ctx, *e.Offset, objNr, *e.Generation)
+		if err != nil {
+			delete(ctx.Table, objNr)
+			rep.Damaged = append(rep.Damaged, objNr)
+			continue
+		}
+
+		var d types.Dict
+		switch o := o.(type) {
+		case types.Dict:
+			d = o
+		case types.StreamDict:
+			d = o.Dict
+		default:
+			continue
+		}
+
+		t := d.Type()
+		if t == nil {
+			continue
+		}
+
+		switch *t {
+		case "XRef":
+			// Xref streams get rebuilt on write.
+			tt = append(tt, repairTrailer{offset: *e.Offset, d: d})
+			delete(ctx.Table, objNr)
+		case "ObjStm":
+			ctx.Read.ObjectStreams[objNr] = true
+		case "Catalog":
+			if isCatalog(d) && *e.Offset >= catalogOff {
+				catalog, catalogOff = objNr, *e.Offset
+			}
+		}
+	}
+
+	return tt, catalog
+}
+
+// ReadAndRepair reconstructs the cross reference table of a damaged PDF file
+// by scanning rs for indirect objects instead of relying on any xref section.
+// The trailer gets rebuilt from salvaged trailer and xref stream dicts or by locating the catalog.
+func ReadAndRepair(rs io.ReadSeeker, conf *model.Configuration) (*model.Context, *RepairReport, error) {
+	return ReadAndRepairWithContext(context.Background(), rs, conf)
+}
+
+// ReadAndRepairWithContext reconstructs the cross reference table of a damaged PDF file
+// by scanning rs for indirect objects instead of relying on any xref section.
+// If the passed Go context is cancelled, reading will be interrupted.
+func ReadAndRepairWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configuration) (*model.Context, *RepairReport, error) {
+	if log.ReadEnabled() {
+		log.Read.Println("ReadAndRepair: begin")
+	}
+
+	ctx, err := model.NewContext(rs, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ctx.Read.FileSize == 0 {
+		return nil, nil, errors.New("The file could not be opened because it is empty.")
+	}
+
+	rep := &RepairReport{}
+
+	hv, eolCount, _, err := headerVersion(rs)
+	if err == nil {
+		rep.HeaderFound = true
+	} else {
+		v := model.V17
+		hv, eolCount = &v, 1
+		model.ShowRepaired("header")
+	}
+	ctx.HeaderVersion = hv
+	ctx.Read.EolCount = eolCount
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+	buf, err := io.ReadAll(rs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	objs, trailerOffs := scanFile(buf)
+	if len(objs) == 0 {
+		return nil, nil, errors.New("pdfcpu: repair: no objects found")
+	}
+
+	g0 := types.FreeHeadGeneration
+	ctx.Table[0] = &model.XRefTableEntry{Free: true, Offset: &zero, Generation: &g0}
+	for objNr, loc := range objs {
+		off, genNr := loc.offset, loc.genNr
+		ctx.Table[objNr] = &model.XRefTableEntry{Offset: &off, Generation: &genNr}
+	}
+
+	tt, catalog := classifyRepairObjects(c, ctx, rep)
+	for _, off := range trailerOffs {
+		if d := trailerDictAt(c, buf, off); d != nil {
+			tt = append(tt, repairTrailer{offset: off, d: d})
+		}
+	}
+	rep.TrailerFound = len(tt) > 0
+
+	applyRepairTrailers(ctx, tt)
+
+	if err := checkForEncryption(c, ctx); err != nil {
+		return nil, nil, err
+	}
+
+	salvageObjectStreams(c, ctx, rep)
+
+	if err := loadRepairObjects(c, ctx, rep); err != nil {
+		return nil, nil, err
+	}
+
+	if err := dereferenceObjects(c, ctx); err != nil {
+		return nil, nil, err
+	}
+
+	if err := repairRoot(ctx, catalog, rep); err != nil {
+		return nil, nil, err
+	}
+
+	if err := ctx.EnsureValidFreeList(); err != nil {
+		return nil, nil, err
+	}
+
+	maxObjNr := ctx.MaxObjNr + 1
+	ctx.Size = &maxObjNr
+
+	sort.Ints(rep.Damaged)
+
+	if log.ReadEnabled() {
+		log.Read.Println("ReadAndRepair: end")
+	}
+
+	return ctx, rep, nil
+}
@@ -251,3 +251,63 @@ func Resize(ctx *model.Context, selectedPages types.IntSet, res *model.Resize) e
 
 	return nil
 }
+
+func resizePageBoxes(ctx *model.Context, pageNr int, target *types.Dim, visited types.IntSet) error {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	mb := inhPAttrs.MediaBox
+	if mb == nil {
+		return errors.Errorf("pdfcpu: page %d: missing mediaBox", pageNr)
+	}
+
+	// target refers to the displayed page.
+	w, h := target.Width, target.Height
+	if types.IntMemberOf(inhPAttrs.Rotate, []int{+90, -90, +270, -270}) {
+		w, h = h, w
+	}
+
+	// Scale uniformly and center the result.
+	sc := math.Min(w/mb.Width(), h/mb.Height())
+	pt := pageTransform{m: matrix.Matrix{
+		{sc, 0, 0},
+		{0, sc, 0},
+		{(w-mb.Width()*sc)/2 - mb.LL.X*sc, (h-mb.Height()*sc)/2 - mb.LL.Y*sc, 1},
+	}}
+
+	return pt.apply(ctx, d, pageNr, inhPAttrs, visited)
+}
+
+// ResizePages scales selected pages including their content, all page boundaries and annotations to target.
+// The content gets scaled uniformly and centered, target refers to the displayed page in points.
+func ResizePages(ctx *model.Context, selectedPages types.IntSet, target *types.Dim) error {
+	if target == nil || target.Width <= 0 || target.Height <= 0 {
+		return errors.New("pdfcpu: ResizePages: invalid target dimensions")
+	}
+
+	if len(selectedPages) == 0 {
+		selectedPages = types.IntSet{}
+		for i := 1; i <= ctx.PageCount; i++ {
+			selectedPages[i] = true
+		}
+	}
+
+	visited := types.IntSet{}
+
+	for k, v := range selectedPages {
+		if v {
+			if err := resizePageBoxes(ctx, k, target, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
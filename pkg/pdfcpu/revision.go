@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// RevisionInfo describes a revision of a PDF file.
+// Revision 1 is the original document, any following revision has been appended as incremental update.
+type RevisionInfo struct {
+	Nr      int      `json:"revision"`
+	Size    int64    `json:"size"`              // Length of the file as of this revision.
+	ID      []string `json:"id,omitempty"`      // Trailer ID as hex strings.
+	Changed []int    `json:"changed,omitempty"` // Object numbers added, modified or freed by this revision.
+}
+
+// A revision ends with startxref pointing to its last cross reference section followed by %%EOF.
+// The first page trailer of linearized files points to offset 0 and does not end a revision.
+var reRevisionEnd = regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF(\r\n|\r|\n)?`)
+
+const revisionScanOverlap = 128
+
+// RevisionOffsets returns the end offsets of all revisions of rs.
+func RevisionOffsets(rs io.ReadSeeker) ([]int64, error) {
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var (
+		offs []int64
+		buf  []byte
+		base int64 // file offset of buf[0]
+	)
+
+	chunk := make([]byte, maximumBufSize)
+
+	for {
+		n, err := rs.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		eof := err == io.EOF
+		if err != nil && !eof {
+			return nil, err
+		}
+
+		// Matches touching the end of buf may be incomplete unless we are done.
+		limit := len(buf)
+		if !eof {
+			limit -= revisionScanOverlap
+		}
+
+		consumed := 0
+		for _, m := range reRevisionEnd.FindAllSubmatchIndex(buf, -1) {
+			if m[1] > limit {
+				break
+			}
+			consumed = m[1]
+			if off, err := strconv.ParseInt(string(buf[m[2]:m[3]]), 10, 64); err == nil && off > 0 {
+				offs = append(offs, base+int64(m[1]))
+			}
+		}
+
+		if eof {
+			break
+		}
+
+		// Keep a tail in case a match spans chunks.
+		keep := max(consumed, len(buf)-2*revisionScanOverlap)
+		base += int64(keep)
+		buf = append(buf[:0], buf[keep:]...)
+	}
+
+	if len(offs) == 0 {
+		// Corrupt or truncated file: treat as single revision.
+		fileSize, err := rs.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+		offs = append(offs, fileSize)
+	}
+
+	return offs, nil
+}
+
+// RevisionCount returns the number of revisions of rs.
+func RevisionCount(rs io.ReadSeeker) (int, error) {
+	offs, err := RevisionOffsets(rs)
+	if err != nil {
+		return 0, err
+	}
+	return len(offs), nil
+}
+
+// Revision returns the document as of revision n, starting with 1 for the original document.
+func Revision(rs io.ReadSeeker, n int) (io.ReadSeeker, error) {
+	offs, err := RevisionOffsets(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 1 || n > len(offs) {
+		return nil, errors.Errorf("pdfcpu: invalid revision %d, available: 1..%d", n, len(offs))
+	}
+
+	return revision(rs, offs[n-1])
+}
+
+func revision(rs io.ReadSeeker, size int64) (io.ReadSeeker, error) {
+	if ra, ok := rs.(io.ReaderAt); ok {
+		return io.NewSectionReader(ra, 0, size), nil
+	}
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	bb := make([]byte, size)
+	if _, err := io.ReadFull(rs, bb); err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(bb), nil
+}
+
+// ChangedObjects returns the numbers of all objects added, modified or freed by revision n of rs.
+// For revision 1 these are all objects of the original document.
+func ChangedObjects(rs io.ReadSeeker, n int, conf *model.Configuration) ([]int, error) {
+	r, err := Revision(rs, n)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := Read(r, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return changedObjects(ctx, n), nil
+}
+
+// changedObjects returns the object numbers defined by the cross reference section(s) last read.
+func changedObjects(ctx *model.Context, n int) []int {
+	objNrs := []int{}
+	for objNr, e := range ctx.Table {
+		if objNr == 0 || e == nil {
+			continue
+		}
+		if n == 1 && !e.Free || n > 1 && e.Incr == 1 {
+			objNrs = append(objNrs, objNr)
+		}
+	}
+	sort.Ints(objNrs)
+	return objNrs
+}
+
+func idStrings(a types.Array) []string {
+	var ss []string
+	for _, o := range a {
+		switch o := o.(type) {
+		case types.HexLiteral:
+			ss = append(ss, string(o))
+		case types.StringLiteral:
+			bb, err := types.Unescape(o.Value())
+			if err != nil {
+				bb = []byte(o.Value())
+			}
+			ss = append(ss, hex.EncodeToString(bb))
+		}
+	}
+	return ss
+}
+
+// Revisions returns information about all revisions of rs.
+func Revisions(rs io.ReadSeeker, conf *model.Configuration) ([]RevisionInfo, error) {
+	offs, err := RevisionOffsets(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := make([]RevisionInfo, len(offs))
+
+	for i, off := range offs {
+		r, err := revision(rs, off)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, err := Read(r, conf)
+		if err != nil {
+			return nil, errors.Wrapf(err, "pdfcpu: revision %d", i+1)
+		}
+
+		rr[i] = RevisionInfo{Nr: i + 1, Size: off, ID: idStrings(ctx.ID), Changed: changedObjects(ctx, i+1)}
+	}
+
+	return rr, nil
+}
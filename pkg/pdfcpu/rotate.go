@@ -18,8 +18,10 @@ package pdfcpu
 
 import (
 	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
 )
 
 func rotatePage(xRefTable *model.XRefTable, i, j int) error {
@@ -52,3 +54,79 @@ func RotatePages(ctx *model.Context, selectedPages types.IntSet, rotation int) e
 
 	return nil
 }
+
+// uprightTransform returns the transform baking a clockwise rotation rot into the geometry of a page with mediaBox mb.
+func uprightTransform(mb *types.Rectangle, rot int) matrix.Matrix {
+	w, h := mb.Width(), mb.Height()
+
+	var m matrix.Matrix
+	switch rot {
+	case 90:
+		m = matrix.Matrix{{0, -1, 0}, {1, 0, 0}, {0, w, 1}}
+	case 180:
+		m = matrix.Matrix{{-1, 0, 0}, {0, -1, 0}, {w, h, 1}}
+	case 270:
+		m = matrix.Matrix{{0, 1, 0}, {-1, 0, 0}, {h, 0, 1}}
+	default:
+		m = matrix.IdentMatrix
+	}
+
+	// Move the mediaBox to the origin first.
+	t := matrix.IdentMatrix
+	t[2][0], t[2][1] = -mb.LL.X, -mb.LL.Y
+
+	return t.Multiply(m)
+}
+
+func normalizePageRotation(ctx *model.Context, pageNr int, visited types.IntSet) error {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	rot := inhPAttrs.Rotate % 360
+	if rot < 0 {
+		rot += 360
+	}
+	if rot%90 != 0 {
+		return errors.Errorf("pdfcpu: page %d: invalid rotation: %d", pageNr, inhPAttrs.Rotate)
+	}
+
+	if rot == 0 {
+		return nil
+	}
+
+	if inhPAttrs.MediaBox == nil {
+		return errors.Errorf("pdfcpu: page %d: missing mediaBox", pageNr)
+	}
+
+	d.Delete("Rotate")
+
+	// Override any inherited rotation.
+	if _, _, inhPAttrs1, err := ctx.PageDict(pageNr, false); err == nil && inhPAttrs1.Rotate != 0 {
+		d["Rotate"] = types.Integer(0)
+	}
+
+	pt := pageTransform{m: uprightTransform(inhPAttrs.MediaBox, rot), rot: rot}
+
+	return pt.apply(ctx, d, pageNr, inhPAttrs, visited)
+}
+
+// NormalizeRotation bakes the rotation of all pages into their content, page boundaries and annotations
+// resulting in upright pages without /Rotate.
+func NormalizeRotation(ctx *model.Context) error {
+	visited := types.IntSet{}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if err := normalizePageRotation(ctx, i, visited); err != nil {
+			return err
+		}
+	}
+
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
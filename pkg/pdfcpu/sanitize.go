@@ -0,0 +1,367 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// SanitizePolicy configures which kinds of active or external content Sanitize removes.
+type SanitizePolicy struct {
+	JavaScript     bool // JavaScript actions, document level scripts and scripts of Rendition actions
+	Launch         bool // Launch actions
+	URI            bool // URI actions
+	GoToR          bool // GoToR, GoToE and ImportData actions
+	SubmitForm     bool // SubmitForm actions
+	EmbeddedFiles  bool // embedded files and file attachment annotations
+	XFA            bool // XFA forms
+	ExternalRefs   bool // reference XObjects, external stream data, OPI dicts and the catalog URI base
+	NormalizeNames bool // replace control characters and invalid UTF-8 in name objects
+}
+
+// DefaultSanitizePolicy returns the most restrictive sanitize policy.
+func DefaultSanitizePolicy() SanitizePolicy {
+	return SanitizePolicy{
+		JavaScript:     true,
+		Launch:         true,
+		URI:            true,
+		GoToR:          true,
+		SubmitForm:     true,
+		EmbeddedFiles:  true,
+		XFA:            true,
+		ExternalRefs:   true,
+		NormalizeNames: true,
+	}
+}
+
+func (p SanitizePolicy) actionTypes() map[string]bool {
+	m := map[string]bool{}
+	if p.JavaScript {
+		m["JavaScript"] = true
+	}
+	if p.Launch {
+		m["Launch"] = true
+	}
+	if p.URI {
+		m["URI"] = true
+	}
+	if p.GoToR {
+		m["GoToR"] = true
+		m["GoToE"] = true
+		m["ImportData"] = true
+	}
+	if p.SubmitForm {
+		m["SubmitForm"] = true
+	}
+	return m
+}
+
+// SanitizeReport lists the items removed or modified by Sanitize.
+type SanitizeReport struct {
+	Actions       []string `json:"actions,omitempty"`
+	EmbeddedFiles []string `json:"embeddedFiles,omitempty"`
+	XFA           bool     `json:"xfa,omitempty"`
+	ExternalRefs  []string `json:"externalRefs,omitempty"`
+	Names         []string `json:"names,omitempty"`
+}
+
+// Count returns the total number of sanitized items.
+func (r SanitizeReport) Count() int {
+	n := len(r.Actions) + len(r.EmbeddedFiles) + len(r.ExternalRefs) + len(r.Names)
+	if r.XFA {
+		n++
+	}
+	return n
+}
+
+func (r SanitizeReport) String() string {
+	var sb strings.Builder
+	for _, s := range r.Actions {
+		fmt.Fprintf(&sb, "removed %s\n", s)
+	}
+	for _, s := range r.EmbeddedFiles {
+		fmt.Fprintf(&sb, "removed embedded file: %s\n", s)
+	}
+	if r.XFA {
+		sb.WriteString("removed XFA form\n")
+	}
+	for _, s := range r.ExternalRefs {
+		fmt.Fprintf(&sb, "removed external reference: %s\n", s)
+	}
+	for _, s := range r.Names {
+		fmt.Fprintf(&sb, "normalized name: %s\n", s)
+	}
+	return sb.String()
+}
+
+func sanitizeActions(ctx *model.Context, policy SanitizePolicy, r *SanitizeReport) error {
+	m := policy.actionTypes()
+	if len(m) == 0 {
+		return nil
+	}
+
+	v := &actionVisitor{ctx: ctx, scope: JSAll, remove: m}
+	if err := v.visit(); err != nil {
+		return err
+	}
+	r.Actions = append(r.Actions, v.removed...)
+
+	if policy.JavaScript {
+		return removeJavaScriptRequirements(ctx)
+	}
+
+	return nil
+}
+
+func sanitizeEmbeddedFiles(ctx *model.Context, r *SanitizeReport) error {
+	xRefTable := ctx.XRefTable
+
+	if err := xRefTable.LocateNameTree("EmbeddedFiles", false); err != nil {
+		return err
+	}
+
+	if root := xRefTable.Names["EmbeddedFiles"]; root != nil {
+		ids := func(xRefTable *model.XRefTable, k string, v *types.Object) error {
+			r.EmbeddedFiles = append(r.EmbeddedFiles, k)
+			return nil
+		}
+		if err := root.Process(xRefTable, ids); err != nil {
+			return err
+		}
+		if err := xRefTable.RemoveEmbeddedFilesNameTree(); err != nil {
+			return err
+		}
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+
+		d, ir, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+
+		a, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return err
+		}
+
+		var found bool
+		for _, o := range a {
+			d1, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return err
+			}
+			if d1 == nil {
+				continue
+			}
+			if st := d1.NameEntry("Subtype"); st == nil || *st != "FileAttachment" {
+				continue
+			}
+			fileName, _, err := fileSpecData(xRefTable, d1["FS"])
+			if err != nil {
+				return err
+			}
+			r.EmbeddedFiles = append(r.EmbeddedFiles, fmt.Sprintf("%s (file attachment annotation on page %d)", fileName, pageNr))
+			found = true
+		}
+
+		if !found {
+			continue
+		}
+
+		annTypes := []model.AnnotationType{model.AnnFileAttachment}
+		if _, err := RemoveAnnotationsFromPageDict(ctx, annTypes, nil, nil, d, ir.ObjectNumber.Value(), pageNr, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sanitizeXFA(ctx *model.Context, r *SanitizeReport) error {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	d, err := ctx.DereferenceDict(rootDict["AcroForm"])
+	if err != nil || d == nil {
+		return err
+	}
+
+	if _, found := d.Find("XFA"); !found {
+		return nil
+	}
+
+	if err := ctx.DeleteDictEntry(d, "XFA"); err != nil {
+		return err
+	}
+	rootDict.Delete("NeedsRendering")
+	r.XFA = true
+
+	return nil
+}
+
+// objNrsInUse returns the sorted object numbers of all objects in use.
+func objNrsInUse(ctx *model.Context) []int {
+	objNrs := make([]int, 0, len(ctx.Table))
+	for objNr, entry := range ctx.Table {
+		if entry != nil && !entry.Free && entry.Object != nil {
+			objNrs = append(objNrs, objNr)
+		}
+	}
+	sort.Ints(objNrs)
+	return objNrs
+}
+
+func sanitizeExternalRefs(ctx *model.Context, r *SanitizeReport) error {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	if _, found := rootDict.Find("URI"); found {
+		if err := ctx.DeleteDictEntry(rootDict, "URI"); err != nil {
+			return err
+		}
+		r.ExternalRefs = append(r.ExternalRefs, "catalog URI base")
+	}
+
+	for _, objNr := range objNrsInUse(ctx) {
+		sd, ok := ctx.Table[objNr].Object.(types.StreamDict)
+		if !ok {
+			continue
+		}
+
+		if _, found := sd.Find("F"); found {
+			// Stream data located in an external file.
+			for _, k := range []string{"F", "FFilter", "FDecodeParms"} {
+				sd.Delete(k)
+			}
+			r.ExternalRefs = append(r.ExternalRefs, fmt.Sprintf("external stream data of obj#%d", objNr))
+		}
+
+		if st := sd.Subtype(); st == nil || (*st != "Form" && *st != "Image") {
+			continue
+		}
+
+		if _, found := sd.Find("Ref"); found {
+			sd.Delete("Ref")
+			r.ExternalRefs = append(r.ExternalRefs, fmt.Sprintf("reference XObject obj#%d", objNr))
+		}
+
+		if _, found := sd.Find("OPI"); found {
+			sd.Delete("OPI")
+			r.ExternalRefs = append(r.ExternalRefs, fmt.Sprintf("OPI dict of obj#%d", objNr))
+		}
+	}
+
+	return nil
+}
+
+// normalizeName replaces control characters and invalid UTF-8 sequences.
+func normalizeName(s string) (string, bool) {
+	s1 := strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7F || r == utf8.RuneError {
+			return '_'
+		}
+		return r
+	}, strings.ToValidUTF8(s, string(utf8.RuneError)))
+	return s1, s1 != s
+}
+
+func sanitizeNames(ctx *model.Context, r *SanitizeReport) {
+	var walk func(o types.Object) types.Object
+	walk = func(o types.Object) types.Object {
+		switch o := o.(type) {
+		case types.Name:
+			if s, ok := normalizeName(o.Value()); ok {
+				r.Names = append(r.Names, fmt.Sprintf("%q -> %q", o.Value(), s))
+				return types.Name(s)
+			}
+		case types.StreamDict:
+			walk(o.Dict)
+		case types.Dict:
+			for k, v := range o {
+				v = walk(v)
+				if k1, ok := normalizeName(k); ok {
+					if _, found := o[k1]; !found {
+						r.Names = append(r.Names, fmt.Sprintf("%q -> %q", k, k1))
+						delete(o, k)
+						k = k1
+					}
+				}
+				o[k] = v
+			}
+		case types.Array:
+			for i, v := range o {
+				o[i] = walk(v)
+			}
+		}
+		// Indirect objects are visited separately.
+		return o
+	}
+
+	for _, objNr := range objNrsInUse(ctx) {
+		entry := ctx.Table[objNr]
+		entry.Object = walk(entry.Object)
+	}
+}
+
+// Sanitize removes active and external content from ctx according to policy
+// and returns a report of all removed or modified items.
+// Name objects are parsed with their hex escape sequences resolved and are always written in canonical form.
+func Sanitize(ctx *model.Context, policy SanitizePolicy) (*SanitizeReport, error) {
+	r := &SanitizeReport{}
+
+	if err := sanitizeActions(ctx, policy, r); err != nil {
+		return nil, err
+	}
+
+	if policy.EmbeddedFiles {
+		if err := sanitizeEmbeddedFiles(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	if policy.XFA {
+		if err := sanitizeXFA(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	if policy.ExternalRefs {
+		if err := sanitizeExternalRefs(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	if policy.NormalizeNames {
+		sanitizeNames(ctx, r)
+	}
+
+	return r, nil
+}
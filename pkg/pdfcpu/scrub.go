@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// ScrubOptions configures which metadata ScrubMetadata removes.
+type ScrubOptions struct {
+	Info         bool     // document info dict entries except InfoKeep
+	InfoKeep     []string // info dict keys to keep eg. Title
+	XMP          bool     // XMP metadata streams except font metadata
+	PieceInfo    bool     // page-piece dicts and their LastModified dates
+	DocumentID   bool     // file identifiers get regenerated on write
+	Thumbnails   bool     // embedded page thumbnails
+	Annotations  bool     // annotation authors, creation and modification dates
+	Author       string   // replaces the document author and annotation authors if not empty
+	FontMetadata bool     // XMP metadata of font descriptors and embedded font programs
+}
+
+// DefaultScrubOptions returns scrub options removing all metadata but font metadata.
+func DefaultScrubOptions() ScrubOptions {
+	return ScrubOptions{
+		Info:        true,
+		XMP:         true,
+		PieceInfo:   true,
+		DocumentID:  true,
+		Thumbnails:  true,
+		Annotations: true,
+	}
+}
+
+func scrubInfoDict(ctx *model.Context, opts ScrubOptions) ([]string, error) {
+	if ctx.Info == nil {
+		return nil, nil
+	}
+
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var ss []string
+	for _, k := range keys {
+		if types.MemberOf(k, opts.InfoKeep) {
+			continue
+		}
+		if k == "Author" && opts.Author != "" {
+			s, err := types.EscapedUTF16String(opts.Author)
+			if err != nil {
+				return nil, err
+			}
+			d["Author"] = types.StringLiteral(*s)
+			ss = append(ss, "info dict entry: Author (anonymized)")
+			continue
+		}
+		d.Delete(k)
+		ss = append(ss, fmt.Sprintf("info dict entry: %s", k))
+	}
+
+	if len(d) == 0 && ctx.XRefTable.Version() >= model.V20 {
+		// The info dict is deprecated in PDF 2.0
+		ctx.Info = nil
+	}
+
+	return ss, nil
+}
+
+// fontObjNrs returns the object numbers of all font descriptors and embedded font programs.
+func fontObjNrs(ctx *model.Context) map[int]bool {
+	m := map[int]bool{}
+
+	for _, objNr := range objNrsInUse(ctx) {
+		d, ok := ctx.Table[objNr].Object.(types.Dict)
+		if !ok {
+			continue
+		}
+		if t := d.Type(); t == nil || *t != "FontDescriptor" {
+			continue
+		}
+		m[objNr] = true
+		for _, k := range []string{"FontFile", "FontFile2", "FontFile3"} {
+			if ir := d.IndirectRefEntry(k); ir != nil {
+				m[ir.ObjectNumber.Value()] = true
+			}
+		}
+	}
+
+	return m
+}
+
+func scrubAnnotation(d types.Dict, opts ScrubOptions) bool {
+	st := d.Subtype()
+	if st == nil || *st == "Widget" {
+		// For widgets T is the field name.
+		return false
+	}
+
+	var found bool
+
+	if _, ok := d.Find("T"); ok {
+		if opts.Author != "" {
+			s, err := types.EscapedUTF16String(opts.Author)
+			if err == nil {
+				d["T"] = types.StringLiteral(*s)
+			}
+		} else {
+			d.Delete("T")
+		}
+		found = true
+	}
+
+	for _, k := range []string{"CreationDate", "M"} {
+		if _, ok := d.Find(k); ok {
+			d.Delete(k)
+			found = true
+		}
+	}
+
+	return found
+}
+
+// scrubDict removes metadata entries from d.
+func scrubDict(d types.Dict, opts ScrubOptions, fontObj bool) []string {
+	var ss []string
+
+	if _, found := d.Find("Metadata"); found && ((fontObj && opts.FontMetadata) || (!fontObj && opts.XMP)) {
+		d.Delete("Metadata")
+		ss = append(ss, "XMP metadata")
+	}
+
+	if opts.PieceInfo {
+		if _, found := d.Find("PieceInfo"); found {
+			d.Delete("PieceInfo")
+			d.Delete("LastModified")
+			ss = append(ss, "page-piece dict")
+		}
+	}
+
+	t := d.Type()
+
+	if opts.Thumbnails && t != nil && *t == "Page" {
+		if _, found := d.Find("Thumb"); found {
+			d.Delete("Thumb")
+			ss = append(ss, "thumbnail")
+		}
+	}
+
+	return ss
+}
+
+func scrubAnnotations(ctx *model.Context, opts ScrubOptions) ([]string, error) {
+	var ss []string
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+
+		a, err := ctx.DereferenceArray(d["Annots"])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range a {
+			d1, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return nil, err
+			}
+			if d1 == nil || !scrubAnnotation(d1, opts) {
+				continue
+			}
+			s := fmt.Sprintf("annotation author and dates on page %d", pageNr)
+			if ir, ok := o.(types.IndirectRef); ok {
+				s += fmt.Sprintf(" obj#%d", ir.ObjectNumber.Value())
+			}
+			ss = append(ss, s)
+		}
+	}
+
+	return ss, nil
+}
+
+// ScrubMetadata removes or anonymizes metadata of ctx according to opts
+// and returns a list of all scrubbed items.
+// Please note that writing a file always updates Producer, CreationDate and ModDate of the info dict
+// unless running in deterministic mode.
+func ScrubMetadata(ctx *model.Context, opts ScrubOptions) ([]string, error) {
+	var ss []string
+
+	if opts.Info {
+		ss1, err := scrubInfoDict(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, ss1...)
+	}
+
+	if opts.DocumentID && ctx.ID != nil {
+		if ctx.Encrypt != nil {
+			// The encryption key depends on the file identifier.
+			ss = append(ss, "document ID kept for encrypted file")
+		} else {
+			ctx.ID = nil
+			ss = append(ss, "document ID")
+		}
+	}
+
+	if opts.Annotations {
+		ss1, err := scrubAnnotations(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, ss1...)
+	}
+
+	fontObjs := fontObjNrs(ctx)
+
+	var walk func(o types.Object, fontObj bool) []string
+	walk = func(o types.Object, fontObj bool) []string {
+		var ss []string
+		switch o := o.(type) {
+		case types.StreamDict:
+			ss = append(ss, walk(o.Dict, fontObj)...)
+		case types.Dict:
+			ss = append(ss, scrubDict(o, opts, fontObj)...)
+			for _, v := range o {
+				ss = append(ss, walk(v, false)...)
+			}
+		case types.Array:
+			for _, v := range o {
+				ss = append(ss, walk(v, false)...)
+			}
+		}
+		// Indirect objects are visited separately.
+		return ss
+	}
+
+	for _, objNr := range objNrsInUse(ctx) {
+		if ctx.Info != nil && objNr == ctx.Info.ObjectNumber.Value() {
+			continue
+		}
+		for _, s := range walk(ctx.Table[objNr].Object, fontObjs[objNr]) {
+			ss = append(ss, fmt.Sprintf("%s of obj#%d", s, objNr))
+		}
+	}
+
+	return ss, nil
+}
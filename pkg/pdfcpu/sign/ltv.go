@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sign
+
+import (
+	"bytes"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+// RevocationFetcher obtains revocation information for certificates.
+type RevocationFetcher interface {
+	// OCSP returns a DER encoded OCSP response for cert issued by issuer.
+	OCSP(cert, issuer *x509.Certificate) ([]byte, error)
+
+	// CRLs returns the DER encoded CRLs covering cert.
+	CRLs(cert *x509.Certificate) ([][]byte, error)
+}
+
+// HTTPRevocationFetcher is a RevocationFetcher using the OCSP responders and CRL distribution points of a certificate.
+type HTTPRevocationFetcher struct {
+	Timeout time.Duration // defaults to 10s
+}
+
+func (f HTTPRevocationFetcher) client() *http.Client {
+	timeout := f.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+func readResponse(resp *http.Response, url string) ([]byte, error) {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("pdfcpu: %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<24))
+}
+
+// OCSP requests the revocation status of cert from its OCSP responders.
+func (f HTTPRevocationFetcher) OCSP(cert, issuer *x509.Certificate) ([]byte, error) {
+	if len(cert.OCSPServer) == 0 {
+		return nil, errors.Errorf("pdfcpu: no OCSP responder for: %s", cert.Subject)
+	}
+
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := f.client()
+
+	for _, url := range cert.OCSPServer {
+		resp, err := client.Post(url, "application/ocsp-request", bytes.NewReader(req))
+		if err != nil {
+			continue
+		}
+		bb, err := readResponse(resp, url)
+		if err != nil {
+			continue
+		}
+		if _, err := ocsp.ParseResponseForCert(bb, cert, issuer); err != nil {
+			continue
+		}
+		return bb, nil
+	}
+
+	return nil, errors.Errorf("pdfcpu: no OCSP response for: %s", cert.Subject)
+}
+
+// CRLs downloads the CRLs of cert's distribution points.
+func (f HTTPRevocationFetcher) CRLs(cert *x509.Certificate) ([][]byte, error) {
+	if len(cert.CRLDistributionPoints) == 0 {
+		return nil, errors.Errorf("pdfcpu: no CRL distribution point for: %s", cert.Subject)
+	}
+
+	client := f.client()
+
+	var crls [][]byte
+
+	for _, url := range cert.CRLDistributionPoints {
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, err
+		}
+		bb, err := readResponse(resp, url)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := x509.ParseRevocationList(bb); err != nil {
+			return nil, errors.Errorf("pdfcpu: invalid CRL: %s: %v", url, err)
+		}
+		crls = append(crls, bb)
+	}
+
+	return crls, nil
+}
+
+// SignatureCertificates returns the certificates embedded in sigDict.
+func SignatureCertificates(sigDict types.Dict) ([]*x509.Certificate, error) {
+	if sf := sigDict.NameEntry("SubFilter"); sf != nil && *sf == "adbe.x509.rsa_sha1" {
+		return parseP1Certificates(sigDict)
+	}
+
+	p7, err := p7(sigDict)
+	if err != nil {
+		return nil, err
+	}
+
+	return p7.Certificates, nil
+}
+
+// CertificateIssuer returns the issuer of cert contained in certs.
+func CertificateIssuer(cert *x509.Certificate, certs []*x509.Certificate) *x509.Certificate {
+	for _, c := range certs {
+		if bytes.Equal(c.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(c) == nil {
+			return c
+		}
+	}
+	return nil
+}
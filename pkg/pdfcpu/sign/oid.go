@@ -52,4 +52,8 @@ var (
 	oidProofOfCreation         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 6, 6}  // Signer created the content
 	oidRevocationInfoArchival  = asn1.ObjectIdentifier{1, 2, 840, 113583, 1, 1, 8}         // Embedded revocation data, signed
 	oidOCSPNoCheck             = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 5}      // OSCP responder cert extension
+	oidSHA1                    = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}                 // SHA-1 message digest
+	oidSHA256                  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}     // SHA-256 message digest
+	oidSHA384                  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}     // SHA-384 message digest
+	oidSHA512                  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}     // SHA-512 message digest
 )
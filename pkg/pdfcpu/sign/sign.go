@@ -348,11 +348,6 @@ func processDSS(ctx *model.Context, signer *model.Signer) ([]*x509.Certificate,
 		ok = false
 	}
 
-	if _, ok := ctx.DSS.Find("VRI"); ok {
-		signer.AddProblem("DSS: VRI currently unsupported")
-		ok = false
-	}
-
 	return dssCerts, dssCRLs, dssOCSPs, ok
 }
 
@@ -406,7 +401,7 @@ func mergeCerts(certLists ...[]*x509.Certificate) []*x509.Certificate {
 }
 
 func extractCRLsFromDSS(ctx *model.Context) ([][]byte, error) {
-	entry, found := ctx.DSS.Find("CLRs")
+	entry, found := ctx.DSS.Find("CRLs")
 	if !found {
 		return nil, nil
 	}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TSAClient obtains RFC 3161 time-stamp tokens from a time stamping authority.
+type TSAClient interface {
+	// TimeStampToken returns the DER encoded TimeStampToken for digest computed using hash.
+	TimeStampToken(digest []byte, hash crypto.Hash) ([]byte, error)
+}
+
+// HTTPTSAClient is a TSAClient requesting time-stamp tokens via HTTP (RFC 3161 3.4).
+type HTTPTSAClient struct {
+	URL      string
+	Username string // optional basic authentication
+	Password string
+	Timeout  time.Duration // defaults to 10s
+}
+
+type messageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+type timeStampReq struct {
+	Version        int
+	MessageImprint messageImprint
+	Nonce          *big.Int `asn1:"optional"`
+	CertReq        bool     `asn1:"optional,default:false"`
+}
+
+type pkiStatusInfo struct {
+	Status       int
+	StatusString []string       `asn1:"optional,utf8"`
+	FailInfo     asn1.BitString `asn1:"optional"`
+}
+
+type timeStampResp struct {
+	Status         pkiStatusInfo
+	TimeStampToken asn1.RawValue `asn1:"optional"`
+}
+
+func hashOID(hash crypto.Hash) (asn1.ObjectIdentifier, error) {
+	switch hash {
+	case crypto.SHA1:
+		return oidSHA1, nil
+	case crypto.SHA256:
+		return oidSHA256, nil
+	case crypto.SHA384:
+		return oidSHA384, nil
+	case crypto.SHA512:
+		return oidSHA512, nil
+	}
+	return nil, errors.Errorf("pdfcpu: unsupported time-stamp hash algorithm: %s", hash)
+}
+
+// TimeStampRequest returns a DER encoded TimeStampReq for digest computed using hash.
+func TimeStampRequest(digest []byte, hash crypto.Hash) ([]byte, error) {
+	oid, err := hashOID(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(digest) != hash.Size() {
+		return nil, errors.Errorf("pdfcpu: invalid %s digest length: %d", hash, len(digest))
+	}
+
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+
+	req := timeStampReq{
+		Version: 1,
+		MessageImprint: messageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oid},
+			HashedMessage: digest,
+		},
+		Nonce:   nonce,
+		CertReq: true,
+	}
+
+	return asn1.Marshal(req)
+}
+
+// TimeStampTokenFromResponse extracts the TimeStampToken of a DER encoded TimeStampResp.
+func TimeStampTokenFromResponse(bb []byte) ([]byte, error) {
+	var resp timeStampResp
+	if _, err := asn1.Unmarshal(bb, &resp); err != nil {
+		return nil, errors.Errorf("pdfcpu: invalid time-stamp response: %v", err)
+	}
+
+	// 0 = granted, 1 = granted with modifications
+	if resp.Status.Status > 1 {
+		return nil, errors.Errorf("pdfcpu: time-stamp request rejected: status=%d %v", resp.Status.Status, resp.Status.StatusString)
+	}
+
+	if len(resp.TimeStampToken.FullBytes) == 0 {
+		return nil, errors.New("pdfcpu: time-stamp response without token")
+	}
+
+	return resp.TimeStampToken.FullBytes, nil
+}
+
+// TimeStampToken requests a time-stamp token for digest from the TSA at c.URL.
+func (c HTTPTSAClient) TimeStampToken(digest []byte, hash crypto.Hash) ([]byte, error) {
+	if c.URL == "" {
+		return nil, errors.New("pdfcpu: missing TSA URL")
+	}
+
+	bb, err := TimeStampRequest(digest, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewReader(bb))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/timestamp-query")
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	client := &http.Client{Timeout: timeout}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("pdfcpu: TSA %s: %s", c.URL, resp.Status)
+	}
+
+	bb, err = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	return TimeStampTokenFromResponse(bb)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxFileNameLength   = 100
+	defaultSeparatorDPI = 200
+)
+
+// BookmarkSection is a part of a document split along an outline entry.
+type BookmarkSection struct {
+	Title    string
+	FileName string // Sanitized title, unique within a split.
+	From     int
+	Thru     int
+	Ctx      *model.Context
+}
+
+type outlineEntry struct {
+	title string
+	level int
+	page  int
+}
+
+func flattenBookmarks(bms []Bookmark, level int, ee []outlineEntry) []outlineEntry {
+	for _, bm := range bms {
+		if bm.PageFrom > 0 {
+			ee = append(ee, outlineEntry{title: bm.Title, level: level, page: bm.PageFrom})
+		}
+		ee = flattenBookmarks(bm.Kids, level+1, ee)
+	}
+	return ee
+}
+
+// SanitizeFileName turns s into a portable file name.
+func SanitizeFileName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.IsSpace(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, s)
+
+	for strings.Contains(s, "__") {
+		s = strings.ReplaceAll(s, "__", "_")
+	}
+
+	s = strings.Trim(s, "._")
+
+	if rr := []rune(s); len(rr) > maxFileNameLength {
+		s = string(rr[:maxFileNameLength])
+	}
+
+	return s
+}
+
+// bookmarkSections returns sections for all outline entries of level with their page ranges.
+// A section reaches until before the next outline entry of the same or a higher level.
+func bookmarkSections(ee []outlineEntry, level, pageCount int) []*BookmarkSection {
+	bss := []*BookmarkSection{}
+	used := map[string]bool{}
+
+	for i, e := range ee {
+		if e.level != level {
+			continue
+		}
+
+		thru := pageCount
+		for _, e1 := range ee[i+1:] {
+			if e1.level <= level {
+				thru = e1.page - 1
+				break
+			}
+		}
+		if thru < e.page {
+			// Sections starting on the same page.
+			thru = e.page
+		}
+
+		fn := SanitizeFileName(e.title)
+		if fn == "" {
+			fn = fmt.Sprintf("bookmark_%d", len(bss)+1)
+		}
+		fn1 := fn
+		for j := 2; used[strings.ToLower(fn1)]; j++ {
+			fn1 = fmt.Sprintf("%s_%d", fn, j)
+		}
+		used[strings.ToLower(fn1)] = true
+
+		bss = append(bss, &BookmarkSection{Title: e.title, FileName: fn1, From: e.page, Thru: thru})
+	}
+
+	return bss
+}
+
+// SplitByBookmarks splits ctx along the outline entries of level, starting with 1 for the top level.
+func SplitByBookmarks(ctx *model.Context, level int) ([]*BookmarkSection, error) {
+	if level < 1 {
+		return nil, errors.Errorf("pdfcpu: invalid outline level: %d", level)
+	}
+
+	bms, err := Bookmarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(bms) == 0 {
+		return nil, errNoBookmarks
+	}
+
+	bss := bookmarkSections(flattenBookmarks(bms, 1, nil), level, ctx.PageCount)
+	if len(bss) == 0 {
+		return nil, errors.Errorf("pdfcpu: no bookmarks at outline level %d", level)
+	}
+
+	for _, bs := range bss {
+		pageNrs := make([]int, 0, bs.Thru-bs.From+1)
+		for i := bs.From; i <= bs.Thru; i++ {
+			pageNrs = append(pageNrs, i)
+		}
+		if bs.Ctx, err = ExtractPages(ctx, pageNrs, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return bss, nil
+}
+
+// SeparatorCriteria identifies separator pages eg. patch sheets of scanned mail.
+type SeparatorCriteria struct {
+	Text    *regexp.Regexp // Separator pages contain matching text.
+	Barcode string         // Separator pages carry a Code 39 barcode with this value.
+	DPI     float64        // Resolution used for barcode detection, default 200.
+	Drop    bool           // Drop separator pages from the resulting sections.
+}
+
+// PageSection is a span of pages extracted into a context of its own.
+type PageSection struct {
+	From int
+	Thru int
+	Ctx  *model.Context
+}
+
+func isSeparatorPage(ctx *model.Context, pageNr int, sc *SeparatorCriteria) (bool, error) {
+	if sc.Text != nil {
+		s, err := ExtractPageText(ctx, pageNr)
+		if err != nil {
+			return false, err
+		}
+		if sc.Text.MatchString(s) {
+			return true, nil
+		}
+	}
+
+	if sc.Barcode == "" {
+		return false, nil
+	}
+
+	dpi := sc.DPI
+	if dpi == 0 {
+		dpi = defaultSeparatorDPI
+	}
+
+	img, err := RenderPage(ctx, pageNr, dpi)
+	if err != nil {
+		return false, err
+	}
+
+	for _, s := range DecodeCode39(img) {
+		if s == sc.Barcode {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// SeparatorPages returns the numbers of all pages of ctx matching sc.
+func SeparatorPages(ctx *model.Context, sc *SeparatorCriteria) ([]int, error) {
+	if sc == nil || sc.Text == nil && sc.Barcode == "" {
+		return nil, errors.New("pdfcpu: missing separator criteria")
+	}
+
+	pageNrs := []int{}
+	for i := 1; i <= ctx.PageCount; i++ {
+		ok, err := isSeparatorPage(ctx, i, sc)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+
+	return pageNrs, nil
+}
+
+// SplitBySeparator splits ctx at pages matching sc.
+// Each separator page starts a new section unless separators get dropped.
+func SplitBySeparator(ctx *model.Context, sc *SeparatorCriteria) ([]*PageSection, error) {
+	seps, err := SeparatorPages(ctx, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	isSep := types.IntSet{}
+	for _, i := range seps {
+		isSep[i] = true
+	}
+
+	pss := []*PageSection{}
+	var pageNrs []int
+
+	flush := func() error {
+		if len(pageNrs) == 0 {
+			return nil
+		}
+		ctxNew, err := ExtractPages(ctx, pageNrs, false)
+		if err != nil {
+			return err
+		}
+		pss = append(pss, &PageSection{From: pageNrs[0], Thru: pageNrs[len(pageNrs)-1], Ctx: ctxNew})
+		pageNrs = nil
+		return nil
+	}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if isSep[i] {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			if sc.Drop {
+				continue
+			}
+		}
+		pageNrs = append(pageNrs, i)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return pss, nil
+}
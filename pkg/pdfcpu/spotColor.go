@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Colorant represents a colorant used by Separation or DeviceN color spaces.
+type Colorant struct {
+	Name       string   `json:"name"`
+	Process    bool     `json:"process"`    // Cyan, Magenta, Yellow or Black
+	Alternates []string `json:"alternates"` // alternate color spaces
+	Separation int      `json:"separation"` // number of Separation color spaces using this colorant
+	DeviceN    int      `json:"deviceN"`    // number of DeviceN color spaces using this colorant
+}
+
+// walkColorSpaces calls fn for all Separation and DeviceN color space arrays of ctx
+// used in resources, shadings, images or as base of Indexed and Pattern color spaces.
+func walkColorSpaces(ctx *model.Context, fn func(a types.Array) error) error {
+	var walk func(o types.Object) error
+	walk = func(o types.Object) error {
+		switch o := o.(type) {
+		case types.StreamDict:
+			return walk(o.Dict)
+		case types.Dict:
+			for _, v := range o {
+				if err := walk(v); err != nil {
+					return err
+				}
+			}
+		case types.Array:
+			if len(o) >= 4 && (o[0] == types.Name(model.SeparationCS) || o[0] == types.Name(model.DeviceNCS)) {
+				if err := fn(o); err != nil {
+					return err
+				}
+			}
+			for _, v := range o {
+				if err := walk(v); err != nil {
+					return err
+				}
+			}
+		}
+		// Indirect objects are visited separately.
+		return nil
+	}
+
+	for _, objNr := range objNrsInUse(ctx) {
+		if err := walk(ctx.Table[objNr].Object); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isColorant(s string) bool {
+	return s != "All" && s != "None"
+}
+
+// colorantNames returns the colorant names of Separation or DeviceN color space a.
+func colorantNames(ctx *model.Context, a types.Array) (types.Array, error) {
+	if a[0] == types.Name(model.SeparationCS) {
+		return types.Array{a[1]}, nil
+	}
+	return ctx.DereferenceArray(a[1])
+}
+
+func alternateName(ctx *model.Context, o types.Object) string {
+	o, err := ctx.Dereference(o)
+	if err != nil || o == nil {
+		return ""
+	}
+	switch o := o.(type) {
+	case types.Name:
+		return o.Value()
+	case types.Array:
+		if len(o) > 0 {
+			if n, ok := o[0].(types.Name); ok {
+				return n.Value()
+			}
+		}
+	}
+	return ""
+}
+
+// Colorants returns all colorants of Separation and DeviceN color spaces of ctx sorted by name.
+func Colorants(ctx *model.Context) ([]Colorant, error) {
+	m := map[string]*Colorant{}
+
+	err := walkColorSpaces(ctx, func(a types.Array) error {
+		nn, err := colorantNames(ctx, a)
+		if err != nil || nn == nil {
+			return err
+		}
+		alt := alternateName(ctx, a[2])
+		for _, o := range nn {
+			n, ok := o.(types.Name)
+			if !ok || !isColorant(n.Value()) {
+				continue
+			}
+			c, ok := m[n.Value()]
+			if !ok {
+				c = &Colorant{Name: n.Value(), Process: types.MemberOf(n.Value(), processColorants)}
+				m[n.Value()] = c
+			}
+			if alt != "" && !types.MemberOf(alt, c.Alternates) {
+				c.Alternates = append(c.Alternates, alt)
+			}
+			if a[0] == types.Name(model.SeparationCS) {
+				c.Separation++
+			} else {
+				c.DeviceN++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cc := make([]Colorant, 0, len(m))
+	for _, c := range m {
+		sort.Strings(c.Alternates)
+		cc = append(cc, *c)
+	}
+	sort.Slice(cc, func(i, j int) bool { return cc[i].Name < cc[j].Name })
+
+	return cc, nil
+}
+
+// ListColorants returns a formatted list of colorants.
+func ListColorants(ctx *model.Context) ([]string, error) {
+	cc, err := Colorants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cc) == 0 {
+		return []string{"no colorants available"}, nil
+	}
+
+	ss := []string{fmt.Sprintf("%d colorants available", len(cc))}
+	for _, c := range cc {
+		ss = append(ss, fmt.Sprintf("%s (alternate: %v, Separation: %d, DeviceN: %d)", c.Name, c.Alternates, c.Separation, c.DeviceN))
+	}
+
+	return ss, nil
+}
+
+// RenameColorant renames colorant oldName to newName in all Separation and DeviceN color spaces
+// including the colorants dictionary of DeviceN attributes and returns the number of color spaces affected.
+func RenameColorant(ctx *model.Context, oldName, newName string) (int, error) {
+	if oldName == "" || newName == "" {
+		return 0, errors.New("pdfcpu: RenameColorant: missing colorant name")
+	}
+
+	for _, s := range []string{oldName, newName} {
+		if !isColorant(s) {
+			return 0, errors.Errorf("pdfcpu: RenameColorant: invalid colorant: %s", s)
+		}
+	}
+
+	var count int
+
+	err := walkColorSpaces(ctx, func(a types.Array) error {
+		if a[0] == types.Name(model.SeparationCS) {
+			if a[1] == types.Name(oldName) {
+				a[1] = types.Name(newName)
+				count++
+			}
+			return nil
+		}
+
+		nn, err := ctx.DereferenceArray(a[1])
+		if err != nil || nn == nil {
+			return err
+		}
+
+		var found bool
+		for i, o := range nn {
+			if o == types.Name(oldName) {
+				nn[i] = types.Name(newName)
+				found = true
+			}
+		}
+		if !found {
+			return nil
+		}
+		count++
+
+		if len(a) < 5 {
+			return nil
+		}
+		attrs, err := ctx.DereferenceDict(a[4])
+		if err != nil || attrs == nil {
+			return err
+		}
+		colorants, err := ctx.DereferenceDict(attrs["Colorants"])
+		if err != nil || colorants == nil {
+			return err
+		}
+		if o, ok := colorants[oldName]; ok {
+			delete(colorants, oldName)
+			colorants[newName] = o
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+func tintTransform(altCS string, comps []float64) types.Dict {
+	c0 := make([]float64, len(comps))
+	if altCS != model.DeviceCMYKCS {
+		// No tint results in white.
+		for i := range c0 {
+			c0[i] = 1
+		}
+	}
+
+	return types.Dict(map[string]types.Object{
+		"FunctionType": types.Integer(2),
+		"Domain":       types.NewNumberArray(0, 1),
+		"C0":           types.NewNumberArray(c0...),
+		"C1":           types.NewNumberArray(comps...),
+		"N":            types.Float(1),
+	})
+}
+
+// RemapColorant sets the alternate color space of all Separation color spaces for colorant name
+// to altCS (DeviceGray, DeviceRGB or DeviceCMYK) using comps as the equivalent of a full tint
+// and returns the number of color spaces affected.
+// DeviceN color spaces combining name with other colorants are left untouched.
+func RemapColorant(ctx *model.Context, name, altCS string, comps []float64) (int, error) {
+	n, ok := colorConversionTargets[altCS]
+	if !ok {
+		return 0, errors.Errorf("pdfcpu: RemapColorant: unsupported alternate color space: %s", altCS)
+	}
+
+	if len(comps) != n {
+		return 0, errors.Errorf("pdfcpu: RemapColorant: %s needs %d components", altCS, n)
+	}
+
+	for _, f := range comps {
+		if f < 0 || f > 1 {
+			return 0, errors.Errorf("pdfcpu: RemapColorant: color components must be within 0 and 1: %v", comps)
+		}
+	}
+
+	var count int
+
+	err := walkColorSpaces(ctx, func(a types.Array) error {
+		nn, err := colorantNames(ctx, a)
+		if err != nil || nn == nil {
+			return err
+		}
+
+		if len(nn) != 1 {
+			for _, o := range nn {
+				if o == types.Name(name) && log.InfoEnabled() {
+					log.Info.Printf("RemapColorant: skipping DeviceN color space for %s\n", name)
+				}
+			}
+			return nil
+		}
+
+		if nn[0] != types.Name(name) {
+			return nil
+		}
+
+		a[2] = types.Name(altCS)
+		a[3] = tintTransform(altCS, comps)
+		count++
+
+		return nil
+	})
+
+	return count, err
+}
@@ -18,11 +18,14 @@ package pdfcpu
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode/utf16"
@@ -42,6 +45,9 @@ import (
 
 const stampWithBBox = false
 
+// Watermarks are marked content sequences optionally carrying the hex encoded watermark id.
+var reWMArtifact = regexp.MustCompile(`/Artifact <</Subtype /Watermark /Type /Pagination (?:/WatermarkID <([0-9a-fA-F]*)> )?>>BDC`)
+
 var (
 	errNoWatermark        = errors.New("pdfcpu: no watermarks found")
 	errCorruptOCGs        = errors.New("pdfcpu: OCProperties: corrupt OCGs element")
@@ -102,11 +108,14 @@ var wmParamMap = watermarkParamMap{
 	"diagonal":        parseDiagonal,
 	"fillcolor":       parseFillColor,
 	"fontname":        parseFontName,
+	"gap":             parseTileGap,
+	"gradient":        parseGradient,
 	"scriptname":      parseScriptName,
 	"margins":         parseMargins,
 	"mode":            parseRenderMode,
 	"offset":          parsePositionOffsetWM,
 	"opacity":         parseOpacity,
+	"path":            parseTextPath,
 	"points":          parseFontSize,
 	"position":        parsePositionAnchorWM,
 	"rendermode":      parseRenderMode,
@@ -114,6 +123,7 @@ var wmParamMap = watermarkParamMap{
 	"rotation":        parseRotation,
 	"scalefactor":     parseScaleFactorWM,
 	"strokecolor":     parseStrokeColor,
+	"tile":            parseTile,
 	"url":             parseURL,
 }
 
@@ -277,6 +287,54 @@ func parseRightToLeft(s string, wm *model.Watermark) error {
 	return nil
 }
 
+// parseTile parses on/off or the stagger of every other tile row, which implies tiling.
+func parseTile(s string, wm *model.Watermark) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		wm.Tile = true
+		return nil
+	case "off", "false", "f":
+		wm.Tile = false
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || f >= 1 {
+		return errors.New("pdfcpu: tile, please provide one of: on/off true/false t/f or a row stagger: 0.0 <= x < 1.0")
+	}
+
+	wm.Tile = true
+	wm.TileStagger = f
+
+	return nil
+}
+
+func parseTileGap(s string, wm *model.Watermark) error {
+	d := strings.Split(s, " ")
+	if len(d) == 0 || len(d) > 2 {
+		return errors.Errorf("pdfcpu: illegal tile gap string: need 1 or 2 numeric values, %s\n", s)
+	}
+
+	f1, err := strconv.ParseFloat(d[0], 64)
+	if err != nil {
+		return err
+	}
+	f2 := f1
+	if len(d) == 2 {
+		if f2, err = strconv.ParseFloat(d[1], 64); err != nil {
+			return err
+		}
+	}
+	if f1 < 0 || f2 < 0 {
+		return errors.Errorf("pdfcpu: illegal tile gap: need values >= 0, %s\n", s)
+	}
+
+	wm.TileGapX = types.ToUserSpace(f1, wm.InpUnit)
+	wm.TileGapY = types.ToUserSpace(f2, wm.InpUnit)
+
+	return nil
+}
+
 func parseStrokeColor(s string, wm *model.Watermark) error {
 	c, err := color.ParseColor(s)
 	if err != nil {
@@ -295,6 +353,31 @@ func parseFillColor(s string, wm *model.Watermark) error {
 	return nil
 }
 
+func parseGradient(s string, wm *model.Watermark) error {
+	fp, err := model.ParseFillPattern(s)
+	if err != nil {
+		return err
+	}
+	wm.FillPattern = fp
+	return nil
+}
+
+func parseTextPath(s string, wm *model.Watermark) error {
+	ss := strings.Fields(strings.ToLower(s))
+	if len(ss) != 2 || ss[0] != "circle" {
+		return errors.Errorf("pdfcpu: illegal text path: need \"circle radius\", %s\n", s)
+	}
+
+	r, err := strconv.ParseFloat(ss[1], 64)
+	if err != nil || r <= 0 {
+		return errors.Errorf("pdfcpu: illegal circle radius: need value > 0, %s\n", ss[1])
+	}
+
+	wm.CircleRadius = types.ToUserSpace(r, wm.InpUnit)
+
+	return nil
+}
+
 func parseBackgroundColor(s string, wm *model.Watermark) error {
 	c, err := color.ParseColor(s)
 	if err != nil {
@@ -676,7 +759,7 @@ func createPDFRes(ctx, otherCtx *model.Context, pageNrSrc, pageNrDest int, migra
 	}
 
 	// Take into account existing rotation.
-	wm.Rotation -= float64(inhPAttrs.Rotate % 360)
+	pdfRes.Rot = inhPAttrs.Rotate % 360
 
 	// Retrieve content stream bytes of page dict.
 	pdfRes.Content, err = otherXRefTable.PageContent(d, pageNrSrc)
@@ -723,7 +806,7 @@ func createPDFResForWM(ctx *model.Context, wm *model.Watermark) error {
 	}
 
 	if err := otherCtx.EnsurePageCount(); err != nil {
-		return nil
+		return err
 	}
 
 	migrated := map[int]int{}
@@ -732,14 +815,30 @@ func createPDFResForWM(ctx *model.Context, wm *model.Watermark) error {
 		return createPDFRes(ctx, otherCtx, wm.PdfPageNrSrc, wm.PdfPageNrSrc, migrated, wm)
 	}
 
+	endPageNrSrc := otherCtx.PageCount
+	if wm.PdfMultiEndPageNrSrc > 0 {
+		endPageNrSrc = wm.PdfMultiEndPageNrSrc
+	}
+	if endPageNrSrc > otherCtx.PageCount || wm.PdfMultiStartPageNrSrc < 1 || wm.PdfMultiStartPageNrSrc > endPageNrSrc {
+		return errors.Errorf("pdfcpu: invalid source page range %d-%d, available: 1-%d", wm.PdfMultiStartPageNrSrc, endPageNrSrc, otherCtx.PageCount)
+	}
+
 	destPageNr := wm.PdfMultiStartPageNrDest
-	for srcPageNr := wm.PdfMultiStartPageNrSrc; srcPageNr <= min(ctx.PageCount, otherCtx.PageCount); srcPageNr++ {
+	for srcPageNr := wm.PdfMultiStartPageNrSrc; srcPageNr <= endPageNrSrc && destPageNr <= ctx.PageCount; srcPageNr++ {
 		if err := createPDFRes(ctx, otherCtx, srcPageNr, destPageNr, migrated, wm); err != nil {
 			return err
 		}
 		destPageNr++
 	}
 
+	if fb := wm.PdfMultiFallbackPageNr; fb > 0 {
+		if fb > otherCtx.PageCount {
+			return errors.Errorf("pdfcpu: invalid fallback page %d, available: 1-%d", fb, otherCtx.PageCount)
+		}
+		// The fallback page is kept at index 0.
+		return createPDFRes(ctx, otherCtx, fb, 0, migrated, wm)
+	}
+
 	return nil
 }
 
@@ -918,6 +1017,15 @@ func createFormResDict(ctx *model.Context, pageNr int, wm *model.Watermark) (*ty
 		},
 	)
 
+	if wm.FillPattern != nil {
+		// The pattern spans the form.
+		ir, err := ctx.NewFillPattern(*wm.FillPattern, types.RectForDim(wm.Bb.Width(), wm.Bb.Height()), matrix.IdentMatrix)
+		if err != nil {
+			return nil, err
+		}
+		d["Pattern"] = types.Dict{"P0": *ir}
+	}
+
 	return ctx.IndRefForNewObject(d)
 }
 
@@ -982,6 +1090,10 @@ func setupTextDescriptor(wm model.Watermark, timestampFormat string, pageNr, pag
 	td, unique := textDescriptor(wm, timestampFormat, pageNr, pageCount)
 	td.X, td.Y, td.HAlign, td.VAlign, td.FontKey = x, y, hAlign, vAlign, "F1"
 
+	if wm.FillPattern != nil {
+		td.FillPattern = "P0"
+	}
+
 	// Set right to left rendering.
 	td.RTL = wm.RTL
 
@@ -1022,27 +1134,94 @@ func drawBoundingBox(b *bytes.Buffer, wm model.Watermark, bb *types.Rectangle) {
 	)
 }
 
-func calcFormBoundingBox(xRefTable *model.XRefTable, w io.Writer, timestampFormat string, pageNr, pageCount int, wm *model.Watermark) bool {
+// writeCircleText renders the first line of td along the upper half and the second line along the lower half
+// of a circle of radius wm.CircleRadius like a seal and returns the bounding box.
+// A border is rendered as rings enclosing the text.
+func writeCircleText(xRefTable *model.XRefTable, w io.Writer, td model.TextDescriptor, wm model.Watermark) (*types.Rectangle, error) {
+	rad := wm.CircleRadius
+
+	gap := 0.
+	if wm.BorderColor != nil {
+		gap = 2 + wm.BorderWidth
+	}
+
+	// Scale the seal like a single text line.
+	k := td.Scale
+	if !td.ScaleAbs {
+		ext := rad + font.LineHeight(td.FontName, td.FontSize) + gap
+		k = wm.Vp.Width() * td.Scale / (2 * ext)
+	}
+	td.FontSize = max(int(float64(td.FontSize)*k), 1)
+	rad *= k
+
+	asc, desc := font.Ascent(td.FontName, td.FontSize), font.Descent(td.FontName, td.FontSize)
+	ext := rad + asc + gap
+	c := types.Point{X: ext, Y: ext}
+	bb := types.RectForDim(2*ext, 2*ext)
+
+	if wm.BgColor != nil {
+		draw.DrawBorderedEllipse(w, bb, draw.Border{}, wm.BgColor)
+	}
+
+	if wm.BorderColor != nil {
+		b := draw.Border{Width: wm.BorderWidth, Color: wm.BorderColor}
+		for _, r := range []float64{ext - wm.BorderWidth/2, rad - desc - 2 - wm.BorderWidth/2} {
+			if r > 0 {
+				draw.DrawBorderedEllipse(w, types.NewRectangle(c.X-r, c.Y-r, c.X+r, c.Y+r), b, nil)
+			}
+		}
+	}
+
+	lines := model.SplitMultilineStr(td.Text)
+
+	for i, p := range []model.TextPath{
+		{Center: c, Radius: rad, Angle: 90},
+		{Center: c, Radius: rad + asc - desc, Angle: 270, Inside: true},
+	} {
+		if i == len(lines) {
+			break
+		}
+		td.Text = lines[i]
+		if _, err := model.WriteTextOnPath(xRefTable, w, td, p); err != nil {
+			return nil, err
+		}
+	}
+
+	return bb, nil
+}
+
+func calcFormBoundingBox(xRefTable *model.XRefTable, w io.Writer, timestampFormat string, pageNr, pageCount int, wm *model.Watermark) (bool, error) {
 	var unique bool
 	if wm.IsImage() || wm.IsPDF() {
 		wm.CalcBoundingBox(pageNr)
 	} else {
 		var td model.TextDescriptor
 		td, unique = setupTextDescriptor(*wm, timestampFormat, pageNr, pageCount)
+		if wm.CircleRadius > 0 {
+			bb, err := writeCircleText(xRefTable, w, td, *wm)
+			if err != nil {
+				return false, err
+			}
+			wm.Bb = bb
+			return unique, nil
+		}
 		// Render td into b and return the bounding box.
 		wm.Bb = model.WriteMultiLine(xRefTable, w, types.RectForDim(wm.Vp.Width(), wm.Vp.Height()), nil, td)
 	}
-	return unique
+	return unique, nil
 }
 
 func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark, withBB bool) error {
 	var b bytes.Buffer
-	unique := calcFormBoundingBox(ctx.XRefTable, &b, ctx.Configuration.TimestampFormat, pageNr, pageCount, wm)
+	unique, err := calcFormBoundingBox(ctx.XRefTable, &b, ctx.Configuration.TimestampFormat, pageNr, pageCount, wm)
+	if err != nil {
+		return err
+	}
 
 	// The forms bounding box is dependent on the page dimensions.
 	bb := wm.Bb
 
-	maxStampPageNr := wm.PdfMultiStartPageNrDest + len(wm.PdfRes) - 1
+	maxStampPageNr := wm.MaxStampPageNr()
 
 	if !unique && (cachedForm(*wm) || pageNr > maxStampPageNr) {
 		// Use cached form.
@@ -1103,7 +1282,7 @@ func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark,
 
 	wm.Form = ir
 
-	if cachedForm(*wm) || pageNr >= len(wm.PdfRes) {
+	if cachedForm(*wm) || pageNr > maxStampPageNr {
 		// Cache form.
 		wm.FCache[*wm.Bb] = ir
 	}
@@ -1111,16 +1290,111 @@ func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark,
 	return nil
 }
 
-func createExtGStateForStamp(ctx *model.Context, opacity float64) (*types.IndirectRef, error) {
-	d := types.Dict(
-		map[string]types.Object{
-			"Type": types.Name("ExtGState"),
-			"CA":   types.Float(opacity),
-			"ca":   types.Float(opacity),
-		},
-	)
+// tilingPatternContent returns the content of a pattern cell of width w plus gap dx and height h plus gap dy
+// with every other row shifted by stagger times the horizontal step.
+func tilingPatternContent(w, h, dx, dy, stagger float64) (xStep, yStep float64, content []byte) {
+	xStep, yStep = w+dx, h+dy
 
-	return ctx.IndRefForNewObject(d)
+	var b bytes.Buffer
+	b.WriteString("/Fm0 Do ")
+
+	if stagger > 0 {
+		// The second row is drawn twice to cover the cell after clipping.
+		x := stagger * xStep
+		fmt.Fprintf(&b, "q 1 0 0 1 %.2f %.2f cm /Fm0 Do Q ", x, yStep)
+		fmt.Fprintf(&b, "q 1 0 0 1 %.2f %.2f cm /Fm0 Do Q ", x-xStep, yStep)
+		yStep *= 2
+	}
+
+	return xStep, yStep, b.Bytes()
+}
+
+// createTiledForm replaces wm.Form by a form covering the page and painted with a tiling pattern
+// repeating the original form.
+func createTiledForm(ctx *model.Context, wm *model.Watermark) error {
+	if ir := wm.CachedTileForm(); ir != nil {
+		wm.Form = ir
+		return nil
+	}
+
+	xStep, yStep, content := tilingPatternContent(wm.Bb.Width(), wm.Bb.Height(), wm.TileGapX, wm.TileGapY, wm.TileStagger)
+	if xStep <= 0 || yStep <= 0 {
+		return errors.New("pdfcpu: tiling watermark: empty tile")
+	}
+
+	// The first tile is positioned like the plain watermark.
+	m := wm.CalcTransformMatrix()
+
+	pattern := types.StreamDict{
+		Dict: types.Dict(
+			map[string]types.Object{
+				"Type":        types.Name("Pattern"),
+				"PatternType": types.Integer(1),
+				"PaintType":   types.Integer(1),
+				"TilingType":  types.Integer(1),
+				"BBox":        types.NewNumberArray(0, 0, xStep, yStep),
+				"XStep":       types.Float(xStep),
+				"YStep":       types.Float(yStep),
+				"Matrix":      types.NewNumberArray(m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1]),
+				"Resources": types.Dict(map[string]types.Object{
+					"XObject": types.Dict(map[string]types.Object{"Fm0": *wm.Form}),
+				}),
+			},
+		),
+		Content:        content,
+		FilterPipeline: []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}},
+	}
+	pattern.InsertName("Filter", filter.Flate)
+
+	if err := pattern.Encode(); err != nil {
+		return err
+	}
+
+	patternIndRef, err := ctx.IndRefForNewObject(pattern)
+	if err != nil {
+		return err
+	}
+
+	vp := wm.Vp
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "/Pattern cs /P0 scn %.2f %.2f %.2f %.2f re f", vp.LL.X, vp.LL.Y, vp.Width(), vp.Height())
+
+	sd := types.StreamDict{
+		Dict: types.Dict(
+			map[string]types.Object{
+				"Type":    types.Name("XObject"),
+				"Subtype": types.Name("Form"),
+				"BBox":    vp.Array(),
+				"Matrix":  types.NewNumberArray(1, 0, 0, 1, 0, 0),
+				"OC":      *wm.Ocg,
+				"Resources": types.Dict(map[string]types.Object{
+					"Pattern": types.Dict(map[string]types.Object{"P0": *patternIndRef}),
+				}),
+			},
+		),
+		Content:        b.Bytes(),
+		FilterPipeline: []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}},
+	}
+	sd.InsertName("Filter", filter.Flate)
+
+	if err = sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(sd)
+	if err != nil {
+		return err
+	}
+
+	wm.CacheTileForm(ir)
+	wm.Form = ir
+
+	return nil
+}
+
+func createExtGStateForStamp(ctx *model.Context, opacity float64) (*types.IndirectRef, error) {
+	return ctx.EnsureExtGStateFor(model.Opacity(opacity, opacity))
 }
 
 func insertPageResourcesForWM(pageDict types.Dict, wm model.Watermark, gsID, xoID string) error {
@@ -1170,14 +1444,23 @@ func updatePageResourcesForWM(ctx *model.Context, resDict types.Dict, wm model.W
 
 func wmContent(wm *model.Watermark, gsID, xoID string) []byte {
 	m := wm.CalcTransformMatrix()
-	p1 := m.Transform(types.Point{X: wm.Bb.LL.X, Y: wm.Bb.LL.Y})
-	p2 := m.Transform(types.Point{X: wm.Bb.UR.X, Y: wm.Bb.LL.Y})
-	p3 := m.Transform(types.Point{X: wm.Bb.UR.X, Y: wm.Bb.UR.Y})
-	p4 := m.Transform(types.Point{X: wm.Bb.LL.X, Y: wm.Bb.UR.Y})
+	bb := wm.Bb
+	if wm.Tile {
+		// The tiled form covers the page.
+		m, bb = matrix.IdentMatrix, wm.Vp
+	}
+	p1 := m.Transform(types.Point{X: bb.LL.X, Y: bb.LL.Y})
+	p2 := m.Transform(types.Point{X: bb.UR.X, Y: bb.LL.Y})
+	p3 := m.Transform(types.Point{X: bb.UR.X, Y: bb.UR.Y})
+	p4 := m.Transform(types.Point{X: bb.LL.X, Y: bb.UR.Y})
 	wm.BbTrans = types.QuadLiteral{P1: p1, P2: p2, P3: p3, P4: p4}
-	insertOCG := " /Artifact <</Subtype /Watermark /Type /Pagination >>BDC q %.5f %.5f %.5f %.5f %.5f %.5f cm /%s gs /%s Do Q EMC "
+	insertOCG := " /Artifact <</Subtype /Watermark /Type /Pagination %s>>BDC q %.5f %.5f %.5f %.5f %.5f %.5f cm /%s gs /%s Do Q EMC "
+	var id string
+	if wm.ID != "" {
+		id = "/WatermarkID <" + hex.EncodeToString([]byte(wm.ID)) + "> "
+	}
 	var b bytes.Buffer
-	fmt.Fprintf(&b, insertOCG, m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1], gsID, xoID)
+	fmt.Fprintf(&b, insertOCG, id, m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1], gsID, xoID)
 	return b.Bytes()
 }
 
@@ -1358,6 +1641,14 @@ func viewPort(a *model.InheritedPageAttrs) *types.Rectangle {
 	return visibleRegion
 }
 
+// linkID returns the id of the link annotation overlaying the stamp identified by id.
+func linkID(id string) string {
+	if id == "" {
+		return "pdfcpu"
+	}
+	return "pdfcpu:" + id
+}
+
 func handleLink(ctx *model.Context, pageIndRef *types.IndirectRef, d types.Dict, pageNr int, wm model.Watermark) error {
 	if !wm.OnTop || wm.URL == "" {
 		return nil
@@ -1367,7 +1658,7 @@ func handleLink(ctx *model.Context, pageIndRef *types.IndirectRef, d types.Dict,
 		*wm.BbTrans.EnclosingRectangle(5.0), // rect
 		0,                                   // apObjNr
 		"",                                  // contents
-		"pdfcpu",                            // id
+		linkID(wm.ID),                       // id
 		"",                                  // modDate
 		model.AnnNoZoom+model.AnnNoRotate,   // f
 		&color.Red,                          // borderCol
@@ -1393,11 +1684,20 @@ func addPageWatermark(ctx *model.Context, pageNr int, wm model.Watermark) error
 		log.Debug.Printf("addPageWatermark page:%d\n", pageNr)
 	}
 
+	if !wm.PdfResAvailable(pageNr) {
+		// Nothing to stamp onto this page.
+		return nil
+	}
+
+	if wm.IsPDF() {
+		wm.Rotation -= float64(wm.PdfRes[wm.PdfResIndex(pageNr)].Rot)
+	}
+
 	if wm.Update {
 		if log.DebugEnabled() {
 			log.Debug.Println("Updating")
 		}
-		if _, err := removePageWatermark(ctx, pageNr); err != nil {
+		if _, err := removePageWatermark(ctx, pageNr, wm.ID); err != nil {
 			return err
 		}
 	}
@@ -1429,6 +1729,12 @@ func addPageWatermark(ctx *model.Context, pageNr int, wm model.Watermark) error
 		return err
 	}
 
+	if wm.Tile {
+		if err = createTiledForm(ctx, &wm); err != nil {
+			return err
+		}
+	}
+
 	if log.DebugEnabled() {
 		log.Debug.Printf("\n%s\n", wm)
 	}
@@ -1498,48 +1804,73 @@ func AddWatermarks(ctx *model.Context, selectedPages types.IntSet, wm *model.Wat
 	return nil
 }
 
-// AddWatermarksMap adds watermarks in m to corresponding pages.
-func AddWatermarksMap(ctx *model.Context, m map[int]*model.Watermark) error {
-	if len(m) == 0 {
+// prepareWatermarks creates the resources needed by wms.
+// All watermarks share the pdfcpu OCG, watermarks of equal opacity share an ExtGState (see EnsureExtGState)
+// and watermarks referenced more than once are set up only once.
+func prepareWatermarks(ctx *model.Context, wms []*model.Watermark, fonts map[string]types.IndirectRef) error {
+	if len(wms) == 0 {
 		return errors.Errorf("pdfcpu: no watermarks available")
 	}
 
-	// Calc onTop and opacity.
-	// All watermarks are assumed to have the same onTop and opacity.
-	var (
-		onTop   bool
-		opacity float64
-	)
-	for _, wm := range m {
-		onTop = wm.OnTop
-		opacity = wm.Opacity
-		break
+	// The pdfcpu OCG is set up either for stamps or for watermarks.
+	for _, wm := range wms[1:] {
+		if wm.OnTop != wms[0].OnTop {
+			return errors.New("pdfcpu: cannot mix stamps and watermarks")
+		}
 	}
 
-	ocgIndRef, err := prepareOCPropertiesInRoot(ctx, onTop)
+	ocgIndRef, err := prepareOCPropertiesInRoot(ctx, wms[0].OnTop)
 	if err != nil {
 		return err
 	}
 
-	extGStateIndRef, err := createExtGStateForStamp(ctx, opacity)
-	if err != nil {
-		return err
-	}
+	done := map[*model.Watermark]bool{}
 
-	fonts := map[string]types.IndirectRef{}
+	for _, wm := range wms {
+		if done[wm] {
+			continue
+		}
+		done[wm] = true
+
+		wm.Ocg = ocgIndRef
+
+		if wm.ExtGState, err = createExtGStateForStamp(ctx, wm.Opacity); err != nil {
+			return err
+		}
 
-	for _, wm := range m {
 		if err := createResourcesForWM(ctx, wm, fonts); err != nil {
 			return err
 		}
 	}
 
+	return nil
+}
+
+// AddWatermarksMap adds watermarks in m to corresponding pages.
+// Each watermark may come with its own content, position, rotation and opacity.
+// All watermarks in m need to be either stamps or watermarks.
+func AddWatermarksMap(ctx *model.Context, m map[int]*model.Watermark) error {
+	pageNrs := make([]int, 0, len(m))
+	wms := make([]*model.Watermark, 0, len(m))
 	for pageNr, wm := range m {
-		wm.Ocg = ocgIndRef
-		wm.ExtGState = extGStateIndRef
-		wm.OnTop = onTop
-		wm.Opacity = opacity
-		if err := addPageWatermark(ctx, pageNr, *wm); err != nil {
+		if wm == nil {
+			return errors.Errorf("pdfcpu: page %d: missing watermark", pageNr)
+		}
+		pageNrs = append(pageNrs, pageNr)
+	}
+	sort.Ints(pageNrs)
+	for _, pageNr := range pageNrs {
+		wms = append(wms, m[pageNr])
+	}
+
+	fonts := map[string]types.IndirectRef{}
+
+	if err := prepareWatermarks(ctx, wms, fonts); err != nil {
+		return err
+	}
+
+	for _, pageNr := range pageNrs {
+		if err := addPageWatermark(ctx, pageNr, *m[pageNr]); err != nil {
 			return err
 		}
 	}
@@ -1554,54 +1885,33 @@ func AddWatermarksMap(ctx *model.Context, m map[int]*model.Watermark) error {
 }
 
 // AddWatermarksSliceMap adds watermarks in m to corresponding pages.
+// Each watermark may come with its own content, position, rotation and opacity.
+// All watermarks in m need to be either stamps or watermarks.
 func AddWatermarksSliceMap(ctx *model.Context, m map[int][]*model.Watermark) error {
-	var (
-		onTop         bool
-		opacity       float64
-		hasWatermarks bool
-	)
-
-	// All watermarks are assumed to have the same onTop and opacity.
-	for _, wms := range m {
-		if len(wms) > 0 {
-			onTop = wms[0].OnTop
-			opacity = wms[0].Opacity
-			hasWatermarks = true
-			break
+	pageNrs := make([]int, 0, len(m))
+	wms := []*model.Watermark{}
+	for pageNr, wms1 := range m {
+		for _, wm := range wms1 {
+			if wm == nil {
+				return errors.Errorf("pdfcpu: page %d: missing watermark", pageNr)
+			}
 		}
+		pageNrs = append(pageNrs, pageNr)
 	}
-
-	if !hasWatermarks {
-		return errors.Errorf("pdfcpu: no watermarks available")
-	}
-
-	ocgIndRef, err := prepareOCPropertiesInRoot(ctx, onTop)
-	if err != nil {
-		return err
-	}
-
-	extGStateIndRef, err := createExtGStateForStamp(ctx, opacity)
-	if err != nil {
-		return err
+	sort.Ints(pageNrs)
+	for _, pageNr := range pageNrs {
+		wms = append(wms, m[pageNr]...)
 	}
 
 	fonts := map[string]types.IndirectRef{}
 
-	for _, wms := range m {
-		for _, wm := range wms {
-			if err := createResourcesForWM(ctx, wm, fonts); err != nil {
-				return err
-			}
-		}
+	if err := prepareWatermarks(ctx, wms, fonts); err != nil {
+		return err
 	}
 
-	for k, wms := range m {
-		for _, wm := range wms {
-			wm.Ocg = ocgIndRef
-			wm.ExtGState = extGStateIndRef
-			wm.OnTop = onTop
-			wm.Opacity = opacity
-			if err := addPageWatermark(ctx, k, *wm); err != nil {
+	for _, pageNr := range pageNrs {
+		for _, wm := range m[pageNr] {
+			if err := addPageWatermark(ctx, pageNr, *wm); err != nil {
 				return err
 			}
 		}
@@ -1616,7 +1926,7 @@ func AddWatermarksSliceMap(ctx *model.Context, m map[int][]*model.Watermark) err
 	return nil
 }
 
-func removeResDictEntry(ctx *model.Context, d types.Dict, entry string, ids []string, i int) error {
+func removeResDictEntry(ctx *model.Context, d types.Dict, entry string, ids []string, i int, deleteObjs bool) error {
 	o, ok := d.Find(entry)
 	if !ok {
 		return errors.Errorf("pdfcpu: page %d: corrupt resource dict", i)
@@ -1630,9 +1940,10 @@ func removeResDictEntry(ctx *model.Context, d types.Dict, entry string, ids []st
 	for _, id := range ids {
 		o, ok := d1.Find(id)
 		if ok {
-			err = ctx.DeleteObject(o)
-			if err != nil {
-				return err
+			if deleteObjs {
+				if err = ctx.DeleteObject(o); err != nil {
+					return err
+				}
 			}
 			d1.Delete(id)
 		}
@@ -1645,42 +1956,56 @@ func removeResDictEntry(ctx *model.Context, d types.Dict, entry string, ids []st
 	return nil
 }
 
+// removeExtGStates keeps the ExtGState objects which may be shared, see EnsureExtGState.
 func removeExtGStates(ctx *model.Context, d types.Dict, ids []string, i int) error {
-	return removeResDictEntry(ctx, d, "ExtGState", ids, i)
+	return removeResDictEntry(ctx, d, "ExtGState", ids, i, false)
 }
 
 func removeForms(ctx *model.Context, d types.Dict, ids []string, i int) error {
-	return removeResDictEntry(ctx, d, "XObject", ids, i)
+	return removeResDictEntry(ctx, d, "XObject", ids, i, true)
 }
 
-func removeArtifacts(sd *types.StreamDict, i int) (ok bool, extGStates []string, forms []string, err error) {
+// removeArtifacts removes the watermarks identified by id or all watermarks if id is empty.
+func removeArtifacts(sd *types.StreamDict, i int, id string) (ok bool, extGStates, forms, ids []string, err error) {
 	err = sd.Decode()
 	if err == filter.ErrUnsupportedFilter {
 		if log.InfoEnabled() {
 			log.Info.Printf("unsupported filter: unable to patch content with watermark for page %d\n", i)
 		}
-		return false, nil, nil, nil
+		return false, nil, nil, nil, nil
 	}
 	if err != nil {
-		return false, nil, nil, err
+		return false, nil, nil, nil, err
 	}
 
 	var patched bool
 
 	// Watermarks may begin or end the content stream.
 
-	for {
+	for pos := 0; ; {
 		s := string(sd.Content)
-		beg := strings.Index(s, "/Artifact <</Subtype /Watermark /Type /Pagination >>BDC")
-		if beg < 0 {
+		loc := reWMArtifact.FindStringSubmatchIndex(s[pos:])
+		if loc == nil {
 			break
 		}
+		beg := pos + loc[0]
+
+		var wmID string
+		if loc[2] >= 0 {
+			bb, _ := hex.DecodeString(s[pos+loc[2] : pos+loc[3]])
+			wmID = string(bb)
+		}
 
 		end := strings.Index(s[beg:], "EMC")
 		if end < 0 {
 			break
 		}
 
+		if id != "" && wmID != id {
+			pos = beg + end + 3
+			continue
+		}
+
 		// Check for usage of resources.
 		t := s[beg : beg+end]
 
@@ -1702,8 +2027,11 @@ func removeArtifacts(sd *types.StreamDict, i int) (ok bool, extGStates []string,
 			}
 		}
 
+		ids = append(ids, wmID)
+
 		// TODO Remove whitespace until 0x0a
 		sd.Content = append(sd.Content[:beg], sd.Content[beg+end+3:]...)
+		pos = beg
 		patched = true
 	}
 
@@ -1711,28 +2039,28 @@ func removeArtifacts(sd *types.StreamDict, i int) (ok bool, extGStates []string,
 		err = sd.Encode()
 	}
 
-	return patched, extGStates, forms, err
+	return patched, extGStates, forms, ids, err
 }
 
-func removeArtifactsFromPage(ctx *model.Context, sd *types.StreamDict, resDict types.Dict, i int) (bool, error) {
+func removeArtifactsFromPage(ctx *model.Context, sd *types.StreamDict, resDict types.Dict, i int, id string) ([]string, error) {
 	// Remove watermark artifacts and locate id's
 	// of used extGStates and forms.
-	ok, extGStates, forms, err := removeArtifacts(sd, i)
+	ok, extGStates, forms, ids, err := removeArtifacts(sd, i, id)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	if !ok {
-		return false, nil
+		return nil, nil
 	}
 
 	// Remove obsolete extGStates from page resource dict.
 	err = removeExtGStates(ctx, resDict, extGStates, i)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Remove obsolete forms from page resource dict.
-	return true, removeForms(ctx, resDict, forms, i)
+	return ids, removeForms(ctx, resDict, forms, i)
 }
 
 func locatePageContentAndResourceDict(ctx *model.Context, pageNr int) (types.Object, *types.IndirectRef, types.Dict, error) {
@@ -1760,18 +2088,16 @@ func locatePageContentAndResourceDict(ctx *model.Context, pageNr int) (types.Obj
 	return o, pageDictIndRef, resDict, nil
 }
 
-func removeArtifacts1(ctx *model.Context, o types.Object, entry *model.XRefTableEntry, resDict types.Dict, pageNr int) (bool, error) {
-	found := false
+func removeArtifacts1(ctx *model.Context, o types.Object, entry *model.XRefTableEntry, resDict types.Dict, pageNr int, id string) ([]string, error) {
+	var ids []string
 	switch o := o.(type) {
 
 	case types.StreamDict:
-		ok, err := removeArtifactsFromPage(ctx, &o, resDict, pageNr)
+		ids1, err := removeArtifactsFromPage(ctx, &o, resDict, pageNr, id)
 		if err != nil {
-			return false, err
-		}
-		if !found && ok {
-			found = true
+			return nil, err
 		}
+		ids = append(ids, ids1...)
 		entry.Object = o
 
 	case types.Array:
@@ -1783,20 +2109,17 @@ func removeArtifacts1(ctx *model.Context, o types.Object, entry *model.XRefTable
 		entry, _ := ctx.FindTableEntry(objNr, genNr)
 		sd, _ := (entry.Object).(types.StreamDict)
 
-		ok, err := removeArtifactsFromPage(ctx, &sd, resDict, pageNr)
+		ids1, err := removeArtifactsFromPage(ctx, &sd, resDict, pageNr, id)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
-		if !found && ok {
-			found = true
+		if len(ids1) > 0 {
+			ids = append(ids, ids1...)
 			entry.Object = sd
 		}
 
 		if len(o) > 1 {
 			// Get stream dict for last element.
-			if len(o) == 0 {
-				return false, nil
-			}
 			o1 := o[len(o)-1]
 			ir, _ := o1.(types.IndirectRef)
 			objNr = ir.ObjectNumber.Value()
@@ -1804,21 +2127,22 @@ func removeArtifacts1(ctx *model.Context, o types.Object, entry *model.XRefTable
 			entry, _ := ctx.FindTableEntry(objNr, genNr)
 			sd, _ := (entry.Object).(types.StreamDict)
 
-			ok, err = removeArtifactsFromPage(ctx, &sd, resDict, pageNr)
+			ids1, err = removeArtifactsFromPage(ctx, &sd, resDict, pageNr, id)
 			if err != nil {
-				return false, err
+				return nil, err
 			}
-			if !found && ok {
-				found = true
+			if len(ids1) > 0 {
+				ids = append(ids, ids1...)
 				entry.Object = sd
 			}
 		}
 
 	}
-	return found, nil
+	return ids, nil
 }
 
-func removePageWatermark(ctx *model.Context, pageNr int) (bool, error) {
+// removePageWatermark removes the watermarks identified by id or all watermarks if id is empty.
+func removePageWatermark(ctx *model.Context, pageNr int, id string) (bool, error) {
 	o, pageDictIndRef, resDict, err := locatePageContentAndResourceDict(ctx, pageNr)
 	if err != nil {
 		return false, err
@@ -1834,10 +2158,11 @@ func removePageWatermark(ctx *model.Context, pageNr int) (bool, error) {
 		o = entry.Object
 	}
 
-	found, err := removeArtifacts1(ctx, o, entry, resDict, pageNr)
+	ids, err := removeArtifacts1(ctx, o, entry, resDict, pageNr, id)
 	if err != nil {
 		return false, err
 	}
+	found := len(ids) > 0
 
 	/*
 		Supposedly the form needs a PieceInfo in order to be recognized by Acrobat like so:
@@ -1859,7 +2184,11 @@ func removePageWatermark(ctx *model.Context, pageNr int) (bool, error) {
 			return false, err
 		}
 		objNr := pageDictIndRef.ObjectNumber.Value()
-		if _, err = RemoveAnnotationsFromPageDict(ctx, nil, []string{"pdfcpu"}, nil, d, objNr, pageNr, false); err != nil {
+		linkIDs := make([]string, len(ids))
+		for i, id := range ids {
+			linkIDs[i] = linkID(id)
+		}
+		if _, err = RemoveAnnotationsFromPageDict(ctx, nil, linkIDs, nil, d, objNr, pageNr, false); err != nil {
 			return false, err
 		}
 	}
@@ -1922,8 +2251,10 @@ func detectStampOCG(ctx *model.Context, arr types.Array) error {
 	return errNoWatermark
 }
 
-func removePageWatermarks(ctx *model.Context, selectedPages types.IntSet) error {
-	var removed bool
+// removePageWatermarks removes the watermarks identified by id or all watermarks if id is empty
+// and returns the affected pages.
+func removePageWatermarks(ctx *model.Context, selectedPages types.IntSet, id string) (types.IntSet, error) {
+	removed := types.IntSet{}
 
 	for k, v := range selectedPages {
 
@@ -1931,21 +2262,21 @@ func removePageWatermarks(ctx *model.Context, selectedPages types.IntSet) error
 			continue
 		}
 
-		ok, err := removePageWatermark(ctx, k)
+		ok, err := removePageWatermark(ctx, k, id)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if ok {
-			removed = true
+			removed[k] = true
 		}
 	}
 
-	if !removed {
-		return errNoWatermark
+	if len(removed) == 0 {
+		return nil, errNoWatermark
 	}
 
-	return nil
+	return removed, nil
 }
 
 // RemoveWatermarks removes watermarks for all pages selected.
@@ -1963,7 +2294,57 @@ func RemoveWatermarks(ctx *model.Context, selectedPages types.IntSet) error {
 		return err
 	}
 
-	return removePageWatermarks(ctx, selectedPages)
+	_, err = removePageWatermarks(ctx, selectedPages, "")
+	return err
+}
+
+func removeWatermarksByID(ctx *model.Context, id string) (types.IntSet, error) {
+	if id == "" {
+		return nil, errors.New("pdfcpu: missing watermark id")
+	}
+
+	arr, err := locateOCGs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := detectStampOCG(ctx, arr); err != nil {
+		return nil, err
+	}
+
+	pages := types.IntSet{}
+	for i := 1; i <= ctx.PageCount; i++ {
+		pages[i] = true
+	}
+
+	return removePageWatermarks(ctx, pages, id)
+}
+
+// RemoveWatermarkByID removes the watermarks and stamps added with Watermark.ID id from all pages.
+func RemoveWatermarkByID(ctx *model.Context, id string) error {
+	if log.DebugEnabled() {
+		log.Debug.Printf("RemoveWatermarkByID %s\n", id)
+	}
+
+	_, err := removeWatermarksByID(ctx, id)
+	return err
+}
+
+// UpdateWatermarkByID replaces the watermarks and stamps added with Watermark.ID id by wm.
+func UpdateWatermarkByID(ctx *model.Context, id string, wm *model.Watermark) error {
+	if log.DebugEnabled() {
+		log.Debug.Printf("UpdateWatermarkByID %s\n", id)
+	}
+
+	pages, err := removeWatermarksByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	wm.ID = id
+	wm.Update = false
+
+	return AddWatermarks(ctx, pages, wm)
 }
 
 func detectArtifacts(sd *types.StreamDict) (bool, error) {
@@ -1971,8 +2352,7 @@ func detectArtifacts(sd *types.StreamDict) (bool, error) {
 		return false, err
 	}
 	// Watermarks may begin or end the content stream.
-	i := strings.Index(string(sd.Content), "/Artifact <</Subtype /Watermark /Type /Pagination >>BDC")
-	return i >= 0, nil
+	return reWMArtifact.Match(sd.Content), nil
 }
 
 func findPageWatermarks(ctx *model.Context, pageDictIndRef *types.IndirectRef) (bool, error) {
@@ -0,0 +1,1059 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const svgMaxFormDepth = 16
+
+type svgFont struct {
+	family    string
+	generic   string
+	bold      bool
+	italic    bool
+	coreFont  string
+	twoByte   bool
+	cmap      *pdffont.PredefinedCMap // Type0 fonts using a predefined CMap other than Identity-H/V
+	firstChar int
+	widths    []float64
+	dw        float64
+	tum       *ToUnicodeMap
+
+	// Vertical writing
+	vertical bool
+	dw2      [2]float64            // default position vector y and vertical displacement
+	w2       map[uint32][3]float64 // CID => vertical displacement, position vector x and y
+}
+
+func (f *svgFont) codes(bb []byte) []uint32 {
+	cc := []uint32{}
+	if f.cmap != nil {
+		for _, c := range f.cmap.Codes(bb) {
+			cc = append(cc, codeForBytes(c))
+		}
+		return cc
+	}
+	if f.twoByte {
+		for i := 0; i+1 < len(bb); i += 2 {
+			cc = append(cc, uint32(bb[i])<<8|uint32(bb[i+1]))
+		}
+		return cc
+	}
+	for _, b := range bb {
+		cc = append(cc, uint32(b))
+	}
+	return cc
+}
+
+// codeBytes returns the bytes of the character code c.
+func (f *svgFont) codeBytes(c uint32) []byte {
+	if f.cmap != nil {
+		return f.cmap.CodeBytes(c)
+	}
+	if f.twoByte {
+		return []byte{byte(c >> 8), byte(c)}
+	}
+	return []byte{byte(c)}
+}
+
+// space returns true for the single-byte character code 32 which is subject to word spacing.
+func (f *svgFont) space(c uint32) bool {
+	return c == 32 && (!f.twoByte || f.cmap != nil && len(f.codeBytes(c)) == 1)
+}
+
+func (f *svgFont) width(c uint32) float64 {
+	if f.cmap != nil {
+		// The CID is unknown, distinguish half-width glyphs only.
+		if f.cmap.HalfWidth(f.codeBytes(c)) {
+			return f.dw / 2
+		}
+		return f.dw
+	}
+	i := int(c) - f.firstChar
+	if i >= 0 && i < len(f.widths) {
+		return f.widths[i]
+	}
+	if f.coreFont != "" {
+		return float64(font.CharWidth(f.coreFont, rune(c)))
+	}
+	return f.dw
+}
+
+// verticalMetrics returns the vertical displacement and the position vector of the glyph for c
+// in thousandths of text space units (see 9.7.4.3 Glyph Metrics in CIDFonts).
+func (f *svgFont) verticalMetrics(c uint32) (w1y, vx, vy float64) {
+	if m, ok := f.w2[c]; ok && f.cmap == nil {
+		return m[0], m[1], m[2]
+	}
+	return f.dw2[1], f.width(c) / 2, f.dw2[0]
+}
+
+func (f *svgFont) text(c uint32) string {
+	if f.tum != nil {
+		if s, ok := f.tum.M[c]; ok {
+			return s
+		}
+	}
+	if f.cmap != nil {
+		return f.cmap.Text(f.codeBytes(c))
+	}
+	if f.twoByte {
+		return ""
+	}
+	return string(rune(c))
+}
+
+type svgGState struct {
+	ctm         matrix.Matrix
+	fill        string
+	stroke      string
+	fillAlpha   float64
+	strokeAlpha float64
+	lineWidth   float64
+	lineCap     int
+	lineJoin    int
+	miterLimit  float64
+	dash        []float64
+	dashPhase   float64
+	clipGroups  int
+
+	// Text state
+	font       *svgFont
+	fontSize   float64
+	charSpace  float64
+	wordSpace  float64
+	hScale     float64
+	leading    float64
+	rise       float64
+	renderMode int
+}
+
+func newSVGGState(ctm matrix.Matrix) svgGState {
+	return svgGState{
+		ctm:         ctm,
+		fill:        "#000000",
+		stroke:      "#000000",
+		fillAlpha:   1,
+		strokeAlpha: 1,
+		lineWidth:   1,
+		miterLimit:  10,
+		hScale:      1,
+	}
+}
+
+type svgRenderer struct {
+	ctx       *model.Context
+	w         *bytes.Buffer
+	gs        svgGState
+	stack     []svgGState
+	path      []string
+	cx, cy    float64
+	clip      string // "" or fill rule of pending clip
+	clipCount int
+	tm, tlm   matrix.Matrix
+	fonts     map[int]*svgFont
+	depth     int
+}
+
+func svgNum(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1000)/1000, 'f', -1, 64)
+}
+
+func svgMatrix(m matrix.Matrix) string {
+	return fmt.Sprintf("matrix(%s %s %s %s %s %s)",
+		svgNum(m[0][0]), svgNum(m[0][1]), svgNum(m[1][0]), svgNum(m[1][1]), svgNum(m[2][0]), svgNum(m[2][1]))
+}
+
+func matrixForOperands(ff []float64) matrix.Matrix {
+	if len(ff) < 6 {
+		return matrix.IdentMatrix
+	}
+	return matrix.Matrix{{ff[0], ff[1], 0}, {ff[2], ff[3], 0}, {ff[4], ff[5], 1}}
+}
+
+func svgClamp(f float64) float64 {
+	return math.Max(0, math.Min(1, f))
+}
+
+func svgColor(ff []float64) string {
+	var r, g, b float64
+	switch len(ff) {
+	case 1:
+		r, g, b = ff[0], ff[0], ff[0]
+	case 3:
+		r, g, b = ff[0], ff[1], ff[2]
+	case 4:
+		k := svgClamp(ff[3])
+		r = (1 - svgClamp(ff[0])) * (1 - k)
+		g = (1 - svgClamp(ff[1])) * (1 - k)
+		b = (1 - svgClamp(ff[2])) * (1 - k)
+	default:
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", int(svgClamp(r)*255+0.5), int(svgClamp(g)*255+0.5), int(svgClamp(b)*255+0.5))
+}
+
+func fontFamily(baseFont string) (string, string, bool, bool) {
+	s := baseFont
+	if len(s) > 7 && s[6] == '+' {
+		s = s[7:]
+	}
+	name := s
+	if i := strings.IndexAny(s, ",-"); i > 0 {
+		name = s[:i]
+	}
+	generic := "sans-serif"
+	switch {
+	case strings.Contains(s, "Times") || strings.Contains(s, "Serif") && !strings.Contains(s, "Sans"):
+		generic = "serif"
+	case strings.Contains(s, "Courier") || strings.Contains(s, "Mono"):
+		generic = "monospace"
+	}
+	bold := strings.Contains(s, "Bold") || strings.Contains(s, "Black") || strings.Contains(s, "Heavy")
+	italic := strings.Contains(s, "Italic") || strings.Contains(s, "Oblique")
+	return name, generic, bold, italic
+}
+
+func (r *svgRenderer) numberArray(o types.Object) []float64 {
+	a, err := r.ctx.DereferenceArray(o)
+	if err != nil || a == nil {
+		return nil
+	}
+	ff := make([]float64, len(a))
+	for i, o := range a {
+		ff[i], _ = r.ctx.DereferenceNumber(o)
+	}
+	return ff
+}
+
+func (r *svgRenderer) descendantFont(d types.Dict) types.Dict {
+	o, found := d.Find("DescendantFonts")
+	if !found {
+		return nil
+	}
+	a, err := r.ctx.DereferenceArray(o)
+	if err != nil || len(a) == 0 {
+		return nil
+	}
+	df, err := r.ctx.DereferenceDict(a[0])
+	if err != nil {
+		return nil
+	}
+	return df
+}
+
+// loadCIDVerticalMetrics loads DW2 and W2 of the CIDFont df used for vertical writing.
+func (r *svgRenderer) loadCIDVerticalMetrics(f *svgFont, df types.Dict) {
+	f.dw2 = [2]float64{880, -1000}
+	if df == nil {
+		return
+	}
+	if o, found := df.Find("DW2"); found {
+		if ff := r.numberArray(o); len(ff) == 2 {
+			f.dw2 = [2]float64{ff[0], ff[1]}
+		}
+	}
+	o, found := df.Find("W2")
+	if !found {
+		return
+	}
+	w, err := r.ctx.DereferenceArray(o)
+	if err != nil {
+		return
+	}
+	f.w2 = map[uint32][3]float64{}
+	for i := 0; i+1 < len(w); {
+		c, err := r.ctx.DereferenceNumber(w[i])
+		if err != nil {
+			break
+		}
+		o, _ := r.ctx.Dereference(w[i+1])
+		if a, ok := o.(types.Array); ok {
+			// c [w1y vx vy ...]
+			ff := r.numberArray(a)
+			for j := 0; j+2 < len(ff); j += 3 {
+				f.w2[uint32(c)+uint32(j/3)] = [3]float64{ff[j], ff[j+1], ff[j+2]}
+			}
+			i += 2
+			continue
+		}
+		// cfirst clast w1y vx vy
+		if i+4 >= len(w) {
+			break
+		}
+		c2, _ := r.ctx.DereferenceNumber(w[i+1])
+		var m [3]float64
+		for j := range m {
+			m[j], _ = r.ctx.DereferenceNumber(w[i+2+j])
+		}
+		for j := int(c); j <= int(c2) && j-int(c) < 0x10000; j++ {
+			f.w2[uint32(j)] = m
+		}
+		i += 5
+	}
+}
+
+func (r *svgRenderer) loadCIDWidths(f *svgFont, df types.Dict) {
+	f.dw = 1000
+	if df == nil {
+		return
+	}
+	if dw := df.IntEntry("DW"); dw != nil {
+		f.dw = float64(*dw)
+	}
+	o, found := df.Find("W")
+	if !found {
+		return
+	}
+	w, err := r.ctx.DereferenceArray(o)
+	if err != nil {
+		return
+	}
+	// Flatten the W array into a widths slice starting at the lowest CID.
+	m := map[int]float64{}
+	min := math.MaxInt32
+	for i := 0; i+1 < len(w); {
+		c, err := r.ctx.DereferenceNumber(w[i])
+		if err != nil {
+			break
+		}
+		if int(c) < min {
+			min = int(c)
+		}
+		o, _ := r.ctx.Dereference(w[i+1])
+		if a, ok := o.(types.Array); ok {
+			for j, v := range r.numberArray(a) {
+				m[int(c)+j] = v
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			break
+		}
+		c2, _ := r.ctx.DereferenceNumber(w[i+1])
+		v, _ := r.ctx.DereferenceNumber(w[i+2])
+		for j := int(c); j <= int(c2) && j-int(c) < 0x10000; j++ {
+			m[j] = v
+		}
+		i += 3
+	}
+	if len(m) == 0 {
+		return
+	}
+	max := 0
+	for k := range m {
+		if k > max {
+			max = k
+		}
+	}
+	f.firstChar = min
+	f.widths = make([]float64, max-min+1)
+	for i := range f.widths {
+		if v, ok := m[min+i]; ok {
+			f.widths[i] = v
+		} else {
+			f.widths[i] = f.dw
+		}
+	}
+}
+
+func (r *svgRenderer) loadFont(resDict types.Dict, name string) *svgFont {
+	o, found := r.resource(resDict, "Font", name)
+	if !found {
+		return nil
+	}
+	var objNr int
+	if ir, ok := o.(types.IndirectRef); ok {
+		objNr = ir.ObjectNumber.Value()
+		if f, ok := r.fonts[objNr]; ok {
+			return f
+		}
+	}
+	d, err := r.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil
+	}
+	f := &svgFont{}
+	baseFont := ""
+	if bf := d.NameEntry("BaseFont"); bf != nil {
+		baseFont = *bf
+	}
+	f.family, f.generic, f.bold, f.italic = fontFamily(baseFont)
+	if st := d.Subtype(); st != nil && *st == "Type0" {
+		f.twoByte = true
+		df := r.descendantFont(d)
+		r.loadCIDWidths(f, df)
+		r.loadCMap(f, d)
+		if f.vertical {
+			r.loadCIDVerticalMetrics(f, df)
+		}
+	} else {
+		if fc := d.IntEntry("FirstChar"); fc != nil {
+			f.firstChar = *fc
+		}
+		if o, found := d.Find("Widths"); found {
+			f.widths = r.numberArray(o)
+		}
+		if font.IsCoreFont(baseFont) {
+			f.coreFont = baseFont
+		}
+		f.dw = 500
+	}
+	if tum, err := ToUnicodeMapForFontDict(r.ctx.XRefTable, d); err == nil {
+		f.tum = tum
+	}
+	if objNr > 0 {
+		r.fonts[objNr] = f
+	}
+	return f
+}
+
+// loadCMap sets up the character code mapping of the Type0 font d
+// for predefined CMaps other than Identity-H and Identity-V and the writing mode.
+func (r *svgRenderer) loadCMap(f *svgFont, d types.Dict) {
+	var name string
+	o, _ := r.ctx.Dereference(d["Encoding"])
+	switch o := o.(type) {
+	case types.Name:
+		name = o.Value()
+	case types.StreamDict:
+		// An embedded CMap usually extends a predefined one.
+		if wm := o.IntEntry("WMode"); wm != nil && *wm == 1 {
+			f.vertical = true
+		}
+		if n := o.NameEntry("UseCMap"); n != nil {
+			name = *n
+		} else if n := o.NameEntry("CMapName"); n != nil {
+			name = *n
+		}
+	}
+	cm, ok := pdffont.PredefinedCMapByName(name)
+	if !ok {
+		return
+	}
+	if cm.Vertical {
+		f.vertical = true
+	}
+	if cm.Encoding != "CID" {
+		f.cmap = &cm
+	}
+}
+
+func (r *svgRenderer) resource(resDict types.Dict, category, name string) (types.Object, bool) {
+	if resDict == nil {
+		return nil, false
+	}
+	d, err := r.ctx.DereferenceDict(resDict[category])
+	if err != nil || d == nil {
+		return nil, false
+	}
+	o, found := d.Find(name)
+	return o, found && o != nil
+}
+
+func (r *svgRenderer) openClipGroup(fillRule string) {
+	if len(r.path) == 0 {
+		return
+	}
+	r.clipCount++
+	id := fmt.Sprintf("clip%d", r.clipCount)
+	fmt.Fprintf(r.w, "<clipPath id=\"%s\"><path transform=\"%s\" d=\"%s\" clip-rule=\"%s\"/></clipPath>\n",
+		id, svgMatrix(r.gs.ctm), strings.Join(r.path, " "), fillRule)
+	fmt.Fprintf(r.w, "<g clip-path=\"url(#%s)\">\n", id)
+	r.gs.clipGroups++
+}
+
+func (r *svgRenderer) strokeAttrs() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, " stroke=\"%s\"", r.gs.stroke)
+	if r.gs.lineWidth <= 0 {
+		sb.WriteString(" stroke-width=\"1\" vector-effect=\"non-scaling-stroke\"")
+	} else {
+		fmt.Fprintf(&sb, " stroke-width=\"%s\"", svgNum(r.gs.lineWidth))
+	}
+	if r.gs.strokeAlpha < 1 {
+		fmt.Fprintf(&sb, " stroke-opacity=\"%s\"", svgNum(r.gs.strokeAlpha))
+	}
+	switch r.gs.lineCap {
+	case 1:
+		sb.WriteString(" stroke-linecap=\"round\"")
+	case 2:
+		sb.WriteString(" stroke-linecap=\"square\"")
+	}
+	switch r.gs.lineJoin {
+	case 1:
+		sb.WriteString(" stroke-linejoin=\"round\"")
+	case 2:
+		sb.WriteString(" stroke-linejoin=\"bevel\"")
+	default:
+		if r.gs.miterLimit != 4 {
+			fmt.Fprintf(&sb, " stroke-miterlimit=\"%s\"", svgNum(math.Max(1, r.gs.miterLimit)))
+		}
+	}
+	if len(r.gs.dash) > 0 {
+		ss := make([]string, len(r.gs.dash))
+		for i, f := range r.gs.dash {
+			ss[i] = svgNum(f)
+		}
+		fmt.Fprintf(&sb, " stroke-dasharray=\"%s\"", strings.Join(ss, " "))
+		if r.gs.dashPhase != 0 {
+			fmt.Fprintf(&sb, " stroke-dashoffset=\"%s\"", svgNum(r.gs.dashPhase))
+		}
+	}
+	return sb.String()
+}
+
+func (r *svgRenderer) paint(fill, fillRule string, stroke, close bool) {
+	if close {
+		r.path = append(r.path, "Z")
+	}
+	if len(r.path) > 0 && (fill != "" || stroke) {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "<path transform=\"%s\" d=\"%s\"", svgMatrix(r.gs.ctm), strings.Join(r.path, " "))
+		if fill == "" {
+			sb.WriteString(" fill=\"none\"")
+		} else {
+			fmt.Fprintf(&sb, " fill=\"%s\"", fill)
+			if fillRule == "evenodd" {
+				sb.WriteString(" fill-rule=\"evenodd\"")
+			}
+			if r.gs.fillAlpha < 1 {
+				fmt.Fprintf(&sb, " fill-opacity=\"%s\"", svgNum(r.gs.fillAlpha))
+			}
+		}
+		if stroke {
+			sb.WriteString(r.strokeAttrs())
+		}
+		sb.WriteString("/>\n")
+		r.w.WriteString(sb.String())
+	}
+	if r.clip != "" {
+		r.openClipGroup(r.clip)
+		r.clip = ""
+	}
+	r.path = nil
+}
+
+func (r *svgRenderer) pathOp(op model.ContentOp) bool {
+	ff := op.Numbers()
+	switch op.Operator {
+	case "m":
+		if len(ff) >= 2 {
+			r.path = append(r.path, "M"+svgNum(ff[0])+" "+svgNum(ff[1]))
+			r.cx, r.cy = ff[0], ff[1]
+		}
+	case "l":
+		if len(ff) >= 2 {
+			r.path = append(r.path, "L"+svgNum(ff[0])+" "+svgNum(ff[1]))
+			r.cx, r.cy = ff[0], ff[1]
+		}
+	case "c":
+		if len(ff) >= 6 {
+			r.path = append(r.path, fmt.Sprintf("C%s %s %s %s %s %s", svgNum(ff[0]), svgNum(ff[1]), svgNum(ff[2]), svgNum(ff[3]), svgNum(ff[4]), svgNum(ff[5])))
+			r.cx, r.cy = ff[4], ff[5]
+		}
+	case "v":
+		if len(ff) >= 4 {
+			r.path = append(r.path, fmt.Sprintf("C%s %s %s %s %s %s", svgNum(r.cx), svgNum(r.cy), svgNum(ff[0]), svgNum(ff[1]), svgNum(ff[2]), svgNum(ff[3])))
+			r.cx, r.cy = ff[2], ff[3]
+		}
+	case "y":
+		if len(ff) >= 4 {
+			r.path = append(r.path, fmt.Sprintf("C%s %s %s %s %s %s", svgNum(ff[0]), svgNum(ff[1]), svgNum(ff[2]), svgNum(ff[3]), svgNum(ff[2]), svgNum(ff[3])))
+			r.cx, r.cy = ff[2], ff[3]
+		}
+	case "h":
+		r.path = append(r.path, "Z")
+	case "re":
+		if len(ff) >= 4 {
+			r.path = append(r.path, fmt.Sprintf("M%s %s h%s v%s h%s Z", svgNum(ff[0]), svgNum(ff[1]), svgNum(ff[2]), svgNum(ff[3]), svgNum(-ff[2])))
+			r.cx, r.cy = ff[0], ff[1]
+		}
+	case "S":
+		r.paint("", "", true, false)
+	case "s":
+		r.paint("", "", true, true)
+	case "f", "F":
+		r.paint(r.gs.fill, "nonzero", false, false)
+	case "f*":
+		r.paint(r.gs.fill, "evenodd", false, false)
+	case "B":
+		r.paint(r.gs.fill, "nonzero", true, false)
+	case "B*":
+		r.paint(r.gs.fill, "evenodd", true, false)
+	case "b":
+		r.paint(r.gs.fill, "nonzero", true, true)
+	case "b*":
+		r.paint(r.gs.fill, "evenodd", true, true)
+	case "n":
+		r.paint("", "", false, false)
+	case "W":
+		r.clip = "nonzero"
+	case "W*":
+		r.clip = "evenodd"
+	default:
+		return false
+	}
+	return true
+}
+
+func (r *svgRenderer) colorOp(op model.ContentOp) bool {
+	ff := op.Numbers()
+	switch op.Operator {
+	case "g", "rg", "k", "sc", "scn":
+		if c := svgColor(ff); c != "" {
+			r.gs.fill = c
+		}
+	case "G", "RG", "K", "SC", "SCN":
+		if c := svgColor(ff); c != "" {
+			r.gs.stroke = c
+		}
+	case "cs":
+		r.gs.fill = "#000000"
+	case "CS":
+		r.gs.stroke = "#000000"
+	default:
+		return false
+	}
+	return true
+}
+
+func (r *svgRenderer) extGState(resDict types.Dict, name string) {
+	o, found := r.resource(resDict, "ExtGState", name)
+	if !found {
+		return
+	}
+	d, err := r.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return
+	}
+	if f, err := r.ctx.DereferenceNumber(d["CA"]); err == nil && d["CA"] != nil {
+		r.gs.strokeAlpha = f
+	}
+	if f, err := r.ctx.DereferenceNumber(d["ca"]); err == nil && d["ca"] != nil {
+		r.gs.fillAlpha = f
+	}
+	if f, err := r.ctx.DereferenceNumber(d["LW"]); err == nil && d["LW"] != nil {
+		r.gs.lineWidth = f
+	}
+}
+
+func (r *svgRenderer) stateOp(op model.ContentOp, resDict types.Dict) bool {
+	switch op.Operator {
+	case "q":
+		r.stack = append(r.stack, r.gs)
+		r.gs.clipGroups = 0
+	case "Q":
+		for ; r.gs.clipGroups > 0; r.gs.clipGroups-- {
+			r.w.WriteString("</g>\n")
+		}
+		if len(r.stack) > 0 {
+			r.gs = r.stack[len(r.stack)-1]
+			r.stack = r.stack[:len(r.stack)-1]
+		}
+	case "cm":
+		r.gs.ctm = matrixForOperands(op.Numbers()).Multiply(r.gs.ctm)
+	case "w":
+		r.gs.lineWidth = op.Number(0)
+	case "J":
+		r.gs.lineCap = int(op.Number(0))
+	case "j":
+		r.gs.lineJoin = int(op.Number(0))
+	case "M":
+		r.gs.miterLimit = op.Number(0)
+	case "d":
+		r.gs.dash = nil
+		if len(op.Operands) > 0 {
+			if a, ok := op.Operands[0].(types.Array); ok {
+				r.gs.dash = arrayNumbers(a)
+			}
+		}
+		r.gs.dashPhase = op.Number(1)
+	case "gs":
+		r.extGState(resDict, op.Name(0))
+	default:
+		return false
+	}
+	return true
+}
+
+func arrayNumbers(a types.Array) []float64 {
+	return model.ContentOp{Operands: a}.Numbers()
+}
+
+func (r *svgRenderer) nextLine(tx, ty float64) {
+	m := matrix.IdentMatrix
+	m[2][0], m[2][1] = tx, ty
+	r.tlm = m.Multiply(r.tlm)
+	r.tm = r.tlm
+}
+
+func stringBytes(o types.Object) ([]byte, bool) {
+	switch s := o.(type) {
+	case types.StringLiteral:
+		bb, err := types.Unescape(s.Value())
+		return bb, err == nil
+	case types.HexLiteral:
+		bb, err := s.Bytes()
+		return bb, err == nil
+	}
+	return nil, false
+}
+
+func (r *svgRenderer) writeText(f *svgFont, trm matrix.Matrix, s string) {
+	if r.gs.renderMode == 3 || r.gs.renderMode == 7 || s == "" {
+		return
+	}
+	var esc bytes.Buffer
+	xml.EscapeText(&esc, []byte(s))
+	attrs := fmt.Sprintf(" font-family=\"%s, %s\"", f.family, f.generic)
+	if f.bold {
+		attrs += " font-weight=\"bold\""
+	}
+	if f.italic {
+		attrs += " font-style=\"italic\""
+	}
+	if r.gs.fillAlpha < 1 {
+		attrs += fmt.Sprintf(" fill-opacity=\"%s\"", svgNum(r.gs.fillAlpha))
+	}
+	fmt.Fprintf(r.w, "<text transform=\"%s scale(1 -1)\" font-size=\"1\" fill=\"%s\"%s xml:space=\"preserve\">%s</text>\n",
+		svgMatrix(trm), r.gs.fill, attrs, esc.String())
+}
+
+// showVerticalText renders each glyph of bb at its position vector and advances downwards (see 9.7.4.3 Glyph Metrics in CIDFonts).
+func (r *svgRenderer) showVerticalText(f *svgFont, bb []byte) {
+	fs, th := r.gs.fontSize, r.gs.hScale
+	for _, c := range f.codes(bb) {
+		w1y, vx, vy := f.verticalMetrics(c)
+		m := matrix.Matrix{{fs * th, 0, 0}, {0, fs, 0}, {-vx / 1000 * fs * th, -vy/1000*fs + r.gs.rise, 1}}
+		r.writeText(f, m.Multiply(r.tm).Multiply(r.gs.ctm), f.text(c))
+		ty := w1y/1000*fs + r.gs.charSpace
+		if f.space(c) {
+			ty += r.gs.wordSpace
+		}
+		r.advance(0, ty)
+	}
+}
+
+func (r *svgRenderer) showText(bb []byte) {
+	f := r.gs.font
+	if f == nil {
+		return
+	}
+	if f.vertical {
+		r.showVerticalText(f, bb)
+		return
+	}
+	fs, th := r.gs.fontSize, r.gs.hScale
+	m := matrix.Matrix{{fs * th, 0, 0}, {0, fs, 0}, {0, r.gs.rise, 1}}
+	trm := m.Multiply(r.tm).Multiply(r.gs.ctm)
+
+	var sb strings.Builder
+	tx := 0.0
+	for _, c := range f.codes(bb) {
+		sb.WriteString(f.text(c))
+		w := f.width(c)/1000*fs + r.gs.charSpace
+		if f.space(c) {
+			w += r.gs.wordSpace
+		}
+		tx += w * th
+	}
+
+	r.writeText(f, trm, sb.String())
+
+	r.advance(tx, 0)
+}
+
+func (r *svgRenderer) advance(tx, ty float64) {
+	m := matrix.IdentMatrix
+	m[2][0], m[2][1] = tx, ty
+	r.tm = m.Multiply(r.tm)
+}
+
+func (r *svgRenderer) textOp(op model.ContentOp, resDict types.Dict) bool {
+	ff := op.Numbers()
+	switch op.Operator {
+	case "BT":
+		r.tm, r.tlm = matrix.IdentMatrix, matrix.IdentMatrix
+	case "ET":
+	case "Tf":
+		r.gs.font = r.loadFont(resDict, op.Name(0))
+		r.gs.fontSize = op.Number(1)
+	case "Tc":
+		r.gs.charSpace = op.Number(0)
+	case "Tw":
+		r.gs.wordSpace = op.Number(0)
+	case "Tz":
+		r.gs.hScale = op.Number(0) / 100
+	case "TL":
+		r.gs.leading = op.Number(0)
+	case "Ts":
+		r.gs.rise = op.Number(0)
+	case "Tr":
+		r.gs.renderMode = int(op.Number(0))
+	case "Td":
+		if len(ff) >= 2 {
+			r.nextLine(ff[0], ff[1])
+		}
+	case "TD":
+		if len(ff) >= 2 {
+			r.gs.leading = -ff[1]
+			r.nextLine(ff[0], ff[1])
+		}
+	case "Tm":
+		r.tlm = matrixForOperands(ff)
+		r.tm = r.tlm
+	case "T*":
+		r.nextLine(0, -r.gs.leading)
+	case "Tj":
+		if len(op.Operands) > 0 {
+			if bb, ok := stringBytes(op.Operands[0]); ok {
+				r.showText(bb)
+			}
+		}
+	case "'", "\"":
+		if op.Operator == "\"" && len(ff) >= 2 {
+			r.gs.wordSpace, r.gs.charSpace = ff[0], ff[1]
+		}
+		r.nextLine(0, -r.gs.leading)
+		if len(op.Operands) > 0 {
+			if bb, ok := stringBytes(op.Operands[len(op.Operands)-1]); ok {
+				r.showText(bb)
+			}
+		}
+	case "TJ":
+		if len(op.Operands) == 0 {
+			break
+		}
+		a, _ := op.Operands[0].(types.Array)
+		for _, o := range a {
+			if bb, ok := stringBytes(o); ok {
+				r.showText(bb)
+				continue
+			}
+			n := arrayNumbers(types.Array{o})
+			if len(n) != 1 {
+				continue
+			}
+			if r.gs.font != nil && r.gs.font.vertical {
+				r.advance(0, -n[0]/1000*r.gs.fontSize)
+				continue
+			}
+			r.advance(-n[0]/1000*r.gs.fontSize*r.gs.hScale, 0)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (r *svgRenderer) image(sd *types.StreamDict, name string, objNr int) error {
+	img, err := ExtractImage(r.ctx, sd, false, name, objNr, false)
+	if err != nil || img == nil {
+		return err
+	}
+	mime := "image/png"
+	switch img.FileType {
+	case "png":
+	case "jpg":
+		mime = "image/jpeg"
+	default:
+		// Browsers cannot display this image type.
+		fmt.Fprintf(r.w, "<!-- skipped %s image %s -->\n", img.FileType, name)
+		return nil
+	}
+	bb, err := io.ReadAll(img)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.w, "<image transform=\"%s matrix(1 0 0 -1 0 1)\" width=\"1\" height=\"1\" preserveAspectRatio=\"none\" href=\"data:%s;base64,%s\"/>\n",
+		svgMatrix(r.gs.ctm), mime, base64.StdEncoding.EncodeToString(bb))
+	return nil
+}
+
+func (r *svgRenderer) xObject(resDict types.Dict, name string) error {
+	o, found := r.resource(resDict, "XObject", name)
+	if !found {
+		return nil
+	}
+	var objNr int
+	if ir, ok := o.(types.IndirectRef); ok {
+		objNr = ir.ObjectNumber.Value()
+	}
+	sd, _, err := r.ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return err
+	}
+	st := sd.Subtype()
+	if st == nil {
+		return nil
+	}
+	switch *st {
+	case "Image":
+		return r.image(sd, name, objNr)
+	case "Form":
+		if r.depth >= svgMaxFormDepth {
+			return nil
+		}
+		if err := sd.Decode(); err != nil {
+			return err
+		}
+		res := resDict
+		if d, err := r.ctx.DereferenceDict(sd.Dict["Resources"]); err == nil && d != nil {
+			res = d
+		}
+		r.stack = append(r.stack, r.gs)
+		r.gs.clipGroups = 0
+		if o, found := sd.Find("Matrix"); found {
+			r.gs.ctm = matrixForOperands(r.numberArray(o)).Multiply(r.gs.ctm)
+		}
+		r.depth++
+		err := r.render(sd.Content, res)
+		r.depth--
+		for ; r.gs.clipGroups > 0; r.gs.clipGroups-- {
+			r.w.WriteString("</g>\n")
+		}
+		r.gs = r.stack[len(r.stack)-1]
+		r.stack = r.stack[:len(r.stack)-1]
+		return err
+	}
+	return nil
+}
+
+func (r *svgRenderer) render(bb []byte, resDict types.Dict) error {
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return err
+	}
+	stackSize := len(r.stack)
+	for _, op := range ops {
+		if r.pathOp(op) || r.colorOp(op) || r.stateOp(op, resDict) || r.textOp(op, resDict) {
+			continue
+		}
+		if op.Operator == "Do" {
+			if err := r.xObject(resDict, op.Name(0)); err != nil {
+				return err
+			}
+			continue
+		}
+		if log.DebugEnabled() {
+			log.Debug.Printf("svg: skipping operator %s\n", op.Operator)
+		}
+	}
+	// Balance any unmatched q operators.
+	for len(r.stack) > stackSize {
+		r.stateOp(model.ContentOp{Operator: "Q"}, resDict)
+	}
+	return nil
+}
+
+func svgPageTransform(cb *types.Rectangle, rot int) (matrix.Matrix, float64, float64) {
+	w, h := cb.Width(), cb.Height()
+	var a, b, c, d, e, f float64
+	switch rot {
+	case 90:
+		c, b = 1, 1
+		w, h = h, w
+	case 180:
+		a, d, e = -1, 1, w
+	case 270:
+		c, b, e, f = -1, -1, h, w
+		w, h = h, w
+	default:
+		a, d, f = 1, -1, h
+	}
+	e -= a*cb.LL.X + c*cb.LL.Y
+	f -= b*cb.LL.X + d*cb.LL.Y
+	return matrix.Matrix{{a, b, 0}, {c, d, 0}, {e, f, 1}}, w, h
+}
+
+// ExtractPageAsSVG renders the vector content of page pageNr into a SVG document.
+// Text is rendered as SVG text using font references, images get embedded as data URIs.
+func ExtractPageAsSVG(ctx *model.Context, pageNr int) (io.Reader, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	cb := inhPAttrs.CropBox
+	if cb == nil {
+		cb = inhPAttrs.MediaBox
+	}
+	if cb == nil {
+		return nil, errors.Errorf("pdfcpu: page %d: missing mediaBox", pageNr)
+	}
+
+	rot := inhPAttrs.Rotate % 360
+	if rot < 0 {
+		rot += 360
+	}
+	m, w, h := svgPageTransform(cb, rot)
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err != nil && err != model.ErrNoContent {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" version=\"1.1\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %s %s\">\n",
+		svgNum(w), svgNum(h), svgNum(w), svgNum(h))
+	fmt.Fprintf(&buf, "<g transform=\"%s\">\n", svgMatrix(m))
+
+	r := &svgRenderer{
+		ctx:   ctx,
+		w:     &buf,
+		gs:    newSVGGState(matrix.IdentMatrix),
+		fonts: map[int]*svgFont{},
+		tm:    matrix.IdentMatrix,
+		tlm:   matrix.IdentMatrix,
+	}
+
+	if len(bb) > 0 {
+		if err := r.render(bb, inhPAttrs.Resources); err != nil {
+			return nil, err
+		}
+	}
+	for ; r.gs.clipGroups > 0; r.gs.clipGroups-- {
+		buf.WriteString("</g>\n")
+	}
+
+	buf.WriteString("</g>\n</svg>\n")
+
+	return &buf, nil
+}
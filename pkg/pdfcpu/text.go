@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TJ offsets beyond this threshold in thousandths of text space units are considered word gaps.
+const textWordGap = 250
+
+type textExtractor struct {
+	fonts *svgRenderer // font loading is shared with the SVG backend
+	sb    strings.Builder
+	font  *svgFont
+	stack []*svgFont
+	depth int
+}
+
+func (te *textExtractor) last() byte {
+	s := te.sb.String()
+	if len(s) == 0 {
+		return '\n'
+	}
+	return s[len(s)-1]
+}
+
+func (te *textExtractor) separate(b byte) {
+	if l := te.last(); l != '\n' && l != b {
+		te.sb.WriteByte(b)
+	}
+}
+
+func (te *textExtractor) show(o types.Object) {
+	bb, ok := stringBytes(o)
+	if !ok || te.font == nil {
+		return
+	}
+	for _, c := range te.font.codes(bb) {
+		te.sb.WriteString(te.font.text(c))
+	}
+}
+
+func (te *textExtractor) showArray(o types.Object) {
+	a, _ := o.(types.Array)
+	for _, o := range a {
+		if _, ok := stringBytes(o); ok {
+			te.show(o)
+			continue
+		}
+		if n := arrayNumbers(types.Array{o}); len(n) == 1 && n[0] < -textWordGap {
+			te.separate(' ')
+		}
+	}
+}
+
+func (te *textExtractor) form(resDict types.Dict, name string) error {
+	if te.depth >= svgMaxFormDepth {
+		return nil
+	}
+	o, found := te.fonts.resource(resDict, "XObject", name)
+	if !found {
+		return nil
+	}
+	sd, _, err := te.fonts.ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return err
+	}
+	if st := sd.Subtype(); st == nil || *st != "Form" {
+		return nil
+	}
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+	res := resDict
+	if d, err := te.fonts.ctx.DereferenceDict(sd.Dict["Resources"]); err == nil && d != nil {
+		res = d
+	}
+	te.depth++
+	err = te.extract(sd.Content, res)
+	te.depth--
+	return err
+}
+
+func (te *textExtractor) extract(bb []byte, resDict types.Dict) error {
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		switch op.Operator {
+		case "q":
+			te.stack = append(te.stack, te.font)
+		case "Q":
+			if len(te.stack) > 0 {
+				te.font = te.stack[len(te.stack)-1]
+				te.stack = te.stack[:len(te.stack)-1]
+			}
+		case "Tf":
+			te.font = te.fonts.loadFont(resDict, op.Name(0))
+		case "Tj":
+			if len(op.Operands) > 0 {
+				te.show(op.Operands[0])
+			}
+		case "TJ":
+			if len(op.Operands) > 0 {
+				te.showArray(op.Operands[0])
+			}
+		case "'", "\"":
+			te.separate('\n')
+			if len(op.Operands) > 0 {
+				te.show(op.Operands[len(op.Operands)-1])
+			}
+		case "Td", "TD":
+			if op.Number(1) != 0 {
+				te.separate('\n')
+			} else {
+				te.separate(' ')
+			}
+		case "T*", "Tm", "ET":
+			te.separate('\n')
+		case "Do":
+			if err := te.form(resDict, op.Name(0)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExtractPageText returns the text of page pageNr in content stream order.
+// Lines are separated by newlines, character positions are not taken into account.
+func ExtractPageText(ctx *model.Context, pageNr int) (string, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return "", err
+	}
+	if d == nil {
+		return "", errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err == model.ErrNoContent {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	te := &textExtractor{fonts: &svgRenderer{ctx: ctx, fonts: map[int]*svgFont{}}}
+	if err := te.extract(bb, inhPAttrs.Resources); err != nil {
+		return "", err
+	}
+
+	return te.sb.String(), nil
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ThreeDModel represents the 3D artwork of a 3D annotation.
+type ThreeDModel struct {
+	io.Reader             // U3D or PRC data
+	PageNr       int      // page number of the 3D annotation
+	ObjNr        int      // 3D annotation objNr
+	StreamObjNr  int      // 3D stream objNr
+	ID           string   // annotation name
+	Format       string   // U3D or PRC
+	Views        []string // view names
+	DefaultView  string   // default view name
+	Activation   string   // XA, PO or PV
+	Deactivation string   // XD, PC or PI
+	Interactive  bool     // user interaction enabled
+	Rect         types.Rectangle
+}
+
+// Ext returns the file extension for the 3D data format.
+func (m ThreeDModel) Ext() string {
+	return strings.ToLower(m.Format)
+}
+
+// threeDStream resolves o which is a 3D stream or a 3D reference dictionary.
+func threeDStream(xRefTable *model.XRefTable, o types.Object) (*types.StreamDict, int, error) {
+	objNr := 0
+
+	for i := 0; i < 8; i++ {
+		if ir, ok := o.(types.IndirectRef); ok {
+			objNr = ir.ObjectNumber.Value()
+		}
+
+		o1, err := xRefTable.Dereference(o)
+		if err != nil || o1 == nil {
+			return nil, 0, err
+		}
+
+		switch o1 := o1.(type) {
+		case types.StreamDict:
+			return &o1, objNr, nil
+		case types.Dict:
+			// 3D reference dictionary
+			o = o1["3DD"]
+		default:
+			return nil, 0, errors.New("pdfcpu: invalid 3DD entry")
+		}
+	}
+
+	return nil, 0, errors.New("pdfcpu: 3D reference chain too long")
+}
+
+func threeDViewName(xRefTable *model.XRefTable, o types.Object) (string, error) {
+	d, err := xRefTable.DereferenceDict(o)
+	if err != nil || d == nil {
+		return "", err
+	}
+	for _, k := range []string{"XN", "IN"} {
+		if o, found := d.Find(k); found {
+			return xRefTable.DereferenceText(o)
+		}
+	}
+	return "", nil
+}
+
+func threeDViews(xRefTable *model.XRefTable, sd *types.StreamDict, m *ThreeDModel) error {
+	a, err := xRefTable.DereferenceArray(sd.Dict["VA"])
+	if err != nil {
+		return err
+	}
+
+	for _, o := range a {
+		s, err := threeDViewName(xRefTable, o)
+		if err != nil {
+			return err
+		}
+		m.Views = append(m.Views, s)
+	}
+
+	o, found := sd.Find("DV")
+	if !found {
+		if len(m.Views) > 0 {
+			m.DefaultView = m.Views[0]
+		}
+		return nil
+	}
+
+	o, err = xRefTable.Dereference(o)
+	if err != nil {
+		return err
+	}
+
+	switch o := o.(type) {
+	case types.Integer:
+		if i := o.Value(); i >= 0 && i < len(m.Views) {
+			m.DefaultView = m.Views[i]
+		}
+	case types.Name:
+		if o.Value() == "L" && len(m.Views) > 0 {
+			m.DefaultView = m.Views[len(m.Views)-1]
+		} else if o.Value() == "F" && len(m.Views) > 0 {
+			m.DefaultView = m.Views[0]
+		}
+	case types.StringLiteral, types.HexLiteral:
+		m.DefaultView, err = xRefTable.DereferenceText(o)
+	case types.Dict:
+		m.DefaultView, err = threeDViewName(xRefTable, o)
+	}
+
+	return err
+}
+
+// ThreeDAnnotationModel returns the 3D artwork of 3D annotation d.
+func ThreeDAnnotationModel(xRefTable *model.XRefTable, d types.Dict, objNr int) (*ThreeDModel, error) {
+	sd, streamObjNr, err := threeDStream(xRefTable, d["3DD"])
+	if err != nil || sd == nil {
+		return nil, err
+	}
+
+	m := &ThreeDModel{ObjNr: objNr, StreamObjNr: streamObjNr, Activation: "XA", Deactivation: "PC"}
+
+	if s := sd.NameEntry("Subtype"); s != nil {
+		m.Format = *s
+	}
+
+	if o, found := d.Find("NM"); found {
+		if m.ID, err = xRefTable.DereferenceText(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if a, err := xRefTable.DereferenceArray(d["Rect"]); err == nil && len(a) == 4 {
+		if r, err := xRefTable.RectForArray(a); err == nil {
+			m.Rect = *r
+		}
+	}
+
+	if d1, err := xRefTable.DereferenceDict(d["3DA"]); err == nil && d1 != nil {
+		if s := d1.NameEntry("A"); s != nil {
+			m.Activation = *s
+		}
+		if s := d1.NameEntry("D"); s != nil {
+			m.Deactivation = *s
+		}
+	}
+
+	if b := d.BooleanEntry("3DI"); b != nil {
+		m.Interactive = *b
+	} else {
+		m.Interactive = true
+	}
+
+	if err := threeDViews(xRefTable, sd, m); err != nil {
+		return nil, err
+	}
+
+	// Work on a copy in order to keep the original stream untouched.
+	sd1 := *sd
+	if err := sd1.Decode(); err != nil {
+		return nil, err
+	}
+	m.Reader = bytes.NewReader(sd1.Content)
+
+	if m.Format == "" {
+		m.Format = model.ThreeDFormat(sd1.Content)
+	}
+
+	return m, nil
+}
+
+// ThreeDModels returns the 3D artwork of all 3D annotations of page pageNr.
+func ThreeDModels(ctx *model.Context, pageNr int) ([]ThreeDModel, error) {
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.Errorf("pdfcpu: ThreeDModels: unknown page number: %d", pageNr)
+	}
+
+	a, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	var mm []ThreeDModel
+
+	for _, o := range a {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d1 == nil {
+			continue
+		}
+		if st := d1.NameEntry("Subtype"); st == nil || *st != "3D" {
+			continue
+		}
+		objNr := 0
+		if ir, ok := o.(types.IndirectRef); ok {
+			objNr = ir.ObjectNumber.Value()
+		}
+		m, err := ThreeDAnnotationModel(ctx.XRefTable, d1, objNr)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			continue
+		}
+		m.PageNr = pageNr
+		mm = append(mm, *m)
+	}
+
+	return mm, nil
+}
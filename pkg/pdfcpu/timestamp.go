@@ -0,0 +1,387 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/sign"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// DocTimeStampContentsSize is the default number of bytes reserved for a time-stamp token.
+const DocTimeStampContentsSize = 16384
+
+// byteRangePlaceholder reserves enough space for any ByteRange of a file < 10GB.
+const byteRangePlaceholder = 9999999999
+
+// markForIncrement marks the object referenced by o for incremental writing.
+// It returns false for direct objects.
+func markForIncrement(ctx *model.Context, o types.Object) bool {
+	ir, ok := o.(types.IndirectRef)
+	if ok {
+		ctx.Write.IncrementWithObjNr(ir.ObjectNumber.Value())
+	}
+	return ok
+}
+
+func newIndirectObjectForIncrement(ctx *model.Context, o types.Object) (*types.IndirectRef, error) {
+	ir, err := ctx.IndRefForNewObject(o)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Write.IncrementWithObjNr(ir.ObjectNumber.Value())
+	return ir, nil
+}
+
+// docTimeStampAcroForm returns the AcroForm of ctx prepared for incremental writing.
+func docTimeStampAcroForm(ctx *model.Context, rootDict types.Dict) (types.Dict, error) {
+	o, found := rootDict.Find("AcroForm")
+	if !found || o == nil {
+		d := types.Dict{"Fields": types.Array{}}
+		ir, err := newIndirectObjectForIncrement(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		rootDict["AcroForm"] = *ir
+		ctx.Write.IncrementWithObjNr(ctx.Root.ObjectNumber.Value())
+		return d, nil
+	}
+
+	d, err := ctx.DereferenceDict(o)
+	if err != nil {
+		return nil, err
+	}
+	if !markForIncrement(ctx, o) {
+		ctx.Write.IncrementWithObjNr(ctx.Root.ObjectNumber.Value())
+	}
+
+	return d, nil
+}
+
+func docTimeStampFieldName(ctx *model.Context, fields types.Array) (string, error) {
+	names := map[string]bool{}
+	for _, o := range fields {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return "", err
+		}
+		if d == nil {
+			continue
+		}
+		s, err := d.StringOrHexLiteralEntry("T")
+		if err != nil {
+			return "", err
+		}
+		if s != nil {
+			names[*s] = true
+		}
+	}
+
+	for i := 1; ; i++ {
+		s := "DocTimeStamp" + strconv.Itoa(i)
+		if !names[s] {
+			return s, nil
+		}
+	}
+}
+
+func addDocTimeStampWidget(ctx *model.Context, widget types.Dict) (*types.IndirectRef, error) {
+	pageDict, pageIndRef, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		return nil, err
+	}
+	if pageDict == nil || pageIndRef == nil {
+		return nil, errors.New("pdfcpu: DocTimeStamp: missing page")
+	}
+
+	widget["P"] = *pageIndRef
+
+	ir, err := newIndirectObjectForIncrement(ctx, widget)
+	if err != nil {
+		return nil, err
+	}
+
+	o, found := pageDict.Find("Annots")
+	if !found || o == nil {
+		pageDict["Annots"] = types.Array{*ir}
+		ctx.Write.IncrementWithObjNr(pageIndRef.ObjectNumber.Value())
+		return ir, nil
+	}
+
+	annots, err := ctx.DereferenceArray(o)
+	if err != nil {
+		return nil, err
+	}
+	annots = append(annots, *ir)
+
+	if indRef, ok := o.(types.IndirectRef); ok {
+		entry, found := ctx.FindTableEntryForIndRef(&indRef)
+		if !found {
+			return nil, errors.New("pdfcpu: DocTimeStamp: corrupt page annotations")
+		}
+		entry.Object = annots
+		ctx.Write.IncrementWithObjNr(indRef.ObjectNumber.Value())
+		return ir, nil
+	}
+
+	pageDict["Annots"] = annots
+	ctx.Write.IncrementWithObjNr(pageIndRef.ObjectNumber.Value())
+
+	return ir, nil
+}
+
+func addDocTimeStampField(ctx *model.Context, acroForm types.Dict, ir types.IndirectRef) error {
+	o := acroForm["Fields"]
+
+	fields, err := ctx.DereferenceArray(o)
+	if err != nil {
+		return err
+	}
+	fields = append(fields, ir)
+
+	if indRef, ok := o.(types.IndirectRef); ok {
+		entry, found := ctx.FindTableEntryForIndRef(&indRef)
+		if !found {
+			return errors.New("pdfcpu: DocTimeStamp: corrupt form fields")
+		}
+		entry.Object = fields
+		ctx.Write.IncrementWithObjNr(indRef.ObjectNumber.Value())
+		return nil
+	}
+
+	acroForm["Fields"] = fields
+
+	return nil
+}
+
+// PrepareDocTimeStamp adds an invisible signature field for a document time-stamp (ETSI.RFC3161) to ctx
+// reserving contentsSize bytes for the time-stamp token.
+// All touched objects are marked for writing as increment, see FinalizeDocTimeStamp.
+func PrepareDocTimeStamp(ctx *model.Context, contentsSize int) (*types.IndirectRef, error) {
+	if ctx.Encrypt != nil {
+		return nil, errors.New("pdfcpu: DocTimeStamp: encrypted files unsupported")
+	}
+
+	if contentsSize <= 0 {
+		contentsSize = DocTimeStampContentsSize
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Write.Increment = true
+	ctx.Write.Offset = ctx.Read.FileSize
+
+	acroForm, err := docTimeStampAcroForm(ctx, rootDict)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := ctx.DereferenceArray(acroForm["Fields"])
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := docTimeStampFieldName(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	sigDict := types.Dict{
+		"Type":      types.Name("DocTimeStamp"),
+		"Filter":    types.Name("Adobe.PPKLite"),
+		"SubFilter": types.Name("ETSI.RFC3161"),
+		"ByteRange": types.NewIntegerArray(0, byteRangePlaceholder, byteRangePlaceholder, byteRangePlaceholder),
+		"Contents":  types.HexLiteral(strings.Repeat("00", contentsSize)),
+	}
+
+	sigIndRef, err := newIndirectObjectForIncrement(ctx, sigDict)
+	if err != nil {
+		return nil, err
+	}
+
+	widget := types.Dict{
+		"Type":    types.Name("Annot"),
+		"Subtype": types.Name("Widget"),
+		"FT":      types.Name("Sig"),
+		"T":       types.StringLiteral(name),
+		"V":       *sigIndRef,
+		"Rect":    types.NewNumberArray(0, 0, 0, 0),
+		"F":       types.Integer(model.AnnPrint + model.AnnLocked),
+	}
+
+	ir, err := addDocTimeStampWidget(ctx, widget)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addDocTimeStampField(ctx, acroForm, *ir); err != nil {
+		return nil, err
+	}
+
+	// SignaturesExist, AppendOnly
+	sigFlags := 3
+	if i := acroForm.IntEntry("SigFlags"); i != nil {
+		sigFlags |= *i
+	}
+	acroForm["SigFlags"] = types.Integer(sigFlags)
+
+	return sigIndRef, nil
+}
+
+func readAt(rws io.ReadWriteSeeker, off int64, n int) ([]byte, error) {
+	if _, err := rws.Seek(off, io.SeekStart); err != nil {
+		return nil, err
+	}
+	bb := make([]byte, n)
+	n, err := io.ReadFull(rws, bb)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return bb[:n], nil
+}
+
+func writeAt(rws io.ReadWriteSeeker, off int64, bb []byte) error {
+	if _, err := rws.Seek(off, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := rws.Write(bb)
+	return err
+}
+
+// locateSigDictPlaceholders returns the file offsets of the ByteRange array and the Contents hex string
+// of the signature dict written at off.
+func locateSigDictPlaceholders(rws io.ReadWriteSeeker, off int64, contentsSize int) (brFrom, brTo, cFrom, cTo int64, err error) {
+	bb, err := readAt(rws, off, 2*contentsSize+1024)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	locate := func(key string, open, close byte) (int64, int64, error) {
+		i := bytes.Index(bb, []byte(key))
+		if i < 0 {
+			return 0, 0, errors.Errorf("pdfcpu: DocTimeStamp: missing %s", key)
+		}
+		i += len(key)
+		j := bytes.IndexByte(bb[i:], open)
+		k := bytes.IndexByte(bb[i:], close)
+		if j < 0 || k < j {
+			return 0, 0, errors.Errorf("pdfcpu: DocTimeStamp: corrupt %s", key)
+		}
+		return off + int64(i+j), off + int64(i+k+1), nil
+	}
+
+	if brFrom, brTo, err = locate("/ByteRange", '[', ']'); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	if cFrom, cTo, err = locate("/Contents", '<', '>'); err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	return brFrom, brTo, cFrom, cTo, nil
+}
+
+func byteRangeDigest(rws io.ReadWriteSeeker, byteRange [4]int64) ([]byte, error) {
+	h := sha256.New()
+	for i := 0; i < 4; i += 2 {
+		if _, err := rws.Seek(byteRange[i], io.SeekStart); err != nil {
+			return nil, err
+		}
+		if _, err := io.CopyN(h, rws, byteRange[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// FinalizeDocTimeStamp completes the document time-stamp sigDict prepared by PrepareDocTimeStamp
+// after ctx has been written as increment to rws.
+// The final ByteRange covers the whole file except the time-stamp token obtained from tsa.
+func FinalizeDocTimeStamp(ctx *model.Context, rws io.ReadWriteSeeker, sigDict types.IndirectRef, tsa sign.TSAClient) error {
+	if tsa == nil {
+		return errors.New("pdfcpu: DocTimeStamp: missing TSA client")
+	}
+
+	off, ok := ctx.Write.Table[sigDict.ObjectNumber.Value()]
+	if !ok || off == 0 {
+		return errors.New("pdfcpu: DocTimeStamp: signature dict not written")
+	}
+
+	d, err := ctx.DereferenceDict(sigDict)
+	if err != nil {
+		return err
+	}
+	hl, ok := d["Contents"].(types.HexLiteral)
+	if !ok {
+		return errors.New("pdfcpu: DocTimeStamp: corrupt signature dict")
+	}
+	contentsSize := len(hl) / 2
+
+	size, err := rws.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	brFrom, brTo, cFrom, cTo, err := locateSigDictPlaceholders(rws, off, contentsSize)
+	if err != nil {
+		return err
+	}
+
+	byteRange := [4]int64{0, cFrom, cTo, size - cTo}
+
+	s := fmt.Sprintf("[%d %d %d %d", byteRange[0], byteRange[1], byteRange[2], byteRange[3])
+	if int64(len(s)+1) > brTo-brFrom {
+		return errors.New("pdfcpu: DocTimeStamp: ByteRange exceeds reserved space")
+	}
+	s += strings.Repeat(" ", int(brTo-brFrom)-len(s)-1) + "]"
+	if err := writeAt(rws, brFrom, []byte(s)); err != nil {
+		return err
+	}
+
+	digest, err := byteRangeDigest(rws, byteRange)
+	if err != nil {
+		return err
+	}
+
+	token, err := tsa.TimeStampToken(digest, crypto.SHA256)
+	if err != nil {
+		return err
+	}
+
+	if 2*len(token) > int(cTo-cFrom-2) {
+		return errors.Errorf("pdfcpu: DocTimeStamp: time-stamp token (%d bytes) exceeds reserved space (%d bytes)", len(token), contentsSize)
+	}
+
+	if err := writeAt(rws, cFrom+1, []byte(hex.EncodeToString(token))); err != nil {
+		return err
+	}
+
+	_, err = rws.Seek(0, io.SeekEnd)
+	return err
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// ToUnicodeMap maps character codes to Unicode text as defined by a ToUnicode CMap.
+type ToUnicodeMap struct {
+	CodeLen int               // bytes per character code
+	M       map[uint32]string // character code -> Unicode
+}
+
+func hexTokenBytes(t string) []byte {
+	t = strings.Trim(t, "<>")
+	t = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\n' || r == '\r' || r == '\t' {
+			return -1
+		}
+		return r
+	}, t)
+	if len(t)%2 == 1 {
+		t += "0"
+	}
+	bb, _ := hex.DecodeString(t)
+	return bb
+}
+
+func codeForBytes(bb []byte) uint32 {
+	var c uint32
+	for _, b := range bb {
+		c = c<<8 | uint32(b)
+	}
+	return c
+}
+
+func utf16BEString(bb []byte) string {
+	if len(bb) == 1 {
+		return string(rune(bb[0]))
+	}
+	u := make([]uint16, 0, len(bb)/2)
+	for i := 0; i+1 < len(bb); i += 2 {
+		u = append(u, uint16(bb[i])<<8|uint16(bb[i+1]))
+	}
+	return string(utf16.Decode(u))
+}
+
+func incrementUTF16BE(bb []byte, n uint32) []byte {
+	bb1 := make([]byte, len(bb))
+	copy(bb1, bb)
+	c := codeForBytes(bb1[len(bb1)-2:]) + n
+	bb1[len(bb1)-2] = byte(c >> 8)
+	bb1[len(bb1)-1] = byte(c)
+	return bb1
+}
+
+func cMapTokens(s string) []string {
+	var tt []string
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " \t\r\n\f\x00")
+		if len(s) == 0 {
+			break
+		}
+		switch s[0] {
+		case '%':
+			i := strings.IndexAny(s, "\r\n")
+			if i < 0 {
+				return tt
+			}
+			s = s[i:]
+		case '<', '[', ']':
+			if strings.HasPrefix(s, "<<") {
+				tt = append(tt, "<<")
+				s = s[2:]
+				continue
+			}
+			if s[0] != '<' {
+				tt = append(tt, s[:1])
+				s = s[1:]
+				continue
+			}
+			i := strings.IndexByte(s, '>')
+			if i < 0 {
+				return tt
+			}
+			tt = append(tt, s[:i+1])
+			s = s[i+1:]
+		default:
+			i := strings.IndexAny(s, " \t\r\n\f<[]%")
+			if i == 0 {
+				i = 1
+			}
+			if i < 0 {
+				i = len(s)
+			}
+			tt = append(tt, s[:i])
+			s = s[i:]
+		}
+	}
+	return tt
+}
+
+func parseBFRange(tt []string, i int, m map[uint32]string) int {
+	for i+2 < len(tt) && tt[i] != "endbfrange" {
+		lo, hi := codeForBytes(hexTokenBytes(tt[i])), codeForBytes(hexTokenBytes(tt[i+1]))
+		if tt[i+2] == "[" {
+			j := i + 3
+			for c := lo; j < len(tt) && tt[j] != "]"; c, j = c+1, j+1 {
+				m[c] = utf16BEString(hexTokenBytes(tt[j]))
+			}
+			i = j + 1
+			continue
+		}
+		dst := hexTokenBytes(tt[i+2])
+		if len(dst) >= 2 && hi >= lo && hi-lo < 0x10000 {
+			for c := lo; c <= hi; c++ {
+				m[c] = utf16BEString(incrementUTF16BE(dst, c-lo))
+			}
+		}
+		i += 3
+	}
+	return i
+}
+
+// ParseToUnicodeCMap parses the content of a ToUnicode CMap stream.
+func ParseToUnicodeCMap(bb []byte) *ToUnicodeMap {
+	tum := &ToUnicodeMap{CodeLen: 1, M: map[uint32]string{}}
+	tt := cMapTokens(string(bb))
+	for i := 0; i < len(tt); i++ {
+		switch tt[i] {
+		case "begincodespacerange":
+			if i+1 < len(tt) {
+				tum.CodeLen = len(hexTokenBytes(tt[i+1]))
+			}
+		case "beginbfchar":
+			i++
+			for ; i+1 < len(tt) && tt[i] != "endbfchar"; i += 2 {
+				tum.M[codeForBytes(hexTokenBytes(tt[i]))] = utf16BEString(hexTokenBytes(tt[i+1]))
+			}
+		case "beginbfrange":
+			i = parseBFRange(tt, i+1, tum.M)
+		}
+	}
+	if tum.CodeLen < 1 || tum.CodeLen > 4 {
+		tum.CodeLen = 1
+	}
+	return tum
+}
+
+// Decode returns the Unicode text for the character codes in bb.
+func (tum ToUnicodeMap) Decode(bb []byte) string {
+	var sb strings.Builder
+	for i := 0; i+tum.CodeLen <= len(bb); i += tum.CodeLen {
+		if s, ok := tum.M[codeForBytes(bb[i:i+tum.CodeLen])]; ok {
+			sb.WriteString(s)
+		}
+	}
+	return sb.String()
+}
+
+// ToUnicodeMapForFontDict returns the parsed ToUnicode CMap of fontDict or nil.
+func ToUnicodeMapForFontDict(xRefTable *model.XRefTable, fontDict types.Dict) (*ToUnicodeMap, error) {
+	o, found := fontDict.Find("ToUnicode")
+	if !found {
+		return nil, nil
+	}
+	sd, _, err := xRefTable.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	if err := sd.Decode(); err != nil {
+		return nil, err
+	}
+	return ParseToUnicodeCMap(sd.Content), nil
+}
+
+// Bytes returns the content of a ToUnicode CMap stream for tum.
+func (tum ToUnicodeMap) Bytes() []byte {
+	var b bytes.Buffer
+
+	b.WriteString(`/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+/CIDSystemInfo <<
+	/Registry (Adobe)
+	/Ordering (UCS)
+	/Supplement 0
+>> def
+/CMapName /Adobe-Identity-UCS def
+/CMapType 2 def
+`)
+
+	codeFmt := fmt.Sprintf("<%%0%dX>", 2*tum.CodeLen)
+	b.WriteString("1 begincodespacerange\n")
+	fmt.Fprintf(&b, codeFmt+" "+codeFmt+"\n", 0, uint32(1)<<(8*tum.CodeLen)-1)
+	b.WriteString("endcodespacerange\n")
+
+	cc := make([]uint32, 0, len(tum.M))
+	for c := range tum.M {
+		cc = append(cc, c)
+	}
+	sort.Slice(cc, func(i, j int) bool { return cc[i] < cc[j] })
+
+	// At most 100 mappings per section.
+	for i := 0; i < len(cc); i += 100 {
+		j := min(i+100, len(cc))
+		fmt.Fprintf(&b, "%d beginbfchar\n", j-i)
+		for _, c := range cc[i:j] {
+			fmt.Fprintf(&b, codeFmt+" <", c)
+			for _, u := range utf16.Encode([]rune(tum.M[c])) {
+				fmt.Fprintf(&b, "%04X", u)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+
+	b.WriteString(`endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end`)
+
+	return b.Bytes()
+}
@@ -0,0 +1,427 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// ToUnicodeIssue describes a font whose text does not extract properly due to a missing or bogus ToUnicode CMap.
+type ToUnicodeIssue struct {
+	ObjNr    int    // object number of the font dict
+	BaseFont string // base font name
+	Problem  string // "missing" or "bogus"
+	Source   string // origin of the regenerated ToUnicode CMap: "encoding", "glyph names" or "font program", empty if not repairable
+	Codes    int    // character codes covered by the regenerated ToUnicode CMap
+}
+
+// Repairable returns true if a ToUnicode CMap could be regenerated for this font.
+func (ti ToUnicodeIssue) Repairable() bool {
+	return ti.Source != ""
+}
+
+// ToUnicodeReport summarizes the ToUnicode CMap audit of all fonts of a PDF.
+type ToUnicodeReport struct {
+	Fonts  int              // inspected fonts
+	Issues []ToUnicodeIssue // fonts extracting garbage
+}
+
+// Repaired returns the number of fonts that got a regenerated ToUnicode CMap.
+func (rep ToUnicodeReport) Repaired() int {
+	i := 0
+	for _, ti := range rep.Issues {
+		if ti.Repairable() {
+			i++
+		}
+	}
+	return i
+}
+
+// garbageText returns true for text that is of no use for text extraction.
+func garbageText(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r == unicode.ReplacementChar || r == 0xFFFE || r == 0xFFFF ||
+			unicode.Is(unicode.Co, r) || unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return true
+		}
+	}
+	return false
+}
+
+// bogusToUnicode returns true if tum maps nothing or mostly garbage.
+func bogusToUnicode(tum *ToUnicodeMap) bool {
+	if len(tum.M) == 0 {
+		return true
+	}
+	n := 0
+	for _, s := range tum.M {
+		if garbageText(s) {
+			n++
+		}
+	}
+	return 2*n > len(tum.M)
+}
+
+var reType1Encoding = regexp.MustCompile(`dup\s+(\d+)\s*/([^\s/\[\]{}()<>%]+)\s+put`)
+
+// type1ProgramEncoding returns the glyph names of the built-in encoding of an embedded Type 1 font program.
+func type1ProgramEncoding(xRefTable *model.XRefTable, fd types.Dict) map[byte]string {
+	sd, _, err := xRefTable.DereferenceStreamDict(fd["FontFile"])
+	if err != nil || sd == nil {
+		return nil
+	}
+	if err := sd.Decode(); err != nil {
+		return nil
+	}
+
+	// The encoding is part of the clear text portion.
+	bb := sd.Content
+	if l := sd.IntEntry("Length1"); l != nil && *l > 0 && *l < len(bb) {
+		bb = bb[:*l]
+	}
+
+	m := map[byte]string{}
+	for _, sm := range reType1Encoding.FindAllSubmatch(bb, -1) {
+		if c, err := strconv.Atoi(string(sm[1])); err == nil && c < 256 {
+			m[byte(c)] = string(sm[2])
+		}
+	}
+
+	return m
+}
+
+func fontDescriptor(xRefTable *model.XRefTable, d types.Dict) types.Dict {
+	fd, err := xRefTable.DereferenceDict(d["FontDescriptor"])
+	if err != nil {
+		return nil
+	}
+	return fd
+}
+
+func embeddedFont(xRefTable *model.XRefTable, d types.Dict) bool {
+	fd := fontDescriptor(xRefTable, d)
+	if fd == nil {
+		return false
+	}
+	for _, k := range []string{"FontFile", "FontFile2", "FontFile3"} {
+		if _, found := fd.Find(k); found {
+			return true
+		}
+	}
+	return false
+}
+
+// simpleFontEncoding returns the base encoding and glyph name differences of a simple font.
+func simpleFontEncoding(xRefTable *model.XRefTable, d types.Dict) (string, map[byte]string, error) {
+	o, err := xRefTable.Dereference(d["Encoding"])
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		base        string
+		differences = map[byte]string{}
+	)
+
+	switch enc := o.(type) {
+
+	case types.Name:
+		base = enc.Value()
+
+	case types.Dict:
+		if n := enc.NameEntry("BaseEncoding"); n != nil {
+			base = *n
+		}
+		a, err := xRefTable.DereferenceArray(enc["Differences"])
+		if err != nil {
+			return "", nil, err
+		}
+		c := 0
+		for _, o := range a {
+			o, _ = xRefTable.Dereference(o)
+			switch o := o.(type) {
+			case types.Integer:
+				c = o.Value()
+			case types.Name:
+				if c >= 0 && c < 256 {
+					differences[byte(c)] = o.Value()
+				}
+				c++
+			}
+		}
+	}
+
+	if base != "" {
+		return base, differences, nil
+	}
+
+	// Built-in encoding.
+	fd := fontDescriptor(xRefTable, d)
+	if fd != nil && d.Subtype() != nil && *d.Subtype() == "Type1" {
+		if m := type1ProgramEncoding(xRefTable, fd); len(m) > 0 {
+			for c, s := range m {
+				if _, ok := differences[c]; !ok {
+					differences[c] = s
+				}
+			}
+			return "", differences, nil
+		}
+	}
+
+	symbolic := false
+	if fd != nil {
+		if f := fd.IntEntry("Flags"); f != nil {
+			symbolic = *f&4 > 0
+		}
+	}
+
+	if st := d.Subtype(); !symbolic && st != nil {
+		switch *st {
+		case "TrueType":
+			base = "WinAnsiEncoding"
+		case "Type1", "MMType1":
+			base = "StandardEncoding"
+		}
+	}
+
+	return base, differences, nil
+}
+
+// simpleFontToUnicode derives a ToUnicode map for a simple font from its encoding.
+func simpleFontToUnicode(xRefTable *model.XRefTable, d types.Dict) (*ToUnicodeMap, string, error) {
+	base, differences, err := simpleFontEncoding(xRefTable, d)
+	if err != nil {
+		return nil, "", err
+	}
+
+	first, last := 0, 255
+	if i := d.IntEntry("FirstChar"); i != nil && *i >= 0 && *i < 256 {
+		first = *i
+	}
+	if i := d.IntEntry("LastChar"); i != nil && *i >= first && *i < 256 {
+		last = *i
+	}
+
+	tum := &ToUnicodeMap{CodeLen: 1, M: map[uint32]string{}}
+	source := "encoding"
+
+	for c := first; c <= last; c++ {
+		if name, ok := differences[byte(c)]; ok {
+			if s, ok := glyphNameText(name); ok {
+				tum.M[uint32(c)] = s
+				source = "glyph names"
+			}
+			continue
+		}
+		if s, ok := baseEncodingText(base, byte(c)); ok {
+			tum.M[uint32(c)] = s
+		}
+	}
+
+	if len(tum.M) == 0 {
+		return nil, "", nil
+	}
+
+	return tum, source, nil
+}
+
+// type0FontToUnicode derives a ToUnicode map for a Type0 font using Identity-H or Identity-V
+// from the Unicode cmap of its embedded TrueType font program.
+func type0FontToUnicode(xRefTable *model.XRefTable, d types.Dict) (*ToUnicodeMap, error) {
+	if enc := d.NameEntry("Encoding"); enc == nil || *enc != "Identity-H" && *enc != "Identity-V" {
+		return nil, nil
+	}
+
+	cd, err := descendantFont(xRefTable, d)
+	if err != nil || cd == nil {
+		return nil, err
+	}
+
+	fd := fontDescriptor(xRefTable, cd)
+	if fd == nil {
+		return nil, nil
+	}
+	sd, _, err := xRefTable.DereferenceStreamDict(fd["FontFile2"])
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	if err := sd.Decode(); err != nil {
+		return nil, err
+	}
+
+	m, err := font.GlyphUnicodes(sd.Content)
+	if err != nil {
+		// Subset font programs often lack a Unicode cmap.
+		return nil, nil
+	}
+
+	// CID => GID, missing or Identity for CID == GID.
+	var cidToGID []byte
+	if sd1, _, err := xRefTable.DereferenceStreamDict(cd["CIDToGIDMap"]); err == nil && sd1 != nil {
+		if err := sd1.Decode(); err != nil {
+			return nil, err
+		}
+		cidToGID = sd1.Content
+	}
+
+	tum := &ToUnicodeMap{CodeLen: 2, M: map[uint32]string{}}
+
+	if cidToGID == nil {
+		for gid, u := range m {
+			if gid > 0 && !garbageText(string(rune(u))) {
+				tum.M[uint32(gid)] = string(rune(u))
+			}
+		}
+		return tum, nil
+	}
+
+	for cid := 0; 2*cid+1 < len(cidToGID); cid++ {
+		gid := uint16(cidToGID[2*cid])<<8 | uint16(cidToGID[2*cid+1])
+		if u, ok := m[gid]; ok && gid > 0 && !garbageText(string(rune(u))) {
+			tum.M[uint32(cid)] = string(rune(u))
+		}
+	}
+
+	return tum, nil
+}
+
+// toUnicodeProblem checks if text shown using the font d extracts as garbage.
+func toUnicodeProblem(xRefTable *model.XRefTable, d types.Dict, type0 bool) (string, error) {
+	if _, found := d.Find("ToUnicode"); found {
+		tum, err := ToUnicodeMapForFontDict(xRefTable, d)
+		if err != nil || tum == nil || bogusToUnicode(tum) {
+			return "bogus", nil
+		}
+		return "", nil
+	}
+
+	if type0 {
+		// Predefined CMaps other than Identity-H/V are Unicode aware.
+		if enc := d.NameEntry("Encoding"); enc != nil && (*enc == "Identity-H" || *enc == "Identity-V") {
+			return "missing", nil
+		}
+		return "", nil
+	}
+
+	// Text using standard encodings extracts fine, so do the built-in encodings of the standard 14 fonts.
+	base, differences, err := simpleFontEncoding(xRefTable, d)
+	if err != nil {
+		return "", err
+	}
+	if len(differences) > 0 || base == "" && embeddedFont(xRefTable, d) {
+		return "missing", nil
+	}
+
+	return "", nil
+}
+
+func repairToUnicode(ctx *model.Context, repair bool) (*ToUnicodeReport, error) {
+	xRefTable := ctx.XRefTable
+
+	objNrs := []int{}
+	for objNr, entry := range xRefTable.Table {
+		if entry == nil || entry.Free {
+			continue
+		}
+		if d, ok := entry.Object.(types.Dict); ok && d.Type() != nil && *d.Type() == "Font" {
+			objNrs = append(objNrs, objNr)
+		}
+	}
+	sort.Ints(objNrs)
+
+	rep := &ToUnicodeReport{}
+
+	for _, objNr := range objNrs {
+		d := xRefTable.Table[objNr].Object.(types.Dict)
+
+		st := d.Subtype()
+		if st == nil {
+			continue
+		}
+		type0 := *st == "Type0"
+		if !type0 && *st != "Type1" && *st != "MMType1" && *st != "TrueType" && *st != "Type3" {
+			// CIDFonts are covered by their Type0 font.
+			continue
+		}
+
+		rep.Fonts++
+
+		problem, err := toUnicodeProblem(xRefTable, d, type0)
+		if err != nil {
+			return nil, err
+		}
+		if problem == "" {
+			continue
+		}
+
+		ti := ToUnicodeIssue{ObjNr: objNr, Problem: problem}
+		if bf := d.NameEntry("BaseFont"); bf != nil {
+			ti.BaseFont = *bf
+		}
+
+		var (
+			tum    *ToUnicodeMap
+			source = "font program"
+		)
+		if type0 {
+			tum, err = type0FontToUnicode(xRefTable, d)
+		} else {
+			tum, source, err = simpleFontToUnicode(xRefTable, d)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if tum != nil && len(tum.M) > 0 {
+			ti.Source, ti.Codes = source, len(tum.M)
+			if repair {
+				// Don't touch a ToUnicode CMap possibly shared with other fonts.
+				indRef, err := xRefTable.StreamDictIndRef(tum.Bytes())
+				if err != nil {
+					return nil, err
+				}
+				d["ToUnicode"] = *indRef
+			}
+		}
+
+		rep.Issues = append(rep.Issues, ti)
+	}
+
+	return rep, nil
+}
+
+// AuditToUnicode detects fonts whose text extracts as garbage due to missing or bogus ToUnicode CMaps
+// and reports whether their ToUnicode CMaps could be regenerated.
+func AuditToUnicode(ctx *model.Context) (*ToUnicodeReport, error) {
+	return repairToUnicode(ctx, false)
+}
+
+// RepairToUnicode detects fonts whose text extracts as garbage due to missing or bogus ToUnicode CMaps
+// and regenerates their ToUnicode CMaps from the font encoding, glyph names or the cmap table of the embedded font program where possible.
+func RepairToUnicode(ctx *model.Context) (*ToUnicodeReport, error) {
+	return repairToUnicode(ctx, true)
+}
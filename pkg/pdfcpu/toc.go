@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/create"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultTOCTitle    = "Contents"
+	defaultTOCFont     = "Helvetica"
+	defaultTOCFontSize = 12
+	tocMargin          = 72
+)
+
+// TOCConfig represents the command details for merging with a table of contents.
+type TOCConfig struct {
+	Title     string   // Heading of the TOC page, default "Contents".
+	Titles    []string // One entry title per merged document, defaults to the document title.
+	TOCPage   bool     // Generate TOC pages in front of the merged documents.
+	Bookmarks bool     // Generate an outline entry for each merged document.
+	PaperSize string   // TOC page size, default A4.
+	FontName  string   // Core font used for the TOC page, default Helvetica.
+	FontSize  int      // Default 12.
+}
+
+type tocEntry struct {
+	title  string
+	pageNr int
+}
+
+func (tc *TOCConfig) validate() error {
+	if !tc.TOCPage && !tc.Bookmarks {
+		return errors.New("pdfcpu: TOC: please enable TOC page and/or bookmarks")
+	}
+	if tc.Title == "" {
+		tc.Title = defaultTOCTitle
+	}
+	if tc.PaperSize == "" {
+		tc.PaperSize = "A4"
+	}
+	if types.PaperSize[tc.PaperSize] == nil {
+		return errors.Errorf("pdfcpu: TOC: unknown paper size: %s", tc.PaperSize)
+	}
+	if tc.FontName == "" {
+		tc.FontName = defaultTOCFont
+	}
+	if tc.FontSize <= 0 {
+		tc.FontSize = defaultTOCFontSize
+	}
+	return nil
+}
+
+func tocEntryTitle(ctx *model.Context, tc *TOCConfig, i int) string {
+	if i < len(tc.Titles) && tc.Titles[i] != "" {
+		return tc.Titles[i]
+	}
+	if ctx.Title != "" {
+		return ctx.Title
+	}
+	if ctx.Read != nil && ctx.Read.FileName != "" {
+		fn := filepath.Base(ctx.Read.FileName)
+		return strings.TrimSuffix(fn, filepath.Ext(fn))
+	}
+	return fmt.Sprintf("Document %d", i+1)
+}
+
+// tocLines returns the TOC lines fitting on each TOC page, the first one also holding the heading.
+func (tc *TOCConfig) tocLines() (int, int) {
+	dim := types.PaperSize[tc.PaperSize]
+	lh := 1.8 * float64(tc.FontSize)
+	avail := dim.Height - 2*tocMargin
+	first := int((avail - 3*float64(tc.FontSize)) / lh)
+	if first < 1 {
+		first = 1
+	}
+	other := int(avail / lh)
+	if other < 1 {
+		other = 1
+	}
+	return first, other
+}
+
+func (tc *TOCConfig) tocPageCount(entries int) int {
+	first, other := tc.tocLines()
+	if entries <= first {
+		return 1
+	}
+	return 1 + (entries-first+other-1)/other
+}
+
+// tocLayout returns the baseline of each entry by TOC page.
+func (tc *TOCConfig) tocLayout(entries int) [][]float64 {
+	dim := types.PaperSize[tc.PaperSize]
+	first, other := tc.tocLines()
+	lh := 1.8 * float64(tc.FontSize)
+
+	pp := [][]float64{}
+	y, n := dim.Height-tocMargin-3*float64(tc.FontSize), first
+	for i := 0; i < entries; {
+		yy := []float64{}
+		for j := 0; j < n && i < entries; i, j = i+1, j+1 {
+			yy = append(yy, y-float64(j)*lh)
+		}
+		pp = append(pp, yy)
+		y, n = dim.Height-tocMargin, other
+	}
+	if len(pp) == 0 {
+		pp = append(pp, nil)
+	}
+	return pp
+}
+
+func tocText(value string, x, y float64, font map[string]interface{}, align string) map[string]interface{} {
+	return map[string]interface{}{
+		"value": value,
+		"pos":   []float64{x, y},
+		"font":  font,
+		"align": align,
+	}
+}
+
+// tocJSON renders the TOC pages into JSON input for the primitives engine.
+func tocJSON(tc *TOCConfig, ee []tocEntry, layout [][]float64) ([]byte, error) {
+	dim := types.PaperSize[tc.PaperSize]
+	font := map[string]interface{}{"name": tc.FontName, "size": tc.FontSize}
+	headFont := map[string]interface{}{"name": tc.FontName, "size": 2 * tc.FontSize}
+
+	pages := map[string]interface{}{}
+	k := 0
+	for i, yy := range layout {
+		tt := []interface{}{}
+		if i == 0 {
+			tt = append(tt, tocText(tc.Title, tocMargin, dim.Height-tocMargin, headFont, "Left"))
+		}
+		for _, y := range yy {
+			e := ee[k]
+			tt = append(tt,
+				tocText(e.title, tocMargin, y, font, "Left"),
+				tocText(strconv.Itoa(e.pageNr), dim.Width-tocMargin, y, font, "Right"))
+			k++
+		}
+		pages[strconv.Itoa(i+1)] = map[string]interface{}{"content": map[string]interface{}{"text": tt}}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"paper":  tc.PaperSize,
+		"origin": "LowerLeft",
+		"pages":  pages,
+	})
+}
+
+func addTOCLinks(ctx *model.Context, tc *TOCConfig, ee []tocEntry, layout [][]float64) error {
+	dim := types.PaperSize[tc.PaperSize]
+	fs := float64(tc.FontSize)
+	k := 0
+	for i, yy := range layout {
+		for _, y := range yy {
+			r := types.NewRectangle(tocMargin, y-fs/2, dim.Width-tocMargin, y+1.5*fs)
+			dest := &model.Destination{Typ: model.DestFit, PageNr: ee[k].pageNr}
+			ann := model.NewLinkAnnotation(*r, 0, "", "", "", 0, nil, dest, "", nil, false, 0, model.BSSolid)
+			if _, _, err := AddAnnotationToPage(ctx, i+1, ann, false); err != nil {
+				return err
+			}
+			k++
+		}
+	}
+	return nil
+}
+
+func tocDestContext(ctxs []*model.Context, tc *TOCConfig, ee []tocEntry, layout [][]float64) (*model.Context, error) {
+	ctx, err := CreateContextWithXRefTable(ctxs[0].Configuration, types.PaperSize[tc.PaperSize])
+	if err != nil {
+		return nil, err
+	}
+
+	bb, err := tocJSON(tc, ee, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create.FromJSON(ctx, bytes.NewReader(bb)); err != nil {
+		return nil, err
+	}
+
+	// Round trip in order to obtain a context suitable as merge destination.
+	var buf bytes.Buffer
+	ctx.Write.Writer = bufio.NewWriter(&buf)
+	if err := WriteContext(ctx); err != nil {
+		return nil, err
+	}
+	if err := ctx.Write.Flush(); err != nil {
+		return nil, err
+	}
+
+	ctxDest, err := Read(bytes.NewReader(buf.Bytes()), ctxs[0].Configuration)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctxDest.EnsurePageCount(); err != nil {
+		return nil, err
+	}
+	ctxDest.EnsureVersionForWriting()
+
+	return ctxDest, nil
+}
+
+// MergeCreateTOC merges ctxs and lists each merged document with its starting page
+// on TOC pages in front of the result and/or as outline entries.
+func MergeCreateTOC(ctxs []*model.Context, tc *TOCConfig) (*model.Context, error) {
+	if len(ctxs) == 0 {
+		return nil, errors.New("pdfcpu: TOC: missing documents")
+	}
+	if tc == nil {
+		tc = &TOCConfig{TOCPage: true}
+	}
+	if err := tc.validate(); err != nil {
+		return nil, err
+	}
+
+	tocPages, layout := 0, [][]float64{}
+	if tc.TOCPage {
+		tocPages = tc.tocPageCount(len(ctxs))
+		layout = tc.tocLayout(len(ctxs))
+	}
+
+	ee := make([]tocEntry, len(ctxs))
+	p := tocPages + 1
+	for i, ctx := range ctxs {
+		ee[i] = tocEntry{title: tocEntryTitle(ctx, tc, i), pageNr: p}
+		p += ctx.PageCount
+	}
+
+	titles := make([]string, len(ee))
+	for i, e := range ee {
+		titles[i] = e.title
+	}
+
+	ctxDest, srcs, outlineTitle := ctxs[0], ctxs[1:], titles[0]
+	if tc.TOCPage {
+		var err error
+		if ctxDest, err = tocDestContext(ctxs, tc, ee, layout); err != nil {
+			return nil, err
+		}
+		srcs, outlineTitle = ctxs, tc.Title
+	} else {
+		if err := ctxDest.RemoveSignatures(); err != nil {
+			return nil, err
+		}
+		titles = titles[1:]
+	}
+
+	ctxDest.Configuration.CreateBookmarks = tc.Bookmarks
+	if tc.Bookmarks {
+		if err := EnsureOutlines(ctxDest, outlineTitle, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, ctxSrc := range srcs {
+		if ctxDest.XRefTable.Version() < model.V20 && ctxSrc.XRefTable.Version() == model.V20 {
+			return nil, ErrUnsupportedVersion
+		}
+		if err := ctxSrc.RemoveSignatures(); err != nil {
+			return nil, err
+		}
+		if err := MergeXRefTables(titles[i], ctxSrc, ctxDest, false, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if tc.TOCPage {
+		if err := addTOCLinks(ctxDest, tc, ee, layout); err != nil {
+			return nil, err
+		}
+	}
+
+	return ctxDest, nil
+}
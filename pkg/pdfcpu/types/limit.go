@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "fmt"
+
+// Resource limits, see model.Limits.
+const (
+	LimitObjects      = "objects"
+	LimitNestingDepth = "nestingDepth"
+	LimitStreamSize   = "streamSize"
+	LimitXRefSections = "xrefSections"
+)
+
+// LimitError reports a resource limit exceeded while processing a PDF.
+type LimitError struct {
+	Limit string // One of the Limit* constants.
+	Max   int64  // The configured maximum.
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("pdfcpu: limit exceeded: %s > %d", e.Limit, e.Max)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// SpillFile is a temporary file holding stream data moved out of memory.
+type SpillFile struct {
+	mu  sync.Mutex
+	f   *os.File
+	off int64
+}
+
+// contentIsRaw is the content length of spilled streams whose content shares the raw buffer.
+const contentIsRaw = -2
+
+// spill locates stream data within a SpillFile or within the input of a deferred stream.
+// A length of -1 represents a nil buffer.
+type spill struct {
+	r                  io.ReaderAt
+	off                int64
+	rawLen, contentLen int
+}
+
+// NewSpillFile creates a SpillFile in dir, the default directory for temporary files if empty.
+// The file is removed by Close or once the SpillFile is no longer referenced.
+func NewSpillFile(dir string) (*SpillFile, error) {
+	f, err := os.CreateTemp(dir, "pdfcpu-spill-*")
+	if err != nil {
+		return nil, err
+	}
+	sf := &SpillFile{f: f}
+	runtime.SetFinalizer(sf, (*SpillFile).Close)
+	return sf, nil
+}
+
+// Size returns the number of bytes written to sf.
+func (sf *SpillFile) Size() int64 {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+	return sf.off
+}
+
+// Close closes and removes sf.
+func (sf *SpillFile) Close() error {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+	if sf.f == nil {
+		return nil
+	}
+	fn := sf.f.Name()
+	err := sf.f.Close()
+	sf.f = nil
+	if err1 := os.Remove(fn); err == nil {
+		err = err1
+	}
+	return err
+}
+
+func (sf *SpillFile) write(bb ...[]byte) (int64, error) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+	if sf.f == nil {
+		return 0, errors.New("pdfcpu: spill file closed")
+	}
+	off := sf.off
+	for _, b := range bb {
+		n, err := sf.f.WriteAt(b, sf.off)
+		sf.off += int64(n)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return off, nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (sf *SpillFile) ReadAt(p []byte, off int64) (int, error) {
+	sf.mu.Lock()
+	f := sf.f
+	sf.mu.Unlock()
+	if f == nil {
+		return 0, errors.New("pdfcpu: spill file closed")
+	}
+	return f.ReadAt(p, off)
+}
+
+func bufLen(bb []byte) int {
+	if bb == nil {
+		return -1
+	}
+	return len(bb)
+}
+
+func bufFrom(bb []byte, off, n int) []byte {
+	if n < 0 {
+		return nil
+	}
+	return bb[off : off+n : off+n]
+}
+
+// Spill moves the stream data of sd to sf and returns the number of bytes released.
+// The stream data gets loaded back transparently by Decode, Encode and Load.
+func (sd *StreamDict) Spill(sf *SpillFile) (int64, error) {
+	if sd.Raw == nil && sd.Content == nil {
+		return 0, nil
+	}
+
+	s := &spill{r: sf, rawLen: bufLen(sd.Raw), contentLen: bufLen(sd.Content)}
+	bb := [][]byte{sd.Raw, sd.Content}
+
+	// Streams without filters share their buffers.
+	if len(sd.Raw) > 0 && len(sd.Content) == len(sd.Raw) && &sd.Content[0] == &sd.Raw[0] {
+		s.contentLen = contentIsRaw
+		bb = bb[:1]
+	}
+
+	off, err := sf.write(bb...)
+	if err != nil {
+		return 0, err
+	}
+	s.off = off
+
+	n := int64(max(s.rawLen, 0) + max(s.contentLen, 0))
+	sd.spill = s
+	sd.Raw, sd.Content = nil, nil
+
+	return n, nil
+}
+
+// Defer sets the raw stream data of sd to the n bytes located in r at off without loading them.
+// r needs to remain readable as long as sd is in use.
+func (sd *StreamDict) Defer(r io.ReaderAt, off, n int64) {
+	sd.spill = &spill{r: r, off: off, rawLen: int(n), contentLen: -1}
+	sd.Raw, sd.Content = nil, nil
+}
+
+// Spilled returns true if the stream data of sd is not held in memory, see Spill and Defer.
+func (sd StreamDict) Spilled() bool {
+	return sd.spill != nil && sd.Raw == nil && sd.Content == nil
+}
+
+// Load loads stream data moved to a SpillFile back into memory.
+// Stream data assigned after spilling takes precedence.
+func (sd *StreamDict) Load() error {
+	s := sd.spill
+	if s == nil {
+		return nil
+	}
+	sd.spill = nil
+	if sd.Raw != nil || sd.Content != nil {
+		return nil
+	}
+
+	bb := make([]byte, max(s.rawLen, 0)+max(s.contentLen, 0))
+	if _, err := s.r.ReadAt(bb, s.off); err != nil {
+		return errors.Wrap(err, "pdfcpu: loading stream data")
+	}
+
+	sd.Raw = bufFrom(bb, 0, s.rawLen)
+	if s.contentLen == contentIsRaw {
+		sd.Content = sd.Raw
+		return nil
+	}
+	sd.Content = bufFrom(bb, max(s.rawLen, 0), s.contentLen)
+
+	return nil
+}
+
+// CopyRaw writes the raw stream data of sd to w.
+// Stream data not held in memory gets copied without loading it.
+func (sd StreamDict) CopyRaw(w io.Writer) (int64, error) {
+	if !sd.Spilled() {
+		n, err := w.Write(sd.Raw)
+		return int64(n), err
+	}
+	s := sd.spill
+	return io.Copy(w, io.NewSectionReader(s.r, s.off, int64(max(s.rawLen, 0))))
+}
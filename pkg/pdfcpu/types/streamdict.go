@@ -46,6 +46,8 @@ type StreamDict struct {
 	//DCTImage          image.Image
 	IsPageContent bool
 	CSComponents  int
+	MaxDecodedLen int64  // If > 0 decoding fails with a LimitError for data decoding to more bytes.
+	spill         *spill // Stream data moved to a SpillFile.
 }
 
 // NewStreamDict creates a new PDFStreamDict for given PDFDict, stream offset and length.
@@ -61,6 +63,8 @@ func NewStreamDict(d Dict, streamOffset int64, streamLength *int64, streamLength
 		//nil,
 		false,
 		0,
+		0,
+		nil,
 	}
 }
 
@@ -68,6 +72,10 @@ func NewStreamDict(d Dict, streamOffset int64, streamLength *int64, streamLength
 func (sd StreamDict) Clone() Object {
 	sd1 := sd
 	sd1.Dict = sd.Dict.Clone().(Dict)
+	if sd.FilterPipeline == nil {
+		// No filter, see Decode.
+		return sd1
+	}
 	pl := make([]PDFFilter, len(sd.FilterPipeline))
 	for k, v := range sd.FilterPipeline {
 		f := PDFFilter{}
@@ -237,6 +245,10 @@ func parmsForFilter(d Dict) map[string]int {
 
 // Encode applies sd's filter pipeline to sd.Content in order to produce sd.Raw.
 func (sd *StreamDict) Encode() error {
+	if err := sd.Load(); err != nil {
+		return err
+	}
+
 	if sd.Content == nil && sd.Raw != nil {
 		// Not decoded yet, no need to encode.
 		return nil
@@ -365,6 +377,8 @@ func (sd *StreamDict) decodeLength(maxLen int64) ([]byte, error) {
 
 		if maxLen >= 0 && idx == len(sd.FilterPipeline)-1 {
 			c, err = fi.DecodeLength(b, maxLen)
+		} else if sd.MaxDecodedLen > 0 {
+			c, err = sd.decodeLimited(fi, b)
 		} else {
 			c, err = fi.Decode(b)
 		}
@@ -393,17 +407,46 @@ func (sd *StreamDict) decodeLength(maxLen int64) ([]byte, error) {
 		return data, nil
 	}
 
-	return data[:maxLen], nil
+	return prefix(data, maxLen), nil
+}
+
+// prefix returns the first maxLen bytes of bb or bb if shorter.
+func prefix(bb []byte, maxLen int64) []byte {
+	if int64(len(bb)) > maxLen {
+		return bb[:maxLen]
+	}
+	return bb
+}
+
+// decodeLimited applies fi to r and fails if the result exceeds sd.MaxDecodedLen.
+func (sd *StreamDict) decodeLimited(fi filter.Filter, r io.Reader) (io.Reader, error) {
+	max := sd.MaxDecodedLen
+	c, err := fi.DecodeLength(r, max+1)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(c, max+1)); err != nil {
+		return nil, err
+	}
+	if int64(buf.Len()) > max {
+		return nil, &LimitError{Limit: LimitStreamSize, Max: max}
+	}
+	return &buf, nil
 }
 
 func (sd *StreamDict) DecodeLength(maxLen int64) ([]byte, error) {
+	if err := sd.Load(); err != nil {
+		return nil, err
+	}
+
 	if sd.Content != nil {
 		// This stream has already been decoded.
 		if maxLen < 0 {
 			return sd.Content, nil
 		}
 
-		return sd.Content[:maxLen], nil
+		return prefix(sd.Content, maxLen), nil
 	}
 
 	fpl := sd.FilterPipeline
@@ -416,7 +459,7 @@ func (sd *StreamDict) DecodeLength(maxLen int64) ([]byte, error) {
 			return sd.Content, nil
 		}
 
-		return sd.Content[:maxLen], nil
+		return prefix(sd.Content, maxLen), nil
 	}
 
 	//fmt.Printf("decodedStream before:\n%s\n", hex.Dump(sd.Raw))
@@ -424,6 +467,35 @@ func (sd *StreamDict) DecodeLength(maxLen int64) ([]byte, error) {
 	return sd.decodeLength(maxLen)
 }
 
+// DecodeValidPrefix decodes the longest prefix of sd.Raw that decodes without error
+// into sd.Content and returns the length of this prefix.
+func (sd *StreamDict) DecodeValidPrefix() int {
+	if err := sd.Load(); err != nil {
+		return 0
+	}
+
+	raw := sd.Raw
+	defer func() { sd.Raw = raw }()
+
+	// Binary search on the encoded length: raw[:lo] decodes, raw[:hi] does not.
+	lo, hi := 0, len(raw)
+	content := []byte{}
+
+	for lo+1 < hi {
+		m := (lo + hi) / 2
+		sd.Raw, sd.Content = raw[:m], nil
+		if bb, err := sd.decodeLength(-1); err == nil {
+			lo, content = m, bb
+			continue
+		}
+		hi = m
+	}
+
+	sd.Content = content
+
+	return lo
+}
+
 // IndexedObject returns the object at given index from a ObjectStreamDict.
 func (osd *ObjectStreamDict) IndexedObject(index int) (Object, error) {
 	if osd.ObjArray == nil || index < 0 || index >= len(osd.ObjArray) {
@@ -110,13 +110,11 @@ func validateGoToEActionDict(xRefTable *model.XRefTable, d types.Dict, dictName
 	// D, required, name, byte string or array
 	err = validateActionDestinationEntry(xRefTable, d, dictName, "D", REQUIRED, model.V10)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
+		if err = xRefTable.ReportSpecViolation(model.FindingAction, err, "GoToE action missing \"D\""); err != nil {
 			return err
 		}
-		err = validateActionDestinationEntry(xRefTable, d, dictName, "Dest", REQUIRED, model.V10)
-		if err != nil && xRefTable.ValidationMode == model.ValidationRelaxed {
+		if err = validateActionDestinationEntry(xRefTable, d, dictName, "Dest", REQUIRED, model.V10); err != nil {
 			err = nil
-			model.ShowSkipped("GotoEAction: missing \"D\"")
 		} else {
 			d["D"] = d["Dest"]
 			delete(d, "Dest")
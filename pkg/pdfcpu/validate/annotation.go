@@ -311,10 +311,9 @@ func validateAnnotationDictLink(xRefTable *model.XRefTable, d types.Dict, dictNa
 
 	// A or Dest, required either or
 	if _, err := validateActionOrDestination(xRefTable, d, dictName, model.V11); err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
+		if err = xRefTable.ReportSpecViolation(model.FindingUnresolvedLinkDest, err, "link annotation with unresolved destination"); err != nil {
 			return err
 		}
-		model.ShowDigestedSpecViolation("link annotation with unresolved destination")
 	}
 
 	// H, optional, name, since V1.2
@@ -1098,12 +1097,22 @@ func validateAnnotationDict3D(xRefTable *model.XRefTable, d types.Dict, dictName
 	}
 
 	// 3DA, optional, activation dict
-	if _, err := validateDictEntry(xRefTable, d, dictName, "3DA", OPTIONAL, model.V16, nil); err != nil {
+	d1, err := validateDictEntry(xRefTable, d, dictName, "3DA", OPTIONAL, model.V16, nil)
+	if err != nil {
 		return err
 	}
 
+	if d1 != nil {
+		if _, err := validateNameEntry(xRefTable, d1, "3DActivationDict", "A", OPTIONAL, model.V16, func(s string) bool { return types.MemberOf(s, []string{"XA", "PO", "PV"}) }); err != nil {
+			return err
+		}
+		if _, err := validateNameEntry(xRefTable, d1, "3DActivationDict", "D", OPTIONAL, model.V16, func(s string) bool { return types.MemberOf(s, []string{"XD", "PC", "PI"}) }); err != nil {
+			return err
+		}
+	}
+
 	// 3DI, optional, boolean
-	_, err := validateBooleanEntry(xRefTable, d, dictName, "3DI", OPTIONAL, model.V16, nil)
+	_, err = validateBooleanEntry(xRefTable, d, dictName, "3DI", OPTIONAL, model.V16, nil)
 
 	return err
 }
@@ -1195,7 +1204,33 @@ func validateAnnotationDictRedact(xRefTable *model.XRefTable, d types.Dict, dict
 }
 
 func validateRichMediaAnnotation(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
-	// TODO See extension level 3.
+	// see extension level 3
+
+	// RichMediaContent, required, dict
+	d1, err := validateDictEntry(xRefTable, d, dictName, "RichMediaContent", REQUIRED, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	// RichMediaSettings, optional, dict
+	d2, err := validateDictEntry(xRefTable, d, dictName, "RichMediaSettings", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	if !xRefTable.PDF20() {
+		return nil
+	}
+
+	// Standardized by PDF 2.0
+	if err := validateRichMediaContentDict(xRefTable, d1); err != nil {
+		return err
+	}
+
+	if d2 != nil {
+		return validateRichMediaSettingsDict(xRefTable, d2)
+	}
+
 	return nil
 }
 
@@ -1207,7 +1242,13 @@ func validateExDataDict(xRefTable *model.XRefTable, d types.Dict) error {
 		return err
 	}
 
-	_, err := validateNameEntry(xRefTable, d, dictName, "Subtype", REQUIRED, model.V10, func(s string) bool { return s == "Markup3D" })
+	subtypes := []string{"Markup3D"}
+	if xRefTable.PDF20() || xRefTable.ValidationMode == model.ValidationRelaxed {
+		// 3D measurement and geospatial data of projection annotations
+		subtypes = append(subtypes, "3DM", "MarkupGeo")
+	}
+
+	_, err := validateNameEntry(xRefTable, d, dictName, "Subtype", REQUIRED, model.V10, func(s string) bool { return types.MemberOf(s, subtypes) })
 
 	return err
 }
@@ -1517,18 +1558,18 @@ func validateAnnotationDictGeneralPart1(xRefTable *model.XRefTable, d types.Dict
 
 	// Rect, required, rectangle
 	if _, err = validateRectangleEntry(xRefTable, d, dictName, "Rect", REQUIRED, model.V10, nil); err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
+		if err = xRefTable.ReportSpecViolation(model.FindingAnnotation, err, "annotation with invalid \"Rect\""); err != nil {
 			return nil, err
 		}
 	}
 
 	// Contents, optional, text string
 	if _, err = validateStringEntry(xRefTable, d, dictName, "Contents", OPTIONAL, model.V10, nil); err != nil {
-		if xRefTable.ValidationMode != model.ValidationRelaxed {
+		i, err1 := validateIntegerEntry(xRefTable, d, dictName, "Contents", OPTIONAL, model.V10, nil)
+		if err1 != nil {
 			return nil, err
 		}
-		i, err := validateIntegerEntry(xRefTable, d, dictName, "Contents", OPTIONAL, model.V10, nil)
-		if err != nil {
+		if err = xRefTable.ReportSpecViolation(model.FindingAnnotation, err, "annotation with integer \"Contents\""); err != nil {
 			return nil, err
 		}
 		if i != nil {
@@ -1602,7 +1643,8 @@ func validateAnnotationDictGeneralPart2(xRefTable *model.XRefTable, d types.Dict
 		return err
 	}
 
-	return nil
+	// AF, optional, array of file specification dicts, since V2.0
+	return validateAssociatedFiles(xRefTable, d, dictName)
 }
 
 func validateAnnotationDictGeneral(xRefTable *model.XRefTable, d types.Dict, dictName string) (*types.Name, error) {
@@ -1661,6 +1703,7 @@ func validateAnnotationDictConcrete(xRefTable *model.XRefTable, d types.Dict, di
 		"3D":             {validateAnnotationDict3D, model.V16, model.V16, false},
 		"Redact":         {validateAnnotationDictRedact, model.V17, model.V17, true},
 		"RichMedia":      {validateRichMediaAnnotation, model.V17, model.V14, false},
+		"Projection":     {validateAnnotationDictProjection, model.V20, model.V17, true},
 	} {
 		if subtype.Value() == k {
 
@@ -1761,14 +1804,10 @@ func validateAnnotationsArray(xRefTable *model.XRefTable, a types.Array) error {
 			if len(annotDict) == 0 {
 				continue
 			}
-		} else if xRefTable.ValidationMode != model.ValidationRelaxed {
-			return errInvalidPageAnnotArray
 		} else if annotDict, ok = v.(types.Dict); !ok {
 			return errInvalidPageAnnotArray
-		} else {
-			if log.ValidateEnabled() {
-				log.Validate.Println("digesting page annotation array w/o indirect references")
-			}
+		} else if err := xRefTable.ReportSpecViolation(model.FindingAnnotation, errInvalidPageAnnotArray, "page annotation array w/o indirect references"); err != nil {
+			return err
 		}
 
 		if hasIndRef {
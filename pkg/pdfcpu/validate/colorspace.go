@@ -600,9 +600,8 @@ func validateColorSpaceArray(xRefTable *model.XRefTable, a types.Array, excludeP
 		err = validateDeviceNColorSpace(xRefTable, a, model.V13)
 
 	case model.DeviceGrayCS, model.DeviceRGBCS, model.DeviceCMYKCS:
-		if xRefTable.ValidationMode != model.ValidationRelaxed {
-			err = errors.Errorf("pdfcpu: validateColorSpaceArray: undefined color space: %s\n", name)
-		}
+		err = errors.Errorf("pdfcpu: validateColorSpaceArray: undefined color space: %s\n", name)
+		err = xRefTable.ReportSpecViolation(model.FindingColorSpace, err, "device color space array "+name.String())
 
 	default:
 		err = errors.Errorf("pdfcpu: validateColorSpaceArray: undefined color space: %s\n", name)
@@ -630,10 +629,8 @@ func validateColorSpace(xRefTable *model.XRefTable, o types.Object, excludePatte
 		err = validateColorSpaceArray(xRefTable, o, excludePatternCS)
 
 	default:
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return errors.Errorf("pdfcpu: validateColorSpace: corrupt obj type(%T), must be Name or Array", o)
-		}
-		model.ShowSkipped(fmt.Sprintf("invalid color space type: %s", o))
+		err = errors.Errorf("pdfcpu: validateColorSpace: corrupt obj type(%T), must be Name or Array", o)
+		err = xRefTable.ReportSpecViolation(model.FindingColorSpace, err, fmt.Sprintf("invalid color space type: %s", o))
 	}
 
 	return err
@@ -650,10 +647,8 @@ func validateColorSpaceEntry(xRefTable *model.XRefTable, d types.Dict, dictName
 
 	case types.Name:
 		if ok := validateDeviceColorSpaceName(o.Value()); !ok {
-			if xRefTable.ValidationMode == model.ValidationStrict {
-				return errors.Errorf("pdfcpu: invalid colorSpaceEntry: Name:%s\n", o.Value())
-			}
-			model.ShowSkipped(fmt.Sprintf("invalid colorSpaceEntry: %s", o.Value()))
+			err = errors.Errorf("pdfcpu: invalid colorSpaceEntry: Name:%s\n", o.Value())
+			err = xRefTable.ReportSpecViolation(model.FindingColorSpace, err, fmt.Sprintf("invalid colorSpaceEntry: %s", o.Value()))
 		}
 
 	case types.Array:
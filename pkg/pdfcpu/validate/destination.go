@@ -17,6 +17,8 @@ limitations under the License.
 package validate
 
 import (
+	"fmt"
+
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -40,9 +42,7 @@ func validateDestinationArrayFirstElement(xRefTable *model.XRefTable, a types.Ar
 
 	default:
 		err = errors.Errorf("pdfcpu: validateDestinationArrayFirstElement: must be a pageDict indRef or an integer: %v (%T)", o, o)
-		if xRefTable.ValidationMode == model.ValidationRelaxed {
-			err = nil
-		}
+		err = xRefTable.ReportSpecViolation(model.FindingDestination, err, "destination array with invalid page")
 	}
 
 	return o, err
@@ -83,10 +83,8 @@ func validateDestType(a types.Array, destType types.Name) error {
 
 func validateDestinationArray(xRefTable *model.XRefTable, a types.Array) error {
 	if !validateDestinationArrayLength(a) {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return errors.Errorf("pdfcpu: validateDestinationArray: invalid length: %d", len(a))
-		}
-		return nil
+		err := errors.Errorf("pdfcpu: validateDestinationArray: invalid length: %d", len(a))
+		return xRefTable.ReportSpecViolation(model.FindingDestination, err, fmt.Sprintf("destination array with invalid length: %d", len(a)))
 	}
 
 	// Validate first element: indRef of page dict or pageNumber(int) of remote doc for remote Go-to Action or nil.
@@ -17,6 +17,8 @@ limitations under the License.
 package validate
 
 import (
+	"fmt"
+
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -62,14 +64,12 @@ func validateBGEntry(xRefTable *model.XRefTable, d types.Dict, dictName string,
 	switch o := o.(type) {
 
 	case types.Name:
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			err = errors.Errorf("pdfcpu: validateBGEntry: dict=%s corrupt entry \"%s\"\n", dictName, entryName)
-			break
-		}
-		s := o.Value()
-		if s != "Identity" {
+		if o.Value() != "Identity" {
 			err = errors.New("pdfcpu: validateBGEntry: corrupt name")
+			break
 		}
+		err = errors.Errorf("pdfcpu: validateBGEntry: dict=%s corrupt entry \"%s\"\n", dictName, entryName)
+		err = xRefTable.ReportSpecViolation(model.FindingExtGState, err, fmt.Sprintf("%s with \"%s\" Identity", dictName, entryName))
 
 	case types.Dict:
 		err = processFunction(xRefTable, o)
@@ -124,14 +124,12 @@ func validateUCREntry(xRefTable *model.XRefTable, d types.Dict, dictName string,
 	switch o := o.(type) {
 
 	case types.Name:
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			err = errors.Errorf("pdfcpu: validateUCREntry: dict=%s corrupt entry \"%s\"\n", dictName, entryName)
-			break
-		}
-		s := o.Value()
-		if s != "Identity" {
+		if o.Value() != "Identity" {
 			err = errors.New("pdfcpu: writeUCREntry: corrupt name")
+			break
 		}
+		err = errors.Errorf("pdfcpu: validateUCREntry: dict=%s corrupt entry \"%s\"\n", dictName, entryName)
+		err = xRefTable.ReportSpecViolation(model.FindingExtGState, err, fmt.Sprintf("%s with \"%s\" Identity", dictName, entryName))
 
 	case types.Dict:
 		err = processFunction(xRefTable, o)
@@ -1005,6 +1003,10 @@ func validateExtGStateDict(xRefTable *model.XRefTable, o types.Object) error {
 		return err
 	}
 
+	if err := validateExtGStateDictPDF20(xRefTable, d, dictName); err != nil {
+		return err
+	}
+
 	// Check for AAPL extensions.
 	o, _, err = d.Entry(dictName, "AAPL:AA", OPTIONAL)
 	if err != nil {
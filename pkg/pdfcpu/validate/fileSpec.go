@@ -349,6 +349,11 @@ func validateFileSpecDictPart2(xRefTable *model.XRefTable, d types.Dict, dictNam
 	if err != nil {
 		return err
 	}
+	if epDict != nil {
+		if err := validateEncryptedPayloadDict(xRefTable, epDict); err != nil {
+			return err
+		}
+	}
 	if err = validateFileSpecDictEFAndRF(xRefTable, d, dictName, len(epDict) > 0); err != nil {
 		return err
 	}
@@ -407,6 +412,39 @@ func validateFileSpecDict(xRefTable *model.XRefTable, d types.Dict) error {
 	return nil
 }
 
+// validateAssociatedFiles validates an optional AF entry of d (14.13 Associated Files).
+func validateAssociatedFiles(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
+	// PDF/A-3 associates files using PDF 1.7.
+	sinceVersion := model.V20
+	if xRefTable.ValidationMode == model.ValidationRelaxed {
+		sinceVersion = model.V17
+	}
+
+	a, err := validateArrayEntry(xRefTable, d, dictName, "AF", OPTIONAL, sinceVersion, nil)
+	if err != nil || len(a) == 0 {
+		return err
+	}
+
+	for _, o := range a {
+		d1, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			err := errors.Errorf("pdfcpu: validateAssociatedFiles: dict=%s missing file specification dict", dictName)
+			if err = xRefTable.ReportSpecViolation(model.FindingFileSpec, err, "associated files with missing file specification dict"); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := validateFileSpecDict(xRefTable, d1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func validateFileSpecification(xRefTable *model.XRefTable, o types.Object) (types.Object, error) {
 	// See 7.11
 
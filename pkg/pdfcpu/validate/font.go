@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
@@ -37,7 +36,7 @@ func validateStandardType1Font(s string) bool {
 		"Symbol", "ZapfDingbats"})
 }
 
-func validateFontFile3SubType(sd *types.StreamDict, fontType string, relaxed bool) error {
+func validateFontFile3SubType(xRefTable *model.XRefTable, sd *types.StreamDict, fontType string) error {
 
 	// Hint about used font program.
 	dictSubType := sd.Subtype()
@@ -49,10 +48,8 @@ func validateFontFile3SubType(sd *types.StreamDict, fontType string, relaxed boo
 	switch fontType {
 	case "Type1":
 		if *dictSubType != "Type1C" && *dictSubType != "OpenType" {
-			if !relaxed {
-				return errors.Errorf("pdfcpu: validateFontFile3SubType: Type1: unexpected Subtype %s", *dictSubType)
-			}
-			model.ShowSkipped(fmt.Sprintf("validateFontFile3SubType: Type1: unexpected Subtype %s", *dictSubType))
+			msg := fmt.Sprintf("validateFontFile3SubType: Type1: unexpected Subtype %s", *dictSubType)
+			return xRefTable.ReportSpecViolation(model.FindingFont, errors.New("pdfcpu: "+msg), msg)
 		}
 
 	case "MMType1":
@@ -85,7 +82,7 @@ func validateFontFile(xRefTable *model.XRefTable, d types.Dict, dictName string,
 
 	// SubType
 	if entryName == "FontFile3" {
-		err = validateFontFile3SubType(sd, fontType, xRefTable.ValidationMode == model.ValidationRelaxed)
+		err = validateFontFile3SubType(xRefTable, sd, fontType)
 		if err != nil {
 			return err
 		}
@@ -119,15 +116,10 @@ func validateFontDescriptorType(xRefTable *model.XRefTable, d types.Dict) (err e
 	dictType := d.Type()
 
 	if dictType == nil {
-
-		if xRefTable.ValidationMode == model.ValidationRelaxed {
-			if log.ValidateEnabled() {
-				log.Validate.Println("validateFontDescriptor: missing entry \"Type\"")
-			}
-		} else {
-			return errors.New("pdfcpu: validateFontDescriptor: missing entry \"Type\"")
+		err := errors.New("pdfcpu: validateFontDescriptor: missing entry \"Type\"")
+		if err = xRefTable.ReportSpecViolation(model.FindingFont, err, "font descriptor missing \"Type\""); err != nil {
+			return err
 		}
-
 	}
 
 	if dictType != nil && *dictType != "FontDescriptor" && *dictType != "Font" {
@@ -146,8 +138,7 @@ func validateFontDescriptorFontName(xRefTable *model.XRefTable, d types.Dict, di
 	if err != nil {
 		if _, err = validateStringEntry(xRefTable, d, dictName, "FontName", required, model.V10, nil); err != nil {
 			if xRefTable.ValidationMode == model.ValidationRelaxed {
-				model.ShowDigestedSpecViolationError(xRefTable, err)
-				return nil
+				return xRefTable.ReportSpecViolation(model.FindingFontName, err, err.Error())
 			}
 		}
 	}
@@ -167,8 +158,7 @@ func validateFontDescriptorFontFamily(xRefTable *model.XRefTable, d types.Dict,
 	if err != nil {
 		if _, err = validateStringEntry(xRefTable, d, dictName, "FontFamily", required, sinceVersion, nil); err != nil {
 			if xRefTable.ValidationMode == model.ValidationRelaxed {
-				model.ShowDigestedSpecViolationError(xRefTable, err)
-				return nil
+				return xRefTable.ReportSpecViolation(model.FindingFontName, err, err.Error())
 			}
 		}
 	}
@@ -204,23 +194,17 @@ func validateFontDescriptorFontWeight(xRefTable *model.XRefTable, d types.Dict,
 func validateFontDescriptorFontFlags(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
 	_, err := validateIntegerEntry(xRefTable, d, dictName, "Flags", REQUIRED, model.V10, nil)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationRelaxed {
-			model.ShowSkipped("missing font descriptor \"Flags\"")
-			return nil
-		}
+		return xRefTable.ReportSpecViolation(model.FindingFont, err, "font descriptor with invalid \"Flags\"")
 	}
-	return err
+	return nil
 }
 
 func validateFontDescriptorFontBox(xRefTable *model.XRefTable, d types.Dict, dictName, fontDictType string) error {
 	_, err := validateRectangleEntry(xRefTable, d, dictName, "FontBBox", fontDictType != "Type3", model.V10, nil)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationRelaxed {
-			model.ShowSkipped("missing font descriptor \"FontBBox\"")
-			return nil
-		}
+		return xRefTable.ReportSpecViolation(model.FindingFont, err, "font descriptor with invalid \"FontBBox\"")
 	}
-	return err
+	return nil
 }
 
 func validateFontDescriptorItalicAngle(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
@@ -272,20 +256,16 @@ func validateFontDescriptorPart2(xRefTable *model.XRefTable, d types.Dict, dictN
 
 	_, err := validateNumberEntry(xRefTable, d, dictName, "Ascent", fontDictType != "Type3", model.V10, nil)
 	if err != nil {
-		if xRefTable.ValidationMode != model.ValidationRelaxed {
+		if err = xRefTable.ReportSpecViolation(model.FindingFont, err, "font descriptor with invalid \"Ascent\""); err != nil {
 			return err
 		}
-		err = nil
-		model.ShowSkipped("missing font descriptor \"Ascent\"")
 	}
 
 	_, err = validateNumberEntry(xRefTable, d, dictName, "Descent", fontDictType != "Type3", model.V10, nil)
 	if err != nil {
-		if xRefTable.ValidationMode != model.ValidationRelaxed {
+		if err = xRefTable.ReportSpecViolation(model.FindingFont, err, "font descriptor with invalid \"Descent\""); err != nil {
 			return err
 		}
-		err = nil
-		model.ShowSkipped("missing font descriptor \"Descent\"")
 	}
 
 	_, err = validateNumberEntry(xRefTable, d, dictName, "Leading", OPTIONAL, model.V10, nil)
@@ -305,11 +285,9 @@ func validateFontDescriptorPart2(xRefTable *model.XRefTable, d types.Dict, dictN
 
 	_, err = validateNumberEntry(xRefTable, d, dictName, "StemV", fontDictType != "Type3", model.V10, nil)
 	if err != nil {
-		if xRefTable.ValidationMode != model.ValidationRelaxed {
+		if err = xRefTable.ReportSpecViolation(model.FindingFont, err, "font descriptor with invalid \"StemV\""); err != nil {
 			return err
 		}
-		err = nil
-		model.ShowSkipped("missing font descriptor \"StemV\"")
 	}
 
 	_, err = validateNumberEntry(xRefTable, d, dictName, "StemH", OPTIONAL, model.V10, nil)
@@ -622,8 +600,11 @@ func validateCIDFontDictEntryCIDToGIDMap(xRefTable *model.XRefTable, d types.Dic
 
 	if o, found := d.Find("CIDToGIDMap"); found {
 
-		if xRefTable.ValidationMode == model.ValidationStrict && !isCIDFontType2 {
-			return errors.New("pdfcpu: validateCIDFontDict: entry CIDToGIDMap not allowed - must be CIDFontType2")
+		if !isCIDFontType2 {
+			err := errors.New("pdfcpu: validateCIDFontDict: entry CIDToGIDMap not allowed - must be CIDFontType2")
+			if err = xRefTable.ReportSpecViolation(model.FindingFont, err, "\"CIDToGIDMap\" not allowed for CIDFontType0"); err != nil {
+				return err
+			}
 		}
 
 		err := validateCIDToGIDMap(xRefTable, o)
@@ -736,6 +717,45 @@ func validateDescendantFonts(xRefTable *model.XRefTable, d types.Dict, fontDictN
 	return validateCIDFontDict(xRefTable, d1)
 }
 
+// validateType0FontCharacterCollection checks that a predefined CMap other than Identity-H and Identity-V
+// is used together with a CIDFont of the same character collection (see 9.7.5.2 Predefined CMaps).
+func validateType0FontCharacterCollection(xRefTable *model.XRefTable, d types.Dict) error {
+
+	enc := d.NameEntry("Encoding")
+	if enc == nil {
+		return nil
+	}
+
+	cm, ok := font.PredefinedCMapByName(*enc)
+	if !ok || cm.Ordering == "Identity" {
+		return nil
+	}
+
+	a, err := xRefTable.DereferenceArray(d["DescendantFonts"])
+	if err != nil || len(a) != 1 {
+		return err
+	}
+
+	d1, err := xRefTable.DereferenceDict(a[0])
+	if err != nil || d1 == nil {
+		return err
+	}
+
+	d2, err := xRefTable.DereferenceDict(d1["CIDSystemInfo"])
+	if err != nil || d2 == nil {
+		return err
+	}
+
+	ordering, err := xRefTable.DereferenceStringOrHexLiteral(d2["Ordering"], model.V10, nil)
+	if err != nil || ordering == cm.Ordering {
+		return err
+	}
+
+	msg := fmt.Sprintf("validateType0FontDict: CMap %s incompatible with character collection %s", cm.Name, ordering)
+
+	return xRefTable.ReportSpecViolation(model.FindingFont, errors.New("pdfcpu: "+msg), msg)
+}
+
 func validateType0FontDict(xRefTable *model.XRefTable, d types.Dict) (string, error) {
 
 	dictName := "type0FontDict"
@@ -764,6 +784,10 @@ func validateType0FontDict(xRefTable *model.XRefTable, d types.Dict) (string, er
 		return fontName, err
 	}
 
+	if err = validateType0FontCharacterCollection(xRefTable, d); err != nil {
+		return fontName, err
+	}
+
 	// ToUnicode, optional, CMap stream dict
 	sinceVersion := model.V12
 	if xRefTable.ValidationMode == model.ValidationRelaxed {
@@ -855,8 +879,7 @@ func validateCharProcsDict(xRefTable *model.XRefTable, d types.Dict, dictName st
 		if !strings.Contains(err.Error(), "invalid type") {
 			return err
 		}
-		model.ShowDigestedSpecViolation("\"CharProcs\" with invalid type")
-		return nil
+		return xRefTable.ReportSpecViolation(model.FindingCharProcs, err, "\"CharProcs\" with invalid type")
 	}
 
 	for _, v := range d1 {
@@ -976,7 +999,10 @@ func validateType0FontEncoding(xRefTable *model.XRefTable, d types.Dict, dictNam
 	switch o := o.(type) {
 
 	case types.Name:
-		// no further processing
+		if !font.IsPredefinedCMap(o.Value()) {
+			err = errors.Errorf("validateType0FontEncoding: dict=%s unknown predefined CMap: %s\n", dictName, o.Value())
+			err = xRefTable.ReportSpecViolation(model.FindingFont, err, fmt.Sprintf("validateType0FontEncoding: unknown predefined CMap: %s", o.Value()))
+		}
 
 	case types.StreamDict:
 		err = validateCMapStreamDict(xRefTable, &o)
@@ -1144,10 +1170,10 @@ func validateFontDict(xRefTable *model.XRefTable, isIndRef bool, indRef types.In
 	}
 
 	if d.Type() == nil || *d.Type() != "Font" {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return "", errors.New("pdfcpu: validateFontDict: corrupt font dict")
+		err := errors.New("pdfcpu: validateFontDict: corrupt font dict")
+		if err = xRefTable.ReportSpecViolation(model.FindingFontType, err, "missing fontDict entry \"Type\""); err != nil {
+			return "", err
 		}
-		model.ShowDigestedSpecViolation("missing fontDict entry \"Type\"")
 	}
 
 	return _validateFontDict(xRefTable, d, isIndRef, indRef)
@@ -1197,9 +1223,7 @@ func validateFontResourceDict(xRefTable *model.XRefTable, o types.Object, sinceV
 		fn, err := validateFontDict(xRefTable, indRefOk, indRef)
 		if err != nil {
 			if err == ErrMissingFont {
-				if xRefTable.ValidationMode == model.ValidationRelaxed {
-					err = nil
-					model.ShowSkipped(fmt.Sprintf("missing font: %s %s", id, fn))
+				if err = xRefTable.ReportSpecViolation(model.FindingFont, err, fmt.Sprintf("missing font: %s %s", id, fn)); err == nil {
 					m1[id] = fn
 					continue
 				}
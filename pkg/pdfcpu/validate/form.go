@@ -100,8 +100,9 @@ func validateAppearanceDict(xRefTable *model.XRefTable, o types.Object) error {
 	// Normal Appearance
 	o, ok := d.Find("N")
 	if !ok {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return errors.New("pdfcpu: validateAppearanceDict: missing required entry \"N\"")
+		err = errors.New("pdfcpu: validateAppearanceDict: missing required entry \"N\"")
+		if err = xRefTable.ReportSpecViolation(model.FindingAnnotation, err, "appearance dict missing \"N\""); err != nil {
+			return err
 		}
 	} else {
 		err = validateAppearanceDictEntry(xRefTable, o)
@@ -442,8 +443,9 @@ func validateFormFieldKids(xRefTable *model.XRefTable, objNr, incr int, d types.
 	var err error
 	// dict represents a non terminal field.
 	if d.Subtype() != nil && *d.Subtype() == "Widget" {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return errors.New("pdfcpu: validateFormFieldKids: non terminal field can not be widget annotation")
+		err = errors.New("pdfcpu: validateFormFieldKids: non terminal field can not be widget annotation")
+		if err = xRefTable.ReportSpecViolation(model.FindingForm, err, "non terminal form field with widget annotation"); err != nil {
+			return err
 		}
 	}
 
@@ -474,10 +476,9 @@ func validateFormFieldKids(xRefTable *model.XRefTable, objNr, incr int, d types.
 		}
 		valid, err := xRefTable.IsValid(ir)
 		if err != nil {
-			if xRefTable.ValidationMode == model.ValidationStrict {
+			if err = xRefTable.ReportSpecViolation(model.FindingForm, err, fmt.Sprintf("missing form field kid obj #%s", ir.ObjectNumber.String())); err != nil {
 				return err
 			}
-			model.ShowSkipped(fmt.Sprintf("missing form field kid obj #%s", ir.ObjectNumber.String()))
 			valid = true
 		}
 
@@ -527,10 +528,9 @@ func validateFormFields(xRefTable *model.XRefTable, arr types.Array, requiresDA
 
 		valid, err := xRefTable.IsValid(ir)
 		if err != nil {
-			if xRefTable.ValidationMode == model.ValidationStrict {
+			if err = xRefTable.ReportSpecViolation(model.FindingForm, err, fmt.Sprintf("missing form field obj #%s", ir.ObjectNumber.String())); err != nil {
 				return err
 			}
-			model.ShowSkipped(fmt.Sprintf("missing form field obj #%s", ir.ObjectNumber.String()))
 			valid = true
 		}
 
@@ -559,6 +559,57 @@ func validateFormCO(xRefTable *model.XRefTable, arr types.Array, sinceVersion mo
 	return validateFormFields(xRefTable, arr, requiresDA)
 }
 
+func collectFormFieldObjNrs(xRefTable *model.XRefTable, arr types.Array, m types.IntSet) error {
+	for _, o := range arr {
+		ir, ok := o.(types.IndirectRef)
+		if !ok || m[ir.ObjectNumber.Value()] {
+			continue
+		}
+		m[ir.ObjectNumber.Value()] = true
+
+		d, err := xRefTable.DereferenceDict(ir)
+		if err != nil || d == nil {
+			continue
+		}
+
+		kids, err := xRefTable.DereferenceArray(d["Kids"])
+		if err != nil {
+			continue
+		}
+		if err := collectFormFieldObjNrs(xRefTable, kids, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateFormCOFields(xRefTable *model.XRefTable, d types.Dict, co types.Array) error {
+
+	// All fields of the calculation order need to be part of the form's field hierarchy.
+
+	fields, err := xRefTable.DereferenceArray(d["Fields"])
+	if err != nil {
+		return err
+	}
+
+	m := types.IntSet{}
+	if err := collectFormFieldObjNrs(xRefTable, fields, m); err != nil {
+		return err
+	}
+
+	for _, o := range co {
+		ir, ok := o.(types.IndirectRef)
+		if !ok || m[ir.ObjectNumber.Value()] {
+			continue
+		}
+		msg := "calculation order refers to unknown form field"
+		return xRefTable.ReportSpecViolation(model.FindingCalculationOrder, errors.Errorf("pdfcpu: %s (obj#:%d)", msg, ir.ObjectNumber), msg)
+	}
+
+	return nil
+}
+
 func validateFormXFA(xRefTable *model.XRefTable, d types.Dict, sinceVersion model.Version) error {
 
 	// see 12.7.8
@@ -635,7 +686,11 @@ func validateFormEntryCO(xRefTable *model.XRefTable, d types.Dict, sinceVersion
 		return err
 	}
 
-	return validateFormCO(xRefTable, arr, sinceVersion, requiresDA)
+	if err := validateFormCO(xRefTable, arr, sinceVersion, requiresDA); err != nil {
+		return err
+	}
+
+	return validateFormCOFields(xRefTable, d, arr)
 }
 
 func validateFormEntryDR(xRefTable *model.XRefTable, d types.Dict) error {
@@ -33,9 +33,8 @@ func DocumentProperty(s string) bool {
 
 func validateInfoDictDate(xRefTable *model.XRefTable, name string, o types.Object) (string, error) {
 	s, err := validateDateObject(xRefTable, o, model.V10)
-	if err != nil && xRefTable.ValidationMode == model.ValidationRelaxed {
-		err = nil
-		model.ShowRepaired(fmt.Sprintf("info dict \"%s\"", name))
+	if err != nil {
+		err = xRefTable.ReportSpecViolation(model.FindingInfo, err, fmt.Sprintf("info dict with invalid \"%s\"", name))
 	}
 	return s, err
 }
@@ -66,11 +65,10 @@ func validateInfoDictTrapped(xRefTable *model.XRefTable, o types.Object) error {
 func handleProperties(xRefTable *model.XRefTable, key string, val types.Object) error {
 	v, err := xRefTable.DereferenceStringOrHexLiteral(val, model.V10, nil)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return err
+		if _, err1 := xRefTable.Dereference(val); err1 != nil {
+			return err1
 		}
-		_, err = xRefTable.Dereference(val)
-		return err
+		return xRefTable.ReportSpecViolation(model.FindingInfo, err, fmt.Sprintf("info dict with invalid \"%s\"", key))
 	}
 
 	if v != "" {
@@ -205,11 +203,13 @@ func validateDocumentInfoObject(xRefTable *model.XRefTable) error {
 
 	hasModDate, err := validateDocumentInfoDict(xRefTable, *xRefTable.Info)
 	if err != nil {
-		if xRefTable.ValidationMode != model.ValidationRelaxed || !strings.Contains(err.Error(), "wrong type") {
+		if !strings.Contains(err.Error(), "wrong type") {
+			return err
+		}
+		if err = xRefTable.ReportSpecViolation(model.FindingInfo, err, "invalid info dict"); err != nil {
 			return err
 		}
 		xRefTable.Info = nil
-		model.ShowSkipped("invalid info dict")
 		return nil
 	}
 
@@ -219,10 +219,10 @@ func validateDocumentInfoObject(xRefTable *model.XRefTable) error {
 	}
 
 	if hasPieceInfo && !hasModDate {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return errors.Errorf("validateDocumentInfoObject: missing required entry \"ModDate\"")
+		err := errors.Errorf("validateDocumentInfoObject: missing required entry \"ModDate\"")
+		if err = xRefTable.ReportSpecViolation(model.FindingInfoModDate, err, "infoDict with \"PieceInfo\" but missing \"ModDate\""); err != nil {
+			return err
 		}
-		model.ShowDigestedSpecViolation("infoDict with \"PieceInfo\" but missing \"ModDate\"")
 	}
 
 	if log.ValidateEnabled() {
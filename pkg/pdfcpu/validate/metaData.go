@@ -86,11 +86,7 @@ func catalogMetaData(xRefTable *model.XRefTable, rootDict types.Dict, required b
 	x := model.XMPMeta{}
 
 	if err = xml.Unmarshal(sd.Content, &x); err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return nil, err
-		}
-		model.ShowSkipped("metadata parse error")
-		return nil, nil
+		return nil, xRefTable.ReportSpecViolation(model.FindingMetadata, err, "metadata parse error")
 	}
 
 	return &x, nil
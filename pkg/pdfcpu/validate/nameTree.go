@@ -17,6 +17,8 @@ limitations under the License.
 package validate
 
 import (
+	"fmt"
+
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -678,22 +680,13 @@ func validateNameTreeDictLimitsEntry(xRefTable *model.XRefTable, d types.Dict, f
 	}
 	lkv := *s
 
-	if xRefTable.ValidationMode == model.ValidationRelaxed {
-
-		if fkv != firstKey && xRefTable.ValidationMode == model.ValidationRelaxed {
-			fkv = firstKey
-			a[0] = types.StringLiteral(fkv)
-		}
-
-		if lkv != lastKey && xRefTable.ValidationMode == model.ValidationRelaxed {
-			lkv = lastKey
-			a[1] = types.StringLiteral(lkv)
-		}
-
-	}
-
 	if firstKey != fkv || lastKey != lkv {
-		return errors.Errorf("pdfcpu: validateNameTreeDictLimitsEntry: invalid leaf node (firstKey: %s vs %s) (lastKey: %s vs %s)\n", firstKey, fkv, lastKey, lkv)
+		msg := fmt.Sprintf("validateNameTreeDictLimitsEntry: invalid leaf node (firstKey: %s vs %s) (lastKey: %s vs %s)", firstKey, fkv, lastKey, lkv)
+		if err := xRefTable.ReportSpecViolation(model.FindingNameTree, errors.New("pdfcpu: "+msg), msg); err != nil {
+			return err
+		}
+		// Repair
+		a[0], a[1] = types.StringLiteral(firstKey), types.StringLiteral(lastKey)
 	}
 
 	return nil
@@ -725,10 +718,8 @@ func validateNameTree(xRefTable *model.XRefTable, name string, d types.Dict, roo
 		}
 
 		if len(a) == 0 {
-			if xRefTable.ValidationMode == model.ValidationStrict {
-				return "", "", nil, errors.New("pdfcpu: validateNameTree: missing \"Kids\" array")
-			}
-			return "", "", nil, nil
+			err := errors.New("pdfcpu: validateNameTree: missing \"Kids\" array")
+			return "", "", nil, xRefTable.ReportSpecViolation(model.FindingNameTree, err, "name tree node with empty \"Kids\"")
 		}
 
 		for _, o := range a {
@@ -742,7 +733,7 @@ func validateNameTree(xRefTable *model.XRefTable, name string, d types.Dict, roo
 			var kidNode *model.Node
 			kminKid, kmax, kidNode, err = validateNameTree(xRefTable, name, d, false)
 			if err != nil {
-				if xRefTable.ValidationMode == model.ValidationStrict {
+				if err = xRefTable.ReportSpecViolation(model.FindingNameTree, err, "skipping corrupt name tree node"); err != nil {
 					return "", "", nil, err
 				}
 				continue
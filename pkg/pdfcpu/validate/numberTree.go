@@ -63,78 +63,42 @@ func validatePageLabelDict(xRefTable *model.XRefTable, o types.Object) error {
 	return err
 }
 
-func validateNumberTreeDictNumsEntry(xRefTable *model.XRefTable, d types.Dict, name string, useIDs bool) (firstKey, lastKey int, err error) {
+func validateNumberTreeDictNumsEntry(xRefTable *model.XRefTable, d types.Dict) error {
 
 	// Nums: array of the form [key1 value1 key2 value2 ... key n value n]
 	o, found := d.Find("Nums")
 	if !found {
-		return 0, 0, errors.New("pdfcpu: validateNumberTreeDictNumsEntry: missing \"Kids\" or \"Nums\" entry")
+		return errors.New("pdfcpu: validateNumberTreeDictNumsEntry: missing \"Kids\" or \"Nums\" entry")
 	}
 
 	a, err := xRefTable.DereferenceArray(o)
 	if err != nil {
-		return 0, 0, err
+		return err
 	}
 	if a == nil {
-		return 0, 0, errors.New("pdfcpu: validateNumberTreeDictNumsEntry: missing \"Nums\" array")
+		return errors.New("pdfcpu: validateNumberTreeDictNumsEntry: missing \"Nums\" array")
 	}
 
 	// arr length needs to be even because of contained key value pairs.
 	if len(a)%2 == 1 {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return 0, 0, errors.Errorf("pdfcpu: validateNumberTreeDictNumsEntry: Nums array entry length needs to be even, length=%d\n", len(a))
+		err := errors.Errorf("pdfcpu: validateNumberTreeDictNumsEntry: Nums array entry length needs to be even, length=%d\n", len(a))
+		if err = xRefTable.ReportSpecViolation(model.FindingNumberTree, err, "number tree \"Num\" entry array length needs to be even"); err != nil {
+			return err
 		}
-		model.ShowDigestedSpecViolation("number tree \"Num\" entry array length needs to be even")
-		model.ShowSkipped("invalid number tree")
-		return 0, 0, nil
 	}
 
-	// every other entry is a value
-	// value = indRef to an array of indRefs of structElemDicts
-	// or
-	// value = indRef of structElementDict.
-
-	for i, o := range a {
-
-		if i%2 == 0 {
-
-			o, err = xRefTable.Dereference(o)
-			if err != nil {
-				return 0, 0, err
-			}
-
-			i, ok := o.(types.Integer)
-			if !ok {
-				return 0, 0, errors.Errorf("pdfcpu: validateNumberTreeDictNumsEntry: corrupt key <%v>\n", o)
-			}
-
-			if firstKey == 0 {
-				firstKey = i.Value()
-			}
-
-			lastKey = i.Value()
-
-			continue
+	// every other entry is a key
+	for i := 0; i < len(a); i += 2 {
+		o, err := xRefTable.Dereference(a[i])
+		if err != nil {
+			return err
 		}
-
-		switch name {
-
-		case "PageLabel":
-			err = validatePageLabelDict(xRefTable, o)
-			if err != nil {
-				return 0, 0, err
-			}
-
-		case "StructTree":
-			err = validateStructTreeRootDictEntryK(xRefTable, o, useIDs)
-			if err != nil {
-				return 0, 0, err
-			}
+		if _, ok := o.(types.Integer); !ok {
+			return errors.Errorf("pdfcpu: validateNumberTreeDictNumsEntry: corrupt key <%v>\n", o)
 		}
-
 	}
 
-	return firstKey, lastKey, nil
+	return nil
 }
 
 func validateNumberTreeDictLimitsEntry(xRefTable *model.XRefTable, d types.Dict, firstKey, lastKey int) error {
@@ -156,66 +120,72 @@ func validateNumberTreeDictLimitsEntry(xRefTable *model.XRefTable, d types.Dict,
 
 	if firstKey < fk || lastKey > lk {
 		msg := fmt.Sprintf("validateNumberTreeDictLimitsEntry: invalid leaf node: firstKey(%d vs. %d) lastKey(%d vs. %d)", firstKey, fk, lastKey, lk)
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return errors.Errorf("pdfcpu: %s\n", msg)
+		if err := xRefTable.ReportSpecViolation(model.FindingNumberTree, errors.Errorf("pdfcpu: %s\n", msg), msg); err != nil {
+			return err
 		}
-		model.ShowDigestedSpecViolation(msg)
 	}
 
 	return nil
 }
 
-func validateNumberTree(xRefTable *model.XRefTable, name string, d types.Dict, root, useIDs bool) (firstKey, lastKey int, err error) {
+func validateNumberTreeNode(xRefTable *model.XRefTable, t *model.NumberTree, d types.Dict, root bool) error {
 
 	// A node has "Kids" or "Nums" entry.
 
 	// Kids: array of indirect references to the immediate children of this node.
-	// if Kids present then recurse
 	if o, found := d.Find("Kids"); found {
-
 		a, err := xRefTable.DereferenceArray(o)
 		if err != nil {
-			return 0, 0, err
+			return err
 		}
 		if a == nil {
-			return 0, 0, errors.New("pdfcpu: validateNumberTree: missing \"Kids\" array")
+			return errors.New("pdfcpu: validateNumberTree: missing \"Kids\" array")
 		}
+	} else if err := validateNumberTreeDictNumsEntry(xRefTable, d); err != nil {
+		return err
+	}
 
-		for _, o := range a {
-
-			d1, err := xRefTable.DereferenceDict(o)
-			if err != nil {
-				return 0, 0, err
-			}
-
-			var fk int
-			fk, lastKey, err = validateNumberTree(xRefTable, name, d1, false, useIDs)
-			if err != nil {
-				return 0, 0, err
-			}
-			if firstKey == 0 {
-				firstKey = fk
-			}
-		}
+	if root {
+		return nil
+	}
 
-	} else {
+	// Verify calculated key range.
+	firstKey, lastKey, _, err := t.KeyRange(d)
+	if err != nil {
+		return err
+	}
 
-		// Leaf node
-		firstKey, lastKey, err = validateNumberTreeDictNumsEntry(xRefTable, d, name, useIDs)
-		if err != nil {
-			return 0, 0, err
-		}
+	return validateNumberTreeDictLimitsEntry(xRefTable, d, firstKey, lastKey)
+}
+
+func validateNumberTreeValue(xRefTable *model.XRefTable, name string, o types.Object, useIDs bool) error {
+
+	// value = indRef to an array of indRefs of structElemDicts
+	// or
+	// value = indRef of structElementDict.
+
+	switch name {
+
+	case "PageLabel":
+		return validatePageLabelDict(xRefTable, o)
+
+	case "StructTree":
+		return validateStructTreeRootDictEntryK(xRefTable, o, useIDs)
 	}
 
-	if !root {
+	return nil
+}
 
-		// Verify calculated key range.
-		err = validateNumberTreeDictLimitsEntry(xRefTable, d, firstKey, lastKey)
-		if err != nil {
-			return 0, 0, err
-		}
+func validateNumberTree(xRefTable *model.XRefTable, name string, d types.Dict, useIDs bool) error {
+	t := xRefTable.NumberTree(d)
 
+	if err := t.EachNode(func(d types.Dict, root bool) error {
+		return validateNumberTreeNode(xRefTable, t, d, root)
+	}); err != nil {
+		return err
 	}
 
-	return firstKey, lastKey, nil
+	return t.Each(func(_ int, v types.Object) error {
+		return validateNumberTreeValue(xRefTable, name, v, useIDs)
+	})
 }
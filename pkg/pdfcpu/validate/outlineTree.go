@@ -17,6 +17,8 @@ limitations under the License.
 package validate
 
 import (
+	"strings"
+
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -24,15 +26,19 @@ import (
 
 var ErrBookmarksRepair = errors.New("pdfcpu: bookmarks repair failed")
 
+// outlineViolation reports err as spec violation of the outline tree.
+func outlineViolation(xRefTable *model.XRefTable, err error) error {
+	msg := strings.TrimSpace(strings.TrimPrefix(err.Error(), "pdfcpu: "))
+	return xRefTable.ReportSpecViolation(model.FindingOutline, err, msg)
+}
+
 func validateOutlineItemDictTitle(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
 	_, err := validateStringEntry(xRefTable, d, dictName, "Title", REQUIRED, model.V10, nil)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return err
-		}
-		if _, err := validateNameEntry(xRefTable, d, dictName, "Title", REQUIRED, model.V10, nil); err != nil {
+		if _, err1 := validateNameEntry(xRefTable, d, dictName, "Title", REQUIRED, model.V10, nil); err1 != nil {
 			return err
 		}
+		return xRefTable.ReportSpecViolation(model.FindingOutline, err, "outline item with name \"Title\"")
 	}
 	return nil
 }
@@ -104,8 +110,7 @@ func validateOutlineItemDict(xRefTable *model.XRefTable, d types.Dict) error {
 	}
 	if destName != "" {
 		if _, err = xRefTable.DereferenceDestArray(destName); err != nil && xRefTable.ValidationMode == model.ValidationRelaxed {
-			model.ShowDigestedSpecViolation("outlineDict with unresolved destination")
-			return nil
+			return xRefTable.ReportSpecViolation(model.FindingUnresolvedOutlineDest, err, "outlineDict with unresolved destination")
 		}
 	}
 
@@ -128,24 +133,26 @@ func handleOutlineItemDict(xRefTable *model.XRefTable, ir types.IndirectRef, obj
 	return d, nil
 }
 
-func leaf(firstChild, lastChild *types.IndirectRef, objNumber, validationMode int) (bool, error) {
+func leaf(xRefTable *model.XRefTable, firstChild, lastChild *types.IndirectRef, objNumber int) (bool, error) {
 	if firstChild == nil {
 		if lastChild == nil {
 			// Leaf
 			return true, nil
 		}
-		if validationMode == model.ValidationStrict {
-			return false, errors.Errorf("pdfcpu: validateOutlineTree: missing \"First\" at obj#%d", objNumber)
+		if err := outlineViolation(xRefTable, errors.Errorf("pdfcpu: validateOutlineTree: missing \"First\" at obj#%d", objNumber)); err != nil {
+			return false, err
 		}
 	}
-	if lastChild == nil && validationMode == model.ValidationStrict {
-		return false, errors.Errorf("pdfcpu: validateOutlineTree: missing \"Last\" at obj#%d", objNumber)
+	if lastChild == nil {
+		if err := outlineViolation(xRefTable, errors.Errorf("pdfcpu: validateOutlineTree: missing \"Last\" at obj#%d", objNumber)); err != nil {
+			return false, err
+		}
 	}
 	if firstChild != nil && firstChild.ObjectNumber.Value() == objNumber &&
 		lastChild != nil && lastChild.ObjectNumber.Value() == objNumber {
 		// Degenerated leaf = node pointing to itself.
-		if validationMode == model.ValidationStrict {
-			return false, errors.Errorf("pdfcpu: validateOutlineTree: invalid at obj#%d", objNumber)
+		if err := outlineViolation(xRefTable, errors.Errorf("pdfcpu: validateOutlineTree: invalid at obj#%d", objNumber)); err != nil {
+			return false, err
 		}
 		return true, nil
 	}
@@ -155,14 +162,14 @@ func leaf(firstChild, lastChild *types.IndirectRef, objNumber, validationMode in
 func evalOutlineCount(xRefTable *model.XRefTable, c, visc int, count int, total, visible *int) error {
 	if visc == 0 {
 		if count == 0 {
-			if xRefTable.ValidationMode == model.ValidationStrict {
-				return errors.New("pdfcpu: validateOutlineTree: non-empty outline item dict needs \"Count\" <> 0")
+			if err := outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlineTree: non-empty outline item dict needs \"Count\" <> 0")); err != nil {
+				return err
 			}
 			count = c
 		}
 		if count != c && count != -c {
-			if xRefTable.ValidationMode == model.ValidationStrict {
-				return errors.Errorf("pdfcpu: validateOutlineTree: non-empty outline item dict got \"Count\" %d, want %d or %d", count, c, -c)
+			if err := outlineViolation(xRefTable, errors.Errorf("pdfcpu: validateOutlineTree: non-empty outline item dict got \"Count\" %d, want %d or %d", count, c, -c)); err != nil {
+				return err
 			}
 			count = c
 		}
@@ -209,14 +216,14 @@ func validateOutlineTree(xRefTable *model.XRefTable, first, last *types.Indirect
 		firstChild := d.IndirectRefEntry("First")
 		lastChild := d.IndirectRefEntry("Last")
 
-		ok, err := leaf(firstChild, lastChild, objNr, xRefTable.ValidationMode)
+		ok, err := leaf(xRefTable, firstChild, lastChild, objNr)
 		if err != nil {
 			return 0, 0, err
 		}
 		if ok {
 			if count != 0 {
-				if xRefTable.ValidationMode == model.ValidationStrict {
-					return 0, 0, errors.New("pdfcpu: validateOutlineTree: empty outline item dict \"Count\" must be 0")
+				if err := outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlineTree: empty outline item dict \"Count\" must be 0")); err != nil {
+					return 0, 0, err
 				}
 			}
 			continue
@@ -237,8 +244,10 @@ func validateOutlineTree(xRefTable *model.XRefTable, first, last *types.Indirect
 
 	}
 
-	if xRefTable.ValidationMode == model.ValidationStrict && objNr != last.ObjectNumber.Value() {
-		return 0, 0, errors.Errorf("pdfcpu: validateOutlineTree: invalid child list %d <> %d\n", objNr, last.ObjectNumber)
+	if objNr != last.ObjectNumber.Value() {
+		if err := outlineViolation(xRefTable, errors.Errorf("pdfcpu: validateOutlineTree: invalid child list %d <> %d\n", objNr, last.ObjectNumber)); err != nil {
+			return 0, 0, err
+		}
 	}
 
 	return total, visible, nil
@@ -248,23 +257,24 @@ func validateVisibleOutlineCount(xRefTable *model.XRefTable, total, visible int,
 	if count == nil {
 		return errors.Errorf("pdfcpu: validateOutlines: invalid, root \"Count\" is nil, expected to be %d", total+visible)
 	}
-	if xRefTable.ValidationMode == model.ValidationStrict && *count != total+visible {
-		return errors.Errorf("pdfcpu: validateOutlines: invalid, root \"Count\" = %d, expected to be %d", *count, total+visible)
+	if *count == total+visible {
+		return nil
 	}
-	if xRefTable.ValidationMode == model.ValidationRelaxed && *count != total+visible && *count != -total-visible {
-		return errors.Errorf("pdfcpu: validateOutlines: invalid, root \"Count\" = %d, expected to be %d", *count, total+visible)
+	err := errors.Errorf("pdfcpu: validateOutlines: invalid, root \"Count\" = %d, expected to be %d", *count, total+visible)
+	if *count != -total-visible {
+		return err
 	}
 
-	return nil
+	return outlineViolation(xRefTable, err)
 }
 
 func validateInvisibleOutlineCount(xRefTable *model.XRefTable, total int, count *int) error {
 	if count != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict && *count == 0 {
-			return errors.New("pdfcpu: validateOutlines: invalid, root \"Count\" shall be omitted if there are no open outline items")
+		if *count == 0 {
+			return outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlines: invalid, root \"Count\" shall be omitted if there are no open outline items"))
 		}
-		if xRefTable.ValidationMode == model.ValidationStrict && *count != total && *count != -total {
-			return errors.Errorf("pdfcpu: validateOutlines: invalid, root \"Count\" = %d, expected to be %d", *count, total)
+		if *count != total && *count != -total {
+			return outlineViolation(xRefTable, errors.Errorf("pdfcpu: validateOutlines: invalid, root \"Count\" = %d, expected to be %d", *count, total))
 		}
 	}
 
@@ -292,8 +302,8 @@ func firstOfRemainder(xRefTable *model.XRefTable, last *types.IndirectRef, duplO
 			return 0, nil, err
 		}
 		if len(d) == 0 {
-			if xRefTable.ValidationMode == model.ValidationStrict {
-				return 0, nil, errors.New("pdfcpu: validateOutlines: corrupt outline items detected")
+			if err := outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlines: corrupt outline items detected")); err != nil {
+				return 0, nil, err
 			}
 		}
 		irPrev := d.IndirectRefEntry("Prev")
@@ -328,8 +338,8 @@ func removeDuplFirst(xRefTable *model.XRefTable, first, last *types.IndirectRef,
 }
 
 func handleCircular(xRefTable *model.XRefTable, dict types.Dict, first *types.IndirectRef, fixed *bool) error {
-	if xRefTable.ValidationMode == model.ValidationStrict {
-		return errors.New("pdfcpu: validateOutlines: circular outline items detected")
+	if err := outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlines: circular outline items detected")); err != nil {
+		return err
 	}
 	dict["Prev"] = *first
 	delete(dict, "Next")
@@ -338,8 +348,8 @@ func handleCircular(xRefTable *model.XRefTable, dict types.Dict, first *types.In
 }
 
 func handleCorruptDict(xRefTable *model.XRefTable) error {
-	if xRefTable.ValidationMode == model.ValidationStrict {
-		return errors.New("pdfcpu: validateOutlines: corrupt outline items detected")
+	if err := outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlines: corrupt outline items detected")); err != nil {
+		return err
 	}
 	return ErrBookmarksRepair
 }
@@ -398,8 +408,8 @@ func scanAndFixOutlineItems(xRefTable *model.XRefTable, first, last *types.Indir
 
 		if ir == first && dict["Prev"] != nil {
 			*fixed = true
-			if xRefTable.ValidationMode == model.ValidationStrict {
-				return errors.New("pdfcpu: validateOutlines: corrupt outline items detected")
+			if err := outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlines: corrupt outline items detected")); err != nil {
+				return err
 			}
 			delete(dict, "Prev")
 		}
@@ -444,22 +454,29 @@ func validateOutlinesGeneral(xRefTable *model.XRefTable, rootDict types.Dict) (*
 		removeOutlines(xRefTable, rootDict)
 		return nil, nil, nil, nil
 	}
-	if last == nil && xRefTable.ValidationMode == model.ValidationStrict {
-		return nil, nil, nil, errors.New("pdfcpu: validateOutlines: invalid, root missing \"Last\"")
+	if last == nil {
+		if err := outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlines: invalid, root missing \"Last\"")); err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	count := d.IntEntry("Count")
-	if xRefTable.ValidationMode == model.ValidationStrict && count != nil && *count < 0 {
-		return nil, nil, nil, errors.New("pdfcpu: validateOutlines: invalid, root \"Count\" can't be negative")
+	if count != nil && *count < 0 {
+		if err := outlineViolation(xRefTable, errors.New("pdfcpu: validateOutlines: invalid, root \"Count\" can't be negative")); err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	return first, last, count, nil
 }
 
-func handleCorruptOutlineItems(xRefTable *model.XRefTable, rootDict types.Dict) {
-	model.ShowMsg("validateOutlines: corrupt outline items detected")
+func handleCorruptOutlineItems(xRefTable *model.XRefTable, rootDict types.Dict) error {
+	if err := xRefTable.ReportSpecViolation(model.FindingOutline, ErrBookmarksRepair, "corrupt outline items detected"); err != nil {
+		return err
+	}
 	removeOutlines(xRefTable, rootDict)
 	model.ShowSkipped("bookmarks")
+	return nil
 }
 
 func scanAndFixOutlines(xRefTable *model.XRefTable, rootDict types.Dict, first, last *types.IndirectRef, count *int) error {
@@ -469,18 +486,16 @@ func scanAndFixOutlines(xRefTable *model.XRefTable, rootDict types.Dict, first,
 
 	err := scanAndFixOutlineItems(xRefTable, first, last, m, &fixed)
 	if err != nil {
-		if err == ErrBookmarksRepair && xRefTable.ValidationMode == model.ValidationRelaxed {
-			handleCorruptOutlineItems(xRefTable, rootDict)
-			return nil
+		if err == ErrBookmarksRepair {
+			return handleCorruptOutlineItems(xRefTable, rootDict)
 		}
 		return err
 	}
 
 	total, visible, err := validateOutlineTree(xRefTable, first, last, m, &fixed)
 	if err != nil {
-		if err == ErrBookmarksRepair && xRefTable.ValidationMode == model.ValidationRelaxed {
-			handleCorruptOutlineItems(xRefTable, rootDict)
-			return nil
+		if err == ErrBookmarksRepair {
+			return handleCorruptOutlineItems(xRefTable, rootDict)
 		}
 		return err
 	}
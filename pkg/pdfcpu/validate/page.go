@@ -36,19 +36,20 @@ func validateResourceDict(xRefTable *model.XRefTable, o types.Object) (hasResour
 	for k, v := range map[string]struct {
 		validate     func(xRefTable *model.XRefTable, o types.Object, sinceVersion model.Version) error
 		sinceVersion model.Version
+		findingID    string
 	}{
-		"ExtGState":  {validateExtGStateResourceDict, model.V10},
-		"Font":       {validateFontResourceDict, model.V10},
-		"XObject":    {validateXObjectResourceDict, model.V10},
-		"Properties": {validatePropertiesResourceDict, model.V10},
-		"ColorSpace": {validateColorSpaceResourceDict, model.V10},
-		"Pattern":    {validatePatternResourceDict, model.V10},
-		"Shading":    {validateShadingResourceDict, model.V13},
+		"ExtGState":  {validateExtGStateResourceDict, model.V10, model.FindingExtGState},
+		"Font":       {validateFontResourceDict, model.V10, model.FindingFont},
+		"XObject":    {validateXObjectResourceDict, model.V10, model.FindingXObject},
+		"Properties": {validatePropertiesResourceDict, model.V10, model.FindingResources},
+		"ColorSpace": {validateColorSpaceResourceDict, model.V10, model.FindingColorSpace},
+		"Pattern":    {validatePatternResourceDict, model.V10, model.FindingResources},
+		"Shading":    {validateShadingResourceDict, model.V13, model.FindingResources},
 	} {
 		if o, ok := d.Find(k); ok {
 			err = v.validate(xRefTable, o, v.sinceVersion)
 			if err != nil {
-				return false, err
+				return false, xRefTable.ReportInvalid(v.findingID, err)
 			}
 		}
 	}
@@ -98,8 +99,9 @@ func validateContents(obj types.Object, xRefTable *model.XRefTable, d types.Dict
 			break
 		}
 
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return false, errors.Errorf("validatePageContents: empty page content array detected")
+		err := errors.Errorf("validatePageContents: empty page content array detected")
+		if err = xRefTable.ReportSpecViolation(model.FindingPages, err, "page dict with empty \"Contents\" array"); err != nil {
+			return false, err
 		}
 
 		// Digest empty array.
@@ -110,8 +112,12 @@ func validateContents(obj types.Object, xRefTable *model.XRefTable, d types.Dict
 
 		s := strings.TrimSpace(obj.Value())
 
-		if len(s) > 0 || xRefTable.ValidationMode == model.ValidationStrict {
-			return false, errors.Errorf("validatePageContents: page content must be stream dict or array, got: %T", obj)
+		err := errors.Errorf("validatePageContents: page content must be stream dict or array, got: %T", obj)
+		if len(s) > 0 {
+			return false, err
+		}
+		if err = xRefTable.ReportSpecViolation(model.FindingPages, err, "page dict with empty \"Contents\" string"); err != nil {
+			return false, err
 		}
 
 		// Digest empty string literal.
@@ -120,8 +126,12 @@ func validateContents(obj types.Object, xRefTable *model.XRefTable, d types.Dict
 
 	case types.Dict:
 
-		if len(obj) > 0 || xRefTable.ValidationMode == model.ValidationStrict {
-			return false, errors.Errorf("validatePageContents: page content must be stream dict or array, got: %T", obj)
+		err := errors.Errorf("validatePageContents: page content must be stream dict or array, got: %T", obj)
+		if len(obj) > 0 {
+			return false, err
+		}
+		if err = xRefTable.ReportSpecViolation(model.FindingPages, err, "page dict with empty \"Contents\" dict"); err != nil {
+			return false, err
 		}
 
 		// Digest empty dict.
@@ -497,11 +507,14 @@ func validatePageEntryTabs(xRefTable *model.XRefTable, d types.Dict, required bo
 
 	_, err := validateNameEntry(xRefTable, d, "pagesDict", "Tabs", required, sinceVersion, validateTabs)
 
-	if err != nil && xRefTable.ValidationMode == model.ValidationRelaxed {
-		_, err = validateStringEntry(xRefTable, d, "pagesDict", "Tabs", required, sinceVersion, validateTabs)
+	if err != nil {
+		if _, err1 := validateStringEntry(xRefTable, d, "pagesDict", "Tabs", required, sinceVersion, validateTabs); err1 != nil {
+			return err
+		}
+		return xRefTable.ReportSpecViolation(model.FindingPages, err, "page dict with string \"Tabs\"")
 	}
 
-	return err
+	return nil
 }
 
 func validatePageEntryTemplateInstantiated(xRefTable *model.XRefTable, d types.Dict, required bool, sinceVersion model.Version) error {
@@ -651,6 +664,10 @@ func validateMeasureDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion
 		return err
 	}
 
+	if *coordSys == "GEO" {
+		return validateGeoMeasureDict(xRefTable, d, dictName)
+	}
+
 	if *coordSys != "RL" {
 		if xRefTable.Version() > sinceVersion {
 			// unknown coord system
@@ -716,6 +733,81 @@ func validateMeasureDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion
 	return nil
 }
 
+func validateGeoCoordSysDict(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
+
+	_, err := validateNameEntry(xRefTable, d, dictName, "Type", REQUIRED, model.V17, func(s string) bool { return s == "GEOGCS" || s == "PROJCS" })
+	if err != nil {
+		return err
+	}
+
+	epsg, err := validateIntegerEntry(xRefTable, d, dictName, "EPSG", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	wkt, err := validateStringEntry(xRefTable, d, dictName, "WKT", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	if epsg == nil && wkt == nil {
+		return errors.Errorf("validateGeoCoordSysDict dict=%s: missing EPSG or WKT", dictName)
+	}
+
+	return nil
+}
+
+func validateGeoMeasureDict(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
+
+	// see 12.10 Geospatial features (PDF 2.0, Adobe Extension Level 3 for PDF 1.7)
+
+	evenLen := func(a types.Array) bool { return len(a) >= 6 && len(a)%2 == 0 }
+
+	_, err := validateNumberArrayEntry(xRefTable, d, dictName, "Bounds", OPTIONAL, model.V17, evenLen)
+	if err != nil {
+		return err
+	}
+
+	d1, err := validateDictEntry(xRefTable, d, dictName, "GCS", REQUIRED, model.V17, nil)
+	if err != nil {
+		return err
+	}
+	if err = validateGeoCoordSysDict(xRefTable, d1, "gcsDict"); err != nil {
+		return err
+	}
+
+	d1, err = validateDictEntry(xRefTable, d, dictName, "DCS", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+	if d1 != nil {
+		if err = validateGeoCoordSysDict(xRefTable, d1, "dcsDict"); err != nil {
+			return err
+		}
+	}
+
+	_, err = validateNameArrayEntry(xRefTable, d, dictName, "PDU", OPTIONAL, model.V17, func(a types.Array) bool { return len(a) == 3 })
+	if err != nil {
+		return err
+	}
+
+	gpts, err := validateNumberArrayEntry(xRefTable, d, dictName, "GPTS", REQUIRED, model.V17, evenLen)
+	if err != nil {
+		return err
+	}
+
+	lpts, err := validateNumberArrayEntry(xRefTable, d, dictName, "LPTS", OPTIONAL, model.V17, evenLen)
+	if err != nil {
+		return err
+	}
+
+	if lpts != nil && len(lpts) != len(gpts) {
+		return errors.Errorf("validateGeoMeasureDict dict=%s: LPTS and GPTS length mismatch", dictName)
+	}
+
+	return nil
+}
+
 func validateViewportDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion model.Version) error {
 
 	dictName := "viewportDict"
@@ -798,8 +890,9 @@ func handlePieceInfo(xRefTable *model.XRefTable, d types.Dict, dictName string)
 		return err
 	}
 
-	if hasPieceInfo && lm == nil && xRefTable.ValidationMode == model.ValidationStrict {
-		return errors.New("pdfcpu: validatePageDict: missing \"LastModified\" (required by \"PieceInfo\")")
+	if hasPieceInfo && lm == nil {
+		err := errors.New("pdfcpu: validatePageDict: missing \"LastModified\" (required by \"PieceInfo\")")
+		return xRefTable.ReportSpecViolation(model.FindingPieceInfo, err, "page dict with \"PieceInfo\" but missing \"LastModified\"")
 	}
 
 	return nil
@@ -836,6 +929,11 @@ func validatePageDict(xRefTable *model.XRefTable, d types.Dict, hasMediaBox bool
 		return nil, err
 	}
 
+	// AF
+	if err := validateAssociatedFiles(xRefTable, d, dictName); err != nil {
+		return nil, err
+	}
+
 	// AA
 	sinceVersion := model.V14
 	if xRefTable.ValidationMode == model.ValidationRelaxed {
@@ -976,8 +1074,9 @@ func detectPageNodeDict(xRefTable *model.XRefTable, indRef types.IndirectRef, ob
 		return pageNodeDict, nil
 	}
 
-	if xRefTable.ValidationMode == model.ValidationStrict {
-		return nil, errors.Errorf("pdfcpu: validatePagesDict: corrupt page %d (obj#%d)", pageNr, objNr)
+	err = errors.Errorf("pdfcpu: validatePagesDict: corrupt page %d (obj#%d)", pageNr, objNr)
+	if err = xRefTable.ReportSpecViolation(model.FindingPages, err, fmt.Sprintf("corrupt page %d replaced by blank page", pageNr)); err != nil {
+		return nil, err
 	}
 
 	var mediaBox *types.Rectangle
@@ -992,8 +1091,6 @@ func detectPageNodeDict(xRefTable *model.XRefTable, indRef types.IndirectRef, ob
 		return nil, err
 	}
 
-	model.ShowRepaired(fmt.Sprintf("currupt page %d with blank page", pageNr))
-
 	return xRefTable.DereferenceDict(indRef)
 }
 
@@ -1162,14 +1259,14 @@ func validatePages(xRefTable *model.XRefTable, rootDict types.Dict) (types.Dict,
 
 	ir, ok := obj.(types.IndirectRef)
 	if !ok {
-		if xRefTable.ValidationMode != model.ValidationRelaxed {
-			return nil, errors.New("pdfcpu: validatePages: missing indirect reference \"Pages\"")
+		err = errors.New("pdfcpu: validatePages: missing indirect reference \"Pages\"")
+		if err = xRefTable.ReportSpecViolation(model.FindingPages, err, "catalog with direct \"Pages\""); err != nil {
+			return nil, err
 		}
 		pageRoot, objNr, err = repairPagesDict(xRefTable, obj, rootDict)
 		if err != nil {
 			return nil, err
 		}
-		model.ShowRepaired("missing \"Pages\" indirect reference")
 	}
 
 	if ok {
@@ -1207,5 +1304,13 @@ func validatePages(xRefTable *model.XRefTable, rootDict types.Dict) (types.Dict,
 		return nil, errors.New("pdfcpu: validatePages: page tree invalid")
 	}
 
-	return pageRoot, err
+	if s, err := xRefTable.PageTreeShape(); err == nil && s.Degenerate() {
+		msg := fmt.Sprintf("degenerate page tree: depth=%d maxKids=%d pages=%d", s.Depth, s.MaxKids, s.Pages)
+		f := model.Finding{ID: model.FindingPageTree, Severity: model.SeverityInfo, ObjNr: objNr, Message: msg}
+		if err := xRefTable.ReportFinding(f, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return pageRoot, nil
 }
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// This file contains validation of constructs introduced by ISO 32000-2 (PDF 2.0).
+
+func validateEncryptionPDF20(xRefTable *model.XRefTable) error {
+	// 7.6.4 PDF 2.0 deprecates RC4 and all standard security handler revisions but 6 (AES-256).
+
+	if !xRefTable.PDF20() || xRefTable.Encrypt == nil || xRefTable.E == nil {
+		return nil
+	}
+
+	d, err := xRefTable.DereferenceDict(*xRefTable.Encrypt)
+	if err != nil || d == nil {
+		return err
+	}
+
+	if f := d.NameEntry("Filter"); f == nil || *f != "Standard" {
+		return nil
+	}
+
+	if e := xRefTable.E; e.V < 5 || e.R < 6 {
+		msg := "encryption revision deprecated in PDF 2.0"
+		return xRefTable.ReportSpecViolation(model.FindingDeprecatedEncryption, errors.Errorf("pdfcpu: %s: V=%d R=%d", msg, e.V, e.R), msg)
+	}
+
+	return nil
+}
+
+func validateEncryptedPayloadDict(xRefTable *model.XRefTable, d types.Dict) error {
+	// 7.6.7 Unencrypted wrapper document
+
+	dictName := "encryptedPayloadDict"
+
+	// Type, optional, name
+	if _, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V20, func(s string) bool { return s == "EncryptedPayload" }); err != nil {
+		return err
+	}
+
+	// Subtype, required, name of the cryptographic filter
+	if _, err := validateNameEntry(xRefTable, d, dictName, "Subtype", REQUIRED, model.V20, nil); err != nil {
+		return err
+	}
+
+	// Version, optional, text string
+	_, err := validateStringEntry(xRefTable, d, dictName, "Version", OPTIONAL, model.V20, nil)
+
+	return err
+}
+
+func validateWrapperDocument(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
+	// 7.6.7 Unencrypted wrapper document
+	// The encrypted payload is an embedded file with "AFRelationship" EncryptedPayload referenced by the catalog's "AF".
+
+	if xRefTable.Names["EmbeddedFiles"] == nil {
+		return nil
+	}
+
+	af, err := xRefTable.DereferenceArray(rootDict["AF"])
+	if err != nil {
+		return err
+	}
+
+	associated := func(objNr int) bool {
+		for _, o := range af {
+			if ir, ok := o.(types.IndirectRef); ok && ir.ObjectNumber.Value() == objNr {
+				return true
+			}
+		}
+		return false
+	}
+
+	return xRefTable.Names["EmbeddedFiles"].Process(xRefTable, func(xRefTable *model.XRefTable, k string, o *types.Object) error {
+		d, err := xRefTable.DereferenceDict(*o)
+		if err != nil || d == nil {
+			return err
+		}
+		if _, found := d.Find("EP"); !found {
+			return nil
+		}
+
+		msg := "invalid encrypted payload " + k
+		if n := d.NameEntry("AFRelationship"); n == nil || *n != "EncryptedPayload" {
+			return xRefTable.ReportSpecViolation(model.FindingEncryptedPayload, errors.Errorf("pdfcpu: %s: missing \"AFRelationship\" EncryptedPayload", msg), msg)
+		}
+		if ir, ok := (*o).(types.IndirectRef); !ok || !associated(ir.ObjectNumber.Value()) {
+			return xRefTable.ReportSpecViolation(model.FindingEncryptedPayload, errors.Errorf("pdfcpu: %s: not referenced by catalog \"AF\"", msg), msg)
+		}
+		if xRefTable.Encrypt != nil {
+			return xRefTable.ReportSpecViolation(model.FindingEncryptedPayload, errors.Errorf("pdfcpu: %s: wrapper document is encrypted", msg), msg)
+		}
+
+		return nil
+	})
+}
+
+func validateExtGStateDictPDF20(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
+	sinceVersion := model.V20
+	if xRefTable.ValidationMode == model.ValidationRelaxed {
+		sinceVersion = model.V14
+	}
+
+	// UseBlackPtComp, name, optional, since V2.0, black point compensation
+	if _, err := validateNameEntry(xRefTable, d, dictName, "UseBlackPtComp", OPTIONAL, sinceVersion, func(s string) bool {
+		return types.MemberOf(s, []string{"OFF", "ON", "Default"})
+	}); err != nil {
+		return err
+	}
+
+	// HTO, array of 2 numbers, optional, since V2.0, halftone origin in device space
+	_, err := validateNumberArrayEntry(xRefTable, d, dictName, "HTO", OPTIONAL, sinceVersion, func(a types.Array) bool { return len(a) == 2 })
+
+	return err
+}
+
+func validateAnnotationDictProjection(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
+	// 12.5.6.24 Projection annotations
+	// A markup annotation without additional entries associating 3D or geospatial measurement data by "ExData".
+	return nil
+}
+
+func richMediaSubtypes(xRefTable *model.XRefTable) []string {
+	ss := []string{"3D", "Sound", "Video"}
+	if xRefTable.ValidationMode == model.ValidationRelaxed {
+		// Adobe extension level 3
+		ss = append(ss, "Flash")
+	}
+	return ss
+}
+
+func validateRichMediaInstanceDict(xRefTable *model.XRefTable, d types.Dict) error {
+	dictName := "richMediaInstanceDict"
+
+	if _, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V20, func(s string) bool { return s == "RichMediaInstance" }); err != nil {
+		return err
+	}
+
+	if _, err := validateNameEntry(xRefTable, d, dictName, "Subtype", REQUIRED, model.V20, func(s string) bool {
+		return types.MemberOf(s, richMediaSubtypes(xRefTable))
+	}); err != nil {
+		return err
+	}
+
+	// Asset, required, file specification (also part of the Assets name tree)
+	o, found := d.Find("Asset")
+	if !found {
+		err := errors.New("pdfcpu: validateRichMediaInstanceDict: missing \"Asset\"")
+		return xRefTable.ReportSpecViolation(model.FindingAnnotation, err, "rich media instance missing \"Asset\"")
+	}
+	_, err := validateFileSpecification(xRefTable, o)
+
+	return err
+}
+
+func validateRichMediaConfigurationDict(xRefTable *model.XRefTable, d types.Dict) error {
+	dictName := "richMediaConfigurationDict"
+
+	if _, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V20, func(s string) bool { return s == "RichMediaConfiguration" }); err != nil {
+		return err
+	}
+
+	if _, err := validateNameEntry(xRefTable, d, dictName, "Subtype", OPTIONAL, model.V20, func(s string) bool {
+		return types.MemberOf(s, richMediaSubtypes(xRefTable))
+	}); err != nil {
+		return err
+	}
+
+	if _, err := validateStringEntry(xRefTable, d, dictName, "Name", OPTIONAL, model.V20, nil); err != nil {
+		return err
+	}
+
+	a, err := validateArrayEntry(xRefTable, d, dictName, "Instances", OPTIONAL, model.V20, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range a {
+		d1, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			continue
+		}
+		if err := validateRichMediaInstanceDict(xRefTable, d1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateRichMediaContentDict(xRefTable *model.XRefTable, d types.Dict) error {
+	// 13.7.2.3 RichMediaContent dictionary
+
+	dictName := "richMediaContentDict"
+
+	if _, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V20, func(s string) bool { return s == "RichMediaContent" }); err != nil {
+		return err
+	}
+
+	// Assets, optional, name tree of file specifications
+	if _, err := validateDictEntry(xRefTable, d, dictName, "Assets", OPTIONAL, model.V20, nil); err != nil {
+		return err
+	}
+
+	a, err := validateArrayEntry(xRefTable, d, dictName, "Configurations", OPTIONAL, model.V20, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range a {
+		d1, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			continue
+		}
+		if err := validateRichMediaConfigurationDict(xRefTable, d1); err != nil {
+			return err
+		}
+	}
+
+	// Views, optional, array of 3D view dicts
+	_, err = validateArrayEntry(xRefTable, d, dictName, "Views", OPTIONAL, model.V20, nil)
+
+	return err
+}
+
+func validateRichMediaSettingsDict(xRefTable *model.XRefTable, d types.Dict) error {
+	// 13.7.2.2 RichMediaSettings dictionary
+
+	dictName := "richMediaSettingsDict"
+
+	if _, err := validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V20, func(s string) bool { return s == "RichMediaSettings" }); err != nil {
+		return err
+	}
+
+	for _, e := range []struct {
+		entryName, typ string
+		conditions     []string
+	}{
+		{"Activation", "RichMediaActivation", []string{"XA", "PO", "PV"}},
+		{"Deactivation", "RichMediaDeactivation", []string{"XD", "PC", "PI"}},
+	} {
+		d1, err := validateDictEntry(xRefTable, d, dictName, e.entryName, OPTIONAL, model.V20, nil)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			continue
+		}
+		if _, err := validateNameEntry(xRefTable, d1, e.entryName, "Type", OPTIONAL, model.V20, func(s string) bool { return s == e.typ }); err != nil {
+			return err
+		}
+		if _, err := validateNameEntry(xRefTable, d1, e.entryName, "Condition", OPTIONAL, model.V20, func(s string) bool { return types.MemberOf(s, e.conditions) }); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
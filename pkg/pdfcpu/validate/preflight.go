@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Preflight rules.
+const (
+	RuleMaxPageSize    = "maxPageSize"
+	RuleMinImageDPI    = "minImageDPI"
+	RuleNoRGB          = "noRGB"
+	RuleFontsEmbedded  = "fontsEmbedded"
+	RuleNoTransparency = "noTransparency"
+	RuleNoEncryption   = "noEncryption"
+)
+
+// PreflightProfile configures the rules checked by Preflight.
+// Rules with zero values are disabled.
+type PreflightProfile struct {
+	Name           string  `json:"name"`
+	MaxPageWidth   float64 `json:"maxPageWidth,omitempty"`  // in points, either orientation
+	MaxPageHeight  float64 `json:"maxPageHeight,omitempty"` // in points, either orientation
+	MinImageDPI    float64 `json:"minImageDPI,omitempty"`   // effective resolution at placed size
+	NoRGB          bool    `json:"noRGB,omitempty"`         // eg. for CMYK jobs
+	FontsEmbedded  bool    `json:"fontsEmbedded,omitempty"`
+	NoTransparency bool    `json:"noTransparency,omitempty"`
+	NoEncryption   bool    `json:"noEncryption,omitempty"`
+}
+
+// PreflightViolation represents a failed preflight rule together with its location.
+type PreflightViolation struct {
+	Rule         string `json:"rule"`
+	PageNr       int    `json:"page,omitempty"`
+	ObjNr        int    `json:"objNr,omitempty"`
+	ResourceName string `json:"resource,omitempty"`
+	Msg          string `json:"msg"`
+}
+
+func (v PreflightViolation) String() string {
+	s := v.Rule
+	if v.PageNr > 0 {
+		s += fmt.Sprintf(" page %d", v.PageNr)
+	}
+	if v.ObjNr > 0 {
+		s += fmt.Sprintf(" obj#%d", v.ObjNr)
+	}
+	if v.ResourceName != "" {
+		s += " " + v.ResourceName
+	}
+	return s + ": " + v.Msg
+}
+
+// ParsePreflightProfile parses a JSON encoded preflight profile.
+func ParsePreflightProfile(r io.Reader) (*PreflightProfile, error) {
+	p := &PreflightProfile{}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(p); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid preflight profile")
+	}
+	if p.MaxPageWidth < 0 || p.MaxPageHeight < 0 || p.MinImageDPI < 0 {
+		return nil, errors.New("pdfcpu: invalid preflight profile: negative values not allowed")
+	}
+	return p, nil
+}
+
+type preflight struct {
+	ctx *model.Context
+	p   *PreflightProfile
+	vv  []PreflightViolation
+}
+
+func (pf *preflight) add(rule string, pageNr, objNr int, resName, format string, a ...interface{}) {
+	pf.vv = append(pf.vv, PreflightViolation{
+		Rule:         rule,
+		PageNr:       pageNr,
+		ObjNr:        objNr,
+		ResourceName: resName,
+		Msg:          fmt.Sprintf(format, a...),
+	})
+}
+
+func rgbColorSpace(cs string, comp int) bool {
+	switch cs {
+	case model.DeviceRGBCS, model.CalRGBCS, "RGB":
+		return true
+	case model.ICCBasedCS, model.IndexedCS:
+		return comp == 3
+	}
+	return false
+}
+
+func (pf *preflight) checkPageSize(pageNr int, mediaBox *types.Rectangle) {
+	if pf.p.MaxPageWidth == 0 && pf.p.MaxPageHeight == 0 || mediaBox == nil {
+		return
+	}
+	maxW, maxH := pf.p.MaxPageWidth, pf.p.MaxPageHeight
+	if maxW == 0 {
+		maxW = math.MaxFloat64
+	}
+	if maxH == 0 {
+		maxH = math.MaxFloat64
+	}
+	w, h := mediaBox.Width(), mediaBox.Height()
+	if w <= maxW && h <= maxH || h <= maxW && w <= maxH {
+		return
+	}
+	pf.add(RuleMaxPageSize, pageNr, 0, "", "page size %.2f x %.2f exceeds %.2f x %.2f", w, h, pf.p.MaxPageWidth, pf.p.MaxPageHeight)
+}
+
+func (pf *preflight) checkImages(u *pdfcpu.PageResourceUsage) {
+	for _, img := range u.Images {
+		if pf.p.MinImageDPI > 0 {
+			for _, pl := range img.Placements {
+				dpi := math.Min(pl.HorDPI, pl.VerDPI)
+				if dpi > 0 && dpi < pf.p.MinImageDPI {
+					pf.add(RuleMinImageDPI, u.PageNr, img.ObjNr, img.ResourceName, "effective resolution %.0f dpi below %.0f dpi", dpi, pf.p.MinImageDPI)
+				}
+			}
+		}
+		if pf.p.NoRGB && rgbColorSpace(img.Cs, img.Comp) {
+			pf.add(RuleNoRGB, u.PageNr, img.ObjNr, img.ResourceName, "image uses colorspace %s", img.Cs)
+		}
+		if pf.p.NoTransparency && img.SMask {
+			pf.add(RuleNoTransparency, u.PageNr, img.ObjNr, img.ResourceName, "image has a soft mask")
+		}
+	}
+}
+
+func (pf *preflight) checkFonts(u *pdfcpu.PageResourceUsage) {
+	if !pf.p.FontsEmbedded {
+		return
+	}
+	for _, f := range u.Fonts {
+		if !f.Embedded && f.Type != "Type3" {
+			pf.add(RuleFontsEmbedded, u.PageNr, f.ObjNr, f.ResourceName, "font %s not embedded", f.Name)
+		}
+	}
+}
+
+func (pf *preflight) checkTransparency(pageDict types.Dict, u *pdfcpu.PageResourceUsage) error {
+	if !pf.p.NoTransparency {
+		return nil
+	}
+	if o, found := pageDict.Find("Group"); found {
+		d, err := pf.ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if s := d.NameEntry("S"); s != nil && *s == "Transparency" {
+			pf.add(RuleNoTransparency, u.PageNr, 0, "", "page has a transparency group")
+		}
+	}
+	for _, gs := range u.ExtGStates {
+		if gs.Transparency {
+			pf.add(RuleNoTransparency, u.PageNr, gs.ObjNr, gs.ResourceName, "graphics state introduces transparency")
+		}
+	}
+	return nil
+}
+
+func (pf *preflight) checkRGBContent(pageDict types.Dict, u *pdfcpu.PageResourceUsage) error {
+	if !pf.p.NoRGB {
+		return nil
+	}
+	for _, sh := range u.Shadings {
+		if rgbColorSpace(sh.Cs, 0) {
+			pf.add(RuleNoRGB, u.PageNr, sh.ObjNr, sh.ResourceName, "shading uses colorspace %s", sh.Cs)
+		}
+	}
+	bb, err := pf.ctx.PageContent(pageDict, u.PageNr)
+	if err != nil {
+		if err == model.ErrNoContent {
+			return nil
+		}
+		return err
+	}
+	ops, err := model.ParseContentOps(string(bb))
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if op.Operator == "rg" || op.Operator == "RG" {
+			pf.add(RuleNoRGB, u.PageNr, 0, "", "page content uses DeviceRGB color")
+			break
+		}
+	}
+	return nil
+}
+
+func (pf *preflight) checkPage(pageNr int) error {
+	d, _, inhPAttrs, err := pf.ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: preflight: unknown page number: %d", pageNr)
+	}
+
+	pf.checkPageSize(pageNr, inhPAttrs.MediaBox)
+
+	if !pf.p.NoRGB && !pf.p.NoTransparency && !pf.p.FontsEmbedded && pf.p.MinImageDPI == 0 {
+		return nil
+	}
+
+	u, err := pdfcpu.PageResources(pf.ctx, pageNr)
+	if err != nil {
+		return err
+	}
+
+	pf.checkImages(u)
+	pf.checkFonts(u)
+
+	if err := pf.checkTransparency(d, u); err != nil {
+		return err
+	}
+
+	return pf.checkRGBContent(d, u)
+}
+
+// Preflight checks ctx against the rules of profile and returns all rule violations.
+func Preflight(ctx *model.Context, profile *PreflightProfile) ([]PreflightViolation, error) {
+	if profile == nil {
+		return nil, errors.New("pdfcpu: preflight: missing profile")
+	}
+
+	if log.ValidateEnabled() {
+		log.Validate.Printf("*** Preflight begin: %s ***\n", profile.Name)
+	}
+
+	pf := &preflight{ctx: ctx, p: profile, vv: []PreflightViolation{}}
+
+	if profile.NoEncryption && ctx.Encrypt != nil {
+		pf.add(RuleNoEncryption, 0, ctx.Encrypt.ObjectNumber.Value(), "", "document is encrypted")
+	}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if err := pf.checkPage(i); err != nil {
+			return nil, err
+		}
+	}
+
+	if log.ValidateEnabled() {
+		log.Validate.Printf("*** Preflight end: %d violations ***\n", len(pf.vv))
+	}
+
+	return pf.vv, nil
+}
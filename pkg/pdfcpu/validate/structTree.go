@@ -79,12 +79,9 @@ func validateObjectReferenceDict(xRefTable *model.XRefTable, d types.Dict) error
 
 	// Obj: required, indirect reference
 	ir := d.IndirectRefEntry("Obj")
-	if xRefTable.ValidationMode == model.ValidationStrict && ir == nil {
-		return errors.New("pdfcpu: validateObjectReferenceDict: missing required entry \"Obj\"")
-	}
-
 	if ir == nil {
-		return nil
+		err := errors.New("pdfcpu: validateObjectReferenceDict: missing required entry \"Obj\"")
+		return xRefTable.ReportSpecViolation(model.FindingStructTree, err, "object reference dict missing \"Obj\"")
 	}
 
 	obj, err := xRefTable.Dereference(*ir)
@@ -243,20 +240,16 @@ func processStructElementDictPgEntry(xRefTable *model.XRefTable, ir types.Indire
 	}
 
 	pageDict, ok := o.(types.Dict)
+	msg := fmt.Sprintf("invalid structElementDict Pg entry, objNr: %d", ir.ObjectNumber)
+
 	if !ok {
-		if xRefTable.ValidationMode == model.ValidationRelaxed {
-			model.ShowSkipped(fmt.Sprintf("invalid structElementDict Pg entry, objNr: %d ", ir.ObjectNumber))
-			return nil
-		}
-		return errors.Errorf("pdfcpu: processStructElementDictPgEntry: Pg object corrupt dict: %s objNr:%d\n", o, ir.ObjectNumber)
+		err := errors.Errorf("pdfcpu: processStructElementDictPgEntry: Pg object corrupt dict: %s objNr:%d\n", o, ir.ObjectNumber)
+		return xRefTable.ReportSpecViolation(model.FindingStructTree, err, msg)
 	}
 
 	if t := pageDict.Type(); t == nil || *t != "Page" {
-		if xRefTable.ValidationMode == model.ValidationRelaxed {
-			model.ShowSkipped(fmt.Sprintf("invalid structElementDict Pg entry, objNr: %d ", ir.ObjectNumber))
-			return nil
-		}
-		return errors.Errorf("pdfcpu: processStructElementDictPgEntry: Pg object no pageDict: %s objNr:%d\n", pageDict, ir.ObjectNumber)
+		err := errors.Errorf("pdfcpu: processStructElementDictPgEntry: Pg object no pageDict: %s objNr:%d\n", pageDict, ir.ObjectNumber)
+		return xRefTable.ReportSpecViolation(model.FindingStructTree, err, msg)
 	}
 
 	return nil
@@ -364,12 +357,11 @@ func validateStructElementDictPart1(xRefTable *model.XRefTable, d types.Dict, di
 	// S: structure type, required, name, see 14.7.3 and Annex E.
 	_, err := validateNameEntry(xRefTable, d, dictName, "S", OPTIONAL, model.V10, nil)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
+		i, err1 := validateIntegerEntry(xRefTable, d, dictName, "S", OPTIONAL, model.V10, nil)
+		if err1 != nil {
 			return err
 		}
-		err = nil
-		i, err := validateIntegerEntry(xRefTable, d, dictName, "S", OPTIONAL, model.V10, nil)
-		if err != nil {
+		if err = xRefTable.ReportSpecViolation(model.FindingStructTree, err, "structure element with integer \"S\""); err != nil {
 			return err
 		}
 		if i != nil {
@@ -380,14 +372,16 @@ func validateStructElementDictPart1(xRefTable *model.XRefTable, d types.Dict, di
 
 	// P: immediate parent, required, indirect reference
 	ir := d.IndirectRefEntry("P")
-	if xRefTable.ValidationMode != model.ValidationRelaxed {
-		if ir == nil {
-			return errors.Errorf("pdfcpu: validateStructElementDict: missing entry P: %s\n", d)
+	if ir == nil {
+		err := errors.Errorf("pdfcpu: validateStructElementDict: missing entry P: %s\n", d)
+		if err = xRefTable.ReportSpecViolation(model.FindingStructTree, err, "structure element missing \"P\""); err != nil {
+			return err
 		}
-
-		// Check if parent structure element exists.
-		if _, ok := xRefTable.FindTableEntryForIndRef(ir); !ok {
-			return errors.Errorf("pdfcpu: validateStructElementDict: unknown parent: %v\n", ir)
+	} else if _, ok := xRefTable.FindTableEntryForIndRef(ir); !ok {
+		// Parent structure element does not exist.
+		err := errors.Errorf("pdfcpu: validateStructElementDict: unknown parent: %v\n", ir)
+		if err = xRefTable.ReportSpecViolation(model.FindingStructTree, err, "structure element with unknown parent"); err != nil {
+			return err
 		}
 	}
 
@@ -635,8 +629,7 @@ func validateStructTreeRootDictEntryParentTree(xRefTable *model.XRefTable, ir *t
 		return err
 	}
 
-	_, _, err = validateNumberTree(xRefTable, "StructTree", d, true, useIDs)
-	return err
+	return validateNumberTree(xRefTable, "StructTree", d, useIDs)
 }
 
 func validateStructTreeRootDict(xRefTable *model.XRefTable, d types.Dict) error {
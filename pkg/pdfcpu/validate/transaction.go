@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+func validateTouchedRef(xRefTable *model.XRefTable, objNr int, ir types.IndirectRef) error {
+	refNr := ir.ObjectNumber.Value()
+
+	e, found := xRefTable.FindTableEntryLight(refNr)
+	if !found || e.Free {
+		if xRefTable.ValidationMode == model.ValidationRelaxed {
+			// A reference to a free or undefined object resolves to null.
+			return nil
+		}
+		return errors.Errorf("pdfcpu: obj#%d references free or undefined obj#%d", objNr, refNr)
+	}
+
+	if e.Generation != nil && *e.Generation != ir.GenerationNumber.Value() {
+		return errors.Errorf("pdfcpu: obj#%d references obj#%d with stale generation %d", objNr, refNr, ir.GenerationNumber.Value())
+	}
+
+	return nil
+}
+
+func validateTouchedObject(xRefTable *model.XRefTable, objNr int, o types.Object) error {
+	switch o := o.(type) {
+
+	case types.IndirectRef:
+		return validateTouchedRef(xRefTable, objNr, o)
+
+	case types.Dict:
+		for _, v := range o {
+			if err := validateTouchedObject(xRefTable, objNr, v); err != nil {
+				return err
+			}
+		}
+
+	case types.StreamDict:
+		return validateTouchedObject(xRefTable, objNr, o.Dict)
+
+	case types.Array:
+		for _, v := range o {
+			if err := validateTouchedObject(xRefTable, objNr, v); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// inheritedMediaBox returns true if d inherits a media box from an ancestor page tree node.
+func inheritedMediaBox(xRefTable *model.XRefTable, d types.Dict) bool {
+	visited := types.IntSet{}
+	for ir := d.IndirectRefEntry("Parent"); ir != nil && !visited[ir.ObjectNumber.Value()]; ir = d.IndirectRefEntry("Parent") {
+		visited[ir.ObjectNumber.Value()] = true
+		var err error
+		if d, err = xRefTable.DereferenceDict(*ir); err != nil || d == nil {
+			return false
+		}
+		if _, found := d.Find("MediaBox"); found {
+			return true
+		}
+	}
+	return false
+}
+
+func validateTouchedRootDict(xRefTable *model.XRefTable, d types.Dict) error {
+	if err := validateRootType(xRefTable, d); err != nil {
+		return err
+	}
+
+	if d.IndirectRefEntry("Pages") == nil {
+		return xRefTable.ReportInvalid(model.FindingPages, errors.New("pdfcpu: validateTouchedRootDict: missing \"Pages\""))
+	}
+
+	return validateRootEntries(xRefTable, d)
+}
+
+func validateTouchedDict(xRefTable *model.XRefTable, ir types.IndirectRef, d types.Dict) error {
+	var t string
+	if d.Type() != nil {
+		t = *d.Type()
+	}
+
+	switch {
+
+	case t == "Page":
+		_, err := validatePageDict(xRefTable, d, inheritedMediaBox(xRefTable, d))
+		return xRefTable.ReportInvalid(model.FindingPages, err)
+
+	case t == "Pages":
+		_, _, err := validatePagesDictGeneralEntries(xRefTable, d)
+		if err == nil && pagesDictKids(xRefTable, d) == nil {
+			err = errors.New("pdfcpu: validateTouchedDict: corrupt \"Kids\" entry")
+		}
+		return xRefTable.ReportInvalid(model.FindingPages, err)
+
+	case t == "Annot" || t == "" && d.NameEntry("Subtype") != nil && d.ArrayEntry("Rect") != nil:
+		_, err := validateAnnotationDict(xRefTable, d)
+		return xRefTable.ReportInvalid(model.FindingAnnotation, err)
+
+	case t == "Font":
+		_, err := validateFontDict(xRefTable, true, ir)
+		return xRefTable.ReportInvalid(model.FindingFont, err)
+
+	case t == "Action":
+		return xRefTable.ReportInvalid(model.FindingAction, validateActionDict(xRefTable, d))
+
+	case t == "ExtGState":
+		return xRefTable.ReportInvalid(model.FindingExtGState, validateExtGStateDict(xRefTable, d))
+	}
+
+	return nil
+}
+
+func validateTouchedStreamDict(xRefTable *model.XRefTable, ir types.IndirectRef, sd types.StreamDict) error {
+	if t := sd.Type(); t != nil && *t == "XObject" || sd.Subtype() != nil && types.MemberOf(*sd.Subtype(), []string{"Image", "Form"}) {
+		return xRefTable.ReportInvalid(model.FindingXObject, validateXObjectStreamDict(xRefTable, ir))
+	}
+	return nil
+}
+
+// validateTouchedEntry dispatches a touched object to the validator matching its role.
+func validateTouchedEntry(ctx *model.Context, objNr int, e *model.XRefTableEntry) error {
+	xRefTable := ctx.XRefTable
+
+	gen := 0
+	if e.Generation != nil {
+		gen = *e.Generation
+	}
+	ir := *types.NewIndirectRef(objNr, gen)
+
+	if ctx.Root != nil && ctx.Root.ObjectNumber.Value() == objNr {
+		d, ok := e.Object.(types.Dict)
+		if !ok {
+			return xRefTable.ReportInvalid(model.FindingCatalog, errors.Errorf("pdfcpu: obj#%d: corrupt root dict", objNr))
+		}
+		return validateTouchedRootDict(xRefTable, d)
+	}
+
+	if ctx.Info != nil && ctx.Info.ObjectNumber.Value() == objNr {
+		return xRefTable.ReportInvalid(model.FindingInfo, validateDocumentInfoObject(xRefTable))
+	}
+
+	switch o := e.Object.(type) {
+	case types.Dict:
+		return validateTouchedDict(xRefTable, ir, o)
+	case types.StreamDict:
+		return validateTouchedStreamDict(xRefTable, ir, o)
+	}
+
+	return nil
+}
+
+// Touched validates the objects touched by tx instead of the whole cross reference table.
+// Replaced objects must only reference objects in use, deleted objects must be free.
+// The root dict, the document info dict, page tree nodes, annotations, fonts, actions, extended graphics states
+// and XObjects are validated like during full validation, referenced objects already validated are not revisited.
+// Page annotations of a touched page and references from untouched objects into deleted objects
+// are only validated by XRefTable.
+// If validation fails tx is rolled back.
+func Touched(ctx *model.Context, tx *model.Transaction) (err error) {
+	xRefTable := ctx.XRefTable
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, objNr := range tx.ObjNrs() {
+
+		e, found := xRefTable.FindTableEntryLight(objNr)
+		if !found {
+			return errors.Errorf("pdfcpu: obj#%d not registered in xRefTable", objNr)
+		}
+
+		if e.Free || objNr == 0 {
+			continue
+		}
+
+		xRefTable.CurObj = objNr
+
+		if e.Object == nil {
+			return errors.Errorf("pdfcpu: obj#%d: missing object", objNr)
+		}
+
+		if err := validateTouchedObject(xRefTable, objNr, e.Object); err != nil {
+			return err
+		}
+
+		// Replaced objects inherit the validation state of the original entry.
+		e.Valid = false
+		e.BeingValidated = false
+	}
+
+	for _, objNr := range tx.ObjNrs() {
+
+		e, _ := xRefTable.FindTableEntryLight(objNr)
+		if e.Free || objNr == 0 || e.Valid {
+			continue
+		}
+
+		xRefTable.CurObj = objNr
+
+		if err := validateTouchedEntry(ctx, objNr, e); err != nil {
+			return err
+		}
+
+		e.Valid = true
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+var versions = []model.Version{model.V10, model.V11, model.V12, model.V13, model.V14, model.V15, model.V16, model.V17, model.V20}
+
+// VersionAudit returns the features of ctx unsupported by PDF version v.
+// Any requirement of v not met by ctx ends the audit and is returned as fatal finding.
+// ctx remains unchanged.
+func VersionAudit(ctx *model.Context, v model.Version) ([]model.Finding, error) {
+	ctx1, err := ctx.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	// Revalidate all objects against v.
+	for _, entry := range ctx1.Table {
+		if entry != nil {
+			entry.Valid = false
+		}
+	}
+	ctx1.XRefTable.Valid = false
+	ctx1.HeaderVersion = &v
+	ctx1.RootVersion = nil
+	if ctx1.RootDict != nil {
+		ctx1.RootDict.Delete("Version")
+	}
+
+	// Collect version findings instead of failing.
+	if ctx1.Conf.FindingSeverity == nil {
+		ctx1.Conf.FindingSeverity = map[string]model.Severity{}
+	}
+	ctx1.Conf.FindingSeverity[model.FindingVersion] = model.SeverityWarning
+
+	// Validation stops at requirements of v not met, eg. entries required in earlier versions only.
+	_ = XRefTable(ctx1)
+
+	ff := []model.Finding{}
+	for _, f := range ctx1.Findings {
+		if f.ID == model.FindingVersion || f.Fatal {
+			ff = append(ff, f)
+		}
+	}
+
+	return ff, nil
+}
+
+// RequiredVersion returns the lowest PDF version supporting all features of ctx.
+func RequiredVersion(ctx *model.Context) (model.Version, error) {
+	var err error
+
+	// Version audits are monotone.
+	i := sort.Search(len(versions), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		var ff []model.Finding
+		ff, err = VersionAudit(ctx, versions[i])
+		return len(ff) == 0
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	if i == len(versions) {
+		return model.V20, nil
+	}
+
+	return versions[i], nil
+}
+
+// VersionInfo describes the PDF version of a document.
+type VersionInfo struct {
+	Header    model.Version  // version claimed by the header
+	Catalog   *model.Version // optional version of the catalog taking precedence over the header version
+	Effective model.Version  // version in effect
+	Required  model.Version  // lowest version supporting all features in use
+}
+
+// Versions returns the declared, the effective and the required PDF version of ctx.
+func Versions(ctx *model.Context) (*VersionInfo, error) {
+	if ctx.HeaderVersion == nil {
+		return nil, errors.New("pdfcpu: missing header version")
+	}
+
+	required, err := RequiredVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VersionInfo{
+		Header:    *ctx.HeaderVersion,
+		Catalog:   ctx.RootVersion,
+		Effective: ctx.XRefTable.Version(),
+		Required:  required,
+	}, nil
+}
@@ -29,11 +29,11 @@ func validateDirection(xRefTable *model.XRefTable, d types.Dict, dictName string
 
 	n, err := validateNameEntry(xRefTable, d, dictName, "Direction", OPTIONAL, model.V13, validate)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
+		s, err1 := validateStringEntry(xRefTable, d, dictName, "Direction", OPTIONAL, model.V13, validate)
+		if err1 != nil {
 			return err
 		}
-		s, err := validateStringEntry(xRefTable, d, dictName, "Direction", OPTIONAL, model.V13, validate)
-		if err != nil {
+		if err = xRefTable.ReportSpecViolation(model.FindingViewerPreferences, err, "viewer preferences with string \"Direction\""); err != nil {
 			return err
 		}
 		if s != nil {
@@ -147,10 +147,9 @@ func validatePrinterPreferences(xRefTable *model.XRefTable, d types.Dict, dictNa
 	}
 	n, err := validateNameEntry(xRefTable, d, dictName, "PrintScaling", OPTIONAL, sinceVersion, validate)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
+		if err = xRefTable.ReportSpecViolation(model.FindingViewerPreferences, err, "viewer preferences with invalid \"PrintScaling\""); err != nil {
 			return err
 		}
-		// Ignore in relaxed mode.
 	}
 	if n != nil {
 		vp.PrintScaling = model.PrintScalingFor(n.String())
@@ -242,15 +241,14 @@ func validateViewerPreferences(xRefTable *model.XRefTable, rootDict types.Dict,
 
 	d, err := validateDictEntry(xRefTable, rootDict, dictName, "ViewerPreferences", required, sinceVersion, nil)
 	if err != nil {
-		if xRefTable.ValidationMode == model.ValidationStrict {
-			return err
-		}
 		arr, err := validateArrayEntry(xRefTable, rootDict, dictName, "ViewerPreferences", required, sinceVersion, nil)
 		if err != nil || len(arr) == 0 {
 			return err
 		}
 		// For an out-of-spec viewer preferences array, we assume it only contains boolean flags set to true.
-		model.ShowDigestedSpecViolation("viewer preferences array instead of dict")
+		if err := xRefTable.ReportSpecViolation(model.FindingViewerPreferencesArray, nil, "viewer preferences array instead of dict"); err != nil {
+			return err
+		}
 		d = types.NewDict()
 		for _, v := range arr {
 			n, ok := v.(types.Name)
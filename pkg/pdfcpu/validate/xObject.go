@@ -514,10 +514,13 @@ func validateImageStreamDictPart2(xRefTable *model.XRefTable, sd *types.StreamDi
 
 	// Alternates, array, optional, since V1.3
 	if !isAlternate {
-		err = validateAlternateImageStreamDicts(xRefTable, sd.Dict, dictName, "Alternates", OPTIONAL, model.V13)
+		if err = validateAlternateImageStreamDicts(xRefTable, sd.Dict, dictName, "Alternates", OPTIONAL, model.V13); err != nil {
+			return err
+		}
 	}
 
-	return err
+	// AF, array of file specification dicts, optional, since V2.0
+	return validateAssociatedFiles(xRefTable, sd.Dict, dictName)
 }
 
 func validateImageStreamDict(xRefTable *model.XRefTable, sd *types.StreamDict, isAlternate bool) error {
@@ -593,14 +596,14 @@ func validateImageStreamDict(xRefTable *model.XRefTable, sd *types.StreamDict, i
 }
 
 func validateFormStreamDictPart1(xRefTable *model.XRefTable, sd *types.StreamDict, dictName string) error {
-	var err error
-	if xRefTable.ValidationMode == model.ValidationRelaxed {
-		_, err = validateNumberEntry(xRefTable, sd.Dict, dictName, "FormType", OPTIONAL, model.V10, func(f float64) bool { return f == 1. })
-	} else {
-		_, err = validateIntegerEntry(xRefTable, sd.Dict, dictName, "FormType", OPTIONAL, model.V10, func(i int) bool { return i == 1 })
-	}
+	_, err := validateIntegerEntry(xRefTable, sd.Dict, dictName, "FormType", OPTIONAL, model.V10, func(i int) bool { return i == 1 })
 	if err != nil {
-		return err
+		if _, err1 := validateNumberEntry(xRefTable, sd.Dict, dictName, "FormType", OPTIONAL, model.V10, func(f float64) bool { return f == 1. }); err1 != nil {
+			return err
+		}
+		if err = xRefTable.ReportSpecViolation(model.FindingXObject, err, "form XObject with real \"FormType\""); err != nil {
+			return err
+		}
 	}
 
 	_, err = validateRectangleEntry(xRefTable, sd.Dict, dictName, "BBox", REQUIRED, model.V10, nil)
@@ -726,9 +729,12 @@ func validateFormStreamDictPart2(xRefTable *model.XRefTable, d types.Dict, dictN
 
 	// Name, name, optional (required in 1.0)
 	required := xRefTable.Version() == model.V10
-	_, err = validateNameEntry(xRefTable, d, dictName, "Name", required, model.V10, nil)
+	if _, err = validateNameEntry(xRefTable, d, dictName, "Name", required, model.V10, nil); err != nil {
+		return err
+	}
 
-	return err
+	// AF, array of file specification dicts, optional, since V2.0
+	return validateAssociatedFiles(xRefTable, d, dictName)
 }
 
 func validateFormStreamDict(xRefTable *model.XRefTable, sd *types.StreamDict) error {
@@ -746,20 +752,18 @@ func validateFormStreamDict(xRefTable *model.XRefTable, sd *types.StreamDict) er
 }
 
 func validateXObjectType(xRefTable *model.XRefTable, sd *types.StreamDict) error {
-	ss := []string{"XObject"}
-	if xRefTable.ValidationMode == model.ValidationRelaxed {
-		ss = append(ss, "Xobject")
+	n, err := validateNameEntry(xRefTable, sd.Dict, "xObjectStreamDict", "Type", OPTIONAL, model.V10, func(s string) bool { return types.MemberOf(s, []string{"XObject", "Xobject"}) })
+	if err != nil || n == nil || *n == "XObject" {
+		return err
 	}
 
-	n, err := validateNameEntry(xRefTable, sd.Dict, "xObjectStreamDict", "Type", OPTIONAL, model.V10, func(s string) bool { return types.MemberOf(s, ss) })
-	if err != nil {
+	err = errors.Errorf("pdfcpu: validateXObjectType: invalid \"Type\": %s", *n)
+	if err = xRefTable.ReportSpecViolation(model.FindingXObject, err, "XObject with \"Type\" Xobject"); err != nil {
 		return err
 	}
 
 	// Repair "Xobject" to "XObject".
-	if n != nil && *n == "Xobject" {
-		sd.Dict["Type"] = types.Name("XObject")
-	}
+	sd.Dict["Type"] = types.Name("XObject")
 
 	return nil
 }
@@ -33,7 +33,27 @@ import (
 )
 
 // XRefTable validates a PDF cross reference table obeying the validation mode.
-func XRefTable(ctx *model.Context) error {
+// Validation findings are recorded in ctx.Findings.
+func XRefTable(ctx *model.Context) (err error) {
+	xRefTable := ctx.XRefTable
+	xRefTable.Findings = nil
+
+	end := ctx.Conf.TraceOp("validate")
+	defer func() { end(err) }()
+
+	if err := validateXRefTable(ctx); err != nil {
+		return xRefTable.ReportInvalid(model.FindingInvalid, err)
+	}
+
+	// Fatal findings recovered from during validation.
+	for i := range xRefTable.Findings {
+		xRefTable.Findings[i].Fatal = false
+	}
+
+	return nil
+}
+
+func validateXRefTable(ctx *model.Context) error {
 	if log.InfoEnabled() {
 		log.Info.Println("validating")
 	}
@@ -45,16 +65,16 @@ func XRefTable(ctx *model.Context) error {
 
 	rootDict, err := xRefTable.Catalog()
 	if err != nil {
-		return err
+		return xRefTable.ReportInvalid(model.FindingCatalog, err)
 	}
 
 	if err := validateRootVersion(xRefTable, rootDict, OPTIONAL, model.V14); err != nil {
-		return err
+		return xRefTable.ReportInvalid(model.FindingCatalog, err)
 	}
 
 	metaDataAuthoritative, err := metaDataModifiedAfterInfoDict(xRefTable)
 	if err != nil {
-		return err
+		return xRefTable.ReportInvalid(model.FindingMetadata, err)
 	}
 
 	if metaDataAuthoritative {
@@ -62,7 +82,7 @@ func XRefTable(ctx *model.Context) error {
 		// validate document information dictionary before catalog metadata.
 		err := validateDocumentInfoObject(xRefTable)
 		if err != nil {
-			return err
+			return xRefTable.ReportInvalid(model.FindingInfo, err)
 		}
 	}
 
@@ -72,11 +92,15 @@ func XRefTable(ctx *model.Context) error {
 		return err
 	}
 
+	if err := validateEncryptionPDF20(xRefTable); err != nil {
+		return xRefTable.ReportInvalid(model.FindingDeprecatedEncryption, err)
+	}
+
 	if !metaDataAuthoritative {
 		// Validate document information dictionary after catalog metadata.
 		err = validateDocumentInfoObject(xRefTable)
 		if err != nil {
-			return err
+			return xRefTable.ReportInvalid(model.FindingInfo, err)
 		}
 	}
 
@@ -219,23 +243,18 @@ func validateRootVersion(xRefTable *model.XRefTable, rootDict types.Dict, requir
 		return nil
 	}
 
-	if xRefTable.ValidationMode == model.ValidationStrict {
+	f, err1 := validateNumberEntryToFloat(xRefTable, rootDict, "rootDict", "Version", OPTIONAL, sinceVersion, nil)
+	if err1 != nil || f == 0 {
 		return err
 	}
 
-	f, err := validateNumberEntryToFloat(xRefTable, rootDict, "rootDict", "Version", OPTIONAL, sinceVersion, nil)
-	if err != nil || f == 0 {
-		return errors.New("invalid catalog version")
-	}
-
-	rootVersionStr := strconv.FormatFloat(f, 'f', 1, 64)
-	if err := setRootVersion(xRefTable, rootVersionStr); err != nil {
+	if err := xRefTable.ReportSpecViolation(model.FindingCatalog, err, "catalog version with unexpected number type"); err != nil {
 		return err
 	}
 
-	model.ShowDigestedSpecViolation("catalog version with unexpected number type")
+	rootVersionStr := strconv.FormatFloat(f, 'f', 1, 64)
 
-	return nil
+	return setRootVersion(xRefTable, rootVersionStr)
 }
 
 func validateExtensions(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
@@ -270,14 +289,12 @@ func validatePageLabels(xRefTable *model.XRefTable, rootDict types.Dict, require
 		return err
 	}
 
-	d, err := xRefTable.DereferenceDict(*ir)
-	if err != nil {
+	t, err := xRefTable.PageLabels(false)
+	if err != nil || t == nil {
 		return err
 	}
 
-	_, _, err = validateNumberTree(xRefTable, "PageLabel", d, true, false)
-
-	return err
+	return validateNumberTree(xRefTable, "PageLabel", t.D, false)
 }
 
 func validateNames(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
@@ -981,12 +998,7 @@ func validateDSS(xRefTable *model.XRefTable, rootDict types.Dict, required bool,
 func validateAF(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
 	// => 14.13 Associated Files
 
-	a, err := validateArrayEntry(xRefTable, rootDict, "rootDict", "AF", required, sinceVersion, nil)
-	if err != nil || len(a) == 0 {
-		return err
-	}
-
-	return errors.New("pdfcpu: PDF2.0 \"AF\" not supported")
+	return validateAssociatedFiles(xRefTable, rootDict, "rootDict")
 }
 
 func validateDPartRoot(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
@@ -1000,6 +1012,18 @@ func validateDPartRoot(xRefTable *model.XRefTable, rootDict types.Dict, required
 	return errors.New("pdfcpu: PDF2.0 \"DPartRoot\" not supported")
 }
 
+func uriStatus(resp string) string {
+	switch resp {
+	case "i":
+		return "invalid url"
+	case "s":
+		return "severe error"
+	case "t":
+		return "timeout"
+	}
+	return fmt.Sprintf("status=%s", resp)
+}
+
 func logURIError(xRefTable *model.XRefTable, pages []int) {
 	if log.CLIEnabled() {
 		log.CLI.Println()
@@ -1007,25 +1031,38 @@ func logURIError(xRefTable *model.XRefTable, pages []int) {
 	for _, page := range pages {
 		for uri, resp := range xRefTable.URIs[page] {
 			if resp != "" {
-				var s string
-				switch resp {
-				case "i":
-					s = "invalid url"
-				case "s":
-					s = "severe error"
-				case "t":
-					s = "timeout"
-				default:
-					s = fmt.Sprintf("status=%s", resp)
-				}
 				if log.CLIEnabled() {
-					log.CLI.Printf("Page %d: %s - %s\n", page, uri, s)
+					log.CLI.Printf("Page %d: %s - %s\n", page, uri, uriStatus(resp))
 				}
 			}
 		}
 	}
 }
 
+func reportBrokenLinks(xRefTable *model.XRefTable, pages []int) error {
+	var fatal bool
+	for _, page := range pages {
+		uris := make([]string, 0, len(xRefTable.URIs[page]))
+		for uri, resp := range xRefTable.URIs[page] {
+			if resp != "" {
+				uris = append(uris, uri)
+			}
+		}
+		sort.Strings(uris)
+		for _, uri := range uris {
+			msg := fmt.Sprintf("page %d: %s - %s", page, uri, uriStatus(xRefTable.URIs[page][uri]))
+			f := model.Finding{ID: model.FindingBrokenLink, Severity: model.SeverityError, Message: msg}
+			if err := xRefTable.ReportFinding(f, nil); err != nil {
+				fatal = true
+			}
+		}
+	}
+	if fatal {
+		return errors.New("broken links detected")
+	}
+	return nil
+}
+
 func checkLinks(xRefTable *model.XRefTable, client http.Client, pages []int) bool {
 	var httpErr bool
 	for _, page := range pages {
@@ -1095,8 +1132,73 @@ func checkForBrokenLinks(ctx *model.Context) error {
 		logURIError(xRefTable, pages)
 	}
 
-	if httpErr {
-		return errors.New("broken links detected")
+	if !httpErr {
+		return nil
+	}
+
+	return reportBrokenLinks(xRefTable, pages)
+}
+
+func validateRootType(xRefTable *model.XRefTable, rootDict types.Dict) error {
+	required := true
+	if xRefTable.ValidationMode == model.ValidationRelaxed {
+		required = false
+	}
+	_, err := validateNameEntry(xRefTable, rootDict, "rootDict", "Type", required, model.V10, func(s string) bool { return s == "Catalog" })
+	if err != nil {
+		return xRefTable.ReportInvalid(model.FindingCatalog, err)
+	}
+	return nil
+}
+
+// validateRootEntries validates all root dict entries except "Type" and "Pages".
+func validateRootEntries(xRefTable *model.XRefTable, rootDict types.Dict) error {
+	for _, f := range []struct {
+		validate     func(xRefTable *model.XRefTable, d types.Dict, required bool, sinceVersion model.Version) (err error)
+		required     bool
+		sinceVersion model.Version
+		findingID    string
+	}{
+		//{validateRootVersion, OPTIONAL, model.V14}, Note: moved up
+		{validateExtensions, OPTIONAL, model.V10, model.FindingExtensions},
+		{validatePageLabels, OPTIONAL, model.V13, model.FindingPageLabels},
+		{validateNames, OPTIONAL, model.V11, model.FindingNameTree}, //model.V12},
+		{validateNamedDestinations, OPTIONAL, model.V11, model.FindingDestination},
+		{validateViewerPreferences, OPTIONAL, model.V12, model.FindingViewerPreferences},
+		{validatePageLayout, OPTIONAL, model.V10, model.FindingCatalog},
+		{validatePageMode, OPTIONAL, model.V10, model.FindingCatalog},
+		{validateOutlines, OPTIONAL, model.V10, model.FindingOutline},
+		{validateThreads, OPTIONAL, model.V11, model.FindingThreads},
+		{validateOpenAction, OPTIONAL, model.V11, model.FindingAction},
+		{validateRootAdditionalActions, OPTIONAL, model.V14, model.FindingAction},
+		{validateURI, OPTIONAL, model.V11, model.FindingAction},
+		{validateForm, OPTIONAL, model.V12, model.FindingForm},
+		{validateRootMetadata, OPTIONAL, model.V14, model.FindingMetadata},
+		{validateStructTree, OPTIONAL, model.V13, model.FindingStructTree},
+		{validateMarkInfo, OPTIONAL, model.V14, model.FindingCatalog},
+		{validateLang, OPTIONAL, model.V10, model.FindingCatalog},
+		{validateSpiderInfo, OPTIONAL, model.V13, model.FindingCatalog},
+		{validateOutputIntents, OPTIONAL, model.V14, model.FindingOutputIntents},
+		{validateRootPieceInfo, OPTIONAL, model.V14, model.FindingPieceInfo},
+		{validateOCProperties, OPTIONAL, model.V15, model.FindingOptionalContent},
+		{validatePermissions, OPTIONAL, model.V15, model.FindingPermissions},
+		{validateLegal, OPTIONAL, model.V17, model.FindingPermissions},
+		{validateRequirements, OPTIONAL, model.V17, model.FindingCatalog},
+		{validateCollection, OPTIONAL, model.V17, model.FindingCollection},
+		{validateNeedsRendering, OPTIONAL, model.V17, model.FindingCatalog},
+		{validateDSS, OPTIONAL, model.V17, model.FindingSignature},
+		{validateAF, OPTIONAL, model.V17, model.FindingAssociatedFiles},
+		{validateWrapperDocument, OPTIONAL, model.V20, model.FindingEncryptedPayload},
+		{validateDPartRoot, OPTIONAL, model.V20, model.FindingCatalog},
+	} {
+		if !f.required && xRefTable.Version() < f.sinceVersion {
+			// Ignore optional fields if currentVersion < sinceVersion
+			// This is really a workaround for explicitly extending relaxed validation.
+			continue
+		}
+		if err := f.validate(xRefTable, rootDict, f.required, f.sinceVersion); err != nil {
+			return xRefTable.ReportInvalid(f.findingID, err)
+		}
 	}
 
 	return nil
@@ -1142,83 +1244,35 @@ func validateRootObject(ctx *model.Context, rootDict types.Dict) error {
 	// NeedsRendering       y   1.7         boolean         => XML Forms Architecture (XFA) Spec.
 
 	// DSS					y	2.0			dict			=> 12.8.4.3 Document Security Store	TODO
-	// AF					y	2.0			array of dicts	=> 14.3 Associated Files			TODO
+	// AF					y	2.0			array of dicts	=> 14.13 Associated Files
 	// DPartRoot			y	2.0			dict			=> 14.12 Document parts				TODO
 
 	xRefTable := ctx.XRefTable
 
 	// Type
-	required := true
-	if ctx.XRefTable.ValidationMode == model.ValidationRelaxed {
-		required = false
-	}
-	_, err := validateNameEntry(xRefTable, rootDict, "rootDict", "Type", required, model.V10, func(s string) bool { return s == "Catalog" })
-	if err != nil {
+	if err := validateRootType(xRefTable, rootDict); err != nil {
 		return err
 	}
 
 	// Pages
 	rootPageNodeDict, err := validatePages(xRefTable, rootDict)
 	if err != nil {
-		return err
+		return xRefTable.ReportInvalid(model.FindingPages, err)
 	}
 
-	for _, f := range []struct {
-		validate     func(xRefTable *model.XRefTable, d types.Dict, required bool, sinceVersion model.Version) (err error)
-		required     bool
-		sinceVersion model.Version
-	}{
-		//{validateRootVersion, OPTIONAL, model.V14}, Note: moved up
-		{validateExtensions, OPTIONAL, model.V10},
-		{validatePageLabels, OPTIONAL, model.V13},
-		{validateNames, OPTIONAL, model.V11}, //model.V12},
-		{validateNamedDestinations, OPTIONAL, model.V11},
-		{validateViewerPreferences, OPTIONAL, model.V12},
-		{validatePageLayout, OPTIONAL, model.V10},
-		{validatePageMode, OPTIONAL, model.V10},
-		{validateOutlines, OPTIONAL, model.V10},
-		{validateThreads, OPTIONAL, model.V11},
-		{validateOpenAction, OPTIONAL, model.V11},
-		{validateRootAdditionalActions, OPTIONAL, model.V14},
-		{validateURI, OPTIONAL, model.V11},
-		{validateForm, OPTIONAL, model.V12},
-		{validateRootMetadata, OPTIONAL, model.V14},
-		{validateStructTree, OPTIONAL, model.V13},
-		{validateMarkInfo, OPTIONAL, model.V14},
-		{validateLang, OPTIONAL, model.V10},
-		{validateSpiderInfo, OPTIONAL, model.V13},
-		{validateOutputIntents, OPTIONAL, model.V14},
-		{validateRootPieceInfo, OPTIONAL, model.V14},
-		{validateOCProperties, OPTIONAL, model.V15},
-		{validatePermissions, OPTIONAL, model.V15},
-		{validateLegal, OPTIONAL, model.V17},
-		{validateRequirements, OPTIONAL, model.V17},
-		{validateCollection, OPTIONAL, model.V17},
-		{validateNeedsRendering, OPTIONAL, model.V17},
-		{validateDSS, OPTIONAL, model.V17},
-		{validateAF, OPTIONAL, model.V20},
-		{validateDPartRoot, OPTIONAL, model.V20},
-	} {
-		if !f.required && xRefTable.Version() < f.sinceVersion {
-			// Ignore optional fields if currentVersion < sinceVersion
-			// This is really a workaround for explicitly extending relaxed validation.
-			continue
-		}
-		err = f.validate(xRefTable, rootDict, f.required, f.sinceVersion)
-		if err != nil {
-			return err
-		}
+	if err := validateRootEntries(xRefTable, rootDict); err != nil {
+		return err
 	}
 
 	// Validate remainder of annotations after AcroForm validation only.
 	if _, err = validatePagesAnnotations(xRefTable, rootPageNodeDict, 0); err != nil {
-		return err
+		return xRefTable.ReportInvalid(model.FindingAnnotation, err)
 	}
 
 	// Validate form fields against page annotations.
 	if xRefTable.Form != nil {
 		if err := validateFormFieldsAgainstPageAnnotations(xRefTable); err != nil {
-			return err
+			return xRefTable.ReportInvalid(model.FindingForm, err)
 		}
 	}
 
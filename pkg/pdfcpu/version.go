@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// SetVersion declares v as the PDF version of ctx to be written into the header, replacing any catalog version.
+// SetVersion does not check ctx for features unsupported by v, see validate.VersionAudit.
+func SetVersion(ctx *model.Context, v model.Version) error {
+	if ctx.ReadOnly() {
+		return model.ErrReadOnly
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+	rootDict.Delete("Version")
+
+	ctx.HeaderVersion = &v
+	ctx.RootVersion = nil
+	ctx.DeclaredVersion = &v
+
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Gray level differences up to this threshold are considered rendering noise.
+const visualDiffThreshold = 16
+
+// PageDiff represents the visual difference of a page of two documents.
+type PageDiff struct {
+	PageNr       int
+	Score        float64     // Fraction of differing pixels, 0 means visually identical.
+	Hash1, Hash2 uint64      // Difference hash of each rendered page.
+	HashDistance int         // Hamming distance of Hash1 and Hash2.
+	Missing      bool        // Page exists in one document only.
+	Highlight    image.Image // Page of the first document with differing pixels marked red, nil if Score is 0.
+}
+
+// dHash computes a 64 bit difference hash of img.
+func dHash(img *image.Gray) uint64 {
+	b := img.Bounds()
+	var px [8][9]float64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 9; x++ {
+			x0, x1 := b.Min.X+x*b.Dx()/9, b.Min.X+(x+1)*b.Dx()/9
+			y0, y1 := b.Min.Y+y*b.Dy()/8, b.Min.Y+(y+1)*b.Dy()/8
+			var sum, n float64
+			for j := y0; j < y1; j++ {
+				for i := x0; i < x1; i++ {
+					sum += float64(img.GrayAt(i, j).Y)
+					n++
+				}
+			}
+			if n > 0 {
+				px[y][x] = sum / n
+			}
+		}
+	}
+	var h uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			h <<= 1
+			if px[y][x] < px[y][x+1] {
+				h |= 1
+			}
+		}
+	}
+	return h
+}
+
+func comparePages(img1, img2 *image.Gray) (float64, image.Image) {
+	b := img1.Bounds().Union(img2.Bounds())
+	highlight := image.NewRGBA(b)
+	red := color.RGBA{R: 0xFF, A: 0xFF}
+
+	gray := func(img *image.Gray, x, y int) uint8 {
+		if !(image.Point{x, y}.In(img.Bounds())) {
+			return 0xFF
+		}
+		return img.GrayAt(x, y).Y
+	}
+
+	var n int
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			g1, g2 := int(gray(img1, x, y)), int(gray(img2, x, y))
+			d := g1 - g2
+			if d < 0 {
+				d = -d
+			}
+			if d > visualDiffThreshold {
+				n++
+				highlight.SetRGBA(x, y, red)
+				continue
+			}
+			// Lighten unchanged content.
+			v := uint8(0xFF - (0xFF-g1)/3)
+			highlight.SetRGBA(x, y, color.RGBA{R: v, G: v, B: v, A: 0xFF})
+		}
+	}
+
+	if n == 0 {
+		return 0, nil
+	}
+
+	return float64(n) / float64(b.Dx()*b.Dy()), highlight
+}
+
+func visualPageDiff(ctx1, ctx2 *model.Context, pageNr int, dpi float64) (*PageDiff, error) {
+	pd := &PageDiff{PageNr: pageNr}
+
+	var img1, img2 *image.Gray
+	var err error
+
+	if pageNr <= ctx1.PageCount {
+		if img1, err = RenderPage(ctx1, pageNr, dpi); err != nil {
+			return nil, err
+		}
+		pd.Hash1 = dHash(img1)
+	}
+
+	if pageNr <= ctx2.PageCount {
+		if img2, err = RenderPage(ctx2, pageNr, dpi); err != nil {
+			return nil, err
+		}
+		pd.Hash2 = dHash(img2)
+	}
+
+	if img1 == nil || img2 == nil {
+		pd.Missing, pd.Score = true, 1
+		pd.HashDistance = 64
+		return pd, nil
+	}
+
+	pd.HashDistance = bits.OnesCount64(pd.Hash1 ^ pd.Hash2)
+	pd.Score, pd.Highlight = comparePages(img1, img2)
+
+	return pd, nil
+}
+
+// VisualDiff renders all pages of ctx1 and ctx2 at dpi and compares them page by page.
+func VisualDiff(ctx1, ctx2 *model.Context, dpi float64) ([]PageDiff, error) {
+	if ctx1 == nil || ctx2 == nil {
+		return nil, errors.New("pdfcpu: VisualDiff: missing context")
+	}
+
+	pageCount := ctx1.PageCount
+	if ctx2.PageCount > pageCount {
+		pageCount = ctx2.PageCount
+	}
+
+	pdd := make([]PageDiff, 0, pageCount)
+	for i := 1; i <= pageCount; i++ {
+		pd, err := visualPageDiff(ctx1, ctx2, i, dpi)
+		if err != nil {
+			return nil, err
+		}
+		pdd = append(pdd, *pd)
+	}
+
+	return pdd, nil
+}
@@ -17,12 +17,11 @@ limitations under the License.
 package pdfcpu
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 
@@ -62,20 +61,33 @@ func writeObjects(ctx *model.Context) error {
 
 // WriteContext generates a PDF file for the cross reference table contained in Context.
 func WriteContext(ctx *model.Context) (err error) {
-	// Create a writer for dirname and filename if not already supplied.
+	end := ctx.Conf.TraceOp("write")
+	defer func() { end(err) }()
+
+	// Create a writer for filename within the output file system if not already supplied.
 	if ctx.Write.Writer == nil {
 
-		fileName := filepath.Join(ctx.Write.DirName, ctx.Write.FileName)
+		fsys := ctx.Write.FS
+		if fsys == nil {
+			fsys = model.DirFS("")
+		}
+
+		fileName := ctx.Write.FileName
 		if log.CLIEnabled() {
 			log.CLI.Printf("writing to %s\n", fileName)
 		}
 
-		file, err := os.Create(fileName)
+		file, err := fsys.Create(fileName)
 		if err != nil {
 			return errors.Wrapf(err, "can't create %s\n%s", fileName, err)
 		}
 
-		ctx.Write.Writer = bufio.NewWriter(file)
+		if f, ok := file.(*os.File); ok {
+			// In order to retrieve the written file size.
+			ctx.Write.Fp = f
+		}
+
+		ctx.Write.SetWriter(file)
 
 		defer func() {
 
@@ -109,6 +121,10 @@ func WriteContext(ctx *model.Context) (err error) {
 		v = model.V20
 	}
 
+	if ctx.DeclaredVersion != nil {
+		v = *ctx.DeclaredVersion
+	}
+
 	if err = writeHeader(ctx.Write, v); err != nil {
 		return err
 	}
@@ -122,10 +138,38 @@ func WriteContext(ctx *model.Context) (err error) {
 		log.Write.Printf("offset after writeHeader: %d\n", ctx.Write.Offset)
 	}
 
+	// Ensure corresponding and accurate name tree object graphs.
+	if !ctx.ApplyReducedFeatureSet() {
+		if err := ctx.BindNameTrees(); err != nil {
+			return err
+		}
+	}
+
+	gc := ctx.Configuration.GCUnreachable || ctx.Configuration.CompactXRef
+	keep := ctx.Configuration.GCKeep
+
+	if gc {
+		if err := freeUnreachableObjects(ctx, keep); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Configuration.CompactXRef {
+		if keep, err = renumberObjects(ctx, keep); err != nil {
+			return err
+		}
+	}
+
 	if err := writeObjects(ctx); err != nil {
 		return err
 	}
 
+	if gc {
+		if err := writeKeptObjects(ctx, keep); err != nil {
+			return err
+		}
+	}
+
 	// Mark redundant objects as free.
 	// eg. duplicate resources, compressed objects, linearization dicts..
 	deleteRedundantObjects(ctx)
@@ -143,6 +187,20 @@ func WriteContext(ctx *model.Context) (err error) {
 		return err
 	}
 
+	reportWriteProgress(ctx, true)
+	ctx.Conf.Count(model.MetricBytesWritten, ctx.Write.BytesWritten)
+
+	if ctx.Conf.LogEnabled(slog.LevelInfo) {
+		args := []any{"version", v.String(), "bytes", ctx.Write.BytesWritten, "objects", len(ctx.Write.Table)}
+		if ctx.Read != nil && ctx.Read.FileSize > 0 {
+			args = append(args, "savedBytes", ctx.Read.FileSize-ctx.Write.BytesWritten)
+		}
+		if xc := ctx.Write.XRefCompaction; xc != nil {
+			args = append(args, "renumbered", xc.ObjectsRenumbered, "xrefBytesSaved", xc.BytesSaved())
+		}
+		ctx.Conf.LogEvent(slog.LevelInfo, "pdfcpu: written", args...)
+	}
+
 	if ctx.Read != nil {
 		ctx.Write.BinaryImageSize = ctx.Read.BinaryImageSize
 		ctx.Write.BinaryFontSize = ctx.Read.BinaryFontSize
@@ -156,6 +214,13 @@ func WriteContext(ctx *model.Context) (err error) {
 func WriteIncrement(ctx *model.Context) error {
 	// Write all modified objects that are part of this increment.
 	for _, i := range ctx.Write.ObjNrs {
+		if ctx.Encrypt != nil && ctx.EncKey != nil && i == ctx.Encrypt.ObjectNumber.Value() {
+			// The encryption dict is never encrypted.
+			if err := writeEncryptDict(ctx); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := writeFlatObject(ctx, i); err != nil {
 			return err
 		}
@@ -165,7 +230,17 @@ func WriteIncrement(ctx *model.Context) error {
 		return err
 	}
 
-	return writeTrailer(ctx.Write)
+	if err := writeTrailer(ctx.Write); err != nil {
+		return err
+	}
+
+	if err := ctx.Write.Flush(); err != nil {
+		return err
+	}
+
+	ctx.Conf.Count(model.MetricBytesWritten, ctx.Write.BytesWritten)
+
+	return nil
 }
 
 func prepareContextForWriting(ctx *model.Context) error {
@@ -189,9 +264,14 @@ func writeAdditionalStreams(ctx *model.Context) error {
 }
 
 func ensureFileID(ctx *model.Context) error {
-	fid, err := fileID(ctx)
-	if err != nil {
-		return err
+	var fid types.HexLiteral
+	if ctx.Configuration != nil && len(ctx.FileID) > 0 {
+		fid = types.HexLiteral(hex.EncodeToString(ctx.FileID))
+	} else {
+		var err error
+		if fid, err = fileID(ctx); err != nil {
+			return err
+		}
 	}
 
 	if ctx.ID == nil {
@@ -315,6 +395,7 @@ func writeRootAttrsBatch2(ctx *model.Context, d types.Dict, dictName string) err
 		{"Requirements", model.RootRequirements},
 		{"Collection", model.RootCollection},
 		{"NeedsRendering", model.RootNeedsRendering},
+		{"AF", model.RootAF},
 	} {
 		if err := writeRootEntry(ctx, d, dictName, e.entryName, e.statsAttr); err != nil {
 			return err
@@ -336,13 +417,6 @@ func writeRootObject(ctx *model.Context) error {
 		log.Write.Printf("*** writeRootObject: begin offset=%d *** %s\n", ctx.Write.Offset, catalog)
 	}
 
-	// Ensure corresponding and accurate name tree object graphs.
-	if !ctx.ApplyReducedFeatureSet() {
-		if err := ctx.BindNameTrees(); err != nil {
-			return err
-		}
-	}
-
 	d, err := xRefTable.DereferenceDict(catalog)
 	if err != nil {
 		return err
@@ -603,6 +677,12 @@ func sortedWritableKeys(ctx *model.Context) []int {
 func writeXRefTable(ctx *model.Context) error {
 	keys := sortedWritableKeys(ctx)
 
+	if xc := ctx.Write.XRefCompaction; xc != nil {
+		// Each xref table entry is exactly 20 bytes long.
+		xc.XRefBytesBefore = int64(len(keys)+xc.FreeEntriesDropped) * 20
+		xc.XRefBytesAfter = int64(len(keys)) * 20
+	}
+
 	objCount := len(keys)
 	if log.WriteEnabled() {
 		log.Write.Printf("xref has %d entries\n", objCount)
@@ -687,6 +767,35 @@ func int64ToBuf(i int64, byteCount int) (buf []byte) {
 	return
 }
 
+// byteCount returns the number of bytes needed to represent i.
+func byteCount(i int64) (n int) {
+	for i > 0 {
+		i >>= 8
+		n++
+	}
+	return n
+}
+
+// maxXRefStreamField3 returns the largest value of the third field of the xref stream entries for objNrs
+// not taking into account the generation of the free list head.
+func maxXRefStreamField3(ctx *model.Context, objNrs []int) int64 {
+	var m int64
+	for _, objNr := range objNrs {
+		if objNr == 0 {
+			continue
+		}
+		entry := ctx.Table[objNr]
+		v := int64(*entry.Generation)
+		if !entry.Free && entry.Compressed {
+			v = int64(*entry.ObjectStreamInd)
+		}
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
+
 func createXRefStream(ctx *model.Context, i1, i2, i3 int, objNrs []int) ([]byte, *types.Array, error) {
 	if log.WriteEnabled() {
 		log.Write.Println("createXRefStream begin")
@@ -720,9 +829,15 @@ func createXRefStream(ctx *model.Context, i1, i2, i3 int, objNrs []int) ([]byte,
 				log.Write.Printf("createXRefStream: unused i=%d nextFreeAt:%d gen:%d\n", j, int(*entry.Offset), int(*entry.Generation))
 			}
 
+			gen := int64(*entry.Generation)
+			if j == 0 && byteCount(gen) > i3 {
+				// The generation of the free list head is predefined and may exceed a compacted field width.
+				gen = 0
+			}
+
 			s1 = int64ToBuf(0, i1)
 			s2 = int64ToBuf(*entry.Offset, i2)
-			s3 = int64ToBuf(int64(*entry.Generation), i3)
+			s3 = int64ToBuf(gen, i3)
 
 		} else if entry.Compressed {
 
@@ -816,8 +931,14 @@ func writeXRefStream(ctx *model.Context) error {
 	xRefTableEntry := model.NewXRefTableEntryGen0(*xRefStreamDict)
 
 	// Reuse free objects (including recycled objects from this run).
-	objNumber, err := xRefTable.InsertAndUseRecycled(*xRefTableEntry)
-	if err != nil {
+	// An increment has to preserve the free entries of objects deleted since the last revision.
+	var (
+		objNumber int
+		err       error
+	)
+	if ctx.Write.Increment {
+		objNumber = xRefTable.InsertNew(*xRefTableEntry)
+	} else if objNumber, err = xRefTable.InsertAndUseRecycled(*xRefTableEntry); err != nil {
 		return err
 	}
 
@@ -834,26 +955,32 @@ func writeXRefStream(ctx *model.Context) error {
 
 	i1 := 1 // 0, 1 or 2 always fit into 1 byte.
 
-	i2 := func(i int64) (byteCount int) {
-		for i > 0 {
-			i >>= 8
-			byteCount++
-		}
-		return byteCount
-	}(i2Base)
+	i2 := byteCount(i2Base)
 
 	i3 := 2 // scale for max objectstream index <= 0x ff ff
 
+	objNrs := sortedWritableKeys(ctx)
+
+	xc := ctx.Write.XRefCompaction
+	if xc != nil {
+		i3 = byteCount(maxXRefStreamField3(ctx, objNrs))
+	}
+
 	wArr := types.Array{types.Integer(i1), types.Integer(i2), types.Integer(i3)}
 	xRefStreamDict.Insert("W", wArr)
 
 	// Generate xRefStreamDict data = xref entries -> xRefStreamDict.Content
-	objNrs := sortedWritableKeys(ctx)
 	content, indArr, err := createXRefStream(ctx, i1, i2, i3, objNrs)
 	if err != nil {
 		return err
 	}
 
+	if xc != nil {
+		n := int64(len(objNrs))
+		xc.XRefBytesBefore = (n + int64(xc.FreeEntriesDropped)) * int64(i1+i2+2)
+		xc.XRefBytesAfter = n * int64(i1+i2+i3)
+	}
+
 	xRefStreamDict.Content = content
 	xRefStreamDict.Insert("Index", *indArr)
 
@@ -920,11 +1047,21 @@ func setupEncryption(ctx *model.Context) error {
 		return errors.New("pdfcpu: unsupported encryption algorithm (PDF 2.0 assumes AES/256)")
 	}
 
+	if len(ctx.EncryptRecipients) > 0 {
+		return setupPubSecEncryption(ctx)
+	}
+
+	if (ctx.PlaintextMetadata || ctx.EncryptEmbeddedFilesOnly) && ctx.EncryptKeyLength != 128 && ctx.EncryptKeyLength != 256 {
+		return errors.New("pdfcpu: crypt filters need a key length of 128 or 256")
+	}
+
 	d := newEncryptDict(
 		ctx.PDF20(),
 		ctx.EncryptUsingAES,
 		ctx.EncryptKeyLength,
 		int16(ctx.Permissions),
+		ctx.PlaintextMetadata,
+		ctx.EncryptEmbeddedFilesOnly,
 	)
 
 	if ctx.E, err = supportedEncryption(ctx, d); err != nil {
@@ -1019,6 +1156,10 @@ func updateEncryption(ctx *model.Context) error {
 
 func handleEncryption(ctx *model.Context) error {
 
+	if ctx.EmbeddedFilesLocked {
+		return model.ErrEmbeddedFilesLocked
+	}
+
 	if ctx.Cmd == model.ENCRYPT || ctx.Cmd == model.DECRYPT {
 
 		if ctx.Cmd == model.DECRYPT {
@@ -1059,7 +1200,7 @@ func handleEncryption(ctx *model.Context) error {
 }
 
 func writeXRef(ctx *model.Context) error {
-	if ctx.WriteXRefStream {
+	if ctx.UseXRefStream() {
 		// Write cross reference stream and generate objectstreams.
 		return writeXRefStream(ctx)
 	}
@@ -27,7 +27,8 @@ import (
 
 const (
 
-	// ObjectStreamMaxObjects limits the number of objects within an object stream written.
+	// ObjectStreamMaxObjects limits the number of objects within an object stream written
+	// unless overridden by WriteContext.ObjStmMaxObjects.
 	ObjectStreamMaxObjects = 100
 )
 
@@ -147,15 +148,21 @@ func stopObjectStream(ctx *model.Context) error {
 	return nil
 }
 
-func writeToObjectStream(ctx *model.Context, objNumber, genNumber int) (ok bool, err error) {
+func objectStreamMaxObjects(w *model.WriteContext) int {
+	if w.ObjStmMaxObjects > 0 {
+		return w.ObjStmMaxObjects
+	}
+	return ObjectStreamMaxObjects
+}
+
+func writeToObjectStream(ctx *model.Context, objNumber, genNumber int, t model.ObjectStreamType) (ok bool, err error) {
 	if log.WriteEnabled() {
 		log.Write.Printf("addToObjectStream begin, obj#:%d gen#:%d\n", objNumber, genNumber)
 	}
 
 	w := ctx.Write
 
-	if ctx.WriteXRefStream && // object streams assume an xRefStream to be generated.
-		ctx.WriteObjectStream && // signal for compression into object stream is on.
+	if ctx.UseObjectStream(t) && // signal for compression of t into object stream is on.
 		ctx.Write.WriteToObjectStream && // currently writing to object stream.
 		genNumber == 0 {
 
@@ -193,7 +200,7 @@ func writeToObjectStream(ctx *model.Context, objNumber, genNumber int) (ok bool,
 			log.Write.Printf("writeObject end, obj#%d written to objectStream #%d\n", objNumber, *ctx.Write.CurrentObjStream)
 		}
 
-		if objStreamDict.ObjCount == ObjectStreamMaxObjects {
+		if objStreamDict.ObjCount >= objectStreamMaxObjects(w) {
 			if err = stopObjectStream(ctx); err != nil {
 				return false, err
 			}
@@ -259,7 +266,7 @@ func writePDFNullObject(ctx *model.Context, objNumber, genNumber int) error {
 }
 
 func writeBooleanObject(ctx *model.Context, objNumber, genNumber int, boolean types.Boolean) error {
-	ok, err := writeToObjectStream(ctx, objNumber, genNumber)
+	ok, err := writeToObjectStream(ctx, objNumber, genNumber, model.ObjStmScalar)
 	if err != nil {
 		return err
 	}
@@ -272,7 +279,7 @@ func writeBooleanObject(ctx *model.Context, objNumber, genNumber int, boolean ty
 }
 
 func writeNameObject(ctx *model.Context, objNumber, genNumber int, name types.Name) error {
-	ok, err := writeToObjectStream(ctx, objNumber, genNumber)
+	ok, err := writeToObjectStream(ctx, objNumber, genNumber, model.ObjStmScalar)
 	if err != nil {
 		return err
 	}
@@ -285,7 +292,7 @@ func writeNameObject(ctx *model.Context, objNumber, genNumber int, name types.Na
 }
 
 func writeStringLiteralObject(ctx *model.Context, objNumber, genNumber int, sl types.StringLiteral) error {
-	ok, err := writeToObjectStream(ctx, objNumber, genNumber)
+	ok, err := writeToObjectStream(ctx, objNumber, genNumber, model.ObjStmString)
 	if err != nil {
 		return err
 	}
@@ -294,7 +301,7 @@ func writeStringLiteralObject(ctx *model.Context, objNumber, genNumber int, sl t
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if encryptStrings(ctx) {
 		sl1, err := encryptStringLiteral(sl, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -307,7 +314,7 @@ func writeStringLiteralObject(ctx *model.Context, objNumber, genNumber int, sl t
 }
 
 func writeHexLiteralObject(ctx *model.Context, objNumber, genNumber int, hl types.HexLiteral) error {
-	ok, err := writeToObjectStream(ctx, objNumber, genNumber)
+	ok, err := writeToObjectStream(ctx, objNumber, genNumber, model.ObjStmString)
 	if err != nil {
 		return err
 	}
@@ -316,7 +323,7 @@ func writeHexLiteralObject(ctx *model.Context, objNumber, genNumber int, hl type
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if encryptStrings(ctx) {
 		hl1, err := encryptHexLiteral(hl, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -333,7 +340,7 @@ func writeIntegerObject(ctx *model.Context, objNumber, genNumber int, integer ty
 }
 
 func writeFloatObject(ctx *model.Context, objNumber, genNumber int, float types.Float) error {
-	ok, err := writeToObjectStream(ctx, objNumber, genNumber)
+	ok, err := writeToObjectStream(ctx, objNumber, genNumber, model.ObjStmScalar)
 	if err != nil {
 		return err
 	}
@@ -346,7 +353,7 @@ func writeFloatObject(ctx *model.Context, objNumber, genNumber int, float types.
 }
 
 func writeDictObject(ctx *model.Context, objNumber, genNumber int, d types.Dict) error {
-	ok, err := writeToObjectStream(ctx, objNumber, genNumber)
+	ok, err := writeToObjectStream(ctx, objNumber, genNumber, model.ObjStmDict)
 	if err != nil {
 		return err
 	}
@@ -355,7 +362,7 @@ func writeDictObject(ctx *model.Context, objNumber, genNumber int, d types.Dict)
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if encryptStrings(ctx) {
 		_, err := encryptDeepObject(d, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -366,7 +373,7 @@ func writeDictObject(ctx *model.Context, objNumber, genNumber int, d types.Dict)
 }
 
 func writeArrayObject(ctx *model.Context, objNumber, genNumber int, a types.Array) error {
-	ok, err := writeToObjectStream(ctx, objNumber, genNumber)
+	ok, err := writeToObjectStream(ctx, objNumber, genNumber, model.ObjStmArray)
 	if err != nil {
 		return err
 	}
@@ -375,7 +382,7 @@ func writeArrayObject(ctx *model.Context, objNumber, genNumber int, a types.Arra
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if encryptStrings(ctx) {
 		if _, err := encryptDeepObject(a, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return err
 		}
@@ -390,11 +397,12 @@ func writeStream(w *model.WriteContext, sd types.StreamDict) (int64, error) {
 		return 0, errors.Wrapf(err, "writeStream: failed to write raw content")
 	}
 
-	c, err := w.Write(sd.Raw)
+	// Stream data not held in memory gets copied from its origin.
+	c, err := sd.CopyRaw(w)
 	if err != nil {
 		return 0, errors.Wrapf(err, "writeStream: failed to write raw content")
 	}
-	if int64(c) != *sd.StreamLength {
+	if c != *sd.StreamLength {
 		return 0, errors.Errorf("writeStream: failed to write raw content: %d bytes written - streamlength:%d", c, *sd.StreamLength)
 	}
 
@@ -476,11 +484,16 @@ func writeStreamDictObject(ctx *model.Context, objNr, genNr int, sd types.Stream
 
 	// Unless the "Identity" crypt filter is used we have to encrypt.
 	isXRefStreamDict := sd.Type() != nil && *sd.Type() == "XRef"
-	if ctx.EncKey != nil &&
+	ok, aes := streamCryptFilter(ctx, &sd)
+	if ok &&
 		!isXRefStreamDict &&
 		!(len(sd.FilterPipeline) == 1 && sd.FilterPipeline[0].Name == "Crypt") {
 
-		if sd.Raw, err = encryptStream(sd.Raw, objNr, genNr, ctx.EncKey, ctx.AES4Streams, ctx.E.R); err != nil {
+		if err := sd.Load(); err != nil {
+			return err
+		}
+
+		if sd.Raw, err = encryptStream(sd.Raw, objNr, genNr, ctx.EncKey, aes, ctx.E.R); err != nil {
 			return err
 		}
 
@@ -518,7 +531,8 @@ func writeDirectObject(ctx *model.Context, o types.Object) error {
 	switch o := o.(type) {
 
 	case types.Dict:
-		for k, v := range o {
+		for _, k := range ctx.DictKeys(o) {
+			v := o[k]
 			if ctx.WritingPages && (k == "Dest" || k == "D") {
 				ctx.Dest = true
 			}
@@ -580,7 +594,8 @@ func writeDeepDict(ctx *model.Context, d types.Dict, objNr, genNr int) error {
 		return err
 	}
 
-	for k, v := range d {
+	for _, k := range ctx.DictKeys(d) {
+		v := d[k]
 		if ctx.WritingPages && (k == "Dest" || k == "D") {
 			ctx.Dest = true
 		}
@@ -594,7 +609,7 @@ func writeDeepDict(ctx *model.Context, d types.Dict, objNr, genNr int) error {
 }
 
 func writeDeepStreamDict(ctx *model.Context, sd *types.StreamDict, objNr, genNr int) error {
-	if ctx.EncKey != nil {
+	if encryptStrings(ctx) {
 		if _, err := encryptDeepObject(*sd, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return err
 		}
@@ -604,8 +619,8 @@ func writeDeepStreamDict(ctx *model.Context, sd *types.StreamDict, objNr, genNr
 		return err
 	}
 
-	for _, v := range sd.Dict {
-		if _, _, err := writeDeepObject(ctx, v); err != nil {
+	for _, k := range ctx.DictKeys(sd.Dict) {
+		if _, _, err := writeDeepObject(ctx, sd.Dict[k]); err != nil {
 			return err
 		}
 	}
@@ -679,6 +694,18 @@ func writeObjectGeneric(ctx *model.Context, o types.Object, objNr, genNr int) (e
 	return err
 }
 
+func reportWriteProgress(ctx *model.Context, done bool) {
+	if ctx.Progress == nil || ctx.Size == nil {
+		return
+	}
+	total := *ctx.Size
+	n := len(ctx.Write.Table)
+	if done || n > total {
+		n = total
+	}
+	ctx.ReportProgress(model.StageWrite, n, total)
+}
+
 func writeIndirectObject(ctx *model.Context, ir types.IndirectRef) error {
 	objNr := int(ir.ObjectNumber)
 	genNr := int(ir.GenerationNumber)
@@ -716,6 +743,8 @@ func writeIndirectObject(ctx *model.Context, ir types.IndirectRef) error {
 		return err
 	}
 
+	reportWriteProgress(ctx, false)
+
 	return err
 }
 
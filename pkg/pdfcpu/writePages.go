@@ -100,6 +100,7 @@ func writePageDict(ctx *model.Context, indRef *types.IndirectRef, pageDict types
 		{"PresSteps", model.PagePresSteps},
 		{"UserUnit", model.PageUserUnit},
 		{"VP", model.PageVP},
+		{"AF", model.PageAF},
 	} {
 		if err := writePageEntry(ctx, pageDict, dictName, e.entryName, e.statsAttr); err != nil {
 			return err